@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"github.com/paw1a/eschool/pkg/paysim"
+	"log"
+	"net/http"
+	"os"
+)
+
+// paysim is a local card acquirer simulator which implements the same
+// http contract as the acquirer payment gateway
+func main() {
+	addr := flag.String("addr", getEnv("PAYSIM_ADDR", ":8090"), "listen address")
+	shopID := flag.String("shop-id", getEnv("ACQUIRER_SHOP_ID", "eschool"), "merchant shop id")
+	secretKey := flag.String("secret-key", getEnv("ACQUIRER_SECRET_KEY", "secret"), "merchant secret key")
+	publicUrl := flag.String("public-url", getEnv("PAYSIM_PUBLIC_URL", "http://localhost:8090"),
+		"base url of the simulator used in confirmation urls")
+	webhookUrl := flag.String("webhook-url", getEnv("PAYSIM_WEBHOOK_URL",
+		"http://localhost:8080/api/v1/payment/acquirer/webhook"), "merchant webhook url")
+	flag.Parse()
+
+	server := paysim.NewServer(paysim.Config{
+		ShopID:     *shopID,
+		SecretKey:  *secretKey,
+		PublicUrl:  *publicUrl,
+		WebhookUrl: *webhookUrl,
+	})
+
+	log.Printf("payment simulator is listening on %s", *addr)
+	if err := http.ListenAndServe(*addr, server); err != nil {
+		log.Fatalf("payment simulator stopped: %v", err)
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
@@ -23,44 +23,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/logout": {
-            "post": {
-                "description": "logout",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Logout",
-                "responses": {
-                    "200": {
-                        "description": "message",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/refresh": {
+        "/auth/forgot-password": {
             "post": {
-                "description": "refresh",
+                "description": "send password reset token to the user email",
                 "consumes": [
                     "application/json"
                 ],
@@ -70,21 +35,21 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "TokenRefresh",
+                "summary": "ForgotPassword",
                 "parameters": [
                     {
-                        "description": "refresh payload",
+                        "description": "user email",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.RefreshDTO"
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ForgotPasswordDTO"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "auth token",
+                        "description": "message",
                         "schema": {
                             "type": "string"
                         }
@@ -95,10 +60,10 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorTooManyRequests"
                         }
                     },
                     "500": {
@@ -110,9 +75,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/sign-in": {
+        "/auth/logout": {
             "post": {
-                "description": "sign-in",
+                "description": "logout",
                 "consumes": [
                     "application/json"
                 ],
@@ -122,31 +87,14 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "SignIn",
-                "parameters": [
-                    {
-                        "description": "credentials",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SignInDTO"
-                        }
-                    }
-                ],
+                "summary": "Logout",
                 "responses": {
                     "200": {
-                        "description": "auth token",
+                        "description": "message",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -162,9 +110,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/sign-up": {
+        "/auth/mfa/verify": {
             "post": {
-                "description": "sign-up",
+                "description": "complete sign-in with two-factor authentication code",
                 "consumes": [
                     "application/json"
                 ],
@@ -174,21 +122,21 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "SignUp",
+                "summary": "MfaVerify",
                 "parameters": [
                     {
-                        "description": "user information",
+                        "description": "mfa challenge token and code",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SignUpDTO"
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.MfaVerifyDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "message",
+                    "200": {
+                        "description": "auth token",
                         "schema": {
                             "type": "string"
                         }
@@ -199,10 +147,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorTooManyRequests"
                         }
                     },
                     "500": {
@@ -214,27 +168,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/courses": {
+        "/auth/oidc/{provider}": {
             "get": {
-                "description": "get all courses",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "redirect to identity provider login page",
                 "tags": [
-                    "course"
+                    "auth"
+                ],
+                "summary": "OidcLogin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "identity provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "GetAllCourses",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "302": {
+                        "description": "redirect to identity provider",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
-                            }
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
                         }
                     },
                     "500": {
@@ -246,14 +206,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/courses/{courseID}/lessons/{lessonID}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get lesson by id",
+        "/auth/oidc/{provider}/callback": {
+            "post": {
+                "description": "complete sign-in with identity provider authorization code",
                 "consumes": [
                     "application/json"
                 ],
@@ -261,33 +216,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "course"
+                    "auth"
                 ],
-                "summary": "GetLessonByID",
+                "summary": "OidcCallback",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "course id",
-                        "name": "courseID",
+                        "description": "identity provider name",
+                        "name": "provider",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "lesson id",
-                        "name": "lessonID",
-                        "in": "path",
-                        "required": true
+                        "description": "authorization code and state",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.OidcCallbackDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "auth token",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonDTO"
-                            }
+                            "type": "string"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.MfaChallengeDTO"
                         }
                     },
                     "400": {
@@ -296,6 +256,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -315,14 +281,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "delete course lesson",
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "refresh",
                 "consumes": [
                     "application/json"
                 ],
@@ -330,28 +293,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "course"
+                    "auth"
                 ],
-                "summary": "DeleteCourseLesson",
+                "summary": "TokenRefresh",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "course id",
-                        "name": "courseID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "lesson id",
-                        "name": "lessonID",
-                        "in": "path",
-                        "required": true
+                        "description": "refresh payload",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.RefreshDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "message",
+                        "description": "auth token",
                         "schema": {
                             "type": "string"
                         }
@@ -368,18 +326,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -387,14 +333,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "update course lesson",
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "reset user password with token from the mail",
                 "consumes": [
                     "application/json"
                 ],
@@ -402,39 +345,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "course"
+                    "auth"
                 ],
-                "summary": "UpdateCourseLesson",
+                "summary": "ResetPassword",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "course id",
-                        "name": "courseID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "lesson id",
-                        "name": "lessonID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "updated lesson info",
+                        "description": "email token and new password",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateLessonDTO"
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ResetPasswordDTO"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "message",
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonDTO"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -443,22 +372,10 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorTooManyRequests"
                         }
                     },
                     "500": {
@@ -470,14 +387,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/courses/{courseID}/lessons/{lessonID}/stat": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get lesson statistics",
+        "/auth/sign-in": {
+            "post": {
+                "description": "sign-in",
                 "consumes": [
                     "application/json"
                 ],
@@ -485,30 +397,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "course"
+                    "auth"
                 ],
-                "summary": "GetLessonStat",
+                "summary": "SignIn",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "course id",
-                        "name": "courseID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "lesson id",
-                        "name": "lessonID",
-                        "in": "path",
-                        "required": true
+                        "description": "credentials",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SignInDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "auth token",
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonStatDTO"
+                            "type": "string"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.MfaChallengeDTO"
                         }
                     },
                     "400": {
@@ -523,16 +436,10 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorTooManyRequests"
                         }
                     },
                     "500": {
@@ -542,14 +449,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/sign-up": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "pass course lesson",
+                "description": "sign-up",
                 "consumes": [
                     "application/json"
                 ],
@@ -557,39 +461,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "course"
+                    "auth"
                 ],
-                "summary": "PassCourseLesson",
+                "summary": "SignUp",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "course id",
-                        "name": "courseID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "lesson id",
-                        "name": "lessonID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "passed lesson info",
+                        "description": "user information",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PassLessonDTO"
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SignUpDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "message",
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonStatDTO"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -598,22 +488,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorTooManyRequests"
                         }
                     },
                     "500": {
@@ -625,14 +509,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/courses/{courseID}/teachers/{teacherID}": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "add course teacher",
+        "/auth/verify-email": {
+            "post": {
+                "description": "verify user email with token from the mail",
                 "consumes": [
                     "application/json"
                 ],
@@ -640,27 +519,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "course"
+                    "auth"
                 ],
-                "summary": "AddCourseTeacher",
+                "summary": "VerifyEmail",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "course id",
-                        "name": "courseID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "course id",
-                        "name": "teacherID",
-                        "in": "path",
-                        "required": true
+                        "description": "email token",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.VerifyEmailDTO"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
+                    "200": {
                         "description": "message",
                         "schema": {
                             "type": "string"
@@ -672,24 +546,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -699,9 +555,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/courses/{id}": {
+        "/categories": {
             "get": {
-                "description": "get course by id",
+                "description": "get category tree, course count includes courses of subcategories",
                 "consumes": [
                     "application/json"
                 ],
@@ -709,40 +565,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "course"
-                ],
-                "summary": "GetCourseByID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "course id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "category"
                 ],
+                "summary": "GetCategories",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CategoryDTO"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -750,16 +585,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/courses/{id}/lessons": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get course lessons",
+            },
+            "post": {
+                "description": "create platform category, requires admin token",
                 "consumes": [
                     "application/json"
                 ],
@@ -767,26 +595,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "course"
+                    "category"
                 ],
-                "summary": "GetCourseLessons",
+                "summary": "CreateCategory",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "course id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "created category info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateCategoryDTO"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonDTO"
-                            }
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CategoryDTO"
                         }
                     },
                     "400": {
@@ -795,12 +622,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
-                        }
-                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -820,14 +641,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "create course lesson",
+            }
+        },
+        "/categories/{id}": {
+            "delete": {
+                "description": "delete platform category without subcategories, requires admin token",
                 "consumes": [
                     "application/json"
                 ],
@@ -835,32 +653,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "course"
+                    "category"
                 ],
-                "summary": "CreateCourseLesson",
+                "summary": "DeleteCategory",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "course id",
+                        "description": "category id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "created lesson info",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateLessonDTO"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "message",
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonDTO"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -869,12 +678,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
-                        }
-                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -887,8 +690,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
                         }
@@ -896,9 +705,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/courses/{id}/teachers": {
+        "/certificates/{id}": {
             "get": {
-                "description": "get course teachers",
+                "description": "verify learning path certificate by its id",
                 "consumes": [
                     "application/json"
                 ],
@@ -906,13 +715,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "course"
+                    "certificate"
                 ],
-                "summary": "GetCourseTeachers",
+                "summary": "GetPathCertificate",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "course id",
+                        "description": "certificate id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -922,10 +731,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UserDTO"
-                            }
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PathCertificateDTO"
                         }
                     },
                     "400": {
@@ -949,9 +755,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/courses/{id}": {
+        "/courses": {
             "get": {
-                "description": "get course payment url",
+                "description": "get all courses, filtered courses are limited to the catalog",
                 "consumes": [
                     "application/json"
                 ],
@@ -959,23 +765,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payment"
+                    "course"
                 ],
-                "summary": "GetCoursePaymentUrl",
+                "summary": "GetAllCourses",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "course id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "category id, subcategories are included",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "tag name",
+                        "name": "tag",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "url",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                            }
                         }
                     },
                     "400": {
@@ -984,18 +798,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1005,44 +807,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/schools": {
+        "/courses/{courseID}/lessons/{lessonID}": {
             "get": {
-                "description": "get all schools",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "school"
-                ],
-                "summary": "GetAllSchools",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SchoolDTO"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
-                        }
-                    }
-                }
-            },
-            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "create school",
+                "description": "get lesson by id, preview lessons are readable without auth and test answers",
                 "consumes": [
                     "application/json"
                 ],
@@ -1050,25 +822,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "school"
+                    "course"
                 ],
-                "summary": "CreateSchool",
+                "summary": "GetLessonByID",
                 "parameters": [
                     {
-                        "description": "created school info",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateSchoolDTO"
-                        }
+                        "type": "string",
+                        "description": "course id",
+                        "name": "courseID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "lesson id",
+                        "name": "lessonID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SchoolDTO"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonDTO"
+                            }
                         }
                     },
                     "400": {
@@ -1083,6 +863,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1090,11 +882,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/schools/{id}": {
-            "get": {
-                "description": "get school by id",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete course lesson",
                 "consumes": [
                     "application/json"
                 ],
@@ -1102,26 +897,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "school"
+                    "course"
                 ],
-                "summary": "GetSchoolByID",
+                "summary": "DeleteCourseLesson",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "school id",
-                        "name": "id",
+                        "description": "course id",
+                        "name": "courseID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "lesson id",
+                        "name": "lessonID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "message",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SchoolDTO"
-                            }
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1130,6 +929,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1150,7 +961,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update school",
+                "description": "update course lesson",
                 "consumes": [
                     "application/json"
                 ],
@@ -1158,24 +969,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "school"
+                    "course"
                 ],
-                "summary": "UpdateSchool",
+                "summary": "UpdateCourseLesson",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "school id",
-                        "name": "id",
+                        "description": "course id",
+                        "name": "courseID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "updated school info",
+                        "type": "string",
+                        "description": "lesson id",
+                        "name": "lessonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "updated lesson info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateSchoolDTO"
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateLessonDTO"
                         }
                     }
                 ],
@@ -1183,7 +1001,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SchoolDTO"
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonDTO"
                         }
                     },
                     "400": {
@@ -1219,9 +1037,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/schools/{id}/courses": {
-            "get": {
-                "description": "get school courses",
+        "/courses/{courseID}/lessons/{lessonID}/preview": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "mark lesson as the course preview readable by anyone, course has a few preview lessons",
                 "consumes": [
                     "application/json"
                 ],
@@ -1229,26 +1052,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "school"
+                    "course"
                 ],
-                "summary": "GetSchoolCourses",
+                "summary": "UpdateLessonPreview",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "school id",
-                        "name": "id",
+                        "description": "course id",
+                        "name": "courseID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "lesson id",
+                        "name": "lessonID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "preview flag",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateLessonPreviewDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
-                            }
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonDTO"
                         }
                     },
                     "400": {
@@ -1257,12 +1093,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1270,14 +1124,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/courses/{courseID}/lessons/{lessonID}/release": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "create school course",
+                "description": "release lesson at the date or in days after enrollment, empty body releases it at once",
                 "consumes": [
                     "application/json"
                 ],
@@ -1285,24 +1141,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "school"
+                    "course"
                 ],
-                "summary": "CreateSchoolCourse",
+                "summary": "UpdateLessonRelease",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "school id",
-                        "name": "id",
+                        "description": "course id",
+                        "name": "courseID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "created course info",
+                        "type": "string",
+                        "description": "lesson id",
+                        "name": "lessonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "release rule",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateCourseDTO"
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateLessonReleaseDTO"
                         }
                     }
                 ],
@@ -1310,10 +1173,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
-                            }
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonDTO"
                         }
                     },
                     "400": {
@@ -1349,9 +1209,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/schools/{id}/teachers": {
+        "/courses/{courseID}/lessons/{lessonID}/stat": {
             "get": {
-                "description": "get school teachers",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get lesson statistics",
                 "consumes": [
                     "application/json"
                 ],
@@ -1359,14 +1224,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "school"
+                    "course"
                 ],
-                "summary": "GetSchoolTeachers",
+                "summary": "GetLessonStat",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "school id",
-                        "name": "id",
+                        "description": "course id",
+                        "name": "courseID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "lesson id",
+                        "name": "lessonID",
                         "in": "path",
                         "required": true
                     }
@@ -1375,10 +1247,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UserDTO"
-                            }
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonStatDTO"
                         }
                     },
                     "400": {
@@ -1387,6 +1256,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1400,16 +1281,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/schools/{schoolID}/courses/{courseID}": {
-            "delete": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete school course",
+                "description": "pass course lesson",
                 "consumes": [
                     "application/json"
                 ],
@@ -1417,30 +1296,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "school"
+                    "course"
                 ],
-                "summary": "DeleteSchoolCourse",
+                "summary": "PassCourseLesson",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "school id",
-                        "name": "schoolID",
+                        "description": "course id",
+                        "name": "courseID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "course id",
-                        "name": "courseID",
+                        "description": "lesson id",
+                        "name": "lessonID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "passed lesson info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PassLessonDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "message",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonStatDTO"
                         }
                     },
                     "400": {
@@ -1474,14 +1362,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/courses/{courseID}/prerequisites/{requiredID}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update school course",
+                "description": "require completion of another school course or reaching its score before enrollment",
                 "consumes": [
                     "application/json"
                 ],
@@ -1489,39 +1379,111 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "school"
+                    "course"
                 ],
-                "summary": "UpdateSchoolCourse",
+                "summary": "AddCoursePrerequisite",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "school id",
-                        "name": "schoolID",
+                        "description": "course id",
+                        "name": "courseID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "course id",
-                        "name": "courseID",
+                        "description": "required course id",
+                        "name": "requiredID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "updated course info",
+                        "description": "prerequisite info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateCourseDTO"
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.AddPrerequisiteDTO"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "message",
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "remove course prerequisite",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "RemoveCoursePrerequisite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "courseID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "required course id",
+                        "name": "requiredID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1557,14 +1519,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/schools/{schoolID}/teachers/{teacherID}": {
+        "/courses/{courseID}/teachers/{teacherID}": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add school teacher",
+                "description": "add course teacher",
                 "consumes": [
                     "application/json"
                 ],
@@ -1572,14 +1534,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "school"
+                    "course"
                 ],
-                "summary": "AddSchoolTeacher",
+                "summary": "AddCourseTeacher",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "school id",
-                        "name": "schoolID",
+                        "description": "course id",
+                        "name": "courseID",
                         "in": "path",
                         "required": true
                     },
@@ -1631,9 +1593,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/courses/{id}": {
             "get": {
-                "description": "get all users",
+                "description": "get course by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1641,19 +1603,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "course"
+                ],
+                "summary": "GetCourseByID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "GetAllUsers",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UserDTO"
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1663,14 +1646,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me": {
+        "/courses/{id}/categories": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get user account",
+                "description": "get categories of the course",
                 "consumes": [
                     "application/json"
                 ],
@@ -1678,23 +1656,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "course"
+                ],
+                "summary": "GetCourseCategories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "GetUserAccount",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UserInfoDTO"
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CategoryDTO"
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
                     "500": {
@@ -1705,13 +1692,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user information",
+                "description": "replace categories of the course",
                 "consumes": [
                     "application/json"
                 ],
@@ -1719,17 +1706,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "course"
                 ],
-                "summary": "UpdateUser",
+                "summary": "UpdateCourseCategories",
                 "parameters": [
                     {
-                        "description": "update user info",
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "course categories",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateUserDTO"
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateCourseCategoriesDTO"
                         }
                     }
                 ],
@@ -1739,7 +1733,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UserDTO"
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CategoryDTO"
                             }
                         }
                     },
@@ -1755,6 +1749,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1764,14 +1770,89 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me/courses": {
+        "/courses/{id}/duplicate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "copy course with lessons and tests into a new draft course of the same or another school",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "DuplicateCourse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "target school, course school by default",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.DuplicateCourseDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/enrollment": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "find user courses",
+                "description": "get enrollment of the current user, expired enrollment can be renewed by payment",
                 "consumes": [
                     "application/json"
                 ],
@@ -1779,17 +1860,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "course"
+                ],
+                "summary": "GetCourseEnrollment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "FindUserCourses",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
-                            }
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.EnrollmentDTO"
                         }
                     },
                     "400": {
@@ -1804,6 +1891,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1813,14 +1906,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me/courses/{id}": {
-            "put": {
+        "/courses/{id}/enrollment/migrate": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add user free course",
+                "description": "move the current user to the published course version, stats of lessons are kept",
                 "consumes": [
                     "application/json"
                 ],
@@ -1828,9 +1921,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "course"
                 ],
-                "summary": "AddUserFreeCourse",
+                "summary": "MigrateCourseEnrollment",
                 "parameters": [
                     {
                         "type": "string",
@@ -1844,7 +1937,71 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.EnrollmentDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "download zip archive with course.json manifest, lesson markdown and media of the course",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "ExportCourse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1859,6 +2016,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1868,9 +2037,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/courses/{id}/lessons": {
             "get": {
-                "description": "get user by id",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get course lessons",
                 "consumes": [
                     "application/json"
                 ],
@@ -1878,13 +2052,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "course"
                 ],
-                "summary": "GetUserByID",
+                "summary": "GetCourseLessons",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user id",
+                        "description": "course id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1896,7 +2070,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UserDTO"
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonDTO"
                             }
                         }
                     },
@@ -1906,6 +2080,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1919,40 +2105,5085 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
-                },
-                "language": {
-                    "type": "string",
-                    "example": "english"
-                },
-                "level": {
-                    "type": "integer",
-                    "example": 5
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Course name"
-                },
-                "price": {
-                    "type": "integer",
-                    "example": 3990
-                },
-                "school_id": {
-                    "type": "string",
-                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
-                },
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create course lesson",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "CreateCourseLesson",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "created lesson info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateLessonDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/lessons/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create practice lessons from QTI 2.1 items or Common Cartridge package, only choice items are imported",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "ImportCourseQuiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "QTI xml file or zip package",
+                        "name": "package",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.QuizImportDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/prerequisites": {
+            "get": {
+                "description": "get courses required to enroll in the course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "GetCoursePrerequisites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CoursePrerequisiteDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/progress": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get progress of current user in the course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "GetCourseProgress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseProgressDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/refunds": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "request refund for a bought course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "RequestCourseRefund",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "refund reason",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateRefundDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.RefundDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/revision": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "copy lessons of the published course into a draft revision",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "CreateCourseRevision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/revision/publish": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "publish the draft revision as a new course version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "PublishCourseRevision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/students/{student_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "remove student from the course, freed seat is offered to the waitlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "RemoveCourseStudent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "student id",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/tags": {
+            "get": {
+                "description": "get tags of the course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "GetCourseTags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.TagDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "replace tags of the course, new tags are added to the course school",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "UpdateCourseTags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "course tags",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateCourseTagsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.TagDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/teachers": {
+            "get": {
+                "description": "get course teachers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "GetCourseTeachers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UserDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/validation": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "check course lessons and tests without changing the course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "ValidateCourse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseValidationReportDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/waitlist": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find course waitlist, available to school owner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "FindCourseWaitlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.WaitlistEntryDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "join waitlist of a course with limited capacity, free seat is offered right away",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "JoinCourseWaitlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.WaitlistEntryDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "leave course waitlist, offered seat is passed to the next user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "LeaveCourseWaitlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/gifts/{code}/redeem": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "redeem gift code and enroll current user to the gift course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gift"
+                ],
+                "summary": "RedeemGift",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "gift code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/acquirer/webhook": {
+            "post": {
+                "description": "card acquirer payment status webhook",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "ProcessAcquirerNotification",
+                "parameters": [
+                    {
+                        "description": "acquirer notification",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.AcquirerNotificationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/bundles/{id}": {
+            "get": {
+                "description": "get bundle payment url, price is reduced by shares of already bought courses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "GetBundlePaymentUrl",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bundle id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "url",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/courses/{id}": {
+            "get": {
+                "description": "get course payment url",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "GetCoursePaymentUrl",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "promo code",
+                        "name": "promo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "url",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/courses/{id}/gift": {
+            "post": {
+                "description": "get payment url of the course bought as a gift, gift code is sent to the recipient,\ncourses with seat limit can not be gifted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "GetGiftPaymentUrl",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "gift recipient",
+                        "name": "gift",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.GiftPaymentDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "url",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/schools/{id}/subscription": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get school subscription payment url, the payment method is saved for renewals",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "GetSubscriptionPaymentUrl",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "url",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools": {
+            "get": {
+                "description": "get all schools",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetAllSchools",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SchoolDTO"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create school",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "CreateSchool",
+                "parameters": [
+                    {
+                        "description": "created school info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateSchoolDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SchoolDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}": {
+            "get": {
+                "description": "get school by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolByID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SchoolDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update school",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "UpdateSchool",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "updated school info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateSchoolDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SchoolDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/bundles": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get all school bundles including drafts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolBundles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.BundleDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create school course bundle",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "CreateSchoolBundle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "created bundle info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateBundleDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.BundleDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/catalog": {
+            "get": {
+                "description": "get published school courses and bundles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolCatalog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SchoolCatalogDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/courses": {
+            "get": {
+                "description": "get school courses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolCourses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create school course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "CreateSchoolCourse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "created course info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateCourseDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/courses/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create draft school course from the zip archive made by course export",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "ImportSchoolCourse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "course zip archive",
+                        "name": "archive",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/paths": {
+            "get": {
+                "description": "get school learning paths",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolPaths",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PathDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create school learning path, courses are studied in the given order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "CreateSchoolPath",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "created path info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreatePathDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PathDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/payouts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get school payouts history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolPayouts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PayoutDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "settle unsettled school earnings in one currency, requires platform admin token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "CreateSchoolPayout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "platform admin token",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "payout",
+                        "name": "payout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreatePayoutDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PayoutDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/promo-codes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get school promo codes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolPromoCodes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PromoCodeDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create school or course promo code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "CreateSchoolPromoCode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "created promo code info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreatePromoCodeDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PromoCodeDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/refunds": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get refund requests for school courses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolRefunds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.RefundDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/statements": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get monthly school revenue statement per course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolStatement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "statement month in YYYY-MM format, current month by default",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.StatementDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/subscription": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "cancel automatic renewals of the current user subscription, paid period stays active",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "CancelSchoolSubscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/subscription-plan": {
+            "get": {
+                "description": "get school subscription plan, subscribers study every published course of the school",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolSubscriptionPlan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SubscriptionPlanDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create or update school subscription plan, changes apply to the next payments",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "SaveSchoolSubscriptionPlan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "subscription plan info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SaveSubscriptionPlanDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SubscriptionPlanDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/tags": {
+            "get": {
+                "description": "get tags used by courses of the school",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolTags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.TagDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/teachers": {
+            "get": {
+                "description": "get school teachers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolTeachers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UserDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/bundles/{bundleID}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete school bundle, bought courses stay with students",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "DeleteSchoolBundle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "bundle id",
+                        "name": "bundleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/bundles/{bundleID}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "publish school bundle, all its courses must be published",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "PublishSchoolBundle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "bundle id",
+                        "name": "bundleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/courses/{courseID}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete school course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "DeleteSchoolCourse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "courseID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update school course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "UpdateSchoolCourse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "courseID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "updated course info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateCourseDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/courses/{courseID}/schedule": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "publish ready school course at the given date, null date cancels the schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "ScheduleSchoolCourse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "courseID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "publish date",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ScheduleCourseDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/courses/{courseID}/status": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "move school course to another status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "ChangeSchoolCourseStatus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "courseID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new course status",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ChangeCourseStatusDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/courses/{courseID}/transitions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get status history of the school course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolCourseTransitions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "courseID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseTransitionDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/paths/{pathID}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete school learning path, issued certificates are deleted too",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "DeleteSchoolPath",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "path id",
+                        "name": "pathID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/paths/{pathID}/certificate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "issue certificate of the completed learning path, issued certificate is returned again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "IssueSchoolPathCertificate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "path id",
+                        "name": "pathID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PathCertificateDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/paths/{pathID}/progress": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get progress of current user across courses of the learning path",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "GetSchoolPathProgress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "path id",
+                        "name": "pathID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PathProgressDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/promo-codes/{promoID}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete school promo code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "DeleteSchoolPromoCode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "promo code id",
+                        "name": "promoID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/refunds/{refundID}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "approve refund, payment is returned and the access period it paid is revoked,\nrefund left approving by failed approval is approved again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "ApproveSchoolRefund",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "refund id",
+                        "name": "refundID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.RefundDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/refunds/{refundID}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "reject refund request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "RejectSchoolRefund",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "refund id",
+                        "name": "refundID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.RefundDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{schoolID}/teachers/{teacherID}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add school teacher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "school"
+                ],
+                "summary": "AddSchoolTeacher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "school id",
+                        "name": "schoolID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "teacherID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "get all users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "GetAllUsers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UserDTO"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get user account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "GetUserAccount",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UserInfoDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update user information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "UpdateUser",
+                "parameters": [
+                    {
+                        "description": "update user info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateUserDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UserDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find user api keys",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "FindUserApiKeys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ApiKeyDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create api key, the key is shown only once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "CreateUserApiKey",
+                "parameters": [
+                    {
+                        "description": "api key name and scopes",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateApiKeyDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreatedApiKeyDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/api-keys/{key_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "revoke api key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "RevokeUserApiKey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "api key id",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/courses": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find user courses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "FindUserCourses",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/courses/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add user free course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "AddUserFreeCourse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "course id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorForbidden"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/mfa": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "start two-factor authentication enrollment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "EnrollUserMfa",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.MfaEnrollmentDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorConflict"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "disable two-factor authentication",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "DisableUserMfa",
+                "parameters": [
+                    {
+                        "description": "totp or recovery code",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.MfaCodeDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/mfa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "enable two-factor authentication with the first code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "ConfirmUserMfa",
+                "parameters": [
+                    {
+                        "description": "totp code",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.MfaCodeDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/refunds": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get current user refund requests",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "GetUserRefunds",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.RefundDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/subscriptions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get school subscriptions of the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "GetUserSubscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SubscriptionDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorUnauthorized"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "get user by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "GetUserByID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UserDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_delivery_http_v1.RestErrorInternalError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.AcquirerAmountDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "value": {
+                    "type": "integer",
+                    "example": 3990
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.AcquirerNotificationDTO": {
+            "type": "object",
+            "required": [
+                "event",
+                "object"
+            ],
+            "properties": {
+                "event": {
+                    "type": "string",
+                    "example": "payment.waiting_for_capture"
+                },
+                "object": {
+                    "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.AcquirerPaymentDTO"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.AcquirerPaymentDTO": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.AcquirerAmountDTO"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "2f4e2b8a-8b55-4f1d-a6f4-8c7d5e1f0b33"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "waiting_for_capture"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.AddPrerequisiteDTO": {
+            "type": "object",
+            "properties": {
+                "min_score": {
+                    "description": "MinScore replaces completion of the required course with reaching the score",
+                    "type": "string",
+                    "example": "50"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ApiKeyDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-10-01T12:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-10-02T12:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "course sync script"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "esk_AbCdEfGh"
+                },
+                "revoked": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read",
+                        "course_write"
+                    ]
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.BundleDTO": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-10-01T00:00:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "3 courses for the price of 2"
+                },
+                "display_price": {
+                    "type": "string",
+                    "example": "7 980,00 ₽"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Backend starter pack"
+                },
+                "price": {
+                    "description": "Price is in minor units of the currency of bundle courses",
+                    "type": "integer",
+                    "example": 798000
+                },
+                "school_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "published"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CategoryDTO": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CategoryDTO"
+                    }
+                },
+                "course_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Programming"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ChangeCourseStatusDTO": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "ready",
+                        "published",
+                        "unpublished",
+                        "archived"
+                    ],
+                    "example": "unpublished"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO": {
+            "type": "object",
+            "properties": {
+                "access_days": {
+                    "type": "integer",
+                    "example": 365
+                },
+                "capacity": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "display_price": {
+                    "type": "string",
+                    "example": "3 990,00 ₽"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "english"
+                },
+                "level": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Course name"
+                },
+                "price": {
+                    "description": "Price is in minor units of the currency, e.g. kopecks",
+                    "type": "integer",
+                    "example": 399000
+                },
+                "publish_at": {
+                    "description": "PublishAt is the date when the ready course is published by the scheduler",
+                    "type": "string",
+                    "example": "2024-09-01T09:00:00Z"
+                },
+                "published_version": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "school_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                },
                 "status": {
                     "type": "string",
                     "example": "published"
+                },
+                "version": {
+                    "description": "Version is edited by teachers, PublishedVersion is studied by new students",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CoursePrerequisiteDTO": {
+            "type": "object",
+            "properties": {
+                "course": {
+                    "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                },
+                "min_score": {
+                    "type": "string",
+                    "example": "50"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseProgressDTO": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "course_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                },
+                "lessons": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "max_score": {
+                    "type": "integer",
+                    "example": 80
+                },
+                "passed_lessons": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 45
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseStatementDTO": {
+            "type": "object",
+            "properties": {
+                "commission": {
+                    "type": "integer",
+                    "example": 3289
+                },
+                "course_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                },
+                "course_name": {
+                    "type": "string",
+                    "example": "Go backend"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "gross": {
+                    "type": "integer",
+                    "example": 32890
+                },
+                "net": {
+                    "type": "integer",
+                    "example": 29601
+                },
+                "refunds": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sales": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "settled": {
+                    "type": "integer",
+                    "example": 26910
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseTransitionDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-05-01T10:00:00Z"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "published"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "unpublished"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseValidationReportDTO": {
+            "type": "object",
+            "properties": {
+                "course_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                },
+                "errors": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ValidationIssueDTO"
+                    }
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "warnings": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateApiKeyDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "course sync script"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read",
+                        "course_write"
+                    ]
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateBundleDTO": {
+            "type": "object",
+            "required": [
+                "course_ids",
+                "name",
+                "price"
+            ],
+            "properties": {
+                "course_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                    ]
+                },
+                "description": {
+                    "type": "string",
+                    "example": "3 courses for the price of 2"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Backend starter pack"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 798000
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateCategoryDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Programming"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
                 }
             }
         },
@@ -1965,6 +7196,18 @@ const docTemplate = `{
                 "price"
             ],
             "properties": {
+                "access_days": {
+                    "type": "string",
+                    "example": "365"
+                },
+                "capacity": {
+                    "type": "string",
+                    "example": "30"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
                 "language": {
                     "type": "string",
                     "example": "english"
@@ -1979,13 +7222,110 @@ const docTemplate = `{
                 },
                 "price": {
                     "type": "string",
-                    "example": "3990"
+                    "example": "399000"
                 }
             }
         },
         "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateLessonDTO": {
             "type": "object"
         },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreatePathDTO": {
+            "type": "object",
+            "required": [
+                "course_ids",
+                "name"
+            ],
+            "properties": {
+                "course_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                    ]
+                },
+                "description": {
+                    "type": "string",
+                    "example": "From the first program to production services"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Backend developer"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreatePayoutDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "settled_until": {
+                    "type": "string",
+                    "example": "2024-11-01T00:00:00Z"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreatePromoCodeDTO": {
+            "type": "object",
+            "required": [
+                "code",
+                "discount",
+                "discount_type"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "SPRING25"
+                },
+                "course_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "discount": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ],
+                    "example": "percent"
+                },
+                "max_uses": {
+                    "type": "string",
+                    "example": "100"
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2024-10-01T00:00:00Z"
+                },
+                "valid_until": {
+                    "type": "string",
+                    "example": "2024-11-01T00:00:00Z"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateRefundDTO": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Course does not match the description"
+                }
+            }
+        },
         "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreateSchoolDTO": {
             "type": "object",
             "required": [
@@ -1993,12 +7333,86 @@ const docTemplate = `{
                 "name"
             ],
             "properties": {
-                "description": {
+                "description": {
+                    "type": "string",
+                    "example": "School description"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CreatedApiKeyDTO": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ApiKeyDTO"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "esk_AbCdEfGhIjKlMnOpQrStUvWxYz0123456789abcde"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.DuplicateCourseDTO": {
+            "type": "object",
+            "properties": {
+                "school_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.EnrollmentDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "course_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                },
+                "enrolled_at": {
+                    "type": "string",
+                    "example": "2024-05-01T10:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-05-01T10:00:00Z"
+                },
+                "student_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ForgotPasswordDTO": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "paw1a@yandex.ru"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.GiftPaymentDTO": {
+            "type": "object",
+            "required": [
+                "recipient_email"
+            ],
+            "properties": {
+                "recipient_email": {
                     "type": "string",
-                    "example": "School description"
-                },
-                "name": {
-                    "type": "string"
+                    "example": "employee@example.com"
                 }
             }
         },
@@ -2011,6 +7425,20 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "is_preview": {
+                    "description": "preview lesson is readable by anyone without test answers",
+                    "type": "boolean"
+                },
+                "locked": {
+                    "description": "Locked lesson is not released for the student yet, its content is hidden",
+                    "type": "boolean"
+                },
+                "release_at": {
+                    "type": "string"
+                },
+                "release_days": {
+                    "type": "integer"
+                },
                 "score": {
                     "type": "integer"
                 },
@@ -2029,6 +7457,9 @@ const docTemplate = `{
                 "type": {
                     "type": "string"
                 },
+                "version": {
+                    "type": "integer"
+                },
                 "video_url": {
                     "type": "string"
                 }
@@ -2057,6 +7488,89 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.MfaChallengeDTO": {
+            "type": "object",
+            "properties": {
+                "mfa_token": {
+                    "type": "string",
+                    "example": "token"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.MfaCodeDTO": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.MfaEnrollmentDTO": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
+                    "type": "string",
+                    "example": "otpauth://totp/eschool:user@gmail.com?secret=SECRET"
+                },
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "a2b3c4d5e6f7g8h9"
+                    ]
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.MfaVerifyDTO": {
+            "type": "object",
+            "required": [
+                "code",
+                "fingerprint",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "fingerprint": {
+                    "type": "string",
+                    "example": "fingerprint"
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "token"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.OidcCallbackDTO": {
+            "type": "object",
+            "required": [
+                "code",
+                "fingerprint",
+                "state"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "code"
+                },
+                "fingerprint": {
+                    "type": "string",
+                    "example": "fingerprint"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "state"
+                }
+            }
+        },
         "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PassLessonDTO": {
             "type": "object",
             "properties": {
@@ -2068,30 +7582,321 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PassTestDTO": {
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PassTestDTO": {
+            "type": "object",
+            "required": [
+                "answer",
+                "test_id"
+            ],
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "test_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PathCertificateDTO": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7027cb"
+                },
+                "issued_at": {
+                    "type": "string",
+                    "example": "2024-10-01T00:00:00Z"
+                },
+                "path": {
+                    "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PathDTO"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 240
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034ca"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PathDTO": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-10-01T00:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "From the first program to production services"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Backend developer"
+                },
+                "school_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PathProgressDTO": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "completed_courses": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseProgressDTO"
+                    }
+                },
+                "current_course_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                },
+                "path_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PayoutDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 26910
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-11-02T10:00:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027cb"
+                },
+                "school_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                },
+                "settled_until": {
+                    "type": "string",
+                    "example": "2024-11-01T00:00:00Z"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.PromoCodeDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "SPRING25"
+                },
+                "course_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "discount": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percent"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
+                },
+                "max_uses": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "school_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                },
+                "used_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2024-10-01T00:00:00Z"
+                },
+                "valid_until": {
+                    "type": "string",
+                    "example": "2024-11-01T00:00:00Z"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.QuizImportDTO": {
+            "type": "object",
+            "properties": {
+                "lessons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.LessonDTO"
+                    }
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SkippedQuizItemDTO"
+                    }
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.RefreshDTO": {
+            "type": "object",
+            "required": [
+                "fingerprint"
+            ],
+            "properties": {
+                "fingerprint": {
+                    "type": "string",
+                    "example": "fingerprint"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.RefundDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 3990
+                },
+                "course_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Course does not match the description"
+                },
+                "requested_at": {
+                    "type": "string",
+                    "example": "2024-10-01T12:00:00Z"
+                },
+                "resolved_at": {
+                    "type": "string",
+                    "example": "2024-10-02T12:00:00Z"
+                },
+                "resolved_by": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7011aa"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "requested"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ResetPasswordDTO": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "token"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SaveSubscriptionPlanDTO": {
             "type": "object",
             "required": [
-                "answer",
-                "test_id"
+                "name",
+                "period_days",
+                "price"
             ],
             "properties": {
-                "answer": {
-                    "type": "string"
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
                 },
-                "test_id": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "All courses"
+                },
+                "period_days": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 990
                 }
             }
         },
-        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.RefreshDTO": {
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ScheduleCourseDTO": {
             "type": "object",
-            "required": [
-                "fingerprint"
-            ],
             "properties": {
-                "fingerprint": {
+                "publish_at": {
                     "type": "string",
-                    "example": "fingerprint"
+                    "example": "2024-09-01T09:00:00Z"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SchoolCatalogDTO": {
+            "type": "object",
+            "properties": {
+                "bundles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.BundleDTO"
+                    }
+                },
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseDTO"
+                    }
                 }
             }
         },
@@ -2177,6 +7982,157 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SkippedQuizItemDTO": {
+            "type": "object",
+            "properties": {
+                "identifier": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.StatementDTO": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.CourseStatementDTO"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-10-01T00:00:00Z"
+                },
+                "school_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-11-01T00:00:00Z"
+                },
+                "totals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.StatementTotalDTO"
+                    }
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.StatementTotalDTO": {
+            "type": "object",
+            "properties": {
+                "commission": {
+                    "type": "integer",
+                    "example": 3289
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "gross": {
+                    "type": "integer",
+                    "example": 32890
+                },
+                "net": {
+                    "type": "integer",
+                    "example": 29601
+                },
+                "settled": {
+                    "type": "integer",
+                    "example": 26910
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SubscriptionDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "auto_renewed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "canceled_at": {
+                    "type": "string",
+                    "example": "2024-05-10T10:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-05-31T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
+                },
+                "plan_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                },
+                "school_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2024-05-01T10:00:00Z"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.SubscriptionPlanDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "All courses"
+                },
+                "period_days": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 990
+                },
+                "school_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.TagDTO": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "golang"
+                },
+                "school_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                }
+            }
+        },
         "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.TestDTO": {
             "type": "object",
             "properties": {
@@ -2223,9 +8179,38 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateCourseCategoriesDTO": {
+            "type": "object",
+            "required": [
+                "category_ids"
+            ],
+            "properties": {
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
+                    ]
+                }
+            }
+        },
         "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateCourseDTO": {
             "type": "object",
             "properties": {
+                "access_days": {
+                    "type": "string",
+                    "example": "365"
+                },
+                "capacity": {
+                    "type": "string",
+                    "example": "30"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
                 "language": {
                     "type": "string",
                     "example": "english"
@@ -2240,13 +8225,52 @@ const docTemplate = `{
                 },
                 "price": {
                     "type": "string",
-                    "example": "3990"
+                    "example": "399000"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateCourseTagsDTO": {
+            "type": "object",
+            "required": [
+                "tags"
+            ],
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "golang"
+                    ]
                 }
             }
         },
         "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateLessonDTO": {
             "type": "object"
         },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateLessonPreviewDTO": {
+            "type": "object",
+            "properties": {
+                "is_preview": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateLessonReleaseDTO": {
+            "type": "object",
+            "properties": {
+                "release_at": {
+                    "type": "string",
+                    "example": "2024-09-01T09:00:00Z"
+                },
+                "release_days": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
         "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.UpdateSchoolDTO": {
             "type": "object",
             "properties": {
@@ -2296,6 +8320,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "paw1a@yandex.ru"
                 },
+                "email_verified": {
+                    "type": "boolean",
+                    "example": true
+                },
                 "id": {
                     "type": "string",
                     "example": "30e18bc1-4354-4937-9a3b-03cf0b7027ca"
@@ -2327,6 +8355,78 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.ValidationIssueDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "test_empty_options"
+                },
+                "lesson_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "course practice lesson test has no options"
+                },
+                "severity": {
+                    "type": "string",
+                    "example": "error"
+                },
+                "test_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.VerifyEmailDTO": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "token"
+                }
+            }
+        },
+        "github_com_paw1a_eschool_internal_adapter_delivery_http_v1_dto.WaitlistEntryDTO": {
+            "type": "object",
+            "properties": {
+                "course_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a4d-03cf0b7027ca"
+                },
+                "joined_at": {
+                    "type": "string",
+                    "example": "2024-10-01T12:00:00Z"
+                },
+                "offered_until": {
+                    "type": "string",
+                    "example": "2024-10-02T12:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "30e18bc1-4354-4937-9a3b-03cf0b7034cc"
+                }
+            }
+        },
+        "internal_adapter_delivery_http_v1.RestError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_adapter_delivery_http_v1.RestErrorBadRequest": {
             "type": "object",
             "properties": {
@@ -2412,6 +8512,23 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_adapter_delivery_http_v1.RestErrorTooManyRequests": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "too many attempts, try again later"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 429
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2020-11-10T23:00:00+00:00"
+                }
+            }
+        },
         "internal_adapter_delivery_http_v1.RestErrorUnauthorized": {
             "type": "object",
             "properties": {
@@ -0,0 +1,47 @@
+package redis
+
+import (
+	"encoding/json"
+	"github.com/go-redis/redis/v7"
+	"github.com/paw1a/eschool/internal/adapter/auth/port"
+	"time"
+)
+
+const authRequestPrefix = "oidc:state:"
+
+type AuthRequestStorage struct {
+	redisClient *redis.Client
+}
+
+func NewAuthRequestStorage(redisClient *redis.Client) *AuthRequestStorage {
+	return &AuthRequestStorage{redisClient: redisClient}
+}
+
+func (s *AuthRequestStorage) Put(state string, request port.AuthRequest, expireTime time.Duration) error {
+	requestJson, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	return s.redisClient.Set(authRequestPrefix+state, requestJson, expireTime).Err()
+}
+
+func (s *AuthRequestStorage) Pop(state string) (port.AuthRequest, error) {
+	var get *redis.StringCmd
+	_, err := s.redisClient.TxPipelined(func(pipe redis.Pipeliner) error {
+		get = pipe.Get(authRequestPrefix + state)
+		pipe.Del(authRequestPrefix + state)
+		return nil
+	})
+	if err != nil {
+		return port.AuthRequest{}, err
+	}
+
+	var request port.AuthRequest
+	err = json.Unmarshal([]byte(get.Val()), &request)
+	if err != nil {
+		return port.AuthRequest{}, err
+	}
+
+	return request, nil
+}
@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"github.com/go-redis/redis/v7"
 	"github.com/paw1a/eschool/internal/adapter/auth/port"
+	"github.com/paw1a/eschool/internal/core/domain"
 	"time"
 )
 
+// userSessionsPrefix keys the set of refresh tokens issued to the user,
+// tokens of expired sessions stay in the set until it expires itself
+const userSessionsPrefix = "session:user:"
+
 type SessionStorage struct {
 	redisClient *redis.Client
 }
@@ -37,9 +42,26 @@ func (s *SessionStorage) Put(refreshToken string, session port.AuthSession,
 		return err
 	}
 
-	return s.redisClient.Set(refreshToken, sessionJson, expireTime).Err()
+	userSessionsKey := userSessionsPrefix + session.Payload.UserID.String()
+	_, err = s.redisClient.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Set(refreshToken, sessionJson, expireTime)
+		pipe.SAdd(userSessionsKey, refreshToken)
+		pipe.Expire(userSessionsKey, expireTime)
+		return nil
+	})
+	return err
 }
 
 func (s *SessionStorage) Delete(refreshToken string) error {
 	return s.redisClient.Del(refreshToken).Err()
 }
+
+func (s *SessionStorage) DeleteUserSessions(userID domain.ID) error {
+	userSessionsKey := userSessionsPrefix + userID.String()
+	refreshTokens, err := s.redisClient.SMembers(userSessionsKey).Result()
+	if err != nil {
+		return err
+	}
+
+	return s.redisClient.Del(append(refreshTokens, userSessionsKey)...).Err()
+}
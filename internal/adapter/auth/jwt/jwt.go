@@ -14,8 +14,12 @@ type Config struct {
 	Secret           string
 	AccessTokenTime  int64
 	RefreshTokenTime int64
+	EmailTokenTime   int64
+	MfaTokenTime     int64
 }
 
+const mfaChallengePurpose = "mfa_challenge"
+
 type AuthProvider struct {
 	cfg            *Config
 	sessionStorage port.ISessionStorage
@@ -88,6 +92,12 @@ func (p *AuthProvider) DeleteJWTSession(refreshToken domain.Token) error {
 	return p.sessionStorage.Delete(refreshToken.String())
 }
 
+// DeleteUserJWTSessions revokes refresh tokens of the user,
+// issued access tokens stay valid until they expire
+func (p *AuthProvider) DeleteUserJWTSessions(userID domain.ID) error {
+	return p.sessionStorage.DeleteUserSessions(userID)
+}
+
 func (p *AuthProvider) VerifyJWTToken(accessToken domain.Token) (domain.AuthPayload, error) {
 	token, err := jwt.Parse(accessToken.String(), func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -100,7 +110,7 @@ func (p *AuthProvider) VerifyJWTToken(accessToken domain.Token) (domain.AuthPayl
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
-	if ok && token.Valid {
+	if _, isEmailToken := claims["purpose"]; ok && token.Valid && !isEmailToken {
 		payload := domain.AuthPayload{
 			UserID: domain.ID(claims["userID"].(string)),
 		}
@@ -109,3 +119,101 @@ func (p *AuthProvider) VerifyJWTToken(accessToken domain.Token) (domain.AuthPayl
 
 	return domain.AuthPayload{}, errs.ErrInvalidTokenClaims
 }
+
+func (p *AuthProvider) CreateEmailToken(userID domain.ID,
+	purpose domain.EmailTokenPurpose) (domain.EmailToken, domain.Token, error) {
+	expTime := time.Minute * time.Duration(p.cfg.EmailTokenTime)
+	emailToken := domain.EmailToken{
+		ID:        domain.NewID(),
+		UserID:    userID,
+		Purpose:   purpose,
+		ExpiresAt: time.Now().Add(expTime),
+	}
+	claims := jwt.MapClaims{
+		"exp":     emailToken.ExpiresAt.Unix(),
+		"tokenID": emailToken.ID.String(),
+		"userID":  emailToken.UserID.String(),
+		"purpose": string(emailToken.Purpose),
+	}
+
+	unsignedToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signedToken, err := unsignedToken.SignedString([]byte(p.cfg.Secret))
+	if err != nil {
+		return domain.EmailToken{}, "", err
+	}
+
+	return emailToken, domain.Token(signedToken), nil
+}
+
+func (p *AuthProvider) VerifyEmailToken(token domain.Token) (domain.EmailToken, error) {
+	parsedToken, err := jwt.Parse(token.String(), func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errs.ErrInvalidTokenSignMethod
+		}
+		return []byte(p.cfg.Secret), nil
+	})
+	if err != nil {
+		return domain.EmailToken{}, errors.Wrap(errs.ErrInvalidEmailToken, err.Error())
+	}
+
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok || !parsedToken.Valid {
+		return domain.EmailToken{}, errs.ErrInvalidEmailToken
+	}
+
+	tokenID, tokenIDOk := claims["tokenID"].(string)
+	userID, userIDOk := claims["userID"].(string)
+	purpose, purposeOk := claims["purpose"].(string)
+	exp, expOk := claims["exp"].(float64)
+	if !tokenIDOk || !userIDOk || !purposeOk || !expOk {
+		return domain.EmailToken{}, errs.ErrInvalidEmailToken
+	}
+
+	return domain.EmailToken{
+		ID:        domain.ID(tokenID),
+		UserID:    domain.ID(userID),
+		Purpose:   domain.EmailTokenPurpose(purpose),
+		ExpiresAt: time.Unix(int64(exp), 0),
+	}, nil
+}
+
+func (p *AuthProvider) CreateMfaToken(userID domain.ID) (domain.Token, error) {
+	expTime := time.Minute * time.Duration(p.cfg.MfaTokenTime)
+	claims := jwt.MapClaims{
+		"exp":     time.Now().Add(expTime).Unix(),
+		"userID":  userID.String(),
+		"purpose": mfaChallengePurpose,
+	}
+
+	unsignedToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signedToken, err := unsignedToken.SignedString([]byte(p.cfg.Secret))
+	if err != nil {
+		return "", err
+	}
+
+	return domain.Token(signedToken), nil
+}
+
+func (p *AuthProvider) VerifyMfaToken(token domain.Token) (domain.ID, error) {
+	parsedToken, err := jwt.Parse(token.String(), func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errs.ErrInvalidTokenSignMethod
+		}
+		return []byte(p.cfg.Secret), nil
+	})
+	if err != nil {
+		return "", errors.Wrap(errs.ErrInvalidMfaToken, err.Error())
+	}
+
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok || !parsedToken.Valid || claims["purpose"] != mfaChallengePurpose {
+		return "", errs.ErrInvalidMfaToken
+	}
+
+	userID, ok := claims["userID"].(string)
+	if !ok {
+		return "", errs.ErrInvalidMfaToken
+	}
+
+	return domain.ID(userID), nil
+}
@@ -0,0 +1,274 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/paw1a/eschool/internal/adapter/auth/port"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	randomSize    = 32
+	httpTimeout   = 10 * time.Second
+)
+
+var defaultScopes = []string{"openid", "email", "profile"}
+
+type ProviderConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectUrl  string
+	Scopes       []string
+}
+
+type Config struct {
+	StateTime int64 // seconds
+	Providers map[string]ProviderConfig
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	TokenType        string `json:"token_type"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type OidcProvider struct {
+	config         *Config
+	requestStorage port.IAuthRequestStorage
+	httpClient     *http.Client
+	mu             sync.Mutex
+	discoveries    map[string]discovery
+	keys           map[string]keySet
+}
+
+func NewOidcProvider(config *Config, requestStorage port.IAuthRequestStorage) *OidcProvider {
+	return &OidcProvider{
+		config:         config,
+		requestStorage: requestStorage,
+		httpClient:     &http.Client{Timeout: httpTimeout},
+		discoveries:    make(map[string]discovery),
+		keys:           make(map[string]keySet),
+	}
+}
+
+func (p *OidcProvider) AuthCodeUrl(ctx context.Context, provider string) (string, error) {
+	providerConfig, err := p.providerConfig(provider)
+	if err != nil {
+		return "", err
+	}
+
+	disc, err := p.discover(ctx, provider, providerConfig)
+	if err != nil {
+		return "", err
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return "", err
+	}
+
+	err = p.requestStorage.Put(state, port.AuthRequest{
+		Provider:     provider,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+	}, time.Second*time.Duration(p.config.StateTime))
+	if err != nil {
+		return "", err
+	}
+
+	scopes := providerConfig.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultScopes
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", providerConfig.ClientID)
+	query.Set("redirect_uri", providerConfig.RedirectUrl)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge(verifier))
+	query.Set("code_challenge_method", "S256")
+
+	authUrl, err := url.Parse(disc.AuthorizationEndpoint)
+	if err != nil {
+		return "", errors.Wrap(errs.ErrOidcExchangeFailed, err.Error())
+	}
+	authUrl.RawQuery = query.Encode()
+	return authUrl.String(), nil
+}
+
+func (p *OidcProvider) Exchange(ctx context.Context, provider, state,
+	code string) (domain.ExternalProfile, error) {
+	request, err := p.requestStorage.Pop(state)
+	if err != nil || request.Provider != provider {
+		return domain.ExternalProfile{}, errs.ErrInvalidOidcState
+	}
+
+	providerConfig, err := p.providerConfig(provider)
+	if err != nil {
+		return domain.ExternalProfile{}, err
+	}
+
+	disc, err := p.discover(ctx, provider, providerConfig)
+	if err != nil {
+		return domain.ExternalProfile{}, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", providerConfig.RedirectUrl)
+	form.Set("client_id", providerConfig.ClientID)
+	form.Set("code_verifier", request.CodeVerifier)
+	if providerConfig.ClientSecret != "" {
+		form.Set("client_secret", providerConfig.ClientSecret)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, disc.TokenEndpoint,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return domain.ExternalProfile{}, errors.Wrap(errs.ErrOidcExchangeFailed, err.Error())
+	}
+	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpRequest.Header.Set("Accept", "application/json")
+
+	var token tokenResponse
+	err = p.doJson(httpRequest, &token)
+	if err != nil {
+		return domain.ExternalProfile{}, errors.Wrap(errs.ErrOidcExchangeFailed, err.Error())
+	}
+	if token.Error != "" {
+		return domain.ExternalProfile{}, errors.Wrap(errs.ErrOidcExchangeFailed,
+			fmt.Sprintf("%s: %s", token.Error, token.ErrorDescription))
+	}
+	if token.IDToken == "" {
+		return domain.ExternalProfile{}, errors.Wrap(errs.ErrOidcExchangeFailed, "token response has no id_token")
+	}
+
+	claims, err := p.verifyIDToken(ctx, provider, providerConfig, disc, token.IDToken, request.Nonce)
+	if err != nil {
+		return domain.ExternalProfile{}, errors.Wrap(errs.ErrOidcExchangeFailed, err.Error())
+	}
+
+	return newExternalProfile(provider, claims), nil
+}
+
+func (p *OidcProvider) providerConfig(provider string) (ProviderConfig, error) {
+	providerConfig, ok := p.config.Providers[provider]
+	if !ok {
+		return ProviderConfig{}, errs.ErrUnknownIdentityProvider
+	}
+	return providerConfig, nil
+}
+
+func (p *OidcProvider) discover(ctx context.Context, provider string,
+	providerConfig ProviderConfig) (discovery, error) {
+	p.mu.Lock()
+	disc, ok := p.discoveries[provider]
+	p.mu.Unlock()
+	if ok {
+		return disc, nil
+	}
+
+	issuer := strings.TrimSuffix(providerConfig.Issuer, "/")
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+discoveryPath, nil)
+	if err != nil {
+		return discovery{}, errors.Wrap(errs.ErrOidcExchangeFailed, err.Error())
+	}
+
+	err = p.doJson(request, &disc)
+	if err != nil {
+		return discovery{}, errors.Wrap(errs.ErrOidcExchangeFailed, err.Error())
+	}
+	if strings.TrimSuffix(disc.Issuer, "/") != issuer {
+		return discovery{}, errors.Wrap(errs.ErrOidcExchangeFailed,
+			fmt.Sprintf("discovered issuer %s does not match %s", disc.Issuer, issuer))
+	}
+
+	p.mu.Lock()
+	p.discoveries[provider] = disc
+	p.mu.Unlock()
+	return disc, nil
+}
+
+func (p *OidcProvider) doJson(request *http.Request, v interface{}) error {
+	response, err := p.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	// token endpoint reports errors as json with 400 status
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("unexpected status %d from %s", response.StatusCode, request.URL.String())
+	}
+	return json.Unmarshal(body, v)
+}
+
+func newExternalProfile(provider string, claims idTokenClaims) domain.ExternalProfile {
+	name, surname := claims.GivenName, claims.FamilyName
+	if name == "" && surname == "" {
+		name, surname, _ = strings.Cut(claims.Name, " ")
+	}
+
+	return domain.ExternalProfile{
+		Provider:      provider,
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(claims.Email),
+		EmailVerified: claims.EmailVerified,
+		Name:          name,
+		Surname:       surname,
+	}
+}
+
+func randomString() (string, error) {
+	b := make([]byte, randomSize)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
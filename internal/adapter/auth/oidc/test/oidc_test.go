@@ -0,0 +1,136 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"github.com/paw1a/eschool/internal/adapter/auth/oidc"
+	"github.com/paw1a/eschool/internal/adapter/auth/port"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+const redirectUrl = "http://localhost:3000/oidc/corp/callback"
+
+type memoryRequestStorage struct {
+	mu       sync.Mutex
+	requests map[string]port.AuthRequest
+}
+
+func (s *memoryRequestStorage) Put(state string, request port.AuthRequest, expireTime time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[state] = request
+	return nil
+}
+
+func (s *memoryRequestStorage) Pop(state string) (port.AuthRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	request, ok := s.requests[state]
+	if !ok {
+		return port.AuthRequest{}, errors.New("state is not found")
+	}
+	delete(s.requests, state)
+	return request, nil
+}
+
+func newProvider(t *testing.T, user MockUser) (*oidc.OidcProvider, *MockServer) {
+	server, err := NewMockServer(user)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	provider := oidc.NewOidcProvider(&oidc.Config{
+		StateTime: 600,
+		Providers: map[string]oidc.ProviderConfig{
+			"corp": {
+				Issuer:       server.URL,
+				ClientID:     MockClientID,
+				ClientSecret: MockClientSecret,
+				RedirectUrl:  redirectUrl,
+			},
+		},
+	}, &memoryRequestStorage{requests: make(map[string]port.AuthRequest)})
+	return provider, server
+}
+
+// authorize follows the authorization url and returns code and state
+// which identity provider sends to the redirect url
+func authorize(t *testing.T, authUrl string) (string, string) {
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	response, err := client.Get(authUrl)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusFound, response.StatusCode)
+
+	location, err := url.Parse(response.Header.Get("Location"))
+	require.NoError(t, err)
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func TestOidcAuthorizationCodeFlow(t *testing.T) {
+	provider, _ := newProvider(t, MockUser{
+		Subject:       "42",
+		Email:         "John.Doe@corp.com",
+		EmailVerified: true,
+		GivenName:     "John",
+		FamilyName:    "Doe",
+	})
+
+	authUrl, err := provider.AuthCodeUrl(context.Background(), "corp")
+	require.NoError(t, err)
+	parsedUrl, err := url.Parse(authUrl)
+	require.NoError(t, err)
+	require.Equal(t, "S256", parsedUrl.Query().Get("code_challenge_method"))
+	require.NotEmpty(t, parsedUrl.Query().Get("code_challenge"))
+	require.Equal(t, redirectUrl, parsedUrl.Query().Get("redirect_uri"))
+
+	code, state := authorize(t, authUrl)
+	profile, err := provider.Exchange(context.Background(), "corp", state, code)
+	require.NoError(t, err)
+	require.Equal(t, "corp", profile.Provider)
+	require.Equal(t, "42", profile.Subject)
+	require.Equal(t, "john.doe@corp.com", profile.Email)
+	require.True(t, profile.EmailVerified)
+	require.Equal(t, "John", profile.Name)
+	require.Equal(t, "Doe", profile.Surname)
+}
+
+func TestOidcStateIsSingleUse(t *testing.T) {
+	provider, _ := newProvider(t, MockUser{Subject: "42"})
+
+	authUrl, err := provider.AuthCodeUrl(context.Background(), "corp")
+	require.NoError(t, err)
+	code, state := authorize(t, authUrl)
+
+	_, err = provider.Exchange(context.Background(), "corp", state, code)
+	require.NoError(t, err)
+	_, err = provider.Exchange(context.Background(), "corp", state, code)
+	require.ErrorIs(t, err, errs.ErrInvalidOidcState)
+}
+
+func TestOidcInvalidCode(t *testing.T) {
+	provider, _ := newProvider(t, MockUser{Subject: "42"})
+
+	authUrl, err := provider.AuthCodeUrl(context.Background(), "corp")
+	require.NoError(t, err)
+	_, state := authorize(t, authUrl)
+
+	_, err = provider.Exchange(context.Background(), "corp", state, "invalid code")
+	require.ErrorIs(t, err, errs.ErrOidcExchangeFailed)
+}
+
+func TestOidcUnknownProvider(t *testing.T) {
+	provider, _ := newProvider(t, MockUser{Subject: "42"})
+
+	_, err := provider.AuthCodeUrl(context.Background(), "unknown")
+	require.ErrorIs(t, err, errs.ErrUnknownIdentityProvider)
+}
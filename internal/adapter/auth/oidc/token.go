@@ -0,0 +1,174 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type keySet map[string]*rsa.PublicKey
+
+type idTokenClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	GivenName     string
+	FamilyName    string
+}
+
+func (p *OidcProvider) verifyIDToken(ctx context.Context, provider string, providerConfig ProviderConfig,
+	disc discovery, rawToken, nonce string) (idTokenClaims, error) {
+	token, err := jwt.Parse(rawToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected id token signing method %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return p.publicKey(ctx, provider, disc.JwksUri, kid)
+	})
+	if err != nil {
+		return idTokenClaims{}, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return idTokenClaims{}, fmt.Errorf("invalid id token claims")
+	}
+
+	if !claims.VerifyIssuer(disc.Issuer, true) {
+		return idTokenClaims{}, fmt.Errorf("id token issuer %v is not %s", claims["iss"], disc.Issuer)
+	}
+	if !verifyAudience(claims["aud"], providerConfig.ClientID) {
+		return idTokenClaims{}, fmt.Errorf("id token audience %v does not contain %s",
+			claims["aud"], providerConfig.ClientID)
+	}
+	if claimNonce, _ := claims["nonce"].(string); claimNonce != nonce {
+		return idTokenClaims{}, fmt.Errorf("id token nonce mismatch")
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return idTokenClaims{}, fmt.Errorf("id token has no subject")
+	}
+
+	var result = idTokenClaims{Subject: subject}
+	result.Email, _ = claims["email"].(string)
+	result.Name, _ = claims["name"].(string)
+	result.GivenName, _ = claims["given_name"].(string)
+	result.FamilyName, _ = claims["family_name"].(string)
+	// some providers send email_verified as a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		result.EmailVerified = verified == "true"
+	}
+
+	return result, nil
+}
+
+func verifyAudience(aud interface{}, clientID string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// publicKey returns the provider signing key with the given kid, keys are
+// fetched again when the kid is unknown to pick up rotated keys.
+func (p *OidcProvider) publicKey(ctx context.Context, provider, jwksUri, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	keys := p.keys[provider]
+	p.mu.Unlock()
+	if key, ok := keys.find(kid); ok {
+		return key, nil
+	}
+
+	keys, err := p.fetchKeys(ctx, jwksUri)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.keys[provider] = keys
+	p.mu.Unlock()
+
+	if key, ok := keys.find(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("signing key %s is not found", kid)
+}
+
+func (p *OidcProvider) fetchKeys(ctx context.Context, jwksUri string) (keySet, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksUri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	err = p.doJson(request, &jwks)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(keySet)
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := jwk.rsaPublicKey()
+		if err != nil {
+			return nil, err
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k keySet) find(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(k) == 1 {
+		for _, key := range k {
+			return key, true
+		}
+	}
+	key, ok := k[kid]
+	return key, ok
+}
+
+func (k jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.N, "="))
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.E, "="))
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
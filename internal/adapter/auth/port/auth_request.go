@@ -0,0 +1,14 @@
+package port
+
+import "time"
+
+type AuthRequest struct {
+	Provider     string
+	CodeVerifier string
+	Nonce        string
+}
+
+type IAuthRequestStorage interface {
+	Put(state string, request AuthRequest, expireTime time.Duration) error
+	Pop(state string) (AuthRequest, error)
+}
@@ -16,4 +16,5 @@ type ISessionStorage interface {
 	Get(refreshToken string) (AuthSession, error)
 	Put(refreshToken string, session AuthSession, expireTime time.Duration) error
 	Delete(refreshToken string) error
+	DeleteUserSessions(userID domain.ID) error
 }
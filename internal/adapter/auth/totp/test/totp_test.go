@@ -0,0 +1,50 @@
+package totp
+
+import (
+	"encoding/base32"
+	"github.com/paw1a/eschool/internal/adapter/auth/totp"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B test vectors truncated to 6 digits
+var rfcVectors = []struct {
+	time int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+}
+
+func TestTotpRfcVectors(t *testing.T) {
+	key := []byte("12345678901234567890")
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(key)
+	for _, vector := range rfcVectors {
+		require.Equal(t, vector.code, totp.GenerateCode(key, uint64(vector.time/30)))
+		require.True(t, totp.ValidateCodeAt(secret, vector.code, time.Unix(vector.time, 0)))
+		require.True(t, totp.ValidateCodeAt(secret, vector.code, time.Unix(vector.time+30, 0)))
+		require.False(t, totp.ValidateCodeAt(secret, vector.code, time.Unix(vector.time+90, 0)))
+	}
+}
+
+func TestTotpProvider(t *testing.T) {
+	provider := totp.NewTotpProvider(&totp.Config{Issuer: "eschool"})
+
+	secret, err := provider.GenerateSecret()
+	require.NoError(t, err)
+	require.False(t, provider.ValidateCode(secret, "12345"))
+
+	uri := provider.ProvisioningUri(secret, "user@mail.ru")
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/eschool:user@mail.ru?"))
+	require.Contains(t, uri, "secret="+secret)
+
+	codes, err := provider.GenerateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+	require.NotEqual(t, codes[0], codes[1])
+}
@@ -0,0 +1,110 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	secretSize         = 20
+	period             = 30
+	digits             = 6
+	skew               = 1
+	recoveryCodesCount = 10
+	recoveryCodeSize   = 5
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type Config struct {
+	Issuer string
+}
+
+type TotpProvider struct {
+	config *Config
+}
+
+func NewTotpProvider(config *Config) *TotpProvider {
+	return &TotpProvider{
+		config: config,
+	}
+}
+
+func (p *TotpProvider) GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+func (p *TotpProvider) GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, recoveryCodesCount)
+	for i := range codes {
+		code := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(code); err != nil {
+			return nil, err
+		}
+		codes[i] = strings.ToLower(encoding.EncodeToString(code))
+	}
+	return codes, nil
+}
+
+func (p *TotpProvider) ProvisioningUri(secret, account string) string {
+	label := url.PathEscape(p.config.Issuer + ":" + account)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", p.config.Issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(digits))
+	params.Set("period", fmt.Sprint(period))
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+func (p *TotpProvider) ValidateCode(secret, code string) bool {
+	return ValidateCodeAt(secret, code, time.Now())
+}
+
+func ValidateCodeAt(secret, code string, t time.Time) bool {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != digits {
+		return false
+	}
+
+	counter := t.Unix() / period
+	for i := -skew; i <= skew; i++ {
+		expected := GenerateCode(key, uint64(counter+int64(i)))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateCode computes HOTP value (RFC 4226) for the given counter,
+// TOTP (RFC 6238) uses the number of elapsed periods as the counter.
+func GenerateCode(key []byte, counter uint64) string {
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%modulo)
+}
@@ -22,12 +22,28 @@ func (h *Handler) UserSignIn(c *Console) {
 		Email:       signInDTO.Email,
 		Password:    signInDTO.Password,
 		Fingerprint: signInDTO.Fingerprint,
+		IP:          "console",
 	})
 	if err != nil {
 		ErrorResponse(err)
 		return
 	}
 
+	if authDetails.MfaRequired() {
+		var code string
+		fmt.Print("Two-factor authentication code: ")
+		fmt.Scanln(&code)
+		authDetails, err = h.authService.VerifyMfa(context.Background(), port.MfaVerifyParam{
+			MfaToken:    authDetails.MfaToken,
+			Code:        code,
+			Fingerprint: signInDTO.Fingerprint,
+		})
+		if err != nil {
+			ErrorResponse(err)
+			return
+		}
+	}
+
 	user, err := h.userService.FindByCredentials(context.Background(), port.UserCredentials{
 		Email:    signInDTO.Email,
 		Password: signInDTO.Password,
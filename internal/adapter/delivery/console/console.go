@@ -55,6 +55,25 @@ const (
 
 	findCourseReviews
 	addCourseReview
+
+	changeCourseStatus
+	findCourseTransitions
+
+	createCourseRevision
+	publishCourseRevision
+	migrateCourseVersion
+
+	scheduleCoursePublish
+	updateLessonRelease
+
+	duplicateCourse
+
+	exportCourse
+	importSchoolCourse
+
+	importCourseQuiz
+
+	updateLessonPreview
 )
 
 func NewConsole(lc fx.Lifecycle, handler *Handler, logger *zap.Logger) *Console {
@@ -115,6 +134,25 @@ func (c *Console) InitRoutes() {
 
 		findCourseReviews: c.Handler.FindCourseReviews,
 		addCourseReview:   c.Handler.AddCourseReview,
+
+		changeCourseStatus:    c.Handler.ChangeCourseStatus,
+		findCourseTransitions: c.Handler.FindCourseTransitions,
+
+		createCourseRevision:  c.Handler.CreateCourseRevision,
+		publishCourseRevision: c.Handler.PublishCourseRevision,
+		migrateCourseVersion:  c.Handler.MigrateCourseVersion,
+
+		scheduleCoursePublish: c.Handler.ScheduleCoursePublish,
+		updateLessonRelease:   c.Handler.UpdateLessonRelease,
+
+		duplicateCourse: c.Handler.DuplicateCourse,
+
+		exportCourse:       c.Handler.ExportCourse,
+		importSchoolCourse: c.Handler.ImportSchoolCourse,
+
+		importCourseQuiz: c.Handler.ImportCourseQuiz,
+
+		updateLessonPreview: c.Handler.UpdateLessonPreview,
 	}
 }
 
@@ -179,5 +217,24 @@ func (c *Console) PrintMenu() {
 	fmt.Println("27 Find course reviews")
 	fmt.Println("28 Add course review")
 
+	fmt.Println("29 Change course status")
+	fmt.Println("30 Get course status history")
+
+	fmt.Println("31 Create course revision")
+	fmt.Println("32 Publish course revision")
+	fmt.Println("33 Move to the latest course version")
+
+	fmt.Println("34 Schedule course publishing")
+	fmt.Println("35 Set lesson release")
+
+	fmt.Println("36 Duplicate course")
+
+	fmt.Println("37 Export course to archive")
+	fmt.Println("38 Import course from archive")
+
+	fmt.Println("39 Import QTI quiz to course")
+
+	fmt.Println("40 Set lesson preview")
+
 	fmt.Println("--------------------------------")
 }
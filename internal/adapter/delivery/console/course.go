@@ -7,15 +7,32 @@ import (
 	"github.com/guregu/null"
 	dto2 "github.com/paw1a/eschool/internal/adapter/delivery/console/dto"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/paw1a/eschool/internal/core/port"
 	"io"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 )
 
 func (h *Handler) FindAllCourses(c *Console) {
-	courses, err := h.courseService.FindAll(context.Background())
+	var filterDTO dto2.CourseFilterDTO
+	err := dto2.InputCourseFilterDTO(&filterDTO)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	var courses []domain.Course
+	if filterDTO.CategoryID != "" || filterDTO.Tag != "" {
+		courses, err = h.catalogService.FindCourses(context.Background(), port.CourseFilter{
+			CategoryID: filterDTO.CategoryID,
+			Tag:        filterDTO.Tag,
+		})
+	} else {
+		courses, err = h.courseService.FindAll(context.Background())
+	}
 	if err != nil {
 		ErrorResponse(err)
 		return
@@ -164,13 +181,16 @@ func (h *Handler) PublishCourse(c *Console) {
 		return
 	}
 
-	errList := h.courseService.ConfirmDraftCourse(context.Background(), courseID)
-	if errList != nil {
-		ErrorResponse(errList[0])
+	report, err := h.courseService.ConfirmDraftCourse(context.Background(), *c.UserID, courseID)
+	if len(report.Issues) > 0 {
+		dto2.PrintCourseValidationReport(report)
+	}
+	if err != nil {
+		ErrorResponse(err)
 		return
 	}
 
-	err = h.courseService.PublishReadyCourse(context.Background(), courseID)
+	err = h.courseService.PublishReadyCourse(context.Background(), *c.UserID, courseID)
 	if err != nil {
 		ErrorResponse(err)
 		return
@@ -179,6 +199,305 @@ func (h *Handler) PublishCourse(c *Console) {
 	fmt.Println("course successfully published")
 }
 
+func (h *Handler) ChangeCourseStatus(c *Console) {
+	err := h.verifyAuth(c)
+	if err != nil {
+		ErrorResponse(UnauthorizedError)
+		return
+	}
+
+	var courseID domain.ID
+	err = dto2.InputID(&courseID, "course")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	if !h.verifyCourseWriteAccess(c, courseID) {
+		fmt.Println("you are not a course teacher")
+		return
+	}
+
+	var status domain.CourseStatus
+	err = dto2.InputCourseStatus(&status)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	err = h.courseService.ChangeCourseStatus(context.Background(), *c.UserID, courseID, status)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	fmt.Printf("course status successfully changed to %s\n", status)
+}
+
+func (h *Handler) FindCourseTransitions(c *Console) {
+	err := h.verifyAuth(c)
+	if err != nil {
+		ErrorResponse(UnauthorizedError)
+		return
+	}
+
+	var courseID domain.ID
+	err = dto2.InputID(&courseID, "course")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	if !h.verifyCourseWriteAccess(c, courseID) {
+		fmt.Println("you are not a course teacher")
+		return
+	}
+
+	transitions, err := h.courseService.FindCourseTransitions(context.Background(), courseID)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	for _, transition := range transitions {
+		dto2.PrintCourseTransitionDTO(dto2.NewCourseTransitionDTO(transition))
+	}
+}
+
+func (h *Handler) CreateCourseRevision(c *Console) {
+	err := h.verifyAuth(c)
+	if err != nil {
+		ErrorResponse(UnauthorizedError)
+		return
+	}
+
+	var courseID domain.ID
+	err = dto2.InputID(&courseID, "course")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	if !h.verifyCourseWriteAccess(c, courseID) {
+		fmt.Println("you are not a course teacher")
+		return
+	}
+
+	course, err := h.courseService.CreateCourseRevision(context.Background(), courseID)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	fmt.Printf("course revision %d successfully created\n", course.Version)
+}
+
+func (h *Handler) PublishCourseRevision(c *Console) {
+	err := h.verifyAuth(c)
+	if err != nil {
+		ErrorResponse(UnauthorizedError)
+		return
+	}
+
+	var courseID domain.ID
+	err = dto2.InputID(&courseID, "course")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	if !h.verifyCourseWriteAccess(c, courseID) {
+		fmt.Println("you are not a course teacher")
+		return
+	}
+
+	err = h.courseService.PublishCourseRevision(context.Background(), courseID)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	fmt.Println("course revision successfully published")
+}
+
+func (h *Handler) MigrateCourseVersion(c *Console) {
+	err := h.verifyAuth(c)
+	if err != nil {
+		ErrorResponse(UnauthorizedError)
+		return
+	}
+
+	var courseID domain.ID
+	err = dto2.InputID(&courseID, "course")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	enrollment, err := h.courseService.MigrateStudentVersion(context.Background(), *c.UserID, courseID)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	fmt.Printf("you successfully moved to course version %d\n", enrollment.Version)
+}
+
+func (h *Handler) ScheduleCoursePublish(c *Console) {
+	err := h.verifyAuth(c)
+	if err != nil {
+		ErrorResponse(UnauthorizedError)
+		return
+	}
+
+	var courseID domain.ID
+	err = dto2.InputID(&courseID, "course")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	if !h.verifyCourseWriteAccess(c, courseID) {
+		fmt.Println("you are not a course teacher")
+		return
+	}
+
+	var publishAt null.Time
+	err = dto2.InputPublishAt(&publishAt)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	course, err := h.courseService.ScheduleCoursePublish(context.Background(), courseID, publishAt)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	if !course.PublishAt.Valid {
+		fmt.Println("course publishing successfully cancelled")
+		return
+	}
+
+	job := domain.NewJob(domain.JobPublishCourse, course.ID, *c.UserID, course.PublishAt.Time)
+	err = h.jobService.ScheduleJob(context.Background(), job)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	fmt.Printf("course is scheduled to publish at %s UTC\n", course.PublishAt.Time.Format(time.DateTime))
+}
+
+func (h *Handler) DuplicateCourse(c *Console) {
+	err := h.verifyAuth(c)
+	if err != nil {
+		ErrorResponse(UnauthorizedError)
+		return
+	}
+
+	var courseID domain.ID
+	err = dto2.InputID(&courseID, "course")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	if !h.verifyCourseWriteAccess(c, courseID) {
+		fmt.Println("you are not a course teacher")
+		return
+	}
+
+	course, err := h.courseService.FindByID(context.Background(), courseID)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	schoolID := course.SchoolID
+	err = dto2.InputOptionalID(&schoolID, "target school")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	isTeacher, err := h.schoolService.IsSchoolTeacher(context.Background(), schoolID, *c.UserID)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+	if !isTeacher {
+		fmt.Println("you are not a teacher of the target school")
+		return
+	}
+
+	duplicate, err := h.courseService.DuplicateCourse(context.Background(), courseID, schoolID)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	_, err = h.lessonService.CopyCourseLessons(context.Background(), courseID, duplicate.ID)
+	if err != nil {
+		h.courseService.Delete(context.Background(), duplicate.ID)
+		ErrorResponse(err)
+		return
+	}
+
+	err = h.courseService.AddCourseTeacher(context.Background(), *c.UserID, duplicate.ID)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	dto2.PrintCourseDTO(dto2.NewCourseDTO(duplicate))
+}
+
+// ExportCourse saves the course archive to the local file
+func (h *Handler) ExportCourse(c *Console) {
+	err := h.verifyAuth(c)
+	if err != nil {
+		ErrorResponse(UnauthorizedError)
+		return
+	}
+
+	var courseID domain.ID
+	err = dto2.InputID(&courseID, "course")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	if !h.verifyCourseWriteAccess(c, courseID) {
+		fmt.Println("you are not a course teacher")
+		return
+	}
+
+	var path string
+	err = dto2.InputFilePath(&path, "archive")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+	defer file.Close()
+
+	err = h.archiveService.ExportCourse(context.Background(), courseID, file)
+	if err != nil {
+		os.Remove(path)
+		ErrorResponse(err)
+		return
+	}
+
+	fmt.Printf("course is exported to %s\n", path)
+}
+
 func (h *Handler) FindCourseLessons(c *Console) {
 	err := h.verifyAuth(c)
 	if err != nil {
@@ -198,7 +517,16 @@ func (h *Handler) FindCourseLessons(c *Console) {
 		return
 	}
 
-	lessons, err := h.lessonService.FindCourseLessons(context.Background(), courseID)
+	// students see the version they study, teachers see the edited one
+	var lessons []domain.Lesson
+	enrollment, err := h.courseService.FindEnrollment(context.Background(), *c.UserID, courseID)
+	student := err == nil && !h.checkCurrentUserIsCourseTeacher(c, courseID)
+	if student {
+		lessons, err = h.lessonService.FindCourseVersionLessons(context.Background(),
+			courseID, enrollment.Version)
+	} else {
+		lessons, err = h.lessonService.FindCourseLessons(context.Background(), courseID)
+	}
 	if err != nil {
 		ErrorResponse(err)
 		return
@@ -209,8 +537,13 @@ func (h *Handler) FindCourseLessons(c *Console) {
 		return
 	}
 
+	now := time.Now()
 	for _, lesson := range lessons {
-		dto2.PrintLessonDTO(dto2.NewLessonDTO(lesson))
+		if student && !lesson.Released(enrollment, now) {
+			dto2.PrintLessonDTO(dto2.NewLockedLessonDTO(lesson))
+		} else {
+			dto2.PrintLessonDTO(dto2.NewLessonDTO(lesson))
+		}
 		fmt.Println()
 	}
 }
@@ -300,6 +633,152 @@ func (h *Handler) CreateCourseLesson(c *Console) {
 	dto2.PrintLessonDTO(lessonDTO)
 }
 
+// ImportCourseQuiz creates practice lessons from the local QTI file or package
+func (h *Handler) ImportCourseQuiz(c *Console) {
+	err := h.verifyAuth(c)
+	if err != nil {
+		ErrorResponse(UnauthorizedError)
+		return
+	}
+
+	var courseID domain.ID
+	err = dto2.InputID(&courseID, "course")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	if !h.verifyCourseWriteAccess(c, courseID) {
+		fmt.Println("you are not a course teacher")
+		return
+	}
+
+	var path string
+	err = dto2.InputFilePath(&path, "quiz")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	quiz, err := h.quizService.ParseQuiz(context.Background(), file, info.Size())
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	for _, param := range quiz.Lessons {
+		lesson, err := h.lessonService.CreatePracticeLesson(context.Background(), courseID, param)
+		if err != nil {
+			ErrorResponse(err)
+			return
+		}
+		dto2.PrintLessonDTO(dto2.NewLessonDTO(lesson))
+		fmt.Println()
+	}
+	for _, item := range quiz.Skipped {
+		fmt.Printf("skipped item %s %q: %s\n", item.Identifier, item.Title, item.Reason)
+	}
+}
+
+func (h *Handler) UpdateLessonRelease(c *Console) {
+	err := h.verifyAuth(c)
+	if err != nil {
+		ErrorResponse(UnauthorizedError)
+		return
+	}
+
+	var lessonID domain.ID
+	err = dto2.InputID(&lessonID, "lesson")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	lesson, err := h.lessonService.FindByID(context.Background(), lessonID)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	if !h.verifyCourseWriteAccess(c, lesson.CourseID) {
+		ErrorResponse(ForbiddenError)
+		return
+	}
+
+	var releaseDTO dto2.UpdateLessonReleaseDTO
+	err = dto2.InputUpdateLessonReleaseDTO(&releaseDTO)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	lesson, err = h.lessonService.UpdateLessonRelease(context.Background(), lessonID,
+		port.UpdateLessonReleaseParam{
+			ReleaseAt:   releaseDTO.ReleaseAt,
+			ReleaseDays: releaseDTO.ReleaseDays,
+		})
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	dto2.PrintLessonDTO(dto2.NewLessonDTO(lesson))
+}
+
+func (h *Handler) UpdateLessonPreview(c *Console) {
+	err := h.verifyAuth(c)
+	if err != nil {
+		ErrorResponse(UnauthorizedError)
+		return
+	}
+
+	var lessonID domain.ID
+	err = dto2.InputID(&lessonID, "lesson")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	lesson, err := h.lessonService.FindByID(context.Background(), lessonID)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	if !h.verifyCourseWriteAccess(c, lesson.CourseID) {
+		ErrorResponse(ForbiddenError)
+		return
+	}
+
+	var previewDTO dto2.UpdateLessonPreviewDTO
+	err = dto2.InputUpdateLessonPreviewDTO(&previewDTO)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	lesson, err = h.lessonService.UpdateLessonPreview(context.Background(), lessonID, previewDTO.IsPreview)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	dto2.PrintLessonDTO(dto2.NewLessonDTO(lesson))
+}
+
 func (h *Handler) AddCourseReview(c *Console) {
 	err := h.verifyAuth(c)
 	if err != nil {
@@ -422,11 +901,17 @@ func (h *Handler) PassCourseLesson(c *Console) {
 		return
 	}
 
-	if course.Status != domain.CoursePublished {
+	if !course.Status.Learnable() {
 		fmt.Println("course is not published yet")
 		return
 	}
 
+	enrollment, err := h.courseService.FindEnrollment(context.Background(), userID, lesson.CourseID)
+	if err == nil && !lesson.Released(enrollment, time.Now()) {
+		ErrorResponse(errs.ErrLessonNotReleased)
+		return
+	}
+
 	switch lesson.Type {
 	case domain.TheoryLesson:
 		fallthrough
@@ -464,7 +949,7 @@ func (h *Handler) PassCourseLesson(c *Console) {
 			fmt.Println()
 
 			var newScore int
-			if answer == test.Answer {
+			if test.Check(answer) {
 				newScore = lesson.Tests[i].Score
 			}
 
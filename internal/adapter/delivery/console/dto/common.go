@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"bufio"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/paw1a/eschool/internal/core/domain"
@@ -8,6 +9,8 @@ import (
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"net/mail"
+	"os"
+	"strings"
 )
 
 func InputEmail(email *string) error {
@@ -22,6 +25,22 @@ func InputEmail(email *string) error {
 	return nil
 }
 
+// InputOptionalID keeps the current id when the input is empty
+func InputOptionalID(id *domain.ID, idOwner string) error {
+	fmt.Printf("%s ID (empty to keep %s): ", cases.Title(language.Und, cases.NoLower).String(idOwner), *id)
+	var input string
+	fmt.Scanln(&input)
+	if input == "" {
+		return nil
+	}
+	_, err := uuid.Parse(input)
+	if err != nil {
+		return errors.New("invalid uuid format")
+	}
+	*id = domain.ID(input)
+	return nil
+}
+
 func InputID(id *domain.ID, idOwner string) error {
 	fmt.Printf("%s ID: ", cases.Title(language.Und, cases.NoLower).String(idOwner))
 	var input string
@@ -33,3 +52,16 @@ func InputID(id *domain.ID, idOwner string) error {
 	*id = domain.ID(input)
 	return nil
 }
+
+// InputFilePath reads the path of the local file, path may contain spaces
+func InputFilePath(path *string, fileOwner string) error {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Printf("%s file path: ", cases.Title(language.Und, cases.NoLower).String(fileOwner))
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return errors.New("file path is empty")
+	}
+	*path = input
+	return nil
+}
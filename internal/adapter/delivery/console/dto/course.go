@@ -3,24 +3,32 @@ package dto
 import (
 	"bufio"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/guregu/null"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/pkg/errors"
 	"os"
 	"strings"
+	"time"
 )
 
 const (
-	CourseDTODraft     = "draft"
-	CourseDTOReady     = "ready"
-	CourseDTOPublished = "published"
+	CourseDTODraft       = "draft"
+	CourseDTOReady       = "ready"
+	CourseDTOPublished   = "published"
+	CourseDTOUnpublished = "unpublished"
+	CourseDTOArchived    = "archived"
 )
 
 type CreateCourseDTO struct {
-	Name     string
-	Level    null.Int
-	Price    null.Int
-	Language string
+	Name       string
+	Level      null.Int
+	Price      null.Int
+	Currency   string
+	Language   string
+	Capacity   null.Int
+	AccessDays null.Int
 }
 
 func InputCreateCourseDTO(d *CreateCourseDTO) error {
@@ -38,10 +46,25 @@ func InputCreateCourseDTO(d *CreateCourseDTO) error {
 	fmt.Scanf("%d", &level)
 	d.Level = null.IntFrom(level)
 
-	var price int64
-	fmt.Print("Price: ")
-	fmt.Scanf("%d", &price)
-	d.Price = null.IntFrom(price)
+	var currency string
+	fmt.Printf("Currency (empty for %s): ", domain.DefaultCurrency)
+	fmt.Scanln(&currency)
+	d.Currency = domain.DefaultCurrency
+	if currency != "" {
+		d.Currency = strings.ToUpper(currency)
+	}
+	if !domain.SupportedCurrency(d.Currency) {
+		return errs.ErrCourseInvalidCurrency
+	}
+
+	var price string
+	fmt.Print("Price (e.g. 3990.00): ")
+	fmt.Scanln(&price)
+	amount, err := domain.ParseAmount(price, d.Currency)
+	if err != nil {
+		return err
+	}
+	d.Price = null.IntFrom(amount)
 
 	var language string
 	fmt.Print("Language: ")
@@ -51,18 +74,34 @@ func InputCreateCourseDTO(d *CreateCourseDTO) error {
 	}
 	d.Language = language
 
+	var capacity int64
+	fmt.Print("Capacity (empty for unlimited): ")
+	if _, err := fmt.Scanf("%d", &capacity); err == nil {
+		d.Capacity = null.IntFrom(capacity)
+	}
+
+	var accessDays int64
+	fmt.Print("Access days (empty for lifetime access): ")
+	if _, err := fmt.Scanf("%d", &accessDays); err == nil {
+		d.AccessDays = null.IntFrom(accessDays)
+	}
+
 	fmt.Println()
 	return nil
 }
 
 type UpdateCourseDTO struct {
-	Name     null.String
-	Level    null.Int
-	Price    null.Int
-	Language null.String
+	Name       null.String
+	Level      null.Int
+	Price      null.Int
+	Currency   null.String
+	Language   null.String
+	Capacity   null.Int
+	AccessDays null.Int
 }
 
-func InputUpdateCourseDTO(d *UpdateCourseDTO) error {
+// InputUpdateCourseDTO reads the price in the current course currency unless it is changed
+func InputUpdateCourseDTO(d *UpdateCourseDTO, currentCurrency string) error {
 	var name string
 	fmt.Print("Course name: ")
 	fmt.Scanln(&name)
@@ -78,13 +117,27 @@ func InputUpdateCourseDTO(d *UpdateCourseDTO) error {
 	}
 	d.Level = null.IntFrom(level)
 
-	var price int64
-	fmt.Print("Price: ")
-	_, err = fmt.Scanf("%d", &price)
-	if err != nil {
-		return errors.New("invalid number")
+	var currency string
+	fmt.Print("Currency (empty to keep): ")
+	fmt.Scanln(&currency)
+	if currency != "" {
+		currentCurrency = strings.ToUpper(currency)
+		if !domain.SupportedCurrency(currentCurrency) {
+			return errs.ErrCourseInvalidCurrency
+		}
+		d.Currency = null.StringFrom(currentCurrency)
+	}
+
+	var price string
+	fmt.Print("Price (empty to keep): ")
+	fmt.Scanln(&price)
+	if price != "" {
+		amount, err := domain.ParseAmount(price, currentCurrency)
+		if err != nil {
+			return err
+		}
+		d.Price = null.IntFrom(amount)
 	}
-	d.Price = null.IntFrom(price)
 
 	var language string
 	fmt.Print("Language: ")
@@ -93,18 +146,36 @@ func InputUpdateCourseDTO(d *UpdateCourseDTO) error {
 		d.Language = null.StringFrom(language)
 	}
 
+	var capacity int64
+	fmt.Print("Capacity (empty to keep): ")
+	if _, err := fmt.Scanf("%d", &capacity); err == nil {
+		d.Capacity = null.IntFrom(capacity)
+	}
+
+	var accessDays int64
+	fmt.Print("Access days (empty to keep): ")
+	if _, err := fmt.Scanf("%d", &accessDays); err == nil {
+		d.AccessDays = null.IntFrom(accessDays)
+	}
+
 	fmt.Println()
 	return nil
 }
 
 type CourseDTO struct {
-	ID       string
-	SchoolID string
-	Name     string
-	Level    int
-	Price    int64
-	Language string
-	Status   string
+	ID         string
+	SchoolID   string
+	Name       string
+	Level      int
+	Price      string
+	Language   string
+	Status     string
+	Capacity   null.Int
+	AccessDays null.Int
+
+	Version          int
+	PublishedVersion int
+	PublishAt        null.Time
 }
 
 func NewCourseDTO(course domain.Course) CourseDTO {
@@ -116,16 +187,26 @@ func NewCourseDTO(course domain.Course) CourseDTO {
 		status = CourseDTOReady
 	case domain.CoursePublished:
 		status = CourseDTOPublished
+	case domain.CourseUnpublished:
+		status = CourseDTOUnpublished
+	case domain.CourseArchived:
+		status = CourseDTOArchived
 	}
 
 	return CourseDTO{
-		ID:       course.ID.String(),
-		SchoolID: course.SchoolID.String(),
-		Name:     course.Name,
-		Level:    course.Level,
-		Price:    course.Price,
-		Language: course.Language,
-		Status:   status,
+		ID:         course.ID.String(),
+		SchoolID:   course.SchoolID.String(),
+		Name:       course.Name,
+		Level:      course.Level,
+		Price:      course.Price.Format(domain.DefaultLocale),
+		Language:   course.Language,
+		Status:     status,
+		Capacity:   course.Capacity,
+		AccessDays: course.AccessDays,
+
+		Version:          course.Version,
+		PublishedVersion: course.PublishedVersion,
+		PublishAt:        course.PublishAt,
 	}
 }
 
@@ -133,8 +214,127 @@ func PrintCourseDTO(d CourseDTO) {
 	fmt.Printf("ID: %s\n", d.ID)
 	fmt.Printf("School ID: %s\n", d.SchoolID)
 	fmt.Printf("Name: %s\n", d.Name)
-	fmt.Printf("Price: %d\n", d.Price)
+	fmt.Printf("Price: %s\n", d.Price)
 	fmt.Printf("Level: %d\n", d.Level)
 	fmt.Printf("Language: %s\n", d.Language)
 	fmt.Printf("Status: %s\n", d.Status)
+	if d.Capacity.Valid {
+		fmt.Printf("Capacity: %d\n", d.Capacity.Int64)
+	}
+	if d.AccessDays.Valid {
+		fmt.Printf("Access days: %d\n", d.AccessDays.Int64)
+	}
+	fmt.Printf("Version: %d (published %d)\n", d.Version, d.PublishedVersion)
+	if d.PublishAt.Valid {
+		fmt.Printf("Publish at: %s UTC\n", d.PublishAt.Time.Format(time.DateTime))
+	}
+}
+
+// InputPublishAt reads the date in UTC, empty date cancels the schedule
+func InputPublishAt(publishAt *null.Time) error {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Print("Publish at (YYYY-MM-DD HH:MM:SS UTC, empty to cancel): ")
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		*publishAt = null.Time{}
+		return nil
+	}
+
+	parsed, err := time.ParseInLocation(time.DateTime, input, time.UTC)
+	if err != nil {
+		return errors.New("invalid date format")
+	}
+	*publishAt = null.TimeFrom(parsed)
+	return nil
+}
+
+func InputCourseStatus(status *domain.CourseStatus) error {
+	fmt.Printf("Status (%s, %s, %s, %s, %s): ", CourseDTODraft, CourseDTOReady,
+		CourseDTOPublished, CourseDTOUnpublished, CourseDTOArchived)
+	var input string
+	fmt.Scanln(&input)
+	parsed, ok := domain.ParseCourseStatus(strings.TrimSpace(input))
+	if !ok {
+		return errors.New("unknown course status")
+	}
+	*status = parsed
+	return nil
+}
+
+type CourseTransitionDTO struct {
+	From      string
+	To        string
+	UserID    string
+	CreatedAt time.Time
+}
+
+func NewCourseTransitionDTO(transition domain.CourseTransition) CourseTransitionDTO {
+	return CourseTransitionDTO{
+		From:      transition.From.String(),
+		To:        transition.To.String(),
+		UserID:    transition.UserID.String(),
+		CreatedAt: transition.CreatedAt,
+	}
+}
+
+func PrintCourseTransitionDTO(d CourseTransitionDTO) {
+	fmt.Printf("%s: %s -> %s by %s\n", d.CreatedAt.Format(time.DateTime), d.From, d.To, d.UserID)
+}
+
+type ValidationIssueDTO struct {
+	Code     string
+	Severity string
+	LessonID string
+	TestID   string
+	Message  string
+}
+
+func NewValidationIssueDTO(issue domain.ValidationIssue) ValidationIssueDTO {
+	return ValidationIssueDTO{
+		Code:     issue.Code,
+		Severity: issue.Severity.String(),
+		LessonID: issue.LessonID.String(),
+		TestID:   issue.TestID.String(),
+		Message:  issue.Message,
+	}
+}
+
+func PrintCourseValidationReport(report domain.CourseValidationReport) {
+	fmt.Printf("Validation: %d errors, %d warnings\n",
+		report.Count(domain.ValidationError), report.Count(domain.ValidationWarning))
+	for _, issue := range report.Issues {
+		d := NewValidationIssueDTO(issue)
+		fmt.Printf("[%s] %s: %s\n", d.Severity, d.Code, d.Message)
+		if d.LessonID != "" {
+			fmt.Printf("\tlesson: %s\n", d.LessonID)
+		}
+		if d.TestID != "" {
+			fmt.Printf("\ttest: %s\n", d.TestID)
+		}
+	}
+}
+
+type CourseFilterDTO struct {
+	CategoryID domain.ID
+	Tag        string
+}
+
+// InputCourseFilterDTO reads the optional filter, empty filter lists all courses
+func InputCourseFilterDTO(d *CourseFilterDTO) error {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Print("Category ID (empty to skip): ")
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input != "" {
+		if _, err := uuid.Parse(input); err != nil {
+			return errors.New("invalid uuid format")
+		}
+		d.CategoryID = domain.ID(input)
+	}
+
+	fmt.Print("Tag (empty to skip): ")
+	input, _ = reader.ReadString('\n')
+	d.Tag = strings.TrimSpace(input)
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"os"
 	"strings"
+	"time"
 )
 
 const (
@@ -135,6 +136,48 @@ type UpdateLessonDTO struct {
 	Tests    []CreateTestDTO
 }
 
+type UpdateLessonReleaseDTO struct {
+	ReleaseAt   null.Time
+	ReleaseDays null.Int
+}
+
+// InputUpdateLessonReleaseDTO reads one of the release rules,
+// lesson without both rules is released at once
+func InputUpdateLessonReleaseDTO(d *UpdateLessonReleaseDTO) error {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Print("Release at (YYYY-MM-DD HH:MM:SS UTC, empty to skip): ")
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input != "" {
+		releaseAt, err := time.ParseInLocation(time.DateTime, input, time.UTC)
+		if err != nil {
+			return errors.New("invalid date format")
+		}
+		d.ReleaseAt = null.TimeFrom(releaseAt)
+		return nil
+	}
+
+	var days int64
+	fmt.Print("Release days after enrollment (empty to skip): ")
+	if _, err := fmt.Scanf("%d", &days); err == nil {
+		d.ReleaseDays = null.IntFrom(days)
+	}
+	return nil
+}
+
+type UpdateLessonPreviewDTO struct {
+	IsPreview bool
+}
+
+// InputUpdateLessonPreviewDTO reads the preview flag, any answer but y unmarks the lesson
+func InputUpdateLessonPreviewDTO(d *UpdateLessonPreviewDTO) error {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Print("Show lesson as course preview (y/n): ")
+	input, _ := reader.ReadString('\n')
+	d.IsPreview = strings.EqualFold(strings.TrimSpace(input), "y")
+	return nil
+}
+
 type PassLessonDTO struct {
 	LessonID  string
 	PassTests []PassTestDTO
@@ -155,6 +198,11 @@ type LessonDTO struct {
 	TheoryUrl null.String
 	VideoUrl  null.String
 	Tests     []TestDTO
+
+	ReleaseAt   null.Time
+	ReleaseDays null.Int
+	Locked      bool
+	IsPreview   bool
 }
 
 func PrintLessonDTO(d LessonDTO) {
@@ -163,6 +211,19 @@ func PrintLessonDTO(d LessonDTO) {
 	fmt.Printf("Title: %s\n", d.Title)
 	fmt.Printf("Score: %d\n", d.Score)
 	fmt.Printf("Type: %s\n", d.Type)
+	if d.ReleaseAt.Valid {
+		fmt.Printf("Release at: %s UTC\n", d.ReleaseAt.Time.Format(time.DateTime))
+	}
+	if d.ReleaseDays.Valid {
+		fmt.Printf("Release days after enrollment: %d\n", d.ReleaseDays.Int64)
+	}
+	if d.IsPreview {
+		fmt.Println("Preview: lesson is readable by anyone")
+	}
+	if d.Locked {
+		fmt.Println("Locked: lesson is not released yet")
+		return
+	}
 	switch d.Type {
 	case LessonDTOTheory:
 		fmt.Printf("TheoryUrl: %s\n", d.TheoryUrl.String)
@@ -225,9 +286,19 @@ func NewLessonDTO(lesson domain.Lesson) LessonDTO {
 		TheoryUrl: lesson.TheoryUrl,
 		VideoUrl:  lesson.VideoUrl,
 		Tests:     tests,
+
+		ReleaseAt:   lesson.ReleaseAt,
+		ReleaseDays: lesson.ReleaseDays,
+		IsPreview:   lesson.IsPreview,
 	}
 }
 
+func NewLockedLessonDTO(lesson domain.Lesson) LessonDTO {
+	lessonDTO := NewLessonDTO(lesson.Lock())
+	lessonDTO.Locked = true
+	return lessonDTO
+}
+
 func NewTestDTO(test domain.Test) TestDTO {
 	return TestDTO{
 		ID:       test.ID.String(),
@@ -16,6 +16,10 @@ type Handler struct {
 	statService    port.IStatService
 	authService    port.IAuthTokenService
 	paymentService port.IPaymentService
+	jobService     port.IJobService
+	archiveService port.ICourseArchiveService
+	quizService    port.IQuizImportService
+	catalogService port.ICatalogService
 }
 
 type HandlerParams struct {
@@ -29,6 +33,10 @@ type HandlerParams struct {
 	StatService    port.IStatService
 	AuthService    port.IAuthTokenService
 	PaymentService port.IPaymentService
+	JobService     port.IJobService
+	ArchiveService port.ICourseArchiveService
+	QuizService    port.IQuizImportService
+	CatalogService port.ICatalogService
 }
 
 func NewHandler(params HandlerParams) *Handler {
@@ -42,6 +50,10 @@ func NewHandler(params HandlerParams) *Handler {
 		statService:    params.StatService,
 		authService:    params.AuthService,
 		paymentService: params.PaymentService,
+		jobService:     params.JobService,
+		archiveService: params.ArchiveService,
+		quizService:    params.QuizService,
+		catalogService: params.CatalogService,
 	}
 }
 
@@ -173,10 +173,13 @@ func (h *Handler) CreateSchoolCourse(c *Console) {
 
 	course, err := h.courseService.CreateSchoolCourse(context.Background(),
 		schoolID, port.CreateCourseParam{
-			Name:     createCourseDTO.Name,
-			Level:    int(createCourseDTO.Level.Int64),
-			Price:    createCourseDTO.Price.Int64,
-			Language: createCourseDTO.Language,
+			Name:       createCourseDTO.Name,
+			Level:      int(createCourseDTO.Level.Int64),
+			Price:      createCourseDTO.Price.Int64,
+			Currency:   createCourseDTO.Currency,
+			Language:   createCourseDTO.Language,
+			Capacity:   createCourseDTO.Capacity,
+			AccessDays: createCourseDTO.AccessDays,
 		})
 	if err != nil {
 		ErrorResponse(err)
@@ -219,7 +222,7 @@ func (h *Handler) UpdateSchoolCourse(c *Console) {
 	}
 
 	var updateCourseDTO dto2.UpdateCourseDTO
-	err = dto2.InputUpdateCourseDTO(&updateCourseDTO)
+	err = dto2.InputUpdateCourseDTO(&updateCourseDTO, course.Price.Currency)
 	if err != nil {
 		ErrorResponse(err)
 		return
@@ -227,10 +230,13 @@ func (h *Handler) UpdateSchoolCourse(c *Console) {
 
 	updatedCourse, err := h.courseService.Update(context.Background(),
 		courseID, port.UpdateCourseParam{
-			Name:     updateCourseDTO.Name,
-			Level:    updateCourseDTO.Level,
-			Price:    updateCourseDTO.Price,
-			Language: updateCourseDTO.Language,
+			Name:       updateCourseDTO.Name,
+			Level:      updateCourseDTO.Level,
+			Price:      updateCourseDTO.Price,
+			Currency:   updateCourseDTO.Currency,
+			Language:   updateCourseDTO.Language,
+			Capacity:   updateCourseDTO.Capacity,
+			AccessDays: updateCourseDTO.AccessDays,
 		})
 	if err != nil {
 		ErrorResponse(err)
@@ -334,6 +340,61 @@ func (h *Handler) AddSchoolTeacher(c *Console) {
 	fmt.Println("successfully added teacher")
 }
 
+// ImportSchoolCourse creates the draft school course from the local archive file
+func (h *Handler) ImportSchoolCourse(c *Console) {
+	err := h.verifyAuth(c)
+	if err != nil {
+		ErrorResponse(UnauthorizedError)
+		return
+	}
+
+	var schoolID domain.ID
+	err = dto2.InputID(&schoolID, "school")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	if !h.verifySchoolOwner(c, schoolID) {
+		ErrorResponse(ForbiddenError)
+		return
+	}
+
+	var path string
+	err = dto2.InputFilePath(&path, "archive")
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	course, err := h.archiveService.ImportCourse(context.Background(), schoolID, file, info.Size())
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	err = h.courseService.AddCourseTeacher(context.Background(), *c.UserID, course.ID)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
+	dto2.PrintCourseDTO(dto2.NewCourseDTO(course))
+}
+
 func (h *Handler) verifySchoolOwner(c *Console, schoolID domain.ID) bool {
 	return h.checkCurrentUserIsSchoolOwner(c, schoolID)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	dto2 "github.com/paw1a/eschool/internal/adapter/delivery/console/dto"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/paw1a/eschool/internal/core/port"
 )
 
@@ -93,11 +94,22 @@ func (h *Handler) AddUserFreeCourse(c *Console) {
 		return
 	}
 
-	if course.Price > 0 {
+	if course.Price.Amount > 0 {
 		ErrorResponse(ForbiddenError)
 		return
 	}
 
+	if !course.Status.Listed() {
+		ErrorResponse(errs.ErrCourseNotForSale)
+		return
+	}
+
+	err = h.courseService.CheckCoursePrerequisites(context.Background(), userID, courseID)
+	if err != nil {
+		ErrorResponse(err)
+		return
+	}
+
 	err = h.courseService.AddCourseStudent(context.Background(), userID, courseID)
 	if err != nil {
 		ErrorResponse(err)
@@ -5,6 +5,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/paw1a/eschool/internal/core/port"
 	"net/http"
 	"strings"
@@ -15,8 +16,17 @@ func (h *Handler) initAuthRoutes(api *gin.RouterGroup) {
 	{
 		authGroup.POST("/sign-in", h.userSignIn)
 		authGroup.POST("/logout", h.userLogout)
-		authGroup.POST("/sign-up", h.userSignUp)
+		authGroup.POST("/sign-up",
+			RateLimitMiddleware(h.rateLimiter, "sign-up", h.logger), h.userSignUp)
 		authGroup.POST("/refresh", h.userRefresh)
+		authGroup.POST("/verify-email", h.userVerifyEmail)
+		authGroup.POST("/forgot-password",
+			RateLimitMiddleware(h.rateLimiter, "forgot-password", h.logger), h.userForgotPassword)
+		authGroup.POST("/reset-password",
+			RateLimitMiddleware(h.rateLimiter, "reset-password", h.logger), h.userResetPassword)
+		authGroup.POST("/mfa/verify", h.userVerifyMfa)
+		authGroup.GET("/oidc/:provider", h.userOidcLogin)
+		authGroup.POST("/oidc/:provider/callback", h.userOidcCallback)
 	}
 }
 
@@ -28,8 +38,10 @@ func (h *Handler) initAuthRoutes(api *gin.RouterGroup) {
 // @Param input body dto.SignInDTO true "credentials"
 // @Failure 400 {object} RestErrorBadRequest
 // @Failure 401 {object} RestErrorUnauthorized
+// @Failure 429 {object} RestErrorTooManyRequests
 // @Failure 500 {object} RestErrorInternalError
 // @Success 200 {string} string "auth token"
+// @Success 202 {object} dto.MfaChallengeDTO
 // @Router /auth/sign-in [post]
 func (h *Handler) userSignIn(context *gin.Context) {
 	var signInDTO dto.SignInDTO
@@ -43,12 +55,20 @@ func (h *Handler) userSignIn(context *gin.Context) {
 		Email:       signInDTO.Email,
 		Password:    signInDTO.Password,
 		Fingerprint: signInDTO.Fingerprint,
+		IP:          context.ClientIP(),
 	})
 	if err != nil {
 		h.errorResponse(context, err)
 		return
 	}
 
+	if authDetails.MfaRequired() {
+		context.JSON(http.StatusAccepted, dto.MfaChallengeDTO{
+			MfaToken: authDetails.MfaToken.String(),
+		})
+		return
+	}
+
 	context.SetSameSite(http.SameSiteLaxMode)
 	context.SetCookie("refreshToken", authDetails.RefreshToken.String(),
 		86400, "/", h.config.Host, false, false)
@@ -64,6 +84,7 @@ func (h *Handler) userSignIn(context *gin.Context) {
 // @Param input body dto.SignUpDTO true "user information"
 // @Failure 400 {object} RestErrorBadRequest
 // @Failure 409 {object} RestErrorConflict
+// @Failure 429 {object} RestErrorTooManyRequests
 // @Failure 500 {object} RestErrorInternalError
 // @Success 201 {string} string "message"
 // @Router /auth/sign-up [post]
@@ -133,6 +154,195 @@ func (h *Handler) userLogout(context *gin.Context) {
 	h.successResponse(context, "successfully logged out")
 }
 
+// @Summary VerifyEmail
+// @Tags auth
+// @Description verify user email with token from the mail
+// @Accept  json
+// @Produce json
+// @Param input body dto.VerifyEmailDTO true "email token"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /auth/verify-email [post]
+func (h *Handler) userVerifyEmail(context *gin.Context) {
+	var verifyEmailDTO dto.VerifyEmailDTO
+	err := context.ShouldBindJSON(&verifyEmailDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.authService.VerifyEmail(context, domain.Token(verifyEmailDTO.Token))
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "email successfully verified")
+}
+
+// @Summary ForgotPassword
+// @Tags auth
+// @Description send password reset token to the user email
+// @Accept  json
+// @Produce json
+// @Param input body dto.ForgotPasswordDTO true "user email"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 429 {object} RestErrorTooManyRequests
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /auth/forgot-password [post]
+func (h *Handler) userForgotPassword(context *gin.Context) {
+	var forgotPasswordDTO dto.ForgotPasswordDTO
+	err := context.ShouldBindJSON(&forgotPasswordDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.authService.ForgotPassword(context, forgotPasswordDTO.Email)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "password reset token is sent if the email is registered")
+}
+
+// @Summary ResetPassword
+// @Tags auth
+// @Description reset user password with token from the mail
+// @Accept  json
+// @Produce json
+// @Param input body dto.ResetPasswordDTO true "email token and new password"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 429 {object} RestErrorTooManyRequests
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /auth/reset-password [post]
+func (h *Handler) userResetPassword(context *gin.Context) {
+	var resetPasswordDTO dto.ResetPasswordDTO
+	err := context.ShouldBindJSON(&resetPasswordDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.authService.ResetPassword(context, port.ResetPasswordParam{
+		Token:    domain.Token(resetPasswordDTO.Token),
+		Password: resetPasswordDTO.Password,
+	})
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "password successfully reset")
+}
+
+// @Summary MfaVerify
+// @Tags auth
+// @Description complete sign-in with two-factor authentication code
+// @Accept  json
+// @Produce json
+// @Param input body dto.MfaVerifyDTO true "mfa challenge token and code"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 429 {object} RestErrorTooManyRequests
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "auth token"
+// @Router /auth/mfa/verify [post]
+func (h *Handler) userVerifyMfa(context *gin.Context) {
+	var mfaVerifyDTO dto.MfaVerifyDTO
+	err := context.ShouldBindJSON(&mfaVerifyDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	authDetails, err := h.authService.VerifyMfa(context, port.MfaVerifyParam{
+		MfaToken:    domain.Token(mfaVerifyDTO.MfaToken),
+		Code:        mfaVerifyDTO.Code,
+		Fingerprint: mfaVerifyDTO.Fingerprint,
+	})
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	context.SetSameSite(http.SameSiteLaxMode)
+	context.SetCookie("refreshToken", authDetails.RefreshToken.String(),
+		86400, "/", h.config.Host, false, false)
+
+	h.successResponse(context, authDetails.AccessToken.String())
+}
+
+// @Summary OidcLogin
+// @Tags auth
+// @Description redirect to identity provider login page
+// @Param provider path string true "identity provider name"
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 302 {string} string "redirect to identity provider"
+// @Router /auth/oidc/{provider} [get]
+func (h *Handler) userOidcLogin(context *gin.Context) {
+	authUrl, err := h.authService.OidcAuthUrl(context, context.Param("provider"))
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	context.Redirect(http.StatusFound, authUrl)
+}
+
+// @Summary OidcCallback
+// @Tags auth
+// @Description complete sign-in with identity provider authorization code
+// @Accept  json
+// @Produce json
+// @Param provider path string true "identity provider name"
+// @Param input body dto.OidcCallbackDTO true "authorization code and state"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "auth token"
+// @Success 202 {object} dto.MfaChallengeDTO
+// @Router /auth/oidc/{provider}/callback [post]
+func (h *Handler) userOidcCallback(context *gin.Context) {
+	var oidcCallbackDTO dto.OidcCallbackDTO
+	err := context.ShouldBindJSON(&oidcCallbackDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	authDetails, err := h.authService.OidcSignIn(context, port.OidcSignInParam{
+		Provider:    context.Param("provider"),
+		State:       oidcCallbackDTO.State,
+		Code:        oidcCallbackDTO.Code,
+		Fingerprint: oidcCallbackDTO.Fingerprint,
+	})
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	if authDetails.MfaRequired() {
+		context.JSON(http.StatusAccepted, dto.MfaChallengeDTO{
+			MfaToken: authDetails.MfaToken.String(),
+		})
+		return
+	}
+
+	context.SetSameSite(http.SameSiteLaxMode)
+	context.SetCookie("refreshToken", authDetails.RefreshToken.String(),
+		86400, "/", h.config.Host, false, false)
+
+	h.successResponse(context, authDetails.AccessToken.String())
+}
+
 func (h *Handler) refreshToken(context *gin.Context) {
 	var refreshDTO dto.RefreshDTO
 	err := context.ShouldBindJSON(&refreshDTO)
@@ -162,12 +372,17 @@ func (h *Handler) refreshToken(context *gin.Context) {
 }
 
 func (h *Handler) verifyToken(context *gin.Context) {
-	tokenString, err := extractAuthToken(context)
+	scheme, tokenString, err := extractAuthHeader(context)
 	if err != nil {
 		h.errorResponse(context, UnauthorizedError)
 		return
 	}
 
+	if scheme == "ApiKey" {
+		h.verifyApiKey(context, domain.Token(tokenString))
+		return
+	}
+
 	payload, err := h.authService.Payload(context, domain.Token(tokenString))
 	if err != nil {
 		h.errorResponse(context, err)
@@ -177,22 +392,76 @@ func (h *Handler) verifyToken(context *gin.Context) {
 	context.Set("userID", payload.UserID.String())
 }
 
-func extractAuthToken(context *gin.Context) (string, error) {
+// verifyOptionalToken identifies the user of public routes,
+// requests without auth header are served anonymously
+func (h *Handler) verifyOptionalToken(context *gin.Context) {
+	if context.GetHeader("Authorization") == "" {
+		return
+	}
+	h.verifyToken(context)
+}
+
+func (h *Handler) verifyApiKey(context *gin.Context, token domain.Token) {
+	apiKey, err := h.apiKeyService.Verify(context, token)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	if !apiKey.HasScope(apiKeyRequiredScope(context)) {
+		h.errorResponse(context, errs.ErrApiKeyScopeDenied)
+		return
+	}
+
+	context.Set("userID", apiKey.UserID.String())
+	context.Set("apiKeyID", apiKey.ID.String())
+}
+
+var apiKeyCourseWriteRoutes = map[string]bool{
+	"/api/v1/courses/:id/lessons":            true,
+	"/api/v1/courses/:id/lessons/:lesson_id": true,
+	"/api/v1/schools/:id/courses":            true,
+	"/api/v1/schools/:id/courses/:course_id": true,
+}
+
+func apiKeyRequiredScope(context *gin.Context) domain.ApiKeyScope {
+	switch context.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return domain.ApiKeyReadScope
+	}
+	if apiKeyCourseWriteRoutes[context.FullPath()] {
+		return domain.ApiKeyCourseWriteScope
+	}
+	return ""
+}
+
+func extractAuthHeader(context *gin.Context) (string, string, error) {
 	authHeader := context.GetHeader("Authorization")
 	if authHeader == "" {
-		return "", errors.New("empty auth header")
+		return "", "", errors.New("empty auth header")
 	}
 
 	headerParts := strings.Split(authHeader, " ")
-	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
-		return "", errors.New("invalid auth header")
+	if len(headerParts) != 2 || (headerParts[0] != "Bearer" && headerParts[0] != "ApiKey") {
+		return "", "", errors.New("invalid auth header")
 	}
 
 	if len(headerParts[1]) == 0 {
-		return "", errors.New("token is empty")
+		return "", "", errors.New("token is empty")
 	}
 
-	return headerParts[1], nil
+	return headerParts[0], headerParts[1], nil
+}
+
+func extractAuthToken(context *gin.Context) (string, error) {
+	scheme, token, err := extractAuthHeader(context)
+	if err != nil {
+		return "", err
+	}
+	if scheme != "Bearer" {
+		return "", errors.New("invalid auth header")
+	}
+	return token, nil
 }
 
 func (h *Handler) extractIdFromAuthHeader(context *gin.Context) (domain.ID, error) {
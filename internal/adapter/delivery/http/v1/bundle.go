@@ -0,0 +1,268 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"time"
+)
+
+// @Summary GetSchoolCatalog
+// @Tags school
+// @Description get published school courses and bundles
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.SchoolCatalogDTO
+// @Router /schools/{id}/catalog [get]
+func (h *Handler) findSchoolCatalog(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	courses, err := h.schoolService.FindSchoolCourses(context.Request.Context(), schoolID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	bundles, err := h.bundleService.FindSchoolBundles(context.Request.Context(), schoolID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	catalogDTO := dto.SchoolCatalogDTO{
+		Courses: make([]dto.CourseDTO, 0),
+		Bundles: make([]dto.BundleDTO, 0),
+	}
+	for _, course := range courses {
+		if course.Status == domain.CoursePublished {
+			catalogDTO.Courses = append(catalogDTO.Courses, dto.NewCourseDTO(course, getLocale(context)))
+		}
+	}
+	for _, bundle := range bundles {
+		if bundle.Status != domain.BundlePublished {
+			continue
+		}
+		bundleDTO, err := h.newBundleDTO(context.Request.Context(), bundle, getLocale(context))
+		if err != nil {
+			h.errorResponse(context, err)
+			return
+		}
+		catalogDTO.Bundles = append(catalogDTO.Bundles, bundleDTO)
+	}
+
+	h.successResponse(context, catalogDTO)
+}
+
+// @Summary GetSchoolBundles
+// @Tags school
+// @Security ApiKeyAuth
+// @Description get all school bundles including drafts
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.BundleDTO
+// @Router /schools/{id}/bundles [get]
+func (h *Handler) findSchoolBundles(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	bundles, err := h.bundleService.FindSchoolBundles(context.Request.Context(), schoolID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	bundleDTOs := make([]dto.BundleDTO, len(bundles))
+	for i, bundle := range bundles {
+		bundleDTOs[i], err = h.newBundleDTO(context.Request.Context(), bundle, getLocale(context))
+		if err != nil {
+			h.errorResponse(context, err)
+			return
+		}
+	}
+
+	h.successResponse(context, bundleDTOs)
+}
+
+// @Summary CreateSchoolBundle
+// @Tags school
+// @Security ApiKeyAuth
+// @Description create school course bundle
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Param input body dto.CreateBundleDTO true "created bundle info"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.BundleDTO
+// @Router /schools/{id}/bundles [post]
+func (h *Handler) createSchoolBundle(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	var createBundleDTO dto.CreateBundleDTO
+	err = context.ShouldBindJSON(&createBundleDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	courseIDs := make([]domain.ID, len(createBundleDTO.CourseIDs))
+	for i, courseID := range createBundleDTO.CourseIDs {
+		courseIDs[i] = domain.ID(courseID)
+	}
+
+	bundle, err := h.bundleService.CreateSchoolBundle(context.Request.Context(), schoolID,
+		port.CreateBundleParam{
+			Name:        createBundleDTO.Name,
+			Description: createBundleDTO.Description,
+			Price:       createBundleDTO.Price,
+			CourseIDs:   courseIDs,
+		})
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	bundleDTO, err := h.newBundleDTO(context.Request.Context(), bundle, getLocale(context))
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.createdResponse(context, bundleDTO)
+}
+
+// @Summary PublishSchoolBundle
+// @Tags school
+// @Security ApiKeyAuth
+// @Description publish school bundle, all its courses must be published
+// @Accept  json
+// @Produce json
+// @Param   schoolID   path    string  true  "school id"
+// @Param   bundleID   path    string  true  "bundle id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /schools/{schoolID}/bundles/{bundleID}/publish [post]
+func (h *Handler) publishSchoolBundle(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	bundleID, err := getIdFromPath(context, "bundle_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.bundleService.PublishSchoolBundle(context.Request.Context(), schoolID, bundleID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "successfully published")
+}
+
+// @Summary DeleteSchoolBundle
+// @Tags school
+// @Security ApiKeyAuth
+// @Description delete school bundle, bought courses stay with students
+// @Accept  json
+// @Produce json
+// @Param   schoolID   path    string  true  "school id"
+// @Param   bundleID   path    string  true  "bundle id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /schools/{schoolID}/bundles/{bundleID} [delete]
+func (h *Handler) deleteSchoolBundle(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	bundleID, err := getIdFromPath(context, "bundle_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.bundleService.Delete(context.Request.Context(), schoolID, bundleID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "successfully deleted")
+}
+
+func (h *Handler) newBundleDTO(ctx context.Context, bundle domain.Bundle, locale string) (dto.BundleDTO, error) {
+	courses, err := h.bundleService.FindBundleCourses(ctx, bundle.ID)
+	if err != nil {
+		return dto.BundleDTO{}, err
+	}
+	return dto.NewBundleDTO(bundle, courses, locale), nil
+}
+
+// addBundleStudent enrolls the student in every bundle course,
+// courses the student already owns are skipped, courses studied
+// by subscription are enrolled so they stay after the subscription ends
+func (h *Handler) addBundleStudent(ctx context.Context, studentID, bundleID domain.ID) error {
+	courses, err := h.bundleService.FindBundleCourses(ctx, bundleID)
+	if err != nil {
+		return err
+	}
+
+	for _, course := range courses {
+		enrollment, err := h.courseService.FindEnrollment(ctx, studentID, course.ID)
+		if err != nil && !errors.Is(err, errs.ErrNotExist) {
+			return err
+		}
+		if err == nil && enrollment.Active(time.Now().UTC()) {
+			continue
+		}
+
+		err = h.courseService.AddCourseStudent(ctx, studentID, course.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/port"
+)
+
+func (h *Handler) initCategoryRoutes(api *gin.RouterGroup) {
+	categories := api.Group("/categories")
+	{
+		categories.GET("/", h.findCategoryTree)
+		categories.POST("/", h.verifyAdminToken, h.createCategory)
+		categories.DELETE("/:id", h.verifyAdminToken, h.deleteCategory)
+	}
+}
+
+// @Summary GetCategories
+// @Tags category
+// @Description get category tree, course count includes courses of subcategories
+// @Accept  json
+// @Produce json
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.CategoryDTO
+// @Router /categories [get]
+func (h *Handler) findCategoryTree(context *gin.Context) {
+	tree, err := h.catalogService.FindCategoryTree(context.Request.Context())
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	categoryDTOs := make([]dto.CategoryDTO, len(tree))
+	for i, node := range tree {
+		categoryDTOs[i] = dto.NewCategoryNodeDTO(node)
+	}
+
+	h.successResponse(context, categoryDTOs)
+}
+
+// @Summary CreateCategory
+// @Tags category
+// @Description create platform category, requires admin token
+// @Accept  json
+// @Produce json
+// @Param input body dto.CreateCategoryDTO true "created category info"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.CategoryDTO
+// @Router /categories [post]
+func (h *Handler) createCategory(context *gin.Context) {
+	var createCategoryDTO dto.CreateCategoryDTO
+	err := context.ShouldBindJSON(&createCategoryDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	category, err := h.catalogService.CreateCategory(context.Request.Context(), port.CreateCategoryParam{
+		Name:     createCategoryDTO.Name,
+		ParentID: domain.ID(createCategoryDTO.ParentID),
+	})
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.createdResponse(context, dto.NewCategoryDTO(category))
+}
+
+// @Summary DeleteCategory
+// @Tags category
+// @Description delete platform category without subcategories, requires admin token
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "category id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /categories/{id} [delete]
+func (h *Handler) deleteCategory(context *gin.Context) {
+	categoryID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.catalogService.DeleteCategory(context.Request.Context(), categoryID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "successfully deleted")
+}
+
+// @Summary GetCourseCategories
+// @Tags course
+// @Description get categories of the course
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.CategoryDTO
+// @Router /courses/{id}/categories [get]
+func (h *Handler) findCourseCategories(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	categories, err := h.catalogService.FindCourseCategories(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, newCategoryDTOs(categories))
+}
+
+// @Summary UpdateCourseCategories
+// @Tags course
+// @Security ApiKeyAuth
+// @Description replace categories of the course
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Param input body dto.UpdateCourseCategoriesDTO true "course categories"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.CategoryDTO
+// @Router /courses/{id}/categories [put]
+func (h *Handler) updateCourseCategories(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	var updateDTO dto.UpdateCourseCategoriesDTO
+	err = context.ShouldBindJSON(&updateDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	categoryIDs := make([]domain.ID, len(updateDTO.CategoryIDs))
+	for i, categoryID := range updateDTO.CategoryIDs {
+		categoryIDs[i] = domain.ID(categoryID)
+	}
+
+	categories, err := h.catalogService.UpdateCourseCategories(context.Request.Context(), courseID, categoryIDs)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, newCategoryDTOs(categories))
+}
+
+// @Summary GetCourseTags
+// @Tags course
+// @Description get tags of the course
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.TagDTO
+// @Router /courses/{id}/tags [get]
+func (h *Handler) findCourseTags(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	tags, err := h.catalogService.FindCourseTags(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, newTagDTOs(tags))
+}
+
+// @Summary UpdateCourseTags
+// @Tags course
+// @Security ApiKeyAuth
+// @Description replace tags of the course, new tags are added to the course school
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Param input body dto.UpdateCourseTagsDTO true "course tags"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.TagDTO
+// @Router /courses/{id}/tags [put]
+func (h *Handler) updateCourseTags(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	var updateDTO dto.UpdateCourseTagsDTO
+	err = context.ShouldBindJSON(&updateDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	tags, err := h.catalogService.UpdateCourseTags(context.Request.Context(), courseID, updateDTO.Tags)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, newTagDTOs(tags))
+}
+
+// @Summary GetSchoolTags
+// @Tags school
+// @Description get tags used by courses of the school
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.TagDTO
+// @Router /schools/{id}/tags [get]
+func (h *Handler) findSchoolTags(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	tags, err := h.catalogService.FindSchoolTags(context.Request.Context(), schoolID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, newTagDTOs(tags))
+}
+
+func newCategoryDTOs(categories []domain.Category) []dto.CategoryDTO {
+	categoryDTOs := make([]dto.CategoryDTO, len(categories))
+	for i, category := range categories {
+		categoryDTOs[i] = dto.NewCategoryDTO(category)
+	}
+	return categoryDTOs
+}
+
+func newTagDTOs(tags []domain.Tag) []dto.TagDTO {
+	tagDTOs := make([]dto.TagDTO, len(tags))
+	for i, tag := range tags {
+		tagDTOs[i] = dto.NewTagDTO(tag)
+	}
+	return tagDTOs
+}
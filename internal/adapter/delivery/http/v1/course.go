@@ -1,25 +1,59 @@
 package v1
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/guregu/null"
 	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
 )
 
+// courseLearnerKey marks requests of course students and subscribers
+const courseLearnerKey = "courseLearner"
+
+// lessonPreviewKey marks requests of preview lesson readers without course access
+const lessonPreviewKey = "lessonPreview"
+
 func (h *Handler) initCourseRoutes(api *gin.RouterGroup) {
 	courses := api.Group("/courses")
 	{
 		courses.GET("/", h.findAllCourses)
 		courses.GET("/:id", h.findCourseByID)
+		courses.GET("/:id/prerequisites", h.findCoursePrerequisites)
+		courses.GET("/:id/categories", h.findCourseCategories)
+		courses.GET("/:id/tags", h.findCourseTags)
+		courses.GET("/:id/lessons/:lesson_id", h.verifyOptionalToken, h.verifyLessonReadAccess, h.findLessonByID)
 		authenticated := courses.Group("/", h.verifyToken)
 		{
 			authenticated.GET("/:id/lessons", h.verifyCourseReadAccess, h.findCourseLessons)
-			authenticated.GET("/:id/lessons/:lesson_id", h.verifyCourseReadAccess, h.findLessonByID)
 			authenticated.POST("/:id/lessons", h.verifyCourseWriteAccess, h.createCourseLesson)
+			authenticated.POST("/:id/lessons/import", h.verifyCourseWriteAccess, h.importCourseQuiz)
 			authenticated.PATCH("/:id/lessons/:lesson_id", h.verifyCourseWriteAccess, h.updateCourseLesson)
 			authenticated.DELETE("/:id/lessons/:lesson_id", h.verifyCourseWriteAccess, h.deleteCourseLesson)
+			authenticated.PUT("/:id/lessons/:lesson_id/release", h.verifyCourseWriteAccess, h.updateLessonRelease)
+			authenticated.PUT("/:id/lessons/:lesson_id/preview", h.verifyCourseWriteAccess, h.updateLessonPreview)
+
+			authenticated.POST("/:id/revision", h.verifyCourseWriteAccess, h.createCourseRevision)
+			authenticated.POST("/:id/revision/publish", h.verifyCourseWriteAccess, h.publishCourseRevision)
+			authenticated.GET("/:id/validation", h.verifyCourseWriteAccess, h.validateCourse)
+			authenticated.POST("/:id/duplicate", h.verifyCourseWriteAccess, h.duplicateCourse)
+			authenticated.GET("/:id/export", h.verifyCourseWriteAccess, h.exportCourse)
+
+			authenticated.PUT("/:id/prerequisites/:required_id", h.verifyCourseWriteAccess, h.addCoursePrerequisite)
+			authenticated.DELETE("/:id/prerequisites/:required_id", h.verifyCourseWriteAccess, h.removeCoursePrerequisite)
+			authenticated.GET("/:id/progress", h.findCourseProgress)
+
+			authenticated.PUT("/:id/categories", h.verifyCourseWriteAccess, h.updateCourseCategories)
+			authenticated.PUT("/:id/tags", h.verifyCourseWriteAccess, h.updateCourseTags)
 
 			authenticated.GET("/:id/teachers", h.findCourseTeachers)
 			authenticated.PUT("/:id/teachers/:teacher_id", h.addCourseTeacher)
@@ -27,6 +61,16 @@ func (h *Handler) initCourseRoutes(api *gin.RouterGroup) {
 			authenticated.GET("/:id/reviews", h.findCourseReviews)
 			authenticated.POST("/:id/reviews", h.addCourseReview)
 
+			authenticated.POST("/:id/refunds", h.requestCourseRefund)
+
+			authenticated.GET("/:id/enrollment", h.findCourseEnrollment)
+			authenticated.POST("/:id/enrollment/migrate", h.migrateCourseEnrollment)
+
+			authenticated.GET("/:id/waitlist", h.findCourseWaitlist)
+			authenticated.POST("/:id/waitlist", h.joinCourseWaitlist)
+			authenticated.DELETE("/:id/waitlist", h.leaveCourseWaitlist)
+			authenticated.DELETE("/:id/students/:student_id", h.removeCourseStudent)
+
 			authenticated.GET("/:id/lessons/:lesson_id/stat", h.verifyCourseReadAccess, h.findLessonStat)
 			authenticated.POST("/:id/lessons/:lesson_id/stat", h.verifyCourseReadAccess, h.passCourseLesson)
 		}
@@ -35,14 +79,33 @@ func (h *Handler) initCourseRoutes(api *gin.RouterGroup) {
 
 // @Summary GetAllCourses
 // @Tags course
-// @Description get all courses
+// @Description get all courses, filtered courses are limited to the catalog
 // @Accept  json
 // @Produce json
+// @Param   category  query   string  false  "category id, subcategories are included"
+// @Param   tag       query   string  false  "tag name"
+// @Failure 400 {object} RestErrorBadRequest
 // @Failure 500 {object} RestErrorInternalError
 // @Success 200 {object} []dto.CourseDTO
 // @Router /courses [get]
 func (h *Handler) findAllCourses(context *gin.Context) {
-	courses, err := h.courseService.FindAll(context.Request.Context())
+	var courses []domain.Course
+	var err error
+	categoryString, tag := context.Query("category"), context.Query("tag")
+	if categoryString != "" || tag != "" {
+		if categoryString != "" {
+			if _, err = uuid.Parse(categoryString); err != nil {
+				h.errorResponse(context, BadRequestError)
+				return
+			}
+		}
+		courses, err = h.catalogService.FindCourses(context.Request.Context(), port.CourseFilter{
+			CategoryID: domain.ID(categoryString),
+			Tag:        tag,
+		})
+	} else {
+		courses, err = h.courseService.FindAll(context.Request.Context())
+	}
 	if err != nil {
 		h.errorResponse(context, err)
 		return
@@ -50,7 +113,7 @@ func (h *Handler) findAllCourses(context *gin.Context) {
 
 	courseDTOs := make([]dto.CourseDTO, len(courses))
 	for i, course := range courses {
-		courseDTOs[i] = dto.NewCourseDTO(course)
+		courseDTOs[i] = dto.NewCourseDTO(course, getLocale(context))
 	}
 
 	h.successResponse(context, courseDTOs)
@@ -80,19 +143,20 @@ func (h *Handler) findCourseByID(context *gin.Context) {
 		return
 	}
 
-	courseDTO := dto.NewCourseDTO(course)
+	courseDTO := dto.NewCourseDTO(course, getLocale(context))
 	h.successResponse(context, courseDTO)
 }
 
 // @Summary GetLessonByID
 // @Tags course
 // @Security ApiKeyAuth
-// @Description get lesson by id
+// @Description get lesson by id, preview lessons are readable without auth and test answers
 // @Accept  json
 // @Produce json
 // @Param   courseID   path    string  true  "course id"
 // @Param   lessonID   path    string  true  "lesson id"
 // @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
 // @Failure 403 {object} RestErrorForbidden
 // @Failure 404 {object} RestErrorNotFound
 // @Failure 500 {object} RestErrorInternalError
@@ -111,10 +175,310 @@ func (h *Handler) findLessonByID(context *gin.Context) {
 		return
 	}
 
+	if context.GetBool(lessonPreviewKey) {
+		h.successResponse(context, dto.NewPreviewLessonDTO(lesson))
+		return
+	}
+
+	err = h.verifyLessonReleased(context, lesson)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
 	lessonDTO := dto.NewLessonDTO(lesson)
 	h.successResponse(context, lessonDTO)
 }
 
+// @Summary GetCourseEnrollment
+// @Tags course
+// @Security ApiKeyAuth
+// @Description get enrollment of the current user, expired enrollment can be renewed by payment
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.EnrollmentDTO
+// @Router /courses/{id}/enrollment [get]
+func (h *Handler) findCourseEnrollment(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	enrollment, err := h.courseService.FindEnrollment(context.Request.Context(), userID, courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	enrollmentDTO := dto.NewEnrollmentDTO(enrollment)
+	h.successResponse(context, enrollmentDTO)
+}
+
+// @Summary MigrateCourseEnrollment
+// @Tags course
+// @Security ApiKeyAuth
+// @Description move the current user to the published course version, stats of lessons are kept
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.EnrollmentDTO
+// @Router /courses/{id}/enrollment/migrate [post]
+func (h *Handler) migrateCourseEnrollment(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	enrollment, err := h.courseService.MigrateStudentVersion(context.Request.Context(), userID, courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	enrollmentDTO := dto.NewEnrollmentDTO(enrollment)
+	h.successResponse(context, enrollmentDTO)
+}
+
+// @Summary CreateCourseRevision
+// @Tags course
+// @Security ApiKeyAuth
+// @Description copy lessons of the published course into a draft revision
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.CourseDTO
+// @Router /courses/{id}/revision [post]
+func (h *Handler) createCourseRevision(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	course, err := h.courseService.CreateCourseRevision(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	courseDTO := dto.NewCourseDTO(course, getLocale(context))
+	h.createdResponse(context, courseDTO)
+}
+
+// @Summary DuplicateCourse
+// @Tags course
+// @Security ApiKeyAuth
+// @Description copy course with lessons and tests into a new draft course of the same or another school
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Param input body dto.DuplicateCourseDTO false "target school, course school by default"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.CourseDTO
+// @Router /courses/{id}/duplicate [post]
+func (h *Handler) duplicateCourse(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	// body is optional, course is copied into its own school without it
+	var duplicateDTO dto.DuplicateCourseDTO
+	err = context.ShouldBindJSON(&duplicateDTO)
+	if err != nil && !errors.Is(err, io.EOF) {
+		h.errorResponse(context, err)
+		return
+	}
+
+	course, err := h.courseService.FindByID(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	schoolID := course.SchoolID
+	if duplicateDTO.SchoolID != "" {
+		schoolID = domain.ID(duplicateDTO.SchoolID)
+	}
+
+	isTeacher, err := h.schoolService.IsSchoolTeacher(context.Request.Context(), schoolID, userID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+	if !isTeacher {
+		h.errorResponse(context, ForbiddenError)
+		return
+	}
+
+	duplicate, err := h.courseService.DuplicateCourse(context.Request.Context(), courseID, schoolID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	_, err = h.lessonService.CopyCourseLessons(context.Request.Context(), courseID, duplicate.ID)
+	if err != nil {
+		// the copy without lessons is useless, it is removed
+		h.deleteIncompleteCourseCopy(context, duplicate.ID)
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.courseService.AddCourseTeacher(context.Request.Context(), userID, duplicate.ID)
+	if err != nil {
+		// nobody can edit the copy without the teacher, it is removed too
+		h.deleteIncompleteCourseCopy(context, duplicate.ID)
+		h.errorResponse(context, err)
+		return
+	}
+
+	courseDTO := dto.NewCourseDTO(duplicate, getLocale(context))
+	h.createdResponse(context, courseDTO)
+}
+
+func (h *Handler) deleteIncompleteCourseCopy(context *gin.Context, courseID domain.ID) {
+	if err := h.courseService.Delete(context.Request.Context(), courseID); err != nil {
+		h.logger.Error("failed to delete incomplete course copy", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+	}
+}
+
+// @Summary ExportCourse
+// @Tags course
+// @Security ApiKeyAuth
+// @Description download zip archive with course.json manifest, lesson markdown and media of the course
+// @Produce application/zip
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {file} file
+// @Router /courses/{id}/export [get]
+func (h *Handler) exportCourse(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	// archive is built before the response, so failure is sent as json error
+	var archive bytes.Buffer
+	err = h.archiveService.ExportCourse(context.Request.Context(), courseID, &archive)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	context.Header("Content-Disposition",
+		fmt.Sprintf("attachment; filename=\"course-%s.zip\"", courseID.String()))
+	context.Data(http.StatusOK, "application/zip", archive.Bytes())
+}
+
+// @Summary PublishCourseRevision
+// @Tags course
+// @Security ApiKeyAuth
+// @Description publish the draft revision as a new course version
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /courses/{id}/revision/publish [post]
+func (h *Handler) publishCourseRevision(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.courseService.PublishCourseRevision(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "course revision successfully published")
+}
+
+// @Summary ValidateCourse
+// @Tags course
+// @Security ApiKeyAuth
+// @Description check course lessons and tests without changing the course
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.CourseValidationReportDTO
+// @Router /courses/{id}/validation [get]
+func (h *Handler) validateCourse(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	report, err := h.courseService.ValidateCourse(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, dto.NewCourseValidationReportDTO(report))
+}
+
 // @Summary GetCourseTeachers
 // @Tags course
 // @Description get course teachers
@@ -216,15 +580,33 @@ func (h *Handler) findCourseLessons(context *gin.Context) {
 		return
 	}
 
-	lessons, err := h.lessonService.FindCourseLessons(context.Request.Context(), courseID)
+	// learners see the version they study, teachers see the edited one
+	var lessons []domain.Lesson
+	var enrollment domain.Enrollment
+	learner := context.GetBool(courseLearnerKey)
+	if learner {
+		enrollment, err = h.findLearnerEnrollment(context, courseID)
+		if err == nil {
+			lessons, err = h.lessonService.FindCourseVersionLessons(context.Request.Context(),
+				courseID, enrollment.Version)
+		}
+	} else {
+		lessons, err = h.lessonService.FindCourseLessons(context.Request.Context(), courseID)
+	}
 	if err != nil {
 		h.errorResponse(context, err)
 		return
 	}
 
+	// unreleased lessons are listed for learners without content
+	now := time.Now()
 	lessonDTOs := make([]dto.LessonDTO, len(lessons))
 	for i, lesson := range lessons {
-		lessonDTOs[i] = dto.NewLessonDTO(lesson)
+		if learner && !lesson.Released(enrollment, now) {
+			lessonDTOs[i] = dto.NewLockedLessonDTO(lesson)
+		} else {
+			lessonDTOs[i] = dto.NewLessonDTO(lesson)
+		}
 	}
 
 	h.successResponse(context, lessonDTOs)
@@ -318,6 +700,70 @@ func (h *Handler) createCourseLesson(context *gin.Context) {
 	h.createdResponse(context, lessonDTO)
 }
 
+// @Summary ImportCourseQuiz
+// @Tags course
+// @Security ApiKeyAuth
+// @Description create practice lessons from QTI 2.1 items or Common Cartridge package, only choice items are imported
+// @Accept  multipart/form-data
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Param package formData file true "QTI xml file or zip package"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 413 {object} RestError
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.QuizImportDTO
+// @Router /courses/{id}/lessons/import [post]
+func (h *Handler) importCourseQuiz(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	fileHeader, err := context.FormFile("package")
+	if err != nil {
+		h.errorResponse(context, BadRequestError)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.errorResponse(context, BadRequestError)
+		return
+	}
+	defer file.Close()
+
+	quiz, err := h.quizService.ParseQuiz(context.Request.Context(), file, fileHeader.Size)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	quizDTO := dto.QuizImportDTO{
+		Lessons: make([]dto.LessonDTO, len(quiz.Lessons)),
+		Skipped: make([]dto.SkippedQuizItemDTO, len(quiz.Skipped)),
+	}
+	for i, param := range quiz.Lessons {
+		lesson, err := h.lessonService.CreatePracticeLesson(context.Request.Context(), courseID, param)
+		if err != nil {
+			h.errorResponse(context, err)
+			return
+		}
+		quizDTO.Lessons[i] = dto.NewLessonDTO(lesson)
+	}
+	for i, item := range quiz.Skipped {
+		quizDTO.Skipped[i] = dto.SkippedQuizItemDTO{
+			Identifier: item.Identifier,
+			Title:      item.Title,
+			Reason:     item.Reason,
+		}
+	}
+
+	h.createdResponse(context, quizDTO)
+}
+
 // @Summary UpdateCourseLesson
 // @Tags course
 // @Security ApiKeyAuth
@@ -444,6 +890,91 @@ func (h *Handler) deleteCourseLesson(context *gin.Context) {
 	h.successResponse(context, "lesson successfully deleted")
 }
 
+// @Summary UpdateLessonRelease
+// @Tags course
+// @Security ApiKeyAuth
+// @Description release lesson at the date or in days after enrollment, empty body releases it at once
+// @Accept  json
+// @Produce json
+// @Param   courseID   path    string  true  "course id"
+// @Param   lessonID   path    string  true  "lesson id"
+// @Param input body dto.UpdateLessonReleaseDTO true "release rule"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.LessonDTO
+// @Router /courses/{courseID}/lessons/{lessonID}/release [put]
+func (h *Handler) updateLessonRelease(context *gin.Context) {
+	lessonID, err := getIdFromPath(context, "lesson_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	var releaseDTO dto.UpdateLessonReleaseDTO
+	err = context.ShouldBindJSON(&releaseDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	lesson, err := h.lessonService.UpdateLessonRelease(context.Request.Context(), lessonID,
+		port.UpdateLessonReleaseParam{
+			ReleaseAt:   releaseDTO.ReleaseAt,
+			ReleaseDays: releaseDTO.ReleaseDays,
+		})
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	lessonDTO := dto.NewLessonDTO(lesson)
+	h.successResponse(context, lessonDTO)
+}
+
+// @Summary UpdateLessonPreview
+// @Tags course
+// @Security ApiKeyAuth
+// @Description mark lesson as the course preview readable by anyone, course has a few preview lessons
+// @Accept  json
+// @Produce json
+// @Param   courseID   path    string  true  "course id"
+// @Param   lessonID   path    string  true  "lesson id"
+// @Param input body dto.UpdateLessonPreviewDTO true "preview flag"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.LessonDTO
+// @Router /courses/{courseID}/lessons/{lessonID}/preview [put]
+func (h *Handler) updateLessonPreview(context *gin.Context) {
+	lessonID, err := getIdFromPath(context, "lesson_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	var previewDTO dto.UpdateLessonPreviewDTO
+	err = context.ShouldBindJSON(&previewDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	lesson, err := h.lessonService.UpdateLessonPreview(context.Request.Context(), lessonID, previewDTO.IsPreview)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	lessonDTO := dto.NewLessonDTO(lesson)
+	h.successResponse(context, lessonDTO)
+}
+
 func (h *Handler) addCourseReview(context *gin.Context) {
 	userID, err := getIdFromRequestContext(context)
 	if err != nil {
@@ -520,7 +1051,7 @@ func (h *Handler) findLessonStat(context *gin.Context) {
 		return
 	}
 
-	stat, err := h.statService.FindLessonStat(context.Request.Context(), userID, lessonID)
+	stat, err := h.findLearnerLessonStat(context, userID, lessonID)
 	if err != nil {
 		h.errorResponse(context, err)
 		return
@@ -572,6 +1103,18 @@ func (h *Handler) passCourseLesson(context *gin.Context) {
 		return
 	}
 
+	err = h.verifyLessonReleased(context, lesson)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	_, err = h.findLearnerLessonStat(context, userID, lessonID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
 	switch lesson.Type {
 	case domain.TheoryLesson:
 		fallthrough
@@ -592,7 +1135,7 @@ func (h *Handler) passCourseLesson(context *gin.Context) {
 			for j, test := range lesson.Tests {
 				if passTest.TestID == test.ID.String() {
 					var newScore int
-					if passTest.Answer == test.Answer {
+					if test.Check(passTest.Answer) {
 						newScore = lesson.Tests[j].Score
 					}
 
@@ -639,12 +1182,147 @@ func (h *Handler) verifyCourseReadAccess(context *gin.Context) {
 		return
 	}
 
-	if !h.checkCurrentUserIsCourseOwner(context, courseID) &&
-		!h.checkCurrentUserIsCourseTeacher(context, courseID) &&
-		!h.checkCurrentUserIsCourseStudent(context, courseID) {
-		h.errorResponse(context, ForbiddenError)
+	if h.checkCurrentUserIsCourseOwner(context, courseID) ||
+		h.checkCurrentUserIsCourseTeacher(context, courseID) {
+		return
+	}
+
+	if !h.checkCurrentUserIsCourseStudent(context, courseID) ||
+		!h.checkCourseIsLearnable(context, courseID) {
+		if !context.IsAborted() {
+			h.errorResponse(context, ForbiddenError)
+		}
+		return
+	}
+	context.Set(courseLearnerKey, true)
+}
+
+// verifyLessonReadAccess lets anyone read preview lessons of the listed course,
+// other lessons are read by users with the course access
+func (h *Handler) verifyLessonReadAccess(context *gin.Context) {
+	if _, err := getIdFromRequestContext(context); err == nil {
+		courseID, err := getIdFromPath(context, "id")
+		if err != nil {
+			h.errorResponse(context, err)
+			return
+		}
+
+		if h.checkCurrentUserIsCourseOwner(context, courseID) ||
+			h.checkCurrentUserIsCourseTeacher(context, courseID) {
+			return
+		}
+		if h.checkCurrentUserIsCourseStudent(context, courseID) &&
+			h.checkCourseIsLearnable(context, courseID) {
+			context.Set(courseLearnerKey, true)
+			return
+		}
+		if context.IsAborted() {
+			return
+		}
+	}
+
+	isPreview, err := h.checkLessonIsPreview(context)
+	if err != nil {
+		h.errorResponse(context, err)
 		return
 	}
+	if !isPreview {
+		if _, err := getIdFromRequestContext(context); err != nil {
+			h.errorResponse(context, UnauthorizedError)
+		} else {
+			h.errorResponse(context, ForbiddenError)
+		}
+		return
+	}
+	context.Set(lessonPreviewKey, true)
+}
+
+func (h *Handler) checkLessonIsPreview(context *gin.Context) (bool, error) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		return false, err
+	}
+
+	lessonID, err := getIdFromPath(context, "lesson_id")
+	if err != nil {
+		return false, err
+	}
+
+	course, err := h.courseService.FindByID(context.Request.Context(), courseID)
+	if err != nil {
+		return false, err
+	}
+
+	lesson, err := h.lessonService.FindByID(context.Request.Context(), lessonID)
+	if err != nil {
+		return false, err
+	}
+	return lesson.PreviewOf(course), nil
+}
+
+// findLearnerLessonStat creates missing stat of the student or subscriber,
+// subscribers and students of lessons added later have no stats in advance
+func (h *Handler) findLearnerLessonStat(context *gin.Context, userID,
+	lessonID domain.ID) (domain.LessonStat, error) {
+	if context.GetBool(courseLearnerKey) {
+		return h.statService.FindOrCreateLessonStat(context.Request.Context(), userID, lessonID)
+	}
+	return h.statService.FindLessonStat(context.Request.Context(), userID, lessonID)
+}
+
+// findLearnerEnrollment returns the enrollment of the student,
+// subscribers study the published version and have no enrollment date
+func (h *Handler) findLearnerEnrollment(context *gin.Context, courseID domain.ID) (domain.Enrollment, error) {
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		return domain.Enrollment{}, UnauthorizedError
+	}
+
+	enrollment, err := h.courseService.FindEnrollment(context.Request.Context(), userID, courseID)
+	if err == nil {
+		return enrollment, nil
+	}
+	if !errors.Is(err, errs.ErrNotExist) {
+		return domain.Enrollment{}, err
+	}
+
+	course, err := h.courseService.FindByID(context.Request.Context(), courseID)
+	if err != nil {
+		return domain.Enrollment{}, err
+	}
+	return domain.Enrollment{
+		StudentID: userID,
+		CourseID:  courseID,
+		Version:   course.LearnerVersion(),
+	}, nil
+}
+
+// verifyLessonReleased forbids learners to open the lesson before its release,
+// teachers always see the lesson
+func (h *Handler) verifyLessonReleased(context *gin.Context, lesson domain.Lesson) error {
+	if !context.GetBool(courseLearnerKey) {
+		return nil
+	}
+
+	enrollment, err := h.findLearnerEnrollment(context, lesson.CourseID)
+	if err != nil {
+		return err
+	}
+	if !lesson.Released(enrollment, time.Now()) {
+		return errs.ErrLessonNotReleased
+	}
+	return nil
+}
+
+// checkCourseIsLearnable reports whether students study the course,
+// unpublished course is closed for them until it is published again
+func (h *Handler) checkCourseIsLearnable(context *gin.Context, courseID domain.ID) bool {
+	course, err := h.courseService.FindByID(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return false
+	}
+	return course.Status.Learnable()
 }
 
 func (h *Handler) checkCurrentUserIsCourseStudent(context *gin.Context, courseID domain.ID) bool {
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type CreateApiKeyDTO struct {
+	Name   string   `json:"name" binding:"required" example:"course sync script"`
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,oneof=read course_write" example:"read,course_write"`
+}
+
+type ApiKeyDTO struct {
+	ID         string     `json:"id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+	Name       string     `json:"name" example:"course sync script"`
+	Prefix     string     `json:"prefix" example:"esk_AbCdEfGh"`
+	Scopes     []string   `json:"scopes" example:"read,course_write"`
+	CreatedAt  time.Time  `json:"created_at" example:"2024-10-01T12:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at" example:"2024-10-02T12:00:00Z"`
+	Revoked    bool       `json:"revoked" example:"false"`
+}
+
+type CreatedApiKeyDTO struct {
+	Key    string    `json:"key" example:"esk_AbCdEfGhIjKlMnOpQrStUvWxYz0123456789abcde"`
+	ApiKey ApiKeyDTO `json:"api_key"`
+}
+
+func NewApiKeyDTO(key domain.ApiKey) ApiKeyDTO {
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return ApiKeyDTO{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt.Ptr(),
+		Revoked:    key.Revoked(),
+	}
+}
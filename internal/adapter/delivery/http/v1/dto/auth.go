@@ -1,6 +1,9 @@
 package dto
 
-import "github.com/guregu/null"
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+)
 
 type SignUpDTO struct {
 	Name      string      `json:"name" binding:"required" example:"Maxim"`
@@ -21,3 +24,48 @@ type SignInDTO struct {
 type RefreshDTO struct {
 	Fingerprint string `json:"fingerprint" binding:"required" example:"fingerprint"`
 }
+
+type VerifyEmailDTO struct {
+	Token string `json:"token" binding:"required" example:"token"`
+}
+
+type ForgotPasswordDTO struct {
+	Email string `json:"email" binding:"required,email" example:"paw1a@yandex.ru"`
+}
+
+type ResetPasswordDTO struct {
+	Token    string `json:"token" binding:"required" example:"token"`
+	Password string `json:"password" binding:"required" example:"123"`
+}
+
+type MfaChallengeDTO struct {
+	MfaToken string `json:"mfa_token" example:"token"`
+}
+
+type MfaVerifyDTO struct {
+	MfaToken    string `json:"mfa_token" binding:"required" example:"token"`
+	Code        string `json:"code" binding:"required" example:"123456"`
+	Fingerprint string `json:"fingerprint" binding:"required" example:"fingerprint"`
+}
+
+type OidcCallbackDTO struct {
+	Code        string `json:"code" binding:"required" example:"code"`
+	State       string `json:"state" binding:"required" example:"state"`
+	Fingerprint string `json:"fingerprint" binding:"required" example:"fingerprint"`
+}
+
+type MfaCodeDTO struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+type MfaEnrollmentDTO struct {
+	ProvisioningUri string   `json:"provisioning_uri" example:"otpauth://totp/eschool:user@gmail.com?secret=SECRET"`
+	RecoveryCodes   []string `json:"recovery_codes" example:"a2b3c4d5e6f7g8h9"`
+}
+
+func NewMfaEnrollmentDTO(enrollment domain.MfaEnrollment) MfaEnrollmentDTO {
+	return MfaEnrollmentDTO{
+		ProvisioningUri: enrollment.ProvisioningUri,
+		RecoveryCodes:   enrollment.RecoveryCodes,
+	}
+}
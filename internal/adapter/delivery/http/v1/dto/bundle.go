@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+const (
+	BundleDTODraft     = "draft"
+	BundleDTOPublished = "published"
+)
+
+type CreateBundleDTO struct {
+	Name        string   `json:"name" binding:"required" example:"Backend starter pack"`
+	Description string   `json:"description" binding:"omitempty" example:"3 courses for the price of 2"`
+	Price       int64    `json:"price" binding:"required" example:"798000"`
+	CourseIDs   []string `json:"course_ids" binding:"required,dive,uuid" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+}
+
+type BundleDTO struct {
+	ID          string `json:"id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+	SchoolID    string `json:"school_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+	Name        string `json:"name" example:"Backend starter pack"`
+	Description string `json:"description" example:"3 courses for the price of 2"`
+	// Price is in minor units of the currency of bundle courses
+	Price        int64       `json:"price" example:"798000"`
+	Currency     string      `json:"currency" example:"RUB"`
+	DisplayPrice string      `json:"display_price" example:"7 980,00 ₽"`
+	Status       string      `json:"status" example:"published"`
+	Courses      []CourseDTO `json:"courses"`
+	CreatedAt    time.Time   `json:"created_at" example:"2024-10-01T00:00:00Z"`
+}
+
+type SchoolCatalogDTO struct {
+	Courses []CourseDTO `json:"courses"`
+	Bundles []BundleDTO `json:"bundles"`
+}
+
+func NewBundleDTO(bundle domain.Bundle, courses []domain.Course, locale string) BundleDTO {
+	var status string
+	switch bundle.Status {
+	case domain.BundleDraft:
+		status = BundleDTODraft
+	case domain.BundlePublished:
+		status = BundleDTOPublished
+	}
+
+	courseDTOs := make([]CourseDTO, len(courses))
+	for i, course := range courses {
+		courseDTOs[i] = NewCourseDTO(course, locale)
+	}
+
+	// bundles can't mix currencies, so the first course currency is the bundle one
+	price := domain.NewMoney(bundle.Price, domain.DefaultCurrency)
+	if len(courses) > 0 {
+		price.Currency = courses[0].Price.Currency
+	}
+
+	return BundleDTO{
+		ID:           bundle.ID.String(),
+		SchoolID:     bundle.SchoolID.String(),
+		Name:         bundle.Name,
+		Description:  bundle.Description,
+		Price:        price.Amount,
+		Currency:     price.Currency,
+		DisplayPrice: price.Format(locale),
+		Status:       status,
+		Courses:      courseDTOs,
+		CreatedAt:    bundle.CreatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+)
+
+type CreateCategoryDTO struct {
+	Name     string `json:"name" binding:"required,max=255" example:"Programming"`
+	ParentID string `json:"parent_id" binding:"omitempty,uuid" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+}
+
+type CategoryDTO struct {
+	ID          string        `json:"id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+	ParentID    string        `json:"parent_id,omitempty" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+	Name        string        `json:"name" example:"Programming"`
+	CourseCount int           `json:"course_count" example:"12"`
+	Children    []CategoryDTO `json:"children,omitempty"`
+}
+
+type UpdateCourseCategoriesDTO struct {
+	CategoryIDs []string `json:"category_ids" binding:"required,dive,uuid" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+}
+
+type TagDTO struct {
+	ID       string `json:"id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+	SchoolID string `json:"school_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+	Name     string `json:"name" example:"golang"`
+}
+
+type UpdateCourseTagsDTO struct {
+	Tags []string `json:"tags" binding:"required" example:"golang"`
+}
+
+func NewCategoryDTO(category domain.Category) CategoryDTO {
+	return CategoryDTO{
+		ID:       category.ID.String(),
+		ParentID: category.ParentID.String(),
+		Name:     category.Name,
+	}
+}
+
+func NewCategoryNodeDTO(node domain.CategoryNode) CategoryDTO {
+	children := make([]CategoryDTO, len(node.Children))
+	for i, child := range node.Children {
+		children[i] = NewCategoryNodeDTO(child)
+	}
+
+	categoryDTO := NewCategoryDTO(node.Category)
+	categoryDTO.CourseCount = node.CourseCount
+	categoryDTO.Children = children
+	return categoryDTO
+}
+
+func NewTagDTO(tag domain.Tag) TagDTO {
+	return TagDTO{
+		ID:       tag.ID.String(),
+		SchoolID: tag.SchoolID.String(),
+		Name:     tag.Name,
+	}
+}
@@ -3,26 +3,43 @@ package dto
 import (
 	"github.com/guregu/null"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
 )
 
 const (
-	CourseDTODraft     = "draft"
-	CourseDTOReady     = "ready"
-	CourseDTOPublished = "published"
+	CourseDTODraft       = "draft"
+	CourseDTOReady       = "ready"
+	CourseDTOPublished   = "published"
+	CourseDTOUnpublished = "unpublished"
+	CourseDTOArchived    = "archived"
 )
 
 type CreateCourseDTO struct {
-	Name     string   `json:"name" binding:"required" example:"Course name"`
-	Level    null.Int `json:"level" binding:"required" swaggertype:"string" example:"5"`
-	Price    null.Int `json:"price" binding:"required" swaggertype:"string" example:"3990"`
-	Language string   `json:"language" binding:"required" example:"english"`
+	Name       string   `json:"name" binding:"required" example:"Course name"`
+	Level      null.Int `json:"level" binding:"required" swaggertype:"string" example:"5"`
+	Price      null.Int `json:"price" binding:"required" swaggertype:"string" example:"399000"`
+	Currency   string   `json:"currency" binding:"omitempty,len=3" example:"RUB"`
+	Language   string   `json:"language" binding:"required" example:"english"`
+	Capacity   null.Int `json:"capacity" binding:"omitempty" swaggertype:"string" example:"30"`
+	AccessDays null.Int `json:"access_days" binding:"omitempty" swaggertype:"string" example:"365"`
 }
 
 type UpdateCourseDTO struct {
-	Name     null.String `json:"name" binding:"omitempty" swaggertype:"string" example:"Updated name"`
-	Level    null.Int    `json:"level" binding:"omitempty" swaggertype:"string" example:"5"`
-	Price    null.Int    `json:"price" binding:"omitempty" swaggertype:"string" example:"3990"`
-	Language null.String `json:"language" binding:"omitempty" swaggertype:"string" example:"english"`
+	Name       null.String `json:"name" binding:"omitempty" swaggertype:"string" example:"Updated name"`
+	Level      null.Int    `json:"level" binding:"omitempty" swaggertype:"string" example:"5"`
+	Price      null.Int    `json:"price" binding:"omitempty" swaggertype:"string" example:"399000"`
+	Currency   null.String `json:"currency" binding:"omitempty" swaggertype:"string" example:"RUB"`
+	Language   null.String `json:"language" binding:"omitempty" swaggertype:"string" example:"english"`
+	Capacity   null.Int    `json:"capacity" binding:"omitempty" swaggertype:"string" example:"30"`
+	AccessDays null.Int    `json:"access_days" binding:"omitempty" swaggertype:"string" example:"365"`
+}
+
+type DuplicateCourseDTO struct {
+	SchoolID string `json:"school_id" binding:"omitempty,uuid" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+}
+
+type ScheduleCourseDTO struct {
+	PublishAt null.Time `json:"publish_at" swaggertype:"string" example:"2024-09-01T09:00:00Z"`
 }
 
 type CourseDTO struct {
@@ -30,12 +47,23 @@ type CourseDTO struct {
 	SchoolID string `json:"school_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
 	Name     string `json:"name" example:"Course name"`
 	Level    int    `json:"level" example:"5"`
-	Price    int64  `json:"price" example:"3990"`
-	Language string `json:"language" example:"english"`
-	Status   string `json:"status" example:"published"`
+	// Price is in minor units of the currency, e.g. kopecks
+	Price        int64  `json:"price" example:"399000"`
+	Currency     string `json:"currency" example:"RUB"`
+	DisplayPrice string `json:"display_price" example:"3 990,00 ₽"`
+	Language     string `json:"language" example:"english"`
+	Status       string `json:"status" example:"published"`
+	Capacity     *int64 `json:"capacity" example:"30"`
+	AccessDays   *int64 `json:"access_days" example:"365"`
+	// Version is edited by teachers, PublishedVersion is studied by new students
+	Version          int `json:"version" example:"2"`
+	PublishedVersion int `json:"published_version" example:"1"`
+	// PublishAt is the date when the ready course is published by the scheduler
+	PublishAt *time.Time `json:"publish_at" example:"2024-09-01T09:00:00Z"`
 }
 
-func NewCourseDTO(course domain.Course) CourseDTO {
+// NewCourseDTO formats the display price in the notation of the locale
+func NewCourseDTO(course domain.Course, locale string) CourseDTO {
 	var status string
 	switch course.Status {
 	case domain.CourseDraft:
@@ -44,15 +72,104 @@ func NewCourseDTO(course domain.Course) CourseDTO {
 		status = CourseDTOReady
 	case domain.CoursePublished:
 		status = CourseDTOPublished
+	case domain.CourseUnpublished:
+		status = CourseDTOUnpublished
+	case domain.CourseArchived:
+		status = CourseDTOArchived
 	}
 
 	return CourseDTO{
-		ID:       course.ID.String(),
-		SchoolID: course.SchoolID.String(),
-		Name:     course.Name,
-		Level:    course.Level,
-		Price:    course.Price,
-		Language: course.Language,
-		Status:   status,
+		ID:           course.ID.String(),
+		SchoolID:     course.SchoolID.String(),
+		Name:         course.Name,
+		Level:        course.Level,
+		Price:        course.Price.Amount,
+		Currency:     course.Price.Currency,
+		DisplayPrice: course.Price.Format(locale),
+		Language:     course.Language,
+		Status:       status,
+		Capacity:     course.Capacity.Ptr(),
+		AccessDays:   course.AccessDays.Ptr(),
+
+		Version:          course.Version,
+		PublishedVersion: course.PublishedVersion,
+		PublishAt:        course.PublishAt.Ptr(),
+	}
+}
+
+type EnrollmentDTO struct {
+	StudentID  string     `json:"student_id" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	CourseID   string     `json:"course_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+	EnrolledAt time.Time  `json:"enrolled_at" example:"2024-05-01T10:00:00Z"`
+	ExpiresAt  *time.Time `json:"expires_at" example:"2025-05-01T10:00:00Z"`
+	Active     bool       `json:"active" example:"true"`
+	Version    int        `json:"version" example:"1"`
+}
+
+func NewEnrollmentDTO(enrollment domain.Enrollment) EnrollmentDTO {
+	return EnrollmentDTO{
+		StudentID:  enrollment.StudentID.String(),
+		CourseID:   enrollment.CourseID.String(),
+		EnrolledAt: enrollment.EnrolledAt,
+		ExpiresAt:  enrollment.ExpiresAt.Ptr(),
+		Active:     enrollment.Active(time.Now()),
+		Version:    enrollment.Version,
+	}
+}
+
+type ChangeCourseStatusDTO struct {
+	Status string `json:"status" binding:"required,oneof=draft ready published unpublished archived" example:"unpublished"`
+}
+
+type CourseTransitionDTO struct {
+	From      string    `json:"from" example:"published"`
+	To        string    `json:"to" example:"unpublished"`
+	UserID    string    `json:"user_id" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	CreatedAt time.Time `json:"created_at" example:"2024-05-01T10:00:00Z"`
+}
+
+func NewCourseTransitionDTO(transition domain.CourseTransition) CourseTransitionDTO {
+	return CourseTransitionDTO{
+		From:      transition.From.String(),
+		To:        transition.To.String(),
+		UserID:    transition.UserID.String(),
+		CreatedAt: transition.CreatedAt,
+	}
+}
+
+type ValidationIssueDTO struct {
+	Code     string `json:"code" example:"test_empty_options"`
+	Severity string `json:"severity" example:"error"`
+	LessonID string `json:"lesson_id,omitempty" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	TestID   string `json:"test_id,omitempty" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	Message  string `json:"message" example:"course practice lesson test has no options"`
+}
+
+type CourseValidationReportDTO struct {
+	CourseID string               `json:"course_id" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	Valid    bool                 `json:"valid" example:"false"`
+	Errors   int                  `json:"errors" example:"1"`
+	Warnings int                  `json:"warnings" example:"0"`
+	Issues   []ValidationIssueDTO `json:"issues"`
+}
+
+func NewCourseValidationReportDTO(report domain.CourseValidationReport) CourseValidationReportDTO {
+	issues := make([]ValidationIssueDTO, len(report.Issues))
+	for i, issue := range report.Issues {
+		issues[i] = ValidationIssueDTO{
+			Code:     issue.Code,
+			Severity: issue.Severity.String(),
+			LessonID: issue.LessonID.String(),
+			TestID:   issue.TestID.String(),
+			Message:  issue.Message,
+		}
+	}
+
+	return CourseValidationReportDTO{
+		CourseID: report.CourseID.String(),
+		Valid:    report.Valid(),
+		Errors:   report.Count(domain.ValidationError),
+		Warnings: report.Count(domain.ValidationWarning),
+		Issues:   issues,
 	}
 }
@@ -0,0 +1,5 @@
+package dto
+
+type GiftPaymentDTO struct {
+	RecipientEmail string `json:"recipient_email" binding:"required,email" example:"employee@example.com"`
+}
@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type CourseStatementDTO struct {
+	CourseID   string `json:"course_id" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	CourseName string `json:"course_name" example:"Go backend"`
+	Currency   string `json:"currency" example:"RUB"`
+	Sales      int    `json:"sales" example:"12"`
+	Refunds    int    `json:"refunds" example:"1"`
+	Gross      int64  `json:"gross" example:"32890"`
+	Commission int64  `json:"commission" example:"3289"`
+	Net        int64  `json:"net" example:"29601"`
+	Settled    int64  `json:"settled" example:"26910"`
+}
+
+type StatementTotalDTO struct {
+	Currency   string `json:"currency" example:"RUB"`
+	Gross      int64  `json:"gross" example:"32890"`
+	Commission int64  `json:"commission" example:"3289"`
+	Net        int64  `json:"net" example:"29601"`
+	Settled    int64  `json:"settled" example:"26910"`
+}
+
+type StatementDTO struct {
+	SchoolID string               `json:"school_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+	From     time.Time            `json:"from" example:"2024-10-01T00:00:00Z"`
+	To       time.Time            `json:"to" example:"2024-11-01T00:00:00Z"`
+	Courses  []CourseStatementDTO `json:"courses"`
+	Totals   []StatementTotalDTO  `json:"totals"`
+}
+
+type PayoutDTO struct {
+	ID           string    `json:"id" example:"30e18bc1-4354-4937-9a4d-03cf0b7027cb"`
+	SchoolID     string    `json:"school_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+	Amount       int64     `json:"amount" example:"26910"`
+	Currency     string    `json:"currency" example:"RUB"`
+	SettledUntil time.Time `json:"settled_until" example:"2024-11-01T00:00:00Z"`
+	CreatedAt    time.Time `json:"created_at" example:"2024-11-02T10:00:00Z"`
+}
+
+type CreatePayoutDTO struct {
+	Currency     string    `json:"currency" binding:"omitempty,len=3" example:"RUB"`
+	SettledUntil null.Time `json:"settled_until" binding:"omitempty" swaggertype:"string" example:"2024-11-01T00:00:00Z"`
+}
+
+func NewStatementDTO(statement domain.Statement) StatementDTO {
+	courseDTOs := make([]CourseStatementDTO, len(statement.Courses))
+	for i, course := range statement.Courses {
+		courseDTOs[i] = CourseStatementDTO{
+			CourseID:   course.CourseID.String(),
+			CourseName: course.CourseName,
+			Currency:   course.Currency,
+			Sales:      course.Sales,
+			Refunds:    course.Refunds,
+			Gross:      course.Gross,
+			Commission: course.Commission,
+			Net:        course.Net,
+			Settled:    course.Settled,
+		}
+	}
+
+	totalDTOs := make([]StatementTotalDTO, len(statement.Totals))
+	for i, total := range statement.Totals {
+		totalDTOs[i] = StatementTotalDTO{
+			Currency:   total.Currency,
+			Gross:      total.Gross,
+			Commission: total.Commission,
+			Net:        total.Net,
+			Settled:    total.Settled,
+		}
+	}
+
+	return StatementDTO{
+		SchoolID: statement.SchoolID.String(),
+		From:     statement.From,
+		To:       statement.To,
+		Courses:  courseDTOs,
+		Totals:   totalDTOs,
+	}
+}
+
+func NewPayoutDTO(payout domain.Payout) PayoutDTO {
+	return PayoutDTO{
+		ID:           payout.ID.String(),
+		SchoolID:     payout.SchoolID.String(),
+		Amount:       payout.Amount,
+		Currency:     payout.Currency,
+		SettledUntil: payout.SettledUntil,
+		CreatedAt:    payout.CreatedAt,
+	}
+}
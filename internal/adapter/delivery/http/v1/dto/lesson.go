@@ -36,6 +36,15 @@ type UpdateLessonDTO struct {
 	Tests    []CreateTestDTO `json:"tests" binding:"omitempty"`
 }
 
+type UpdateLessonReleaseDTO struct {
+	ReleaseAt   null.Time `json:"release_at" binding:"omitempty" swaggertype:"string" example:"2024-09-01T09:00:00Z"`
+	ReleaseDays null.Int  `json:"release_days" binding:"omitempty" swaggertype:"integer" example:"7"`
+}
+
+type UpdateLessonPreviewDTO struct {
+	IsPreview bool `json:"is_preview" example:"true"`
+}
+
 type PassLessonDTO struct {
 	PassTests []PassTestDTO `json:"tests" binding:"omitempty"`
 }
@@ -48,6 +57,7 @@ type PassTestDTO struct {
 type LessonDTO struct {
 	ID       string `json:"id"`
 	CourseID string `json:"course_id"`
+	Version  int    `json:"version"`
 	Title    string `json:"title"`
 	Score    int    `json:"score"`
 	Type     string `json:"type"`
@@ -55,6 +65,13 @@ type LessonDTO struct {
 	TheoryUrl null.String `json:"theory_url" binding:"omitempty" swaggertype:"string"`
 	VideoUrl  null.String `json:"video_url" binding:"omitempty" swaggertype:"string"`
 	Tests     []TestDTO   `json:"tests" binding:"omitempty"`
+
+	ReleaseAt   null.Time `json:"release_at" swaggertype:"string"`
+	ReleaseDays null.Int  `json:"release_days" swaggertype:"integer"`
+	// Locked lesson is not released for the student yet, its content is hidden
+	Locked bool `json:"locked"`
+	// preview lesson is readable by anyone without test answers
+	IsPreview bool `json:"is_preview"`
 }
 
 type TestDTO struct {
@@ -88,15 +105,31 @@ func NewLessonDTO(lesson domain.Lesson) LessonDTO {
 	return LessonDTO{
 		ID:        lesson.ID.String(),
 		CourseID:  lesson.CourseID.String(),
+		Version:   lesson.Version,
 		Title:     lesson.Title,
 		Score:     lesson.Score,
 		Type:      lessonType,
 		TheoryUrl: lesson.TheoryUrl,
 		VideoUrl:  lesson.VideoUrl,
 		Tests:     tests,
+
+		ReleaseAt:   lesson.ReleaseAt,
+		ReleaseDays: lesson.ReleaseDays,
+		IsPreview:   lesson.IsPreview,
 	}
 }
 
+func NewLockedLessonDTO(lesson domain.Lesson) LessonDTO {
+	lessonDTO := NewLessonDTO(lesson.Lock())
+	lessonDTO.Locked = true
+	return lessonDTO
+}
+
+// NewPreviewLessonDTO hides test answers from readers of the course preview
+func NewPreviewLessonDTO(lesson domain.Lesson) LessonDTO {
+	return NewLessonDTO(lesson.HideAnswers())
+}
+
 func NewTestDTO(test domain.Test) TestDTO {
 	return TestDTO{
 		ID:       test.ID.String(),
@@ -108,3 +141,16 @@ func NewTestDTO(test domain.Test) TestDTO {
 		Score:    test.Score,
 	}
 }
+
+// QuizImportDTO lists practice lessons created from the quiz package
+// and items of the package that are not imported
+type QuizImportDTO struct {
+	Lessons []LessonDTO          `json:"lessons"`
+	Skipped []SkippedQuizItemDTO `json:"skipped"`
+}
+
+type SkippedQuizItemDTO struct {
+	Identifier string `json:"identifier"`
+	Title      string `json:"title"`
+	Reason     string `json:"reason"`
+}
@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type AddPrerequisiteDTO struct {
+	// MinScore replaces completion of the required course with reaching the score
+	MinScore null.Int `json:"min_score" binding:"omitempty" swaggertype:"string" example:"50"`
+}
+
+type CoursePrerequisiteDTO struct {
+	Course   CourseDTO `json:"course"`
+	MinScore null.Int  `json:"min_score" swaggertype:"string" example:"50"`
+}
+
+type CourseProgressDTO struct {
+	CourseID      string `json:"course_id" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	Lessons       int    `json:"lessons" example:"12"`
+	PassedLessons int    `json:"passed_lessons" example:"7"`
+	Score         int    `json:"score" example:"45"`
+	MaxScore      int    `json:"max_score" example:"80"`
+	Completed     bool   `json:"completed" example:"false"`
+}
+
+type CreatePathDTO struct {
+	Name        string   `json:"name" binding:"required" example:"Backend developer"`
+	Description string   `json:"description" binding:"omitempty" example:"From the first program to production services"`
+	CourseIDs   []string `json:"course_ids" binding:"required,dive,uuid" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+}
+
+type PathDTO struct {
+	ID          string      `json:"id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+	SchoolID    string      `json:"school_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+	Name        string      `json:"name" example:"Backend developer"`
+	Description string      `json:"description" example:"From the first program to production services"`
+	Courses     []CourseDTO `json:"courses"`
+	CreatedAt   time.Time   `json:"created_at" example:"2024-10-01T00:00:00Z"`
+}
+
+type PathProgressDTO struct {
+	PathID           string              `json:"path_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+	CompletedCourses int                 `json:"completed_courses" example:"1"`
+	CurrentCourseID  string              `json:"current_course_id,omitempty" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	Score            int                 `json:"score" example:"120"`
+	Completed        bool                `json:"completed" example:"false"`
+	Courses          []CourseProgressDTO `json:"courses"`
+}
+
+type PathCertificateDTO struct {
+	ID       string    `json:"id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027cb"`
+	Path     PathDTO   `json:"path"`
+	UserID   string    `json:"user_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034ca"`
+	Score    int       `json:"score" example:"240"`
+	IssuedAt time.Time `json:"issued_at" example:"2024-10-01T00:00:00Z"`
+}
+
+func NewCoursePrerequisiteDTO(prerequisite domain.CoursePrerequisite,
+	required domain.Course, locale string) CoursePrerequisiteDTO {
+	return CoursePrerequisiteDTO{
+		Course:   NewCourseDTO(required, locale),
+		MinScore: prerequisite.MinScore,
+	}
+}
+
+func NewCourseProgressDTO(progress domain.CourseProgress) CourseProgressDTO {
+	return CourseProgressDTO{
+		CourseID:      progress.CourseID.String(),
+		Lessons:       progress.Lessons,
+		PassedLessons: progress.PassedLessons,
+		Score:         progress.Score,
+		MaxScore:      progress.MaxScore,
+		Completed:     progress.Completed(),
+	}
+}
+
+func NewPathDTO(path domain.LearningPath, courses []domain.Course, locale string) PathDTO {
+	courseDTOs := make([]CourseDTO, len(courses))
+	for i, course := range courses {
+		courseDTOs[i] = NewCourseDTO(course, locale)
+	}
+
+	return PathDTO{
+		ID:          path.ID.String(),
+		SchoolID:    path.SchoolID.String(),
+		Name:        path.Name,
+		Description: path.Description,
+		Courses:     courseDTOs,
+		CreatedAt:   path.CreatedAt,
+	}
+}
+
+func NewPathProgressDTO(progress domain.PathProgress) PathProgressDTO {
+	courseDTOs := make([]CourseProgressDTO, len(progress.Courses))
+	for i, course := range progress.Courses {
+		courseDTOs[i] = NewCourseProgressDTO(course)
+	}
+
+	return PathProgressDTO{
+		PathID:           progress.PathID.String(),
+		CompletedCourses: progress.CompletedCourses(),
+		CurrentCourseID:  progress.CurrentCourseID().String(),
+		Score:            progress.Score(),
+		Completed:        progress.Completed(),
+		Courses:          courseDTOs,
+	}
+}
+
+func NewPathCertificateDTO(certificate domain.PathCertificate, path PathDTO) PathCertificateDTO {
+	return PathCertificateDTO{
+		ID:       certificate.ID.String(),
+		Path:     path,
+		UserID:   certificate.UserID.String(),
+		Score:    certificate.Score,
+		IssuedAt: certificate.IssuedAt,
+	}
+}
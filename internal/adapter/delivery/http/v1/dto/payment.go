@@ -0,0 +1,23 @@
+package dto
+
+const (
+	AcquirerEventWaitingForCapture = "payment.waiting_for_capture"
+	AcquirerEventSucceeded         = "payment.succeeded"
+	AcquirerEventCanceled          = "payment.canceled"
+)
+
+type AcquirerAmountDTO struct {
+	Value    int64  `json:"value" example:"3990"`
+	Currency string `json:"currency" example:"RUB"`
+}
+
+type AcquirerPaymentDTO struct {
+	ID     string            `json:"id" binding:"required" example:"2f4e2b8a-8b55-4f1d-a6f4-8c7d5e1f0b33"`
+	Status string            `json:"status" example:"waiting_for_capture"`
+	Amount AcquirerAmountDTO `json:"amount"`
+}
+
+type AcquirerNotificationDTO struct {
+	Event  string             `json:"event" binding:"required" example:"payment.waiting_for_capture"`
+	Object AcquirerPaymentDTO `json:"object" binding:"required"`
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+const (
+	PromoDTOPercentDiscount = "percent"
+	PromoDTOFixedDiscount   = "fixed"
+)
+
+type CreatePromoCodeDTO struct {
+	CourseID     null.String `json:"course_id" binding:"omitempty" swaggertype:"string" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	Code         string      `json:"code" binding:"required" example:"SPRING25"`
+	DiscountType string      `json:"discount_type" binding:"required,oneof=percent fixed" example:"percent"`
+	Discount     int64       `json:"discount" binding:"required" example:"25"`
+	Currency     string      `json:"currency" binding:"omitempty,len=3" example:"RUB"`
+	MaxUses      null.Int    `json:"max_uses" binding:"omitempty" swaggertype:"string" example:"100"`
+	ValidFrom    null.Time   `json:"valid_from" binding:"omitempty" swaggertype:"string" example:"2024-10-01T00:00:00Z"`
+	ValidUntil   null.Time   `json:"valid_until" binding:"omitempty" swaggertype:"string" example:"2024-11-01T00:00:00Z"`
+}
+
+type PromoCodeDTO struct {
+	ID           string     `json:"id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+	SchoolID     string     `json:"school_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+	CourseID     *string    `json:"course_id" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	Code         string     `json:"code" example:"SPRING25"`
+	DiscountType string     `json:"discount_type" example:"percent"`
+	Discount     int64      `json:"discount" example:"25"`
+	Currency     *string    `json:"currency" example:"RUB"`
+	MaxUses      *int64     `json:"max_uses" example:"100"`
+	UsedCount    int64      `json:"used_count" example:"12"`
+	ValidFrom    *time.Time `json:"valid_from" example:"2024-10-01T00:00:00Z"`
+	ValidUntil   *time.Time `json:"valid_until" example:"2024-11-01T00:00:00Z"`
+}
+
+func NewPromoDiscountType(discountType string) domain.PromoDiscountType {
+	if discountType == PromoDTOFixedDiscount {
+		return domain.PromoFixedDiscount
+	}
+	return domain.PromoPercentDiscount
+}
+
+func NewPromoCodeDTO(promo domain.PromoCode) PromoCodeDTO {
+	var discountType string
+	switch promo.DiscountType {
+	case domain.PromoPercentDiscount:
+		discountType = PromoDTOPercentDiscount
+	case domain.PromoFixedDiscount:
+		discountType = PromoDTOFixedDiscount
+	}
+
+	return PromoCodeDTO{
+		ID:           promo.ID.String(),
+		SchoolID:     promo.SchoolID.String(),
+		CourseID:     promo.CourseID.Ptr(),
+		Code:         promo.Code,
+		DiscountType: discountType,
+		Discount:     promo.Discount,
+		Currency:     null.NewString(promo.Currency, promo.Currency != "").Ptr(),
+		MaxUses:      promo.MaxUses.Ptr(),
+		UsedCount:    promo.UsedCount,
+		ValidFrom:    promo.ValidFrom.Ptr(),
+		ValidUntil:   promo.ValidUntil.Ptr(),
+	}
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+const (
+	RefundDTORequested = "requested"
+	RefundDTOApproved  = "approved"
+	RefundDTORejected  = "rejected"
+	RefundDTOApproving = "approving"
+)
+
+type CreateRefundDTO struct {
+	Reason string `json:"reason" binding:"required" example:"Course does not match the description"`
+}
+
+type RefundDTO struct {
+	ID          string     `json:"id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+	UserID      string     `json:"user_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+	CourseID    string     `json:"course_id" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	Amount      int64      `json:"amount" example:"3990"`
+	Reason      string     `json:"reason" example:"Course does not match the description"`
+	Status      string     `json:"status" example:"requested"`
+	RequestedAt time.Time  `json:"requested_at" example:"2024-10-01T12:00:00Z"`
+	ResolvedBy  *string    `json:"resolved_by" example:"30e18bc1-4354-4937-9a3b-03cf0b7011aa"`
+	ResolvedAt  *time.Time `json:"resolved_at" example:"2024-10-02T12:00:00Z"`
+}
+
+func NewRefundDTO(refund domain.Refund) RefundDTO {
+	var status string
+	switch refund.Status {
+	case domain.RefundRequested:
+		status = RefundDTORequested
+	case domain.RefundApproved:
+		status = RefundDTOApproved
+	case domain.RefundRejected:
+		status = RefundDTORejected
+	case domain.RefundApproving:
+		status = RefundDTOApproving
+	}
+
+	return RefundDTO{
+		ID:          refund.ID.String(),
+		UserID:      refund.UserID.String(),
+		CourseID:    refund.CourseID.String(),
+		Amount:      refund.Amount,
+		Reason:      refund.Reason,
+		Status:      status,
+		RequestedAt: refund.RequestedAt,
+		ResolvedBy:  refund.ResolvedBy.Ptr(),
+		ResolvedAt:  refund.ResolvedAt.Ptr(),
+	}
+}
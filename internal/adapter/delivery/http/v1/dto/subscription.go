@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type SaveSubscriptionPlanDTO struct {
+	Name       string `json:"name" binding:"required" example:"All courses"`
+	Price      int64  `json:"price" binding:"required" example:"990"`
+	Currency   string `json:"currency" binding:"omitempty,len=3" example:"RUB"`
+	PeriodDays int    `json:"period_days" binding:"required" example:"30"`
+}
+
+type SubscriptionPlanDTO struct {
+	ID         string `json:"id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+	SchoolID   string `json:"school_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+	Name       string `json:"name" example:"All courses"`
+	Price      int64  `json:"price" example:"990"`
+	Currency   string `json:"currency" example:"RUB"`
+	PeriodDays int    `json:"period_days" example:"30"`
+}
+
+type SubscriptionDTO struct {
+	ID          string     `json:"id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+	PlanID      string     `json:"plan_id" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	SchoolID    string     `json:"school_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+	StartedAt   time.Time  `json:"started_at" example:"2024-05-01T10:00:00Z"`
+	ExpiresAt   time.Time  `json:"expires_at" example:"2024-05-31T10:00:00Z"`
+	CanceledAt  *time.Time `json:"canceled_at" example:"2024-05-10T10:00:00Z"`
+	Active      bool       `json:"active" example:"true"`
+	AutoRenewed bool       `json:"auto_renewed" example:"true"`
+}
+
+func NewSubscriptionPlanDTO(plan domain.SubscriptionPlan) SubscriptionPlanDTO {
+	return SubscriptionPlanDTO{
+		ID:         plan.ID.String(),
+		SchoolID:   plan.SchoolID.String(),
+		Name:       plan.Name,
+		Price:      plan.Price,
+		Currency:   plan.Currency,
+		PeriodDays: plan.PeriodDays,
+	}
+}
+
+func NewSubscriptionDTO(subscription domain.Subscription) SubscriptionDTO {
+	return SubscriptionDTO{
+		ID:          subscription.ID.String(),
+		PlanID:      subscription.PlanID.String(),
+		SchoolID:    subscription.SchoolID.String(),
+		StartedAt:   subscription.StartedAt,
+		ExpiresAt:   subscription.ExpiresAt,
+		CanceledAt:  subscription.CanceledAt.Ptr(),
+		Active:      subscription.Active(time.Now()),
+		AutoRenewed: subscription.AutoRenewable(),
+	}
+}
@@ -19,23 +19,25 @@ type UserInfoDTO struct {
 }
 
 type UserDTO struct {
-	ID        string `json:"id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
-	Name      string `json:"name" example:"Pavel"`
-	Surname   string `json:"surname" example:"Shpakovskiy"`
-	Email     string `json:"email" example:"paw1a@yandex.ru"`
-	Phone     string `json:"phone" example:"+79999999999"`
-	City      string `json:"city" example:"Moscow"`
-	AvatarUrl string `json:"avatar_url" example:"image.io/avatar.png"`
+	ID            string `json:"id" example:"30e18bc1-4354-4937-9a3b-03cf0b7027ca"`
+	Name          string `json:"name" example:"Pavel"`
+	Surname       string `json:"surname" example:"Shpakovskiy"`
+	Email         string `json:"email" example:"paw1a@yandex.ru"`
+	Phone         string `json:"phone" example:"+79999999999"`
+	City          string `json:"city" example:"Moscow"`
+	AvatarUrl     string `json:"avatar_url" example:"image.io/avatar.png"`
+	EmailVerified bool   `json:"email_verified" example:"true"`
 }
 
 func NewUserDTO(user domain.User) UserDTO {
 	return UserDTO{
-		ID:        user.ID.String(),
-		Name:      user.Name,
-		Surname:   user.Surname,
-		Phone:     user.Phone.String,
-		City:      user.City.String,
-		AvatarUrl: user.AvatarUrl.String,
-		Email:     user.Email,
+		ID:            user.ID.String(),
+		Name:          user.Name,
+		Surname:       user.Surname,
+		Phone:         user.Phone.String,
+		City:          user.City.String,
+		AvatarUrl:     user.AvatarUrl.String,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
 	}
 }
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type WaitlistEntryDTO struct {
+	CourseID     string     `json:"course_id" example:"30e18bc1-4354-4937-9a4d-03cf0b7027ca"`
+	UserID       string     `json:"user_id" example:"30e18bc1-4354-4937-9a3b-03cf0b7034cc"`
+	JoinedAt     time.Time  `json:"joined_at" example:"2024-10-01T12:00:00Z"`
+	OfferedUntil *time.Time `json:"offered_until" example:"2024-10-02T12:00:00Z"`
+}
+
+func NewWaitlistEntryDTO(entry domain.WaitlistEntry) WaitlistEntryDTO {
+	return WaitlistEntryDTO{
+		CourseID:     entry.CourseID.String(),
+		UserID:       entry.UserID.String(),
+		JoinedAt:     entry.JoinedAt,
+		OfferedUntil: entry.OfferedUntil.Ptr(),
+	}
+}
@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
+)
+
+func (h *Handler) initGiftRoutes(api *gin.RouterGroup) {
+	gifts := api.Group("/gifts", h.verifyToken)
+	{
+		gifts.POST("/:code/redeem", h.redeemGift)
+	}
+}
+
+// @Summary RedeemGift
+// @Tags gift
+// @Security ApiKeyAuth
+// @Description redeem gift code and enroll current user to the gift course
+// @Accept  json
+// @Produce json
+// @Param   code   path    string  true  "gift code"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.CourseDTO
+// @Router /gifts/{code}/redeem [post]
+func (h *Handler) redeemGift(context *gin.Context) {
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	gift, err := h.giftService.RedeemGift(context.Request.Context(), userID, context.Param("code"))
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	course, err := h.courseService.FindByID(context.Request.Context(), gift.CourseID)
+	if err == nil {
+		// waitlisted users get free seats before anyone else
+		err = h.waitlistService.OfferFreeSeats(context.Request.Context(), gift.CourseID)
+	}
+	if err == nil {
+		err = h.courseService.AddCourseStudent(context.Request.Context(), userID, gift.CourseID)
+	}
+	if err != nil {
+		// gift stays redeemable if the user was not enrolled
+		if cancelErr := h.giftService.CancelGiftRedemption(
+			context.Request.Context(), userID, gift); cancelErr != nil {
+			h.logger.Error(cancelErr.Error())
+		}
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, dto.NewCourseDTO(course, getLocale(context)))
+}
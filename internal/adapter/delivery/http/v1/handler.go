@@ -5,61 +5,106 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/paw1a/eschool/internal/core/port"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"math"
 	"net/http"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	Host string
-	Port string
+	Host       string
+	Port       string
+	AdminToken string // grants access to platform operations such as payouts
 }
 
 type Handler struct {
-	config         *Config
-	logger         *zap.Logger
-	userService    port.IUserService
-	schoolService  port.ISchoolService
-	lessonService  port.ILessonService
-	reviewService  port.IReviewService
-	courseService  port.ICourseService
-	mediaService   port.IMediaService
-	statService    port.IStatService
-	authService    port.IAuthTokenService
-	paymentService port.IPaymentService
+	config              *Config
+	logger              *zap.Logger
+	userService         port.IUserService
+	schoolService       port.ISchoolService
+	lessonService       port.ILessonService
+	reviewService       port.IReviewService
+	courseService       port.ICourseService
+	mediaService        port.IMediaService
+	statService         port.IStatService
+	authService         port.IAuthTokenService
+	apiKeyService       port.IApiKeyService
+	paymentService      port.IPaymentService
+	promoCodeService    port.IPromoCodeService
+	refundService       port.IRefundService
+	waitlistService     port.IWaitlistService
+	bundleService       port.IBundleService
+	ledgerService       port.ILedgerService
+	giftService         port.IGiftService
+	subscriptionService port.ISubscriptionService
+	jobService          port.IJobService
+	archiveService      port.ICourseArchiveService
+	quizService         port.IQuizImportService
+	pathService         port.ILearningPathService
+	catalogService      port.ICatalogService
+	rateLimiter         port.IRateLimiter
 }
 
 type HandlerParams struct {
 	fx.In
-	Config         *Config
-	Logger         *zap.Logger
-	UserService    port.IUserService
-	SchoolService  port.ISchoolService
-	LessonService  port.ILessonService
-	ReviewService  port.IReviewService
-	CourseService  port.ICourseService
-	MediaService   port.IMediaService
-	StatService    port.IStatService
-	AuthService    port.IAuthTokenService
-	PaymentService port.IPaymentService
+	Config              *Config
+	Logger              *zap.Logger
+	UserService         port.IUserService
+	SchoolService       port.ISchoolService
+	LessonService       port.ILessonService
+	ReviewService       port.IReviewService
+	CourseService       port.ICourseService
+	MediaService        port.IMediaService
+	StatService         port.IStatService
+	AuthService         port.IAuthTokenService
+	ApiKeyService       port.IApiKeyService
+	PaymentService      port.IPaymentService
+	PromoCodeService    port.IPromoCodeService
+	RefundService       port.IRefundService
+	WaitlistService     port.IWaitlistService
+	BundleService       port.IBundleService
+	LedgerService       port.ILedgerService
+	GiftService         port.IGiftService
+	SubscriptionService port.ISubscriptionService
+	JobService          port.IJobService
+	ArchiveService      port.ICourseArchiveService
+	QuizService         port.IQuizImportService
+	PathService         port.ILearningPathService
+	CatalogService      port.ICatalogService
+	RateLimiter         port.IRateLimiter
 }
 
 func NewHandler(params HandlerParams, router *gin.Engine) *Handler {
 	handler := &Handler{
-		config:         params.Config,
-		logger:         params.Logger,
-		userService:    params.UserService,
-		schoolService:  params.SchoolService,
-		lessonService:  params.LessonService,
-		reviewService:  params.ReviewService,
-		courseService:  params.CourseService,
-		mediaService:   params.MediaService,
-		statService:    params.StatService,
-		authService:    params.AuthService,
-		paymentService: params.PaymentService,
+		config:              params.Config,
+		logger:              params.Logger,
+		userService:         params.UserService,
+		schoolService:       params.SchoolService,
+		lessonService:       params.LessonService,
+		reviewService:       params.ReviewService,
+		courseService:       params.CourseService,
+		mediaService:        params.MediaService,
+		statService:         params.StatService,
+		authService:         params.AuthService,
+		apiKeyService:       params.ApiKeyService,
+		paymentService:      params.PaymentService,
+		promoCodeService:    params.PromoCodeService,
+		refundService:       params.RefundService,
+		waitlistService:     params.WaitlistService,
+		bundleService:       params.BundleService,
+		ledgerService:       params.LedgerService,
+		giftService:         params.GiftService,
+		subscriptionService: params.SubscriptionService,
+		jobService:          params.JobService,
+		archiveService:      params.ArchiveService,
+		quizService:         params.QuizService,
+		pathService:         params.PathService,
+		catalogService:      params.CatalogService,
+		rateLimiter:         params.RateLimiter,
 	}
 
 	v1 := router.Group("/api/v1")
@@ -70,6 +115,9 @@ func NewHandler(params HandlerParams, router *gin.Engine) *Handler {
 		handler.initCourseRoutes(v1)
 		handler.initSchoolRoutes(v1)
 		handler.initPaymentRoutes(v1)
+		handler.initGiftRoutes(v1)
+		handler.initCertificateRoutes(v1)
+		handler.initCategoryRoutes(v1)
 	}
 
 	return handler
@@ -99,6 +147,36 @@ func LoggerMiddleware(logger *zap.Logger) gin.HandlerFunc {
 	}
 }
 
+func RateLimitMiddleware(limiter port.IRateLimiter, prefix string, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := prefix + ":ip:" + c.ClientIP()
+		retryAfter, err := limiter.Check(c.Request.Context(), key)
+		if err != nil {
+			logger.Error("failed to check rate limit", zap.Error(err), zap.String("key", key))
+			c.Next()
+			return
+		}
+		if retryAfter == 0 {
+			retryAfter, err = limiter.Hit(c.Request.Context(), key)
+			if err != nil {
+				logger.Error("failed to register request", zap.Error(err), zap.String("key", key))
+				c.Next()
+				return
+			}
+		}
+
+		if retryAfter > 0 {
+			logger.Warn("rate limit is exceeded", zap.String("key", key),
+				zap.Duration("retryAfter", retryAfter))
+			setRetryAfterHeader(c, retryAfter)
+			restErr := NewRestError(http.StatusTooManyRequests, errs.ErrTooManyAttempts.Error())
+			c.AbortWithStatusJSON(restErr.Status(), restErr)
+			return
+		}
+		c.Next()
+	}
+}
+
 func getIdFromPath(c *gin.Context, paramName string) (domain.ID, error) {
 	idString := c.Param(paramName)
 	if idString == "" {
@@ -118,3 +196,14 @@ func getIdFromRequestContext(context *gin.Context) (domain.ID, error) {
 	}
 	return domain.ID(id.(string)), nil
 }
+
+// getLocale returns the most preferred language tag of the Accept-Language header
+func getLocale(context *gin.Context) string {
+	locale, _, _ := strings.Cut(context.GetHeader("Accept-Language"), ",")
+	locale, _, _ = strings.Cut(locale, ";")
+	locale = strings.TrimSpace(locale)
+	if locale == "" || locale == "*" {
+		return domain.DefaultLocale
+	}
+	return locale
+}
@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"crypto/subtle"
+	"github.com/gin-gonic/gin"
+	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
+	"time"
+)
+
+const (
+	adminTokenHeader = "X-Admin-Token"
+	statementMonth   = "2006-01"
+)
+
+// @Summary GetSchoolStatement
+// @Tags school
+// @Security ApiKeyAuth
+// @Description get monthly school revenue statement per course
+// @Accept  json
+// @Produce json
+// @Param   id     path    string  true   "school id"
+// @Param   month  query   string  false  "statement month in YYYY-MM format, current month by default"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.StatementDTO
+// @Router /schools/{id}/statements [get]
+func (h *Handler) findSchoolStatement(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	month := time.Now().UTC()
+	if monthString := context.Query("month"); monthString != "" {
+		month, err = time.Parse(statementMonth, monthString)
+		if err != nil {
+			h.errorResponse(context, BadRequestError)
+			return
+		}
+	}
+
+	statement, err := h.ledgerService.FindSchoolStatement(context.Request.Context(), schoolID, month)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, dto.NewStatementDTO(statement))
+}
+
+// @Summary GetSchoolPayouts
+// @Tags school
+// @Security ApiKeyAuth
+// @Description get school payouts history
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.PayoutDTO
+// @Router /schools/{id}/payouts [get]
+func (h *Handler) findSchoolPayouts(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	payouts, err := h.ledgerService.FindSchoolPayouts(context.Request.Context(), schoolID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	payoutDTOs := make([]dto.PayoutDTO, len(payouts))
+	for i, payout := range payouts {
+		payoutDTOs[i] = dto.NewPayoutDTO(payout)
+	}
+
+	h.successResponse(context, payoutDTOs)
+}
+
+// @Summary CreateSchoolPayout
+// @Tags school
+// @Description settle unsettled school earnings in one currency, requires platform admin token
+// @Accept  json
+// @Produce json
+// @Param   id            path    string               true  "school id"
+// @Param   X-Admin-Token header  string               true  "platform admin token"
+// @Param   payout        body    dto.CreatePayoutDTO  true  "payout"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.PayoutDTO
+// @Router /schools/{id}/payouts [post]
+func (h *Handler) createSchoolPayout(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	var createPayoutDTO dto.CreatePayoutDTO
+	err = context.ShouldBindJSON(&createPayoutDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	payout, err := h.ledgerService.CreateSchoolPayout(context.Request.Context(), schoolID,
+		createPayoutDTO.Currency, createPayoutDTO.SettledUntil.Time)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.createdResponse(context, dto.NewPayoutDTO(payout))
+}
+
+func (h *Handler) verifyAdminToken(context *gin.Context) {
+	token := context.GetHeader(adminTokenHeader)
+	if h.config.AdminToken == "" ||
+		subtle.ConstantTimeCompare([]byte(token), []byte(h.config.AdminToken)) != 1 {
+		h.errorResponse(context, ForbiddenError)
+		return
+	}
+}
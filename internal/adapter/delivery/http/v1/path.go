@@ -0,0 +1,292 @@
+package v1
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+)
+
+func (h *Handler) initCertificateRoutes(api *gin.RouterGroup) {
+	certificates := api.Group("/certificates")
+	{
+		certificates.GET("/:id", h.findPathCertificate)
+	}
+}
+
+// @Summary GetSchoolPaths
+// @Tags school
+// @Description get school learning paths
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.PathDTO
+// @Router /schools/{id}/paths [get]
+func (h *Handler) findSchoolPaths(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	paths, err := h.pathService.FindSchoolPaths(context.Request.Context(), schoolID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	pathDTOs := make([]dto.PathDTO, len(paths))
+	for i, path := range paths {
+		pathDTOs[i], err = h.newPathDTO(context.Request.Context(), path, getLocale(context))
+		if err != nil {
+			h.errorResponse(context, err)
+			return
+		}
+	}
+
+	h.successResponse(context, pathDTOs)
+}
+
+// @Summary CreateSchoolPath
+// @Tags school
+// @Security ApiKeyAuth
+// @Description create school learning path, courses are studied in the given order
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Param input body dto.CreatePathDTO true "created path info"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.PathDTO
+// @Router /schools/{id}/paths [post]
+func (h *Handler) createSchoolPath(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	var createPathDTO dto.CreatePathDTO
+	err = context.ShouldBindJSON(&createPathDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	courseIDs := make([]domain.ID, len(createPathDTO.CourseIDs))
+	for i, courseID := range createPathDTO.CourseIDs {
+		courseIDs[i] = domain.ID(courseID)
+	}
+
+	path, err := h.pathService.CreateSchoolPath(context.Request.Context(), schoolID,
+		port.CreatePathParam{
+			Name:        createPathDTO.Name,
+			Description: createPathDTO.Description,
+			CourseIDs:   courseIDs,
+		})
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	pathDTO, err := h.newPathDTO(context.Request.Context(), path, getLocale(context))
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.createdResponse(context, pathDTO)
+}
+
+// @Summary DeleteSchoolPath
+// @Tags school
+// @Security ApiKeyAuth
+// @Description delete school learning path, issued certificates are deleted too
+// @Accept  json
+// @Produce json
+// @Param   schoolID   path    string  true  "school id"
+// @Param   pathID     path    string  true  "path id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /schools/{schoolID}/paths/{pathID} [delete]
+func (h *Handler) deleteSchoolPath(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	pathID, err := getIdFromPath(context, "path_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.pathService.Delete(context.Request.Context(), schoolID, pathID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "successfully deleted")
+}
+
+// @Summary GetSchoolPathProgress
+// @Tags school
+// @Security ApiKeyAuth
+// @Description get progress of current user across courses of the learning path
+// @Accept  json
+// @Produce json
+// @Param   schoolID   path    string  true  "school id"
+// @Param   pathID     path    string  true  "path id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.PathProgressDTO
+// @Router /schools/{schoolID}/paths/{pathID}/progress [get]
+func (h *Handler) findPathProgress(context *gin.Context) {
+	path, err := h.findSchoolPath(context)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	progress, err := h.pathService.FindPathProgress(context.Request.Context(), userID, path.ID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, dto.NewPathProgressDTO(progress))
+}
+
+// @Summary IssueSchoolPathCertificate
+// @Tags school
+// @Security ApiKeyAuth
+// @Description issue certificate of the completed learning path, issued certificate is returned again
+// @Accept  json
+// @Produce json
+// @Param   schoolID   path    string  true  "school id"
+// @Param   pathID     path    string  true  "path id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.PathCertificateDTO
+// @Router /schools/{schoolID}/paths/{pathID}/certificate [post]
+func (h *Handler) issuePathCertificate(context *gin.Context) {
+	path, err := h.findSchoolPath(context)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	certificate, err := h.pathService.IssuePathCertificate(context.Request.Context(), userID, path.ID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	pathDTO, err := h.newPathDTO(context.Request.Context(), path, getLocale(context))
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.createdResponse(context, dto.NewPathCertificateDTO(certificate, pathDTO))
+}
+
+// @Summary GetPathCertificate
+// @Tags certificate
+// @Description verify learning path certificate by its id
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "certificate id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.PathCertificateDTO
+// @Router /certificates/{id} [get]
+func (h *Handler) findPathCertificate(context *gin.Context) {
+	certificateID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	certificate, err := h.pathService.FindCertificate(context.Request.Context(), certificateID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	path, err := h.pathService.FindByID(context.Request.Context(), certificate.PathID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	pathDTO, err := h.newPathDTO(context.Request.Context(), path, getLocale(context))
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, dto.NewPathCertificateDTO(certificate, pathDTO))
+}
+
+// findSchoolPath returns the path from the request,
+// path of another school is not found
+func (h *Handler) findSchoolPath(context *gin.Context) (domain.LearningPath, error) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		return domain.LearningPath{}, err
+	}
+
+	pathID, err := getIdFromPath(context, "path_id")
+	if err != nil {
+		return domain.LearningPath{}, err
+	}
+
+	path, err := h.pathService.FindByID(context.Request.Context(), pathID)
+	if err != nil {
+		return domain.LearningPath{}, err
+	}
+	if path.SchoolID != schoolID {
+		return domain.LearningPath{}, errs.ErrNotExist
+	}
+	return path, nil
+}
+
+func (h *Handler) newPathDTO(ctx context.Context, path domain.LearningPath, locale string) (dto.PathDTO, error) {
+	courses, err := h.pathService.FindPathCourses(ctx, path.ID)
+	if err != nil {
+		return dto.PathDTO{}, err
+	}
+	return dto.NewPathDTO(path, courses, locale), nil
+}
@@ -2,17 +2,21 @@ package v1
 
 import (
 	"github.com/gin-gonic/gin"
-	"strconv"
-	"strings"
+	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
+	"github.com/paw1a/eschool/internal/core/domain"
 )
 
 func (h *Handler) initPaymentRoutes(api *gin.RouterGroup) {
 	payment := api.Group("/payment")
 	{
 		payment.POST("/webhook", h.processCoursePayment)
+		payment.POST("/acquirer/webhook", h.processAcquirerNotification)
 		authenticated := payment.Group("/", h.verifyToken)
 		{
 			authenticated.GET("/courses/:id", h.getCoursePaymentUrl)
+			authenticated.GET("/bundles/:id", h.getBundlePaymentUrl)
+			authenticated.POST("/courses/:id/gift", h.getGiftPaymentUrl)
+			authenticated.GET("/schools/:id/subscription", h.getSubscriptionPaymentUrl)
 		}
 	}
 }
@@ -23,9 +27,12 @@ func (h *Handler) initPaymentRoutes(api *gin.RouterGroup) {
 // @Accept  json
 // @Produce json
 // @Param   id   path    string  true  "course id"
+// @Param   promo   query    string  false  "promo code"
 // @Failure 400 {object} RestErrorBadRequest
 // @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
 // @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
 // @Failure 500 {object} RestErrorInternalError
 // @Success 200 {string} string "url"
 // @Router /payment/courses/{id} [get]
@@ -42,8 +49,109 @@ func (h *Handler) getCoursePaymentUrl(context *gin.Context) {
 		return
 	}
 
+	// waitlisted users get free seats before anyone else
+	err = h.waitlistService.OfferFreeSeats(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
 	url, err := h.paymentService.GetCoursePaymentUrl(
-		context.Request.Context(), userID, courseID)
+		context.Request.Context(), userID, courseID, context.Query("promo"))
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, url.String())
+}
+
+// @Summary GetBundlePaymentUrl
+// @Tags payment
+// @Description get bundle payment url, price is reduced by shares of already bought courses
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "bundle id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "url"
+// @Router /payment/bundles/{id} [get]
+func (h *Handler) getBundlePaymentUrl(context *gin.Context) {
+	bundleID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	courses, err := h.bundleService.FindBundleCourses(context.Request.Context(), bundleID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	// waitlisted users get free seats before anyone else
+	for _, course := range courses {
+		err = h.waitlistService.OfferFreeSeats(context.Request.Context(), course.ID)
+		if err != nil {
+			h.errorResponse(context, err)
+			return
+		}
+	}
+
+	url, err := h.paymentService.GetBundlePaymentUrl(context.Request.Context(), userID, bundleID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, url.String())
+}
+
+// @Summary GetGiftPaymentUrl
+// @Tags payment
+// @Description get payment url of the course bought as a gift, gift code is sent to the recipient,
+// @Description courses with seat limit can not be gifted
+// @Accept  json
+// @Produce json
+// @Param   id     path    string              true  "course id"
+// @Param   gift   body    dto.GiftPaymentDTO  true  "gift recipient"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "url"
+// @Router /payment/courses/{id}/gift [post]
+func (h *Handler) getGiftPaymentUrl(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	var giftPaymentDTO dto.GiftPaymentDTO
+	err = context.ShouldBindJSON(&giftPaymentDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	url, err := h.paymentService.GetGiftPaymentUrl(context.Request.Context(), userID, courseID,
+		giftPaymentDTO.RecipientEmail)
 	if err != nil {
 		h.errorResponse(context, err)
 		return
@@ -56,20 +164,71 @@ func (h *Handler) processCoursePayment(context *gin.Context) {
 	key := context.PostForm("label")
 	paid := context.PostForm("withdraw_amount")
 
-	paidDigits := strings.Split(paid, ".")
-	paidInt, err := strconv.ParseInt(paidDigits[0], 10, 64)
+	// yoomoney wallets are in rubles, amount is sent with kopecks after the dot
+	paidAmount, err := domain.ParseAmount(paid, "RUB")
 	if err != nil {
 		h.errorResponse(context, BadRequestError)
 		return
 	}
 
-	payload, err := h.paymentService.ProcessCoursePayment(context.Request.Context(), key, paidInt)
+	payload, err := h.paymentService.ProcessCoursePayment(context.Request.Context(), key, paidAmount)
 	if err != nil {
 		h.errorResponse(context, err)
 		return
 	}
 
-	err = h.courseService.AddCourseStudent(context.Request.Context(), payload.UserID, payload.CourseID)
+	h.completeCoursePayment(context, key, payload)
+}
+
+// @Summary ProcessAcquirerNotification
+// @Tags payment
+// @Description card acquirer payment status webhook
+// @Accept  json
+// @Produce json
+// @Param   input body dto.AcquirerNotificationDTO true "acquirer notification"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "ok"
+// @Router /payment/acquirer/webhook [post]
+func (h *Handler) processAcquirerNotification(context *gin.Context) {
+	var notificationDTO dto.AcquirerNotificationDTO
+	if err := context.BindJSON(&notificationDTO); err != nil {
+		h.errorResponse(context, BadRequestError)
+		return
+	}
+
+	// canceled payments need no action, acquirer expects 200 to stop retries
+	if notificationDTO.Event != dto.AcquirerEventWaitingForCapture &&
+		notificationDTO.Event != dto.AcquirerEventSucceeded {
+		h.successResponse(context, "ignored")
+		return
+	}
+
+	// notification is unauthenticated, amount of the body is not trusted
+	key := notificationDTO.Object.ID
+	payload, err := h.paymentService.ProcessVerifiedPayment(context.Request.Context(), key)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.completeCoursePayment(context, key, payload)
+}
+
+func (h *Handler) completeCoursePayment(context *gin.Context, key string, payload domain.PaymentPayload) {
+	var err error
+	if payload.BundleID != "" {
+		err = h.addBundleStudent(context.Request.Context(), payload.UserID, payload.BundleID)
+	} else if payload.SubscriptionPlanID != "" {
+		_, err = h.subscriptionService.ActivateSubscription(context.Request.Context(), key, payload)
+	} else if payload.RecipientEmail != "" {
+		_, err = h.giftService.IssueGift(context.Request.Context(), key, payload)
+	} else if payload.Renewal {
+		_, err = h.courseService.RenewCourseStudent(context.Request.Context(),
+			payload.UserID, payload.CourseID, key)
+	} else {
+		err = h.courseService.AddCourseStudent(context.Request.Context(), payload.UserID, payload.CourseID)
+	}
 	if err != nil {
 		h.errorResponse(context, err)
 		return
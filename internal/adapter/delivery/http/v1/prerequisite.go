@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
+	"github.com/paw1a/eschool/internal/core/port"
+)
+
+// @Summary GetCoursePrerequisites
+// @Tags course
+// @Description get courses required to enroll in the course
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.CoursePrerequisiteDTO
+// @Router /courses/{id}/prerequisites [get]
+func (h *Handler) findCoursePrerequisites(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	prerequisites, err := h.courseService.FindCoursePrerequisites(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	prerequisiteDTOs := make([]dto.CoursePrerequisiteDTO, len(prerequisites))
+	for i, prerequisite := range prerequisites {
+		required, err := h.courseService.FindByID(context.Request.Context(), prerequisite.RequiredCourseID)
+		if err != nil {
+			h.errorResponse(context, err)
+			return
+		}
+		prerequisiteDTOs[i] = dto.NewCoursePrerequisiteDTO(prerequisite, required, getLocale(context))
+	}
+
+	h.successResponse(context, prerequisiteDTOs)
+}
+
+// @Summary AddCoursePrerequisite
+// @Tags course
+// @Security ApiKeyAuth
+// @Description require completion of another school course or reaching its score before enrollment
+// @Accept  json
+// @Produce json
+// @Param   courseID     path    string  true  "course id"
+// @Param   requiredID   path    string  true  "required course id"
+// @Param input body dto.AddPrerequisiteDTO true "prerequisite info"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /courses/{courseID}/prerequisites/{requiredID} [put]
+func (h *Handler) addCoursePrerequisite(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	requiredCourseID, err := getIdFromPath(context, "required_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	var addPrerequisiteDTO dto.AddPrerequisiteDTO
+	err = context.ShouldBindJSON(&addPrerequisiteDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.courseService.AddCoursePrerequisite(context.Request.Context(), courseID,
+		port.AddPrerequisiteParam{
+			RequiredCourseID: requiredCourseID,
+			MinScore:         addPrerequisiteDTO.MinScore,
+		})
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "successfully added prerequisite")
+}
+
+// @Summary RemoveCoursePrerequisite
+// @Tags course
+// @Security ApiKeyAuth
+// @Description remove course prerequisite
+// @Accept  json
+// @Produce json
+// @Param   courseID     path    string  true  "course id"
+// @Param   requiredID   path    string  true  "required course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /courses/{courseID}/prerequisites/{requiredID} [delete]
+func (h *Handler) removeCoursePrerequisite(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	requiredCourseID, err := getIdFromPath(context, "required_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.courseService.RemoveCoursePrerequisite(context.Request.Context(), courseID, requiredCourseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "successfully removed prerequisite")
+}
+
+// @Summary GetCourseProgress
+// @Tags course
+// @Security ApiKeyAuth
+// @Description get progress of current user in the course
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.CourseProgressDTO
+// @Router /courses/{id}/progress [get]
+func (h *Handler) findCourseProgress(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	progress, err := h.courseService.FindCourseProgress(context.Request.Context(), userID, courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, dto.NewCourseProgressDTO(progress))
+}
@@ -0,0 +1,137 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
+	"github.com/paw1a/eschool/internal/core/port"
+)
+
+// @Summary GetSchoolPromoCodes
+// @Tags school
+// @Security ApiKeyAuth
+// @Description get school promo codes
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.PromoCodeDTO
+// @Router /schools/{id}/promo-codes [get]
+func (h *Handler) findSchoolPromoCodes(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	promos, err := h.promoCodeService.FindSchoolPromoCodes(context.Request.Context(), schoolID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	promoDTOs := make([]dto.PromoCodeDTO, len(promos))
+	for i, promo := range promos {
+		promoDTOs[i] = dto.NewPromoCodeDTO(promo)
+	}
+
+	h.successResponse(context, promoDTOs)
+}
+
+// @Summary CreateSchoolPromoCode
+// @Tags school
+// @Security ApiKeyAuth
+// @Description create school or course promo code
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Param input body dto.CreatePromoCodeDTO true "created promo code info"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.PromoCodeDTO
+// @Router /schools/{id}/promo-codes [post]
+func (h *Handler) createSchoolPromoCode(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	var createPromoDTO dto.CreatePromoCodeDTO
+	err = context.ShouldBindJSON(&createPromoDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	if createPromoDTO.CourseID.Valid {
+		if _, err = uuid.Parse(createPromoDTO.CourseID.String); err != nil {
+			h.errorResponse(context, BadRequestError)
+			return
+		}
+	}
+
+	promo, err := h.promoCodeService.CreateSchoolPromoCode(context.Request.Context(),
+		schoolID, port.CreatePromoCodeParam{
+			CourseID:     createPromoDTO.CourseID,
+			Code:         createPromoDTO.Code,
+			DiscountType: dto.NewPromoDiscountType(createPromoDTO.DiscountType),
+			Discount:     createPromoDTO.Discount,
+			Currency:     createPromoDTO.Currency,
+			MaxUses:      createPromoDTO.MaxUses,
+			ValidFrom:    createPromoDTO.ValidFrom,
+			ValidUntil:   createPromoDTO.ValidUntil,
+		})
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	promoDTO := dto.NewPromoCodeDTO(promo)
+	h.createdResponse(context, promoDTO)
+}
+
+// @Summary DeleteSchoolPromoCode
+// @Tags school
+// @Security ApiKeyAuth
+// @Description delete school promo code
+// @Accept  json
+// @Produce json
+// @Param   schoolID   path    string  true  "school id"
+// @Param   promoID   path    string  true  "promo code id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /schools/{schoolID}/promo-codes/{promoID} [delete]
+func (h *Handler) deleteSchoolPromoCode(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	promoID, err := getIdFromPath(context, "promo_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.promoCodeService.Delete(context.Request.Context(), schoolID, promoID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "successfully deleted")
+}
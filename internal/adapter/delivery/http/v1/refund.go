@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/port"
+)
+
+// @Summary RequestCourseRefund
+// @Tags course
+// @Security ApiKeyAuth
+// @Description request refund for a bought course
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Param input body dto.CreateRefundDTO true "refund reason"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.RefundDTO
+// @Router /courses/{id}/refunds [post]
+func (h *Handler) requestCourseRefund(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	var createRefundDTO dto.CreateRefundDTO
+	err = context.ShouldBindJSON(&createRefundDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	refund, err := h.refundService.RequestCourseRefund(context.Request.Context(), userID, courseID,
+		port.CreateRefundParam{Reason: createRefundDTO.Reason})
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	refundDTO := dto.NewRefundDTO(refund)
+	h.createdResponse(context, refundDTO)
+}
+
+// @Summary GetUserRefunds
+// @Tags user
+// @Security ApiKeyAuth
+// @Description get current user refund requests
+// @Accept  json
+// @Produce json
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.RefundDTO
+// @Router /users/me/refunds [get]
+func (h *Handler) findUserRefunds(context *gin.Context) {
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	refunds, err := h.refundService.FindUserRefunds(context.Request.Context(), userID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, newRefundDTOs(refunds))
+}
+
+// @Summary GetSchoolRefunds
+// @Tags school
+// @Security ApiKeyAuth
+// @Description get refund requests for school courses
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.RefundDTO
+// @Router /schools/{id}/refunds [get]
+func (h *Handler) findSchoolRefunds(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	refunds, err := h.refundService.FindSchoolRefunds(context.Request.Context(), schoolID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, newRefundDTOs(refunds))
+}
+
+// @Summary ApproveSchoolRefund
+// @Tags school
+// @Security ApiKeyAuth
+// @Description approve refund, payment is returned and the access period it paid is revoked,
+// @Description refund left approving by failed approval is approved again
+// @Accept  json
+// @Produce json
+// @Param   schoolID   path    string  true  "school id"
+// @Param   refundID   path    string  true  "refund id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.RefundDTO
+// @Router /schools/{schoolID}/refunds/{refundID}/approve [post]
+func (h *Handler) approveSchoolRefund(context *gin.Context) {
+	h.resolveSchoolRefund(context, true)
+}
+
+// @Summary RejectSchoolRefund
+// @Tags school
+// @Security ApiKeyAuth
+// @Description reject refund request
+// @Accept  json
+// @Produce json
+// @Param   schoolID   path    string  true  "school id"
+// @Param   refundID   path    string  true  "refund id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.RefundDTO
+// @Router /schools/{schoolID}/refunds/{refundID}/reject [post]
+func (h *Handler) rejectSchoolRefund(context *gin.Context) {
+	h.resolveSchoolRefund(context, false)
+}
+
+func (h *Handler) resolveSchoolRefund(context *gin.Context, approve bool) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	refundID, err := getIdFromPath(context, "refund_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	var refund domain.Refund
+	if approve {
+		refund, err = h.refundService.ApproveRefund(context.Request.Context(), schoolID, refundID, userID)
+	} else {
+		refund, err = h.refundService.RejectRefund(context.Request.Context(), schoolID, refundID, userID)
+	}
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	refundDTO := dto.NewRefundDTO(refund)
+	h.successResponse(context, refundDTO)
+}
+
+func newRefundDTOs(refunds []domain.Refund) []dto.RefundDTO {
+	refundDTOs := make([]dto.RefundDTO, len(refunds))
+	for i, refund := range refunds {
+		refundDTOs[i] = dto.NewRefundDTO(refund)
+	}
+	return refundDTOs
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/pkg/errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -45,6 +47,10 @@ var errorStatusMap = map[error]int{
 	errs.ErrCoursePublishedState:                 http.StatusBadRequest,
 	errs.ErrCourseInvalidLevel:                   http.StatusBadRequest,
 	errs.ErrCourseInvalidPrice:                   http.StatusBadRequest,
+	errs.ErrCourseInvalidCurrency:                http.StatusBadRequest,
+	errs.ErrCourseNotForSale:                     http.StatusForbidden,
+	errs.ErrCourseInvalidCapacity:                http.StatusBadRequest,
+	errs.ErrCourseInvalidAccessPeriod:            http.StatusBadRequest,
 	errs.ErrFilenameEmpty:                        http.StatusBadRequest,
 	errs.ErrFilepathEmpty:                        http.StatusBadRequest,
 	errs.ErrFileReaderEmpty:                      http.StatusBadRequest,
@@ -53,6 +59,63 @@ var errorStatusMap = map[error]int{
 	errs.ErrUserIsAlreadyCourseStudent:           http.StatusConflict,
 	errs.ErrInvalidPaymentSum:                    http.StatusBadRequest,
 	errs.ErrDecodePaymentKeyFailed:               http.StatusBadRequest,
+	errs.ErrInvalidPromoCode:                     http.StatusBadRequest,
+	errs.ErrPromoCodeExhausted:                   http.StatusBadRequest,
+	errs.ErrPromoCodeNotApplicable:               http.StatusBadRequest,
+	errs.ErrPromoCodeEmptyCode:                   http.StatusBadRequest,
+	errs.ErrPromoCodeInvalidDiscount:             http.StatusBadRequest,
+	errs.ErrPromoCodeInvalidMaxUses:              http.StatusBadRequest,
+	errs.ErrPromoCodeInvalidValidity:             http.StatusBadRequest,
+	errs.ErrPromoCodeDuplicate:                   http.StatusConflict,
+	errs.ErrPromoCodeInvalidCurrency:             http.StatusBadRequest,
+	errs.ErrPromoCodeCurrencyMismatch:            http.StatusBadRequest,
+	errs.ErrUserIsNotCourseStudent:               http.StatusBadRequest,
+	errs.ErrCourseNotPaid:                        http.StatusBadRequest,
+	errs.ErrRefundAlreadyRequested:               http.StatusConflict,
+	errs.ErrRefundAlreadyResolved:                http.StatusConflict,
+	errs.ErrPaymentNotCompleted:                  http.StatusBadRequest,
+	errs.ErrCourseIsFull:                         http.StatusConflict,
+	errs.ErrCourseHasNoCapacity:                  http.StatusBadRequest,
+	errs.ErrWaitlistAlreadyJoined:                http.StatusConflict,
+	errs.ErrBundleInvalidPrice:                   http.StatusBadRequest,
+	errs.ErrBundleTooFewCourses:                  http.StatusBadRequest,
+	errs.ErrBundleCourseNotInSchool:              http.StatusBadRequest,
+	errs.ErrBundleCourseNotPublished:             http.StatusBadRequest,
+	errs.ErrBundlePublishedState:                 http.StatusBadRequest,
+	errs.ErrBundleNotPublished:                   http.StatusBadRequest,
+	errs.ErrBundleAlreadyOwned:                   http.StatusConflict,
+	errs.ErrNothingToSettle:                      http.StatusConflict,
+	errs.ErrPayoutInvalidCurrency:                http.StatusBadRequest,
+	errs.ErrGiftAlreadyRedeemed:                  http.StatusConflict,
+	errs.ErrGiftCourseHasCapacity:                http.StatusBadRequest,
+	errs.ErrCourseNotRenewable:                   http.StatusBadRequest,
+	errs.ErrSubscriptionInvalidPrice:             http.StatusBadRequest,
+	errs.ErrSubscriptionInvalidPeriod:            http.StatusBadRequest,
+	errs.ErrSubscriptionInvalidCurrency:          http.StatusBadRequest,
+	errs.ErrSubscriptionAlreadyActive:            http.StatusConflict,
+	errs.ErrSubscriptionNotActive:                http.StatusBadRequest,
+	errs.ErrRecurringPaymentNotSupported:         http.StatusBadRequest,
+	errs.ErrCurrencyNotSupported:                 http.StatusBadRequest,
+	errs.ErrInvalidMoneyAmount:                   http.StatusBadRequest,
+	errs.ErrBundleMixedCurrencies:                http.StatusBadRequest,
+	errs.ErrCourseRevisionExists:                 http.StatusConflict,
+	errs.ErrCourseNoRevision:                     http.StatusConflict,
+	errs.ErrLessonPublished:                      http.StatusConflict,
+	errs.ErrEnrollmentLatestVersion:              http.StatusConflict,
+	errs.ErrCoursePublishAtInPast:                http.StatusBadRequest,
+	errs.ErrLessonInvalidRelease:                 http.StatusBadRequest,
+	errs.ErrLessonNotReleased:                    http.StatusForbidden,
+	errs.ErrLessonPreviewLimit:                   http.StatusConflict,
+	errs.ErrCoursePrerequisiteSelf:               http.StatusBadRequest,
+	errs.ErrCoursePrerequisiteCycle:              http.StatusConflict,
+	errs.ErrCoursePrerequisiteNotInSchool:        http.StatusBadRequest,
+	errs.ErrCoursePrerequisiteInvalidScore:       http.StatusBadRequest,
+	errs.ErrLearningPathTooFewCourses:            http.StatusBadRequest,
+	errs.ErrLearningPathCourseNotInSchool:        http.StatusBadRequest,
+	errs.ErrLearningPathNotCompleted:             http.StatusForbidden,
+	errs.ErrCategoryNotEmpty:                     http.StatusConflict,
+	errs.ErrTagInvalid:                           http.StatusBadRequest,
+	errs.ErrCourseTooManyTags:                    http.StatusBadRequest,
 
 	errs.ErrDuplicate:         http.StatusBadRequest,
 	errs.ErrNotExist:          http.StatusNotFound,
@@ -62,12 +125,26 @@ var errorStatusMap = map[error]int{
 	errs.ErrEnumValueError:    http.StatusInternalServerError,
 	errs.ErrTransactionError:  http.StatusInternalServerError,
 
-	errs.ErrNotUniqueEmail:          http.StatusConflict,
-	errs.ErrInvalidCredentials:      http.StatusUnauthorized,
-	errs.ErrAuthSessionIsNotPresent: http.StatusUnauthorized,
-	errs.ErrInvalidTokenSignMethod:  http.StatusUnauthorized,
-	errs.ErrInvalidTokenClaims:      http.StatusUnauthorized,
-	errs.ErrInvalidFingerprint:      http.StatusUnauthorized,
+	errs.ErrNotUniqueEmail:           http.StatusConflict,
+	errs.ErrInvalidCredentials:       http.StatusUnauthorized,
+	errs.ErrAuthSessionIsNotPresent:  http.StatusUnauthorized,
+	errs.ErrInvalidTokenSignMethod:   http.StatusUnauthorized,
+	errs.ErrInvalidTokenClaims:       http.StatusUnauthorized,
+	errs.ErrInvalidFingerprint:       http.StatusUnauthorized,
+	errs.ErrInvalidEmailToken:        http.StatusBadRequest,
+	errs.ErrEmailNotVerified:         http.StatusForbidden,
+	errs.ErrInvalidMfaToken:          http.StatusUnauthorized,
+	errs.ErrInvalidMfaCode:           http.StatusUnauthorized,
+	errs.ErrMfaNotEnrolled:           http.StatusBadRequest,
+	errs.ErrMfaAlreadyEnabled:        http.StatusConflict,
+	errs.ErrTooManyAttempts:          http.StatusTooManyRequests,
+	errs.ErrUnknownIdentityProvider:  http.StatusNotFound,
+	errs.ErrInvalidOidcState:         http.StatusBadRequest,
+	errs.ErrExternalEmailNotVerified: http.StatusForbidden,
+	errs.ErrInvalidApiKey:            http.StatusUnauthorized,
+	errs.ErrApiKeyInvalidScope:       http.StatusBadRequest,
+	errs.ErrApiKeyEmptyName:          http.StatusBadRequest,
+	errs.ErrApiKeyScopeDenied:        http.StatusForbidden,
 
 	PathIdParamIsEmptyError:  http.StatusBadRequest,
 	PathIdParamIsInvalidUUID: http.StatusBadRequest,
@@ -114,6 +191,12 @@ type RestErrorConflict struct {
 	Timestamp  time.Time `json:"timestamp,omitempty" example:"2020-11-10T23:00:00+00:00"`
 }
 
+type RestErrorTooManyRequests struct {
+	ErrStatus  int       `json:"status,omitempty" example:"429"`
+	ErrMessage string    `json:"error,omitempty" example:"too many attempts, try again later"`
+	Timestamp  time.Time `json:"timestamp,omitempty" example:"2020-11-10T23:00:00+00:00"`
+}
+
 type RestErrorInternalError struct {
 	ErrStatus  int       `json:"status,omitempty" example:"500"`
 	ErrMessage string    `json:"error,omitempty" example:"internal server error"`
@@ -167,6 +250,23 @@ func ParseError(err error) RestErr {
 		return NewRestError(http.StatusNotFound, ErrNotFound)
 	case errors.Is(err, errs.ErrInvalidToken):
 		return NewRestError(http.StatusUnauthorized, err.Error())
+	case errors.Is(err, errs.ErrOidcExchangeFailed):
+		return NewRestError(http.StatusUnauthorized, errs.ErrOidcExchangeFailed.Error())
+	case errors.Is(err, errs.ErrPaymentGatewayFailed):
+		return NewRestError(http.StatusBadGateway, errs.ErrPaymentGatewayFailed.Error())
+	case errors.Is(err, errs.ErrCourseInvalidTransition):
+		return NewRestError(http.StatusConflict, err.Error())
+	case errors.Is(err, errs.ErrCoursePrerequisitesNotMet):
+		return NewRestError(http.StatusForbidden, err.Error())
+	case errors.Is(err, errs.ErrCourseArchiveInvalid), errors.Is(err, errs.ErrCourseArchiveVersion),
+		errors.Is(err, errs.ErrCourseArchiveMissingFile):
+		return NewRestError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, errs.ErrQuizPackageInvalid):
+		return NewRestError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, errs.ErrCourseArchiveTooLarge), errors.Is(err, errs.ErrQuizPackageTooLarge):
+		return NewRestError(http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, errs.ErrTooManyAttempts):
+		return NewRestError(http.StatusTooManyRequests, errs.ErrTooManyAttempts.Error())
 	case errors.As(err, &validationErrors):
 		return NewRestError(http.StatusBadRequest, getValidationMessage(validationErrors[0]))
 	default:
@@ -183,6 +283,10 @@ func ParseError(err error) RestErr {
 func (h *Handler) errorResponse(context *gin.Context, err error) {
 	h.logger.Error(err.Error())
 	restErr := ParseError(err)
+	var retryAfterErr *errs.RetryAfterError
+	if errors.As(err, &retryAfterErr) {
+		setRetryAfterHeader(context, retryAfterErr.RetryAfter)
+	}
 	context.AbortWithStatusJSON(restErr.Status(), restErr)
 }
 
@@ -193,3 +297,8 @@ func (h *Handler) successResponse(context *gin.Context, data interface{}) {
 func (h *Handler) createdResponse(context *gin.Context, data interface{}) {
 	context.JSON(http.StatusCreated, data)
 }
+
+func setRetryAfterHeader(context *gin.Context, retryAfter time.Duration) {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	context.Header("Retry-After", strconv.FormatInt(seconds, 10))
+}
@@ -12,6 +12,11 @@ func (h *Handler) initSchoolRoutes(api *gin.RouterGroup) {
 	{
 		schools.GET("/", h.findAllSchools)
 		schools.GET("/:id", h.findSchoolByID)
+		schools.GET("/:id/catalog", h.findSchoolCatalog)
+		schools.GET("/:id/subscription-plan", h.findSchoolSubscriptionPlan)
+		schools.GET("/:id/paths", h.findSchoolPaths)
+		schools.GET("/:id/tags", h.findSchoolTags)
+		schools.POST("/:id/payouts", h.verifyAdminToken, h.createSchoolPayout)
 		authenticated := schools.Group("/", h.verifyToken)
 		{
 			authenticated.POST("/", h.createSchool)
@@ -19,8 +24,36 @@ func (h *Handler) initSchoolRoutes(api *gin.RouterGroup) {
 
 			authenticated.GET("/:id/courses", h.findSchoolCourses)
 			authenticated.POST("/:id/courses", h.verifySchoolOwner, h.createSchoolCourse)
+			authenticated.POST("/:id/courses/import", h.verifySchoolOwner, h.importSchoolCourse)
 			authenticated.PATCH("/:id/courses/:course_id", h.verifySchoolOwner, h.updateSchoolCourse)
 			authenticated.DELETE("/:id/courses/:course_id", h.verifySchoolOwner, h.deleteSchoolCourse)
+			authenticated.PUT("/:id/courses/:course_id/status", h.verifySchoolOwner, h.changeSchoolCourseStatus)
+			authenticated.GET("/:id/courses/:course_id/transitions", h.verifySchoolOwner, h.findSchoolCourseTransitions)
+			authenticated.PUT("/:id/courses/:course_id/schedule", h.verifySchoolOwner, h.scheduleSchoolCourse)
+
+			authenticated.GET("/:id/promo-codes", h.verifySchoolOwner, h.findSchoolPromoCodes)
+			authenticated.POST("/:id/promo-codes", h.verifySchoolOwner, h.createSchoolPromoCode)
+			authenticated.DELETE("/:id/promo-codes/:promo_id", h.verifySchoolOwner, h.deleteSchoolPromoCode)
+
+			authenticated.GET("/:id/bundles", h.verifySchoolOwner, h.findSchoolBundles)
+			authenticated.POST("/:id/bundles", h.verifySchoolOwner, h.createSchoolBundle)
+			authenticated.POST("/:id/bundles/:bundle_id/publish", h.verifySchoolOwner, h.publishSchoolBundle)
+			authenticated.DELETE("/:id/bundles/:bundle_id", h.verifySchoolOwner, h.deleteSchoolBundle)
+
+			authenticated.POST("/:id/paths", h.verifySchoolOwner, h.createSchoolPath)
+			authenticated.DELETE("/:id/paths/:path_id", h.verifySchoolOwner, h.deleteSchoolPath)
+			authenticated.GET("/:id/paths/:path_id/progress", h.findPathProgress)
+			authenticated.POST("/:id/paths/:path_id/certificate", h.issuePathCertificate)
+
+			authenticated.PUT("/:id/subscription-plan", h.verifySchoolOwner, h.saveSchoolSubscriptionPlan)
+			authenticated.DELETE("/:id/subscription", h.cancelSchoolSubscription)
+
+			authenticated.GET("/:id/statements", h.verifySchoolOwner, h.findSchoolStatement)
+			authenticated.GET("/:id/payouts", h.verifySchoolOwner, h.findSchoolPayouts)
+
+			authenticated.GET("/:id/refunds", h.verifySchoolOwner, h.findSchoolRefunds)
+			authenticated.POST("/:id/refunds/:refund_id/approve", h.verifySchoolOwner, h.approveSchoolRefund)
+			authenticated.POST("/:id/refunds/:refund_id/reject", h.verifySchoolOwner, h.rejectSchoolRefund)
 
 			authenticated.GET("/:id/teachers", h.findSchoolTeachers)
 			// https://datatracker.ietf.org/doc/html/rfc2616#section-9.6
@@ -197,7 +230,7 @@ func (h *Handler) findSchoolCourses(context *gin.Context) {
 
 	courseDTOs := make([]dto.CourseDTO, len(courses))
 	for i, course := range courses {
-		courseDTOs[i] = dto.NewCourseDTO(course)
+		courseDTOs[i] = dto.NewCourseDTO(course, getLocale(context))
 	}
 
 	h.successResponse(context, courseDTOs)
@@ -234,10 +267,13 @@ func (h *Handler) createSchoolCourse(context *gin.Context) {
 
 	course, err := h.courseService.CreateSchoolCourse(context.Request.Context(),
 		schoolID, port.CreateCourseParam{
-			Name:     createCourseDTO.Name,
-			Level:    int(createCourseDTO.Level.Int64),
-			Price:    createCourseDTO.Price.Int64,
-			Language: createCourseDTO.Language,
+			Name:       createCourseDTO.Name,
+			Level:      int(createCourseDTO.Level.Int64),
+			Price:      createCourseDTO.Price.Int64,
+			Currency:   createCourseDTO.Currency,
+			Language:   createCourseDTO.Language,
+			Capacity:   createCourseDTO.Capacity,
+			AccessDays: createCourseDTO.AccessDays,
 		})
 	if err != nil {
 		h.errorResponse(context, err)
@@ -256,7 +292,65 @@ func (h *Handler) createSchoolCourse(context *gin.Context) {
 		return
 	}
 
-	courseDTO := dto.NewCourseDTO(course)
+	courseDTO := dto.NewCourseDTO(course, getLocale(context))
+	h.createdResponse(context, courseDTO)
+}
+
+// @Summary ImportSchoolCourse
+// @Tags school
+// @Security ApiKeyAuth
+// @Description create draft school course from the zip archive made by course export
+// @Accept  multipart/form-data
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Param archive formData file true "course zip archive"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 413 {object} RestError
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.CourseDTO
+// @Router /schools/{id}/courses/import [post]
+func (h *Handler) importSchoolCourse(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	fileHeader, err := context.FormFile("archive")
+	if err != nil {
+		h.errorResponse(context, BadRequestError)
+		return
+	}
+	archive, err := fileHeader.Open()
+	if err != nil {
+		h.errorResponse(context, BadRequestError)
+		return
+	}
+	defer archive.Close()
+
+	course, err := h.archiveService.ImportCourse(context.Request.Context(),
+		schoolID, archive, fileHeader.Size)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.courseService.AddCourseTeacher(context.Request.Context(), userID, course.ID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	courseDTO := dto.NewCourseDTO(course, getLocale(context))
 	h.createdResponse(context, courseDTO)
 }
 
@@ -292,17 +386,20 @@ func (h *Handler) updateSchoolCourse(context *gin.Context) {
 
 	course, err := h.courseService.Update(context.Request.Context(),
 		courseID, port.UpdateCourseParam{
-			Name:     updateCourseDTO.Name,
-			Level:    updateCourseDTO.Level,
-			Price:    updateCourseDTO.Price,
-			Language: updateCourseDTO.Language,
+			Name:       updateCourseDTO.Name,
+			Level:      updateCourseDTO.Level,
+			Price:      updateCourseDTO.Price,
+			Currency:   updateCourseDTO.Currency,
+			Language:   updateCourseDTO.Language,
+			Capacity:   updateCourseDTO.Capacity,
+			AccessDays: updateCourseDTO.AccessDays,
 		})
 	if err != nil {
 		h.errorResponse(context, err)
 		return
 	}
 
-	courseDTO := dto.NewCourseDTO(course)
+	courseDTO := dto.NewCourseDTO(course, getLocale(context))
 	h.successResponse(context, courseDTO)
 }
 
@@ -337,6 +434,157 @@ func (h *Handler) deleteSchoolCourse(context *gin.Context) {
 	h.successResponse(context, "successfully deleted")
 }
 
+// @Summary ChangeSchoolCourseStatus
+// @Tags school
+// @Security ApiKeyAuth
+// @Description move school course to another status
+// @Accept  json
+// @Produce json
+// @Param   schoolID   path    string  true  "school id"
+// @Param   courseID   path    string  true  "course id"
+// @Param input body dto.ChangeCourseStatusDTO true "new course status"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.CourseDTO
+// @Router /schools/{schoolID}/courses/{courseID}/status [put]
+func (h *Handler) changeSchoolCourseStatus(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "course_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	var changeStatusDTO dto.ChangeCourseStatusDTO
+	err = context.ShouldBindJSON(&changeStatusDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	status, ok := domain.ParseCourseStatus(changeStatusDTO.Status)
+	if !ok {
+		h.errorResponse(context, BadRequestError)
+		return
+	}
+
+	err = h.courseService.ChangeCourseStatus(context.Request.Context(), userID, courseID, status)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	course, err := h.courseService.FindByID(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	courseDTO := dto.NewCourseDTO(course, getLocale(context))
+	h.successResponse(context, courseDTO)
+}
+
+// @Summary ScheduleSchoolCourse
+// @Tags school
+// @Security ApiKeyAuth
+// @Description publish ready school course at the given date, null date cancels the schedule
+// @Accept  json
+// @Produce json
+// @Param   schoolID   path    string  true  "school id"
+// @Param   courseID   path    string  true  "course id"
+// @Param input body dto.ScheduleCourseDTO true "publish date"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.CourseDTO
+// @Router /schools/{schoolID}/courses/{courseID}/schedule [put]
+func (h *Handler) scheduleSchoolCourse(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "course_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	var scheduleDTO dto.ScheduleCourseDTO
+	err = context.ShouldBindJSON(&scheduleDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	course, err := h.courseService.ScheduleCoursePublish(context.Request.Context(), courseID, scheduleDTO.PublishAt)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	// jobs of the previous schedule are skipped by the scheduler, see PublishScheduledCourse
+	if course.PublishAt.Valid {
+		job := domain.NewJob(domain.JobPublishCourse, course.ID, userID, course.PublishAt.Time)
+		err = h.jobService.ScheduleJob(context.Request.Context(), job)
+		if err != nil {
+			h.errorResponse(context, err)
+			return
+		}
+	}
+
+	courseDTO := dto.NewCourseDTO(course, getLocale(context))
+	h.successResponse(context, courseDTO)
+}
+
+// @Summary GetSchoolCourseTransitions
+// @Tags school
+// @Security ApiKeyAuth
+// @Description get status history of the school course
+// @Accept  json
+// @Produce json
+// @Param   schoolID   path    string  true  "school id"
+// @Param   courseID   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.CourseTransitionDTO
+// @Router /schools/{schoolID}/courses/{courseID}/transitions [get]
+func (h *Handler) findSchoolCourseTransitions(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "course_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	transitions, err := h.courseService.FindCourseTransitions(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	transitionDTOs := make([]dto.CourseTransitionDTO, len(transitions))
+	for i, transition := range transitions {
+		transitionDTOs[i] = dto.NewCourseTransitionDTO(transition)
+	}
+
+	h.successResponse(context, transitionDTOs)
+}
+
 // @Summary GetSchoolTeachers
 // @Tags school
 // @Description get school teachers
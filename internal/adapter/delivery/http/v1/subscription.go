@@ -0,0 +1,177 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
+	"github.com/paw1a/eschool/internal/core/port"
+)
+
+// @Summary GetSchoolSubscriptionPlan
+// @Tags school
+// @Description get school subscription plan, subscribers study every published course of the school
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.SubscriptionPlanDTO
+// @Router /schools/{id}/subscription-plan [get]
+func (h *Handler) findSchoolSubscriptionPlan(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	plan, err := h.subscriptionService.FindSchoolPlan(context.Request.Context(), schoolID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, dto.NewSubscriptionPlanDTO(plan))
+}
+
+// @Summary SaveSchoolSubscriptionPlan
+// @Tags school
+// @Security ApiKeyAuth
+// @Description create or update school subscription plan, changes apply to the next payments
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Param input body dto.SaveSubscriptionPlanDTO true "subscription plan info"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.SubscriptionPlanDTO
+// @Router /schools/{id}/subscription-plan [put]
+func (h *Handler) saveSchoolSubscriptionPlan(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	var planDTO dto.SaveSubscriptionPlanDTO
+	err = context.ShouldBindJSON(&planDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	plan, err := h.subscriptionService.SaveSchoolPlan(context.Request.Context(), schoolID,
+		port.SubscriptionPlanParam{
+			Name:       planDTO.Name,
+			Price:      planDTO.Price,
+			Currency:   planDTO.Currency,
+			PeriodDays: planDTO.PeriodDays,
+		})
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, dto.NewSubscriptionPlanDTO(plan))
+}
+
+// @Summary CancelSchoolSubscription
+// @Tags school
+// @Security ApiKeyAuth
+// @Description cancel automatic renewals of the current user subscription, paid period stays active
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "ok"
+// @Router /schools/{id}/subscription [delete]
+func (h *Handler) cancelSchoolSubscription(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	err = h.subscriptionService.CancelSubscription(context.Request.Context(), userID, schoolID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "successfully canceled subscription")
+}
+
+// @Summary GetSubscriptionPaymentUrl
+// @Tags payment
+// @Security ApiKeyAuth
+// @Description get school subscription payment url, the payment method is saved for renewals
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "school id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "url"
+// @Router /payment/schools/{id}/subscription [get]
+func (h *Handler) getSubscriptionPaymentUrl(context *gin.Context) {
+	schoolID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	url, err := h.subscriptionService.GetSubscriptionPaymentUrl(context.Request.Context(), userID, schoolID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, url.String())
+}
+
+// @Summary GetUserSubscriptions
+// @Tags user
+// @Security ApiKeyAuth
+// @Description get school subscriptions of the current user
+// @Accept  json
+// @Produce json
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.SubscriptionDTO
+// @Router /users/me/subscriptions [get]
+func (h *Handler) findUserSubscriptions(context *gin.Context) {
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	subscriptions, err := h.subscriptionService.FindUserSubscriptions(context.Request.Context(), userID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	subscriptionDTOs := make([]dto.SubscriptionDTO, len(subscriptions))
+	for i, subscription := range subscriptions {
+		subscriptionDTOs[i] = dto.NewSubscriptionDTO(subscription)
+	}
+	h.successResponse(context, subscriptionDTOs)
+}
@@ -3,6 +3,8 @@ package v1
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/paw1a/eschool/internal/core/port"
 )
 
@@ -16,6 +18,18 @@ func (h *Handler) initUsersRoutes(api *gin.RouterGroup) {
 			authenticated.GET("/me", h.findUserAccount)
 			authenticated.PATCH("/me", h.updateUser)
 
+			authenticated.POST("/me/mfa", h.enrollUserMfa)
+			authenticated.POST("/me/mfa/confirm", h.confirmUserMfa)
+			authenticated.DELETE("/me/mfa", h.disableUserMfa)
+
+			authenticated.GET("/me/api-keys", h.findUserApiKeys)
+			authenticated.POST("/me/api-keys", h.createUserApiKey)
+			authenticated.DELETE("/me/api-keys/:key_id", h.revokeUserApiKey)
+
+			authenticated.GET("/me/refunds", h.findUserRefunds)
+
+			authenticated.GET("/me/subscriptions", h.findUserSubscriptions)
+
 			authenticated.GET("/me/courses", h.findUserCourses)
 			authenticated.PUT("/me/courses/:course_id", h.addUserFreeCourse)
 		}
@@ -151,6 +165,8 @@ func (h *Handler) updateUser(context *gin.Context) {
 // @Param id path string true "course id"
 // @Failure 400 {object} RestErrorBadRequest
 // @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 409 {object} RestErrorConflict
 // @Failure 500 {object} RestErrorInternalError
 // @Success 200 {string} string
 // @Router /users/me/courses/{id} [put]
@@ -167,17 +183,35 @@ func (h *Handler) addUserFreeCourse(context *gin.Context) {
 		return
 	}
 
-	if course.Price > 0 {
+	if course.Price.Amount > 0 {
 		h.errorResponse(context, ForbiddenError)
 		return
 	}
 
+	if !course.Status.Listed() {
+		h.errorResponse(context, errs.ErrCourseNotForSale)
+		return
+	}
+
 	userID, err := getIdFromRequestContext(context)
 	if err != nil {
 		h.errorResponse(context, UnauthorizedError)
 		return
 	}
 
+	err = h.courseService.CheckCoursePrerequisites(context.Request.Context(), userID, courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	// waitlisted users get free seats before anyone else
+	err = h.waitlistService.OfferFreeSeats(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
 	err = h.courseService.AddCourseStudent(context.Request.Context(), userID, courseID)
 	if err != nil {
 		h.errorResponse(context, err)
@@ -213,8 +247,219 @@ func (h *Handler) findUserCourses(context *gin.Context) {
 
 	courseDTOs := make([]dto.CourseDTO, len(courses))
 	for i, course := range courses {
-		courseDTOs[i] = dto.NewCourseDTO(course)
+		courseDTOs[i] = dto.NewCourseDTO(course, getLocale(context))
 	}
 
 	h.successResponse(context, courseDTOs)
 }
+
+// @Summary EnrollUserMfa
+// @Tags user
+// @Security ApiKeyAuth
+// @Description start two-factor authentication enrollment
+// @Accept  json
+// @Produce json
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} dto.MfaEnrollmentDTO
+// @Router /users/me/mfa [post]
+func (h *Handler) enrollUserMfa(context *gin.Context) {
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	enrollment, err := h.authService.EnrollMfa(context.Request.Context(), userID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, dto.NewMfaEnrollmentDTO(enrollment))
+}
+
+// @Summary ConfirmUserMfa
+// @Tags user
+// @Security ApiKeyAuth
+// @Description enable two-factor authentication with the first code
+// @Accept  json
+// @Produce json
+// @Param input body dto.MfaCodeDTO true "totp code"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /users/me/mfa/confirm [post]
+func (h *Handler) confirmUserMfa(context *gin.Context) {
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	var mfaCodeDTO dto.MfaCodeDTO
+	err = context.ShouldBindJSON(&mfaCodeDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.authService.ConfirmMfa(context.Request.Context(), userID, mfaCodeDTO.Code)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "two-factor authentication is enabled")
+}
+
+// @Summary DisableUserMfa
+// @Tags user
+// @Security ApiKeyAuth
+// @Description disable two-factor authentication
+// @Accept  json
+// @Produce json
+// @Param input body dto.MfaCodeDTO true "totp or recovery code"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /users/me/mfa [delete]
+func (h *Handler) disableUserMfa(context *gin.Context) {
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	var mfaCodeDTO dto.MfaCodeDTO
+	err = context.ShouldBindJSON(&mfaCodeDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.authService.DisableMfa(context.Request.Context(), userID, mfaCodeDTO.Code)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "two-factor authentication is disabled")
+}
+
+// @Summary FindUserApiKeys
+// @Tags user
+// @Security ApiKeyAuth
+// @Description find user api keys
+// @Accept  json
+// @Produce json
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.ApiKeyDTO
+// @Router /users/me/api-keys [get]
+func (h *Handler) findUserApiKeys(context *gin.Context) {
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	keys, err := h.apiKeyService.FindUserKeys(context.Request.Context(), userID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	keyDTOs := make([]dto.ApiKeyDTO, len(keys))
+	for i, key := range keys {
+		keyDTOs[i] = dto.NewApiKeyDTO(key)
+	}
+
+	h.successResponse(context, keyDTOs)
+}
+
+// @Summary CreateUserApiKey
+// @Tags user
+// @Security ApiKeyAuth
+// @Description create api key, the key is shown only once
+// @Accept  json
+// @Produce json
+// @Param input body dto.CreateApiKeyDTO true "api key name and scopes"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.CreatedApiKeyDTO
+// @Router /users/me/api-keys [post]
+func (h *Handler) createUserApiKey(context *gin.Context) {
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	var createApiKeyDTO dto.CreateApiKeyDTO
+	err = context.ShouldBindJSON(&createApiKeyDTO)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	scopes := make([]domain.ApiKeyScope, len(createApiKeyDTO.Scopes))
+	for i, scope := range createApiKeyDTO.Scopes {
+		scopes[i] = domain.ApiKeyScope(scope)
+	}
+
+	key, token, err := h.apiKeyService.Create(context.Request.Context(), userID, port.CreateApiKeyParam{
+		Name:   createApiKeyDTO.Name,
+		Scopes: scopes,
+	})
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.createdResponse(context, dto.CreatedApiKeyDTO{
+		Key:    token.String(),
+		ApiKey: dto.NewApiKeyDTO(key),
+	})
+}
+
+// @Summary RevokeUserApiKey
+// @Tags user
+// @Security ApiKeyAuth
+// @Description revoke api key
+// @Accept  json
+// @Produce json
+// @Param key_id path string true "api key id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string "message"
+// @Router /users/me/api-keys/{key_id} [delete]
+func (h *Handler) revokeUserApiKey(context *gin.Context) {
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	keyID, err := getIdFromPath(context, "key_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.apiKeyService.Revoke(context.Request.Context(), userID, keyID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "api key is revoked")
+}
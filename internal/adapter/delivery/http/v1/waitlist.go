@@ -0,0 +1,172 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/paw1a/eschool/internal/adapter/delivery/http/v1/dto"
+)
+
+// @Summary FindCourseWaitlist
+// @Tags course
+// @Security ApiKeyAuth
+// @Description find course waitlist, available to school owner
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {object} []dto.WaitlistEntryDTO
+// @Router /courses/{id}/waitlist [get]
+func (h *Handler) findCourseWaitlist(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	course, err := h.courseService.FindByID(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	if !h.checkCurrentUserIsSchoolOwner(context, course.SchoolID) {
+		h.errorResponse(context, ForbiddenError)
+		return
+	}
+
+	entries, err := h.waitlistService.FindCourseWaitlist(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	entryDTOs := make([]dto.WaitlistEntryDTO, len(entries))
+	for i, entry := range entries {
+		entryDTOs[i] = dto.NewWaitlistEntryDTO(entry)
+	}
+
+	h.successResponse(context, entryDTOs)
+}
+
+// @Summary JoinCourseWaitlist
+// @Tags course
+// @Security ApiKeyAuth
+// @Description join waitlist of a course with limited capacity, free seat is offered right away
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 409 {object} RestErrorConflict
+// @Failure 500 {object} RestErrorInternalError
+// @Success 201 {object} dto.WaitlistEntryDTO
+// @Router /courses/{id}/waitlist [post]
+func (h *Handler) joinCourseWaitlist(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	entry, err := h.waitlistService.JoinCourseWaitlist(context.Request.Context(), userID, courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	entryDTO := dto.NewWaitlistEntryDTO(entry)
+	h.createdResponse(context, entryDTO)
+}
+
+// @Summary LeaveCourseWaitlist
+// @Tags course
+// @Security ApiKeyAuth
+// @Description leave course waitlist, offered seat is passed to the next user
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string
+// @Router /courses/{id}/waitlist [delete]
+func (h *Handler) leaveCourseWaitlist(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	userID, err := getIdFromRequestContext(context)
+	if err != nil {
+		h.errorResponse(context, UnauthorizedError)
+		return
+	}
+
+	err = h.waitlistService.LeaveCourseWaitlist(context.Request.Context(), userID, courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "successfully left course waitlist")
+}
+
+// @Summary RemoveCourseStudent
+// @Tags course
+// @Security ApiKeyAuth
+// @Description remove student from the course, freed seat is offered to the waitlist
+// @Accept  json
+// @Produce json
+// @Param   id   path    string  true  "course id"
+// @Param   student_id   path    string  true  "student id"
+// @Failure 400 {object} RestErrorBadRequest
+// @Failure 401 {object} RestErrorUnauthorized
+// @Failure 403 {object} RestErrorForbidden
+// @Failure 404 {object} RestErrorNotFound
+// @Failure 500 {object} RestErrorInternalError
+// @Success 200 {string} string
+// @Router /courses/{id}/students/{student_id} [delete]
+func (h *Handler) removeCourseStudent(context *gin.Context) {
+	courseID, err := getIdFromPath(context, "id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	course, err := h.courseService.FindByID(context.Request.Context(), courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	if !h.checkCurrentUserIsSchoolOwner(context, course.SchoolID) {
+		h.errorResponse(context, ForbiddenError)
+		return
+	}
+
+	studentID, err := getIdFromPath(context, "student_id")
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	err = h.courseService.RemoveCourseStudent(context.Request.Context(), studentID, courseID)
+	if err != nil {
+		h.errorResponse(context, err)
+		return
+	}
+
+	h.successResponse(context, "successfully removed student")
+}
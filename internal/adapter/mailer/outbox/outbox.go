@@ -0,0 +1,44 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	Path string
+}
+
+type OutboxMailer struct {
+	config *Config
+}
+
+func NewMailer(config *Config) *OutboxMailer {
+	return &OutboxMailer{
+		config: config,
+	}
+}
+
+func (m *OutboxMailer) Send(ctx context.Context, mail domain.Mail) error {
+	if err := os.MkdirAll(m.config.Path, 0755); err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), domain.NewID())
+	return os.WriteFile(filepath.Join(m.config.Path, filename), buildMessage(mail), 0644)
+}
+
+func buildMessage(mail domain.Mail) []byte {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("To: %s\n", mail.To))
+	builder.WriteString(fmt.Sprintf("Subject: %s\n", mail.Subject))
+	builder.WriteString(fmt.Sprintf("Date: %s\n", time.Now().Format(time.RFC1123Z)))
+	builder.WriteString("\n")
+	builder.WriteString(mail.Body)
+	return []byte(builder.String())
+}
@@ -0,0 +1,50 @@
+package smtp
+
+import (
+	"context"
+	"fmt"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+type Config struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type SmtpMailer struct {
+	config *Config
+}
+
+func NewMailer(config *Config) *SmtpMailer {
+	return &SmtpMailer{
+		config: config,
+	}
+}
+
+func (m *SmtpMailer) Send(ctx context.Context, mail domain.Mail) error {
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	addr := net.JoinHostPort(m.config.Host, m.config.Port)
+	return smtp.SendMail(addr, auth, m.config.From, []string{mail.To}, buildMessage(m.config.From, mail))
+}
+
+func buildMessage(from string, mail domain.Mail) []byte {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("From: %s\r\n", from))
+	builder.WriteString(fmt.Sprintf("To: %s\r\n", mail.To))
+	builder.WriteString(fmt.Sprintf("Subject: %s\r\n", mail.Subject))
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(mail.Body)
+	return []byte(builder.String())
+}
@@ -0,0 +1,347 @@
+package acquirer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	httpTimeout = 10 * time.Second
+	maxBodySize = 1 << 20
+)
+
+const (
+	StatusPending           = "pending"
+	StatusWaitingForCapture = "waiting_for_capture"
+	StatusSucceeded         = "succeeded"
+	StatusCanceled          = "canceled"
+)
+
+type Config struct {
+	BaseUrl   string
+	ShopID    string
+	SecretKey string
+	ReturnUrl string
+	// Currencies accepted by the shop, only the default currency if empty
+	Currencies []string
+}
+
+// Amount value is in minor units of the currency
+type Amount struct {
+	Value    int64  `json:"value"`
+	Currency string `json:"currency"`
+}
+
+type Metadata struct {
+	UserID             string `json:"user_id,omitempty"`
+	CourseID           string `json:"course_id,omitempty"`
+	BundleID           string `json:"bundle_id,omitempty"`
+	PromoCodeID        string `json:"promo_code_id,omitempty"`
+	RecipientEmail     string `json:"recipient_email,omitempty"`
+	SubscriptionPlanID string `json:"subscription_plan_id,omitempty"`
+	// metadata values are strings only, so the flag is "true" or empty
+	Renewal string `json:"renewal,omitempty"`
+}
+
+// CreatePaymentRequest with payment method id charges the saved method
+// without customer confirmation
+type CreatePaymentRequest struct {
+	Amount            Amount   `json:"amount"`
+	Capture           bool     `json:"capture"`
+	Description       string   `json:"description,omitempty"`
+	ReturnUrl         string   `json:"return_url,omitempty"`
+	Metadata          Metadata `json:"metadata"`
+	SavePaymentMethod bool     `json:"save_payment_method,omitempty"`
+	PaymentMethodID   string   `json:"payment_method_id,omitempty"`
+}
+
+type PaymentMethod struct {
+	ID    string `json:"id"`
+	Saved bool   `json:"saved"`
+}
+
+type Payment struct {
+	ID              string         `json:"id"`
+	Status          string         `json:"status"`
+	Amount          Amount         `json:"amount"`
+	Metadata        Metadata       `json:"metadata"`
+	PaymentMethod   *PaymentMethod `json:"payment_method,omitempty"`
+	ConfirmationUrl string         `json:"confirmation_url"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+type CreateRefundRequest struct {
+	PaymentID string `json:"payment_id"`
+	Amount    Amount `json:"amount"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type Refund struct {
+	ID        string `json:"id"`
+	PaymentID string `json:"payment_id"`
+	Status    string `json:"status"`
+	Amount    Amount `json:"amount"`
+}
+
+// Notification is sent by the acquirer to the merchant webhook
+// when payment status changes
+type Notification struct {
+	Event  string  `json:"event"`
+	Object Payment `json:"object"`
+}
+
+type ErrorResponse struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+type PaymentAcquirerGateway struct {
+	config     *Config
+	httpClient *http.Client
+}
+
+func NewPaymentGateway(config *Config) *PaymentAcquirerGateway {
+	return &PaymentAcquirerGateway{
+		config:     config,
+		httpClient: &http.Client{Timeout: httpTimeout},
+	}
+}
+
+func (g *PaymentAcquirerGateway) GetPaymentUrl(ctx context.Context, payload domain.PaymentPayload) (url.URL, error) {
+	amount, err := g.amount(payload.PaySum)
+	if err != nil {
+		return url.URL{}, err
+	}
+
+	request := CreatePaymentRequest{
+		Amount:      amount,
+		Capture:     false,
+		Description: fmt.Sprintf("course %s", payload.CourseID),
+		ReturnUrl:   g.config.ReturnUrl,
+		Metadata:    newMetadata(payload),
+	}
+	if payload.SubscriptionPlanID != "" {
+		request.Description = fmt.Sprintf("subscription plan %s", payload.SubscriptionPlanID)
+		request.SavePaymentMethod = true
+	} else if payload.BundleID != "" {
+		request.Description = fmt.Sprintf("bundle %s", payload.BundleID)
+	} else if payload.RecipientEmail != "" {
+		request.Description = fmt.Sprintf("gift course %s", payload.CourseID)
+	} else if payload.Renewal {
+		request.Description = fmt.Sprintf("renewal of course %s", payload.CourseID)
+		request.Metadata.Renewal = strconv.FormatBool(payload.Renewal)
+	}
+
+	var payment Payment
+	err = g.do(ctx, http.MethodPost, "/payments", domain.RandomID().String(), request, &payment)
+	if err != nil {
+		return url.URL{}, err
+	}
+
+	confirmationUrl, err := url.Parse(payment.ConfirmationUrl)
+	if err != nil {
+		return url.URL{}, errors.Wrap(errs.ErrPaymentGatewayFailed, err.Error())
+	}
+	return *confirmationUrl, nil
+}
+
+// ProcessPayment does not trust webhook body, payment is fetched from
+// the acquirer by its id and captured if it is only authorized
+func (g *PaymentAcquirerGateway) ProcessPayment(ctx context.Context, key string) (domain.PaymentPayload, error) {
+	if key == "" {
+		return domain.PaymentPayload{}, errs.ErrDecodePaymentKeyFailed
+	}
+
+	path := "/payments/" + url.PathEscape(key)
+	var payment Payment
+	err := g.do(ctx, http.MethodGet, path, "", nil, &payment)
+	if err != nil {
+		return domain.PaymentPayload{}, err
+	}
+
+	switch payment.Status {
+	case StatusSucceeded:
+	case StatusWaitingForCapture:
+		err = g.do(ctx, http.MethodPost, path+"/capture", key, nil, &payment)
+		if err != nil {
+			return domain.PaymentPayload{}, err
+		}
+		if payment.Status != StatusSucceeded {
+			return domain.PaymentPayload{}, errs.ErrPaymentNotCompleted
+		}
+	default:
+		return domain.PaymentPayload{}, errs.ErrPaymentNotCompleted
+	}
+
+	if (payment.Metadata.UserID == "" && payment.Metadata.RecipientEmail == "") ||
+		(payment.Metadata.CourseID == "" && payment.Metadata.BundleID == "" &&
+			payment.Metadata.SubscriptionPlanID == "") {
+		return domain.PaymentPayload{}, errs.ErrDecodePaymentKeyFailed
+	}
+
+	payload := domain.PaymentPayload{
+		UserID:             domain.ID(payment.Metadata.UserID),
+		CourseID:           domain.ID(payment.Metadata.CourseID),
+		BundleID:           domain.ID(payment.Metadata.BundleID),
+		SubscriptionPlanID: domain.ID(payment.Metadata.SubscriptionPlanID),
+		PaySum:             domain.NewMoney(payment.Amount.Value, payment.Amount.Currency),
+		PromoCodeID:        domain.ID(payment.Metadata.PromoCodeID),
+		RecipientEmail:     payment.Metadata.RecipientEmail,
+		Renewal:            payment.Metadata.Renewal == strconv.FormatBool(true),
+	}
+	if payment.PaymentMethod != nil && payment.PaymentMethod.Saved {
+		payload.PaymentMethodID = payment.PaymentMethod.ID
+	}
+	return payload, nil
+}
+
+// ChargeRecurring charges the saved payment method without customer
+// confirmation and returns the key of the succeeded payment,
+// repeated charge with the same idempotence key returns the same payment
+func (g *PaymentAcquirerGateway) ChargeRecurring(ctx context.Context, payload domain.PaymentPayload,
+	paymentMethodID, idempotenceKey string) (string, error) {
+	amount, err := g.amount(payload.PaySum)
+	if err != nil {
+		return "", err
+	}
+
+	request := CreatePaymentRequest{
+		Amount:          amount,
+		Capture:         true,
+		Description:     fmt.Sprintf("renewal of subscription plan %s", payload.SubscriptionPlanID),
+		Metadata:        newMetadata(payload),
+		PaymentMethodID: paymentMethodID,
+	}
+
+	var payment Payment
+	err = g.do(ctx, http.MethodPost, "/payments", idempotenceKey, request, &payment)
+	if err != nil {
+		return "", err
+	}
+	if payment.Status != StatusSucceeded {
+		return "", errs.ErrPaymentNotCompleted
+	}
+	return payment.ID, nil
+}
+
+// CancelRecurring removes the saved payment method, method already removed
+// by the acquirer is not an error
+func (g *PaymentAcquirerGateway) CancelRecurring(ctx context.Context, paymentMethodID string) error {
+	var method PaymentMethod
+	err := g.do(ctx, http.MethodDelete, "/payment_methods/"+url.PathEscape(paymentMethodID), "", nil, &method)
+	if err != nil && !errors.Is(err, errs.ErrDecodePaymentKeyFailed) {
+		return err
+	}
+	return nil
+}
+
+func newMetadata(payload domain.PaymentPayload) Metadata {
+	return Metadata{
+		UserID:             payload.UserID.String(),
+		CourseID:           payload.CourseID.String(),
+		BundleID:           payload.BundleID.String(),
+		PromoCodeID:        payload.PromoCodeID.String(),
+		RecipientEmail:     payload.RecipientEmail,
+		SubscriptionPlanID: payload.SubscriptionPlanID.String(),
+	}
+}
+
+func (g *PaymentAcquirerGateway) Refund(ctx context.Context, refund domain.Refund) error {
+	amount, err := g.amount(domain.NewMoney(refund.Amount, refund.Currency))
+	if err != nil {
+		return err
+	}
+
+	request := CreateRefundRequest{
+		PaymentID: refund.PaymentKey,
+		Amount:    amount,
+		Reason:    refund.Reason,
+	}
+
+	var response Refund
+	err = g.do(ctx, http.MethodPost, "/refunds", refund.ID.String(), request, &response)
+	if err != nil {
+		return err
+	}
+	if response.Status != StatusSucceeded {
+		return errors.Wrap(errs.ErrPaymentGatewayFailed,
+			fmt.Sprintf("refund %s has status %s", response.ID, response.Status))
+	}
+	return nil
+}
+
+func (g *PaymentAcquirerGateway) amount(money domain.Money) (Amount, error) {
+	currencies := g.config.Currencies
+	if len(currencies) == 0 {
+		currencies = []string{domain.DefaultCurrency}
+	}
+	if !slices.Contains(currencies, money.Currency) {
+		return Amount{}, errs.ErrCurrencyNotSupported
+	}
+	return Amount{Value: money.Amount, Currency: money.Currency}, nil
+}
+
+func (g *PaymentAcquirerGateway) do(ctx context.Context, method, path, idempotenceKey string,
+	body interface{}, v interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrap(errs.ErrPaymentGatewayFailed, err.Error())
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method,
+		strings.TrimSuffix(g.config.BaseUrl, "/")+path, reader)
+	if err != nil {
+		return errors.Wrap(errs.ErrPaymentGatewayFailed, err.Error())
+	}
+	request.SetBasicAuth(g.config.ShopID, g.config.SecretKey)
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if idempotenceKey != "" {
+		request.Header.Set("Idempotence-Key", idempotenceKey)
+	}
+
+	response, err := g.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrap(errs.ErrPaymentGatewayFailed, err.Error())
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxBodySize))
+	if err != nil {
+		return errors.Wrap(errs.ErrPaymentGatewayFailed, err.Error())
+	}
+
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return errs.ErrDecodePaymentKeyFailed
+	case response.StatusCode != http.StatusOK:
+		var errResponse ErrorResponse
+		_ = json.Unmarshal(data, &errResponse)
+		return errors.Wrap(errs.ErrPaymentGatewayFailed, fmt.Sprintf("unexpected status %d from %s: %s",
+			response.StatusCode, request.URL.Path, errResponse.Description))
+	}
+
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return errors.Wrap(errs.ErrPaymentGatewayFailed, err.Error())
+	}
+	return nil
+}
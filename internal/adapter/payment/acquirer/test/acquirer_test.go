@@ -0,0 +1,254 @@
+package acquirer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/paw1a/eschool/internal/adapter/payment/acquirer"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/pkg/paysim"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sync"
+	"testing"
+)
+
+const (
+	shopID    = "eschool"
+	secretKey = "secret"
+)
+
+var payload = domain.PaymentPayload{
+	UserID:      domain.ID("30e18bc1-4354-4937-9a3b-03cf0b7027ca"),
+	CourseID:    domain.ID("30e18bc1-4354-4937-9a4d-03cf0b7027ca"),
+	PaySum:      domain.NewMoney(399000, "RUB"),
+	PromoCodeID: domain.ID("30e18bc1-4354-4937-9a5e-03cf0b7027ca"),
+}
+
+type merchant struct {
+	mu            sync.Mutex
+	notifications []acquirer.Notification
+}
+
+func (m *merchant) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var notification acquirer.Notification
+	if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	m.mu.Lock()
+	m.notifications = append(m.notifications, notification)
+	m.mu.Unlock()
+}
+
+func newGateway(t *testing.T, key string) (*acquirer.PaymentAcquirerGateway, *merchant) {
+	m := &merchant{}
+	webhook := httptest.NewServer(m)
+	t.Cleanup(webhook.Close)
+
+	sim := paysim.NewServer(paysim.Config{
+		ShopID:     shopID,
+		SecretKey:  secretKey,
+		WebhookUrl: webhook.URL,
+	})
+	server := httptest.NewServer(sim)
+	t.Cleanup(server.Close)
+	sim.SetPublicUrl(server.URL)
+
+	gateway := acquirer.NewPaymentGateway(&acquirer.Config{
+		BaseUrl:    server.URL,
+		ShopID:     shopID,
+		SecretKey:  key,
+		Currencies: []string{"RUB", "USD"},
+	})
+	return gateway, m
+}
+
+// checkout submits checkout form on behalf of the customer
+// and returns id of the payment
+func checkout(t *testing.T, confirmationUrl url.URL, action string) string {
+	response, err := http.PostForm(confirmationUrl.String(), url.Values{"action": {action}})
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	return path.Base(confirmationUrl.Path)
+}
+
+func TestAcquirerPaymentFlow(t *testing.T) {
+	gateway, m := newGateway(t, secretKey)
+	ctx := context.Background()
+
+	confirmationUrl, err := gateway.GetPaymentUrl(ctx, payload)
+	require.NoError(t, err)
+
+	paymentID := path.Base(confirmationUrl.Path)
+	_, err = gateway.ProcessPayment(ctx, paymentID)
+	require.True(t, errors.Is(err, errs.ErrPaymentNotCompleted))
+
+	checkout(t, confirmationUrl, "pay")
+	require.Len(t, m.notifications, 1)
+	require.Equal(t, "payment.waiting_for_capture", m.notifications[0].Event)
+	require.Equal(t, paymentID, m.notifications[0].Object.ID)
+	require.Equal(t, payload.PaySum.Amount, m.notifications[0].Object.Amount.Value)
+
+	processed, err := gateway.ProcessPayment(ctx, paymentID)
+	require.NoError(t, err)
+	require.Equal(t, payload, processed)
+
+	// repeated webhook delivery must not fail on already captured payment
+	processed, err = gateway.ProcessPayment(ctx, paymentID)
+	require.NoError(t, err)
+	require.Equal(t, payload, processed)
+
+	refund := domain.Refund{
+		ID:         domain.NewID(),
+		PaymentKey: paymentID,
+		Amount:     payload.PaySum.Amount,
+		Currency:   payload.PaySum.Currency,
+		Reason:     "reason",
+	}
+	require.NoError(t, gateway.Refund(ctx, refund))
+	// same refund is replayed by idempotence key
+	require.NoError(t, gateway.Refund(ctx, refund))
+
+	refund.ID = domain.NewID()
+	err = gateway.Refund(ctx, refund)
+	require.True(t, errors.Is(err, errs.ErrPaymentGatewayFailed))
+}
+
+func TestAcquirerGiftPayment(t *testing.T) {
+	gateway, _ := newGateway(t, secretKey)
+	ctx := context.Background()
+
+	giftPayload := domain.PaymentPayload{
+		CourseID:       payload.CourseID,
+		RecipientEmail: "recipient@example.com",
+		PaySum:         payload.PaySum,
+	}
+	confirmationUrl, err := gateway.GetPaymentUrl(ctx, giftPayload)
+	require.NoError(t, err)
+
+	paymentID := checkout(t, confirmationUrl, "pay")
+	processed, err := gateway.ProcessPayment(ctx, paymentID)
+	require.NoError(t, err)
+	require.Equal(t, giftPayload, processed)
+}
+
+func TestAcquirerRenewalPayment(t *testing.T) {
+	gateway, _ := newGateway(t, secretKey)
+	ctx := context.Background()
+
+	renewalPayload := payload
+	renewalPayload.PromoCodeID = ""
+	renewalPayload.Renewal = true
+	confirmationUrl, err := gateway.GetPaymentUrl(ctx, renewalPayload)
+	require.NoError(t, err)
+
+	paymentID := checkout(t, confirmationUrl, "pay")
+	processed, err := gateway.ProcessPayment(ctx, paymentID)
+	require.NoError(t, err)
+	require.Equal(t, renewalPayload, processed)
+}
+
+func TestAcquirerSubscriptionPayment(t *testing.T) {
+	gateway, _ := newGateway(t, secretKey)
+	ctx := context.Background()
+
+	subscriptionPayload := domain.PaymentPayload{
+		UserID:             payload.UserID,
+		SubscriptionPlanID: domain.ID("30e18bc1-4354-4937-9a6f-03cf0b7027ca"),
+		PaySum:             payload.PaySum,
+	}
+	confirmationUrl, err := gateway.GetPaymentUrl(ctx, subscriptionPayload)
+	require.NoError(t, err)
+
+	paymentID := checkout(t, confirmationUrl, "pay")
+	processed, err := gateway.ProcessPayment(ctx, paymentID)
+	require.NoError(t, err)
+	require.NotEmpty(t, processed.PaymentMethodID)
+	paymentMethodID := processed.PaymentMethodID
+	processed.PaymentMethodID = ""
+	require.Equal(t, subscriptionPayload, processed)
+
+	renewalID, err := gateway.ChargeRecurring(ctx, subscriptionPayload, paymentMethodID, "period-1")
+	require.NoError(t, err)
+	require.NotEqual(t, paymentID, renewalID)
+
+	// retried charge of the same period is replayed by idempotence key
+	retriedID, err := gateway.ChargeRecurring(ctx, subscriptionPayload, paymentMethodID, "period-1")
+	require.NoError(t, err)
+	require.Equal(t, renewalID, retriedID)
+
+	renewed, err := gateway.ProcessPayment(ctx, renewalID)
+	require.NoError(t, err)
+	require.Equal(t, paymentMethodID, renewed.PaymentMethodID)
+
+	require.NoError(t, gateway.CancelRecurring(ctx, paymentMethodID))
+	// repeated cancel of the removed method is not an error
+	require.NoError(t, gateway.CancelRecurring(ctx, paymentMethodID))
+
+	_, err = gateway.ChargeRecurring(ctx, subscriptionPayload, paymentMethodID, "period-2")
+	require.True(t, errors.Is(err, errs.ErrPaymentGatewayFailed))
+}
+
+func TestAcquirerDeclinedPayment(t *testing.T) {
+	gateway, m := newGateway(t, secretKey)
+	ctx := context.Background()
+
+	confirmationUrl, err := gateway.GetPaymentUrl(ctx, payload)
+	require.NoError(t, err)
+
+	paymentID := checkout(t, confirmationUrl, "decline")
+	require.Len(t, m.notifications, 1)
+	require.Equal(t, "payment.canceled", m.notifications[0].Event)
+
+	_, err = gateway.ProcessPayment(ctx, paymentID)
+	require.True(t, errors.Is(err, errs.ErrPaymentNotCompleted))
+
+	err = gateway.Refund(ctx, domain.Refund{ID: domain.NewID(), PaymentKey: paymentID, Amount: payload.PaySum.Amount,
+		Currency: payload.PaySum.Currency})
+	require.True(t, errors.Is(err, errs.ErrPaymentGatewayFailed))
+}
+
+func TestAcquirerUnsupportedCurrency(t *testing.T) {
+	gateway, m := newGateway(t, secretKey)
+	ctx := context.Background()
+
+	eurPayload := payload
+	eurPayload.PaySum = domain.NewMoney(3990, "EUR")
+	_, err := gateway.GetPaymentUrl(ctx, eurPayload)
+	require.True(t, errors.Is(err, errs.ErrCurrencyNotSupported))
+	require.Empty(t, m.notifications)
+
+	usdPayload := payload
+	usdPayload.PaySum = domain.NewMoney(3990, "USD")
+	confirmationUrl, err := gateway.GetPaymentUrl(ctx, usdPayload)
+	require.NoError(t, err)
+
+	paymentID := checkout(t, confirmationUrl, "pay")
+	processed, err := gateway.ProcessPayment(ctx, paymentID)
+	require.NoError(t, err)
+	require.Equal(t, usdPayload.PaySum, processed.PaySum)
+}
+
+func TestAcquirerUnknownPayment(t *testing.T) {
+	gateway, _ := newGateway(t, secretKey)
+
+	_, err := gateway.ProcessPayment(context.Background(), domain.NewID().String())
+	require.True(t, errors.Is(err, errs.ErrDecodePaymentKeyFailed))
+
+	_, err = gateway.ProcessPayment(context.Background(), "")
+	require.True(t, errors.Is(err, errs.ErrDecodePaymentKeyFailed))
+}
+
+func TestAcquirerInvalidCredentials(t *testing.T) {
+	gateway, _ := newGateway(t, "wrong")
+
+	_, err := gateway.GetPaymentUrl(context.Background(), payload)
+	require.True(t, errors.Is(err, errs.ErrPaymentGatewayFailed))
+}
@@ -2,6 +2,7 @@ package yoomoney
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/binary"
 	"github.com/google/uuid"
@@ -9,9 +10,30 @@ import (
 	"github.com/paw1a/eschool/internal/core/errs"
 	"net/url"
 	"slices"
-	"strconv"
+	"strings"
 )
 
+// label layout: random nonce, user id, course id, pay sum in kopecks and optional promo code id,
+// bundle labels carry bundle id instead of course id and a trailing marker,
+// subscription labels carry plan id instead of course id and a trailing marker,
+// renewal labels carry a trailing marker after the optional promo code id,
+// gift labels are prefixed and carry a random nonce, course id, pay sum and recipient email,
+// the nonce keeps labels of repeated checkouts of the same product distinct
+const (
+	payloadSize        = nonceSize + 16 + 16 + 8
+	promoPayloadSize   = payloadSize + 16
+	bundlePayloadSize  = payloadSize + 1
+	bundleMarker       = 0xb
+	renewalMarker      = 0xe
+	subscriptionMarker = 0x5
+	nonceSize          = 8
+	giftPayloadSize    = nonceSize + 16 + 8
+	giftLabelPrefix    = "gift:"
+)
+
+// walletCurrency is the only currency of yoomoney wallets
+const walletCurrency = "RUB"
+
 type Config struct {
 	Scheme string
 	Host   string
@@ -30,21 +52,50 @@ func NewPaymentGateway(config *Config) *PaymentYookassaGateway {
 }
 
 func (g *PaymentYookassaGateway) GetPaymentUrl(ctx context.Context, payload domain.PaymentPayload) (url.URL, error) {
+	if payload.PaySum.Currency != walletCurrency {
+		return url.URL{}, errs.ErrCurrencyNotSupported
+	}
+
+	if payload.RecipientEmail != "" {
+		return g.paymentUrl(payload.PaySum, giftLabel(payload)), nil
+	}
+
 	userUUID, _ := uuid.Parse(payload.UserID.String())
 	userUUIDBytes, _ := userUUID.MarshalBinary()
-	courseUUID, _ := uuid.Parse(payload.CourseID.String())
+	productID := payload.CourseID
+	if payload.BundleID != "" {
+		productID = payload.BundleID
+	} else if payload.SubscriptionPlanID != "" {
+		productID = payload.SubscriptionPlanID
+	}
+	courseUUID, _ := uuid.Parse(productID.String())
 	courseUUIDBytes, _ := courseUUID.MarshalBinary()
 
 	paySumBytes := make([]byte, 8)
-	binary.LittleEndian.PutUint64(paySumBytes, uint64(payload.PaySum))
+	binary.LittleEndian.PutUint64(paySumBytes, uint64(payload.PaySum.Amount))
+
+	dataBytes := slices.Concat(labelNonce(), userUUIDBytes, courseUUIDBytes, paySumBytes)
+	if payload.BundleID != "" {
+		dataBytes = append(dataBytes, bundleMarker)
+	} else if payload.SubscriptionPlanID != "" {
+		dataBytes = append(dataBytes, subscriptionMarker)
+	} else if payload.PromoCodeID != "" {
+		promoUUID, _ := uuid.Parse(payload.PromoCodeID.String())
+		promoUUIDBytes, _ := promoUUID.MarshalBinary()
+		dataBytes = slices.Concat(dataBytes, promoUUIDBytes)
+	}
+	if payload.Renewal {
+		dataBytes = append(dataBytes, renewalMarker)
+	}
+	return g.paymentUrl(payload.PaySum, base64.StdEncoding.EncodeToString(dataBytes)), nil
+}
 
-	dataBytes := slices.Concat(userUUIDBytes, courseUUIDBytes, paySumBytes)
-	encodedData := base64.StdEncoding.EncodeToString(dataBytes)
+func (g *PaymentYookassaGateway) paymentUrl(paySum domain.Money, label string) url.URL {
 	formParams := url.Values{
-		"sum":           {strconv.FormatInt(payload.PaySum, 10)},
+		"sum":           {paySum.Decimal()},
 		"receiver":      {g.config.Wallet},
 		"quickpay-form": {"donate"},
-		"label":         {encodedData},
+		"label":         {label},
 	}
 
 	return url.URL{
@@ -52,34 +103,118 @@ func (g *PaymentYookassaGateway) GetPaymentUrl(ctx context.Context, payload doma
 		Host:     g.config.Host,
 		Path:     g.config.Path,
 		RawQuery: formParams.Encode(),
-	}, nil
+	}
 }
 
 func (g *PaymentYookassaGateway) ProcessPayment(ctx context.Context, key string) (domain.PaymentPayload, error) {
+	// prefix can't be mistaken for base64 data of other labels
+	if strings.HasPrefix(key, giftLabelPrefix) {
+		return decodeGiftLabel(strings.TrimPrefix(key, giftLabelPrefix))
+	}
+
 	dataBytes, err := base64.StdEncoding.DecodeString(key)
-	if err != nil {
+	if err != nil || (len(dataBytes) != payloadSize && len(dataBytes) != promoPayloadSize &&
+		len(dataBytes) != bundlePayloadSize && len(dataBytes) != promoPayloadSize+1) {
 		return domain.PaymentPayload{}, errs.ErrDecodePaymentKeyFailed
 	}
 
 	var userID, courseID uuid.UUID
-	err = userID.UnmarshalBinary(dataBytes[:16])
+	err = userID.UnmarshalBinary(dataBytes[nonceSize : nonceSize+16])
 	if err != nil {
 		return domain.PaymentPayload{}, errs.ErrDecodePaymentKeyFailed
 	}
 
-	err = courseID.UnmarshalBinary(dataBytes[16:32])
+	err = courseID.UnmarshalBinary(dataBytes[nonceSize+16 : nonceSize+32])
 	if err != nil {
 		return domain.PaymentPayload{}, errs.ErrDecodePaymentKeyFailed
 	}
 
-	paySum := binary.LittleEndian.Uint64(dataBytes[32:])
+	paySum := binary.LittleEndian.Uint64(dataBytes[nonceSize+32 : payloadSize])
+	payload := domain.PaymentPayload{
+		UserID:   domain.ID(userID.String()),
+		CourseID: domain.ID(courseID.String()),
+		PaySum:   domain.NewMoney(int64(paySum), walletCurrency),
+	}
+
+	if len(dataBytes) == bundlePayloadSize || len(dataBytes) == promoPayloadSize+1 {
+		marker := dataBytes[len(dataBytes)-1]
+		dataBytes = dataBytes[:len(dataBytes)-1]
+		switch {
+		case marker == renewalMarker:
+			payload.Renewal = true
+		case marker == bundleMarker && len(dataBytes) == payloadSize:
+			payload.BundleID = payload.CourseID
+			payload.CourseID = ""
+		case marker == subscriptionMarker && len(dataBytes) == payloadSize:
+			payload.SubscriptionPlanID = payload.CourseID
+			payload.CourseID = ""
+		default:
+			return domain.PaymentPayload{}, errs.ErrDecodePaymentKeyFailed
+		}
+	}
+
+	if len(dataBytes) == promoPayloadSize {
+		var promoID uuid.UUID
+		err = promoID.UnmarshalBinary(dataBytes[payloadSize:])
+		if err != nil {
+			return domain.PaymentPayload{}, errs.ErrDecodePaymentKeyFailed
+		}
+		payload.PromoCodeID = domain.ID(promoID.String())
+	}
+
+	return payload, nil
+}
+
+func giftLabel(payload domain.PaymentPayload) string {
+	courseUUID, _ := uuid.Parse(payload.CourseID.String())
+	courseUUIDBytes, _ := courseUUID.MarshalBinary()
+
+	paySumBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(paySumBytes, uint64(payload.PaySum.Amount))
+
+	dataBytes := slices.Concat(labelNonce(), courseUUIDBytes, paySumBytes, []byte(payload.RecipientEmail))
+	return giftLabelPrefix + base64.StdEncoding.EncodeToString(dataBytes)
+}
+
+func decodeGiftLabel(data string) (domain.PaymentPayload, error) {
+	dataBytes, err := base64.StdEncoding.DecodeString(data)
+	if err != nil || len(dataBytes) <= giftPayloadSize {
+		return domain.PaymentPayload{}, errs.ErrDecodePaymentKeyFailed
+	}
+
+	var courseID uuid.UUID
+	err = courseID.UnmarshalBinary(dataBytes[nonceSize : nonceSize+16])
 	if err != nil {
 		return domain.PaymentPayload{}, errs.ErrDecodePaymentKeyFailed
 	}
 
+	paySum := binary.LittleEndian.Uint64(dataBytes[nonceSize+16 : giftPayloadSize])
 	return domain.PaymentPayload{
-		UserID:   domain.ID(userID.String()),
-		CourseID: domain.ID(courseID.String()),
-		PaySum:   int64(paySum),
+		CourseID:       domain.ID(courseID.String()),
+		RecipientEmail: string(dataBytes[giftPayloadSize:]),
+		PaySum:         domain.NewMoney(int64(paySum), walletCurrency),
 	}, nil
 }
+
+func labelNonce() []byte {
+	nonce := make([]byte, nonceSize)
+	_, _ = rand.Read(nonce)
+	return nonce
+}
+
+// Refund does not call yoomoney, quickpay transfers to a wallet can't be
+// reversed through the API, so approved refunds are paid back manually
+func (g *PaymentYookassaGateway) Refund(ctx context.Context, refund domain.Refund) error {
+	return nil
+}
+
+// ChargeRecurring is not supported, quickpay transfers can't save the payment
+// method, so yoomoney subscriptions are paid manually every period
+func (g *PaymentYookassaGateway) ChargeRecurring(ctx context.Context, payload domain.PaymentPayload,
+	paymentMethodID, idempotenceKey string) (string, error) {
+	return "", errs.ErrRecurringPaymentNotSupported
+}
+
+func (g *PaymentYookassaGateway) CancelRecurring(ctx context.Context, paymentMethodID string) error {
+	return nil
+}
@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"github.com/paw1a/eschool/internal/adapter/ratelimit"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	attempts    int
+	windowStart time.Time
+	lockouts    int
+	lockedUntil time.Time
+}
+
+type MemoryRateLimiter struct {
+	config    *ratelimit.Config
+	mu        sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+}
+
+func NewRateLimiter(config *ratelimit.Config) *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		config:    config,
+		entries:   make(map[string]*entry),
+		lastSweep: time.Now(),
+	}
+}
+
+func (m *MemoryRateLimiter) Check(ctx context.Context, key string) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	e, ok := m.entries[key]
+	if !ok || !now.Before(e.lockedUntil) {
+		return 0, nil
+	}
+	return e.lockedUntil.Sub(now), nil
+}
+
+func (m *MemoryRateLimiter) Hit(ctx context.Context, key string) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sweep(now)
+
+	e, ok := m.entries[key]
+	if !ok {
+		e = &entry{windowStart: now}
+		m.entries[key] = e
+	}
+
+	if now.Before(e.lockedUntil) {
+		return e.lockedUntil.Sub(now), nil
+	}
+
+	if now.Sub(e.windowStart) > m.config.WindowDuration() {
+		e.attempts = 0
+		e.windowStart = now
+	}
+
+	e.attempts++
+	if e.attempts < m.config.MaxAttempts {
+		return 0, nil
+	}
+
+	e.attempts = 0
+	e.lockouts++
+	lockout := m.config.LockoutDuration(e.lockouts)
+	e.lockedUntil = now.Add(lockout)
+	return lockout, nil
+}
+
+func (m *MemoryRateLimiter) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+	return nil
+}
+
+// sweep removes entries which have no active window and no lockout
+// for longer than max lockout time, so lockout history is forgotten.
+func (m *MemoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < m.config.WindowDuration() {
+		return
+	}
+	m.lastSweep = now
+
+	for key, e := range m.entries {
+		expiresAt := e.windowStart.Add(m.config.WindowDuration())
+		if e.lockedUntil.After(expiresAt) {
+			expiresAt = e.lockedUntil
+		}
+		if now.Sub(expiresAt) > m.config.MaxLockoutDuration() {
+			delete(m.entries, key)
+		}
+	}
+}
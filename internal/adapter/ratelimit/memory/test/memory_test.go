@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"github.com/paw1a/eschool/internal/adapter/ratelimit"
+	"github.com/paw1a/eschool/internal/adapter/ratelimit/memory"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func newConfig() *ratelimit.Config {
+	return &ratelimit.Config{
+		Driver:         "memory",
+		MaxAttempts:    3,
+		Window:         60,
+		LockoutTime:    1,
+		MaxLockoutTime: 3,
+	}
+}
+
+func TestMemoryRateLimiterLockout(t *testing.T) {
+	ctx := context.Background()
+	limiter := memory.NewRateLimiter(newConfig())
+
+	for i := 0; i < 2; i++ {
+		lockout, err := limiter.Hit(ctx, "key")
+		require.NoError(t, err)
+		require.Zero(t, lockout)
+	}
+
+	lockout, err := limiter.Hit(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, time.Second, lockout)
+
+	retryAfter, err := limiter.Check(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, retryAfter > 0 && retryAfter <= time.Second)
+
+	retryAfter, err = limiter.Check(ctx, "other key")
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+}
+
+func TestMemoryRateLimiterProgressiveLockout(t *testing.T) {
+	ctx := context.Background()
+	limiter := memory.NewRateLimiter(newConfig())
+
+	hitUntilLocked := func() time.Duration {
+		for {
+			lockout, err := limiter.Hit(ctx, "key")
+			require.NoError(t, err)
+			if lockout > 0 {
+				return lockout
+			}
+		}
+	}
+
+	require.Equal(t, time.Second, hitUntilLocked())
+	time.Sleep(time.Second)
+	require.Equal(t, 2*time.Second, hitUntilLocked())
+}
+
+func TestMemoryRateLimiterReset(t *testing.T) {
+	ctx := context.Background()
+	limiter := memory.NewRateLimiter(newConfig())
+
+	for i := 0; i < 3; i++ {
+		_, err := limiter.Hit(ctx, "key")
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, limiter.Reset(ctx, "key"))
+	retryAfter, err := limiter.Check(ctx, "key")
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+}
+
+func TestLockoutDurationIsCapped(t *testing.T) {
+	config := newConfig()
+	require.Equal(t, time.Second, config.LockoutDuration(1))
+	require.Equal(t, 2*time.Second, config.LockoutDuration(2))
+	require.Equal(t, 3*time.Second, config.LockoutDuration(3))
+	require.Equal(t, 3*time.Second, config.LockoutDuration(10))
+}
@@ -0,0 +1,32 @@
+package ratelimit
+
+import "time"
+
+type Config struct {
+	Driver         string // redis, memory
+	MaxAttempts    int
+	Window         int64 // seconds
+	LockoutTime    int64 // seconds
+	MaxLockoutTime int64 // seconds
+}
+
+func (c *Config) WindowDuration() time.Duration {
+	return time.Second * time.Duration(c.Window)
+}
+
+func (c *Config) MaxLockoutDuration() time.Duration {
+	return time.Second * time.Duration(c.MaxLockoutTime)
+}
+
+// LockoutDuration doubles the lockout time for every subsequent lockout
+// until it reaches the configured maximum.
+func (c *Config) LockoutDuration(lockouts int) time.Duration {
+	duration := time.Second * time.Duration(c.LockoutTime)
+	for i := 1; i < lockouts && duration < c.MaxLockoutDuration(); i++ {
+		duration *= 2
+	}
+	if duration > c.MaxLockoutDuration() {
+		duration = c.MaxLockoutDuration()
+	}
+	return duration
+}
@@ -0,0 +1,88 @@
+package redis
+
+import (
+	"context"
+	"github.com/go-redis/redis/v7"
+	"github.com/paw1a/eschool/internal/adapter/ratelimit"
+	"time"
+)
+
+const keyPrefix = "ratelimit:"
+
+type RedisRateLimiter struct {
+	config      *ratelimit.Config
+	redisClient *redis.Client
+}
+
+func NewRateLimiter(config *ratelimit.Config, redisClient *redis.Client) *RedisRateLimiter {
+	return &RedisRateLimiter{
+		config:      config,
+		redisClient: redisClient,
+	}
+}
+
+func (r *RedisRateLimiter) Check(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.redisClient.PTTL(lockKey(key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (r *RedisRateLimiter) Hit(ctx context.Context, key string) (time.Duration, error) {
+	retryAfter, err := r.Check(ctx, key)
+	if err != nil || retryAfter > 0 {
+		return retryAfter, err
+	}
+
+	attempts, err := r.redisClient.Incr(attemptsKey(key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if attempts == 1 {
+		err = r.redisClient.Expire(attemptsKey(key), r.config.WindowDuration()).Err()
+		if err != nil {
+			return 0, err
+		}
+	}
+	if attempts < int64(r.config.MaxAttempts) {
+		return 0, nil
+	}
+
+	lockouts, err := r.redisClient.Incr(lockoutsKey(key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	lockout := r.config.LockoutDuration(int(lockouts))
+
+	_, err = r.redisClient.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Del(attemptsKey(key))
+		pipe.Expire(lockoutsKey(key), lockout+r.config.MaxLockoutDuration())
+		pipe.Set(lockKey(key), lockouts, lockout)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return lockout, nil
+}
+
+func (r *RedisRateLimiter) Reset(ctx context.Context, key string) error {
+	return r.redisClient.Del(attemptsKey(key), lockoutsKey(key), lockKey(key)).Err()
+}
+
+func attemptsKey(key string) string {
+	return keyPrefix + key + ":attempts"
+}
+
+func lockoutsKey(key string) string {
+	return keyPrefix + key + ":lockouts"
+}
+
+func lockKey(key string) string {
+	return keyPrefix + key + ":lock"
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type PostgresApiKeyRepo struct {
+	db *sqlx.DB
+}
+
+func NewApiKeyRepo(db *sqlx.DB) *PostgresApiKeyRepo {
+	return &PostgresApiKeyRepo{
+		db: db,
+	}
+}
+
+const (
+	ApiKeyFindUserKeysQuery = "SELECT * FROM public.api_key WHERE user_id = $1 ORDER BY created_at"
+	ApiKeyFindByHashQuery   = "SELECT * FROM public.api_key WHERE key_hash = $1"
+	ApiKeyRevokeQuery       = "UPDATE public.api_key SET revoked_at = $3 " +
+		"WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL"
+	ApiKeyUpdateLastUsedQuery = "UPDATE public.api_key SET last_used_at = $2 WHERE id = $1"
+)
+
+func (a *PostgresApiKeyRepo) FindUserKeys(ctx context.Context, userID domain.ID) ([]domain.ApiKey, error) {
+	var pgKeys []entity.PgApiKey
+	if err := a.db.SelectContext(ctx, &pgKeys, ApiKeyFindUserKeysQuery, userID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	keys := make([]domain.ApiKey, len(pgKeys))
+	for i, key := range pgKeys {
+		keys[i] = key.ToDomain()
+	}
+	return keys, nil
+}
+
+func (a *PostgresApiKeyRepo) FindByHash(ctx context.Context, keyHash string) (domain.ApiKey, error) {
+	var pgKey entity.PgApiKey
+	if err := a.db.GetContext(ctx, &pgKey, ApiKeyFindByHashQuery, keyHash); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ApiKey{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.ApiKey{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgKey.ToDomain(), nil
+}
+
+func (a *PostgresApiKeyRepo) Create(ctx context.Context, key domain.ApiKey) (domain.ApiKey, error) {
+	var pgKey = entity.NewPgApiKey(key)
+	queryString := entity.InsertQueryString(pgKey, "api_key")
+	_, err := a.db.NamedExecContext(ctx, queryString, pgKey)
+	if err != nil {
+		return domain.ApiKey{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	var createdKey entity.PgApiKey
+	err = a.db.GetContext(ctx, &createdKey, ApiKeyFindByHashQuery, pgKey.KeyHash)
+	if err != nil {
+		return domain.ApiKey{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+	return createdKey.ToDomain(), nil
+}
+
+func (a *PostgresApiKeyRepo) Revoke(ctx context.Context, userID, keyID domain.ID) error {
+	result, err := a.db.ExecContext(ctx, ApiKeyRevokeQuery, keyID, userID, time.Now().UTC())
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrNotExist
+	}
+	return nil
+}
+
+func (a *PostgresApiKeyRepo) UpdateLastUsed(ctx context.Context, keyID domain.ID, usedAt time.Time) error {
+	_, err := a.db.ExecContext(ctx, ApiKeyUpdateLastUsedQuery, keyID, usedAt)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	return nil
+}
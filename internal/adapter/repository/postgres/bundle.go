@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+)
+
+type PostgresBundleRepo struct {
+	db *sqlx.DB
+}
+
+func NewBundleRepo(db *sqlx.DB) *PostgresBundleRepo {
+	return &PostgresBundleRepo{
+		db: db,
+	}
+}
+
+const (
+	BundleFindByIDQuery          = "SELECT * FROM public.bundle WHERE id = $1"
+	BundleFindSchoolBundlesQuery = "SELECT * FROM public.bundle WHERE school_id = $1 ORDER BY created_at"
+	BundleFindBundleCoursesQuery = "SELECT c.* FROM public.course c " +
+		"JOIN public.bundle_course bc on c.id = bc.course_id WHERE bc.bundle_id = $1 ORDER BY bc.position"
+	BundleAddCourseQuery = "INSERT INTO public.bundle_course (bundle_id, course_id, position) " +
+		"VALUES ($1, $2, $3)"
+	BundlePublishQuery = "UPDATE public.bundle SET status = 'published' WHERE id = $1"
+	BundleDeleteQuery  = "DELETE FROM public.bundle WHERE id = $1 AND school_id = $2"
+)
+
+func (b *PostgresBundleRepo) FindByID(ctx context.Context, bundleID domain.ID) (domain.Bundle, error) {
+	var pgBundle entity.PgBundle
+	if err := b.db.GetContext(ctx, &pgBundle, BundleFindByIDQuery, bundleID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Bundle{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.Bundle{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgBundle.ToDomain(), nil
+}
+
+func (b *PostgresBundleRepo) FindSchoolBundles(ctx context.Context, schoolID domain.ID) ([]domain.Bundle, error) {
+	var pgBundles []entity.PgBundle
+	if err := b.db.SelectContext(ctx, &pgBundles, BundleFindSchoolBundlesQuery, schoolID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	bundles := make([]domain.Bundle, len(pgBundles))
+	for i, bundle := range pgBundles {
+		bundles[i] = bundle.ToDomain()
+	}
+	return bundles, nil
+}
+
+func (b *PostgresBundleRepo) FindBundleCourses(ctx context.Context, bundleID domain.ID) ([]domain.Course, error) {
+	var pgCourses []entity.PgCourse
+	if err := b.db.SelectContext(ctx, &pgCourses, BundleFindBundleCoursesQuery, bundleID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	courses := make([]domain.Course, len(pgCourses))
+	for i, course := range pgCourses {
+		courses[i] = course.ToDomain()
+	}
+	return courses, nil
+}
+
+// Create saves the bundle together with its courses, course order is kept
+func (b *PostgresBundleRepo) Create(ctx context.Context, bundle domain.Bundle,
+	courseIDs []domain.ID) (domain.Bundle, error) {
+	var pgBundle = entity.NewPgBundle(bundle)
+	tx, err := b.db.Beginx()
+	if err != nil {
+		return domain.Bundle{}, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	queryString := entity.InsertQueryString(pgBundle, "bundle")
+	_, err = tx.NamedExecContext(ctx, queryString, pgBundle)
+	if err != nil {
+		tx.Rollback()
+		return domain.Bundle{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	for i, courseID := range courseIDs {
+		_, err = tx.ExecContext(ctx, BundleAddCourseQuery, pgBundle.ID, courseID, i)
+		if err != nil {
+			tx.Rollback()
+			return domain.Bundle{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+
+	var createdBundle entity.PgBundle
+	err = tx.GetContext(ctx, &createdBundle, BundleFindByIDQuery, pgBundle.ID)
+	if err != nil {
+		tx.Rollback()
+		return domain.Bundle{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return domain.Bundle{}, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return createdBundle.ToDomain(), nil
+}
+
+func (b *PostgresBundleRepo) Publish(ctx context.Context, bundleID domain.ID) error {
+	_, err := b.db.ExecContext(ctx, BundlePublishQuery, bundleID)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	return nil
+}
+
+func (b *PostgresBundleRepo) Delete(ctx context.Context, schoolID, bundleID domain.ID) error {
+	result, err := b.db.ExecContext(ctx, BundleDeleteQuery, bundleID, schoolID)
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrNotExist
+	}
+	return nil
+}
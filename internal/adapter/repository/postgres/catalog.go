@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+)
+
+type PostgresCatalogRepo struct {
+	db *sqlx.DB
+}
+
+func NewCatalogRepo(db *sqlx.DB) *PostgresCatalogRepo {
+	return &PostgresCatalogRepo{
+		db: db,
+	}
+}
+
+const (
+	CatalogFindCategoriesQuery   = "SELECT * FROM public.category ORDER BY name, id"
+	CatalogFindCategoryByIDQuery = "SELECT * FROM public.category WHERE id = $1"
+	CatalogFindAssignmentsQuery  = "SELECT cc.course_id, cc.category_id FROM public.course_category cc " +
+		"JOIN public.course c on c.id = cc.course_id WHERE c.status NOT IN ('unpublished', 'archived')"
+	CatalogDeleteCategoryQuery       = "DELETE FROM public.category WHERE id = $1"
+	CatalogFindCourseCategoriesQuery = "SELECT c.* FROM public.category c " +
+		"JOIN public.course_category cc on c.id = cc.category_id WHERE cc.course_id = $1 ORDER BY c.name"
+	CatalogDeleteCourseCategoriesQuery = "DELETE FROM public.course_category WHERE course_id = $1"
+	CatalogAddCourseCategoryQuery      = "INSERT INTO public.course_category (course_id, category_id) " +
+		"VALUES ($1, $2)"
+	CatalogFindSchoolTagsQuery = "SELECT * FROM public.tag WHERE school_id = $1 ORDER BY name"
+	CatalogFindCourseTagsQuery = "SELECT t.* FROM public.tag t " +
+		"JOIN public.course_tag ct on t.id = ct.tag_id WHERE ct.course_id = $1 ORDER BY t.name"
+	CatalogDeleteCourseTagsQuery = "DELETE FROM public.course_tag WHERE course_id = $1"
+	// tag of the school is created once, courses of the school share it
+	CatalogCreateTagQuery = "INSERT INTO public.tag (id, school_id, name) VALUES ($1, $2, $3) " +
+		"ON CONFLICT (school_id, name) DO NOTHING"
+	CatalogAddCourseTagQuery = "INSERT INTO public.course_tag (course_id, tag_id) " +
+		"SELECT $1, id FROM public.tag WHERE school_id = $2 AND name = $3"
+	// unpublished and archived courses are withdrawn from the catalog,
+	// category filter includes courses of its subcategories
+	CatalogFindCoursesQuery = "WITH RECURSIVE subtree AS (" +
+		"SELECT id FROM public.category WHERE id = $1 " +
+		"UNION SELECT c.id FROM public.category c JOIN subtree s on c.parent_id = s.id) " +
+		"SELECT * FROM public.course WHERE status NOT IN ('unpublished', 'archived') " +
+		"AND ($1::uuid IS NULL OR id IN (SELECT cc.course_id FROM public.course_category cc " +
+		"JOIN subtree s on cc.category_id = s.id)) " +
+		"AND ($2 = '' OR id IN (SELECT ct.course_id FROM public.course_tag ct " +
+		"JOIN public.tag t on t.id = ct.tag_id WHERE t.name = $2)) ORDER BY id"
+)
+
+func (p *PostgresCatalogRepo) FindCategories(ctx context.Context) ([]domain.Category, error) {
+	var pgCategories []entity.PgCategory
+	if err := p.db.SelectContext(ctx, &pgCategories, CatalogFindCategoriesQuery); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	categories := make([]domain.Category, len(pgCategories))
+	for i, category := range pgCategories {
+		categories[i] = category.ToDomain()
+	}
+	return categories, nil
+}
+
+func (p *PostgresCatalogRepo) FindCategoryByID(ctx context.Context, categoryID domain.ID) (domain.Category, error) {
+	var pgCategory entity.PgCategory
+	if err := p.db.GetContext(ctx, &pgCategory, CatalogFindCategoryByIDQuery, categoryID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Category{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.Category{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgCategory.ToDomain(), nil
+}
+
+// FindCategoryAssignments returns categories of the catalog courses
+func (p *PostgresCatalogRepo) FindCategoryAssignments(ctx context.Context) ([]domain.CourseCategory, error) {
+	var pgAssignments []entity.PgCourseCategory
+	if err := p.db.SelectContext(ctx, &pgAssignments, CatalogFindAssignmentsQuery); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	assignments := make([]domain.CourseCategory, len(pgAssignments))
+	for i, assignment := range pgAssignments {
+		assignments[i] = assignment.ToDomain()
+	}
+	return assignments, nil
+}
+
+func (p *PostgresCatalogRepo) CreateCategory(ctx context.Context,
+	category domain.Category) (domain.Category, error) {
+	var pgCategory = entity.NewPgCategory(category)
+	queryString := entity.InsertQueryString(pgCategory, "category")
+	_, err := p.db.NamedExecContext(ctx, queryString, pgCategory)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == PgUniqueViolationCode {
+				return domain.Category{}, errors.Wrap(errs.ErrDuplicate, err.Error())
+			} else {
+				return domain.Category{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+			}
+		} else {
+			return domain.Category{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+
+	return p.FindCategoryByID(ctx, category.ID)
+}
+
+func (p *PostgresCatalogRepo) DeleteCategory(ctx context.Context, categoryID domain.ID) error {
+	result, err := p.db.ExecContext(ctx, CatalogDeleteCategoryQuery, categoryID)
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrNotExist
+	}
+	return nil
+}
+
+func (p *PostgresCatalogRepo) FindCourseCategories(ctx context.Context,
+	courseID domain.ID) ([]domain.Category, error) {
+	var pgCategories []entity.PgCategory
+	if err := p.db.SelectContext(ctx, &pgCategories, CatalogFindCourseCategoriesQuery, courseID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	categories := make([]domain.Category, len(pgCategories))
+	for i, category := range pgCategories {
+		categories[i] = category.ToDomain()
+	}
+	return categories, nil
+}
+
+func (p *PostgresCatalogRepo) UpdateCourseCategories(ctx context.Context, courseID domain.ID,
+	categoryIDs []domain.ID) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	_, err = tx.ExecContext(ctx, CatalogDeleteCourseCategoriesQuery, courseID)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	for _, categoryID := range categoryIDs {
+		_, err = tx.ExecContext(ctx, CatalogAddCourseCategoryQuery, courseID, categoryID)
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+	return nil
+}
+
+func (p *PostgresCatalogRepo) FindSchoolTags(ctx context.Context, schoolID domain.ID) ([]domain.Tag, error) {
+	var pgTags []entity.PgTag
+	if err := p.db.SelectContext(ctx, &pgTags, CatalogFindSchoolTagsQuery, schoolID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	tags := make([]domain.Tag, len(pgTags))
+	for i, tag := range pgTags {
+		tags[i] = tag.ToDomain()
+	}
+	return tags, nil
+}
+
+func (p *PostgresCatalogRepo) FindCourseTags(ctx context.Context, courseID domain.ID) ([]domain.Tag, error) {
+	var pgTags []entity.PgTag
+	if err := p.db.SelectContext(ctx, &pgTags, CatalogFindCourseTagsQuery, courseID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	tags := make([]domain.Tag, len(pgTags))
+	for i, tag := range pgTags {
+		tags[i] = tag.ToDomain()
+	}
+	return tags, nil
+}
+
+// UpdateCourseTags replaces tags of the course, missing tags are created in the school
+func (p *PostgresCatalogRepo) UpdateCourseTags(ctx context.Context, courseID, schoolID domain.ID,
+	names []string) ([]domain.Tag, error) {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	_, err = tx.ExecContext(ctx, CatalogDeleteCourseTagsQuery, courseID)
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	for _, name := range names {
+		pgTag := entity.NewPgTag(domain.Tag{
+			ID:       domain.NewID(),
+			SchoolID: schoolID,
+			Name:     name,
+		})
+		_, err = tx.ExecContext(ctx, CatalogCreateTagQuery, pgTag.ID, pgTag.SchoolID, pgTag.Name)
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.Wrap(errs.ErrUpdateFailed, err.Error())
+		}
+
+		_, err = tx.ExecContext(ctx, CatalogAddCourseTagQuery, courseID, schoolID, name)
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.Wrap(errs.ErrUpdateFailed, err.Error())
+		}
+	}
+
+	var pgTags []entity.PgTag
+	err = tx.SelectContext(ctx, &pgTags, CatalogFindCourseTagsQuery, courseID)
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return nil, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	tags := make([]domain.Tag, len(pgTags))
+	for i, tag := range pgTags {
+		tags[i] = tag.ToDomain()
+	}
+	return tags, nil
+}
+
+// FindCourses returns catalog courses of the category and the tag,
+// empty category or tag doesn't filter courses
+func (p *PostgresCatalogRepo) FindCourses(ctx context.Context, categoryID domain.ID,
+	tag string) ([]domain.Course, error) {
+	var category uuid.NullUUID
+	if categoryID != "" {
+		category.UUID, _ = uuid.Parse(categoryID.String())
+		category.Valid = true
+	}
+
+	var pgCourses []entity.PgCourse
+	if err := p.db.SelectContext(ctx, &pgCourses, CatalogFindCoursesQuery, category, tag); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	courses := make([]domain.Course, len(pgCourses))
+	for i, course := range pgCourses {
+		courses[i] = course.ToDomain()
+	}
+	return courses, nil
+}
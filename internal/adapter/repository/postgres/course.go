@@ -3,12 +3,14 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"github.com/guregu/null"
 	"github.com/jackc/pgconn"
 	"github.com/jmoiron/sqlx"
 	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
 	"github.com/paw1a/eschool/internal/core/domain"
 	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/pkg/errors"
+	"time"
 )
 
 type PostgresCourseRepo struct {
@@ -22,7 +24,9 @@ func NewCourseRepo(db *sqlx.DB) *PostgresCourseRepo {
 }
 
 const (
-	CourseFindAllQuery            = "SELECT * FROM public.course ORDER BY id"
+	// unpublished and archived courses are withdrawn from the catalog
+	CourseFindAllQuery = "SELECT * FROM public.course " +
+		"WHERE status NOT IN ('unpublished', 'archived') ORDER BY id"
 	CourseFindByIDQuery           = "SELECT * FROM public.course WHERE id = $1"
 	CourseFindStudentCoursesQuery = "SELECT c.* FROM public.course c " +
 		"JOIN public.course_student cs on c.id = cs.course_id " +
@@ -33,15 +37,72 @@ const (
 	CourseFindCourseTeachersQuery = "SELECT u.* FROM public.user u " +
 		"JOIN public.course_teacher ct on u.id = ct.teacher_id " +
 		"JOIN public.course c on ct.course_id = c.id WHERE c.id = $1"
+	// active subscription to the school makes the user a student of its published courses
 	CourseContainsStudentQuery = "SELECT EXISTS (SELECT 1 FROM public.course_student " +
-		"WHERE course_id = $1 AND student_id = $2)"
+		"WHERE course_id = $1 AND student_id = $2 AND (expires_at IS NULL OR expires_at > $3)) " +
+		"OR EXISTS (SELECT 1 FROM public.subscription s JOIN public.course c ON c.school_id = s.school_id " +
+		"WHERE c.id = $1 AND s.user_id = $2 AND s.expires_at > $3 AND c.status = 'published')"
+	CourseFindEnrollmentQuery = "SELECT student_id, course_id, enrolled_at, expires_at, version " +
+		"FROM public.course_student WHERE student_id = $1 AND course_id = $2"
+	CourseFindExpiringEnrollmentsQuery = "SELECT student_id, course_id, enrolled_at, expires_at, version " +
+		"FROM public.course_student WHERE expires_at > $1 AND expires_at <= $2 AND reminded_at IS NULL " +
+		"ORDER BY expires_at"
+	CourseMarkEnrollmentRemindedQuery = "UPDATE public.course_student SET reminded_at = $3 " +
+		"WHERE student_id = $1 AND course_id = $2"
+	CourseRenewCourseStudentQuery = "UPDATE public.course_student cs SET " +
+		"expires_at = GREATEST(cs.expires_at, $3::timestamp) + make_interval(days => c.access_days), " +
+		"renewal_key = $4, reminded_at = NULL FROM public.course c " +
+		"WHERE c.id = cs.course_id AND cs.student_id = $1 AND cs.course_id = $2 " +
+		"AND cs.expires_at IS NOT NULL AND c.access_days IS NOT NULL " +
+		"AND cs.renewal_key IS DISTINCT FROM $4"
+	// refunded renewal takes back the access period it added
+	CourseRevokeCourseStudentPeriodQuery = "UPDATE public.course_student cs SET " +
+		"expires_at = cs.expires_at - make_interval(days => c.access_days), reminded_at = NULL " +
+		"FROM public.course c WHERE c.id = cs.course_id AND cs.student_id = $1 AND cs.course_id = $2 " +
+		"AND cs.expires_at IS NOT NULL AND c.access_days IS NOT NULL"
 	CourseContainsTeacherQuery = "SELECT EXISTS (SELECT 1 FROM public.course_teacher " +
 		"WHERE course_id = $1 AND teacher_id = $2)"
-	CourseAddCourseStudentQuery = "INSERT INTO public.course_student (student_id, course_id) " +
-		"VALUES ($1, $2)"
+	// new student studies the published version of the course
+	CourseAddCourseStudentQuery = "INSERT INTO public.course_student " +
+		"(student_id, course_id, enrolled_at, expires_at, version) " +
+		"SELECT $1, $2, $3::timestamp, $3::timestamp + make_interval(days => access_days), " +
+		"COALESCE(NULLIF(published_version, 0), version) FROM public.course WHERE id = $2"
+	CourseReenrollCourseStudentQuery = "UPDATE public.course_student cs SET enrolled_at = $3, " +
+		"expires_at = $3::timestamp + make_interval(days => c.access_days), renewal_key = NULL, " +
+		"reminded_at = NULL FROM public.course c WHERE c.id = cs.course_id " +
+		"AND cs.student_id = $1 AND cs.course_id = $2 AND cs.expires_at <= $3"
 	CourseAddCourseTeacherQuery = "INSERT INTO public.course_teacher (teacher_id, course_id) " +
 		"VALUES ($1, $2)"
+	CourseRemoveCourseStudentQuery = "DELETE FROM public.course_student " +
+		"WHERE student_id = $1 AND course_id = $2"
+	CourseDeleteStudentLessonStatsQuery = "DELETE FROM public.lesson_stat WHERE user_id = $1 " +
+		"AND lesson_id IN (SELECT id FROM public.lesson WHERE course_id = $2)"
+	CourseDeleteStudentTestStatsQuery = "DELETE FROM public.test_stat WHERE user_id = $1 " +
+		"AND test_id IN (SELECT t.id FROM public.test t " +
+		"JOIN public.lesson l on t.lesson_id = l.id WHERE l.course_id = $2)"
 	CourseDeleteQuery = "DELETE FROM public.course WHERE id = $1"
+	// status is compared with the expected one, concurrent transitions don't overwrite each other
+	// the first publishing makes the edited content version visible to students
+	CourseUpdateStatusQuery = "UPDATE public.course SET status = $3, published_version = " +
+		"CASE WHEN $3 = 'published' AND published_version = 0 THEN version ELSE published_version END " +
+		"WHERE id = $1 AND status = $2"
+	CoursePublishRevisionQuery = "UPDATE public.course SET published_version = version " +
+		"WHERE id = $1 AND version > published_version AND published_version > 0"
+	CourseUpdateEnrollmentVersionQuery = "UPDATE public.course_student SET version = $3 " +
+		"WHERE student_id = $1 AND course_id = $2"
+	CourseUpdatePublishAtQuery = "UPDATE public.course SET publish_at = $2 " +
+		"WHERE id = $1"
+	CourseAddTransitionQuery = "INSERT INTO public.course_transition " +
+		"(id, course_id, from_status, to_status, user_id, created_at) VALUES ($1, $2, $3, $4, $5, $6)"
+	CourseFindTransitionsQuery = "SELECT * FROM public.course_transition " +
+		"WHERE course_id = $1 ORDER BY created_at"
+	CourseFindPrerequisitesQuery = "SELECT * FROM public.course_prerequisite " +
+		"WHERE course_id = $1 ORDER BY required_course_id"
+	CourseAddPrerequisiteQuery = "INSERT INTO public.course_prerequisite " +
+		"(course_id, required_course_id, min_score) VALUES ($1, $2, $3) " +
+		"ON CONFLICT (course_id, required_course_id) DO UPDATE SET min_score = excluded.min_score"
+	CourseRemovePrerequisiteQuery = "DELETE FROM public.course_prerequisite " +
+		"WHERE course_id = $1 AND required_course_id = $2"
 )
 
 func (p *PostgresCourseRepo) FindAll(ctx context.Context) ([]domain.Course, error) {
@@ -126,7 +187,7 @@ func (p *PostgresCourseRepo) FindCourseTeachers(ctx context.Context, courseID do
 
 func (p *PostgresCourseRepo) IsCourseStudent(ctx context.Context, studentID, courseID domain.ID) (bool, error) {
 	var exists bool
-	err := p.db.GetContext(ctx, &exists, CourseContainsStudentQuery, courseID, studentID)
+	err := p.db.GetContext(ctx, &exists, CourseContainsStudentQuery, courseID, studentID, time.Now().UTC())
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, errors.Wrap(errs.ErrNotExist, err.Error())
@@ -150,8 +211,90 @@ func (p *PostgresCourseRepo) IsCourseTeacher(ctx context.Context, teacherID, cou
 	return exists, nil
 }
 
+// AddCourseStudent takes a seat in the course atomically, the seat held
+// for the student is taken over, seats held for other users stay reserved.
+// Expired enrollment has freed its seat, it takes a free one and is started again
 func (p *PostgresCourseRepo) AddCourseStudent(ctx context.Context, studentID, courseID domain.ID) error {
-	_, err := p.db.ExecContext(ctx, CourseAddCourseStudentQuery, studentID, courseID)
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	capacity, err := lockCourseCapacity(ctx, tx, courseID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	enrolledAt := time.Now().UTC()
+	err = checkFreeSeat(ctx, tx, courseID, studentID, capacity, enrolledAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, CourseReenrollCourseStudentQuery, studentID, courseID, enrolledAt)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	reenrolled, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	if reenrolled == 0 {
+		err = insertCourseStudent(ctx, tx, studentID, courseID, enrolledAt)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, SeatHoldDeleteQuery, courseID, studentID)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	_, err = tx.ExecContext(ctx, WaitlistDeleteQuery, courseID, studentID)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return nil
+}
+
+// checkFreeSeat reports whether the locked course has a seat for the student,
+// the seat held for the student counts as free
+func checkFreeSeat(ctx context.Context, tx *sqlx.Tx, courseID, studentID domain.ID,
+	capacity null.Int, now time.Time) error {
+	if !capacity.Valid {
+		return nil
+	}
+
+	taken, err := countTakenSeats(ctx, tx, courseID, studentID, now)
+	if err != nil {
+		return err
+	}
+	if taken >= capacity.Int64 {
+		return errs.ErrCourseIsFull
+	}
+	return nil
+}
+
+// insertCourseStudent adds the new student to the locked course with a free seat
+func insertCourseStudent(ctx context.Context, tx *sqlx.Tx, studentID, courseID domain.ID,
+	enrolledAt time.Time) error {
+	_, err := tx.ExecContext(ctx, CourseAddCourseStudentQuery, studentID, courseID, enrolledAt)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -167,6 +310,115 @@ func (p *PostgresCourseRepo) AddCourseStudent(ctx context.Context, studentID, co
 	return nil
 }
 
+func (p *PostgresCourseRepo) FindEnrollment(ctx context.Context,
+	studentID, courseID domain.ID) (domain.Enrollment, error) {
+	var pgEnrollment entity.PgEnrollment
+	err := p.db.GetContext(ctx, &pgEnrollment, CourseFindEnrollmentQuery, studentID, courseID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Enrollment{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.Enrollment{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgEnrollment.ToDomain(), nil
+}
+
+func (p *PostgresCourseRepo) FindExpiringEnrollments(ctx context.Context,
+	from, to time.Time) ([]domain.Enrollment, error) {
+	var pgEnrollments []entity.PgEnrollment
+	if err := p.db.SelectContext(ctx, &pgEnrollments, CourseFindExpiringEnrollmentsQuery, from, to); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	enrollments := make([]domain.Enrollment, len(pgEnrollments))
+	for i, enrollment := range pgEnrollments {
+		enrollments[i] = enrollment.ToDomain()
+	}
+	return enrollments, nil
+}
+
+// RenewCourseStudent extends time-limited enrollment by the course access period,
+// renewal with the same key is applied only once. Expired enrollment has freed
+// its seat, so it is renewed only if the course has a free seat
+func (p *PostgresCourseRepo) RenewCourseStudent(ctx context.Context, studentID, courseID domain.ID,
+	renewalKey string, renewedAt time.Time) (domain.Enrollment, error) {
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Enrollment{}, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	capacity, err := lockCourseCapacity(ctx, tx, courseID)
+	if err != nil {
+		tx.Rollback()
+		return domain.Enrollment{}, err
+	}
+
+	var pgEnrollment entity.PgEnrollment
+	err = tx.GetContext(ctx, &pgEnrollment, CourseFindEnrollmentQuery, studentID, courseID)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return domain.Enrollment{}, errs.ErrCourseNotRenewable
+		}
+		return domain.Enrollment{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	if enrollment := pgEnrollment.ToDomain(); !enrollment.Active(renewedAt) {
+		err = checkFreeSeat(ctx, tx, courseID, studentID, capacity, renewedAt)
+		if err != nil {
+			tx.Rollback()
+			return domain.Enrollment{}, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, CourseRenewCourseStudentQuery, studentID, courseID, renewedAt, renewalKey)
+	if err != nil {
+		tx.Rollback()
+		return domain.Enrollment{}, errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	_, err = tx.ExecContext(ctx, SeatHoldDeleteQuery, courseID, studentID)
+	if err != nil {
+		tx.Rollback()
+		return domain.Enrollment{}, errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return domain.Enrollment{}, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	enrollment, err := p.FindEnrollment(ctx, studentID, courseID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotExist) {
+			return domain.Enrollment{}, errs.ErrCourseNotRenewable
+		}
+		return domain.Enrollment{}, err
+	}
+	if !enrollment.ExpiresAt.Valid {
+		return domain.Enrollment{}, errs.ErrCourseNotRenewable
+	}
+	return enrollment, nil
+}
+
+func (p *PostgresCourseRepo) MarkEnrollmentReminded(ctx context.Context, studentID, courseID domain.ID,
+	remindedAt time.Time) error {
+	result, err := p.db.ExecContext(ctx, CourseMarkEnrollmentRemindedQuery, studentID, courseID, remindedAt)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrNotExist
+	}
+	return nil
+}
+
 func (p *PostgresCourseRepo) AddCourseTeacher(ctx context.Context, teacherID, courseID domain.ID) error {
 	_, err := p.db.ExecContext(ctx, CourseAddCourseTeacherQuery, teacherID, courseID)
 	if err != nil {
@@ -184,6 +436,54 @@ func (p *PostgresCourseRepo) AddCourseTeacher(ctx context.Context, teacherID, co
 	return nil
 }
 
+func (p *PostgresCourseRepo) RemoveCourseStudent(ctx context.Context, studentID, courseID domain.ID) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	if err = removeCourseStudent(ctx, tx, studentID, courseID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return nil
+}
+
+// removeCourseStudent deletes course student together with lesson and test stats
+// inside the given transaction, so refunds can reuse it
+func removeCourseStudent(ctx context.Context, tx *sqlx.Tx, studentID, courseID domain.ID) error {
+	result, err := tx.ExecContext(ctx, CourseRemoveCourseStudentQuery, studentID, courseID)
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrNotExist
+	}
+
+	_, err = tx.ExecContext(ctx, CourseDeleteStudentLessonStatsQuery, studentID, courseID)
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	_, err = tx.ExecContext(ctx, CourseDeleteStudentTestStatsQuery, studentID, courseID)
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	return nil
+}
+
 func (p *PostgresCourseRepo) Create(ctx context.Context, course domain.Course) (domain.Course, error) {
 	var pgCourse = entity.NewPgCourse(course)
 	queryString := entity.InsertQueryString(pgCourse, "course")
@@ -234,30 +534,60 @@ func (p *PostgresCourseRepo) Update(ctx context.Context, course domain.Course) (
 	return updatedCourse.ToDomain(), nil
 }
 
-func (p *PostgresCourseRepo) UpdateStatus(ctx context.Context, courseID domain.ID, status domain.CourseStatus) error {
-	var pgCourse entity.PgCourse
-	err := p.db.GetContext(ctx, &pgCourse, CourseFindByIDQuery, courseID)
+// UpdateStatus changes the course status and records the transition atomically,
+// course that is not in the expected status is not changed
+func (p *PostgresCourseRepo) UpdateStatus(ctx context.Context, transition domain.CourseTransition) error {
+	pgTransition := entity.NewPgCourseTransition(transition)
+	tx, err := p.db.Beginx()
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return errors.Wrap(errs.ErrNotExist, err.Error())
-		} else {
-			return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
-		}
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
 	}
 
-	course := pgCourse.ToDomain()
-	course.Status = status
-	pgCourse = entity.NewPgCourse(course)
+	result, err := tx.ExecContext(ctx, CourseUpdateStatusQuery, pgTransition.CourseID,
+		pgTransition.FromStatus, pgTransition.ToStatus)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
 
-	queryString := entity.UpdateQueryString(pgCourse, "course")
-	_, err = p.db.NamedExecContext(ctx, queryString, pgCourse)
+	updated, err := result.RowsAffected()
 	if err != nil {
+		tx.Rollback()
 		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
 	}
+	if updated == 0 {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrCourseInvalidTransition, "course status has been changed")
+	}
+
+	_, err = tx.ExecContext(ctx, CourseAddTransitionQuery, pgTransition.ID, pgTransition.CourseID,
+		pgTransition.FromStatus, pgTransition.ToStatus, pgTransition.UserID, pgTransition.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
 
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
 	return nil
 }
 
+func (p *PostgresCourseRepo) FindCourseTransitions(ctx context.Context,
+	courseID domain.ID) ([]domain.CourseTransition, error) {
+	var pgTransitions []entity.PgCourseTransition
+	if err := p.db.SelectContext(ctx, &pgTransitions, CourseFindTransitionsQuery, courseID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	transitions := make([]domain.CourseTransition, len(pgTransitions))
+	for i, transition := range pgTransitions {
+		transitions[i] = transition.ToDomain()
+	}
+	return transitions, nil
+}
+
 func (p *PostgresCourseRepo) Delete(ctx context.Context, courseID domain.ID) error {
 	_, err := p.db.ExecContext(ctx, CourseDeleteQuery, courseID)
 	if err != nil {
@@ -265,3 +595,97 @@ func (p *PostgresCourseRepo) Delete(ctx context.Context, courseID domain.ID) err
 	}
 	return nil
 }
+
+// PublishRevision makes the draft revision the version studied by new students
+func (p *PostgresCourseRepo) PublishRevision(ctx context.Context, courseID domain.ID) error {
+	result, err := p.db.ExecContext(ctx, CoursePublishRevisionQuery, courseID)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if updated == 0 {
+		return errs.ErrCourseNoRevision
+	}
+	return nil
+}
+
+func (p *PostgresCourseRepo) UpdatePublishAt(ctx context.Context, courseID domain.ID,
+	publishAt null.Time) error {
+	result, err := p.db.ExecContext(ctx, CourseUpdatePublishAtQuery, courseID, publishAt)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if updated == 0 {
+		return errs.ErrNotExist
+	}
+	return nil
+}
+
+func (p *PostgresCourseRepo) UpdateEnrollmentVersion(ctx context.Context,
+	studentID, courseID domain.ID, version int) error {
+	result, err := p.db.ExecContext(ctx, CourseUpdateEnrollmentVersionQuery, studentID, courseID, version)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if updated == 0 {
+		return errs.ErrNotExist
+	}
+	return nil
+}
+
+func (p *PostgresCourseRepo) FindCoursePrerequisites(ctx context.Context,
+	courseID domain.ID) ([]domain.CoursePrerequisite, error) {
+	var pgPrerequisites []entity.PgCoursePrerequisite
+	if err := p.db.SelectContext(ctx, &pgPrerequisites, CourseFindPrerequisitesQuery, courseID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	prerequisites := make([]domain.CoursePrerequisite, len(pgPrerequisites))
+	for i, prerequisite := range pgPrerequisites {
+		prerequisites[i] = prerequisite.ToDomain()
+	}
+	return prerequisites, nil
+}
+
+// AddCoursePrerequisite replaces min score of the already required course
+func (p *PostgresCourseRepo) AddCoursePrerequisite(ctx context.Context,
+	prerequisite domain.CoursePrerequisite) error {
+	pgPrerequisite := entity.NewPgCoursePrerequisite(prerequisite)
+	_, err := p.db.ExecContext(ctx, CourseAddPrerequisiteQuery, pgPrerequisite.CourseID,
+		pgPrerequisite.RequiredCourseID, pgPrerequisite.MinScore)
+	if err != nil {
+		return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+	return nil
+}
+
+func (p *PostgresCourseRepo) RemoveCoursePrerequisite(ctx context.Context,
+	courseID, requiredCourseID domain.ID) error {
+	result, err := p.db.ExecContext(ctx, CourseRemovePrerequisiteQuery, courseID, requiredCourseID)
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrNotExist
+	}
+	return nil
+}
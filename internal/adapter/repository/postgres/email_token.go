@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type PostgresEmailTokenRepo struct {
+	db *sqlx.DB
+}
+
+func NewEmailTokenRepo(db *sqlx.DB) *PostgresEmailTokenRepo {
+	return &PostgresEmailTokenRepo{
+		db: db,
+	}
+}
+
+const (
+	EmailTokenUseQuery = "UPDATE public.email_token SET used_at = $2 WHERE id = $1 AND used_at IS NULL"
+)
+
+func (e *PostgresEmailTokenRepo) Create(ctx context.Context, token domain.EmailToken) error {
+	var pgToken = entity.NewPgEmailToken(token)
+	queryString := entity.InsertQueryString(pgToken, "email_token")
+	_, err := e.db.NamedExecContext(ctx, queryString, pgToken)
+	if err != nil {
+		return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+	return nil
+}
+
+func (e *PostgresEmailTokenRepo) Use(ctx context.Context, tokenID domain.ID) error {
+	result, err := e.db.ExecContext(ctx, EmailTokenUseQuery, tokenID, time.Now().UTC())
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrNotExist
+	}
+	return nil
+}
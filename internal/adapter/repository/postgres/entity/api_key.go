@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"strings"
+	"time"
+)
+
+type PgApiKey struct {
+	ID         uuid.UUID `db:"id"`
+	UserID     uuid.UUID `db:"user_id"`
+	Name       string    `db:"name"`
+	Prefix     string    `db:"prefix"`
+	KeyHash    string    `db:"key_hash"`
+	Scopes     string    `db:"scopes"`
+	CreatedAt  time.Time `db:"created_at"`
+	LastUsedAt null.Time `db:"last_used_at"`
+	RevokedAt  null.Time `db:"revoked_at"`
+}
+
+func (k *PgApiKey) ToDomain() domain.ApiKey {
+	var scopes []domain.ApiKeyScope
+	for _, scope := range strings.Fields(k.Scopes) {
+		scopes = append(scopes, domain.ApiKeyScope(scope))
+	}
+
+	return domain.ApiKey{
+		ID:         domain.ID(k.ID.String()),
+		UserID:     domain.ID(k.UserID.String()),
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		KeyHash:    k.KeyHash,
+		Scopes:     scopes,
+		CreatedAt:  k.CreatedAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+func NewPgApiKey(key domain.ApiKey) PgApiKey {
+	id, _ := uuid.Parse(key.ID.String())
+	userID, _ := uuid.Parse(key.UserID.String())
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return PgApiKey{
+		ID:         id,
+		UserID:     userID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		KeyHash:    key.KeyHash,
+		Scopes:     strings.Join(scopes, " "),
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
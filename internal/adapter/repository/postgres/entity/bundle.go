@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+const (
+	PgBundleDraft     = "draft"
+	PgBundlePublished = "published"
+)
+
+type PgBundle struct {
+	ID          uuid.UUID `db:"id"`
+	SchoolID    uuid.UUID `db:"school_id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Price       int64     `db:"price"`
+	Status      string    `db:"status"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (b *PgBundle) ToDomain() domain.Bundle {
+	var status domain.BundleStatus
+	switch b.Status {
+	case PgBundleDraft:
+		status = domain.BundleDraft
+	case PgBundlePublished:
+		status = domain.BundlePublished
+	}
+
+	return domain.Bundle{
+		ID:          domain.ID(b.ID.String()),
+		SchoolID:    domain.ID(b.SchoolID.String()),
+		Name:        b.Name,
+		Description: b.Description,
+		Price:       b.Price,
+		Status:      status,
+		CreatedAt:   b.CreatedAt,
+	}
+}
+
+func NewPgBundle(bundle domain.Bundle) PgBundle {
+	id, _ := uuid.Parse(bundle.ID.String())
+	schoolID, _ := uuid.Parse(bundle.SchoolID.String())
+	var status string
+	switch bundle.Status {
+	case domain.BundleDraft:
+		status = PgBundleDraft
+	case domain.BundlePublished:
+		status = PgBundlePublished
+	}
+
+	return PgBundle{
+		ID:          id,
+		SchoolID:    schoolID,
+		Name:        bundle.Name,
+		Description: bundle.Description,
+		Price:       bundle.Price,
+		Status:      status,
+		CreatedAt:   bundle.CreatedAt,
+	}
+}
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type PgCategory struct {
+	ID        uuid.UUID     `db:"id"`
+	ParentID  uuid.NullUUID `db:"parent_id"`
+	Name      string        `db:"name"`
+	CreatedAt time.Time     `db:"created_at"`
+}
+
+func (c *PgCategory) ToDomain() domain.Category {
+	var parentID domain.ID
+	if c.ParentID.Valid {
+		parentID = domain.ID(c.ParentID.UUID.String())
+	}
+
+	return domain.Category{
+		ID:        domain.ID(c.ID.String()),
+		ParentID:  parentID,
+		Name:      c.Name,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+func NewPgCategory(category domain.Category) PgCategory {
+	id, _ := uuid.Parse(category.ID.String())
+	var parentID uuid.NullUUID
+	if category.ParentID != "" {
+		parentID.UUID, _ = uuid.Parse(category.ParentID.String())
+		parentID.Valid = true
+	}
+
+	return PgCategory{
+		ID:        id,
+		ParentID:  parentID,
+		Name:      category.Name,
+		CreatedAt: category.CreatedAt,
+	}
+}
+
+type PgCourseCategory struct {
+	CourseID   uuid.UUID `db:"course_id"`
+	CategoryID uuid.UUID `db:"category_id"`
+}
+
+func (c *PgCourseCategory) ToDomain() domain.CourseCategory {
+	return domain.CourseCategory{
+		CourseID:   domain.ID(c.CourseID.String()),
+		CategoryID: domain.ID(c.CategoryID.String()),
+	}
+}
+
+type PgTag struct {
+	ID       uuid.UUID `db:"id"`
+	SchoolID uuid.UUID `db:"school_id"`
+	Name     string    `db:"name"`
+}
+
+func (t *PgTag) ToDomain() domain.Tag {
+	return domain.Tag{
+		ID:       domain.ID(t.ID.String()),
+		SchoolID: domain.ID(t.SchoolID.String()),
+		Name:     t.Name,
+	}
+}
+
+func NewPgTag(tag domain.Tag) PgTag {
+	id, _ := uuid.Parse(tag.ID.String())
+	schoolID, _ := uuid.Parse(tag.SchoolID.String())
+
+	return PgTag{
+		ID:       id,
+		SchoolID: schoolID,
+		Name:     tag.Name,
+	}
+}
@@ -2,67 +2,202 @@ package entity
 
 import (
 	"github.com/google/uuid"
+	"github.com/guregu/null"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
 )
 
 const (
-	PgCourseDraft     = "draft"
-	PgCourseReady     = "ready"
-	PgCoursePublished = "published"
+	PgCourseDraft       = "draft"
+	PgCourseReady       = "ready"
+	PgCoursePublished   = "published"
+	PgCourseUnpublished = "unpublished"
+	PgCourseArchived    = "archived"
 )
 
 type PgCourse struct {
-	ID       uuid.UUID `db:"id"`
-	SchoolID uuid.UUID `db:"school_id"`
-	Name     string    `db:"name"`
-	Level    int       `db:"level"`
-	Price    int64     `db:"price"`
-	Language string    `db:"language"`
-	Status   string    `db:"status"`
+	ID         uuid.UUID `db:"id"`
+	SchoolID   uuid.UUID `db:"school_id"`
+	Name       string    `db:"name"`
+	Level      int       `db:"level"`
+	Price      int64     `db:"price"`
+	Currency   string    `db:"currency"`
+	Language   string    `db:"language"`
+	Status     string    `db:"status"`
+	Capacity   null.Int  `db:"capacity"`
+	AccessDays null.Int  `db:"access_days"`
+
+	Version          int       `db:"version"`
+	PublishedVersion int       `db:"published_version"`
+	PublishAt        null.Time `db:"publish_at"`
 }
 
 func (s *PgCourse) ToDomain() domain.Course {
-	var status domain.CourseStatus
-	switch s.Status {
-	case PgCourseDraft:
-		status = domain.CourseDraft
-	case PgCourseReady:
-		status = domain.CourseReady
-	case PgCoursePublished:
-		status = domain.CoursePublished
-	}
-
 	return domain.Course{
-		ID:       domain.ID(s.ID.String()),
-		SchoolID: domain.ID(s.SchoolID.String()),
-		Name:     s.Name,
-		Level:    s.Level,
-		Price:    s.Price,
-		Language: s.Language,
-		Status:   status,
+		ID:         domain.ID(s.ID.String()),
+		SchoolID:   domain.ID(s.SchoolID.String()),
+		Name:       s.Name,
+		Level:      s.Level,
+		Price:      domain.NewMoney(s.Price, s.Currency),
+		Language:   s.Language,
+		Status:     courseStatusToDomain(s.Status),
+		Capacity:   s.Capacity,
+		AccessDays: s.AccessDays,
+
+		Version:          s.Version,
+		PublishedVersion: s.PublishedVersion,
+		PublishAt:        s.PublishAt,
 	}
 }
 
 func NewPgCourse(course domain.Course) PgCourse {
 	id, _ := uuid.Parse(course.ID.String())
 	schoolID, _ := uuid.Parse(course.SchoolID.String())
-	var status string
-	switch course.Status {
-	case domain.CourseDraft:
-		status = PgCourseDraft
+
+	return PgCourse{
+		ID:         id,
+		SchoolID:   schoolID,
+		Name:       course.Name,
+		Level:      course.Level,
+		Price:      course.Price.Amount,
+		Currency:   course.Price.Currency,
+		Language:   course.Language,
+		Status:     newPgCourseStatus(course.Status),
+		Capacity:   course.Capacity,
+		AccessDays: course.AccessDays,
+
+		Version:          course.Version,
+		PublishedVersion: course.PublishedVersion,
+		PublishAt:        course.PublishAt,
+	}
+}
+
+func courseStatusToDomain(status string) domain.CourseStatus {
+	switch status {
+	case PgCourseReady:
+		return domain.CourseReady
+	case PgCoursePublished:
+		return domain.CoursePublished
+	case PgCourseUnpublished:
+		return domain.CourseUnpublished
+	case PgCourseArchived:
+		return domain.CourseArchived
+	default:
+		return domain.CourseDraft
+	}
+}
+
+func newPgCourseStatus(status domain.CourseStatus) string {
+	switch status {
 	case domain.CourseReady:
-		status = PgCourseReady
+		return PgCourseReady
 	case domain.CoursePublished:
-		status = PgCoursePublished
+		return PgCoursePublished
+	case domain.CourseUnpublished:
+		return PgCourseUnpublished
+	case domain.CourseArchived:
+		return PgCourseArchived
+	default:
+		return PgCourseDraft
 	}
+}
 
-	return PgCourse{
-		ID:       id,
-		SchoolID: schoolID,
-		Name:     course.Name,
-		Level:    course.Level,
-		Price:    course.Price,
-		Language: course.Language,
-		Status:   status,
+type PgCourseTransition struct {
+	ID         uuid.UUID     `db:"id"`
+	CourseID   uuid.UUID     `db:"course_id"`
+	FromStatus string        `db:"from_status"`
+	ToStatus   string        `db:"to_status"`
+	UserID     uuid.NullUUID `db:"user_id"`
+	CreatedAt  time.Time     `db:"created_at"`
+}
+
+func (t *PgCourseTransition) ToDomain() domain.CourseTransition {
+	var userID domain.ID
+	if t.UserID.Valid {
+		userID = domain.ID(t.UserID.UUID.String())
+	}
+
+	return domain.CourseTransition{
+		ID:        domain.ID(t.ID.String()),
+		CourseID:  domain.ID(t.CourseID.String()),
+		From:      courseStatusToDomain(t.FromStatus),
+		To:        courseStatusToDomain(t.ToStatus),
+		UserID:    userID,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+func NewPgCourseTransition(transition domain.CourseTransition) PgCourseTransition {
+	id, _ := uuid.Parse(transition.ID.String())
+	courseID, _ := uuid.Parse(transition.CourseID.String())
+	var userID uuid.NullUUID
+	if transition.UserID != "" {
+		userID.UUID, _ = uuid.Parse(transition.UserID.String())
+		userID.Valid = true
+	}
+
+	return PgCourseTransition{
+		ID:         id,
+		CourseID:   courseID,
+		FromStatus: newPgCourseStatus(transition.From),
+		ToStatus:   newPgCourseStatus(transition.To),
+		UserID:     userID,
+		CreatedAt:  transition.CreatedAt,
+	}
+}
+
+type PgEnrollment struct {
+	StudentID  uuid.UUID `db:"student_id"`
+	CourseID   uuid.UUID `db:"course_id"`
+	EnrolledAt time.Time `db:"enrolled_at"`
+	ExpiresAt  null.Time `db:"expires_at"`
+	Version    int       `db:"version"`
+}
+
+func (e *PgEnrollment) ToDomain() domain.Enrollment {
+	return domain.Enrollment{
+		StudentID:  domain.ID(e.StudentID.String()),
+		CourseID:   domain.ID(e.CourseID.String()),
+		EnrolledAt: e.EnrolledAt,
+		ExpiresAt:  e.ExpiresAt,
+		Version:    e.Version,
+	}
+}
+
+func NewPgEnrollment(enrollment domain.Enrollment) PgEnrollment {
+	studentID, _ := uuid.Parse(enrollment.StudentID.String())
+	courseID, _ := uuid.Parse(enrollment.CourseID.String())
+
+	return PgEnrollment{
+		StudentID:  studentID,
+		CourseID:   courseID,
+		EnrolledAt: enrollment.EnrolledAt,
+		ExpiresAt:  enrollment.ExpiresAt,
+		Version:    enrollment.Version,
+	}
+}
+
+type PgCoursePrerequisite struct {
+	CourseID         uuid.UUID `db:"course_id"`
+	RequiredCourseID uuid.UUID `db:"required_course_id"`
+	MinScore         null.Int  `db:"min_score"`
+}
+
+func (p *PgCoursePrerequisite) ToDomain() domain.CoursePrerequisite {
+	return domain.CoursePrerequisite{
+		CourseID:         domain.ID(p.CourseID.String()),
+		RequiredCourseID: domain.ID(p.RequiredCourseID.String()),
+		MinScore:         p.MinScore,
+	}
+}
+
+func NewPgCoursePrerequisite(prerequisite domain.CoursePrerequisite) PgCoursePrerequisite {
+	courseID, _ := uuid.Parse(prerequisite.CourseID.String())
+	requiredCourseID, _ := uuid.Parse(prerequisite.RequiredCourseID.String())
+
+	return PgCoursePrerequisite{
+		CourseID:         courseID,
+		RequiredCourseID: requiredCourseID,
+		MinScore:         prerequisite.MinScore,
 	}
 }
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+const (
+	PgEmailVerificationPurpose = "email_verification"
+	PgPasswordResetPurpose     = "password_reset"
+)
+
+type PgEmailToken struct {
+	ID        uuid.UUID `db:"id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Purpose   string    `db:"purpose"`
+	ExpiresAt time.Time `db:"expires_at"`
+	UsedAt    null.Time `db:"used_at"`
+}
+
+func (t *PgEmailToken) ToDomain() domain.EmailToken {
+	var purpose domain.EmailTokenPurpose
+	switch t.Purpose {
+	case PgEmailVerificationPurpose:
+		purpose = domain.EmailVerificationPurpose
+	case PgPasswordResetPurpose:
+		purpose = domain.PasswordResetPurpose
+	}
+
+	return domain.EmailToken{
+		ID:        domain.ID(t.ID.String()),
+		UserID:    domain.ID(t.UserID.String()),
+		Purpose:   purpose,
+		ExpiresAt: t.ExpiresAt,
+	}
+}
+
+func NewPgEmailToken(token domain.EmailToken) PgEmailToken {
+	id, _ := uuid.Parse(token.ID.String())
+	userID, _ := uuid.Parse(token.UserID.String())
+	var purpose string
+	switch token.Purpose {
+	case domain.EmailVerificationPurpose:
+		purpose = PgEmailVerificationPurpose
+	case domain.PasswordResetPurpose:
+		purpose = PgPasswordResetPurpose
+	}
+
+	return PgEmailToken{
+		ID:        id,
+		UserID:    userID,
+		Purpose:   purpose,
+		ExpiresAt: token.ExpiresAt,
+	}
+}
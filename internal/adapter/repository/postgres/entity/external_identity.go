@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/paw1a/eschool/internal/core/domain"
+)
+
+type PgExternalIdentity struct {
+	Provider string    `db:"provider"`
+	Subject  string    `db:"subject"`
+	UserID   uuid.UUID `db:"user_id"`
+	Email    string    `db:"email"`
+}
+
+func (i *PgExternalIdentity) ToDomain() domain.ExternalIdentity {
+	return domain.ExternalIdentity{
+		UserID:   domain.ID(i.UserID.String()),
+		Provider: i.Provider,
+		Subject:  i.Subject,
+		Email:    i.Email,
+	}
+}
+
+func NewPgExternalIdentity(identity domain.ExternalIdentity) PgExternalIdentity {
+	userID, _ := uuid.Parse(identity.UserID.String())
+	return PgExternalIdentity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		UserID:   userID,
+		Email:    identity.Email,
+	}
+}
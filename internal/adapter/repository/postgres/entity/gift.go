@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type PgGift struct {
+	ID             uuid.UUID     `db:"id"`
+	Code           string        `db:"code"`
+	CourseID       uuid.UUID     `db:"course_id"`
+	RecipientEmail string        `db:"recipient_email"`
+	PaymentKey     string        `db:"payment_key"`
+	PaySum         int64         `db:"pay_sum"`
+	Currency       string        `db:"currency"`
+	CreatedAt      time.Time     `db:"created_at"`
+	RedeemedBy     uuid.NullUUID `db:"redeemed_by"`
+	RedeemedAt     null.Time     `db:"redeemed_at"`
+}
+
+func (g *PgGift) ToDomain() domain.Gift {
+	var redeemedBy null.String
+	if g.RedeemedBy.Valid {
+		redeemedBy = null.StringFrom(g.RedeemedBy.UUID.String())
+	}
+
+	return domain.Gift{
+		ID:             domain.ID(g.ID.String()),
+		Code:           g.Code,
+		CourseID:       domain.ID(g.CourseID.String()),
+		RecipientEmail: g.RecipientEmail,
+		PaymentKey:     g.PaymentKey,
+		PaySum:         g.PaySum,
+		Currency:       g.Currency,
+		CreatedAt:      g.CreatedAt,
+		RedeemedBy:     redeemedBy,
+		RedeemedAt:     g.RedeemedAt,
+	}
+}
+
+func NewPgGift(gift domain.Gift) PgGift {
+	id, _ := uuid.Parse(gift.ID.String())
+	courseID, _ := uuid.Parse(gift.CourseID.String())
+	var redeemedBy uuid.NullUUID
+	if gift.RedeemedBy.Valid {
+		redeemedBy.UUID, _ = uuid.Parse(gift.RedeemedBy.String)
+		redeemedBy.Valid = true
+	}
+
+	return PgGift{
+		ID:             id,
+		Code:           gift.Code,
+		CourseID:       courseID,
+		RecipientEmail: gift.RecipientEmail,
+		PaymentKey:     gift.PaymentKey,
+		PaySum:         gift.PaySum,
+		Currency:       gift.Currency,
+		CreatedAt:      gift.CreatedAt,
+		RedeemedBy:     redeemedBy,
+		RedeemedAt:     gift.RedeemedAt,
+	}
+}
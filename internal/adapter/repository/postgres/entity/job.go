@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+const (
+	PgJobPending = "pending"
+	PgJobDone    = "done"
+	PgJobFailed  = "failed"
+)
+
+type PgJob struct {
+	ID          uuid.UUID     `db:"id"`
+	Kind        string        `db:"kind"`
+	TargetID    uuid.UUID     `db:"target_id"`
+	UserID      uuid.NullUUID `db:"user_id"`
+	RunAt       time.Time     `db:"run_at"`
+	Status      string        `db:"status"`
+	Attempts    int           `db:"attempts"`
+	LockedUntil null.Time     `db:"locked_until"`
+	LastError   null.String   `db:"last_error"`
+	CreatedAt   time.Time     `db:"created_at"`
+}
+
+func (j *PgJob) ToDomain() domain.Job {
+	var userID domain.ID
+	if j.UserID.Valid {
+		userID = domain.ID(j.UserID.UUID.String())
+	}
+
+	var status domain.JobStatus
+	switch j.Status {
+	case PgJobDone:
+		status = domain.JobDone
+	case PgJobFailed:
+		status = domain.JobFailed
+	default:
+		status = domain.JobPending
+	}
+
+	return domain.Job{
+		ID:          domain.ID(j.ID.String()),
+		Kind:        j.Kind,
+		TargetID:    domain.ID(j.TargetID.String()),
+		UserID:      userID,
+		RunAt:       j.RunAt,
+		Status:      status,
+		Attempts:    j.Attempts,
+		LockedUntil: j.LockedUntil,
+		LastError:   j.LastError,
+		CreatedAt:   j.CreatedAt,
+	}
+}
+
+func NewPgJob(job domain.Job) PgJob {
+	id, _ := uuid.Parse(job.ID.String())
+	targetID, _ := uuid.Parse(job.TargetID.String())
+	var userID uuid.NullUUID
+	if job.UserID != "" {
+		userID.UUID, _ = uuid.Parse(job.UserID.String())
+		userID.Valid = true
+	}
+
+	var status string
+	switch job.Status {
+	case domain.JobDone:
+		status = PgJobDone
+	case domain.JobFailed:
+		status = PgJobFailed
+	default:
+		status = PgJobPending
+	}
+
+	return PgJob{
+		ID:          id,
+		Kind:        job.Kind,
+		TargetID:    targetID,
+		UserID:      userID,
+		RunAt:       job.RunAt,
+		Status:      status,
+		Attempts:    job.Attempts,
+		LockedUntil: job.LockedUntil,
+		LastError:   job.LastError,
+		CreatedAt:   job.CreatedAt,
+	}
+}
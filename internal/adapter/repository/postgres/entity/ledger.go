@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+const (
+	PgLedgerSale   = "sale"
+	PgLedgerRefund = "refund"
+)
+
+type PgLedgerEntry struct {
+	ID         uuid.UUID     `db:"id"`
+	SchoolID   uuid.UUID     `db:"school_id"`
+	CourseID   uuid.UUID     `db:"course_id"`
+	UserID     uuid.NullUUID `db:"user_id"`
+	PaymentKey string        `db:"payment_key"`
+	Kind       string        `db:"kind"`
+	Gross      int64         `db:"gross"`
+	Commission int64         `db:"commission"`
+	Currency   string        `db:"currency"`
+	CreatedAt  time.Time     `db:"created_at"`
+	PayoutID   uuid.NullUUID `db:"payout_id"`
+}
+
+func (e *PgLedgerEntry) ToDomain() domain.LedgerEntry {
+	var kind domain.LedgerEntryKind
+	switch e.Kind {
+	case PgLedgerSale:
+		kind = domain.LedgerSale
+	case PgLedgerRefund:
+		kind = domain.LedgerRefund
+	}
+
+	var userID domain.ID
+	if e.UserID.Valid {
+		userID = domain.ID(e.UserID.UUID.String())
+	}
+
+	var payoutID null.String
+	if e.PayoutID.Valid {
+		payoutID = null.StringFrom(e.PayoutID.UUID.String())
+	}
+
+	return domain.LedgerEntry{
+		ID:         domain.ID(e.ID.String()),
+		SchoolID:   domain.ID(e.SchoolID.String()),
+		CourseID:   domain.ID(e.CourseID.String()),
+		UserID:     userID,
+		PaymentKey: e.PaymentKey,
+		Kind:       kind,
+		Gross:      e.Gross,
+		Commission: e.Commission,
+		Currency:   e.Currency,
+		CreatedAt:  e.CreatedAt,
+		PayoutID:   payoutID,
+	}
+}
+
+func NewPgLedgerEntry(entry domain.LedgerEntry) PgLedgerEntry {
+	id, _ := uuid.Parse(entry.ID.String())
+	schoolID, _ := uuid.Parse(entry.SchoolID.String())
+	courseID, _ := uuid.Parse(entry.CourseID.String())
+	// gift sales have no user until the gift is redeemed
+	var userID uuid.NullUUID
+	if entry.UserID != "" {
+		userID.UUID, _ = uuid.Parse(entry.UserID.String())
+		userID.Valid = true
+	}
+	var payoutID uuid.NullUUID
+	if entry.PayoutID.Valid {
+		payoutID.UUID, _ = uuid.Parse(entry.PayoutID.String)
+		payoutID.Valid = true
+	}
+
+	var kind string
+	switch entry.Kind {
+	case domain.LedgerSale:
+		kind = PgLedgerSale
+	case domain.LedgerRefund:
+		kind = PgLedgerRefund
+	}
+
+	return PgLedgerEntry{
+		ID:         id,
+		SchoolID:   schoolID,
+		CourseID:   courseID,
+		UserID:     userID,
+		PaymentKey: entry.PaymentKey,
+		Kind:       kind,
+		Gross:      entry.Gross,
+		Commission: entry.Commission,
+		Currency:   entry.Currency,
+		CreatedAt:  entry.CreatedAt,
+		PayoutID:   payoutID,
+	}
+}
+
+type PgPayout struct {
+	ID           uuid.UUID `db:"id"`
+	SchoolID     uuid.UUID `db:"school_id"`
+	Amount       int64     `db:"amount"`
+	Currency     string    `db:"currency"`
+	SettledUntil time.Time `db:"settled_until"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+func (p *PgPayout) ToDomain() domain.Payout {
+	return domain.Payout{
+		ID:           domain.ID(p.ID.String()),
+		SchoolID:     domain.ID(p.SchoolID.String()),
+		Amount:       p.Amount,
+		Currency:     p.Currency,
+		SettledUntil: p.SettledUntil,
+		CreatedAt:    p.CreatedAt,
+	}
+}
+
+func NewPgPayout(payout domain.Payout) PgPayout {
+	id, _ := uuid.Parse(payout.ID.String())
+	schoolID, _ := uuid.Parse(payout.SchoolID.String())
+
+	return PgPayout{
+		ID:           id,
+		SchoolID:     schoolID,
+		Amount:       payout.Amount,
+		Currency:     payout.Currency,
+		SettledUntil: payout.SettledUntil,
+		CreatedAt:    payout.CreatedAt,
+	}
+}
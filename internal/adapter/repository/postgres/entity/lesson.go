@@ -15,17 +15,25 @@ const (
 
 type PgLesson struct {
 	ID       uuid.UUID `db:"id"`
+	Key      uuid.UUID `db:"key"`
 	CourseID uuid.UUID `db:"course_id"`
+	Version  int       `db:"version"`
 	Title    string    `db:"title"`
 	Score    int       `db:"score"`
 	Type     string    `db:"type"`
 
 	TheoryUrl null.String `db:"theory_url"`
 	VideoUrl  null.String `db:"video_url"`
+
+	ReleaseAt   null.Time `db:"release_at"`
+	ReleaseDays null.Int  `db:"release_days"`
+
+	IsPreview bool `db:"is_preview"`
 }
 
 type PgTest struct {
 	ID       uuid.UUID `db:"id"`
+	Key      uuid.UUID `db:"key"`
 	LessonID uuid.UUID `db:"lesson_id"`
 	TaskUrl  string    `db:"task_url"`
 	Options  string    `db:"options"`
@@ -47,18 +55,26 @@ func (s *PgLesson) ToDomain() domain.Lesson {
 
 	return domain.Lesson{
 		ID:        domain.ID(s.ID.String()),
+		Key:       domain.ID(s.Key.String()),
 		CourseID:  domain.ID(s.CourseID.String()),
+		Version:   s.Version,
 		Title:     s.Title,
 		Score:     s.Score,
 		Type:      lessonType,
 		TheoryUrl: s.TheoryUrl,
 		VideoUrl:  s.VideoUrl,
 		Tests:     nil,
+
+		ReleaseAt:   s.ReleaseAt,
+		ReleaseDays: s.ReleaseDays,
+
+		IsPreview: s.IsPreview,
 	}
 }
 
 func NewPgLesson(lesson domain.Lesson) PgLesson {
 	id, _ := uuid.Parse(lesson.ID.String())
+	key, _ := uuid.Parse(lesson.Key.String())
 	courseID, _ := uuid.Parse(lesson.CourseID.String())
 	var lessonType string
 	switch lesson.Type {
@@ -72,12 +88,19 @@ func NewPgLesson(lesson domain.Lesson) PgLesson {
 
 	return PgLesson{
 		ID:        id,
+		Key:       key,
 		CourseID:  courseID,
+		Version:   lesson.Version,
 		Title:     lesson.Title,
 		Score:     lesson.Score,
 		Type:      lessonType,
 		TheoryUrl: lesson.TheoryUrl,
 		VideoUrl:  lesson.VideoUrl,
+
+		ReleaseAt:   lesson.ReleaseAt,
+		ReleaseDays: lesson.ReleaseDays,
+
+		IsPreview: lesson.IsPreview,
 	}
 }
 
@@ -85,6 +108,7 @@ func (s *PgTest) ToDomain() domain.Test {
 	options := strings.Split(s.Options, "\n")
 	return domain.Test{
 		ID:       domain.ID(s.ID.String()),
+		Key:      domain.ID(s.Key.String()),
 		LessonID: domain.ID(s.LessonID.String()),
 		TaskUrl:  s.TaskUrl,
 		Options:  options,
@@ -96,10 +120,12 @@ func (s *PgTest) ToDomain() domain.Test {
 
 func NewPgTest(test domain.Test) PgTest {
 	id, _ := uuid.Parse(test.ID.String())
+	key, _ := uuid.Parse(test.Key.String())
 	lessonID, _ := uuid.Parse(test.LessonID.String())
 	options := strings.Join(test.Options, "\n")
 	return PgTest{
 		ID:       id,
+		Key:      key,
 		LessonID: lessonID,
 		TaskUrl:  test.TaskUrl,
 		Options:  options,
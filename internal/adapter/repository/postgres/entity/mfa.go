@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/paw1a/eschool/internal/core/domain"
+)
+
+type PgMfaSettings struct {
+	UserID  uuid.UUID `db:"user_id"`
+	Secret  string    `db:"secret"`
+	Enabled bool      `db:"enabled"`
+}
+
+type PgMfaRecoveryCode struct {
+	ID       uuid.UUID `db:"id"`
+	UserID   uuid.UUID `db:"user_id"`
+	CodeHash string    `db:"code_hash"`
+	Used     bool      `db:"used"`
+}
+
+func (m *PgMfaSettings) ToDomain() domain.MfaSettings {
+	return domain.MfaSettings{
+		UserID:  domain.ID(m.UserID.String()),
+		Secret:  m.Secret,
+		Enabled: m.Enabled,
+	}
+}
+
+func NewPgMfaSettings(settings domain.MfaSettings) PgMfaSettings {
+	userID, _ := uuid.Parse(settings.UserID.String())
+	return PgMfaSettings{
+		UserID:  userID,
+		Secret:  settings.Secret,
+		Enabled: settings.Enabled,
+	}
+}
+
+func NewPgMfaRecoveryCode(userID domain.ID, codeHash string) PgMfaRecoveryCode {
+	id, _ := uuid.Parse(domain.NewID().String())
+	pgUserID, _ := uuid.Parse(userID.String())
+	return PgMfaRecoveryCode{
+		ID:       id,
+		UserID:   pgUserID,
+		CodeHash: codeHash,
+		Used:     false,
+	}
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type PgLearningPath struct {
+	ID          uuid.UUID `db:"id"`
+	SchoolID    uuid.UUID `db:"school_id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (p *PgLearningPath) ToDomain() domain.LearningPath {
+	return domain.LearningPath{
+		ID:          domain.ID(p.ID.String()),
+		SchoolID:    domain.ID(p.SchoolID.String()),
+		Name:        p.Name,
+		Description: p.Description,
+		CreatedAt:   p.CreatedAt,
+	}
+}
+
+func NewPgLearningPath(path domain.LearningPath) PgLearningPath {
+	id, _ := uuid.Parse(path.ID.String())
+	schoolID, _ := uuid.Parse(path.SchoolID.String())
+
+	return PgLearningPath{
+		ID:          id,
+		SchoolID:    schoolID,
+		Name:        path.Name,
+		Description: path.Description,
+		CreatedAt:   path.CreatedAt,
+	}
+}
+
+type PgPathCertificate struct {
+	ID       uuid.UUID `db:"id"`
+	PathID   uuid.UUID `db:"path_id"`
+	UserID   uuid.UUID `db:"user_id"`
+	Score    int       `db:"score"`
+	IssuedAt time.Time `db:"issued_at"`
+}
+
+func (c *PgPathCertificate) ToDomain() domain.PathCertificate {
+	return domain.PathCertificate{
+		ID:       domain.ID(c.ID.String()),
+		PathID:   domain.ID(c.PathID.String()),
+		UserID:   domain.ID(c.UserID.String()),
+		Score:    c.Score,
+		IssuedAt: c.IssuedAt,
+	}
+}
+
+func NewPgPathCertificate(certificate domain.PathCertificate) PgPathCertificate {
+	id, _ := uuid.Parse(certificate.ID.String())
+	pathID, _ := uuid.Parse(certificate.PathID.String())
+	userID, _ := uuid.Parse(certificate.UserID.String())
+
+	return PgPathCertificate{
+		ID:       id,
+		PathID:   pathID,
+		UserID:   userID,
+		Score:    certificate.Score,
+		IssuedAt: certificate.IssuedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type PgPayment struct {
+	UserID   uuid.UUID `db:"user_id"`
+	CourseID uuid.UUID `db:"course_id"`
+	Key      string    `db:"key"`
+	PaySum   int64     `db:"pay_sum"`
+	Currency string    `db:"currency"`
+	PaidAt   time.Time `db:"paid_at"`
+}
+
+func (p *PgPayment) ToDomain() domain.Payment {
+	return domain.Payment{
+		UserID:   domain.ID(p.UserID.String()),
+		CourseID: domain.ID(p.CourseID.String()),
+		Key:      p.Key,
+		PaySum:   p.PaySum,
+		Currency: p.Currency,
+		PaidAt:   p.PaidAt,
+	}
+}
+
+func NewPgPayment(payment domain.Payment) PgPayment {
+	userID, _ := uuid.Parse(payment.UserID.String())
+	courseID, _ := uuid.Parse(payment.CourseID.String())
+
+	return PgPayment{
+		UserID:   userID,
+		CourseID: courseID,
+		Key:      payment.Key,
+		PaySum:   payment.PaySum,
+		Currency: payment.Currency,
+		PaidAt:   payment.PaidAt,
+	}
+}
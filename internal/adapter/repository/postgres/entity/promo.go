@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+const (
+	PgPromoPercentDiscount = "percent"
+	PgPromoFixedDiscount   = "fixed"
+)
+
+type PgPromoCode struct {
+	ID           uuid.UUID     `db:"id"`
+	SchoolID     uuid.UUID     `db:"school_id"`
+	CourseID     uuid.NullUUID `db:"course_id"`
+	Code         string        `db:"code"`
+	DiscountType string        `db:"discount_type"`
+	Discount     int64         `db:"discount"`
+	Currency     null.String   `db:"currency"`
+	MaxUses      null.Int      `db:"max_uses"`
+	UsedCount    int64         `db:"used_count"`
+	ValidFrom    null.Time     `db:"valid_from"`
+	ValidUntil   null.Time     `db:"valid_until"`
+	CreatedAt    time.Time     `db:"created_at"`
+}
+
+func (p *PgPromoCode) ToDomain() domain.PromoCode {
+	var discountType domain.PromoDiscountType
+	switch p.DiscountType {
+	case PgPromoPercentDiscount:
+		discountType = domain.PromoPercentDiscount
+	case PgPromoFixedDiscount:
+		discountType = domain.PromoFixedDiscount
+	}
+
+	var courseID null.String
+	if p.CourseID.Valid {
+		courseID = null.StringFrom(p.CourseID.UUID.String())
+	}
+
+	return domain.PromoCode{
+		ID:           domain.ID(p.ID.String()),
+		SchoolID:     domain.ID(p.SchoolID.String()),
+		CourseID:     courseID,
+		Code:         p.Code,
+		DiscountType: discountType,
+		Discount:     p.Discount,
+		Currency:     p.Currency.String,
+		MaxUses:      p.MaxUses,
+		UsedCount:    p.UsedCount,
+		ValidFrom:    p.ValidFrom,
+		ValidUntil:   p.ValidUntil,
+		CreatedAt:    p.CreatedAt,
+	}
+}
+
+func NewPgPromoCode(promo domain.PromoCode) PgPromoCode {
+	id, _ := uuid.Parse(promo.ID.String())
+	schoolID, _ := uuid.Parse(promo.SchoolID.String())
+	var courseID uuid.NullUUID
+	if promo.CourseID.Valid {
+		courseID.UUID, _ = uuid.Parse(promo.CourseID.String)
+		courseID.Valid = true
+	}
+
+	var discountType string
+	switch promo.DiscountType {
+	case domain.PromoPercentDiscount:
+		discountType = PgPromoPercentDiscount
+	case domain.PromoFixedDiscount:
+		discountType = PgPromoFixedDiscount
+	}
+
+	return PgPromoCode{
+		ID:           id,
+		SchoolID:     schoolID,
+		CourseID:     courseID,
+		Code:         promo.Code,
+		DiscountType: discountType,
+		Discount:     promo.Discount,
+		Currency:     null.NewString(promo.Currency, promo.Currency != ""),
+		MaxUses:      promo.MaxUses,
+		UsedCount:    promo.UsedCount,
+		ValidFrom:    promo.ValidFrom,
+		ValidUntil:   promo.ValidUntil,
+		CreatedAt:    promo.CreatedAt,
+	}
+}
+
+type PgPromoRedemption struct {
+	PromoCodeID uuid.UUID `db:"promo_code_id"`
+	UserID      uuid.UUID `db:"user_id"`
+	CourseID    uuid.UUID `db:"course_id"`
+	PaySum      int64     `db:"pay_sum"`
+	RedeemedAt  time.Time `db:"redeemed_at"`
+}
+
+func NewPgPromoRedemption(redemption domain.PromoRedemption) PgPromoRedemption {
+	promoCodeID, _ := uuid.Parse(redemption.PromoCodeID.String())
+	userID, _ := uuid.Parse(redemption.UserID.String())
+	courseID, _ := uuid.Parse(redemption.CourseID.String())
+
+	return PgPromoRedemption{
+		PromoCodeID: promoCodeID,
+		UserID:      userID,
+		CourseID:    courseID,
+		PaySum:      redemption.PaySum,
+		RedeemedAt:  redemption.RedeemedAt,
+	}
+}
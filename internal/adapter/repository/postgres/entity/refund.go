@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+const (
+	PgRefundRequested = "requested"
+	PgRefundApproved  = "approved"
+	PgRefundRejected  = "rejected"
+	PgRefundApproving = "approving"
+)
+
+type PgRefund struct {
+	ID          uuid.UUID     `db:"id"`
+	UserID      uuid.UUID     `db:"user_id"`
+	CourseID    uuid.UUID     `db:"course_id"`
+	PaymentKey  string        `db:"payment_key"`
+	Amount      int64         `db:"amount"`
+	Currency    string        `db:"currency"`
+	Reason      string        `db:"reason"`
+	Status      string        `db:"status"`
+	RequestedAt time.Time     `db:"requested_at"`
+	ResolvedBy  uuid.NullUUID `db:"resolved_by"`
+	ResolvedAt  null.Time     `db:"resolved_at"`
+}
+
+func (r *PgRefund) ToDomain() domain.Refund {
+	var status domain.RefundStatus
+	switch r.Status {
+	case PgRefundRequested:
+		status = domain.RefundRequested
+	case PgRefundApproved:
+		status = domain.RefundApproved
+	case PgRefundRejected:
+		status = domain.RefundRejected
+	case PgRefundApproving:
+		status = domain.RefundApproving
+	}
+
+	var resolvedBy null.String
+	if r.ResolvedBy.Valid {
+		resolvedBy = null.StringFrom(r.ResolvedBy.UUID.String())
+	}
+
+	return domain.Refund{
+		ID:          domain.ID(r.ID.String()),
+		UserID:      domain.ID(r.UserID.String()),
+		CourseID:    domain.ID(r.CourseID.String()),
+		PaymentKey:  r.PaymentKey,
+		Amount:      r.Amount,
+		Currency:    r.Currency,
+		Reason:      r.Reason,
+		Status:      status,
+		RequestedAt: r.RequestedAt,
+		ResolvedBy:  resolvedBy,
+		ResolvedAt:  r.ResolvedAt,
+	}
+}
+
+func NewPgRefund(refund domain.Refund) PgRefund {
+	id, _ := uuid.Parse(refund.ID.String())
+	userID, _ := uuid.Parse(refund.UserID.String())
+	courseID, _ := uuid.Parse(refund.CourseID.String())
+	var resolvedBy uuid.NullUUID
+	if refund.ResolvedBy.Valid {
+		resolvedBy.UUID, _ = uuid.Parse(refund.ResolvedBy.String)
+		resolvedBy.Valid = true
+	}
+
+	var status string
+	switch refund.Status {
+	case domain.RefundRequested:
+		status = PgRefundRequested
+	case domain.RefundApproved:
+		status = PgRefundApproved
+	case domain.RefundRejected:
+		status = PgRefundRejected
+	case domain.RefundApproving:
+		status = PgRefundApproving
+	}
+
+	return PgRefund{
+		ID:          id,
+		UserID:      userID,
+		CourseID:    courseID,
+		PaymentKey:  refund.PaymentKey,
+		Amount:      refund.Amount,
+		Currency:    refund.Currency,
+		Reason:      refund.Reason,
+		Status:      status,
+		RequestedAt: refund.RequestedAt,
+		ResolvedBy:  resolvedBy,
+		ResolvedAt:  refund.ResolvedAt,
+	}
+}
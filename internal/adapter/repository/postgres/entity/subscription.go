@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type PgSubscriptionPlan struct {
+	ID         uuid.UUID `db:"id"`
+	SchoolID   uuid.UUID `db:"school_id"`
+	Name       string    `db:"name"`
+	Price      int64     `db:"price"`
+	Currency   string    `db:"currency"`
+	PeriodDays int       `db:"period_days"`
+}
+
+func (p *PgSubscriptionPlan) ToDomain() domain.SubscriptionPlan {
+	return domain.SubscriptionPlan{
+		ID:         domain.ID(p.ID.String()),
+		SchoolID:   domain.ID(p.SchoolID.String()),
+		Name:       p.Name,
+		Price:      p.Price,
+		Currency:   p.Currency,
+		PeriodDays: p.PeriodDays,
+	}
+}
+
+func NewPgSubscriptionPlan(plan domain.SubscriptionPlan) PgSubscriptionPlan {
+	id, _ := uuid.Parse(plan.ID.String())
+	schoolID, _ := uuid.Parse(plan.SchoolID.String())
+	return PgSubscriptionPlan{
+		ID:         id,
+		SchoolID:   schoolID,
+		Name:       plan.Name,
+		Price:      plan.Price,
+		Currency:   plan.Currency,
+		PeriodDays: plan.PeriodDays,
+	}
+}
+
+type PgSubscription struct {
+	ID              uuid.UUID   `db:"id"`
+	PlanID          uuid.UUID   `db:"plan_id"`
+	SchoolID        uuid.UUID   `db:"school_id"`
+	UserID          uuid.UUID   `db:"user_id"`
+	PaymentMethodID null.String `db:"payment_method_id"`
+	StartedAt       time.Time   `db:"started_at"`
+	ExpiresAt       time.Time   `db:"expires_at"`
+	CanceledAt      null.Time   `db:"canceled_at"`
+	LastPaymentKey  string      `db:"last_payment_key"`
+	// RenewalLockedUntil is the lease of the renewal run, it isn't part of the domain
+	RenewalLockedUntil null.Time `db:"renewal_locked_until"`
+}
+
+func (s *PgSubscription) ToDomain() domain.Subscription {
+	return domain.Subscription{
+		ID:              domain.ID(s.ID.String()),
+		PlanID:          domain.ID(s.PlanID.String()),
+		SchoolID:        domain.ID(s.SchoolID.String()),
+		UserID:          domain.ID(s.UserID.String()),
+		PaymentMethodID: s.PaymentMethodID,
+		StartedAt:       s.StartedAt,
+		ExpiresAt:       s.ExpiresAt,
+		CanceledAt:      s.CanceledAt,
+	}
+}
+
+func NewPgSubscription(subscription domain.Subscription) PgSubscription {
+	id, _ := uuid.Parse(subscription.ID.String())
+	planID, _ := uuid.Parse(subscription.PlanID.String())
+	schoolID, _ := uuid.Parse(subscription.SchoolID.String())
+	userID, _ := uuid.Parse(subscription.UserID.String())
+	return PgSubscription{
+		ID:              id,
+		PlanID:          planID,
+		SchoolID:        schoolID,
+		UserID:          userID,
+		PaymentMethodID: subscription.PaymentMethodID,
+		StartedAt:       subscription.StartedAt,
+		ExpiresAt:       subscription.ExpiresAt,
+		CanceledAt:      subscription.CanceledAt,
+	}
+}
@@ -7,39 +7,42 @@ import (
 )
 
 type PgUser struct {
-	ID        uuid.UUID   `db:"id"`
-	Name      string      `db:"name"`
-	Surname   string      `db:"surname"`
-	Phone     null.String `db:"phone"`
-	City      null.String `db:"city"`
-	AvatarUrl null.String `db:"avatar_url"`
-	Email     string      `db:"email"`
-	Password  string      `db:"password"`
+	ID            uuid.UUID   `db:"id"`
+	Name          string      `db:"name"`
+	Surname       string      `db:"surname"`
+	Phone         null.String `db:"phone"`
+	City          null.String `db:"city"`
+	AvatarUrl     null.String `db:"avatar_url"`
+	Email         string      `db:"email"`
+	Password      string      `db:"password"`
+	EmailVerified bool        `db:"email_verified"`
 }
 
 func (u *PgUser) ToDomain() domain.User {
 	return domain.User{
-		ID:        domain.ID(u.ID.String()),
-		Name:      u.Name,
-		Surname:   u.Surname,
-		Phone:     u.Phone,
-		City:      u.City,
-		AvatarUrl: u.AvatarUrl,
-		Email:     u.Email,
-		Password:  u.Password,
+		ID:            domain.ID(u.ID.String()),
+		Name:          u.Name,
+		Surname:       u.Surname,
+		Phone:         u.Phone,
+		City:          u.City,
+		AvatarUrl:     u.AvatarUrl,
+		Email:         u.Email,
+		Password:      u.Password,
+		EmailVerified: u.EmailVerified,
 	}
 }
 
 func NewPgUser(user domain.User) PgUser {
 	id, _ := uuid.Parse(user.ID.String())
 	return PgUser{
-		ID:        id,
-		Name:      user.Name,
-		Surname:   user.Surname,
-		Phone:     user.Phone,
-		City:      user.City,
-		AvatarUrl: user.AvatarUrl,
-		Email:     user.Email,
-		Password:  user.Password,
+		ID:            id,
+		Name:          user.Name,
+		Surname:       user.Surname,
+		Phone:         user.Phone,
+		City:          user.City,
+		AvatarUrl:     user.AvatarUrl,
+		Email:         user.Email,
+		Password:      user.Password,
+		EmailVerified: user.EmailVerified,
 	}
 }
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type PgWaitlistEntry struct {
+	CourseID     uuid.UUID `db:"course_id"`
+	UserID       uuid.UUID `db:"user_id"`
+	JoinedAt     time.Time `db:"joined_at"`
+	OfferedUntil null.Time `db:"offered_until"`
+}
+
+func (w *PgWaitlistEntry) ToDomain() domain.WaitlistEntry {
+	return domain.WaitlistEntry{
+		CourseID:     domain.ID(w.CourseID.String()),
+		UserID:       domain.ID(w.UserID.String()),
+		JoinedAt:     w.JoinedAt,
+		OfferedUntil: w.OfferedUntil,
+	}
+}
+
+func NewPgWaitlistEntry(entry domain.WaitlistEntry) PgWaitlistEntry {
+	courseID, _ := uuid.Parse(entry.CourseID.String())
+	userID, _ := uuid.Parse(entry.UserID.String())
+	return PgWaitlistEntry{
+		CourseID:     courseID,
+		UserID:       userID,
+		JoinedAt:     entry.JoinedAt,
+		OfferedUntil: entry.OfferedUntil,
+	}
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+)
+
+type PostgresExternalIdentityRepo struct {
+	db *sqlx.DB
+}
+
+func NewExternalIdentityRepo(db *sqlx.DB) *PostgresExternalIdentityRepo {
+	return &PostgresExternalIdentityRepo{
+		db: db,
+	}
+}
+
+const (
+	ExternalIdentityFindByProviderSubjectQuery = "SELECT * FROM public.external_identity " +
+		"WHERE provider = $1 AND subject = $2"
+)
+
+func (e *PostgresExternalIdentityRepo) FindByProviderSubject(ctx context.Context,
+	provider, subject string) (domain.ExternalIdentity, error) {
+	var pgIdentity entity.PgExternalIdentity
+	err := e.db.GetContext(ctx, &pgIdentity, ExternalIdentityFindByProviderSubjectQuery, provider, subject)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ExternalIdentity{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.ExternalIdentity{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgIdentity.ToDomain(), nil
+}
+
+func (e *PostgresExternalIdentityRepo) Create(ctx context.Context, identity domain.ExternalIdentity) error {
+	var pgIdentity = entity.NewPgExternalIdentity(identity)
+	queryString := entity.InsertQueryString(pgIdentity, "external_identity")
+	_, err := e.db.NamedExecContext(ctx, queryString, pgIdentity)
+	if err != nil {
+		return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+	return nil
+}
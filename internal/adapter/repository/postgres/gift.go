@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type PostgresGiftRepo struct {
+	db *sqlx.DB
+}
+
+func NewGiftRepo(db *sqlx.DB) *PostgresGiftRepo {
+	return &PostgresGiftRepo{
+		db: db,
+	}
+}
+
+const (
+	GiftFindByCodeQuery       = "SELECT * FROM public.gift WHERE code = $1"
+	GiftFindByPaymentKeyQuery = "SELECT * FROM public.gift WHERE payment_key = $1"
+	GiftCreateQuery           = "INSERT INTO public.gift " +
+		"(id, code, course_id, recipient_email, payment_key, pay_sum, currency, created_at) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (payment_key) DO NOTHING"
+	GiftRedeemQuery = "UPDATE public.gift SET redeemed_by = $2, redeemed_at = $3 " +
+		"WHERE id = $1 AND redeemed_by IS NULL"
+	GiftCancelRedemptionQuery = "UPDATE public.gift SET redeemed_by = NULL, redeemed_at = NULL " +
+		"WHERE id = $1 AND redeemed_by = $2"
+)
+
+func (g *PostgresGiftRepo) FindByCode(ctx context.Context, code string) (domain.Gift, error) {
+	var pgGift entity.PgGift
+	if err := g.db.GetContext(ctx, &pgGift, GiftFindByCodeQuery, code); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Gift{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.Gift{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgGift.ToDomain(), nil
+}
+
+// Create issues the gift once per payment, repeated webhook calls
+// get the gift issued by the first call
+func (g *PostgresGiftRepo) Create(ctx context.Context, gift domain.Gift) (domain.Gift, error) {
+	pgGift := entity.NewPgGift(gift)
+	_, err := g.db.ExecContext(ctx, GiftCreateQuery, pgGift.ID, pgGift.Code, pgGift.CourseID,
+		pgGift.RecipientEmail, pgGift.PaymentKey, pgGift.PaySum, pgGift.Currency, pgGift.CreatedAt)
+	if err != nil {
+		return domain.Gift{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	var createdGift entity.PgGift
+	err = g.db.GetContext(ctx, &createdGift, GiftFindByPaymentKeyQuery, pgGift.PaymentKey)
+	if err != nil {
+		return domain.Gift{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+	return createdGift.ToDomain(), nil
+}
+
+func (g *PostgresGiftRepo) Redeem(ctx context.Context, giftID, userID domain.ID, redeemedAt time.Time) error {
+	result, err := g.db.ExecContext(ctx, GiftRedeemQuery, giftID, userID, redeemedAt)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrGiftAlreadyRedeemed
+	}
+	return nil
+}
+
+func (g *PostgresGiftRepo) CancelRedemption(ctx context.Context, giftID, userID domain.ID) error {
+	_, err := g.db.ExecContext(ctx, GiftCancelRedemptionQuery, giftID, userID)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	return nil
+}
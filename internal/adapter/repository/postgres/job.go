@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type PostgresJobRepo struct {
+	db *sqlx.DB
+}
+
+func NewJobRepo(db *sqlx.DB) *PostgresJobRepo {
+	return &PostgresJobRepo{
+		db: db,
+	}
+}
+
+const (
+	// JobClaimDueQuery locks due jobs until the lease ends, job of the crashed
+	// instance becomes due again when its lease is over
+	JobClaimDueQuery = "UPDATE public.job SET locked_until = $2, attempts = attempts + 1 " +
+		"WHERE id IN (SELECT id FROM public.job WHERE status = 'pending' AND run_at <= $1 " +
+		"AND (locked_until IS NULL OR locked_until <= $1) " +
+		"ORDER BY run_at LIMIT $3 FOR UPDATE SKIP LOCKED) RETURNING *"
+	JobCompleteQuery = "UPDATE public.job SET status = 'done', locked_until = NULL WHERE id = $1"
+	JobRetryQuery    = "UPDATE public.job SET locked_until = $2, last_error = $3 WHERE id = $1"
+	JobFailQuery     = "UPDATE public.job SET status = 'failed', locked_until = NULL, last_error = $2 " +
+		"WHERE id = $1"
+)
+
+func (r *PostgresJobRepo) Create(ctx context.Context, job domain.Job) error {
+	var pgJob = entity.NewPgJob(job)
+	queryString := entity.InsertQueryString(pgJob, "job")
+	_, err := r.db.NamedExecContext(ctx, queryString, pgJob)
+	if err != nil {
+		return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+	return nil
+}
+
+func (r *PostgresJobRepo) ClaimDueJobs(ctx context.Context, now, lockedUntil time.Time,
+	limit int) ([]domain.Job, error) {
+	var pgJobs []entity.PgJob
+	if err := r.db.SelectContext(ctx, &pgJobs, JobClaimDueQuery, now, lockedUntil, limit); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	jobs := make([]domain.Job, len(pgJobs))
+	for i, job := range pgJobs {
+		jobs[i] = job.ToDomain()
+	}
+	return jobs, nil
+}
+
+func (r *PostgresJobRepo) Complete(ctx context.Context, jobID domain.ID) error {
+	return r.updateJob(ctx, JobCompleteQuery, jobID)
+}
+
+func (r *PostgresJobRepo) Retry(ctx context.Context, jobID domain.ID, retryAt time.Time,
+	lastError string) error {
+	return r.updateJob(ctx, JobRetryQuery, jobID, retryAt, lastError)
+}
+
+func (r *PostgresJobRepo) Fail(ctx context.Context, jobID domain.ID, lastError string) error {
+	return r.updateJob(ctx, JobFailQuery, jobID, lastError)
+}
+
+func (r *PostgresJobRepo) updateJob(ctx context.Context, query string, jobID domain.ID,
+	args ...interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, append([]interface{}{jobID}, args...)...)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if updated == 0 {
+		return errs.ErrNotExist
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type PostgresLedgerRepo struct {
+	db *sqlx.DB
+}
+
+func NewLedgerRepo(db *sqlx.DB) *PostgresLedgerRepo {
+	return &PostgresLedgerRepo{
+		db: db,
+	}
+}
+
+const (
+	LedgerFindSchoolEntriesQuery = "SELECT * FROM public.ledger_entry " +
+		"WHERE school_id = $1 AND created_at >= $2 AND created_at < $3 ORDER BY created_at"
+	LedgerCreateQuery = "INSERT INTO public.ledger_entry " +
+		"(id, school_id, course_id, user_id, payment_key, kind, gross, commission, currency, created_at) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT DO NOTHING"
+	LedgerReverseSaleQuery = "INSERT INTO public.ledger_entry " +
+		"(id, school_id, course_id, user_id, payment_key, kind, gross, commission, currency, created_at) " +
+		"SELECT $1, school_id, course_id, user_id, payment_key, 'refund', -gross, -commission, currency, $2 " +
+		"FROM public.ledger_entry WHERE user_id = $3 AND course_id = $4 AND payment_key = $5 " +
+		"AND kind = 'sale' ON CONFLICT DO NOTHING"
+	LedgerLockSchoolQuery = "SELECT id FROM public.school WHERE id = $1 FOR UPDATE"
+	// amount is summed over the settled rows, entries inserted concurrently stay unsettled,
+	// entries in other currencies are paid out separately
+	LedgerSettleQuery = "WITH settled AS (UPDATE public.ledger_entry SET payout_id = $1 " +
+		"WHERE school_id = $2 AND currency = $4 AND payout_id IS NULL AND created_at < $3 " +
+		"RETURNING gross - commission AS amount) SELECT COALESCE(SUM(amount), 0) FROM settled"
+	PayoutUpdateAmountQuery      = "UPDATE public.payout SET amount = $2 WHERE id = $1"
+	PayoutFindSchoolPayoutsQuery = "SELECT * FROM public.payout WHERE school_id = $1 ORDER BY created_at"
+)
+
+func (l *PostgresLedgerRepo) FindSchoolEntries(ctx context.Context, schoolID domain.ID,
+	from, to time.Time) ([]domain.LedgerEntry, error) {
+	var pgEntries []entity.PgLedgerEntry
+	if err := l.db.SelectContext(ctx, &pgEntries, LedgerFindSchoolEntriesQuery, schoolID, from, to); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	entries := make([]domain.LedgerEntry, len(pgEntries))
+	for i, entry := range pgEntries {
+		entries[i] = entry.ToDomain()
+	}
+	return entries, nil
+}
+
+// Create stores the entry once, repeated webhook calls are ignored
+func (l *PostgresLedgerRepo) Create(ctx context.Context, entry domain.LedgerEntry) error {
+	pgEntry := entity.NewPgLedgerEntry(entry)
+	_, err := l.db.ExecContext(ctx, LedgerCreateQuery, pgEntry.ID, pgEntry.SchoolID, pgEntry.CourseID,
+		pgEntry.UserID, pgEntry.PaymentKey, pgEntry.Kind, pgEntry.Gross, pgEntry.Commission,
+		pgEntry.Currency, pgEntry.CreatedAt)
+	if err != nil {
+		return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+	return nil
+}
+
+func (l *PostgresLedgerRepo) FindSchoolPayouts(ctx context.Context, schoolID domain.ID) ([]domain.Payout, error) {
+	var pgPayouts []entity.PgPayout
+	if err := l.db.SelectContext(ctx, &pgPayouts, PayoutFindSchoolPayoutsQuery, schoolID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	payouts := make([]domain.Payout, len(pgPayouts))
+	for i, payout := range pgPayouts {
+		payouts[i] = payout.ToDomain()
+	}
+	return payouts, nil
+}
+
+// CreatePayout settles every unsettled entry of the school in payout.Currency
+// created before payout.SettledUntil, payout amount is the net sum of these entries
+func (l *PostgresLedgerRepo) CreatePayout(ctx context.Context, payout domain.Payout) (domain.Payout, error) {
+	pgPayout := entity.NewPgPayout(payout)
+	tx, err := l.db.Beginx()
+	if err != nil {
+		return domain.Payout{}, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	var schoolID string
+	err = tx.GetContext(ctx, &schoolID, LedgerLockSchoolQuery, pgPayout.SchoolID)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return domain.Payout{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.Payout{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+
+	// payout row is referenced by the settled entries, its amount is known after settling
+	pgPayout.Amount = 0
+	queryString := entity.InsertQueryString(pgPayout, "payout")
+	_, err = tx.NamedExecContext(ctx, queryString, pgPayout)
+	if err != nil {
+		tx.Rollback()
+		return domain.Payout{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	err = tx.GetContext(ctx, &pgPayout.Amount, LedgerSettleQuery,
+		pgPayout.ID, pgPayout.SchoolID, pgPayout.SettledUntil, pgPayout.Currency)
+	if err != nil {
+		tx.Rollback()
+		return domain.Payout{}, errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	if pgPayout.Amount <= 0 {
+		tx.Rollback()
+		return domain.Payout{}, errs.ErrNothingToSettle
+	}
+
+	_, err = tx.ExecContext(ctx, PayoutUpdateAmountQuery, pgPayout.ID, pgPayout.Amount)
+	if err != nil {
+		tx.Rollback()
+		return domain.Payout{}, errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return domain.Payout{}, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return pgPayout.ToDomain(), nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"github.com/guregu/null"
 	"github.com/jackc/pgconn"
 	"github.com/jmoiron/sqlx"
 	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
@@ -22,12 +23,20 @@ func NewLessonRepo(db *sqlx.DB) *PostgresLessonRepo {
 }
 
 const (
-	LessonFindAllQuery           = "SELECT * FROM public.lesson ORDER BY id"
-	LessonFindByIDQuery          = "SELECT * FROM public.lesson WHERE id = $1"
-	LessonFindCourseLessonsQuery = "SELECT * FROM public.lesson WHERE course_id = $1"
-	LessonFindLessonTestsQuery   = "SELECT * FROM public.test WHERE lesson_id = $1"
-	LessonDeleteQuery            = "DELETE FROM public.lesson WHERE id = $1"
-	LessonDeleteLessonTestsQuery = "DELETE FROM public.test WHERE lesson_id = $1"
+	LessonFindAllQuery  = "SELECT * FROM public.lesson ORDER BY id"
+	LessonFindByIDQuery = "SELECT * FROM public.lesson WHERE id = $1"
+	// course lessons are taken from the version edited by teachers
+	LessonFindCourseLessonsQuery = "SELECT l.* FROM public.lesson l " +
+		"JOIN public.course c ON c.id = l.course_id AND c.version = l.version WHERE l.course_id = $1"
+	LessonFindCourseVersionLessonsQuery = "SELECT * FROM public.lesson " +
+		"WHERE course_id = $1 AND version = $2"
+	LessonFindLessonTestsQuery    = "SELECT * FROM public.test WHERE lesson_id = $1"
+	LessonDeleteQuery             = "DELETE FROM public.lesson WHERE id = $1"
+	LessonDeleteLessonTestsQuery  = "DELETE FROM public.test WHERE lesson_id = $1"
+	LessonOpenCourseRevisionQuery = "UPDATE public.course SET version = $2 " +
+		"WHERE id = $1 AND version = published_version"
+	LessonUpdateReleaseQuery = "UPDATE public.lesson SET release_at = $2, release_days = $3 WHERE id = $1"
+	LessonUpdatePreviewQuery = "UPDATE public.lesson SET is_preview = $2 WHERE id = $1"
 )
 
 func (p *PostgresLessonRepo) FindAll(ctx context.Context) ([]domain.Lesson, error) {
@@ -99,6 +108,32 @@ func (p *PostgresLessonRepo) FindCourseLessons(ctx context.Context,
 	return lessons, nil
 }
 
+func (p *PostgresLessonRepo) FindCourseVersionLessons(ctx context.Context,
+	courseID domain.ID, version int) ([]domain.Lesson, error) {
+	var pgLessons []entity.PgLesson
+	if err := p.db.SelectContext(ctx, &pgLessons, LessonFindCourseVersionLessonsQuery,
+		courseID, version); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+
+	lessons := make([]domain.Lesson, len(pgLessons))
+	for i, lesson := range pgLessons {
+		lessons[i] = lesson.ToDomain()
+		if lesson.Type == entity.PgLessonPractice {
+			tests, err := p.FindLessonTests(ctx, lessons[i].ID)
+			if err != nil {
+				return nil, err
+			}
+			lessons[i].Tests = tests
+		}
+	}
+	return lessons, nil
+}
+
 func (p *PostgresLessonRepo) FindLessonTests(ctx context.Context, lessonID domain.ID) ([]domain.Test, error) {
 	var pgTests []entity.PgTest
 	if err := p.db.SelectContext(ctx, &pgTests, LessonFindLessonTestsQuery, lessonID); err != nil {
@@ -122,20 +157,93 @@ func (p *PostgresLessonRepo) Create(ctx context.Context, lesson domain.Lesson) (
 		return domain.Lesson{}, errors.Wrap(errs.ErrTransactionError, err.Error())
 	}
 
+	if err = insertLesson(ctx, tx, lesson); err != nil {
+		tx.Rollback()
+		return domain.Lesson{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return domain.Lesson{}, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return p.FindByID(ctx, lesson.ID)
+}
+
+// CreateRevision copies lessons into the new content version of the course,
+// course with the draft revision already opened is not changed
+func (p *PostgresLessonRepo) CreateRevision(ctx context.Context, courseID domain.ID,
+	version int, lessons []domain.Lesson) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	result, err := tx.ExecContext(ctx, LessonOpenCourseRevisionQuery, courseID, version)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if updated == 0 {
+		tx.Rollback()
+		return errs.ErrCourseRevisionExists
+	}
+
+	for _, lesson := range lessons {
+		if err = insertLesson(ctx, tx, lesson); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+	return nil
+}
+
+// CreateCourseLessons saves all lessons or none of them
+func (p *PostgresLessonRepo) CreateCourseLessons(ctx context.Context, lessons []domain.Lesson) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	for _, lesson := range lessons {
+		if err = insertLesson(ctx, tx, lesson); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+	return nil
+}
+
+func insertLesson(ctx context.Context, tx *sqlx.Tx, lesson domain.Lesson) error {
 	var pgLesson = entity.NewPgLesson(lesson)
 	queryString := entity.InsertQueryString(pgLesson, "lesson")
-	_, err = tx.NamedExecContext(ctx, queryString, pgLesson)
+	_, err := tx.NamedExecContext(ctx, queryString, pgLesson)
 	if err != nil {
-		tx.Rollback()
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			if pgErr.Code == PgUniqueViolationCode {
-				return domain.Lesson{}, errors.Wrap(errs.ErrDuplicate, err.Error())
+				return errors.Wrap(errs.ErrDuplicate, err.Error())
 			} else {
-				return domain.Lesson{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+				return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
 			}
 		} else {
-			return domain.Lesson{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+			return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
 		}
 	}
 
@@ -145,27 +253,20 @@ func (p *PostgresLessonRepo) Create(ctx context.Context, lesson domain.Lesson) (
 			queryString := entity.InsertQueryString(pgTest, "test")
 			_, err = tx.NamedExecContext(ctx, queryString, pgTest)
 			if err != nil {
-				tx.Rollback()
 				var pgErr *pgconn.PgError
 				if errors.As(err, &pgErr) {
 					if pgErr.Code == PgUniqueViolationCode {
-						return domain.Lesson{}, errors.Wrap(errs.ErrDuplicate, err.Error())
+						return errors.Wrap(errs.ErrDuplicate, err.Error())
 					} else {
-						return domain.Lesson{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+						return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
 					}
 				} else {
-					return domain.Lesson{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+					return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
 				}
 			}
 		}
 	}
-
-	if err = tx.Commit(); err != nil {
-		tx.Rollback()
-		return domain.Lesson{}, errors.Wrap(errs.ErrTransactionError, err.Error())
-	}
-
-	return p.FindByID(ctx, lesson.ID)
+	return nil
 }
 
 func (p *PostgresLessonRepo) Update(ctx context.Context, lesson domain.Lesson) (domain.Lesson, error) {
@@ -217,6 +318,40 @@ func (p *PostgresLessonRepo) Update(ctx context.Context, lesson domain.Lesson) (
 	return p.FindByID(ctx, lesson.ID)
 }
 
+func (p *PostgresLessonRepo) UpdateRelease(ctx context.Context, lessonID domain.ID,
+	releaseAt null.Time, releaseDays null.Int) (domain.Lesson, error) {
+	result, err := p.db.ExecContext(ctx, LessonUpdateReleaseQuery, lessonID, releaseAt, releaseDays)
+	if err != nil {
+		return domain.Lesson{}, errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return domain.Lesson{}, errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if updated == 0 {
+		return domain.Lesson{}, errs.ErrNotExist
+	}
+	return p.FindByID(ctx, lessonID)
+}
+
+func (p *PostgresLessonRepo) UpdatePreview(ctx context.Context, lessonID domain.ID,
+	isPreview bool) (domain.Lesson, error) {
+	result, err := p.db.ExecContext(ctx, LessonUpdatePreviewQuery, lessonID, isPreview)
+	if err != nil {
+		return domain.Lesson{}, errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return domain.Lesson{}, errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if updated == 0 {
+		return domain.Lesson{}, errs.ErrNotExist
+	}
+	return p.FindByID(ctx, lessonID)
+}
+
 func (p *PostgresLessonRepo) Delete(ctx context.Context, lessonID domain.ID) error {
 	_, err := p.db.ExecContext(ctx, LessonDeleteQuery, lessonID)
 	if err != nil {
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+)
+
+type PostgresMfaRepo struct {
+	db *sqlx.DB
+}
+
+func NewMfaRepo(db *sqlx.DB) *PostgresMfaRepo {
+	return &PostgresMfaRepo{
+		db: db,
+	}
+}
+
+const (
+	MfaFindByUserIDQuery = "SELECT * FROM public.user_mfa WHERE user_id = $1"
+	MfaSaveQuery         = "INSERT INTO public.user_mfa (user_id, secret, enabled) " +
+		"VALUES (:user_id, :secret, :enabled) " +
+		"ON CONFLICT (user_id) DO UPDATE SET secret = excluded.secret, enabled = excluded.enabled"
+	MfaDeleteQuery              = "DELETE FROM public.user_mfa WHERE user_id = $1"
+	MfaDeleteRecoveryCodesQuery = "DELETE FROM public.mfa_recovery_code WHERE user_id = $1"
+	MfaUseRecoveryCodeQuery     = "UPDATE public.mfa_recovery_code SET used = true " +
+		"WHERE user_id = $1 AND code_hash = $2 AND used = false"
+)
+
+func (m *PostgresMfaRepo) FindByUserID(ctx context.Context, userID domain.ID) (domain.MfaSettings, error) {
+	var pgSettings entity.PgMfaSettings
+	if err := m.db.GetContext(ctx, &pgSettings, MfaFindByUserIDQuery, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.MfaSettings{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.MfaSettings{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgSettings.ToDomain(), nil
+}
+
+func (m *PostgresMfaRepo) Save(ctx context.Context, settings domain.MfaSettings) error {
+	var pgSettings = entity.NewPgMfaSettings(settings)
+	_, err := m.db.NamedExecContext(ctx, MfaSaveQuery, pgSettings)
+	if err != nil {
+		return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+	return nil
+}
+
+func (m *PostgresMfaRepo) Delete(ctx context.Context, userID domain.ID) error {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	_, err = tx.ExecContext(ctx, MfaDeleteRecoveryCodesQuery, userID)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	_, err = tx.ExecContext(ctx, MfaDeleteQuery, userID)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return nil
+}
+
+func (m *PostgresMfaRepo) ReplaceRecoveryCodes(ctx context.Context, userID domain.ID, codeHashes []string) error {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	_, err = tx.ExecContext(ctx, MfaDeleteRecoveryCodesQuery, userID)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	for _, codeHash := range codeHashes {
+		pgCode := entity.NewPgMfaRecoveryCode(userID, codeHash)
+		queryString := entity.InsertQueryString(pgCode, "mfa_recovery_code")
+		_, err = tx.NamedExecContext(ctx, queryString, pgCode)
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return nil
+}
+
+func (m *PostgresMfaRepo) UseRecoveryCode(ctx context.Context, userID domain.ID, codeHash string) error {
+	result, err := m.db.ExecContext(ctx, MfaUseRecoveryCodeQuery, userID, codeHash)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrNotExist
+	}
+	return nil
+}
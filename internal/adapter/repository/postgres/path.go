@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+)
+
+type PostgresLearningPathRepo struct {
+	db *sqlx.DB
+}
+
+func NewLearningPathRepo(db *sqlx.DB) *PostgresLearningPathRepo {
+	return &PostgresLearningPathRepo{
+		db: db,
+	}
+}
+
+const (
+	PathFindByIDQuery        = "SELECT * FROM public.learning_path WHERE id = $1"
+	PathFindSchoolPathsQuery = "SELECT * FROM public.learning_path WHERE school_id = $1 ORDER BY created_at"
+	PathFindPathCoursesQuery = "SELECT c.* FROM public.course c " +
+		"JOIN public.learning_path_course pc on c.id = pc.course_id WHERE pc.path_id = $1 ORDER BY pc.position"
+	PathAddCourseQuery = "INSERT INTO public.learning_path_course (path_id, course_id, position) " +
+		"VALUES ($1, $2, $3)"
+	PathDeleteQuery              = "DELETE FROM public.learning_path WHERE id = $1 AND school_id = $2"
+	PathFindCertificateByIDQuery = "SELECT * FROM public.path_certificate WHERE id = $1"
+	PathFindUserCertificateQuery = "SELECT * FROM public.path_certificate WHERE user_id = $1 AND path_id = $2"
+	// certificate is issued once, concurrent requests get the same certificate
+	PathCreateCertificateQuery = "INSERT INTO public.path_certificate (id, path_id, user_id, score, issued_at) " +
+		"VALUES ($1, $2, $3, $4, $5) ON CONFLICT (path_id, user_id) DO NOTHING"
+)
+
+func (p *PostgresLearningPathRepo) FindByID(ctx context.Context, pathID domain.ID) (domain.LearningPath, error) {
+	var pgPath entity.PgLearningPath
+	if err := p.db.GetContext(ctx, &pgPath, PathFindByIDQuery, pathID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.LearningPath{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.LearningPath{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgPath.ToDomain(), nil
+}
+
+func (p *PostgresLearningPathRepo) FindSchoolPaths(ctx context.Context,
+	schoolID domain.ID) ([]domain.LearningPath, error) {
+	var pgPaths []entity.PgLearningPath
+	if err := p.db.SelectContext(ctx, &pgPaths, PathFindSchoolPathsQuery, schoolID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	paths := make([]domain.LearningPath, len(pgPaths))
+	for i, path := range pgPaths {
+		paths[i] = path.ToDomain()
+	}
+	return paths, nil
+}
+
+func (p *PostgresLearningPathRepo) FindPathCourses(ctx context.Context, pathID domain.ID) ([]domain.Course, error) {
+	var pgCourses []entity.PgCourse
+	if err := p.db.SelectContext(ctx, &pgCourses, PathFindPathCoursesQuery, pathID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	courses := make([]domain.Course, len(pgCourses))
+	for i, course := range pgCourses {
+		courses[i] = course.ToDomain()
+	}
+	return courses, nil
+}
+
+// Create saves the path together with its courses, course order is kept
+func (p *PostgresLearningPathRepo) Create(ctx context.Context, path domain.LearningPath,
+	courseIDs []domain.ID) (domain.LearningPath, error) {
+	var pgPath = entity.NewPgLearningPath(path)
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return domain.LearningPath{}, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	queryString := entity.InsertQueryString(pgPath, "learning_path")
+	_, err = tx.NamedExecContext(ctx, queryString, pgPath)
+	if err != nil {
+		tx.Rollback()
+		return domain.LearningPath{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	for i, courseID := range courseIDs {
+		_, err = tx.ExecContext(ctx, PathAddCourseQuery, pgPath.ID, courseID, i)
+		if err != nil {
+			tx.Rollback()
+			return domain.LearningPath{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+
+	var createdPath entity.PgLearningPath
+	err = tx.GetContext(ctx, &createdPath, PathFindByIDQuery, pgPath.ID)
+	if err != nil {
+		tx.Rollback()
+		return domain.LearningPath{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return domain.LearningPath{}, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return createdPath.ToDomain(), nil
+}
+
+func (p *PostgresLearningPathRepo) Delete(ctx context.Context, schoolID, pathID domain.ID) error {
+	result, err := p.db.ExecContext(ctx, PathDeleteQuery, pathID, schoolID)
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrNotExist
+	}
+	return nil
+}
+
+func (p *PostgresLearningPathRepo) FindCertificateByID(ctx context.Context,
+	certificateID domain.ID) (domain.PathCertificate, error) {
+	var pgCertificate entity.PgPathCertificate
+	if err := p.db.GetContext(ctx, &pgCertificate, PathFindCertificateByIDQuery, certificateID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.PathCertificate{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.PathCertificate{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgCertificate.ToDomain(), nil
+}
+
+func (p *PostgresLearningPathRepo) FindUserCertificate(ctx context.Context,
+	userID, pathID domain.ID) (domain.PathCertificate, error) {
+	var pgCertificate entity.PgPathCertificate
+	if err := p.db.GetContext(ctx, &pgCertificate, PathFindUserCertificateQuery, userID, pathID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.PathCertificate{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.PathCertificate{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgCertificate.ToDomain(), nil
+}
+
+// CreateCertificate returns the certificate already issued to the user
+// for the path instead of creating the second one
+func (p *PostgresLearningPathRepo) CreateCertificate(ctx context.Context,
+	certificate domain.PathCertificate) (domain.PathCertificate, error) {
+	pgCertificate := entity.NewPgPathCertificate(certificate)
+	_, err := p.db.ExecContext(ctx, PathCreateCertificateQuery, pgCertificate.ID, pgCertificate.PathID,
+		pgCertificate.UserID, pgCertificate.Score, pgCertificate.IssuedAt)
+	if err != nil {
+		return domain.PathCertificate{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	return p.FindUserCertificate(ctx, certificate.UserID, certificate.PathID)
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+)
+
+type PostgresPaymentRepo struct {
+	db *sqlx.DB
+}
+
+func NewPaymentRepo(db *sqlx.DB) *PostgresPaymentRepo {
+	return &PostgresPaymentRepo{
+		db: db,
+	}
+}
+
+const (
+	PaymentFindCoursePaymentQuery = "SELECT * FROM public.course_payment WHERE user_id = $1 AND course_id = $2 " +
+		"ORDER BY paid_at DESC LIMIT 1"
+	PaymentCountOtherCoursePaymentsQuery = "SELECT count(*) FROM public.course_payment " +
+		"WHERE user_id = $1 AND course_id = $2 AND key <> $3"
+	PaymentCreateQuery = "INSERT INTO public.course_payment " +
+		"(user_id, course_id, key, pay_sum, currency, paid_at) VALUES ($1, $2, $3, $4, $5, $6) " +
+		"ON CONFLICT (key, course_id) DO NOTHING"
+	PaymentDeleteQuery = "DELETE FROM public.course_payment WHERE key = $1 AND course_id = $2"
+)
+
+// FindCoursePayment returns the latest payment of the course,
+// renewals and repeated purchases store a payment each
+func (p *PostgresPaymentRepo) FindCoursePayment(ctx context.Context,
+	userID, courseID domain.ID) (domain.Payment, error) {
+	var pgPayment entity.PgPayment
+	if err := p.db.GetContext(ctx, &pgPayment, PaymentFindCoursePaymentQuery, userID, courseID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Payment{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.Payment{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgPayment.ToDomain(), nil
+}
+
+// Create stores the payment once, repeated webhook calls are ignored
+func (p *PostgresPaymentRepo) Create(ctx context.Context, payment domain.Payment) error {
+	pgPayment := entity.NewPgPayment(payment)
+	_, err := p.db.ExecContext(ctx, PaymentCreateQuery, pgPayment.UserID, pgPayment.CourseID,
+		pgPayment.Key, pgPayment.PaySum, pgPayment.Currency, pgPayment.PaidAt)
+	if err != nil {
+		return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+	return nil
+}
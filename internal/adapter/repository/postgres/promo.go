@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jackc/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+)
+
+type PostgresPromoCodeRepo struct {
+	db *sqlx.DB
+}
+
+func NewPromoCodeRepo(db *sqlx.DB) *PostgresPromoCodeRepo {
+	return &PostgresPromoCodeRepo{
+		db: db,
+	}
+}
+
+const (
+	PromoCodeFindSchoolPromoCodesQuery = "SELECT * FROM public.promo_code WHERE school_id = $1 ORDER BY created_at"
+	PromoCodeFindByCodeQuery           = "SELECT * FROM public.promo_code WHERE school_id = $1 AND code = $2"
+	PromoCodeFindByIDQuery             = "SELECT * FROM public.promo_code WHERE id = $1"
+	PromoCodeDeleteQuery               = "DELETE FROM public.promo_code WHERE id = $1 AND school_id = $2"
+	PromoCodeIncrementUsedQuery        = "UPDATE public.promo_code SET used_count = used_count + 1 WHERE id = $1"
+	PromoRedemptionInsertQuery         = "INSERT INTO public.promo_redemption " +
+		"(promo_code_id, user_id, course_id, pay_sum, redeemed_at) VALUES ($1, $2, $3, $4, $5) " +
+		"ON CONFLICT DO NOTHING"
+)
+
+func (p *PostgresPromoCodeRepo) FindSchoolPromoCodes(ctx context.Context,
+	schoolID domain.ID) ([]domain.PromoCode, error) {
+	var pgPromos []entity.PgPromoCode
+	if err := p.db.SelectContext(ctx, &pgPromos, PromoCodeFindSchoolPromoCodesQuery, schoolID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	promos := make([]domain.PromoCode, len(pgPromos))
+	for i, promo := range pgPromos {
+		promos[i] = promo.ToDomain()
+	}
+	return promos, nil
+}
+
+func (p *PostgresPromoCodeRepo) FindByCode(ctx context.Context, schoolID domain.ID,
+	code string) (domain.PromoCode, error) {
+	var pgPromo entity.PgPromoCode
+	if err := p.db.GetContext(ctx, &pgPromo, PromoCodeFindByCodeQuery, schoolID, code); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.PromoCode{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.PromoCode{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgPromo.ToDomain(), nil
+}
+
+func (p *PostgresPromoCodeRepo) FindByID(ctx context.Context, promoID domain.ID) (domain.PromoCode, error) {
+	var pgPromo entity.PgPromoCode
+	if err := p.db.GetContext(ctx, &pgPromo, PromoCodeFindByIDQuery, promoID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.PromoCode{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.PromoCode{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgPromo.ToDomain(), nil
+}
+
+func (p *PostgresPromoCodeRepo) Create(ctx context.Context, promo domain.PromoCode) (domain.PromoCode, error) {
+	var pgPromo = entity.NewPgPromoCode(promo)
+	queryString := entity.InsertQueryString(pgPromo, "promo_code")
+	_, err := p.db.NamedExecContext(ctx, queryString, pgPromo)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == PgUniqueViolationCode {
+				return domain.PromoCode{}, errors.Wrap(errs.ErrDuplicate, err.Error())
+			} else {
+				return domain.PromoCode{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+			}
+		} else {
+			return domain.PromoCode{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+
+	var createdPromo entity.PgPromoCode
+	err = p.db.GetContext(ctx, &createdPromo, PromoCodeFindByIDQuery, pgPromo.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.PromoCode{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.PromoCode{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return createdPromo.ToDomain(), nil
+}
+
+func (p *PostgresPromoCodeRepo) Delete(ctx context.Context, schoolID, promoID domain.ID) error {
+	result, err := p.db.ExecContext(ctx, PromoCodeDeleteQuery, promoID, schoolID)
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrNotExist
+	}
+	return nil
+}
+
+// Redeem records the redemption and increments the code usage counter,
+// repeated redemptions of the same purchase are counted once
+func (p *PostgresPromoCodeRepo) Redeem(ctx context.Context, redemption domain.PromoRedemption) error {
+	pgRedemption := entity.NewPgPromoRedemption(redemption)
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	result, err := tx.ExecContext(ctx, PromoRedemptionInsertQuery, pgRedemption.PromoCodeID,
+		pgRedemption.UserID, pgRedemption.CourseID, pgRedemption.PaySum, pgRedemption.RedeemedAt)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	if affected > 0 {
+		_, err = tx.ExecContext(ctx, PromoCodeIncrementUsedQuery, pgRedemption.PromoCodeID)
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jackc/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type PostgresRefundRepo struct {
+	db *sqlx.DB
+}
+
+func NewRefundRepo(db *sqlx.DB) *PostgresRefundRepo {
+	return &PostgresRefundRepo{
+		db: db,
+	}
+}
+
+const (
+	RefundFindByIDQuery          = "SELECT * FROM public.refund WHERE id = $1"
+	RefundFindUserRefundsQuery   = "SELECT * FROM public.refund WHERE user_id = $1 ORDER BY requested_at"
+	RefundFindSchoolRefundsQuery = "SELECT r.* FROM public.refund r " +
+		"JOIN public.course c on r.course_id = c.id WHERE c.school_id = $1 ORDER BY r.requested_at"
+	RefundResolveQuery = "UPDATE public.refund SET status = $2, resolved_by = $3, resolved_at = $4 " +
+		"WHERE id = $1 AND status = $5"
+	RefundUpdateStatusQuery = "UPDATE public.refund SET status = $3 WHERE id = $1 AND status = $2"
+)
+
+func (r *PostgresRefundRepo) FindByID(ctx context.Context, refundID domain.ID) (domain.Refund, error) {
+	var pgRefund entity.PgRefund
+	if err := r.db.GetContext(ctx, &pgRefund, RefundFindByIDQuery, refundID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Refund{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.Refund{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgRefund.ToDomain(), nil
+}
+
+func (r *PostgresRefundRepo) FindUserRefunds(ctx context.Context, userID domain.ID) ([]domain.Refund, error) {
+	return r.findRefunds(ctx, RefundFindUserRefundsQuery, userID)
+}
+
+func (r *PostgresRefundRepo) FindSchoolRefunds(ctx context.Context, schoolID domain.ID) ([]domain.Refund, error) {
+	return r.findRefunds(ctx, RefundFindSchoolRefundsQuery, schoolID)
+}
+
+func (r *PostgresRefundRepo) findRefunds(ctx context.Context, query string, id domain.ID) ([]domain.Refund, error) {
+	var pgRefunds []entity.PgRefund
+	if err := r.db.SelectContext(ctx, &pgRefunds, query, id); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	refunds := make([]domain.Refund, len(pgRefunds))
+	for i, refund := range pgRefunds {
+		refunds[i] = refund.ToDomain()
+	}
+	return refunds, nil
+}
+
+func (r *PostgresRefundRepo) Create(ctx context.Context, refund domain.Refund) (domain.Refund, error) {
+	var pgRefund = entity.NewPgRefund(refund)
+	queryString := entity.InsertQueryString(pgRefund, "refund")
+	_, err := r.db.NamedExecContext(ctx, queryString, pgRefund)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == PgUniqueViolationCode {
+				return domain.Refund{}, errors.Wrap(errs.ErrDuplicate, err.Error())
+			} else {
+				return domain.Refund{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+			}
+		} else {
+			return domain.Refund{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+
+	var createdRefund entity.PgRefund
+	err = r.db.GetContext(ctx, &createdRefund, RefundFindByIDQuery, pgRefund.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Refund{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.Refund{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return createdRefund.ToDomain(), nil
+}
+
+// Approve marks the refund as approved, revokes the access it paid for and
+// reverses the school ledger sale in a single transaction. Refunded renewal
+// takes back its period only, the enrollment removed in the meantime is skipped
+func (r *PostgresRefundRepo) Approve(ctx context.Context, refundID, approverID domain.ID,
+	approvedAt time.Time) error {
+	var pgRefund entity.PgRefund
+	if err := r.db.GetContext(ctx, &pgRefund, RefundFindByIDQuery, refundID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	if err = resolveRefund(ctx, tx, refundID, entity.PgRefundApproving, entity.PgRefundApproved,
+		approverID, approvedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = revokeRefundedAccess(ctx, tx, pgRefund); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, PaymentDeleteQuery, pgRefund.PaymentKey, pgRefund.CourseID)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	_, err = tx.ExecContext(ctx, LedgerReverseSaleQuery, domain.NewID(), approvedAt,
+		pgRefund.UserID, pgRefund.CourseID, pgRefund.PaymentKey)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return nil
+}
+
+// revokeRefundedAccess removes the enrollment bought by the only payment,
+// otherwise the refunded payment was a renewal and its period is taken back
+func revokeRefundedAccess(ctx context.Context, tx *sqlx.Tx, pgRefund entity.PgRefund) error {
+	studentID := domain.ID(pgRefund.UserID.String())
+	courseID := domain.ID(pgRefund.CourseID.String())
+
+	var otherPayments int64
+	err := tx.GetContext(ctx, &otherPayments, PaymentCountOtherCoursePaymentsQuery,
+		pgRefund.UserID, pgRefund.CourseID, pgRefund.PaymentKey)
+	if err != nil {
+		return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	if otherPayments > 0 {
+		_, err = tx.ExecContext(ctx, CourseRevokeCourseStudentPeriodQuery, studentID, courseID)
+		if err != nil {
+			return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+		}
+		return nil
+	}
+
+	err = removeCourseStudent(ctx, tx, studentID, courseID)
+	if err != nil && !errors.Is(err, errs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (r *PostgresRefundRepo) Reject(ctx context.Context, refundID, approverID domain.ID,
+	rejectedAt time.Time) error {
+	return resolveRefund(ctx, r.db, refundID, entity.PgRefundRequested, entity.PgRefundRejected,
+		approverID, rejectedAt)
+}
+
+// StartApproval moves the requested refund to approving,
+// so it can't be rejected while the payment is paid back
+func (r *PostgresRefundRepo) StartApproval(ctx context.Context, refundID domain.ID) error {
+	return updateRefundStatus(ctx, r.db, refundID, entity.PgRefundRequested, entity.PgRefundApproving)
+}
+
+// CancelApproval returns the approving refund to requested after failed pay back
+func (r *PostgresRefundRepo) CancelApproval(ctx context.Context, refundID domain.ID) error {
+	return updateRefundStatus(ctx, r.db, refundID, entity.PgRefundApproving, entity.PgRefundRequested)
+}
+
+func updateRefundStatus(ctx context.Context, db sqlx.ExecerContext, refundID domain.ID,
+	from, to string) error {
+	result, err := db.ExecContext(ctx, RefundUpdateStatusQuery, refundID, from, to)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrRefundAlreadyResolved
+	}
+	return nil
+}
+
+func resolveRefund(ctx context.Context, db sqlx.ExecerContext, refundID domain.ID, from, status string,
+	resolvedBy domain.ID, resolvedAt time.Time) error {
+	result, err := db.ExecContext(ctx, RefundResolveQuery, refundID, status, resolvedBy, resolvedAt, from)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrRefundAlreadyResolved
+	}
+	return nil
+}
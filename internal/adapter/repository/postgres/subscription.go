@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type PostgresSubscriptionRepo struct {
+	db *sqlx.DB
+}
+
+func NewSubscriptionRepo(db *sqlx.DB) *PostgresSubscriptionRepo {
+	return &PostgresSubscriptionRepo{
+		db: db,
+	}
+}
+
+const (
+	SubscriptionFindPlanByIDQuery   = "SELECT * FROM public.subscription_plan WHERE id = $1"
+	SubscriptionFindSchoolPlanQuery = "SELECT * FROM public.subscription_plan WHERE school_id = $1"
+	SubscriptionSavePlanQuery       = "INSERT INTO public.subscription_plan " +
+		"(id, school_id, name, price, currency, period_days) VALUES ($1, $2, $3, $4, $5, $6) " +
+		"ON CONFLICT (school_id) DO UPDATE SET name = excluded.name, price = excluded.price, " +
+		"currency = excluded.currency, period_days = excluded.period_days RETURNING *"
+	SubscriptionFindUserSubscriptionQuery = "SELECT * FROM public.subscription " +
+		"WHERE user_id = $1 AND school_id = $2"
+	SubscriptionFindUserSubscriptionsQuery = "SELECT * FROM public.subscription " +
+		"WHERE user_id = $1 ORDER BY started_at"
+	// SubscriptionClaimRenewableQuery locks renewable subscriptions until the lease ends,
+	// subscription of the crashed run is claimed again when its lease is over
+	SubscriptionClaimRenewableQuery = "UPDATE public.subscription SET renewal_locked_until = $3 " +
+		"WHERE id IN (SELECT id FROM public.subscription WHERE canceled_at IS NULL " +
+		"AND payment_method_id IS NOT NULL AND expires_at <= $2 " +
+		"AND (renewal_locked_until IS NULL OR renewal_locked_until <= $1) " +
+		"FOR UPDATE SKIP LOCKED) RETURNING *"
+	SubscriptionExtendQuery = "INSERT INTO public.subscription " +
+		"(id, plan_id, school_id, user_id, payment_method_id, started_at, expires_at, last_payment_key) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (user_id, school_id) DO UPDATE SET " +
+		"plan_id = excluded.plan_id, " +
+		"payment_method_id = COALESCE(excluded.payment_method_id, subscription.payment_method_id), " +
+		"expires_at = GREATEST(subscription.expires_at, excluded.started_at) + " +
+		"(excluded.expires_at - excluded.started_at), " +
+		"canceled_at = NULL, last_payment_key = excluded.last_payment_key " +
+		"WHERE subscription.last_payment_key <> excluded.last_payment_key"
+	SubscriptionCancelQuery = "UPDATE public.subscription SET canceled_at = $2, payment_method_id = NULL " +
+		"WHERE id = $1 AND canceled_at IS NULL"
+)
+
+func (s *PostgresSubscriptionRepo) FindPlanByID(ctx context.Context,
+	planID domain.ID) (domain.SubscriptionPlan, error) {
+	var pgPlan entity.PgSubscriptionPlan
+	if err := s.db.GetContext(ctx, &pgPlan, SubscriptionFindPlanByIDQuery, planID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.SubscriptionPlan{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.SubscriptionPlan{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgPlan.ToDomain(), nil
+}
+
+func (s *PostgresSubscriptionRepo) FindSchoolPlan(ctx context.Context,
+	schoolID domain.ID) (domain.SubscriptionPlan, error) {
+	var pgPlan entity.PgSubscriptionPlan
+	if err := s.db.GetContext(ctx, &pgPlan, SubscriptionFindSchoolPlanQuery, schoolID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.SubscriptionPlan{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.SubscriptionPlan{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgPlan.ToDomain(), nil
+}
+
+// SavePlan creates the school plan or updates the existing one,
+// the plan id of the existing plan is kept
+func (s *PostgresSubscriptionRepo) SavePlan(ctx context.Context,
+	plan domain.SubscriptionPlan) (domain.SubscriptionPlan, error) {
+	pgPlan := entity.NewPgSubscriptionPlan(plan)
+	var savedPlan entity.PgSubscriptionPlan
+	err := s.db.GetContext(ctx, &savedPlan, SubscriptionSavePlanQuery, pgPlan.ID, pgPlan.SchoolID,
+		pgPlan.Name, pgPlan.Price, pgPlan.Currency, pgPlan.PeriodDays)
+	if err != nil {
+		return domain.SubscriptionPlan{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+	return savedPlan.ToDomain(), nil
+}
+
+func (s *PostgresSubscriptionRepo) FindUserSubscription(ctx context.Context,
+	userID, schoolID domain.ID) (domain.Subscription, error) {
+	var pgSubscription entity.PgSubscription
+	err := s.db.GetContext(ctx, &pgSubscription, SubscriptionFindUserSubscriptionQuery, userID, schoolID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Subscription{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return domain.Subscription{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return pgSubscription.ToDomain(), nil
+}
+
+func (s *PostgresSubscriptionRepo) FindUserSubscriptions(ctx context.Context,
+	userID domain.ID) ([]domain.Subscription, error) {
+	var pgSubscriptions []entity.PgSubscription
+	err := s.db.SelectContext(ctx, &pgSubscriptions, SubscriptionFindUserSubscriptionsQuery, userID)
+	if err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	subscriptions := make([]domain.Subscription, len(pgSubscriptions))
+	for i, subscription := range pgSubscriptions {
+		subscriptions[i] = subscription.ToDomain()
+	}
+	return subscriptions, nil
+}
+
+// ClaimRenewableSubscriptions returns subscriptions expiring before until
+// which aren't claimed by another renewal run and claims them till lockedUntil
+func (s *PostgresSubscriptionRepo) ClaimRenewableSubscriptions(ctx context.Context,
+	now, until, lockedUntil time.Time) ([]domain.Subscription, error) {
+	var pgSubscriptions []entity.PgSubscription
+	err := s.db.SelectContext(ctx, &pgSubscriptions, SubscriptionClaimRenewableQuery, now, until, lockedUntil)
+	if err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	subscriptions := make([]domain.Subscription, len(pgSubscriptions))
+	for i, subscription := range pgSubscriptions {
+		subscriptions[i] = subscription.ToDomain()
+	}
+	return subscriptions, nil
+}
+
+// Extend starts the subscription or adds the paid period to the existing one,
+// active period is extended from its end, expired one from the payment time,
+// repeated webhook calls with the same payment key are ignored
+func (s *PostgresSubscriptionRepo) Extend(ctx context.Context, subscription domain.Subscription,
+	paymentKey string) (domain.Subscription, error) {
+	pgSubscription := entity.NewPgSubscription(subscription)
+	_, err := s.db.ExecContext(ctx, SubscriptionExtendQuery, pgSubscription.ID, pgSubscription.PlanID,
+		pgSubscription.SchoolID, pgSubscription.UserID, pgSubscription.PaymentMethodID,
+		pgSubscription.StartedAt, pgSubscription.ExpiresAt, paymentKey)
+	if err != nil {
+		return domain.Subscription{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	var extendedSubscription entity.PgSubscription
+	err = s.db.GetContext(ctx, &extendedSubscription, SubscriptionFindUserSubscriptionQuery,
+		pgSubscription.UserID, pgSubscription.SchoolID)
+	if err != nil {
+		return domain.Subscription{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+	return extendedSubscription.ToDomain(), nil
+}
+
+func (s *PostgresSubscriptionRepo) Cancel(ctx context.Context, subscriptionID domain.ID,
+	canceledAt time.Time) error {
+	result, err := s.db.ExecContext(ctx, SubscriptionCancelQuery, subscriptionID, canceledAt)
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errs.ErrUpdateFailed, err.Error())
+	}
+	if affected == 0 {
+		return errs.ErrSubscriptionNotActive
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package test
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type ApiKeyBuilder struct {
+	key domain.ApiKey
+}
+
+func NewApiKeyBuilder() *ApiKeyBuilder {
+	return &ApiKeyBuilder{
+		key: domain.ApiKey{
+			ID:        domain.NewID(),
+			UserID:    domain.NewID(),
+			Name:      "script",
+			Prefix:    "esk_AbCdEfGh",
+			KeyHash:   "hash",
+			Scopes:    []domain.ApiKeyScope{domain.ApiKeyReadScope, domain.ApiKeyCourseWriteScope},
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func (b *ApiKeyBuilder) Build() domain.ApiKey {
+	return b.key
+}
@@ -0,0 +1,149 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+)
+
+type ApiKeySuite struct {
+	suite.Suite
+}
+
+func NewApiKeyRepository() (port.IApiKeyRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewApiKeyRepo(conn)
+	return repo, mock
+}
+
+type ApiKeyFindByHashSuite struct {
+	ApiKeySuite
+}
+
+func (s *ApiKeyFindByHashSuite) ApiKeyFindByHashSuccessRepositoryMock(mock sqlmock.Sqlmock, key domain.ApiKey) {
+	pgKey := entity.NewPgApiKey(key)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgKey)).
+		AddRow(EntityValues(pgKey)...)
+	mock.ExpectQuery(repository.ApiKeyFindByHashQuery).WithArgs(key.KeyHash).WillReturnRows(expectedRows)
+}
+
+func (s *ApiKeyFindByHashSuite) TestFindByHash_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Api key repository find by hash success")
+	repo, mock := NewApiKeyRepository()
+	key := NewApiKeyBuilder().Build()
+	s.ApiKeyFindByHashSuccessRepositoryMock(mock, key)
+	actual, err := repo.FindByHash(context.Background(), key.KeyHash)
+	t.Assert().Nil(err)
+	t.Assert().Equal(key, actual)
+}
+
+func (s *ApiKeyFindByHashSuite) ApiKeyFindByHashFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(repository.ApiKeyFindByHashQuery).WillReturnError(sql.ErrNoRows)
+}
+
+func (s *ApiKeyFindByHashSuite) TestFindByHash_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Api key repository find by hash failure")
+	repo, mock := NewApiKeyRepository()
+	s.ApiKeyFindByHashFailureRepositoryMock(mock)
+	_, err := repo.FindByHash(context.Background(), "hash")
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestApiKeyFindByHashSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Api key repository find by hash", new(ApiKeyFindByHashSuite))
+}
+
+type ApiKeyCreateSuite struct {
+	ApiKeySuite
+}
+
+func (s *ApiKeyCreateSuite) ApiKeyCreateSuccessRepositoryMock(mock sqlmock.Sqlmock, key domain.ApiKey) {
+	pgKey := entity.NewPgApiKey(key)
+	queryString := InsertQueryString(pgKey, "api_key")
+	mock.ExpectExec(queryString).
+		WithArgs(EntityValues(pgKey)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectedRows := sqlmock.NewRows(EntityColumns(pgKey)).
+		AddRow(EntityValues(pgKey)...)
+	mock.ExpectQuery(repository.ApiKeyFindByHashQuery).WithArgs(key.KeyHash).WillReturnRows(expectedRows)
+}
+
+func (s *ApiKeyCreateSuite) TestCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Api key repository create success")
+	repo, mock := NewApiKeyRepository()
+	key := NewApiKeyBuilder().Build()
+	s.ApiKeyCreateSuccessRepositoryMock(mock, key)
+	actual, err := repo.Create(context.Background(), key)
+	t.Assert().Nil(err)
+	t.Assert().Equal(key, actual)
+}
+
+func (s *ApiKeyCreateSuite) ApiKeyCreateFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	queryString := InsertQueryString(entity.PgApiKey{}, "api_key")
+	mock.ExpectExec(queryString).WillReturnError(sql.ErrConnDone)
+}
+
+func (s *ApiKeyCreateSuite) TestCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Api key repository create failure")
+	repo, mock := NewApiKeyRepository()
+	s.ApiKeyCreateFailureRepositoryMock(mock)
+	_, err := repo.Create(context.Background(), NewApiKeyBuilder().Build())
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestApiKeyCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Api key repository create", new(ApiKeyCreateSuite))
+}
+
+type ApiKeyRevokeSuite struct {
+	ApiKeySuite
+}
+
+func (s *ApiKeyRevokeSuite) ApiKeyRevokeSuccessRepositoryMock(mock sqlmock.Sqlmock, key domain.ApiKey) {
+	mock.ExpectExec(repository.ApiKeyRevokeQuery).
+		WithArgs(key.ID, key.UserID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func (s *ApiKeyRevokeSuite) TestRevoke_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Api key repository revoke success")
+	repo, mock := NewApiKeyRepository()
+	key := NewApiKeyBuilder().Build()
+	s.ApiKeyRevokeSuccessRepositoryMock(mock, key)
+	err := repo.Revoke(context.Background(), key.UserID, key.ID)
+	t.Assert().Nil(err)
+}
+
+func (s *ApiKeyRevokeSuite) ApiKeyRevokeFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(repository.ApiKeyRevokeQuery).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *ApiKeyRevokeSuite) TestRevoke_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Api key repository revoke already revoked key")
+	repo, mock := NewApiKeyRepository()
+	key := NewApiKeyBuilder().Build()
+	s.ApiKeyRevokeFailureRepositoryMock(mock)
+	err := repo.Revoke(context.Background(), key.UserID, key.ID)
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestApiKeyRevokeSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Api key repository revoke", new(ApiKeyRevokeSuite))
+}
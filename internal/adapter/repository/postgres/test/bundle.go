@@ -0,0 +1,28 @@
+package test
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type BundleBuilder struct {
+	bundle domain.Bundle
+}
+
+func NewBundleBuilder() *BundleBuilder {
+	return &BundleBuilder{
+		bundle: domain.Bundle{
+			ID:          domain.NewID(),
+			SchoolID:    domain.NewID(),
+			Name:        "bundle",
+			Description: "description",
+			Price:       8000,
+			Status:      domain.BundleDraft,
+			CreatedAt:   time.Now().UTC(),
+		},
+	}
+}
+
+func (b *BundleBuilder) Build() domain.Bundle {
+	return b.bundle
+}
@@ -0,0 +1,171 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+)
+
+type BundleSuite struct {
+	suite.Suite
+}
+
+func NewBundleRepository() (port.IBundleRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewBundleRepo(conn)
+	return repo, mock
+}
+
+type BundleFindByIDSuite struct {
+	BundleSuite
+}
+
+func (s *BundleFindByIDSuite) BundleFindByIDSuccessRepositoryMock(mock sqlmock.Sqlmock, bundle domain.Bundle) {
+	pgBundle := entity.NewPgBundle(bundle)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgBundle)).
+		AddRow(EntityValues(pgBundle)...)
+	mock.ExpectQuery(repository.BundleFindByIDQuery).WithArgs(bundle.ID).WillReturnRows(expectedRows)
+}
+
+func (s *BundleFindByIDSuite) TestFindByID_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Bundle repository find by id success")
+	repo, mock := NewBundleRepository()
+	bundle := NewBundleBuilder().Build()
+	s.BundleFindByIDSuccessRepositoryMock(mock, bundle)
+	actual, err := repo.FindByID(context.Background(), bundle.ID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(bundle, actual)
+}
+
+func (s *BundleFindByIDSuite) BundleFindByIDFailureRepositoryMock(mock sqlmock.Sqlmock, bundleID domain.ID) {
+	mock.ExpectQuery(repository.BundleFindByIDQuery).WithArgs(bundleID).WillReturnError(sql.ErrNoRows)
+}
+
+func (s *BundleFindByIDSuite) TestFindByID_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Bundle repository find by id failure")
+	repo, mock := NewBundleRepository()
+	bundleID := domain.NewID()
+	s.BundleFindByIDFailureRepositoryMock(mock, bundleID)
+	_, err := repo.FindByID(context.Background(), bundleID)
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestBundleFindByIDSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Bundle repository find by id", new(BundleFindByIDSuite))
+}
+
+type BundleCreateSuite struct {
+	BundleSuite
+}
+
+func (s *BundleCreateSuite) BundleCreateSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	bundle domain.Bundle, courseIDs []domain.ID) {
+	pgBundle := entity.NewPgBundle(bundle)
+	mock.ExpectBegin()
+	mock.ExpectExec(InsertQueryString(pgBundle, "bundle")).
+		WithArgs(EntityValues(pgBundle)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	for i, courseID := range courseIDs {
+		mock.ExpectExec(repository.BundleAddCourseQuery).
+			WithArgs(pgBundle.ID, courseID, i).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	expectedRows := sqlmock.NewRows(EntityColumns(pgBundle)).
+		AddRow(EntityValues(pgBundle)...)
+	mock.ExpectQuery(repository.BundleFindByIDQuery).WithArgs(pgBundle.ID).WillReturnRows(expectedRows)
+	mock.ExpectCommit()
+}
+
+func (s *BundleCreateSuite) TestCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Bundle repository create success")
+	repo, mock := NewBundleRepository()
+	bundle := NewBundleBuilder().Build()
+	courseIDs := []domain.ID{domain.NewID(), domain.NewID()}
+	s.BundleCreateSuccessRepositoryMock(mock, bundle, courseIDs)
+	actual, err := repo.Create(context.Background(), bundle, courseIDs)
+	t.Assert().Nil(err)
+	t.Assert().Equal(bundle, actual)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *BundleCreateSuite) BundleCreateFailureRepositoryMock(mock sqlmock.Sqlmock,
+	bundle domain.Bundle, courseID domain.ID) {
+	pgBundle := entity.NewPgBundle(bundle)
+	mock.ExpectBegin()
+	mock.ExpectExec(InsertQueryString(pgBundle, "bundle")).
+		WithArgs(EntityValues(pgBundle)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(repository.BundleAddCourseQuery).
+		WithArgs(pgBundle.ID, courseID, 0).
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+}
+
+func (s *BundleCreateSuite) TestCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Bundle repository create failure")
+	repo, mock := NewBundleRepository()
+	bundle := NewBundleBuilder().Build()
+	courseID := domain.NewID()
+	s.BundleCreateFailureRepositoryMock(mock, bundle, courseID)
+	_, err := repo.Create(context.Background(), bundle, []domain.ID{courseID})
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func TestBundleCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Bundle repository create", new(BundleCreateSuite))
+}
+
+type BundleDeleteSuite struct {
+	BundleSuite
+}
+
+func (s *BundleDeleteSuite) BundleDeleteSuccessRepositoryMock(mock sqlmock.Sqlmock, bundle domain.Bundle) {
+	mock.ExpectExec(repository.BundleDeleteQuery).
+		WithArgs(bundle.ID, bundle.SchoolID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (s *BundleDeleteSuite) TestDelete_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Bundle repository delete success")
+	repo, mock := NewBundleRepository()
+	bundle := NewBundleBuilder().Build()
+	s.BundleDeleteSuccessRepositoryMock(mock, bundle)
+	err := repo.Delete(context.Background(), bundle.SchoolID, bundle.ID)
+	t.Assert().Nil(err)
+}
+
+func (s *BundleDeleteSuite) BundleDeleteFailureRepositoryMock(mock sqlmock.Sqlmock, bundle domain.Bundle) {
+	mock.ExpectExec(repository.BundleDeleteQuery).
+		WithArgs(bundle.ID, bundle.SchoolID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *BundleDeleteSuite) TestDelete_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Bundle repository delete of another school bundle")
+	repo, mock := NewBundleRepository()
+	bundle := NewBundleBuilder().Build()
+	s.BundleDeleteFailureRepositoryMock(mock, bundle)
+	err := repo.Delete(context.Background(), bundle.SchoolID, bundle.ID)
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestBundleDeleteSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Bundle repository delete", new(BundleDeleteSuite))
+}
@@ -0,0 +1,29 @@
+package test
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type CategoryBuilder struct {
+	category domain.Category
+}
+
+func NewCategoryBuilder() *CategoryBuilder {
+	return &CategoryBuilder{
+		category: domain.Category{
+			ID:        domain.NewID(),
+			Name:      "category",
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func (b *CategoryBuilder) WithParentID(parentID domain.ID) *CategoryBuilder {
+	b.category.ParentID = parentID
+	return b
+}
+
+func (b *CategoryBuilder) Build() domain.Category {
+	return b.category
+}
@@ -0,0 +1,197 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+)
+
+type CatalogSuite struct {
+	suite.Suite
+}
+
+func NewCatalogRepository() (port.ICatalogRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewCatalogRepo(conn)
+	return repo, mock
+}
+
+type CatalogFindCategoryByIDSuite struct {
+	CatalogSuite
+}
+
+func (s *CatalogFindCategoryByIDSuite) CatalogFindCategoryByIDSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	category domain.Category) {
+	pgCategory := entity.NewPgCategory(category)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgCategory)).
+		AddRow(EntityValues(pgCategory)...)
+	mock.ExpectQuery(repository.CatalogFindCategoryByIDQuery).WithArgs(category.ID).WillReturnRows(expectedRows)
+}
+
+func (s *CatalogFindCategoryByIDSuite) TestFindCategoryByID_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Catalog repository find subcategory by id success")
+	repo, mock := NewCatalogRepository()
+	category := NewCategoryBuilder().WithParentID(domain.NewID()).Build()
+	s.CatalogFindCategoryByIDSuccessRepositoryMock(mock, category)
+	actual, err := repo.FindCategoryByID(context.Background(), category.ID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(category, actual)
+}
+
+func (s *CatalogFindCategoryByIDSuite) CatalogFindCategoryByIDFailureRepositoryMock(mock sqlmock.Sqlmock,
+	categoryID domain.ID) {
+	mock.ExpectQuery(repository.CatalogFindCategoryByIDQuery).WithArgs(categoryID).WillReturnError(sql.ErrNoRows)
+}
+
+func (s *CatalogFindCategoryByIDSuite) TestFindCategoryByID_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Catalog repository find category by id failure")
+	repo, mock := NewCatalogRepository()
+	categoryID := domain.NewID()
+	s.CatalogFindCategoryByIDFailureRepositoryMock(mock, categoryID)
+	_, err := repo.FindCategoryByID(context.Background(), categoryID)
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestCatalogFindCategoryByIDSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Catalog repository find category by id", new(CatalogFindCategoryByIDSuite))
+}
+
+type CatalogUpdateCourseCategoriesSuite struct {
+	CatalogSuite
+}
+
+func (s *CatalogUpdateCourseCategoriesSuite) CatalogUpdateCourseCategoriesSuccessRepositoryMock(
+	mock sqlmock.Sqlmock, courseID domain.ID, categoryIDs []domain.ID) {
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.CatalogDeleteCourseCategoriesQuery).
+		WithArgs(courseID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	for _, categoryID := range categoryIDs {
+		mock.ExpectExec(repository.CatalogAddCourseCategoryQuery).
+			WithArgs(courseID, categoryID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+}
+
+func (s *CatalogUpdateCourseCategoriesSuite) TestUpdateCourseCategories_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Catalog repository update course categories success")
+	repo, mock := NewCatalogRepository()
+	courseID := domain.NewID()
+	categoryIDs := []domain.ID{domain.NewID(), domain.NewID()}
+	s.CatalogUpdateCourseCategoriesSuccessRepositoryMock(mock, courseID, categoryIDs)
+	err := repo.UpdateCourseCategories(context.Background(), courseID, categoryIDs)
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *CatalogUpdateCourseCategoriesSuite) CatalogUpdateCourseCategoriesFailureRepositoryMock(
+	mock sqlmock.Sqlmock, courseID, categoryID domain.ID) {
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.CatalogDeleteCourseCategoriesQuery).
+		WithArgs(courseID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(repository.CatalogAddCourseCategoryQuery).
+		WithArgs(courseID, categoryID).
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+}
+
+func (s *CatalogUpdateCourseCategoriesSuite) TestUpdateCourseCategories_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Catalog repository update course categories failure")
+	repo, mock := NewCatalogRepository()
+	courseID, categoryID := domain.NewID(), domain.NewID()
+	s.CatalogUpdateCourseCategoriesFailureRepositoryMock(mock, courseID, categoryID)
+	err := repo.UpdateCourseCategories(context.Background(), courseID, []domain.ID{categoryID})
+	t.Assert().ErrorIs(err, errs.ErrUpdateFailed)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func TestCatalogUpdateCourseCategoriesSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Catalog repository update course categories",
+		new(CatalogUpdateCourseCategoriesSuite))
+}
+
+type CatalogUpdateCourseTagsSuite struct {
+	CatalogSuite
+}
+
+func (s *CatalogUpdateCourseTagsSuite) CatalogUpdateCourseTagsSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	courseID domain.ID, tag domain.Tag) {
+	pgTag := entity.NewPgTag(tag)
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.CatalogDeleteCourseTagsQuery).
+		WithArgs(courseID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(repository.CatalogCreateTagQuery).
+		WithArgs(sqlmock.AnyArg(), pgTag.SchoolID, tag.Name).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(repository.CatalogAddCourseTagQuery).
+		WithArgs(courseID, tag.SchoolID, tag.Name).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectedRows := sqlmock.NewRows(EntityColumns(pgTag)).
+		AddRow(EntityValues(pgTag)...)
+	mock.ExpectQuery(repository.CatalogFindCourseTagsQuery).WithArgs(courseID).WillReturnRows(expectedRows)
+	mock.ExpectCommit()
+}
+
+func (s *CatalogUpdateCourseTagsSuite) TestUpdateCourseTags_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Catalog repository update course tags success")
+	repo, mock := NewCatalogRepository()
+	courseID := domain.NewID()
+	tag := domain.Tag{ID: domain.NewID(), SchoolID: domain.NewID(), Name: "golang"}
+	s.CatalogUpdateCourseTagsSuccessRepositoryMock(mock, courseID, tag)
+	actual, err := repo.UpdateCourseTags(context.Background(), courseID, tag.SchoolID, []string{tag.Name})
+	t.Assert().Nil(err)
+	t.Assert().Equal([]domain.Tag{tag}, actual)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func TestCatalogUpdateCourseTagsSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Catalog repository update course tags", new(CatalogUpdateCourseTagsSuite))
+}
+
+type CatalogFindCoursesSuite struct {
+	CatalogSuite
+}
+
+func (s *CatalogFindCoursesSuite) CatalogFindCoursesSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	tag string, course domain.Course) {
+	pgCourse := entity.NewPgCourse(course)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgCourse)).
+		AddRow(EntityValues(pgCourse)...)
+	mock.ExpectQuery(repository.CatalogFindCoursesQuery).
+		WithArgs(uuid.NullUUID{}, tag).
+		WillReturnRows(expectedRows)
+}
+
+func (s *CatalogFindCoursesSuite) TestFindCourses_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Catalog repository find courses by tag success")
+	repo, mock := NewCatalogRepository()
+	course := NewCourseBuilder().Build()
+	s.CatalogFindCoursesSuccessRepositoryMock(mock, "golang", course)
+	actual, err := repo.FindCourses(context.Background(), "", "golang")
+	t.Assert().Nil(err)
+	t.Assert().Equal([]domain.Course{course}, actual)
+}
+
+func TestCatalogFindCoursesSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Catalog repository find courses", new(CatalogFindCoursesSuite))
+}
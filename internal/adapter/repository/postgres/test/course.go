@@ -1,7 +1,9 @@
 package test
 
 import (
+	"github.com/guregu/null"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
 )
 
 type CourseBuilder struct {
@@ -15,7 +17,7 @@ func NewCourseBuilder() *CourseBuilder {
 			SchoolID: domain.NewID(),
 			Name:     "course name",
 			Level:    3,
-			Price:    1000,
+			Price:    domain.NewMoney(100000, domain.DefaultCurrency),
 			Language: "english",
 			Status:   domain.CourseDraft,
 		},
@@ -43,7 +45,12 @@ func (b *CourseBuilder) WithLevel(level int) *CourseBuilder {
 }
 
 func (b *CourseBuilder) WithPrice(price int64) *CourseBuilder {
-	b.course.Price = price
+	b.course.Price.Amount = price
+	return b
+}
+
+func (b *CourseBuilder) WithCurrency(currency string) *CourseBuilder {
+	b.course.Price.Currency = currency
 	return b
 }
 
@@ -60,3 +67,47 @@ func (b *CourseBuilder) WithStatus(status domain.CourseStatus) *CourseBuilder {
 func (b *CourseBuilder) Build() domain.Course {
 	return b.course
 }
+
+type EnrollmentBuilder struct {
+	enrollment domain.Enrollment
+}
+
+func NewEnrollmentBuilder() *EnrollmentBuilder {
+	return &EnrollmentBuilder{
+		enrollment: domain.Enrollment{
+			StudentID:  domain.NewID(),
+			CourseID:   domain.NewID(),
+			EnrolledAt: time.Now().UTC(),
+		},
+	}
+}
+
+func (b *EnrollmentBuilder) WithExpiresAt(expiresAt time.Time) *EnrollmentBuilder {
+	b.enrollment.ExpiresAt = null.TimeFrom(expiresAt)
+	return b
+}
+
+func (b *EnrollmentBuilder) Build() domain.Enrollment {
+	return b.enrollment
+}
+
+type CourseTransitionBuilder struct {
+	transition domain.CourseTransition
+}
+
+func NewCourseTransitionBuilder() *CourseTransitionBuilder {
+	return &CourseTransitionBuilder{
+		transition: domain.CourseTransition{
+			ID:        domain.NewID(),
+			CourseID:  domain.NewID(),
+			From:      domain.CoursePublished,
+			To:        domain.CourseUnpublished,
+			UserID:    domain.NewID(),
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func (b *CourseTransitionBuilder) Build() domain.CourseTransition {
+	return b.transition
+}
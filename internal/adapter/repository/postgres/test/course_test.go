@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
@@ -13,6 +14,7 @@ import (
 	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/paw1a/eschool/internal/core/port"
 	"testing"
+	"time"
 )
 
 type CourseSuite struct {
@@ -283,7 +285,7 @@ func (s *CourseIsCourseStudentSuite) CourseIsCourseStudentSuccessRepositoryMock(
 	expectedRows := sqlmock.NewRows([]string{"?column"})
 	expectedRows.AddRow(1)
 	mock.ExpectQuery(repository.CourseContainsStudentQuery).
-		WithArgs(courseID, studentID).WillReturnRows(expectedRows)
+		WithArgs(courseID, studentID, sqlmock.AnyArg()).WillReturnRows(expectedRows)
 }
 
 func (s *CourseIsCourseStudentSuite) TestIsCourseStudent_Success(t provider.T) {
@@ -364,9 +366,23 @@ type CourseAddCourseStudentSuite struct {
 
 func (s *CourseAddCourseStudentSuite) CourseAddCourseStudentSuccessRepositoryMock(mock sqlmock.Sqlmock,
 	courseID, studentID domain.ID) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(repository.CourseLockCapacityQuery).
+		WithArgs(courseID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(nil))
+	mock.ExpectExec(repository.CourseReenrollCourseStudentQuery).
+		WithArgs(studentID, courseID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec(repository.CourseAddCourseStudentQuery).
-		WithArgs(studentID, courseID).
+		WithArgs(studentID, courseID, sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(repository.SeatHoldDeleteQuery).
+		WithArgs(courseID, studentID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(repository.WaitlistDeleteQuery).
+		WithArgs(courseID, studentID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
 }
 
 func (s *CourseAddCourseStudentSuite) TestAddCourseStudent_Success(t provider.T) {
@@ -378,10 +394,17 @@ func (s *CourseAddCourseStudentSuite) TestAddCourseStudent_Success(t provider.T)
 	s.CourseAddCourseStudentSuccessRepositoryMock(mock, courseID, studentID)
 	err := repo.AddCourseStudent(context.Background(), studentID, courseID)
 	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
 }
 
 func (s *CourseAddCourseStudentSuite) CourseAddCourseStudentFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(repository.CourseLockCapacityQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(nil))
+	mock.ExpectExec(repository.CourseReenrollCourseStudentQuery).
+		WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec(repository.CourseAddCourseStudentQuery).WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
 }
 
 func (s *CourseAddCourseStudentSuite) TestAddCourseStudent_Failure(t provider.T) {
@@ -395,10 +418,325 @@ func (s *CourseAddCourseStudentSuite) TestAddCourseStudent_Failure(t provider.T)
 	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
 }
 
+func (s *CourseAddCourseStudentSuite) CourseAddCourseStudentFullRepositoryMock(mock sqlmock.Sqlmock,
+	courseID, studentID domain.ID) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(repository.CourseLockCapacityQuery).
+		WithArgs(courseID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(2))
+	mock.ExpectQuery(repository.CourseCountTakenSeatsQuery).
+		WithArgs(courseID, studentID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectRollback()
+}
+
+func (s *CourseAddCourseStudentSuite) TestAddCourseStudent_Full(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository add course student to full course")
+	repo, mock := NewCourseRepository()
+	courseID := domain.NewID()
+	studentID := domain.NewID()
+	s.CourseAddCourseStudentFullRepositoryMock(mock, courseID, studentID)
+	err := repo.AddCourseStudent(context.Background(), studentID, courseID)
+	t.Assert().ErrorIs(err, errs.ErrCourseIsFull)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *CourseAddCourseStudentSuite) CourseAddCourseStudentExpiredRepositoryMock(mock sqlmock.Sqlmock,
+	courseID, studentID domain.ID) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(repository.CourseLockCapacityQuery).
+		WithArgs(courseID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(2))
+	mock.ExpectQuery(repository.CourseCountTakenSeatsQuery).
+		WithArgs(courseID, studentID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(repository.CourseReenrollCourseStudentQuery).
+		WithArgs(studentID, courseID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(repository.SeatHoldDeleteQuery).
+		WithArgs(courseID, studentID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(repository.WaitlistDeleteQuery).
+		WithArgs(courseID, studentID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+}
+
+func (s *CourseAddCourseStudentSuite) TestAddCourseStudent_Expired(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository add course student with expired enrollment takes a free seat")
+	repo, mock := NewCourseRepository()
+	courseID := domain.NewID()
+	studentID := domain.NewID()
+	s.CourseAddCourseStudentExpiredRepositoryMock(mock, courseID, studentID)
+	err := repo.AddCourseStudent(context.Background(), studentID, courseID)
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
 func TestCourseAddCourseStudentSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Course repository add course student", new(CourseAddCourseStudentSuite))
 }
 
+type CourseFindEnrollmentSuite struct {
+	CourseSuite
+}
+
+func (s *CourseFindEnrollmentSuite) CourseFindEnrollmentSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	enrollment domain.Enrollment) {
+	pgEnrollment := entity.NewPgEnrollment(enrollment)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgEnrollment))
+	expectedRows.AddRow(EntityValues(pgEnrollment)...)
+	mock.ExpectQuery(repository.CourseFindEnrollmentQuery).
+		WithArgs(enrollment.StudentID, enrollment.CourseID).WillReturnRows(expectedRows)
+}
+
+func (s *CourseFindEnrollmentSuite) TestFindEnrollment_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository find enrollment success")
+	repo, mock := NewCourseRepository()
+	enrollment := NewEnrollmentBuilder().WithExpiresAt(time.Now().UTC().AddDate(0, 0, 30)).Build()
+	s.CourseFindEnrollmentSuccessRepositoryMock(mock, enrollment)
+	found, err := repo.FindEnrollment(context.Background(), enrollment.StudentID, enrollment.CourseID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(enrollment, found)
+}
+
+func (s *CourseFindEnrollmentSuite) CourseFindEnrollmentFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(repository.CourseFindEnrollmentQuery).WillReturnError(sql.ErrNoRows)
+}
+
+func (s *CourseFindEnrollmentSuite) TestFindEnrollment_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository find enrollment failure")
+	repo, mock := NewCourseRepository()
+	s.CourseFindEnrollmentFailureRepositoryMock(mock)
+	_, err := repo.FindEnrollment(context.Background(), domain.NewID(), domain.NewID())
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestCourseFindEnrollmentSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course repository find enrollment", new(CourseFindEnrollmentSuite))
+}
+
+type CourseRenewCourseStudentSuite struct {
+	CourseSuite
+}
+
+func (s *CourseRenewCourseStudentSuite) CourseRenewCourseStudentLockRepositoryMock(mock sqlmock.Sqlmock,
+	enrollment domain.Enrollment, capacity interface{}) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(repository.CourseLockCapacityQuery).
+		WithArgs(enrollment.CourseID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(capacity))
+	pgEnrollment := entity.NewPgEnrollment(enrollment)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgEnrollment))
+	expectedRows.AddRow(EntityValues(pgEnrollment)...)
+	mock.ExpectQuery(repository.CourseFindEnrollmentQuery).
+		WithArgs(enrollment.StudentID, enrollment.CourseID).WillReturnRows(expectedRows)
+}
+
+func (s *CourseRenewCourseStudentSuite) CourseRenewCourseStudentCommitRepositoryMock(mock sqlmock.Sqlmock,
+	enrollment domain.Enrollment, renewed int64) {
+	mock.ExpectExec(repository.CourseRenewCourseStudentQuery).
+		WithArgs(enrollment.StudentID, enrollment.CourseID, sqlmock.AnyArg(), "key").
+		WillReturnResult(sqlmock.NewResult(0, renewed))
+	mock.ExpectExec(repository.SeatHoldDeleteQuery).
+		WithArgs(enrollment.CourseID, enrollment.StudentID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	pgEnrollment := entity.NewPgEnrollment(enrollment)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgEnrollment))
+	expectedRows.AddRow(EntityValues(pgEnrollment)...)
+	mock.ExpectQuery(repository.CourseFindEnrollmentQuery).
+		WithArgs(enrollment.StudentID, enrollment.CourseID).WillReturnRows(expectedRows)
+}
+
+func (s *CourseRenewCourseStudentSuite) CourseRenewCourseStudentSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	enrollment domain.Enrollment) {
+	s.CourseRenewCourseStudentLockRepositoryMock(mock, enrollment, 2)
+	s.CourseRenewCourseStudentCommitRepositoryMock(mock, enrollment, 1)
+}
+
+func (s *CourseRenewCourseStudentSuite) TestRenewCourseStudent_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository renew course student success")
+	repo, mock := NewCourseRepository()
+	enrollment := NewEnrollmentBuilder().WithExpiresAt(time.Now().UTC().AddDate(0, 0, 30)).Build()
+	s.CourseRenewCourseStudentSuccessRepositoryMock(mock, enrollment)
+	renewed, err := repo.RenewCourseStudent(context.Background(), enrollment.StudentID,
+		enrollment.CourseID, "key", time.Now().UTC())
+	t.Assert().Nil(err)
+	t.Assert().Equal(enrollment.ExpiresAt, renewed.ExpiresAt)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *CourseRenewCourseStudentSuite) CourseRenewCourseStudentExpiredRepositoryMock(mock sqlmock.Sqlmock,
+	enrollment domain.Enrollment) {
+	s.CourseRenewCourseStudentLockRepositoryMock(mock, enrollment, 2)
+	mock.ExpectQuery(repository.CourseCountTakenSeatsQuery).
+		WithArgs(enrollment.CourseID, enrollment.StudentID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	s.CourseRenewCourseStudentCommitRepositoryMock(mock, enrollment, 1)
+}
+
+func (s *CourseRenewCourseStudentSuite) TestRenewCourseStudent_Expired(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository renew expired course student takes a free seat")
+	repo, mock := NewCourseRepository()
+	enrollment := NewEnrollmentBuilder().WithExpiresAt(time.Now().UTC().AddDate(0, 0, -1)).Build()
+	s.CourseRenewCourseStudentExpiredRepositoryMock(mock, enrollment)
+	_, err := repo.RenewCourseStudent(context.Background(), enrollment.StudentID,
+		enrollment.CourseID, "key", time.Now().UTC())
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *CourseRenewCourseStudentSuite) CourseRenewCourseStudentFullRepositoryMock(mock sqlmock.Sqlmock,
+	enrollment domain.Enrollment) {
+	s.CourseRenewCourseStudentLockRepositoryMock(mock, enrollment, 2)
+	mock.ExpectQuery(repository.CourseCountTakenSeatsQuery).
+		WithArgs(enrollment.CourseID, enrollment.StudentID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectRollback()
+}
+
+func (s *CourseRenewCourseStudentSuite) TestRenewCourseStudent_Full(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository renew expired course student of full course")
+	repo, mock := NewCourseRepository()
+	enrollment := NewEnrollmentBuilder().WithExpiresAt(time.Now().UTC().AddDate(0, 0, -1)).Build()
+	s.CourseRenewCourseStudentFullRepositoryMock(mock, enrollment)
+	_, err := repo.RenewCourseStudent(context.Background(), enrollment.StudentID,
+		enrollment.CourseID, "key", time.Now().UTC())
+	t.Assert().ErrorIs(err, errs.ErrCourseIsFull)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *CourseRenewCourseStudentSuite) CourseRenewCourseStudentLifetimeRepositoryMock(mock sqlmock.Sqlmock,
+	enrollment domain.Enrollment) {
+	s.CourseRenewCourseStudentLockRepositoryMock(mock, enrollment, nil)
+	s.CourseRenewCourseStudentCommitRepositoryMock(mock, enrollment, 0)
+}
+
+func (s *CourseRenewCourseStudentSuite) TestRenewCourseStudent_Lifetime(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository renew course student with lifetime access")
+	repo, mock := NewCourseRepository()
+	enrollment := NewEnrollmentBuilder().Build()
+	s.CourseRenewCourseStudentLifetimeRepositoryMock(mock, enrollment)
+	_, err := repo.RenewCourseStudent(context.Background(), enrollment.StudentID,
+		enrollment.CourseID, "key", time.Now().UTC())
+	t.Assert().ErrorIs(err, errs.ErrCourseNotRenewable)
+}
+
+func TestCourseRenewCourseStudentSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course repository renew course student", new(CourseRenewCourseStudentSuite))
+}
+
+type CourseFindExpiringEnrollmentsSuite struct {
+	CourseSuite
+}
+
+func (s *CourseFindExpiringEnrollmentsSuite) CourseFindExpiringEnrollmentsSuccessRepositoryMock(
+	mock sqlmock.Sqlmock, enrollments []domain.Enrollment) {
+	pgEnrollment := entity.NewPgEnrollment(enrollments[0])
+	expectedRows := sqlmock.NewRows(EntityColumns(pgEnrollment))
+	for _, enrollment := range enrollments {
+		pgEnrollment = entity.NewPgEnrollment(enrollment)
+		expectedRows.AddRow(EntityValues(pgEnrollment)...)
+	}
+	mock.ExpectQuery(repository.CourseFindExpiringEnrollmentsQuery).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(expectedRows)
+}
+
+func (s *CourseFindExpiringEnrollmentsSuite) TestFindExpiringEnrollments_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository find expiring enrollments success")
+	repo, mock := NewCourseRepository()
+	now := time.Now().UTC()
+	enrollments := []domain.Enrollment{
+		NewEnrollmentBuilder().WithExpiresAt(now.AddDate(0, 0, 1)).Build(),
+		NewEnrollmentBuilder().WithExpiresAt(now.AddDate(0, 0, 5)).Build(),
+	}
+	s.CourseFindExpiringEnrollmentsSuccessRepositoryMock(mock, enrollments)
+	found, err := repo.FindExpiringEnrollments(context.Background(), now, now.AddDate(0, 0, 7))
+	t.Assert().Nil(err)
+	t.Assert().Equal(enrollments, found)
+}
+
+func (s *CourseFindExpiringEnrollmentsSuite) CourseFindExpiringEnrollmentsFailureRepositoryMock(
+	mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(repository.CourseFindExpiringEnrollmentsQuery).WillReturnError(sql.ErrConnDone)
+}
+
+func (s *CourseFindExpiringEnrollmentsSuite) TestFindExpiringEnrollments_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository find expiring enrollments failure")
+	repo, mock := NewCourseRepository()
+	s.CourseFindExpiringEnrollmentsFailureRepositoryMock(mock)
+	now := time.Now().UTC()
+	_, err := repo.FindExpiringEnrollments(context.Background(), now, now.AddDate(0, 0, 7))
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestCourseFindExpiringEnrollmentsSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course repository find expiring enrollments", new(CourseFindExpiringEnrollmentsSuite))
+}
+
+type CourseRemoveCourseStudentSuite struct {
+	CourseSuite
+}
+
+func (s *CourseRemoveCourseStudentSuite) CourseRemoveCourseStudentSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	courseID, studentID domain.ID) {
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.CourseRemoveCourseStudentQuery).
+		WithArgs(studentID, courseID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(repository.CourseDeleteStudentLessonStatsQuery).
+		WithArgs(studentID, courseID).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(repository.CourseDeleteStudentTestStatsQuery).
+		WithArgs(studentID, courseID).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectCommit()
+}
+
+func (s *CourseRemoveCourseStudentSuite) TestRemoveCourseStudent_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository remove course student success")
+	repo, mock := NewCourseRepository()
+	courseID := domain.NewID()
+	studentID := domain.NewID()
+	s.CourseRemoveCourseStudentSuccessRepositoryMock(mock, courseID, studentID)
+	err := repo.RemoveCourseStudent(context.Background(), studentID, courseID)
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *CourseRemoveCourseStudentSuite) CourseRemoveCourseStudentFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.CourseRemoveCourseStudentQuery).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+}
+
+func (s *CourseRemoveCourseStudentSuite) TestRemoveCourseStudent_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository remove not existing course student")
+	repo, mock := NewCourseRepository()
+	s.CourseRemoveCourseStudentFailureRepositoryMock(mock)
+	err := repo.RemoveCourseStudent(context.Background(), domain.NewID(), domain.NewID())
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func TestCourseRemoveCourseStudentSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course repository remove course student", new(CourseRemoveCourseStudentSuite))
+}
+
 type CourseCreateSuite struct {
 	CourseSuite
 }
@@ -496,48 +834,96 @@ type CourseUpdateStatusSuite struct {
 	CourseSuite
 }
 
-func (s *CourseUpdateStatusSuite) CourseUpdateStatusSuccessRepositoryMock(mock sqlmock.Sqlmock, course domain.Course) {
-	pgCourse := entity.NewPgCourse(course)
-
-	expectedRows := sqlmock.NewRows(EntityColumns(pgCourse)).
-		AddRow(EntityValues(pgCourse)...)
-	mock.ExpectQuery(repository.CourseFindByIDQuery).WithArgs(pgCourse.ID).WillReturnRows(expectedRows)
-
-	queryString := UpdateQueryString(pgCourse, "course")
-	values := append(EntityValues(pgCourse), pgCourse.ID)
-	mock.ExpectExec(queryString).
-		WithArgs(values...).
+func (s *CourseUpdateStatusSuite) CourseUpdateStatusSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	transition domain.CourseTransition) {
+	pgTransition := entity.NewPgCourseTransition(transition)
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.CourseUpdateStatusQuery).
+		WithArgs(pgTransition.CourseID, pgTransition.FromStatus, pgTransition.ToStatus).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(repository.CourseAddTransitionQuery).
+		WithArgs(pgTransition.ID, pgTransition.CourseID, pgTransition.FromStatus,
+			pgTransition.ToStatus, pgTransition.UserID, pgTransition.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 }
 
 func (s *CourseUpdateStatusSuite) TestUpdateStatus_Success(t provider.T) {
 	t.Parallel()
 	t.Title("Course repository update course status success")
 	repo, mock := NewCourseRepository()
-	course := NewCourseBuilder().Build()
-	s.CourseUpdateStatusSuccessRepositoryMock(mock, course)
-	err := repo.UpdateStatus(context.Background(), course.ID, domain.CourseDraft)
+	transition := NewCourseTransitionBuilder().Build()
+	s.CourseUpdateStatusSuccessRepositoryMock(mock, transition)
+	err := repo.UpdateStatus(context.Background(), transition)
 	t.Assert().Nil(err)
 }
 
-func (s *CourseUpdateStatusSuite) CourseUpdateStatusFailureRepositoryMock(mock sqlmock.Sqlmock) {
-	mock.ExpectQuery(repository.CourseFindByIDQuery).WillReturnError(sql.ErrNoRows)
+func (s *CourseUpdateStatusSuite) CourseUpdateStatusFailureRepositoryMock(mock sqlmock.Sqlmock,
+	transition domain.CourseTransition) {
+	pgTransition := entity.NewPgCourseTransition(transition)
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.CourseUpdateStatusQuery).
+		WithArgs(pgTransition.CourseID, pgTransition.FromStatus, pgTransition.ToStatus).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
 }
 
 func (s *CourseUpdateStatusSuite) TestUpdateStatus_Failure(t provider.T) {
 	t.Parallel()
 	t.Title("Course repository update course status failure")
 	repo, mock := NewCourseRepository()
-	course := NewCourseBuilder().Build()
-	s.CourseUpdateStatusFailureRepositoryMock(mock)
-	err := repo.UpdateStatus(context.Background(), course.ID, domain.CourseReady)
-	t.Assert().ErrorIs(err, errs.ErrNotExist)
+	transition := NewCourseTransitionBuilder().Build()
+	s.CourseUpdateStatusFailureRepositoryMock(mock, transition)
+	err := repo.UpdateStatus(context.Background(), transition)
+	t.Assert().ErrorIs(err, errs.ErrCourseInvalidTransition)
 }
 
 func TestCourseUpdateStatusSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Course repository update course status", new(CourseUpdateStatusSuite))
 }
 
+type CourseFindTransitionsSuite struct {
+	CourseSuite
+}
+
+func (s *CourseFindTransitionsSuite) CourseFindTransitionsSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	transition domain.CourseTransition) {
+	pgTransition := entity.NewPgCourseTransition(transition)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgTransition)).
+		AddRow(EntityValues(pgTransition)...)
+	mock.ExpectQuery(repository.CourseFindTransitionsQuery).
+		WithArgs(pgTransition.CourseID).
+		WillReturnRows(expectedRows)
+}
+
+func (s *CourseFindTransitionsSuite) TestFindCourseTransitions_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository find course transitions success")
+	repo, mock := NewCourseRepository()
+	transition := NewCourseTransitionBuilder().Build()
+	s.CourseFindTransitionsSuccessRepositoryMock(mock, transition)
+	transitions, err := repo.FindCourseTransitions(context.Background(), transition.CourseID)
+	t.Assert().Nil(err)
+	t.Assert().Equal([]domain.CourseTransition{transition}, transitions)
+}
+
+func (s *CourseFindTransitionsSuite) CourseFindTransitionsFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(repository.CourseFindTransitionsQuery).WillReturnError(sql.ErrConnDone)
+}
+
+func (s *CourseFindTransitionsSuite) TestFindCourseTransitions_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository find course transitions failure")
+	repo, mock := NewCourseRepository()
+	s.CourseFindTransitionsFailureRepositoryMock(mock)
+	_, err := repo.FindCourseTransitions(context.Background(), domain.NewID())
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestCourseFindTransitionsSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course repository find course transitions", new(CourseFindTransitionsSuite))
+}
+
 type CourseDeleteSuite struct {
 	CourseSuite
 }
@@ -573,3 +959,177 @@ func (s *CourseDeleteSuite) TestDelete_Failure(t provider.T) {
 func TestCourseDeleteSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Course repository delete course", new(CourseDeleteSuite))
 }
+
+type CoursePublishRevisionSuite struct {
+	CourseSuite
+}
+
+func (s *CoursePublishRevisionSuite) CoursePublishRevisionSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	courseID domain.ID) {
+	mock.ExpectExec(repository.CoursePublishRevisionQuery).
+		WithArgs(courseID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (s *CoursePublishRevisionSuite) TestPublishRevision_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository publish course revision success")
+	repo, mock := NewCourseRepository()
+	courseID := domain.NewID()
+	s.CoursePublishRevisionSuccessRepositoryMock(mock, courseID)
+	err := repo.PublishRevision(context.Background(), courseID)
+	t.Assert().Nil(err)
+}
+
+func (s *CoursePublishRevisionSuite) CoursePublishRevisionFailureRepositoryMock(mock sqlmock.Sqlmock,
+	courseID domain.ID) {
+	mock.ExpectExec(repository.CoursePublishRevisionQuery).
+		WithArgs(courseID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *CoursePublishRevisionSuite) TestPublishRevision_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository publish course revision failure")
+	repo, mock := NewCourseRepository()
+	courseID := domain.NewID()
+	s.CoursePublishRevisionFailureRepositoryMock(mock, courseID)
+	err := repo.PublishRevision(context.Background(), courseID)
+	t.Assert().ErrorIs(err, errs.ErrCourseNoRevision)
+}
+
+func TestCoursePublishRevisionSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course repository publish course revision", new(CoursePublishRevisionSuite))
+}
+
+type CourseUpdateEnrollmentVersionSuite struct {
+	CourseSuite
+}
+
+func (s *CourseUpdateEnrollmentVersionSuite) CourseUpdateEnrollmentVersionSuccessRepositoryMock(
+	mock sqlmock.Sqlmock, studentID, courseID domain.ID, version int) {
+	mock.ExpectExec(repository.CourseUpdateEnrollmentVersionQuery).
+		WithArgs(studentID, courseID, version).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (s *CourseUpdateEnrollmentVersionSuite) TestUpdateEnrollmentVersion_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository update enrollment version success")
+	repo, mock := NewCourseRepository()
+	studentID := domain.NewID()
+	courseID := domain.NewID()
+	s.CourseUpdateEnrollmentVersionSuccessRepositoryMock(mock, studentID, courseID, 2)
+	err := repo.UpdateEnrollmentVersion(context.Background(), studentID, courseID, 2)
+	t.Assert().Nil(err)
+}
+
+func (s *CourseUpdateEnrollmentVersionSuite) CourseUpdateEnrollmentVersionFailureRepositoryMock(
+	mock sqlmock.Sqlmock, studentID, courseID domain.ID, version int) {
+	mock.ExpectExec(repository.CourseUpdateEnrollmentVersionQuery).
+		WithArgs(studentID, courseID, version).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *CourseUpdateEnrollmentVersionSuite) TestUpdateEnrollmentVersion_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository update enrollment version failure")
+	repo, mock := NewCourseRepository()
+	studentID := domain.NewID()
+	courseID := domain.NewID()
+	s.CourseUpdateEnrollmentVersionFailureRepositoryMock(mock, studentID, courseID, 2)
+	err := repo.UpdateEnrollmentVersion(context.Background(), studentID, courseID, 2)
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestCourseUpdateEnrollmentVersionSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course repository update enrollment version",
+		new(CourseUpdateEnrollmentVersionSuite))
+}
+
+type CourseUpdatePublishAtSuite struct {
+	CourseSuite
+}
+
+func (s *CourseUpdatePublishAtSuite) CourseUpdatePublishAtSuccessRepositoryMock(
+	mock sqlmock.Sqlmock, courseID domain.ID, publishAt null.Time) {
+	mock.ExpectExec(repository.CourseUpdatePublishAtQuery).
+		WithArgs(courseID, publishAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (s *CourseUpdatePublishAtSuite) TestUpdatePublishAt_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository update publish date success")
+	repo, mock := NewCourseRepository()
+	courseID := domain.NewID()
+	publishAt := null.TimeFrom(time.Now().UTC().Truncate(time.Second))
+	s.CourseUpdatePublishAtSuccessRepositoryMock(mock, courseID, publishAt)
+	err := repo.UpdatePublishAt(context.Background(), courseID, publishAt)
+	t.Assert().Nil(err)
+}
+
+func (s *CourseUpdatePublishAtSuite) CourseUpdatePublishAtFailureRepositoryMock(
+	mock sqlmock.Sqlmock, courseID domain.ID) {
+	mock.ExpectExec(repository.CourseUpdatePublishAtQuery).
+		WithArgs(courseID, null.Time{}).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *CourseUpdatePublishAtSuite) TestUpdatePublishAt_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository update publish date failure")
+	repo, mock := NewCourseRepository()
+	courseID := domain.NewID()
+	s.CourseUpdatePublishAtFailureRepositoryMock(mock, courseID)
+	err := repo.UpdatePublishAt(context.Background(), courseID, null.Time{})
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestCourseUpdatePublishAtSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course repository update publish date", new(CourseUpdatePublishAtSuite))
+}
+
+type CourseRemovePrerequisiteSuite struct {
+	CourseSuite
+}
+
+func (s *CourseRemovePrerequisiteSuite) CourseRemovePrerequisiteSuccessRepositoryMock(
+	mock sqlmock.Sqlmock, courseID, requiredCourseID domain.ID) {
+	mock.ExpectExec(repository.CourseRemovePrerequisiteQuery).
+		WithArgs(courseID, requiredCourseID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (s *CourseRemovePrerequisiteSuite) TestRemoveCoursePrerequisite_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository remove prerequisite success")
+	repo, mock := NewCourseRepository()
+	courseID := domain.NewID()
+	requiredCourseID := domain.NewID()
+	s.CourseRemovePrerequisiteSuccessRepositoryMock(mock, courseID, requiredCourseID)
+	err := repo.RemoveCoursePrerequisite(context.Background(), courseID, requiredCourseID)
+	t.Assert().Nil(err)
+}
+
+func (s *CourseRemovePrerequisiteSuite) CourseRemovePrerequisiteFailureRepositoryMock(
+	mock sqlmock.Sqlmock, courseID, requiredCourseID domain.ID) {
+	mock.ExpectExec(repository.CourseRemovePrerequisiteQuery).
+		WithArgs(courseID, requiredCourseID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *CourseRemovePrerequisiteSuite) TestRemoveCoursePrerequisite_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Course repository remove missing prerequisite")
+	repo, mock := NewCourseRepository()
+	courseID := domain.NewID()
+	requiredCourseID := domain.NewID()
+	s.CourseRemovePrerequisiteFailureRepositoryMock(mock, courseID, requiredCourseID)
+	err := repo.RemoveCoursePrerequisite(context.Background(), courseID, requiredCourseID)
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestCourseRemovePrerequisiteSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course repository remove prerequisite", new(CourseRemovePrerequisiteSuite))
+}
@@ -0,0 +1,30 @@
+package test
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type EmailTokenBuilder struct {
+	token domain.EmailToken
+}
+
+func NewEmailTokenBuilder() *EmailTokenBuilder {
+	return &EmailTokenBuilder{
+		token: domain.EmailToken{
+			ID:        domain.NewID(),
+			UserID:    domain.NewID(),
+			Purpose:   domain.EmailVerificationPurpose,
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func (b *EmailTokenBuilder) WithPurpose(purpose domain.EmailTokenPurpose) *EmailTokenBuilder {
+	b.token.Purpose = purpose
+	return b
+}
+
+func (b *EmailTokenBuilder) Build() domain.EmailToken {
+	return b.token
+}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+)
+
+type EmailTokenSuite struct {
+	suite.Suite
+}
+
+func NewEmailTokenRepository() (port.IEmailTokenRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewEmailTokenRepo(conn)
+	return repo, mock
+}
+
+type EmailTokenCreateSuite struct {
+	EmailTokenSuite
+}
+
+func (s *EmailTokenCreateSuite) EmailTokenCreateSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	token domain.EmailToken) {
+	pgToken := entity.NewPgEmailToken(token)
+	queryString := InsertQueryString(pgToken, "email_token")
+	mock.ExpectExec(queryString).
+		WithArgs(EntityValues(pgToken)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func (s *EmailTokenCreateSuite) TestCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Email token repository create token success")
+	repo, mock := NewEmailTokenRepository()
+	token := NewEmailTokenBuilder().Build()
+	s.EmailTokenCreateSuccessRepositoryMock(mock, token)
+	err := repo.Create(context.Background(), token)
+	t.Assert().Nil(err)
+}
+
+func (s *EmailTokenCreateSuite) EmailTokenCreateFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	queryString := InsertQueryString(entity.PgEmailToken{}, "email_token")
+	mock.ExpectExec(queryString).WillReturnError(sql.ErrConnDone)
+}
+
+func (s *EmailTokenCreateSuite) TestCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Email token repository create token failure")
+	repo, mock := NewEmailTokenRepository()
+	token := NewEmailTokenBuilder().Build()
+	s.EmailTokenCreateFailureRepositoryMock(mock)
+	err := repo.Create(context.Background(), token)
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestEmailTokenCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Email token repository create token", new(EmailTokenCreateSuite))
+}
+
+type EmailTokenUseSuite struct {
+	EmailTokenSuite
+}
+
+func (s *EmailTokenUseSuite) EmailTokenUseSuccessRepositoryMock(mock sqlmock.Sqlmock, tokenID domain.ID) {
+	mock.ExpectExec(repository.EmailTokenUseQuery).
+		WithArgs(tokenID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func (s *EmailTokenUseSuite) TestUse_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Email token repository use token success")
+	repo, mock := NewEmailTokenRepository()
+	token := NewEmailTokenBuilder().Build()
+	s.EmailTokenUseSuccessRepositoryMock(mock, token.ID)
+	err := repo.Use(context.Background(), token.ID)
+	t.Assert().Nil(err)
+}
+
+func (s *EmailTokenUseSuite) EmailTokenUseFailureRepositoryMock(mock sqlmock.Sqlmock, tokenID domain.ID) {
+	mock.ExpectExec(repository.EmailTokenUseQuery).
+		WithArgs(tokenID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *EmailTokenUseSuite) TestUse_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Email token repository use already used token")
+	repo, mock := NewEmailTokenRepository()
+	token := NewEmailTokenBuilder().Build()
+	s.EmailTokenUseFailureRepositoryMock(mock, token.ID)
+	err := repo.Use(context.Background(), token.ID)
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestEmailTokenUseSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Email token repository use token", new(EmailTokenUseSuite))
+}
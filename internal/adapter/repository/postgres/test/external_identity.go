@@ -0,0 +1,24 @@
+package test
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+)
+
+type ExternalIdentityBuilder struct {
+	identity domain.ExternalIdentity
+}
+
+func NewExternalIdentityBuilder() *ExternalIdentityBuilder {
+	return &ExternalIdentityBuilder{
+		identity: domain.ExternalIdentity{
+			UserID:   domain.NewID(),
+			Provider: "corp",
+			Subject:  "subject",
+			Email:    "john.doe@corp.com",
+		},
+	}
+}
+
+func (b *ExternalIdentityBuilder) Build() domain.ExternalIdentity {
+	return b.identity
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+)
+
+type ExternalIdentitySuite struct {
+	suite.Suite
+}
+
+func NewExternalIdentityRepository() (port.IExternalIdentityRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewExternalIdentityRepo(conn)
+	return repo, mock
+}
+
+type ExternalIdentityFindSuite struct {
+	ExternalIdentitySuite
+}
+
+func (s *ExternalIdentityFindSuite) ExternalIdentityFindSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	identity domain.ExternalIdentity) {
+	pgIdentity := entity.NewPgExternalIdentity(identity)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgIdentity)).
+		AddRow(EntityValues(pgIdentity)...)
+	mock.ExpectQuery(repository.ExternalIdentityFindByProviderSubjectQuery).
+		WithArgs(identity.Provider, identity.Subject).
+		WillReturnRows(expectedRows)
+}
+
+func (s *ExternalIdentityFindSuite) TestFindByProviderSubject_Success(t provider.T) {
+	t.Parallel()
+	t.Title("External identity repository find by provider subject success")
+	repo, mock := NewExternalIdentityRepository()
+	identity := NewExternalIdentityBuilder().Build()
+	s.ExternalIdentityFindSuccessRepositoryMock(mock, identity)
+	actual, err := repo.FindByProviderSubject(context.Background(), identity.Provider, identity.Subject)
+	t.Assert().Nil(err)
+	t.Assert().Equal(identity, actual)
+}
+
+func (s *ExternalIdentityFindSuite) ExternalIdentityFindFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(repository.ExternalIdentityFindByProviderSubjectQuery).WillReturnError(sql.ErrNoRows)
+}
+
+func (s *ExternalIdentityFindSuite) TestFindByProviderSubject_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("External identity repository find by provider subject failure")
+	repo, mock := NewExternalIdentityRepository()
+	s.ExternalIdentityFindFailureRepositoryMock(mock)
+	_, err := repo.FindByProviderSubject(context.Background(), "corp", "subject")
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestExternalIdentityFindSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "External identity repository find by provider subject", new(ExternalIdentityFindSuite))
+}
+
+type ExternalIdentityCreateSuite struct {
+	ExternalIdentitySuite
+}
+
+func (s *ExternalIdentityCreateSuite) ExternalIdentityCreateSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	identity domain.ExternalIdentity) {
+	pgIdentity := entity.NewPgExternalIdentity(identity)
+	queryString := InsertQueryString(pgIdentity, "external_identity")
+	mock.ExpectExec(queryString).
+		WithArgs(EntityValues(pgIdentity)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func (s *ExternalIdentityCreateSuite) TestCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("External identity repository create success")
+	repo, mock := NewExternalIdentityRepository()
+	identity := NewExternalIdentityBuilder().Build()
+	s.ExternalIdentityCreateSuccessRepositoryMock(mock, identity)
+	err := repo.Create(context.Background(), identity)
+	t.Assert().Nil(err)
+}
+
+func (s *ExternalIdentityCreateSuite) ExternalIdentityCreateFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	queryString := InsertQueryString(entity.PgExternalIdentity{}, "external_identity")
+	mock.ExpectExec(queryString).WillReturnError(sql.ErrConnDone)
+}
+
+func (s *ExternalIdentityCreateSuite) TestCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("External identity repository create failure")
+	repo, mock := NewExternalIdentityRepository()
+	identity := NewExternalIdentityBuilder().Build()
+	s.ExternalIdentityCreateFailureRepositoryMock(mock)
+	err := repo.Create(context.Background(), identity)
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestExternalIdentityCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "External identity repository create", new(ExternalIdentityCreateSuite))
+}
@@ -0,0 +1,29 @@
+package test
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type GiftBuilder struct {
+	gift domain.Gift
+}
+
+func NewGiftBuilder() *GiftBuilder {
+	return &GiftBuilder{
+		gift: domain.Gift{
+			ID:             domain.NewID(),
+			Code:           "GIFTCODE",
+			CourseID:       domain.NewID(),
+			RecipientEmail: "recipient@example.com",
+			PaymentKey:     "key",
+			PaySum:         1000,
+			Currency:       domain.DefaultCurrency,
+			CreatedAt:      time.Now().UTC(),
+		},
+	}
+}
+
+func (b *GiftBuilder) Build() domain.Gift {
+	return b.gift
+}
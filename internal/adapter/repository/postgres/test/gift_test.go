@@ -0,0 +1,155 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+	"time"
+)
+
+type GiftSuite struct {
+	suite.Suite
+}
+
+func NewGiftRepository() (port.IGiftRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewGiftRepo(conn)
+	return repo, mock
+}
+
+type GiftFindByCodeSuite struct {
+	GiftSuite
+}
+
+func (s *GiftFindByCodeSuite) GiftFindByCodeSuccessRepositoryMock(mock sqlmock.Sqlmock, gift domain.Gift) {
+	pgGift := entity.NewPgGift(gift)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgGift)).
+		AddRow(EntityValues(pgGift)...)
+	mock.ExpectQuery(repository.GiftFindByCodeQuery).WithArgs(gift.Code).WillReturnRows(expectedRows)
+}
+
+func (s *GiftFindByCodeSuite) TestFindByCode_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Gift repository find by code success")
+	repo, mock := NewGiftRepository()
+	gift := NewGiftBuilder().Build()
+	s.GiftFindByCodeSuccessRepositoryMock(mock, gift)
+	actual, err := repo.FindByCode(context.Background(), gift.Code)
+	t.Assert().Nil(err)
+	t.Assert().Equal(gift, actual)
+}
+
+func (s *GiftFindByCodeSuite) GiftFindByCodeFailureRepositoryMock(mock sqlmock.Sqlmock, code string) {
+	mock.ExpectQuery(repository.GiftFindByCodeQuery).WithArgs(code).WillReturnError(sql.ErrNoRows)
+}
+
+func (s *GiftFindByCodeSuite) TestFindByCode_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Gift repository find by code failure")
+	repo, mock := NewGiftRepository()
+	s.GiftFindByCodeFailureRepositoryMock(mock, "UNKNOWN")
+	_, err := repo.FindByCode(context.Background(), "UNKNOWN")
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestGiftFindByCodeSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Gift repository find by code", new(GiftFindByCodeSuite))
+}
+
+type GiftCreateSuite struct {
+	GiftSuite
+}
+
+func (s *GiftCreateSuite) GiftCreateSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	gift domain.Gift, issued domain.Gift) {
+	pgGift := entity.NewPgGift(gift)
+	mock.ExpectExec(repository.GiftCreateQuery).
+		WithArgs(pgGift.ID, pgGift.Code, pgGift.CourseID, pgGift.RecipientEmail,
+			pgGift.PaymentKey, pgGift.PaySum, pgGift.Currency, pgGift.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	pgIssued := entity.NewPgGift(issued)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgIssued)).
+		AddRow(EntityValues(pgIssued)...)
+	mock.ExpectQuery(repository.GiftFindByPaymentKeyQuery).WithArgs(gift.PaymentKey).WillReturnRows(expectedRows)
+}
+
+func (s *GiftCreateSuite) TestCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Gift repository create returns gift issued for the payment before")
+	repo, mock := NewGiftRepository()
+	gift := NewGiftBuilder().Build()
+	issued := NewGiftBuilder().Build()
+	s.GiftCreateSuccessRepositoryMock(mock, gift, issued)
+	actual, err := repo.Create(context.Background(), gift)
+	t.Assert().Nil(err)
+	t.Assert().Equal(issued, actual)
+}
+
+func (s *GiftCreateSuite) GiftCreateFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(repository.GiftCreateQuery).WillReturnError(errs.ErrPersistenceFailed)
+}
+
+func (s *GiftCreateSuite) TestCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Gift repository create failure")
+	repo, mock := NewGiftRepository()
+	s.GiftCreateFailureRepositoryMock(mock)
+	_, err := repo.Create(context.Background(), NewGiftBuilder().Build())
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestGiftCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Gift repository create", new(GiftCreateSuite))
+}
+
+type GiftRedeemSuite struct {
+	GiftSuite
+}
+
+func (s *GiftRedeemSuite) GiftRedeemSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	giftID, userID domain.ID, redeemedAt time.Time) {
+	mock.ExpectExec(repository.GiftRedeemQuery).
+		WithArgs(giftID, userID, redeemedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (s *GiftRedeemSuite) TestRedeem_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Gift repository redeem success")
+	repo, mock := NewGiftRepository()
+	giftID, userID, redeemedAt := domain.NewID(), domain.NewID(), time.Now().UTC()
+	s.GiftRedeemSuccessRepositoryMock(mock, giftID, userID, redeemedAt)
+	err := repo.Redeem(context.Background(), giftID, userID, redeemedAt)
+	t.Assert().Nil(err)
+}
+
+func (s *GiftRedeemSuite) GiftRedeemFailureRepositoryMock(mock sqlmock.Sqlmock,
+	giftID, userID domain.ID, redeemedAt time.Time) {
+	mock.ExpectExec(repository.GiftRedeemQuery).
+		WithArgs(giftID, userID, redeemedAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *GiftRedeemSuite) TestRedeem_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Gift repository redeem already redeemed gift")
+	repo, mock := NewGiftRepository()
+	giftID, userID, redeemedAt := domain.NewID(), domain.NewID(), time.Now().UTC()
+	s.GiftRedeemFailureRepositoryMock(mock, giftID, userID, redeemedAt)
+	err := repo.Redeem(context.Background(), giftID, userID, redeemedAt)
+	t.Assert().ErrorIs(err, errs.ErrGiftAlreadyRedeemed)
+}
+
+func TestGiftRedeemSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Gift repository redeem", new(GiftRedeemSuite))
+}
@@ -0,0 +1,26 @@
+package test
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type JobBuilder struct {
+	job domain.Job
+}
+
+func NewJobBuilder() *JobBuilder {
+	return &JobBuilder{
+		job: domain.NewJob(domain.JobPublishCourse, domain.NewID(), domain.NewID(),
+			time.Now().UTC().Truncate(time.Second)),
+	}
+}
+
+func (b *JobBuilder) WithRunAt(runAt time.Time) *JobBuilder {
+	b.job.RunAt = runAt
+	return b
+}
+
+func (b *JobBuilder) Build() domain.Job {
+	return b.job
+}
@@ -0,0 +1,159 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+	"time"
+)
+
+type JobSuite struct {
+	suite.Suite
+}
+
+func NewJobRepository() (port.IJobRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewJobRepo(conn)
+	return repo, mock
+}
+
+type JobCreateSuite struct {
+	JobSuite
+}
+
+func (s *JobCreateSuite) JobCreateSuccessRepositoryMock(mock sqlmock.Sqlmock, job domain.Job) {
+	pgJob := entity.NewPgJob(job)
+	queryString := InsertQueryString(pgJob, "job")
+	mock.ExpectExec(queryString).
+		WithArgs(EntityValues(pgJob)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func (s *JobCreateSuite) TestCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Job repository create success")
+	repo, mock := NewJobRepository()
+	job := NewJobBuilder().Build()
+	s.JobCreateSuccessRepositoryMock(mock, job)
+	err := repo.Create(context.Background(), job)
+	t.Assert().Nil(err)
+}
+
+func (s *JobCreateSuite) JobCreateFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	queryString := InsertQueryString(entity.PgJob{}, "job")
+	mock.ExpectExec(queryString).WillReturnError(sql.ErrConnDone)
+}
+
+func (s *JobCreateSuite) TestCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Job repository create failure")
+	repo, mock := NewJobRepository()
+	s.JobCreateFailureRepositoryMock(mock)
+	err := repo.Create(context.Background(), NewJobBuilder().Build())
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestJobCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Job repository create", new(JobCreateSuite))
+}
+
+type JobClaimDueJobsSuite struct {
+	JobSuite
+}
+
+func (s *JobClaimDueJobsSuite) JobClaimDueJobsSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	job domain.Job, now, lockedUntil time.Time) {
+	pgJob := entity.NewPgJob(job)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgJob)).
+		AddRow(EntityValues(pgJob)...)
+	mock.ExpectQuery(repository.JobClaimDueQuery).
+		WithArgs(now, lockedUntil, 10).
+		WillReturnRows(expectedRows)
+}
+
+func (s *JobClaimDueJobsSuite) TestClaimDueJobs_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Job repository claim due jobs success")
+	repo, mock := NewJobRepository()
+	now := time.Now().UTC()
+	job := NewJobBuilder().WithRunAt(now.Add(-time.Minute)).Build()
+	s.JobClaimDueJobsSuccessRepositoryMock(mock, job, now, now.Add(time.Minute))
+	jobs, err := repo.ClaimDueJobs(context.Background(), now, now.Add(time.Minute), 10)
+	t.Assert().Nil(err)
+	t.Require().Len(jobs, 1)
+	t.Assert().Equal(job.ID, jobs[0].ID)
+	t.Assert().Equal(job.TargetID, jobs[0].TargetID)
+}
+
+func (s *JobClaimDueJobsSuite) JobClaimDueJobsFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(repository.JobClaimDueQuery).WillReturnError(sql.ErrConnDone)
+}
+
+func (s *JobClaimDueJobsSuite) TestClaimDueJobs_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Job repository claim due jobs failure")
+	repo, mock := NewJobRepository()
+	s.JobClaimDueJobsFailureRepositoryMock(mock)
+	now := time.Now().UTC()
+	_, err := repo.ClaimDueJobs(context.Background(), now, now.Add(time.Minute), 10)
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestJobClaimDueJobsSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Job repository claim due jobs", new(JobClaimDueJobsSuite))
+}
+
+type JobUpdateSuite struct {
+	JobSuite
+}
+
+func (s *JobUpdateSuite) TestComplete_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Job repository complete success")
+	repo, mock := NewJobRepository()
+	jobID := domain.NewID()
+	mock.ExpectExec(repository.JobCompleteQuery).
+		WithArgs(jobID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err := repo.Complete(context.Background(), jobID)
+	t.Assert().Nil(err)
+}
+
+func (s *JobUpdateSuite) TestRetry_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Job repository retry success")
+	repo, mock := NewJobRepository()
+	jobID := domain.NewID()
+	retryAt := time.Now().UTC()
+	mock.ExpectExec(repository.JobRetryQuery).
+		WithArgs(jobID, retryAt, "failure").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err := repo.Retry(context.Background(), jobID, retryAt, "failure")
+	t.Assert().Nil(err)
+}
+
+func (s *JobUpdateSuite) TestFail_NotExist(t provider.T) {
+	t.Parallel()
+	t.Title("Job repository fail of missing job")
+	repo, mock := NewJobRepository()
+	jobID := domain.NewID()
+	mock.ExpectExec(repository.JobFailQuery).
+		WithArgs(jobID, "failure").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err := repo.Fail(context.Background(), jobID, "failure")
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestJobUpdateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Job repository update", new(JobUpdateSuite))
+}
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type LedgerEntryBuilder struct {
+	entry domain.LedgerEntry
+}
+
+func NewLedgerEntryBuilder() *LedgerEntryBuilder {
+	return &LedgerEntryBuilder{
+		entry: domain.LedgerEntry{
+			ID:         domain.NewID(),
+			SchoolID:   domain.NewID(),
+			CourseID:   domain.NewID(),
+			UserID:     domain.NewID(),
+			PaymentKey: "key",
+			Kind:       domain.LedgerSale,
+			Gross:      1000,
+			Commission: 100,
+			Currency:   domain.DefaultCurrency,
+			CreatedAt:  time.Now().UTC(),
+		},
+	}
+}
+
+func (b *LedgerEntryBuilder) Build() domain.LedgerEntry {
+	return b.entry
+}
+
+type PayoutBuilder struct {
+	payout domain.Payout
+}
+
+func NewPayoutBuilder() *PayoutBuilder {
+	return &PayoutBuilder{
+		payout: domain.Payout{
+			ID:           domain.NewID(),
+			SchoolID:     domain.NewID(),
+			Currency:     domain.DefaultCurrency,
+			SettledUntil: time.Now().UTC(),
+			CreatedAt:    time.Now().UTC(),
+		},
+	}
+}
+
+func (b *PayoutBuilder) Build() domain.Payout {
+	return b.payout
+}
@@ -0,0 +1,135 @@
+package test
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+)
+
+type LedgerSuite struct {
+	suite.Suite
+}
+
+func NewLedgerRepository() (port.ILedgerRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewLedgerRepo(conn)
+	return repo, mock
+}
+
+type LedgerCreateSuite struct {
+	LedgerSuite
+}
+
+func (s *LedgerCreateSuite) LedgerCreateSuccessRepositoryMock(mock sqlmock.Sqlmock, entry domain.LedgerEntry) {
+	pgEntry := entity.NewPgLedgerEntry(entry)
+	mock.ExpectExec(repository.LedgerCreateQuery).
+		WithArgs(pgEntry.ID, pgEntry.SchoolID, pgEntry.CourseID, pgEntry.UserID, pgEntry.PaymentKey,
+			pgEntry.Kind, pgEntry.Gross, pgEntry.Commission, pgEntry.Currency, pgEntry.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func (s *LedgerCreateSuite) TestCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Ledger repository create success")
+	repo, mock := NewLedgerRepository()
+	entry := NewLedgerEntryBuilder().Build()
+	s.LedgerCreateSuccessRepositoryMock(mock, entry)
+	err := repo.Create(context.Background(), entry)
+	t.Assert().Nil(err)
+}
+
+func (s *LedgerCreateSuite) LedgerCreateFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(repository.LedgerCreateQuery).
+		WillReturnError(errs.ErrPersistenceFailed)
+}
+
+func (s *LedgerCreateSuite) TestCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Ledger repository create failure")
+	repo, mock := NewLedgerRepository()
+	s.LedgerCreateFailureRepositoryMock(mock)
+	err := repo.Create(context.Background(), NewLedgerEntryBuilder().Build())
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestLedgerCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Ledger repository create", new(LedgerCreateSuite))
+}
+
+type LedgerCreatePayoutSuite struct {
+	LedgerSuite
+}
+
+func (s *LedgerCreatePayoutSuite) LedgerCreatePayoutSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	payout domain.Payout) {
+	pgPayout := entity.NewPgPayout(payout)
+	mock.ExpectBegin()
+	mock.ExpectQuery(repository.LedgerLockSchoolQuery).
+		WithArgs(pgPayout.SchoolID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(pgPayout.SchoolID))
+	amount := pgPayout.Amount
+	pgPayout.Amount = 0
+	mock.ExpectExec(InsertQueryString(pgPayout, "payout")).
+		WithArgs(EntityValues(pgPayout)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(repository.LedgerSettleQuery).
+		WithArgs(pgPayout.ID, pgPayout.SchoolID, pgPayout.SettledUntil, pgPayout.Currency).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(amount))
+	mock.ExpectExec(repository.PayoutUpdateAmountQuery).
+		WithArgs(pgPayout.ID, amount).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func (s *LedgerCreatePayoutSuite) TestCreatePayout_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Ledger repository create payout success")
+	repo, mock := NewLedgerRepository()
+	payout := NewPayoutBuilder().Build()
+	payout.Amount = 1800
+	s.LedgerCreatePayoutSuccessRepositoryMock(mock, payout)
+	payout.Amount = 0
+	actual, err := repo.CreatePayout(context.Background(), payout)
+	t.Assert().Nil(err)
+	t.Assert().Equal(int64(1800), actual.Amount)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *LedgerCreatePayoutSuite) LedgerCreatePayoutFailureRepositoryMock(mock sqlmock.Sqlmock,
+	payout domain.Payout) {
+	pgPayout := entity.NewPgPayout(payout)
+	mock.ExpectBegin()
+	mock.ExpectQuery(repository.LedgerLockSchoolQuery).
+		WithArgs(pgPayout.SchoolID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(pgPayout.SchoolID))
+	mock.ExpectExec(InsertQueryString(pgPayout, "payout")).
+		WithArgs(EntityValues(pgPayout)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(repository.LedgerSettleQuery).
+		WithArgs(pgPayout.ID, pgPayout.SchoolID, pgPayout.SettledUntil, pgPayout.Currency).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(0))
+	mock.ExpectRollback()
+}
+
+func (s *LedgerCreatePayoutSuite) TestCreatePayout_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Ledger repository create payout without unsettled entries")
+	repo, mock := NewLedgerRepository()
+	payout := NewPayoutBuilder().Build()
+	s.LedgerCreatePayoutFailureRepositoryMock(mock, payout)
+	_, err := repo.CreatePayout(context.Background(), payout)
+	t.Assert().ErrorIs(err, errs.ErrNothingToSettle)
+}
+
+func TestLedgerCreatePayoutSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Ledger repository create payout", new(LedgerCreatePayoutSuite))
+}
@@ -10,9 +10,12 @@ type LessonBuilder struct {
 }
 
 func NewLessonBuilder() *LessonBuilder {
+	id := domain.NewID()
 	return &LessonBuilder{
 		lesson: domain.Lesson{
-			ID:        domain.NewID(),
+			ID:        id,
+			Key:       id,
+			Version:   1,
 			Title:     "title",
 			Score:     10,
 			Type:      domain.TheoryLesson,
@@ -33,6 +36,11 @@ func (b *LessonBuilder) WithCourseID(courseID domain.ID) *LessonBuilder {
 	return b
 }
 
+func (b *LessonBuilder) WithVersion(version int) *LessonBuilder {
+	b.lesson.Version = version
+	return b
+}
+
 func (b *LessonBuilder) WithTitle(title string) *LessonBuilder {
 	b.lesson.Title = title
 	return b
@@ -72,9 +80,11 @@ type TestBuilder struct {
 }
 
 func NewTestBuilder() *TestBuilder {
+	id := domain.NewID()
 	return &TestBuilder{
 		test: domain.Test{
-			ID:       domain.NewID(),
+			ID:       id,
+			Key:      id,
 			LessonID: domain.NewID(),
 			TaskUrl:  "url",
 			Options:  []string{"opt1", "opt2"},
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
@@ -192,6 +193,160 @@ func TestLessonFindCourseLessonsSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Lesson repository find course lessons", new(LessonFindCourseLessonsSuite))
 }
 
+type LessonFindCourseVersionLessonsSuite struct {
+	LessonSuite
+}
+
+func (s *LessonFindCourseVersionLessonsSuite) LessonFindCourseVersionLessonsSuccessRepositoryMock(
+	mock sqlmock.Sqlmock, lesson domain.Lesson, courseID domain.ID, version int) {
+	pgLesson := entity.NewPgLesson(lesson)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgLesson))
+	expectedRows.AddRow(EntityValues(pgLesson)...)
+	mock.ExpectQuery(repository.LessonFindCourseVersionLessonsQuery).
+		WithArgs(courseID, version).WillReturnRows(expectedRows)
+}
+
+func (s *LessonFindCourseVersionLessonsSuite) TestFindCourseVersionLessons_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson repository find course version lessons success")
+	repo, mock := NewLessonRepository()
+	courseID := domain.NewID()
+	lesson := NewLessonBuilder().WithCourseID(courseID).WithVersion(2).Build()
+	s.LessonFindCourseVersionLessonsSuccessRepositoryMock(mock, lesson, courseID, 2)
+	lessons, err := repo.FindCourseVersionLessons(context.Background(), courseID, 2)
+	t.Assert().Nil(err)
+	t.Assert().Equal(lesson.Key, lessons[0].Key)
+	t.Assert().Equal(2, lessons[0].Version)
+}
+
+func (s *LessonFindCourseVersionLessonsSuite) LessonFindCourseVersionLessonsFailureRepositoryMock(
+	mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(repository.LessonFindCourseVersionLessonsQuery).WillReturnError(sql.ErrConnDone)
+}
+
+func (s *LessonFindCourseVersionLessonsSuite) TestFindCourseVersionLessons_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson repository find course version lessons failure")
+	repo, mock := NewLessonRepository()
+	s.LessonFindCourseVersionLessonsFailureRepositoryMock(mock)
+	_, err := repo.FindCourseVersionLessons(context.Background(), domain.NewID(), 1)
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestLessonFindCourseVersionLessonsSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Lesson repository find course version lessons",
+		new(LessonFindCourseVersionLessonsSuite))
+}
+
+type LessonCreateRevisionSuite struct {
+	LessonSuite
+}
+
+func (s *LessonCreateRevisionSuite) LessonCreateRevisionSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	lesson domain.Lesson, courseID domain.ID, version int) {
+	pgLesson := entity.NewPgLesson(lesson)
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.LessonOpenCourseRevisionQuery).
+		WithArgs(courseID, version).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(InsertQueryString(pgLesson, "lesson")).
+		WithArgs(EntityValues(pgLesson)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+}
+
+func (s *LessonCreateRevisionSuite) TestCreateRevision_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson repository create course revision success")
+	repo, mock := NewLessonRepository()
+	courseID := domain.NewID()
+	lesson := NewLessonBuilder().WithCourseID(courseID).WithVersion(2).Build()
+	s.LessonCreateRevisionSuccessRepositoryMock(mock, lesson, courseID, 2)
+	err := repo.CreateRevision(context.Background(), courseID, 2, []domain.Lesson{lesson})
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *LessonCreateRevisionSuite) LessonCreateRevisionExistsRepositoryMock(mock sqlmock.Sqlmock,
+	courseID domain.ID, version int) {
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.LessonOpenCourseRevisionQuery).
+		WithArgs(courseID, version).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+}
+
+func (s *LessonCreateRevisionSuite) TestCreateRevision_Exists(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson repository create course revision when revision exists")
+	repo, mock := NewLessonRepository()
+	courseID := domain.NewID()
+	lesson := NewLessonBuilder().WithCourseID(courseID).WithVersion(2).Build()
+	s.LessonCreateRevisionExistsRepositoryMock(mock, courseID, 2)
+	err := repo.CreateRevision(context.Background(), courseID, 2, []domain.Lesson{lesson})
+	t.Assert().ErrorIs(err, errs.ErrCourseRevisionExists)
+}
+
+func TestLessonCreateRevisionSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Lesson repository create course revision", new(LessonCreateRevisionSuite))
+}
+
+type LessonCreateCourseLessonsSuite struct {
+	LessonSuite
+}
+
+func (s *LessonCreateCourseLessonsSuite) LessonCreateCourseLessonsSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	lessons []domain.Lesson) {
+	mock.ExpectBegin()
+	for _, lesson := range lessons {
+		pgLesson := entity.NewPgLesson(lesson)
+		mock.ExpectExec(InsertQueryString(pgLesson, "lesson")).
+			WithArgs(EntityValues(pgLesson)...).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+}
+
+func (s *LessonCreateCourseLessonsSuite) TestCreateCourseLessons_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson repository create course lessons success")
+	repo, mock := NewLessonRepository()
+	courseID := domain.NewID()
+	lessons := []domain.Lesson{
+		NewLessonBuilder().WithCourseID(courseID).Build(),
+		NewLessonBuilder().WithCourseID(courseID).Build(),
+	}
+	s.LessonCreateCourseLessonsSuccessRepositoryMock(mock, lessons)
+	err := repo.CreateCourseLessons(context.Background(), lessons)
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *LessonCreateCourseLessonsSuite) LessonCreateCourseLessonsFailureRepositoryMock(mock sqlmock.Sqlmock,
+	lessons []domain.Lesson) {
+	pgLesson := entity.NewPgLesson(lessons[0])
+	mock.ExpectBegin()
+	mock.ExpectExec(InsertQueryString(pgLesson, "lesson")).
+		WithArgs(EntityValues(pgLesson)...).
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+}
+
+func (s *LessonCreateCourseLessonsSuite) TestCreateCourseLessons_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson repository create course lessons failure")
+	repo, mock := NewLessonRepository()
+	lessons := []domain.Lesson{NewLessonBuilder().Build(), NewLessonBuilder().Build()}
+	s.LessonCreateCourseLessonsFailureRepositoryMock(mock, lessons)
+	err := repo.CreateCourseLessons(context.Background(), lessons)
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func TestLessonCreateCourseLessonsSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Lesson repository create course lessons", new(LessonCreateCourseLessonsSuite))
+}
+
 type LessonCreateSuite struct {
 	LessonSuite
 }
@@ -325,3 +480,100 @@ func (s *LessonDeleteSuite) TestDelete_Failure(t provider.T) {
 func TestLessonDeleteSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Lesson repository delete lesson", new(LessonDeleteSuite))
 }
+
+type LessonUpdateReleaseSuite struct {
+	LessonSuite
+}
+
+func (s *LessonUpdateReleaseSuite) LessonUpdateReleaseSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	lesson domain.Lesson) {
+	mock.ExpectExec(repository.LessonUpdateReleaseQuery).
+		WithArgs(lesson.ID, lesson.ReleaseAt, lesson.ReleaseDays).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	pgLesson := entity.NewPgLesson(lesson)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgLesson)).
+		AddRow(EntityValues(pgLesson)...)
+	mock.ExpectQuery(repository.LessonFindByIDQuery).WithArgs(pgLesson.ID).WillReturnRows(expectedRows)
+}
+
+func (s *LessonUpdateReleaseSuite) TestUpdateRelease_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson repository update release success")
+	repo, mock := NewLessonRepository()
+	lesson := NewLessonBuilder().Build()
+	lesson.ReleaseDays = null.IntFrom(7)
+	s.LessonUpdateReleaseSuccessRepositoryMock(mock, lesson)
+	updatedLesson, err := repo.UpdateRelease(context.Background(), lesson.ID,
+		lesson.ReleaseAt, lesson.ReleaseDays)
+	t.Assert().Nil(err)
+	t.Assert().Equal(lesson.ReleaseDays, updatedLesson.ReleaseDays)
+}
+
+func (s *LessonUpdateReleaseSuite) LessonUpdateReleaseFailureRepositoryMock(mock sqlmock.Sqlmock,
+	lessonID domain.ID) {
+	mock.ExpectExec(repository.LessonUpdateReleaseQuery).
+		WithArgs(lessonID, null.Time{}, null.Int{}).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *LessonUpdateReleaseSuite) TestUpdateRelease_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson repository update release failure")
+	repo, mock := NewLessonRepository()
+	lessonID := domain.NewID()
+	s.LessonUpdateReleaseFailureRepositoryMock(mock, lessonID)
+	_, err := repo.UpdateRelease(context.Background(), lessonID, null.Time{}, null.Int{})
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestLessonUpdateReleaseSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Lesson repository update release", new(LessonUpdateReleaseSuite))
+}
+
+type LessonUpdatePreviewSuite struct {
+	LessonSuite
+}
+
+func (s *LessonUpdatePreviewSuite) LessonUpdatePreviewSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	lesson domain.Lesson) {
+	mock.ExpectExec(repository.LessonUpdatePreviewQuery).
+		WithArgs(lesson.ID, lesson.IsPreview).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	pgLesson := entity.NewPgLesson(lesson)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgLesson)).
+		AddRow(EntityValues(pgLesson)...)
+	mock.ExpectQuery(repository.LessonFindByIDQuery).WithArgs(pgLesson.ID).WillReturnRows(expectedRows)
+}
+
+func (s *LessonUpdatePreviewSuite) TestUpdatePreview_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson repository update preview success")
+	repo, mock := NewLessonRepository()
+	lesson := NewLessonBuilder().Build()
+	lesson.IsPreview = true
+	s.LessonUpdatePreviewSuccessRepositoryMock(mock, lesson)
+	updatedLesson, err := repo.UpdatePreview(context.Background(), lesson.ID, true)
+	t.Assert().Nil(err)
+	t.Assert().True(updatedLesson.IsPreview)
+}
+
+func (s *LessonUpdatePreviewSuite) LessonUpdatePreviewFailureRepositoryMock(mock sqlmock.Sqlmock,
+	lessonID domain.ID) {
+	mock.ExpectExec(repository.LessonUpdatePreviewQuery).
+		WithArgs(lessonID, true).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *LessonUpdatePreviewSuite) TestUpdatePreview_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson repository update preview failure")
+	repo, mock := NewLessonRepository()
+	lessonID := domain.NewID()
+	s.LessonUpdatePreviewFailureRepositoryMock(mock, lessonID)
+	_, err := repo.UpdatePreview(context.Background(), lessonID, true)
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestLessonUpdatePreviewSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Lesson repository update preview", new(LessonUpdatePreviewSuite))
+}
@@ -0,0 +1,26 @@
+package test
+
+import "github.com/paw1a/eschool/internal/core/domain"
+
+type MfaSettingsBuilder struct {
+	settings domain.MfaSettings
+}
+
+func NewMfaSettingsBuilder() *MfaSettingsBuilder {
+	return &MfaSettingsBuilder{
+		settings: domain.MfaSettings{
+			UserID:  domain.NewID(),
+			Secret:  "JBSWY3DPEHPK3PXP",
+			Enabled: true,
+		},
+	}
+}
+
+func (b *MfaSettingsBuilder) WithEnabled(enabled bool) *MfaSettingsBuilder {
+	b.settings.Enabled = enabled
+	return b
+}
+
+func (b *MfaSettingsBuilder) Build() domain.MfaSettings {
+	return b.settings
+}
@@ -0,0 +1,157 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+)
+
+type MfaSuite struct {
+	suite.Suite
+}
+
+func NewMfaRepository() (port.IMfaRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewMfaRepo(conn)
+	return repo, mock
+}
+
+type MfaFindByUserIDSuite struct {
+	MfaSuite
+}
+
+func (s *MfaFindByUserIDSuite) MfaFindByUserIDSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	settings domain.MfaSettings) {
+	pgSettings := entity.NewPgMfaSettings(settings)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgSettings)).
+		AddRow(EntityValues(pgSettings)...)
+	mock.ExpectQuery(repository.MfaFindByUserIDQuery).WithArgs(settings.UserID).WillReturnRows(expectedRows)
+}
+
+func (s *MfaFindByUserIDSuite) TestFindByUserID_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Mfa repository find settings by user id success")
+	repo, mock := NewMfaRepository()
+	settings := NewMfaSettingsBuilder().Build()
+	s.MfaFindByUserIDSuccessRepositoryMock(mock, settings)
+	actual, err := repo.FindByUserID(context.Background(), settings.UserID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(settings, actual)
+}
+
+func (s *MfaFindByUserIDSuite) MfaFindByUserIDFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(repository.MfaFindByUserIDQuery).WillReturnError(sql.ErrNoRows)
+}
+
+func (s *MfaFindByUserIDSuite) TestFindByUserID_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Mfa repository find settings by user id failure")
+	repo, mock := NewMfaRepository()
+	s.MfaFindByUserIDFailureRepositoryMock(mock)
+	_, err := repo.FindByUserID(context.Background(), domain.NewID())
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestMfaFindByUserIDSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Mfa repository find settings by user id", new(MfaFindByUserIDSuite))
+}
+
+type MfaReplaceRecoveryCodesSuite struct {
+	MfaSuite
+}
+
+func (s *MfaReplaceRecoveryCodesSuite) MfaReplaceRecoveryCodesSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	userID domain.ID, codeHashes []string) {
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.MfaDeleteRecoveryCodesQuery).
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	queryString := InsertQueryString(entity.PgMfaRecoveryCode{}, "mfa_recovery_code")
+	for range codeHashes {
+		mock.ExpectExec(queryString).WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+}
+
+func (s *MfaReplaceRecoveryCodesSuite) TestReplaceRecoveryCodes_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Mfa repository replace recovery codes success")
+	repo, mock := NewMfaRepository()
+	userID := domain.NewID()
+	codeHashes := []string{"hash1", "hash2"}
+	s.MfaReplaceRecoveryCodesSuccessRepositoryMock(mock, userID, codeHashes)
+	err := repo.ReplaceRecoveryCodes(context.Background(), userID, codeHashes)
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *MfaReplaceRecoveryCodesSuite) MfaReplaceRecoveryCodesFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.MfaDeleteRecoveryCodesQuery).WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+}
+
+func (s *MfaReplaceRecoveryCodesSuite) TestReplaceRecoveryCodes_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Mfa repository replace recovery codes failure")
+	repo, mock := NewMfaRepository()
+	s.MfaReplaceRecoveryCodesFailureRepositoryMock(mock)
+	err := repo.ReplaceRecoveryCodes(context.Background(), domain.NewID(), []string{"hash"})
+	t.Assert().ErrorIs(err, errs.ErrDeleteFailed)
+}
+
+func TestMfaReplaceRecoveryCodesSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Mfa repository replace recovery codes", new(MfaReplaceRecoveryCodesSuite))
+}
+
+type MfaUseRecoveryCodeSuite struct {
+	MfaSuite
+}
+
+func (s *MfaUseRecoveryCodeSuite) MfaUseRecoveryCodeSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	userID domain.ID, codeHash string) {
+	mock.ExpectExec(repository.MfaUseRecoveryCodeQuery).
+		WithArgs(userID, codeHash).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func (s *MfaUseRecoveryCodeSuite) TestUseRecoveryCode_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Mfa repository use recovery code success")
+	repo, mock := NewMfaRepository()
+	userID := domain.NewID()
+	s.MfaUseRecoveryCodeSuccessRepositoryMock(mock, userID, "hash")
+	err := repo.UseRecoveryCode(context.Background(), userID, "hash")
+	t.Assert().Nil(err)
+}
+
+func (s *MfaUseRecoveryCodeSuite) MfaUseRecoveryCodeFailureRepositoryMock(mock sqlmock.Sqlmock,
+	userID domain.ID, codeHash string) {
+	mock.ExpectExec(repository.MfaUseRecoveryCodeQuery).
+		WithArgs(userID, codeHash).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *MfaUseRecoveryCodeSuite) TestUseRecoveryCode_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Mfa repository use recovery code failure")
+	repo, mock := NewMfaRepository()
+	userID := domain.NewID()
+	s.MfaUseRecoveryCodeFailureRepositoryMock(mock, userID, "hash")
+	err := repo.UseRecoveryCode(context.Background(), userID, "hash")
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestMfaUseRecoveryCodeSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Mfa repository use recovery code", new(MfaUseRecoveryCodeSuite))
+}
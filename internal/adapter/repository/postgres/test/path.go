@@ -0,0 +1,46 @@
+package test
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type LearningPathBuilder struct {
+	path domain.LearningPath
+}
+
+func NewLearningPathBuilder() *LearningPathBuilder {
+	return &LearningPathBuilder{
+		path: domain.LearningPath{
+			ID:          domain.NewID(),
+			SchoolID:    domain.NewID(),
+			Name:        "path",
+			Description: "description",
+			CreatedAt:   time.Now().UTC(),
+		},
+	}
+}
+
+func (b *LearningPathBuilder) Build() domain.LearningPath {
+	return b.path
+}
+
+type PathCertificateBuilder struct {
+	certificate domain.PathCertificate
+}
+
+func NewPathCertificateBuilder() *PathCertificateBuilder {
+	return &PathCertificateBuilder{
+		certificate: domain.PathCertificate{
+			ID:       domain.NewID(),
+			PathID:   domain.NewID(),
+			UserID:   domain.NewID(),
+			Score:    120,
+			IssuedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func (b *PathCertificateBuilder) Build() domain.PathCertificate {
+	return b.certificate
+}
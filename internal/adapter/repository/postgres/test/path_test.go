@@ -0,0 +1,189 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+)
+
+type LearningPathSuite struct {
+	suite.Suite
+}
+
+func NewLearningPathRepository() (port.ILearningPathRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewLearningPathRepo(conn)
+	return repo, mock
+}
+
+type LearningPathFindByIDSuite struct {
+	LearningPathSuite
+}
+
+func (s *LearningPathFindByIDSuite) LearningPathFindByIDSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	path domain.LearningPath) {
+	pgPath := entity.NewPgLearningPath(path)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgPath)).
+		AddRow(EntityValues(pgPath)...)
+	mock.ExpectQuery(repository.PathFindByIDQuery).WithArgs(path.ID).WillReturnRows(expectedRows)
+}
+
+func (s *LearningPathFindByIDSuite) TestFindByID_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Learning path repository find by id success")
+	repo, mock := NewLearningPathRepository()
+	path := NewLearningPathBuilder().Build()
+	s.LearningPathFindByIDSuccessRepositoryMock(mock, path)
+	actual, err := repo.FindByID(context.Background(), path.ID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(path, actual)
+}
+
+func (s *LearningPathFindByIDSuite) LearningPathFindByIDFailureRepositoryMock(mock sqlmock.Sqlmock,
+	pathID domain.ID) {
+	mock.ExpectQuery(repository.PathFindByIDQuery).WithArgs(pathID).WillReturnError(sql.ErrNoRows)
+}
+
+func (s *LearningPathFindByIDSuite) TestFindByID_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Learning path repository find by id failure")
+	repo, mock := NewLearningPathRepository()
+	pathID := domain.NewID()
+	s.LearningPathFindByIDFailureRepositoryMock(mock, pathID)
+	_, err := repo.FindByID(context.Background(), pathID)
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestLearningPathFindByIDSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Learning path repository find by id", new(LearningPathFindByIDSuite))
+}
+
+type LearningPathCreateSuite struct {
+	LearningPathSuite
+}
+
+func (s *LearningPathCreateSuite) LearningPathCreateSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	path domain.LearningPath, courseIDs []domain.ID) {
+	pgPath := entity.NewPgLearningPath(path)
+	mock.ExpectBegin()
+	mock.ExpectExec(InsertQueryString(pgPath, "learning_path")).
+		WithArgs(EntityValues(pgPath)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	for i, courseID := range courseIDs {
+		mock.ExpectExec(repository.PathAddCourseQuery).
+			WithArgs(pgPath.ID, courseID, i).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	expectedRows := sqlmock.NewRows(EntityColumns(pgPath)).
+		AddRow(EntityValues(pgPath)...)
+	mock.ExpectQuery(repository.PathFindByIDQuery).WithArgs(pgPath.ID).WillReturnRows(expectedRows)
+	mock.ExpectCommit()
+}
+
+func (s *LearningPathCreateSuite) TestCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Learning path repository create success")
+	repo, mock := NewLearningPathRepository()
+	path := NewLearningPathBuilder().Build()
+	courseIDs := []domain.ID{domain.NewID(), domain.NewID()}
+	s.LearningPathCreateSuccessRepositoryMock(mock, path, courseIDs)
+	actual, err := repo.Create(context.Background(), path, courseIDs)
+	t.Assert().Nil(err)
+	t.Assert().Equal(path, actual)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *LearningPathCreateSuite) LearningPathCreateFailureRepositoryMock(mock sqlmock.Sqlmock,
+	path domain.LearningPath, courseID domain.ID) {
+	pgPath := entity.NewPgLearningPath(path)
+	mock.ExpectBegin()
+	mock.ExpectExec(InsertQueryString(pgPath, "learning_path")).
+		WithArgs(EntityValues(pgPath)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(repository.PathAddCourseQuery).
+		WithArgs(pgPath.ID, courseID, 0).
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+}
+
+func (s *LearningPathCreateSuite) TestCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Learning path repository create failure")
+	repo, mock := NewLearningPathRepository()
+	path := NewLearningPathBuilder().Build()
+	courseID := domain.NewID()
+	s.LearningPathCreateFailureRepositoryMock(mock, path, courseID)
+	_, err := repo.Create(context.Background(), path, []domain.ID{courseID})
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func TestLearningPathCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Learning path repository create", new(LearningPathCreateSuite))
+}
+
+type LearningPathCreateCertificateSuite struct {
+	LearningPathSuite
+}
+
+func (s *LearningPathCreateCertificateSuite) LearningPathCreateCertificateSuccessRepositoryMock(
+	mock sqlmock.Sqlmock, certificate, issued domain.PathCertificate) {
+	pgCertificate := entity.NewPgPathCertificate(certificate)
+	mock.ExpectExec(repository.PathCreateCertificateQuery).
+		WithArgs(pgCertificate.ID, pgCertificate.PathID, pgCertificate.UserID,
+			pgCertificate.Score, pgCertificate.IssuedAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	pgIssued := entity.NewPgPathCertificate(issued)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgIssued)).
+		AddRow(EntityValues(pgIssued)...)
+	mock.ExpectQuery(repository.PathFindUserCertificateQuery).
+		WithArgs(certificate.UserID, certificate.PathID).
+		WillReturnRows(expectedRows)
+}
+
+func (s *LearningPathCreateCertificateSuite) TestCreateCertificate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Learning path repository create certificate returns already issued one")
+	repo, mock := NewLearningPathRepository()
+	issued := NewPathCertificateBuilder().Build()
+	certificate := issued
+	certificate.ID = domain.NewID()
+	s.LearningPathCreateCertificateSuccessRepositoryMock(mock, certificate, issued)
+	actual, err := repo.CreateCertificate(context.Background(), certificate)
+	t.Assert().Nil(err)
+	t.Assert().Equal(issued, actual)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *LearningPathCreateCertificateSuite) LearningPathCreateCertificateFailureRepositoryMock(
+	mock sqlmock.Sqlmock, certificate domain.PathCertificate) {
+	pgCertificate := entity.NewPgPathCertificate(certificate)
+	mock.ExpectExec(repository.PathCreateCertificateQuery).
+		WithArgs(pgCertificate.ID, pgCertificate.PathID, pgCertificate.UserID,
+			pgCertificate.Score, pgCertificate.IssuedAt).
+		WillReturnError(sql.ErrConnDone)
+}
+
+func (s *LearningPathCreateCertificateSuite) TestCreateCertificate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Learning path repository create certificate failure")
+	repo, mock := NewLearningPathRepository()
+	certificate := NewPathCertificateBuilder().Build()
+	s.LearningPathCreateCertificateFailureRepositoryMock(mock, certificate)
+	_, err := repo.CreateCertificate(context.Background(), certificate)
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestLearningPathCreateCertificateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Learning path repository create certificate", new(LearningPathCreateCertificateSuite))
+}
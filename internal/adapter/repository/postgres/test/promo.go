@@ -0,0 +1,32 @@
+package test
+
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type PromoCodeBuilder struct {
+	promo domain.PromoCode
+}
+
+func NewPromoCodeBuilder() *PromoCodeBuilder {
+	return &PromoCodeBuilder{
+		promo: domain.PromoCode{
+			ID:           domain.NewID(),
+			SchoolID:     domain.NewID(),
+			CourseID:     null.StringFrom(domain.NewID().String()),
+			Code:         "SPRING25",
+			DiscountType: domain.PromoFixedDiscount,
+			Discount:     500,
+			Currency:     domain.DefaultCurrency,
+			MaxUses:      null.IntFrom(100),
+			UsedCount:    3,
+			CreatedAt:    time.Now().UTC(),
+		},
+	}
+}
+
+func (b *PromoCodeBuilder) Build() domain.PromoCode {
+	return b.promo
+}
@@ -0,0 +1,146 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+	"time"
+)
+
+type PromoCodeSuite struct {
+	suite.Suite
+}
+
+func NewPromoCodeRepository() (port.IPromoCodeRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewPromoCodeRepo(conn)
+	return repo, mock
+}
+
+type PromoCodeFindByCodeSuite struct {
+	PromoCodeSuite
+}
+
+func (s *PromoCodeFindByCodeSuite) PromoCodeFindByCodeSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	promo domain.PromoCode) {
+	pgPromo := entity.NewPgPromoCode(promo)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgPromo)).
+		AddRow(EntityValues(pgPromo)...)
+	mock.ExpectQuery(repository.PromoCodeFindByCodeQuery).
+		WithArgs(promo.SchoolID, promo.Code).
+		WillReturnRows(expectedRows)
+}
+
+func (s *PromoCodeFindByCodeSuite) TestFindByCode_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Promo code repository find by code success")
+	repo, mock := NewPromoCodeRepository()
+	promo := NewPromoCodeBuilder().Build()
+	s.PromoCodeFindByCodeSuccessRepositoryMock(mock, promo)
+	actual, err := repo.FindByCode(context.Background(), promo.SchoolID, promo.Code)
+	t.Assert().Nil(err)
+	t.Assert().Equal(promo, actual)
+}
+
+func (s *PromoCodeFindByCodeSuite) PromoCodeFindByCodeFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(repository.PromoCodeFindByCodeQuery).WillReturnError(sql.ErrNoRows)
+}
+
+func (s *PromoCodeFindByCodeSuite) TestFindByCode_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Promo code repository find by code failure")
+	repo, mock := NewPromoCodeRepository()
+	s.PromoCodeFindByCodeFailureRepositoryMock(mock)
+	_, err := repo.FindByCode(context.Background(), domain.NewID(), "UNKNOWN")
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestPromoCodeFindByCodeSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Promo code repository find by code", new(PromoCodeFindByCodeSuite))
+}
+
+type PromoCodeRedeemSuite struct {
+	PromoCodeSuite
+}
+
+func (s *PromoCodeRedeemSuite) PromoCodeRedeemSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	redemption domain.PromoRedemption) {
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.PromoRedemptionInsertQuery).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), redemption.PaySum, redemption.RedeemedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(repository.PromoCodeIncrementUsedQuery).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+}
+
+func (s *PromoCodeRedeemSuite) TestRedeem_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Promo code repository redeem success")
+	repo, mock := NewPromoCodeRepository()
+	redemption := domain.PromoRedemption{
+		PromoCodeID: domain.NewID(),
+		UserID:      domain.NewID(),
+		CourseID:    domain.NewID(),
+		PaySum:      3000,
+		RedeemedAt:  time.Now().UTC(),
+	}
+	s.PromoCodeRedeemSuccessRepositoryMock(mock, redemption)
+	err := repo.Redeem(context.Background(), redemption)
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *PromoCodeRedeemSuite) PromoCodeRedeemRepeatedRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.PromoRedemptionInsertQuery).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+}
+
+func (s *PromoCodeRedeemSuite) TestRedeem_Repeated(t provider.T) {
+	t.Parallel()
+	t.Title("Promo code repository repeated redeem is counted once")
+	repo, mock := NewPromoCodeRepository()
+	s.PromoCodeRedeemRepeatedRepositoryMock(mock)
+	err := repo.Redeem(context.Background(), domain.PromoRedemption{
+		PromoCodeID: domain.NewID(),
+		UserID:      domain.NewID(),
+		CourseID:    domain.NewID(),
+	})
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *PromoCodeRedeemSuite) PromoCodeRedeemFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.PromoRedemptionInsertQuery).WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+}
+
+func (s *PromoCodeRedeemSuite) TestRedeem_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Promo code repository redeem failure")
+	repo, mock := NewPromoCodeRepository()
+	s.PromoCodeRedeemFailureRepositoryMock(mock)
+	err := repo.Redeem(context.Background(), domain.PromoRedemption{
+		PromoCodeID: domain.NewID(),
+		UserID:      domain.NewID(),
+		CourseID:    domain.NewID(),
+	})
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestPromoCodeRedeemSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Promo code repository redeem", new(PromoCodeRedeemSuite))
+}
@@ -0,0 +1,29 @@
+package test
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type RefundBuilder struct {
+	refund domain.Refund
+}
+
+func NewRefundBuilder() *RefundBuilder {
+	return &RefundBuilder{
+		refund: domain.Refund{
+			ID:          domain.NewID(),
+			UserID:      domain.NewID(),
+			CourseID:    domain.NewID(),
+			PaymentKey:  "key",
+			Amount:      3990,
+			Reason:      "reason",
+			Status:      domain.RefundRequested,
+			RequestedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func (b *RefundBuilder) Build() domain.Refund {
+	return b.refund
+}
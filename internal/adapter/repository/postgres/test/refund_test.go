@@ -0,0 +1,270 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+	"time"
+)
+
+type RefundSuite struct {
+	suite.Suite
+}
+
+func NewRefundRepository() (port.IRefundRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewRefundRepo(conn)
+	return repo, mock
+}
+
+type RefundCreateSuite struct {
+	RefundSuite
+}
+
+func (s *RefundCreateSuite) RefundCreateSuccessRepositoryMock(mock sqlmock.Sqlmock, refund domain.Refund) {
+	pgRefund := entity.NewPgRefund(refund)
+	queryString := InsertQueryString(pgRefund, "refund")
+	mock.ExpectExec(queryString).
+		WithArgs(EntityValues(pgRefund)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectedRows := sqlmock.NewRows(EntityColumns(pgRefund)).
+		AddRow(EntityValues(pgRefund)...)
+	mock.ExpectQuery(repository.RefundFindByIDQuery).WithArgs(pgRefund.ID).WillReturnRows(expectedRows)
+}
+
+func (s *RefundCreateSuite) TestCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Refund repository create success")
+	repo, mock := NewRefundRepository()
+	refund := NewRefundBuilder().Build()
+	s.RefundCreateSuccessRepositoryMock(mock, refund)
+	actual, err := repo.Create(context.Background(), refund)
+	t.Assert().Nil(err)
+	t.Assert().Equal(refund, actual)
+}
+
+func (s *RefundCreateSuite) RefundCreateFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	queryString := InsertQueryString(entity.PgRefund{}, "refund")
+	mock.ExpectExec(queryString).WillReturnError(sql.ErrConnDone)
+}
+
+func (s *RefundCreateSuite) TestCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Refund repository create failure")
+	repo, mock := NewRefundRepository()
+	s.RefundCreateFailureRepositoryMock(mock)
+	_, err := repo.Create(context.Background(), NewRefundBuilder().Build())
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestRefundCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Refund repository create", new(RefundCreateSuite))
+}
+
+type RefundApproveSuite struct {
+	RefundSuite
+}
+
+func (s *RefundApproveSuite) RefundApproveSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	refund domain.Refund, approverID domain.ID, approvedAt time.Time) {
+	pgRefund := entity.NewPgRefund(refund)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgRefund)).
+		AddRow(EntityValues(pgRefund)...)
+	mock.ExpectQuery(repository.RefundFindByIDQuery).WithArgs(refund.ID).WillReturnRows(expectedRows)
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.RefundResolveQuery).
+		WithArgs(refund.ID, entity.PgRefundApproved, approverID, approvedAt, entity.PgRefundApproving).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(repository.PaymentCountOtherCoursePaymentsQuery).
+		WithArgs(refund.UserID, refund.CourseID, refund.PaymentKey).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(repository.CourseRemoveCourseStudentQuery).
+		WithArgs(refund.UserID, refund.CourseID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(repository.CourseDeleteStudentLessonStatsQuery).
+		WithArgs(refund.UserID, refund.CourseID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(repository.CourseDeleteStudentTestStatsQuery).
+		WithArgs(refund.UserID, refund.CourseID).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(repository.PaymentDeleteQuery).
+		WithArgs(refund.PaymentKey, refund.CourseID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(repository.LedgerReverseSaleQuery).
+		WithArgs(sqlmock.AnyArg(), approvedAt, refund.UserID, refund.CourseID, refund.PaymentKey).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func (s *RefundApproveSuite) TestApprove_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Refund repository approve revokes enrollment")
+	repo, mock := NewRefundRepository()
+	refund := NewRefundBuilder().Build()
+	approverID := domain.NewID()
+	approvedAt := time.Now().UTC()
+	s.RefundApproveSuccessRepositoryMock(mock, refund, approverID, approvedAt)
+	err := repo.Approve(context.Background(), refund.ID, approverID, approvedAt)
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *RefundApproveSuite) RefundApproveRenewalRepositoryMock(mock sqlmock.Sqlmock,
+	refund domain.Refund, approverID domain.ID, approvedAt time.Time) {
+	pgRefund := entity.NewPgRefund(refund)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgRefund)).
+		AddRow(EntityValues(pgRefund)...)
+	mock.ExpectQuery(repository.RefundFindByIDQuery).WithArgs(refund.ID).WillReturnRows(expectedRows)
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.RefundResolveQuery).
+		WithArgs(refund.ID, entity.PgRefundApproved, approverID, approvedAt, entity.PgRefundApproving).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(repository.PaymentCountOtherCoursePaymentsQuery).
+		WithArgs(refund.UserID, refund.CourseID, refund.PaymentKey).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(repository.CourseRevokeCourseStudentPeriodQuery).
+		WithArgs(refund.UserID, refund.CourseID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(repository.PaymentDeleteQuery).
+		WithArgs(refund.PaymentKey, refund.CourseID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(repository.LedgerReverseSaleQuery).
+		WithArgs(sqlmock.AnyArg(), approvedAt, refund.UserID, refund.CourseID, refund.PaymentKey).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func (s *RefundApproveSuite) TestApprove_Renewal(t provider.T) {
+	t.Parallel()
+	t.Title("Refund repository approve of renewal revokes its period only")
+	repo, mock := NewRefundRepository()
+	refund := NewRefundBuilder().Build()
+	approverID := domain.NewID()
+	approvedAt := time.Now().UTC()
+	s.RefundApproveRenewalRepositoryMock(mock, refund, approverID, approvedAt)
+	err := repo.Approve(context.Background(), refund.ID, approverID, approvedAt)
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *RefundApproveSuite) RefundApproveNotEnrolledRepositoryMock(mock sqlmock.Sqlmock,
+	refund domain.Refund, approverID domain.ID, approvedAt time.Time) {
+	pgRefund := entity.NewPgRefund(refund)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgRefund)).
+		AddRow(EntityValues(pgRefund)...)
+	mock.ExpectQuery(repository.RefundFindByIDQuery).WithArgs(refund.ID).WillReturnRows(expectedRows)
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.RefundResolveQuery).
+		WithArgs(refund.ID, entity.PgRefundApproved, approverID, approvedAt, entity.PgRefundApproving).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(repository.PaymentCountOtherCoursePaymentsQuery).
+		WithArgs(refund.UserID, refund.CourseID, refund.PaymentKey).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(repository.CourseRemoveCourseStudentQuery).
+		WithArgs(refund.UserID, refund.CourseID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(repository.PaymentDeleteQuery).
+		WithArgs(refund.PaymentKey, refund.CourseID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(repository.LedgerReverseSaleQuery).
+		WithArgs(sqlmock.AnyArg(), approvedAt, refund.UserID, refund.CourseID, refund.PaymentKey).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func (s *RefundApproveSuite) TestApprove_NotEnrolled(t provider.T) {
+	t.Parallel()
+	t.Title("Refund repository approve when enrollment is already removed")
+	repo, mock := NewRefundRepository()
+	refund := NewRefundBuilder().Build()
+	approverID := domain.NewID()
+	approvedAt := time.Now().UTC()
+	s.RefundApproveNotEnrolledRepositoryMock(mock, refund, approverID, approvedAt)
+	err := repo.Approve(context.Background(), refund.ID, approverID, approvedAt)
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *RefundApproveSuite) RefundApproveFailureRepositoryMock(mock sqlmock.Sqlmock, refund domain.Refund) {
+	pgRefund := entity.NewPgRefund(refund)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgRefund)).
+		AddRow(EntityValues(pgRefund)...)
+	mock.ExpectQuery(repository.RefundFindByIDQuery).WithArgs(refund.ID).WillReturnRows(expectedRows)
+	mock.ExpectBegin()
+	mock.ExpectExec(repository.RefundResolveQuery).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(repository.PaymentCountOtherCoursePaymentsQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(repository.CourseRemoveCourseStudentQuery).
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+}
+
+func (s *RefundApproveSuite) TestApprove_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Refund repository approve is rolled back on failure")
+	repo, mock := NewRefundRepository()
+	refund := NewRefundBuilder().Build()
+	s.RefundApproveFailureRepositoryMock(mock, refund)
+	err := repo.Approve(context.Background(), refund.ID, domain.NewID(), time.Now().UTC())
+	t.Assert().ErrorIs(err, errs.ErrDeleteFailed)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func TestRefundApproveSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Refund repository approve", new(RefundApproveSuite))
+}
+
+type RefundStartApprovalSuite struct {
+	RefundSuite
+}
+
+func (s *RefundStartApprovalSuite) RefundStartApprovalSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	refundID domain.ID) {
+	mock.ExpectExec(repository.RefundUpdateStatusQuery).
+		WithArgs(refundID, entity.PgRefundRequested, entity.PgRefundApproving).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (s *RefundStartApprovalSuite) TestStartApproval_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Refund repository start approval success")
+	repo, mock := NewRefundRepository()
+	refundID := domain.NewID()
+	s.RefundStartApprovalSuccessRepositoryMock(mock, refundID)
+	err := repo.StartApproval(context.Background(), refundID)
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *RefundStartApprovalSuite) RefundStartApprovalFailureRepositoryMock(mock sqlmock.Sqlmock,
+	refundID domain.ID) {
+	mock.ExpectExec(repository.RefundUpdateStatusQuery).
+		WithArgs(refundID, entity.PgRefundRequested, entity.PgRefundApproving).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (s *RefundStartApprovalSuite) TestStartApproval_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Refund repository start approval of already rejected refund")
+	repo, mock := NewRefundRepository()
+	refundID := domain.NewID()
+	s.RefundStartApprovalFailureRepositoryMock(mock, refundID)
+	err := repo.StartApproval(context.Background(), refundID)
+	t.Assert().ErrorIs(err, errs.ErrRefundAlreadyResolved)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func TestRefundStartApprovalSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Refund repository start approval", new(RefundStartApprovalSuite))
+}
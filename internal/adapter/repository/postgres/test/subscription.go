@@ -0,0 +1,51 @@
+package test
+
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type SubscriptionPlanBuilder struct {
+	plan domain.SubscriptionPlan
+}
+
+func NewSubscriptionPlanBuilder() *SubscriptionPlanBuilder {
+	return &SubscriptionPlanBuilder{
+		plan: domain.SubscriptionPlan{
+			ID:         domain.NewID(),
+			SchoolID:   domain.NewID(),
+			Name:       "All courses",
+			Price:      990,
+			Currency:   domain.DefaultCurrency,
+			PeriodDays: 30,
+		},
+	}
+}
+
+func (b *SubscriptionPlanBuilder) Build() domain.SubscriptionPlan {
+	return b.plan
+}
+
+type SubscriptionBuilder struct {
+	subscription domain.Subscription
+}
+
+func NewSubscriptionBuilder() *SubscriptionBuilder {
+	now := time.Now().UTC()
+	return &SubscriptionBuilder{
+		subscription: domain.Subscription{
+			ID:              domain.NewID(),
+			PlanID:          domain.NewID(),
+			SchoolID:        domain.NewID(),
+			UserID:          domain.NewID(),
+			PaymentMethodID: null.StringFrom("method"),
+			StartedAt:       now,
+			ExpiresAt:       now.AddDate(0, 0, 30),
+		},
+	}
+}
+
+func (b *SubscriptionBuilder) Build() domain.Subscription {
+	return b.subscription
+}
@@ -0,0 +1,203 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+	"time"
+)
+
+type SubscriptionSuite struct {
+	suite.Suite
+}
+
+func NewSubscriptionRepository() (port.ISubscriptionRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewSubscriptionRepo(conn)
+	return repo, mock
+}
+
+type SubscriptionFindSchoolPlanSuite struct {
+	SubscriptionSuite
+}
+
+func (s *SubscriptionFindSchoolPlanSuite) SubscriptionFindSchoolPlanSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	plan domain.SubscriptionPlan) {
+	pgPlan := entity.NewPgSubscriptionPlan(plan)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgPlan)).
+		AddRow(EntityValues(pgPlan)...)
+	mock.ExpectQuery(repository.SubscriptionFindSchoolPlanQuery).WithArgs(plan.SchoolID).WillReturnRows(expectedRows)
+}
+
+func (s *SubscriptionFindSchoolPlanSuite) TestFindSchoolPlan_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Subscription repository find school plan success")
+	repo, mock := NewSubscriptionRepository()
+	plan := NewSubscriptionPlanBuilder().Build()
+	s.SubscriptionFindSchoolPlanSuccessRepositoryMock(mock, plan)
+	actual, err := repo.FindSchoolPlan(context.Background(), plan.SchoolID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(plan, actual)
+}
+
+func (s *SubscriptionFindSchoolPlanSuite) SubscriptionFindSchoolPlanFailureRepositoryMock(mock sqlmock.Sqlmock,
+	schoolID domain.ID) {
+	mock.ExpectQuery(repository.SubscriptionFindSchoolPlanQuery).WithArgs(schoolID).WillReturnError(sql.ErrNoRows)
+}
+
+func (s *SubscriptionFindSchoolPlanSuite) TestFindSchoolPlan_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Subscription repository find school plan failure")
+	repo, mock := NewSubscriptionRepository()
+	schoolID := domain.NewID()
+	s.SubscriptionFindSchoolPlanFailureRepositoryMock(mock, schoolID)
+	_, err := repo.FindSchoolPlan(context.Background(), schoolID)
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestSubscriptionFindSchoolPlanSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Subscription repository find school plan", new(SubscriptionFindSchoolPlanSuite))
+}
+
+type SubscriptionExtendSuite struct {
+	SubscriptionSuite
+}
+
+func (s *SubscriptionExtendSuite) SubscriptionExtendSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	subscription domain.Subscription, extended domain.Subscription) {
+	pgSubscription := entity.NewPgSubscription(subscription)
+	mock.ExpectExec(repository.SubscriptionExtendQuery).
+		WithArgs(pgSubscription.ID, pgSubscription.PlanID, pgSubscription.SchoolID, pgSubscription.UserID,
+			pgSubscription.PaymentMethodID, pgSubscription.StartedAt, pgSubscription.ExpiresAt, "key").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	pgExtended := entity.NewPgSubscription(extended)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgExtended)).
+		AddRow(EntityValues(pgExtended)...)
+	mock.ExpectQuery(repository.SubscriptionFindUserSubscriptionQuery).
+		WithArgs(pgSubscription.UserID, pgSubscription.SchoolID).WillReturnRows(expectedRows)
+}
+
+func (s *SubscriptionExtendSuite) TestExtend_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Subscription repository extend returns stored subscription")
+	repo, mock := NewSubscriptionRepository()
+	subscription := NewSubscriptionBuilder().Build()
+	extended := subscription
+	extended.ID = domain.NewID()
+	extended.ExpiresAt = subscription.ExpiresAt.AddDate(0, 0, 30)
+	s.SubscriptionExtendSuccessRepositoryMock(mock, subscription, extended)
+	actual, err := repo.Extend(context.Background(), subscription, "key")
+	t.Assert().Nil(err)
+	t.Assert().Equal(extended, actual)
+}
+
+func (s *SubscriptionExtendSuite) SubscriptionExtendFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(repository.SubscriptionExtendQuery).WillReturnError(errs.ErrPersistenceFailed)
+}
+
+func (s *SubscriptionExtendSuite) TestExtend_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Subscription repository extend failure")
+	repo, mock := NewSubscriptionRepository()
+	s.SubscriptionExtendFailureRepositoryMock(mock)
+	_, err := repo.Extend(context.Background(), NewSubscriptionBuilder().Build(), "key")
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestSubscriptionExtendSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Subscription repository extend", new(SubscriptionExtendSuite))
+}
+
+type SubscriptionClaimRenewableSuite struct {
+	SubscriptionSuite
+}
+
+func (s *SubscriptionClaimRenewableSuite) SubscriptionClaimRenewableSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	subscription domain.Subscription, now, until, lockedUntil time.Time) {
+	pgSubscription := entity.NewPgSubscription(subscription)
+	pgSubscription.RenewalLockedUntil = null.TimeFrom(lockedUntil)
+	expectedRows := sqlmock.NewRows(EntityColumns(pgSubscription)).
+		AddRow(EntityValues(pgSubscription)...)
+	mock.ExpectQuery(repository.SubscriptionClaimRenewableQuery).
+		WithArgs(now, until, lockedUntil).WillReturnRows(expectedRows)
+}
+
+func (s *SubscriptionClaimRenewableSuite) TestClaimRenewableSubscriptions_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Subscription repository claim renewable subscriptions success")
+	repo, mock := NewSubscriptionRepository()
+	subscription := NewSubscriptionBuilder().Build()
+	now := time.Now().UTC()
+	until, lockedUntil := now.Add(24*time.Hour), now.Add(5*time.Minute)
+	s.SubscriptionClaimRenewableSuccessRepositoryMock(mock, subscription, now, until, lockedUntil)
+	subscriptions, err := repo.ClaimRenewableSubscriptions(context.Background(), now, until, lockedUntil)
+	t.Assert().Nil(err)
+	t.Assert().Equal([]domain.Subscription{subscription}, subscriptions)
+}
+
+func (s *SubscriptionClaimRenewableSuite) SubscriptionClaimRenewableFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(repository.SubscriptionClaimRenewableQuery).WillReturnError(errs.ErrPersistenceFailed)
+}
+
+func (s *SubscriptionClaimRenewableSuite) TestClaimRenewableSubscriptions_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Subscription repository claim renewable subscriptions failure")
+	repo, mock := NewSubscriptionRepository()
+	s.SubscriptionClaimRenewableFailureRepositoryMock(mock)
+	now := time.Now().UTC()
+	_, err := repo.ClaimRenewableSubscriptions(context.Background(), now, now, now)
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestSubscriptionClaimRenewableSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Subscription repository claim renewable subscriptions",
+		new(SubscriptionClaimRenewableSuite))
+}
+
+type SubscriptionCancelSuite struct {
+	SubscriptionSuite
+}
+
+func (s *SubscriptionCancelSuite) SubscriptionCancelRepositoryMock(mock sqlmock.Sqlmock,
+	subscriptionID domain.ID, canceledAt time.Time, affected int64) {
+	mock.ExpectExec(repository.SubscriptionCancelQuery).
+		WithArgs(subscriptionID, canceledAt).
+		WillReturnResult(sqlmock.NewResult(0, affected))
+}
+
+func (s *SubscriptionCancelSuite) TestCancel_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Subscription repository cancel success")
+	repo, mock := NewSubscriptionRepository()
+	subscriptionID := domain.NewID()
+	canceledAt := time.Now().UTC()
+	s.SubscriptionCancelRepositoryMock(mock, subscriptionID, canceledAt, 1)
+	err := repo.Cancel(context.Background(), subscriptionID, canceledAt)
+	t.Assert().Nil(err)
+}
+
+func (s *SubscriptionCancelSuite) TestCancel_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Subscription repository cancel of canceled subscription")
+	repo, mock := NewSubscriptionRepository()
+	subscriptionID := domain.NewID()
+	canceledAt := time.Now().UTC()
+	s.SubscriptionCancelRepositoryMock(mock, subscriptionID, canceledAt, 0)
+	err := repo.Cancel(context.Background(), subscriptionID, canceledAt)
+	t.Assert().ErrorIs(err, errs.ErrSubscriptionNotActive)
+}
+
+func TestSubscriptionCancelSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Subscription repository cancel", new(SubscriptionCancelSuite))
+}
@@ -0,0 +1,29 @@
+package test
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type WaitlistEntryBuilder struct {
+	entry domain.WaitlistEntry
+}
+
+func NewWaitlistEntryBuilder() *WaitlistEntryBuilder {
+	return &WaitlistEntryBuilder{
+		entry: domain.WaitlistEntry{
+			CourseID: domain.NewID(),
+			UserID:   domain.NewID(),
+			JoinedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func (b *WaitlistEntryBuilder) WithCourseID(courseID domain.ID) *WaitlistEntryBuilder {
+	b.entry.CourseID = courseID
+	return b
+}
+
+func (b *WaitlistEntryBuilder) Build() domain.WaitlistEntry {
+	return b.entry
+}
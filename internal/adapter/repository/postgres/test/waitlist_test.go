@@ -0,0 +1,201 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"testing"
+	"time"
+)
+
+type WaitlistSuite struct {
+	suite.Suite
+}
+
+func NewWaitlistRepository() (port.IWaitlistRepository, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	conn := sqlx.NewDb(db, "pgx")
+	repo := repository.NewWaitlistRepo(conn)
+	return repo, mock
+}
+
+type WaitlistCreateSuite struct {
+	WaitlistSuite
+}
+
+func (s *WaitlistCreateSuite) WaitlistCreateSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	entry domain.WaitlistEntry) {
+	pgEntry := entity.NewPgWaitlistEntry(entry)
+	queryString := InsertQueryString(pgEntry, "course_waitlist")
+	mock.ExpectExec(queryString).
+		WithArgs(EntityValues(pgEntry)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func (s *WaitlistCreateSuite) TestCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Waitlist repository create success")
+	repo, mock := NewWaitlistRepository()
+	entry := NewWaitlistEntryBuilder().Build()
+	s.WaitlistCreateSuccessRepositoryMock(mock, entry)
+	err := repo.Create(context.Background(), entry)
+	t.Assert().Nil(err)
+}
+
+func (s *WaitlistCreateSuite) WaitlistCreateFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	queryString := InsertQueryString(entity.PgWaitlistEntry{}, "course_waitlist")
+	mock.ExpectExec(queryString).WillReturnError(sql.ErrConnDone)
+}
+
+func (s *WaitlistCreateSuite) TestCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Waitlist repository create failure")
+	repo, mock := NewWaitlistRepository()
+	s.WaitlistCreateFailureRepositoryMock(mock)
+	err := repo.Create(context.Background(), NewWaitlistEntryBuilder().Build())
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestWaitlistCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Waitlist repository create", new(WaitlistCreateSuite))
+}
+
+type WaitlistHoldSeatSuite struct {
+	WaitlistSuite
+}
+
+func (s *WaitlistHoldSeatSuite) WaitlistHoldSeatSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	hold domain.SeatHold) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(repository.CourseLockCapacityQuery).
+		WithArgs(hold.CourseID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(2))
+	mock.ExpectQuery(repository.CourseCountTakenSeatsQuery).
+		WithArgs(hold.CourseID, hold.UserID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(repository.SeatHoldUpsertQuery).
+		WithArgs(hold.CourseID, hold.UserID, hold.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+}
+
+func (s *WaitlistHoldSeatSuite) TestHoldSeat_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Waitlist repository hold seat success")
+	repo, mock := NewWaitlistRepository()
+	hold := domain.SeatHold{
+		CourseID:  domain.NewID(),
+		UserID:    domain.NewID(),
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}
+	s.WaitlistHoldSeatSuccessRepositoryMock(mock, hold)
+	err := repo.HoldSeat(context.Background(), hold)
+	t.Assert().Nil(err)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *WaitlistHoldSeatSuite) WaitlistHoldSeatFailureRepositoryMock(mock sqlmock.Sqlmock,
+	hold domain.SeatHold) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(repository.CourseLockCapacityQuery).
+		WithArgs(hold.CourseID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(2))
+	mock.ExpectQuery(repository.CourseCountTakenSeatsQuery).
+		WithArgs(hold.CourseID, hold.UserID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectRollback()
+}
+
+func (s *WaitlistHoldSeatSuite) TestHoldSeat_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Waitlist repository hold seat in full course")
+	repo, mock := NewWaitlistRepository()
+	hold := domain.SeatHold{
+		CourseID:  domain.NewID(),
+		UserID:    domain.NewID(),
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}
+	s.WaitlistHoldSeatFailureRepositoryMock(mock, hold)
+	err := repo.HoldSeat(context.Background(), hold)
+	t.Assert().ErrorIs(err, errs.ErrCourseIsFull)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func TestWaitlistHoldSeatSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Waitlist repository hold seat", new(WaitlistHoldSeatSuite))
+}
+
+type WaitlistOfferSeatsSuite struct {
+	WaitlistSuite
+}
+
+func (s *WaitlistOfferSeatsSuite) WaitlistOfferSeatsSuccessRepositoryMock(mock sqlmock.Sqlmock,
+	entry domain.WaitlistEntry, offeredUntil time.Time) {
+	pgEntry := entity.NewPgWaitlistEntry(entry)
+	mock.ExpectBegin()
+	mock.ExpectQuery(repository.CourseLockCapacityQuery).
+		WithArgs(entry.CourseID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(3))
+	mock.ExpectExec(repository.SeatHoldDeleteExpiredQuery).
+		WithArgs(entry.CourseID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(repository.WaitlistDeleteMissedQuery).
+		WithArgs(entry.CourseID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(repository.CourseCountTakenSeatsQuery).
+		WithArgs(entry.CourseID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(repository.WaitlistFindWaitingQuery).
+		WithArgs(entry.CourseID, int64(1)).
+		WillReturnRows(sqlmock.NewRows(EntityColumns(pgEntry)).AddRow(EntityValues(pgEntry)...))
+	mock.ExpectExec(repository.WaitlistOfferQuery).
+		WithArgs(entry.CourseID, entry.UserID, offeredUntil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(repository.SeatHoldUpsertQuery).
+		WithArgs(entry.CourseID, entry.UserID, offeredUntil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+}
+
+func (s *WaitlistOfferSeatsSuite) TestOfferSeats_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Waitlist repository offer seats success")
+	repo, mock := NewWaitlistRepository()
+	entry := NewWaitlistEntryBuilder().Build()
+	offeredUntil := time.Now().UTC().Add(24 * time.Hour)
+	s.WaitlistOfferSeatsSuccessRepositoryMock(mock, entry, offeredUntil)
+	offered, err := repo.OfferSeats(context.Background(), entry.CourseID, offeredUntil)
+	t.Assert().Nil(err)
+	entry.OfferedUntil = null.TimeFrom(offeredUntil)
+	t.Assert().Equal([]domain.WaitlistEntry{entry}, offered)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func (s *WaitlistOfferSeatsSuite) WaitlistOfferSeatsFailureRepositoryMock(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(repository.CourseLockCapacityQuery).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+}
+
+func (s *WaitlistOfferSeatsSuite) TestOfferSeats_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Waitlist repository offer seats of not existing course")
+	repo, mock := NewWaitlistRepository()
+	s.WaitlistOfferSeatsFailureRepositoryMock(mock)
+	_, err := repo.OfferSeats(context.Background(), domain.NewID(), time.Now().UTC())
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+	t.Assert().Nil(mock.ExpectationsWereMet())
+}
+
+func TestWaitlistOfferSeatsSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Waitlist repository offer seats", new(WaitlistOfferSeatsSuite))
+}
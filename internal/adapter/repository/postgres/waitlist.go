@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/jackc/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/paw1a/eschool/internal/adapter/repository/postgres/entity"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type PostgresWaitlistRepo struct {
+	db *sqlx.DB
+}
+
+func NewWaitlistRepo(db *sqlx.DB) *PostgresWaitlistRepo {
+	return &PostgresWaitlistRepo{
+		db: db,
+	}
+}
+
+const (
+	WaitlistFindCourseWaitlistQuery = "SELECT * FROM public.course_waitlist " +
+		"WHERE course_id = $1 ORDER BY joined_at"
+	WaitlistFindWaitingQuery = "SELECT * FROM public.course_waitlist " +
+		"WHERE course_id = $1 AND offered_until IS NULL ORDER BY joined_at LIMIT $2"
+	WaitlistDeleteQuery       = "DELETE FROM public.course_waitlist WHERE course_id = $1 AND user_id = $2"
+	WaitlistDeleteMissedQuery = "DELETE FROM public.course_waitlist " +
+		"WHERE course_id = $1 AND offered_until <= $2"
+	WaitlistOfferQuery = "UPDATE public.course_waitlist SET offered_until = $3 " +
+		"WHERE course_id = $1 AND user_id = $2"
+	SeatHoldUpsertQuery = "INSERT INTO public.course_seat_hold (course_id, user_id, expires_at) " +
+		"VALUES ($1, $2, $3) ON CONFLICT (course_id, user_id) " +
+		"DO UPDATE SET expires_at = GREATEST(course_seat_hold.expires_at, excluded.expires_at)"
+	SeatHoldDeleteQuery        = "DELETE FROM public.course_seat_hold WHERE course_id = $1 AND user_id = $2"
+	SeatHoldDeleteExpiredQuery = "DELETE FROM public.course_seat_hold WHERE course_id = $1 AND expires_at <= $2"
+	CourseLockCapacityQuery    = "SELECT capacity FROM public.course WHERE id = $1 FOR UPDATE"
+	CourseCountTakenSeatsQuery = "SELECT " +
+		"(SELECT count(*) FROM public.course_student WHERE course_id = $1 " +
+		"AND (expires_at IS NULL OR expires_at > $3)) + " +
+		"(SELECT count(*) FROM public.course_seat_hold " +
+		"WHERE course_id = $1 AND user_id <> $2 AND expires_at > $3)"
+)
+
+func (w *PostgresWaitlistRepo) FindCourseWaitlist(ctx context.Context,
+	courseID domain.ID) ([]domain.WaitlistEntry, error) {
+	var pgEntries []entity.PgWaitlistEntry
+	if err := w.db.SelectContext(ctx, &pgEntries, WaitlistFindCourseWaitlistQuery, courseID); err != nil {
+		return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+
+	entries := make([]domain.WaitlistEntry, len(pgEntries))
+	for i, entry := range pgEntries {
+		entries[i] = entry.ToDomain()
+	}
+	return entries, nil
+}
+
+func (w *PostgresWaitlistRepo) Create(ctx context.Context, entry domain.WaitlistEntry) error {
+	var pgEntry = entity.NewPgWaitlistEntry(entry)
+	queryString := entity.InsertQueryString(pgEntry, "course_waitlist")
+	_, err := w.db.NamedExecContext(ctx, queryString, pgEntry)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == PgUniqueViolationCode {
+				return errors.Wrap(errs.ErrDuplicate, err.Error())
+			} else {
+				return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+			}
+		} else {
+			return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return nil
+}
+
+// Delete removes user from the waitlist and releases the seat
+// if it was already offered to the user
+func (w *PostgresWaitlistRepo) Delete(ctx context.Context, courseID, userID domain.ID) error {
+	tx, err := w.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	result, err := tx.ExecContext(ctx, WaitlistDeleteQuery, courseID, userID)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+	if affected == 0 {
+		tx.Rollback()
+		return errs.ErrNotExist
+	}
+
+	_, err = tx.ExecContext(ctx, SeatHoldDeleteQuery, courseID, userID)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return nil
+}
+
+// HoldSeat reserves a seat for the user if the course has a free one,
+// courses without capacity need no reservation
+func (w *PostgresWaitlistRepo) HoldSeat(ctx context.Context, hold domain.SeatHold) error {
+	tx, err := w.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	capacity, err := lockCourseCapacity(ctx, tx, hold.CourseID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if capacity.Valid {
+		taken, err := countTakenSeats(ctx, tx, hold.CourseID, hold.UserID, time.Now().UTC())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if taken >= capacity.Int64 {
+			tx.Rollback()
+			return errs.ErrCourseIsFull
+		}
+
+		_, err = tx.ExecContext(ctx, SeatHoldUpsertQuery, hold.CourseID, hold.UserID, hold.ExpiresAt)
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return nil
+}
+
+// OfferSeats drops expired holds and missed offers, then holds every free seat
+// for the earliest waiting users until offeredUntil and returns offered entries
+func (w *PostgresWaitlistRepo) OfferSeats(ctx context.Context, courseID domain.ID,
+	offeredUntil time.Time) ([]domain.WaitlistEntry, error) {
+	tx, err := w.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	capacity, err := lockCourseCapacity(ctx, tx, courseID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if !capacity.Valid {
+		tx.Rollback()
+		return nil, nil
+	}
+
+	now := time.Now().UTC()
+	_, err = tx.ExecContext(ctx, SeatHoldDeleteExpiredQuery, courseID, now)
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	_, err = tx.ExecContext(ctx, WaitlistDeleteMissedQuery, courseID, now)
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.Wrap(errs.ErrDeleteFailed, err.Error())
+	}
+
+	taken, err := countTakenSeats(ctx, tx, courseID, domain.ID(uuid.Nil.String()), now)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var pgEntries []entity.PgWaitlistEntry
+	if free := capacity.Int64 - taken; free > 0 {
+		err = tx.SelectContext(ctx, &pgEntries, WaitlistFindWaitingQuery, courseID, free)
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+
+	entries := make([]domain.WaitlistEntry, len(pgEntries))
+	for i, pgEntry := range pgEntries {
+		entry := pgEntry.ToDomain()
+		_, err = tx.ExecContext(ctx, WaitlistOfferQuery, entry.CourseID, entry.UserID, offeredUntil)
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.Wrap(errs.ErrUpdateFailed, err.Error())
+		}
+
+		_, err = tx.ExecContext(ctx, SeatHoldUpsertQuery, entry.CourseID, entry.UserID, offeredUntil)
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+
+		entry.OfferedUntil = null.TimeFrom(offeredUntil)
+		entries[i] = entry
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return nil, errors.Wrap(errs.ErrTransactionError, err.Error())
+	}
+
+	return entries, nil
+}
+
+// lockCourseCapacity locks course row, so seats of the course
+// are counted and taken by one transaction at a time
+func lockCourseCapacity(ctx context.Context, tx *sqlx.Tx, courseID domain.ID) (null.Int, error) {
+	var capacity null.Int
+	if err := tx.GetContext(ctx, &capacity, CourseLockCapacityQuery, courseID); err != nil {
+		if err == sql.ErrNoRows {
+			return null.Int{}, errors.Wrap(errs.ErrNotExist, err.Error())
+		} else {
+			return null.Int{}, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+		}
+	}
+	return capacity, nil
+}
+
+// countTakenSeats counts students and active seat holds of other users
+func countTakenSeats(ctx context.Context, tx *sqlx.Tx, courseID, userID domain.ID,
+	now time.Time) (int64, error) {
+	var taken int64
+	if err := tx.GetContext(ctx, &taken, CourseCountTakenSeatsQuery, courseID, userID, now); err != nil {
+		return 0, errors.Wrap(errs.ErrPersistenceFailed, err.Error())
+	}
+	return taken, nil
+}
@@ -6,9 +6,11 @@ import (
 	"github.com/paw1a/eschool/internal/core/domain"
 	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/pkg/errors"
+	"io"
 	"mime"
 	"net/url"
 	"path/filepath"
+	"strings"
 )
 
 type Config struct {
@@ -45,3 +47,59 @@ func (m *MinioObjectStorage) SaveFile(ctx context.Context, file domain.File) (do
 	}
 	return domain.Url(fileUrl.String()), nil
 }
+
+func (m *MinioObjectStorage) CopyFile(ctx context.Context, fileUrl domain.Url,
+	path, name string) (domain.Url, error) {
+	objectName, err := m.objectName(fileUrl)
+	if err != nil {
+		return "", errors.Wrap(errs.ErrCopyFileError, err.Error())
+	}
+
+	minioFilename := filepath.Join(path, name)
+	_, err = m.minioClient.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: m.config.BucketName, Object: minioFilename},
+		minio.CopySrcOptions{Bucket: m.config.BucketName, Object: objectName})
+	if err != nil {
+		return "", errors.Wrap(errs.ErrCopyFileError, err.Error())
+	}
+	copyUrl := url.URL{
+		Scheme: "http",
+		Host:   m.config.Endpoint,
+		Path:   filepath.Join(m.config.BucketName, minioFilename),
+	}
+	return domain.Url(copyUrl.String()), nil
+}
+
+func (m *MinioObjectStorage) LoadFile(ctx context.Context, fileUrl domain.Url) (io.ReadCloser, error) {
+	objectName, err := m.objectName(fileUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := m.minioClient.GetObject(ctx, m.config.BucketName, objectName,
+		minio.GetObjectOptions{})
+	if err != nil {
+		return nil, errors.Wrap(errs.ErrLoadFileError, err.Error())
+	}
+	// object is requested lazily, stat reports missing object right away
+	if _, err = object.Stat(); err != nil {
+		object.Close()
+		return nil, errors.Wrap(errs.ErrLoadFileError, err.Error())
+	}
+	return object, nil
+}
+
+// objectName returns the name of the object saved by this storage,
+// saved file url is the endpoint and bucket name followed by the object name
+func (m *MinioObjectStorage) objectName(fileUrl domain.Url) (string, error) {
+	parsedUrl, err := url.Parse(fileUrl.String())
+	if err != nil {
+		return "", errors.Wrap(errs.ErrFileNotStored, err.Error())
+	}
+
+	bucketPrefix := "/" + m.config.BucketName + "/"
+	if parsedUrl.Host != m.config.Endpoint || !strings.HasPrefix(parsedUrl.Path, bucketPrefix) {
+		return "", errors.Wrap(errs.ErrFileNotStored, "file is not in the bucket")
+	}
+	return strings.TrimPrefix(parsedUrl.Path, bucketPrefix), nil
+}
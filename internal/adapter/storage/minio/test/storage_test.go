@@ -5,6 +5,7 @@ import (
 	"context"
 	storage "github.com/paw1a/eschool/internal/adapter/storage/minio"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/stretchr/testify/require"
 	"io"
 	"net/http"
@@ -131,4 +132,73 @@ func TestObjectStorage(t *testing.T) {
 		}
 		require.Equal(t, reflect.DeepEqual(data, savedData), true)
 	})
+
+	t.Run("test copy markdown file", func(t *testing.T) {
+		testFilename := "test.md"
+		data, err := os.ReadFile(filepath.Join(filesPath, testFilename))
+		if err != nil {
+			t.Errorf("failed to read file %s: %s", testFilename, err)
+		}
+
+		fileUrl, err := store.SaveFile(ctx, domain.File{
+			Name:   testFilename,
+			Path:   "course/source",
+			Reader: bytes.NewReader(data),
+		})
+		if err != nil {
+			t.Errorf("failed to save file to minio: %v", err)
+		}
+
+		copyUrl, err := store.CopyFile(ctx, fileUrl, "course/copy", testFilename)
+		if err != nil {
+			t.Errorf("failed to copy file in minio: %v", err)
+		}
+		require.NotEqual(t, fileUrl, copyUrl)
+
+		resp, err := http.Get(copyUrl.String())
+		if err != nil {
+			t.Errorf("failed to download copied file: %v", err)
+		}
+		defer resp.Body.Close()
+
+		copiedData, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Errorf("failed to read response data: %s", err)
+		}
+		require.Equal(t, reflect.DeepEqual(data, copiedData), true)
+	})
+
+	t.Run("test load markdown file", func(t *testing.T) {
+		testFilename := "test.md"
+		data, err := os.ReadFile(filepath.Join(filesPath, testFilename))
+		if err != nil {
+			t.Errorf("failed to read file %s: %s", testFilename, err)
+		}
+
+		fileUrl, err := store.SaveFile(ctx, domain.File{
+			Name:   testFilename,
+			Path:   "course/load",
+			Reader: bytes.NewReader(data),
+		})
+		if err != nil {
+			t.Errorf("failed to save file to minio: %v", err)
+		}
+
+		reader, err := store.LoadFile(ctx, fileUrl)
+		if err != nil {
+			t.Errorf("failed to load file from minio: %v", err)
+		}
+		defer reader.Close()
+
+		loadedData, err := io.ReadAll(reader)
+		if err != nil {
+			t.Errorf("failed to read loaded file: %s", err)
+		}
+		require.Equal(t, reflect.DeepEqual(data, loadedData), true)
+	})
+
+	t.Run("test load external file", func(t *testing.T) {
+		_, err := store.LoadFile(ctx, "https://example.com/image.png")
+		require.ErrorIs(t, err, errs.ErrFileNotStored)
+	})
 }
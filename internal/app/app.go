@@ -1,15 +1,25 @@
 package app
 
 import (
+	goredis "github.com/go-redis/redis/v7"
 	sessionStorage "github.com/paw1a/eschool/internal/adapter/auth/adapter/storage/redis"
 	"github.com/paw1a/eschool/internal/adapter/auth/jwt"
+	"github.com/paw1a/eschool/internal/adapter/auth/oidc"
 	authPort "github.com/paw1a/eschool/internal/adapter/auth/port"
+	"github.com/paw1a/eschool/internal/adapter/auth/totp"
 	"github.com/paw1a/eschool/internal/adapter/delivery/console"
 	v1 "github.com/paw1a/eschool/internal/adapter/delivery/http/v1"
+	"github.com/paw1a/eschool/internal/adapter/mailer/outbox"
+	"github.com/paw1a/eschool/internal/adapter/mailer/smtp"
+	"github.com/paw1a/eschool/internal/adapter/payment/acquirer"
 	"github.com/paw1a/eschool/internal/adapter/payment/yoomoney"
+	"github.com/paw1a/eschool/internal/adapter/ratelimit"
+	memoryLimiter "github.com/paw1a/eschool/internal/adapter/ratelimit/memory"
+	redisLimiter "github.com/paw1a/eschool/internal/adapter/ratelimit/redis"
 	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
 	storage "github.com/paw1a/eschool/internal/adapter/storage/minio"
 	"github.com/paw1a/eschool/internal/app/config"
+	"github.com/paw1a/eschool/internal/app/scheduler"
 	"github.com/paw1a/eschool/internal/app/server"
 	"github.com/paw1a/eschool/internal/core/port"
 	"github.com/paw1a/eschool/internal/core/service"
@@ -38,6 +48,7 @@ func RunWeb() {
 		fx.Provide(
 			server.NewServer,
 			server.NewGinRouter,
+			scheduler.NewScheduler,
 			v1.NewHandler,
 			postgres.NewPostgresDB,
 			redis.NewClient,
@@ -67,6 +78,66 @@ func RunWeb() {
 				repository.NewStatRepo,
 				fx.As(new(port.IStatRepository)),
 			),
+			fx.Annotate(
+				repository.NewEmailTokenRepo,
+				fx.As(new(port.IEmailTokenRepository)),
+			),
+			fx.Annotate(
+				repository.NewMfaRepo,
+				fx.As(new(port.IMfaRepository)),
+			),
+			fx.Annotate(
+				repository.NewExternalIdentityRepo,
+				fx.As(new(port.IExternalIdentityRepository)),
+			),
+			fx.Annotate(
+				repository.NewApiKeyRepo,
+				fx.As(new(port.IApiKeyRepository)),
+			),
+			fx.Annotate(
+				repository.NewPromoCodeRepo,
+				fx.As(new(port.IPromoCodeRepository)),
+			),
+			fx.Annotate(
+				repository.NewPaymentRepo,
+				fx.As(new(port.IPaymentRepository)),
+			),
+			fx.Annotate(
+				repository.NewRefundRepo,
+				fx.As(new(port.IRefundRepository)),
+			),
+			fx.Annotate(
+				repository.NewWaitlistRepo,
+				fx.As(new(port.IWaitlistRepository)),
+			),
+			fx.Annotate(
+				repository.NewBundleRepo,
+				fx.As(new(port.IBundleRepository)),
+			),
+			fx.Annotate(
+				repository.NewLearningPathRepo,
+				fx.As(new(port.ILearningPathRepository)),
+			),
+			fx.Annotate(
+				repository.NewCatalogRepo,
+				fx.As(new(port.ICatalogRepository)),
+			),
+			fx.Annotate(
+				repository.NewLedgerRepo,
+				fx.As(new(port.ILedgerRepository)),
+			),
+			fx.Annotate(
+				repository.NewGiftRepo,
+				fx.As(new(port.IGiftRepository)),
+			),
+			fx.Annotate(
+				repository.NewSubscriptionRepo,
+				fx.As(new(port.ISubscriptionRepository)),
+			),
+			fx.Annotate(
+				repository.NewJobRepo,
+				fx.As(new(port.IJobRepository)),
+			),
 			fx.Annotate(
 				storage.NewObjectStorage,
 				fx.As(new(port.IObjectStorage)),
@@ -80,9 +151,20 @@ func RunWeb() {
 				fx.As(new(authPort.ISessionStorage)),
 			),
 			fx.Annotate(
-				yoomoney.NewPaymentGateway,
-				fx.As(new(port.IPaymentGateway)),
+				totp.NewTotpProvider,
+				fx.As(new(port.IOtpProvider)),
+			),
+			fx.Annotate(
+				sessionStorage.NewAuthRequestStorage,
+				fx.As(new(authPort.IAuthRequestStorage)),
+			),
+			fx.Annotate(
+				oidc.NewOidcProvider,
+				fx.As(new(port.IIdentityProvider)),
 			),
+			newPaymentGateway,
+			newMailer,
+			newRateLimiter,
 			// services
 			fx.Annotate(
 				service.NewUserService,
@@ -120,10 +202,67 @@ func RunWeb() {
 				service.NewAuthTokenService,
 				fx.As(new(port.IAuthTokenService)),
 			),
+			fx.Annotate(
+				service.NewApiKeyService,
+				fx.As(new(port.IApiKeyService)),
+			),
+			fx.Annotate(
+				service.NewPromoCodeService,
+				fx.As(new(port.IPromoCodeService)),
+			),
+			fx.Annotate(
+				service.NewRefundService,
+				fx.As(new(port.IRefundService)),
+			),
+			fx.Annotate(
+				service.NewWaitlistService,
+				fx.As(new(port.IWaitlistService)),
+				fx.As(new(port.ISeatListener)),
+			),
+			fx.Annotate(
+				service.NewBundleService,
+				fx.As(new(port.IBundleService)),
+			),
+			fx.Annotate(
+				service.NewLearningPathService,
+				fx.As(new(port.ILearningPathService)),
+			),
+			fx.Annotate(
+				service.NewCatalogService,
+				fx.As(new(port.ICatalogService)),
+			),
+			fx.Annotate(
+				service.NewLedgerService,
+				fx.As(new(port.ILedgerService)),
+			),
+			fx.Annotate(
+				service.NewGiftService,
+				fx.As(new(port.IGiftService)),
+			),
+			fx.Annotate(
+				service.NewEnrollmentService,
+				fx.As(new(port.IEnrollmentService)),
+			),
+			fx.Annotate(
+				service.NewSubscriptionService,
+				fx.As(new(port.ISubscriptionService)),
+			),
+			fx.Annotate(
+				service.NewJobService,
+				fx.As(new(port.IJobService)),
+			),
+			fx.Annotate(
+				service.NewCourseArchiveService,
+				fx.As(new(port.ICourseArchiveService)),
+			),
+			fx.Annotate(
+				service.NewQuizImportService,
+				fx.As(new(port.IQuizImportService)),
+			),
 		),
-		fx.Supply(cfg, &cfg.Redis, &cfg.Postgres, &cfg.JWT,
-			&cfg.Minio, &cfg.Yoomoney, &cfg.Web, logger),
-		fx.Invoke(func(*http.Server) {}),
+		fx.Supply(cfg, &cfg.Redis, &cfg.Postgres, &cfg.JWT, &cfg.Totp, &cfg.Oidc,
+			&cfg.Minio, &cfg.Payment, &cfg.Mailer, &cfg.RateLimit, &cfg.Web, &cfg.Ledger, &cfg.Scheduler, logger),
+		fx.Invoke(func(*http.Server, *scheduler.Scheduler) {}),
 		fx.NopLogger,
 	).Run()
 }
@@ -172,6 +311,66 @@ func RunConsole() {
 				repository.NewStatRepo,
 				fx.As(new(port.IStatRepository)),
 			),
+			fx.Annotate(
+				repository.NewEmailTokenRepo,
+				fx.As(new(port.IEmailTokenRepository)),
+			),
+			fx.Annotate(
+				repository.NewMfaRepo,
+				fx.As(new(port.IMfaRepository)),
+			),
+			fx.Annotate(
+				repository.NewExternalIdentityRepo,
+				fx.As(new(port.IExternalIdentityRepository)),
+			),
+			fx.Annotate(
+				repository.NewApiKeyRepo,
+				fx.As(new(port.IApiKeyRepository)),
+			),
+			fx.Annotate(
+				repository.NewPromoCodeRepo,
+				fx.As(new(port.IPromoCodeRepository)),
+			),
+			fx.Annotate(
+				repository.NewPaymentRepo,
+				fx.As(new(port.IPaymentRepository)),
+			),
+			fx.Annotate(
+				repository.NewRefundRepo,
+				fx.As(new(port.IRefundRepository)),
+			),
+			fx.Annotate(
+				repository.NewWaitlistRepo,
+				fx.As(new(port.IWaitlistRepository)),
+			),
+			fx.Annotate(
+				repository.NewBundleRepo,
+				fx.As(new(port.IBundleRepository)),
+			),
+			fx.Annotate(
+				repository.NewLearningPathRepo,
+				fx.As(new(port.ILearningPathRepository)),
+			),
+			fx.Annotate(
+				repository.NewCatalogRepo,
+				fx.As(new(port.ICatalogRepository)),
+			),
+			fx.Annotate(
+				repository.NewLedgerRepo,
+				fx.As(new(port.ILedgerRepository)),
+			),
+			fx.Annotate(
+				repository.NewGiftRepo,
+				fx.As(new(port.IGiftRepository)),
+			),
+			fx.Annotate(
+				repository.NewSubscriptionRepo,
+				fx.As(new(port.ISubscriptionRepository)),
+			),
+			fx.Annotate(
+				repository.NewJobRepo,
+				fx.As(new(port.IJobRepository)),
+			),
 			fx.Annotate(
 				storage.NewObjectStorage,
 				fx.As(new(port.IObjectStorage)),
@@ -185,9 +384,20 @@ func RunConsole() {
 				fx.As(new(authPort.ISessionStorage)),
 			),
 			fx.Annotate(
-				yoomoney.NewPaymentGateway,
-				fx.As(new(port.IPaymentGateway)),
+				totp.NewTotpProvider,
+				fx.As(new(port.IOtpProvider)),
 			),
+			fx.Annotate(
+				sessionStorage.NewAuthRequestStorage,
+				fx.As(new(authPort.IAuthRequestStorage)),
+			),
+			fx.Annotate(
+				oidc.NewOidcProvider,
+				fx.As(new(port.IIdentityProvider)),
+			),
+			newPaymentGateway,
+			newMailer,
+			newRateLimiter,
 			// services
 			fx.Annotate(
 				service.NewUserService,
@@ -225,9 +435,94 @@ func RunConsole() {
 				service.NewAuthTokenService,
 				fx.As(new(port.IAuthTokenService)),
 			),
+			fx.Annotate(
+				service.NewApiKeyService,
+				fx.As(new(port.IApiKeyService)),
+			),
+			fx.Annotate(
+				service.NewPromoCodeService,
+				fx.As(new(port.IPromoCodeService)),
+			),
+			fx.Annotate(
+				service.NewRefundService,
+				fx.As(new(port.IRefundService)),
+			),
+			fx.Annotate(
+				service.NewWaitlistService,
+				fx.As(new(port.IWaitlistService)),
+				fx.As(new(port.ISeatListener)),
+			),
+			fx.Annotate(
+				service.NewBundleService,
+				fx.As(new(port.IBundleService)),
+			),
+			fx.Annotate(
+				service.NewLearningPathService,
+				fx.As(new(port.ILearningPathService)),
+			),
+			fx.Annotate(
+				service.NewCatalogService,
+				fx.As(new(port.ICatalogService)),
+			),
+			fx.Annotate(
+				service.NewLedgerService,
+				fx.As(new(port.ILedgerService)),
+			),
+			fx.Annotate(
+				service.NewGiftService,
+				fx.As(new(port.IGiftService)),
+			),
+			fx.Annotate(
+				service.NewEnrollmentService,
+				fx.As(new(port.IEnrollmentService)),
+			),
+			fx.Annotate(
+				service.NewSubscriptionService,
+				fx.As(new(port.ISubscriptionService)),
+			),
+			fx.Annotate(
+				service.NewJobService,
+				fx.As(new(port.IJobService)),
+			),
+			fx.Annotate(
+				service.NewCourseArchiveService,
+				fx.As(new(port.ICourseArchiveService)),
+			),
+			fx.Annotate(
+				service.NewQuizImportService,
+				fx.As(new(port.IQuizImportService)),
+			),
 		),
-		fx.Supply(cfg, &cfg.Redis, &cfg.Postgres, &cfg.JWT, &cfg.Minio, &cfg.Yoomoney, logger),
+		fx.Supply(cfg, &cfg.Redis, &cfg.Postgres, &cfg.JWT, &cfg.Totp, &cfg.Oidc, &cfg.Minio, &cfg.Payment,
+			&cfg.Mailer, &cfg.RateLimit, &cfg.Ledger, logger),
 		fx.Invoke(func(*console.Console) {}),
 		fx.NopLogger,
 	).Run()
 }
+
+func newPaymentGateway(cfg *config.PaymentConfig) port.IPaymentGateway {
+	switch cfg.Driver {
+	case "acquirer":
+		return acquirer.NewPaymentGateway(&cfg.Acquirer)
+	default:
+		return yoomoney.NewPaymentGateway(&cfg.Yoomoney)
+	}
+}
+
+func newMailer(cfg *config.MailerConfig) port.IMailer {
+	switch cfg.Driver {
+	case "smtp":
+		return smtp.NewMailer(&cfg.Smtp)
+	default:
+		return outbox.NewMailer(&cfg.Outbox)
+	}
+}
+
+func newRateLimiter(cfg *ratelimit.Config, redisClient *goredis.Client) port.IRateLimiter {
+	switch cfg.Driver {
+	case "memory":
+		return memoryLimiter.NewRateLimiter(cfg)
+	default:
+		return redisLimiter.NewRateLimiter(cfg, redisClient)
+	}
+}
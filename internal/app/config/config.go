@@ -2,9 +2,17 @@ package config
 
 import (
 	"github.com/paw1a/eschool/internal/adapter/auth/jwt"
+	"github.com/paw1a/eschool/internal/adapter/auth/oidc"
+	"github.com/paw1a/eschool/internal/adapter/auth/totp"
 	v1 "github.com/paw1a/eschool/internal/adapter/delivery/http/v1"
+	"github.com/paw1a/eschool/internal/adapter/mailer/outbox"
+	"github.com/paw1a/eschool/internal/adapter/mailer/smtp"
+	"github.com/paw1a/eschool/internal/adapter/payment/acquirer"
 	"github.com/paw1a/eschool/internal/adapter/payment/yoomoney"
+	"github.com/paw1a/eschool/internal/adapter/ratelimit"
 	storage "github.com/paw1a/eschool/internal/adapter/storage/minio"
+	"github.com/paw1a/eschool/internal/app/scheduler"
+	"github.com/paw1a/eschool/internal/core/service"
 	"github.com/paw1a/eschool/pkg/database/postgres"
 	"github.com/paw1a/eschool/pkg/database/redis"
 	"github.com/paw1a/eschool/pkg/logging"
@@ -14,13 +22,31 @@ import (
 )
 
 type Config struct {
-	Logging  logging.Config
-	Web      v1.Config
-	Postgres postgres.Config
-	JWT      jwt.Config
-	Redis    redis.Config
-	Minio    storage.Config
+	Logging   logging.Config
+	Web       v1.Config
+	Postgres  postgres.Config
+	JWT       jwt.Config
+	Totp      totp.Config
+	Oidc      oidc.Config
+	Redis     redis.Config
+	Minio     storage.Config
+	Payment   PaymentConfig
+	Mailer    MailerConfig
+	RateLimit ratelimit.Config
+	Ledger    service.LedgerConfig
+	Scheduler scheduler.Config
+}
+
+type MailerConfig struct {
+	Driver string // smtp, outbox
+	Smtp   smtp.Config
+	Outbox outbox.Config
+}
+
+type PaymentConfig struct {
+	Driver   string // yoomoney, acquirer
 	Yoomoney yoomoney.Config
+	Acquirer acquirer.Config
 }
 
 var instance *Config
@@ -54,6 +80,7 @@ func bindEnvConfig() error {
 	bindings := make(map[string]string)
 	bindings["web.host"] = "HOST"
 	bindings["web.port"] = "PORT"
+	bindings["web.adminToken"] = "ADMIN_TOKEN"
 	bindings["jwt.secret"] = "JWT_SECRET"
 	bindings["postgres.database"] = "DB_NAME"
 	bindings["postgres.user"] = "DB_USER"
@@ -65,10 +92,26 @@ func bindEnvConfig() error {
 	bindings["minio.user"] = "MINIO_ROOT_USER"
 	bindings["minio.password"] = "MINIO_ROOT_PASSWORD"
 	bindings["minio.bucketName"] = "MINIO_BUCKET_NAME"
-	bindings["yoomoney.scheme"] = "PAYMENT_SCHEME"
-	bindings["yoomoney.host"] = "PAYMENT_HOST"
-	bindings["yoomoney.path"] = "PAYMENT_PATH"
-	bindings["yoomoney.wallet"] = "PAYMENT_WALLET"
+	bindings["payment.driver"] = "PAYMENT_DRIVER"
+	bindings["payment.yoomoney.scheme"] = "PAYMENT_SCHEME"
+	bindings["payment.yoomoney.host"] = "PAYMENT_HOST"
+	bindings["payment.yoomoney.path"] = "PAYMENT_PATH"
+	bindings["payment.yoomoney.wallet"] = "PAYMENT_WALLET"
+	bindings["payment.acquirer.baseUrl"] = "ACQUIRER_BASE_URL"
+	bindings["payment.acquirer.shopId"] = "ACQUIRER_SHOP_ID"
+	bindings["payment.acquirer.secretKey"] = "ACQUIRER_SECRET_KEY"
+	bindings["payment.acquirer.returnUrl"] = "ACQUIRER_RETURN_URL"
+	bindings["mailer.driver"] = "MAILER_DRIVER"
+	bindings["mailer.smtp.host"] = "SMTP_HOST"
+	bindings["mailer.smtp.port"] = "SMTP_PORT"
+	bindings["mailer.smtp.username"] = "SMTP_USERNAME"
+	bindings["mailer.smtp.password"] = "SMTP_PASSWORD"
+	bindings["mailer.smtp.from"] = "SMTP_FROM"
+	bindings["rateLimit.driver"] = "RATE_LIMIT_DRIVER"
+	bindings["ledger.commissionPercent"] = "LEDGER_COMMISSION_PERCENT"
+	bindings["scheduler.interval"] = "SCHEDULER_INTERVAL"
+	bindings["oidc.providers.corp.issuer"] = "OIDC_CORP_ISSUER"
+	bindings["oidc.providers.corp.clientSecret"] = "OIDC_CORP_CLIENT_SECRET"
 
 	for name, binding := range bindings {
 		if err := viper.BindEnv(name, binding); err != nil {
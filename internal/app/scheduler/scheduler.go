@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"time"
+)
+
+// jobBatchSize limits jobs claimed by one scheduler tick
+const jobBatchSize = 50
+
+type Config struct {
+	Interval int64 // seconds
+}
+
+func (c *Config) IntervalDuration() time.Duration {
+	return time.Duration(c.Interval) * time.Second
+}
+
+// Scheduler runs periodic background jobs of the web application
+type Scheduler struct {
+	config              *Config
+	enrollmentService   port.IEnrollmentService
+	subscriptionService port.ISubscriptionService
+	jobService          port.IJobService
+	courseService       port.ICourseService
+	logger              *zap.Logger
+	cancel              context.CancelFunc
+	done                chan struct{}
+}
+
+func NewScheduler(lc fx.Lifecycle, config *Config, enrollmentService port.IEnrollmentService,
+	subscriptionService port.ISubscriptionService, jobService port.IJobService,
+	courseService port.ICourseService, logger *zap.Logger) *Scheduler {
+	scheduler := &Scheduler{
+		config:              config,
+		enrollmentService:   enrollmentService,
+		subscriptionService: subscriptionService,
+		jobService:          jobService,
+		courseService:       courseService,
+		logger:              logger,
+		done:                make(chan struct{}),
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			runCtx, cancel := context.WithCancel(context.Background())
+			scheduler.cancel = cancel
+			go scheduler.run(runCtx)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			scheduler.cancel()
+			select {
+			case <-scheduler.done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+	return scheduler
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer close(s.done)
+	s.logger.Info("scheduler started", zap.Duration("interval", s.config.IntervalDuration()))
+
+	ticker := time.NewTicker(s.config.IntervalDuration())
+	defer ticker.Stop()
+	for {
+		s.runJobs(ctx)
+		select {
+		case <-ctx.Done():
+			s.logger.Info("scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runJobs logs job failures only, the next tick retries them
+func (s *Scheduler) runJobs(ctx context.Context) {
+	if err := s.enrollmentService.RemindExpiringEnrollments(ctx); err != nil {
+		s.logger.Error("failed to run enrollment reminder job", zap.Error(err))
+	}
+	if err := s.subscriptionService.RenewSubscriptions(ctx); err != nil {
+		s.logger.Error("failed to run subscription renewal job", zap.Error(err))
+	}
+	s.runScheduledJobs(ctx)
+}
+
+// runScheduledJobs runs persistent jobs which are due,
+// failed job is retried by the job service later
+func (s *Scheduler) runScheduledJobs(ctx context.Context) {
+	jobs, err := s.jobService.ClaimDueJobs(ctx, jobBatchSize)
+	if err != nil {
+		s.logger.Error("failed to claim scheduled jobs", zap.Error(err))
+		return
+	}
+
+	for _, job := range jobs {
+		err = s.runJob(ctx, job)
+		if err != nil {
+			err = s.jobService.FailJob(ctx, job, err)
+		} else {
+			err = s.jobService.CompleteJob(ctx, job)
+		}
+		if err != nil {
+			s.logger.Error("failed to finish scheduled job", zap.Error(err),
+				zap.String("jobID", job.ID.String()))
+		}
+	}
+}
+
+func (s *Scheduler) runJob(ctx context.Context, job domain.Job) error {
+	switch job.Kind {
+	case domain.JobPublishCourse:
+		return s.courseService.PublishScheduledCourse(ctx, job)
+	default:
+		return fmt.Errorf("unknown job kind %q", job.Kind)
+	}
+}
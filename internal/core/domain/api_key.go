@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"github.com/guregu/null"
+	"time"
+)
+
+type ApiKeyScope string
+
+const (
+	ApiKeyReadScope        ApiKeyScope = "read"
+	ApiKeyCourseWriteScope ApiKeyScope = "course_write"
+)
+
+func (s ApiKeyScope) Valid() bool {
+	return s == ApiKeyReadScope || s == ApiKeyCourseWriteScope
+}
+
+type ApiKey struct {
+	ID         ID
+	UserID     ID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []ApiKeyScope
+	CreatedAt  time.Time
+	LastUsedAt null.Time
+	RevokedAt  null.Time
+}
+
+func (k ApiKey) Revoked() bool {
+	return k.RevokedAt.Valid
+}
+
+// HasScope reports whether the key grants the scope, course write
+// keys are allowed to read as well
+func (k ApiKey) HasScope(scope ApiKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == scope || (s == ApiKeyCourseWriteScope && scope == ApiKeyReadScope) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type Token string
 
 func (t Token) String() string {
@@ -9,8 +11,54 @@ func (t Token) String() string {
 type AuthDetails struct {
 	AccessToken  Token
 	RefreshToken Token
+	MfaToken     Token
+}
+
+func (d AuthDetails) MfaRequired() bool {
+	return d.MfaToken != ""
 }
 
 type AuthPayload struct {
 	UserID ID
 }
+
+type EmailTokenPurpose string
+
+const (
+	EmailVerificationPurpose EmailTokenPurpose = "email_verification"
+	PasswordResetPurpose     EmailTokenPurpose = "password_reset"
+)
+
+type EmailToken struct {
+	ID        ID
+	UserID    ID
+	Purpose   EmailTokenPurpose
+	ExpiresAt time.Time
+}
+
+type MfaSettings struct {
+	UserID  ID
+	Secret  string
+	Enabled bool
+}
+
+type MfaEnrollment struct {
+	ProvisioningUri string
+	RecoveryCodes   []string
+}
+
+type ExternalProfile struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Surname       string
+}
+
+type ExternalIdentity struct {
+	UserID   ID
+	Provider string
+	Subject  string
+	Email    string
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"github.com/paw1a/eschool/internal/core/errs"
+	"time"
+)
+
+type BundleStatus int
+
+const (
+	BundleDraft BundleStatus = iota
+	BundlePublished
+)
+
+// Bundle is priced in minor units of the currency of its courses
+type Bundle struct {
+	ID          ID
+	SchoolID    ID
+	Name        string
+	Description string
+	Price       int64
+	Status      BundleStatus
+	CreatedAt   time.Time
+}
+
+// Shares splits the bundle price between member courses in proportion to
+// their own prices, rounding remainder goes to the last course
+func (b Bundle) Shares(courses []Course) []int64 {
+	shares := make([]int64, len(courses))
+	if len(courses) == 0 {
+		return shares
+	}
+
+	var total int64
+	for _, course := range courses {
+		total += course.Price.Amount
+	}
+
+	var allocated int64
+	for i, course := range courses[:len(courses)-1] {
+		if total > 0 {
+			shares[i] = b.Price * course.Price.Amount / total
+		} else {
+			shares[i] = b.Price / int64(len(courses))
+		}
+		allocated += shares[i]
+	}
+	shares[len(courses)-1] = b.Price - allocated
+	return shares
+}
+
+// CoursesCurrency returns the currency shared by the bundle courses
+func CoursesCurrency(courses []Course) (string, error) {
+	if len(courses) == 0 {
+		return DefaultCurrency, nil
+	}
+
+	currency := courses[0].Price.Currency
+	for _, course := range courses[1:] {
+		if course.Price.Currency != currency {
+			return "", errs.ErrBundleMixedCurrencies
+		}
+	}
+	return currency, nil
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"github.com/paw1a/eschool/internal/core/errs"
+	"strings"
+	"time"
+)
+
+// Category is a node of the platform category tree, root categories have no ParentID
+type Category struct {
+	ID        ID
+	ParentID  ID
+	Name      string
+	CreatedAt time.Time
+}
+
+// CourseCategory assigns the course to the category
+type CourseCategory struct {
+	CourseID   ID
+	CategoryID ID
+}
+
+// CategoryNode counts catalog courses of the category and its subcategories,
+// course assigned to several nodes of the subtree is counted once
+type CategoryNode struct {
+	Category
+	CourseCount int
+	Children    []CategoryNode
+}
+
+// NewCategoryTree builds the category tree from the flat list,
+// categories keep their order among siblings
+func NewCategoryTree(categories []Category, assignments []CourseCategory) []CategoryNode {
+	children := make(map[ID][]Category)
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category)
+	}
+
+	courses := make(map[ID][]ID)
+	for _, assignment := range assignments {
+		courses[assignment.CategoryID] = append(courses[assignment.CategoryID], assignment.CourseID)
+	}
+
+	var build func(parentID ID) ([]CategoryNode, map[ID]bool)
+	build = func(parentID ID) ([]CategoryNode, map[ID]bool) {
+		nodes := make([]CategoryNode, 0, len(children[parentID]))
+		subtree := make(map[ID]bool)
+		for _, category := range children[parentID] {
+			nodeChildren, nodeCourses := build(category.ID)
+			for _, courseID := range courses[category.ID] {
+				nodeCourses[courseID] = true
+			}
+			for courseID := range nodeCourses {
+				subtree[courseID] = true
+			}
+			nodes = append(nodes, CategoryNode{
+				Category:    category,
+				CourseCount: len(nodeCourses),
+				Children:    nodeChildren,
+			})
+		}
+		return nodes, subtree
+	}
+
+	tree, _ := build("")
+	return tree
+}
+
+// Tag is the free-form label of school courses, names are unique in the school
+type Tag struct {
+	ID       ID
+	SchoolID ID
+	Name     string
+}
+
+const (
+	MaxCourseTags = 10
+	MaxTagLength  = 32
+)
+
+// NormalizeTag returns the tag name in the stored form,
+// tags differing in case and surrounding spaces are the same
+func NormalizeTag(name string) (string, error) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if name == "" || len([]rune(name)) > MaxTagLength {
+		return "", errs.ErrTagInvalid
+	}
+	return name, nil
+}
@@ -1,19 +1,150 @@
 package domain
 
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"time"
+)
+
 type CourseStatus int
 
 const (
 	CourseDraft CourseStatus = iota
 	CourseReady
 	CoursePublished
+	CourseUnpublished
+	CourseArchived
 )
 
+var courseStatusNames = map[CourseStatus]string{
+	CourseDraft:       "draft",
+	CourseReady:       "ready",
+	CoursePublished:   "published",
+	CourseUnpublished: "unpublished",
+	CourseArchived:    "archived",
+}
+
+// courseTransitions lists statuses the course can move to from each status,
+// ready course goes back to draft for rework, archived course can be restored
+// as unpublished
+var courseTransitions = map[CourseStatus][]CourseStatus{
+	CourseDraft:       {CourseReady},
+	CourseReady:       {CourseDraft, CoursePublished},
+	CoursePublished:   {CourseUnpublished, CourseArchived},
+	CourseUnpublished: {CourseDraft, CoursePublished, CourseArchived},
+	CourseArchived:    {CourseUnpublished},
+}
+
+func (s CourseStatus) String() string {
+	return courseStatusNames[s]
+}
+
+func ParseCourseStatus(name string) (CourseStatus, bool) {
+	for status, statusName := range courseStatusNames {
+		if statusName == name {
+			return status, true
+		}
+	}
+	return CourseDraft, false
+}
+
+// CanTransitTo returns typed error if the transition is missing from the table
+func (s CourseStatus) CanTransitTo(status CourseStatus) error {
+	for _, next := range courseTransitions[s] {
+		if next == status {
+			return nil
+		}
+	}
+	return &errs.CourseTransitionError{From: s.String(), To: status.String()}
+}
+
+// Listed courses are shown in the catalog and can be bought
+func (s CourseStatus) Listed() bool {
+	return s == CoursePublished
+}
+
+// Learnable courses are studied by enrolled students,
+// archived course is hidden from the catalog but keeps its students
+func (s CourseStatus) Learnable() bool {
+	return s == CoursePublished || s == CourseArchived
+}
+
+// CourseTransition records the status change made by the user
+type CourseTransition struct {
+	ID        ID
+	CourseID  ID
+	From      CourseStatus
+	To        CourseStatus
+	UserID    ID
+	CreatedAt time.Time
+}
+
 type Course struct {
 	ID       ID
 	SchoolID ID
 	Name     string
 	Level    int
-	Price    int64
+	Price    Money
 	Language string
 	Status   CourseStatus
+	Capacity null.Int
+	// AccessDays limits access of every enrollment, null means lifetime access
+	AccessDays null.Int
+	// Version is the content version edited by teachers, PublishedVersion is
+	// studied by new students, 0 means the course was never published
+	Version          int
+	PublishedVersion int
+	// PublishAt is the date when the ready course is published by the scheduler
+	PublishAt null.Time
+}
+
+// Duplicate returns the new draft course with the same metadata in the school,
+// content of the copy starts from the first version
+func (c Course) Duplicate(schoolID ID) Course {
+	return Course{
+		ID:         NewID(),
+		SchoolID:   schoolID,
+		Name:       c.Name,
+		Level:      c.Level,
+		Price:      c.Price,
+		Language:   c.Language,
+		Status:     CourseDraft,
+		Capacity:   c.Capacity,
+		AccessDays: c.AccessDays,
+		Version:    1,
+	}
+}
+
+// ScheduledFor reports whether the job publishes the course at its current
+// publish date, jobs of canceled or moved dates are stale
+func (c Course) ScheduledFor(job Job) bool {
+	return c.PublishAt.Valid && c.PublishAt.Time.Equal(job.RunAt)
+}
+
+// HasRevision reports whether the course content is edited as a new version
+// while the previous one is studied by students
+func (c Course) HasRevision() bool {
+	return c.Version > c.PublishedVersion
+}
+
+// LearnerVersion returns the content version of new students
+func (c Course) LearnerVersion() int {
+	if c.PublishedVersion == 0 {
+		return c.Version
+	}
+	return c.PublishedVersion
+}
+
+// Editable reports whether lessons of the given version can be changed,
+// published versions are immutable snapshots
+func (c Course) Editable(version int) bool {
+	return version > c.PublishedVersion
+}
+
+// AccessExpiresAt returns the end of access of the enrollment started at the given time
+func (c Course) AccessExpiresAt(from time.Time) null.Time {
+	if !c.AccessDays.Valid {
+		return null.Time{}
+	}
+	return null.TimeFrom(from.AddDate(0, 0, int(c.AccessDays.Int64)))
 }
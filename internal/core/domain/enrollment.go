@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"github.com/guregu/null"
+	"time"
+)
+
+type Enrollment struct {
+	StudentID  ID
+	CourseID   ID
+	EnrolledAt time.Time
+	ExpiresAt  null.Time
+	// Version of the course content the student studies
+	Version int
+}
+
+func (e Enrollment) Active(now time.Time) bool {
+	return !e.ExpiresAt.Valid || e.ExpiresAt.Time.After(now)
+}
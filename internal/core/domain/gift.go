@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"github.com/guregu/null"
+	"time"
+)
+
+// Gift is a paid course seat that is not bound to a user
+// until somebody redeems its code
+type Gift struct {
+	ID             ID
+	Code           string
+	CourseID       ID
+	RecipientEmail string
+	PaymentKey     string
+	PaySum         int64
+	Currency       string
+	CreatedAt      time.Time
+	RedeemedBy     null.String
+	RedeemedAt     null.Time
+}
+
+func (g Gift) Redeemed() bool {
+	return g.RedeemedBy.Valid
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"github.com/guregu/null"
+	"time"
+)
+
+type JobStatus int
+
+const (
+	JobPending JobStatus = iota
+	JobDone
+	JobFailed
+)
+
+const (
+	// JobPublishCourse publishes the ready course at its publish date
+	JobPublishCourse = "publish_course"
+)
+
+const (
+	JobMaxAttempts = 5
+	jobRetryDelay  = time.Minute
+)
+
+// Job is a persistent background task, it is run by the scheduler
+// once RunAt comes and retried with a growing delay until it succeeds
+type Job struct {
+	ID          ID
+	Kind        string
+	TargetID    ID
+	UserID      ID
+	RunAt       time.Time
+	Status      JobStatus
+	Attempts    int
+	LockedUntil null.Time
+	LastError   null.String
+	CreatedAt   time.Time
+}
+
+func NewJob(kind string, targetID, userID ID, runAt time.Time) Job {
+	return Job{
+		ID:        NewID(),
+		Kind:      kind,
+		TargetID:  targetID,
+		UserID:    userID,
+		RunAt:     runAt,
+		Status:    JobPending,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// Exhausted reports that the claimed job must not be retried anymore
+func (j Job) Exhausted() bool {
+	return j.Attempts >= JobMaxAttempts
+}
+
+// RetryAt doubles the delay after every failed attempt
+func (j Job) RetryAt(now time.Time) time.Time {
+	return now.Add(jobRetryDelay << (j.Attempts - 1))
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"github.com/guregu/null"
+	"time"
+)
+
+type LedgerEntryKind int
+
+const (
+	LedgerSale LedgerEntryKind = iota
+	LedgerRefund
+)
+
+// LedgerEntry is a school share of a paid order, refunds are stored
+// as separate entries with negative amounts
+type LedgerEntry struct {
+	ID         ID
+	SchoolID   ID
+	CourseID   ID
+	UserID     ID
+	PaymentKey string
+	Kind       LedgerEntryKind
+	Gross      int64
+	Commission int64
+	Currency   string
+	CreatedAt  time.Time
+	PayoutID   null.String
+}
+
+func (e LedgerEntry) Net() int64 {
+	return e.Gross - e.Commission
+}
+
+func (e LedgerEntry) Settled() bool {
+	return e.PayoutID.Valid
+}
+
+type Payout struct {
+	ID           ID
+	SchoolID     ID
+	Amount       int64
+	Currency     string
+	SettledUntil time.Time
+	CreatedAt    time.Time
+}
+
+type CourseStatement struct {
+	CourseID   ID
+	CourseName string
+	Currency   string
+	Sales      int
+	Refunds    int
+	Gross      int64
+	Commission int64
+	Net        int64
+	Settled    int64
+}
+
+// StatementTotal sums the statement entries of one currency,
+// amounts in different currencies are never added together
+type StatementTotal struct {
+	Currency   string
+	Gross      int64
+	Commission int64
+	Net        int64
+	Settled    int64
+}
+
+type Statement struct {
+	SchoolID ID
+	From     time.Time
+	To       time.Time
+	Courses  []CourseStatement
+	Totals   []StatementTotal
+}
+
+// Commission returns the platform part of the paid sum, rounded down
+// in favour of the school
+func Commission(gross, percent int64) int64 {
+	return gross * percent / 100
+}
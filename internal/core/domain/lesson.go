@@ -2,7 +2,8 @@ package domain
 
 import (
 	"github.com/guregu/null"
-	"github.com/paw1a/eschool/internal/core/errs"
+	"strings"
+	"time"
 )
 
 type LessonType int
@@ -13,9 +14,13 @@ const (
 	VideoLesson
 )
 
+// Lesson belongs to the course content version, Key stays the same
+// in all versions of the lesson, so student stats can be moved between them
 type Lesson struct {
 	ID       ID
+	Key      ID
 	CourseID ID
+	Version  int
 	Title    string
 	Score    int
 	Type     LessonType
@@ -23,10 +28,23 @@ type Lesson struct {
 	TheoryUrl null.String
 	VideoUrl  null.String
 	Tests     []Test
+
+	// lesson is released to students at ReleaseAt or in ReleaseDays
+	// after their enrollment, lesson without rules is always released
+	ReleaseAt   null.Time
+	ReleaseDays null.Int
+
+	// preview lesson of the listed course is readable by anyone
+	// to sample the course content before buying it
+	IsPreview bool
 }
 
+// MaxCoursePreviewLessons limits preview lessons in the course version
+const MaxCoursePreviewLessons = 3
+
 type Test struct {
 	ID       ID
+	Key      ID
 	LessonID ID
 	TaskUrl  string
 	Options  []string
@@ -35,39 +53,168 @@ type Test struct {
 	Score    int
 }
 
-func (l *Lesson) Validate() error {
-	if l.Score <= 0 {
-		return errs.ErrCourseLessonInvalidScore
+// TestAnswerSeparator joins correct options of the test with several answers
+const TestAnswerSeparator = "; "
+
+// JoinTestAnswers returns the answer of the test with several correct options
+func JoinTestAnswers(answers []string) string {
+	return strings.Join(answers, TestAnswerSeparator)
+}
+
+// Answers returns correct options of the test
+func (t *Test) Answers() []string {
+	if answers, ok := t.splitOptions(t.Answer); ok {
+		return answers
+	}
+	return strings.Split(t.Answer, TestAnswerSeparator)
+}
+
+// Check reports whether the answer is correct, options of the test
+// with several correct answers may be given in any order
+func (t *Test) Check(answer string) bool {
+	if answer == t.Answer {
+		return true
+	}
+
+	correct, ok := t.splitOptions(t.Answer)
+	if !ok {
+		return false
 	}
-	switch l.Type {
-	case PracticeLesson:
-		if len(l.Tests) == 0 {
-			return errs.ErrCoursePracticeLessonEmptyTests
+	given, ok := t.splitOptions(answer)
+	if !ok || len(given) != len(correct) {
+		return false
+	}
+
+	left := make(map[string]int, len(correct))
+	for _, option := range correct {
+		left[option]++
+	}
+	for _, option := range given {
+		if left[option] == 0 {
+			return false
 		}
+		left[option]--
+	}
+	return true
+}
 
-		for _, test := range l.Tests {
-			if test.TaskUrl == "" {
-				return errs.ErrCoursePracticeLessonEmptyTestTaskUrl
-			}
-			if len(test.Options) == 0 {
-				return errs.ErrCoursePracticeLessonEmptyTestOptions
-			}
-			if test.Level < 0 {
-				return errs.ErrCoursePracticeLessonInvalidTestLevel
-			}
-			if test.Score <= 0 {
-				return errs.ErrCoursePracticeLessonInvalidTestScore
-			}
+// splitOptions splits the joined answer into the test options, options
+// may contain the separator, so the answer is matched against them
+func (t *Test) splitOptions(answer string) ([]string, bool) {
+	if answer == "" {
+		return nil, false
+	}
+	return t.splitOptionsFrom(answer, 0, make(map[int]bool))
+}
+
+// splitOptionsFrom splits the answer suffix starting at the offset,
+// offsets which can not be split are remembered to keep the search linear
+func (t *Test) splitOptionsFrom(answer string, offset int, failed map[int]bool) ([]string, bool) {
+	if failed[offset] {
+		return nil, false
+	}
+	rest := answer[offset:]
+	for _, option := range t.Options {
+		if option == "" {
+			continue
 		}
-	case TheoryLesson:
-		if !l.TheoryUrl.Valid {
-			return errs.ErrCourseTheoryLessonEmptyUrl
+		if rest == option {
+			return []string{option}, true
 		}
-	case VideoLesson:
-		if !l.VideoUrl.Valid {
-			return errs.ErrCourseVideoLessonEmptyUrl
+		if !strings.HasPrefix(rest, option+TestAnswerSeparator) {
+			continue
+		}
+		next := offset + len(option) + len(TestAnswerSeparator)
+		if options, ok := t.splitOptionsFrom(answer, next, failed); ok {
+			return append([]string{option}, options...), true
 		}
 	}
+	failed[offset] = true
+	return nil, false
+}
 
+// ReleaseTime returns the date when the lesson is opened to the student,
+// rule of days after enrollment applies only to enrolled students
+func (l *Lesson) ReleaseTime(enrollment Enrollment) null.Time {
+	if l.ReleaseAt.Valid {
+		return l.ReleaseAt
+	}
+	if l.ReleaseDays.Valid && !enrollment.EnrolledAt.IsZero() {
+		return null.TimeFrom(enrollment.EnrolledAt.AddDate(0, 0, int(l.ReleaseDays.Int64)))
+	}
+	return null.Time{}
+}
+
+func (l *Lesson) Released(enrollment Enrollment, now time.Time) bool {
+	releaseTime := l.ReleaseTime(enrollment)
+	return !releaseTime.Valid || !releaseTime.Time.After(now)
+}
+
+// PreviewOf reports whether the lesson is shown as the course preview,
+// previews are taken from the published content of the listed course
+func (l *Lesson) PreviewOf(course Course) bool {
+	return l.IsPreview && l.CourseID == course.ID &&
+		course.Status.Listed() && l.Version == course.PublishedVersion
+}
+
+// HideAnswers returns the copy of the lesson without answers of its tests
+func (l *Lesson) HideAnswers() Lesson {
+	hidden := *l
+	hidden.Tests = make([]Test, len(l.Tests))
+	for i, test := range l.Tests {
+		test.Answer = ""
+		hidden.Tests[i] = test
+	}
+	return hidden
+}
+
+// Lock hides the content of the unreleased lesson
+func (l *Lesson) Lock() Lesson {
+	locked := *l
+	locked.TheoryUrl = null.String{}
+	locked.VideoUrl = null.String{}
+	locked.Tests = nil
+	return locked
+}
+
+// Validate returns the first error found in the lesson
+func (l *Lesson) Validate() error {
+	for _, issue := range l.Issues() {
+		if issue.Severity == ValidationError {
+			return issue.Err
+		}
+	}
 	return nil
 }
+
+// Duplicate copies the lesson into the first version of another course,
+// copy gets new ids and keys, urls of stored files are kept
+func (l *Lesson) Duplicate(courseID ID) Lesson {
+	duplicate := *l
+	duplicate.ID = NewID()
+	duplicate.Key = duplicate.ID
+	duplicate.CourseID = courseID
+	duplicate.Version = 1
+	duplicate.Tests = make([]Test, len(l.Tests))
+	for i, test := range l.Tests {
+		test.ID = NewID()
+		test.Key = test.ID
+		test.LessonID = duplicate.ID
+		duplicate.Tests[i] = test
+	}
+	return duplicate
+}
+
+// Revise copies the lesson into the given course version with new ids
+func (l *Lesson) Revise(version int) Lesson {
+	revision := *l
+	revision.ID = NewID()
+	revision.Version = version
+	revision.Tests = make([]Test, len(l.Tests))
+	for i, test := range l.Tests {
+		test.ID = NewID()
+		test.LessonID = revision.ID
+		revision.Tests[i] = test
+	}
+	return revision
+}
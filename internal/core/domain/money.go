@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"github.com/paw1a/eschool/internal/core/errs"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency prices courses created without a currency,
+// amounts stored before multi-currency pricing are rubles
+const DefaultCurrency = "RUB"
+
+const DefaultLocale = "en"
+
+type currency struct {
+	digits int // digits of the minor unit
+	symbol string
+}
+
+// currencies are ISO 4217 codes supported by the platform
+var currencies = map[string]currency{
+	"RUB": {digits: 2, symbol: "₽"},
+	"USD": {digits: 2, symbol: "$"},
+	"EUR": {digits: 2, symbol: "€"},
+	"GBP": {digits: 2, symbol: "£"},
+	"KZT": {digits: 2, symbol: "₸"},
+	"JPY": {digits: 0, symbol: "¥"},
+}
+
+type numberFormat struct {
+	group        string
+	decimal      string
+	symbolBefore bool
+}
+
+var localeFormats = map[string]numberFormat{
+	"en": {group: ",", decimal: ".", symbolBefore: true},
+	"ru": {group: "\u00a0", decimal: ",", symbolBefore: false},
+	"de": {group: ".", decimal: ",", symbolBefore: false},
+}
+
+// Money is an amount in minor units of the currency, e.g. kopecks of RUB
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+func SupportedCurrency(code string) bool {
+	_, ok := currencies[code]
+	return ok
+}
+
+func (m Money) Valid() bool {
+	return SupportedCurrency(m.Currency)
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Decimal returns the amount in major units with a dot separator, e.g. 3990.50
+func (m Money) Decimal() string {
+	return m.format(numberFormat{decimal: "."})
+}
+
+// Format returns the amount with the currency symbol in the notation of the locale,
+// locale is a language tag such as "ru-RU", unknown locales are formatted as english
+func (m Money) Format(locale string) string {
+	format, ok := localeFormats[localeLanguage(locale)]
+	if !ok {
+		format = localeFormats[DefaultLocale]
+	}
+
+	symbol := m.Currency
+	if c, ok := currencies[m.Currency]; ok {
+		symbol = c.symbol
+	}
+
+	number := m.format(format)
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+	if format.symbolBefore {
+		return sign + symbol + number
+	}
+	return sign + number + "\u00a0" + symbol
+}
+
+func (m Money) format(format numberFormat) string {
+	digits := currencies[m.Currency].digits
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	text := strconv.FormatInt(amount, 10)
+	if len(text) <= digits {
+		text = strings.Repeat("0", digits-len(text)+1) + text
+	}
+	major, minor := text[:len(text)-digits], text[len(text)-digits:]
+
+	if format.group != "" {
+		var grouped strings.Builder
+		for i, digit := range major {
+			if i > 0 && (len(major)-i)%3 == 0 {
+				grouped.WriteString(format.group)
+			}
+			grouped.WriteRune(digit)
+		}
+		major = grouped.String()
+	}
+
+	if digits == 0 {
+		return sign + major
+	}
+	return sign + major + format.decimal + minor
+}
+
+// ParseAmount converts the decimal amount in major units such as "3990.5"
+// to minor units of the currency, extra fraction digits are rejected
+func ParseAmount(value string, currencyCode string) (int64, error) {
+	c, ok := currencies[currencyCode]
+	if !ok {
+		return 0, errs.ErrCurrencyNotSupported
+	}
+
+	major, minor, _ := strings.Cut(strings.TrimSpace(value), ".")
+	if major == "" || len(minor) > c.digits || strings.HasPrefix(major, "-") {
+		return 0, errs.ErrInvalidMoneyAmount
+	}
+
+	minor += strings.Repeat("0", c.digits-len(minor))
+	amount, err := strconv.ParseInt(major+minor, 10, 64)
+	if err != nil {
+		return 0, errs.ErrInvalidMoneyAmount
+	}
+	return amount, nil
+}
+
+func localeLanguage(locale string) string {
+	language, _, _ := strings.Cut(locale, "-")
+	language, _, _ = strings.Cut(language, "_")
+	return strings.ToLower(strings.TrimSpace(language))
+}
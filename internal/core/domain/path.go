@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// LearningPath is an ordered sequence of school courses,
+// the student completing every course gets a combined certificate
+type LearningPath struct {
+	ID          ID
+	SchoolID    ID
+	Name        string
+	Description string
+	CreatedAt   time.Time
+}
+
+// PathProgress keeps progress of path courses in the path order
+type PathProgress struct {
+	PathID  ID
+	Courses []CourseProgress
+}
+
+func (p PathProgress) CompletedCourses() int {
+	var completed int
+	for _, course := range p.Courses {
+		if course.Completed() {
+			completed++
+		}
+	}
+	return completed
+}
+
+func (p PathProgress) Completed() bool {
+	return len(p.Courses) > 0 && p.CompletedCourses() == len(p.Courses)
+}
+
+// CurrentCourseID returns the first not completed course of the path,
+// it is empty once the path is completed
+func (p PathProgress) CurrentCourseID() ID {
+	for _, course := range p.Courses {
+		if !course.Completed() {
+			return course.CourseID
+		}
+	}
+	return ""
+}
+
+func (p PathProgress) Score() int {
+	var score int
+	for _, course := range p.Courses {
+		score += course.Score
+	}
+	return score
+}
+
+// PathCertificate is issued once per student and path,
+// its id is used to verify the certificate
+type PathCertificate struct {
+	ID       ID
+	PathID   ID
+	UserID   ID
+	Score    int
+	IssuedAt time.Time
+}
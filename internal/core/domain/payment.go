@@ -1,7 +1,27 @@
 package domain
 
+import "time"
+
+// PaymentPayload of a gift carries recipient email instead of user id,
+// renewal payload extends access of the existing enrollment,
+// subscription payload saves the payment method for recurring charges
 type PaymentPayload struct {
+	UserID             ID
+	CourseID           ID
+	BundleID           ID
+	PromoCodeID        ID
+	SubscriptionPlanID ID
+	RecipientEmail     string
+	Renewal            bool
+	PaymentMethodID    string
+	PaySum             Money
+}
+
+type Payment struct {
 	UserID   ID
 	CourseID ID
+	Key      string
 	PaySum   int64
+	Currency string
+	PaidAt   time.Time
 }
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/guregu/null"
+)
+
+// CourseProgress sums stats of the student over lessons of the studied
+// course version, practice lessons are scored by passed tests
+type CourseProgress struct {
+	CourseID      ID
+	Lessons       int
+	PassedLessons int
+	Score         int
+	MaxScore      int
+}
+
+func NewCourseProgress(courseID ID, lessons []Lesson, stats []LessonStat) CourseProgress {
+	progress := CourseProgress{
+		CourseID: courseID,
+		Lessons:  len(lessons),
+	}
+
+	lessonStats := make(map[ID]LessonStat, len(stats))
+	for _, stat := range stats {
+		lessonStats[stat.LessonID] = stat
+	}
+
+	for _, lesson := range lessons {
+		stat, ok := lessonStats[lesson.ID]
+		if lesson.Type == PracticeLesson {
+			for _, test := range lesson.Tests {
+				progress.MaxScore += test.Score
+			}
+			for _, testStat := range stat.TestStats {
+				progress.Score += testStat.Score
+			}
+		} else {
+			progress.MaxScore += lesson.Score
+			progress.Score += stat.Score
+		}
+		if ok && stat.Score > 0 {
+			progress.PassedLessons++
+		}
+	}
+	return progress
+}
+
+// Completed reports whether every lesson of the course is passed
+func (p CourseProgress) Completed() bool {
+	return p.Lessons > 0 && p.PassedLessons == p.Lessons
+}
+
+// CoursePrerequisite requires the student to complete the required course
+// before enrollment, with MinScore set reaching the score is enough
+type CoursePrerequisite struct {
+	CourseID         ID
+	RequiredCourseID ID
+	MinScore         null.Int
+}
+
+func (p CoursePrerequisite) MetBy(progress CourseProgress) bool {
+	if p.MinScore.Valid {
+		return int64(progress.Score) >= p.MinScore.Int64
+	}
+	return progress.Completed()
+}
+
+// Requirement describes what the student has to do to meet the prerequisite
+func (p CoursePrerequisite) Requirement(required Course, progress CourseProgress) string {
+	if p.MinScore.Valid {
+		return fmt.Sprintf("reach score %d in course %q, current score is %d",
+			p.MinScore.Int64, required.Name, progress.Score)
+	}
+	return fmt.Sprintf("complete course %q, %d of %d lessons passed",
+		required.Name, progress.PassedLessons, progress.Lessons)
+}
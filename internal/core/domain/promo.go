@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"github.com/guregu/null"
+	"time"
+)
+
+type PromoDiscountType int
+
+const (
+	PromoPercentDiscount PromoDiscountType = iota
+	PromoFixedDiscount
+)
+
+type PromoCode struct {
+	ID           ID
+	SchoolID     ID
+	CourseID     null.String
+	Code         string
+	DiscountType PromoDiscountType
+	Discount     int64
+	Currency     string
+	MaxUses      null.Int
+	UsedCount    int64
+	ValidFrom    null.Time
+	ValidUntil   null.Time
+	CreatedAt    time.Time
+}
+
+type PromoRedemption struct {
+	PromoCodeID ID
+	UserID      ID
+	CourseID    ID
+	PaySum      int64
+	RedeemedAt  time.Time
+}
+
+// AppliesTo reports whether the code can be used for the course,
+// school wide codes apply to every course of the school
+func (p PromoCode) AppliesTo(course Course) bool {
+	if p.SchoolID != course.SchoolID {
+		return false
+	}
+	return !p.CourseID.Valid || ID(p.CourseID.String) == course.ID
+}
+
+// MatchesCurrency reports whether the code is issued in the course currency,
+// percent codes carry no currency and match a price in any of them
+func (p PromoCode) MatchesCurrency(course Course) bool {
+	if p.DiscountType != PromoFixedDiscount {
+		return true
+	}
+	return p.Currency == course.Price.Currency
+}
+
+func (p PromoCode) Active(now time.Time) bool {
+	if p.ValidFrom.Valid && now.Before(p.ValidFrom.Time) {
+		return false
+	}
+	if p.ValidUntil.Valid && !now.Before(p.ValidUntil.Time) {
+		return false
+	}
+	return true
+}
+
+func (p PromoCode) Exhausted() bool {
+	return p.MaxUses.Valid && p.UsedCount >= p.MaxUses.Int64
+}
+
+// Apply returns the discounted price, it never goes below zero
+func (p PromoCode) Apply(price int64) int64 {
+	var discounted int64
+	switch p.DiscountType {
+	case PromoPercentDiscount:
+		discounted = price - price*p.Discount/100
+	case PromoFixedDiscount:
+		discounted = price - p.Discount
+	}
+	if discounted < 0 {
+		return 0
+	}
+	return discounted
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"github.com/guregu/null"
+	"time"
+)
+
+type RefundStatus int
+
+const (
+	RefundRequested RefundStatus = iota
+	RefundApproved
+	RefundRejected
+	// RefundApproving is held while the payment is paid back by the gateway,
+	// the refund can't be rejected in this status
+	RefundApproving
+)
+
+type Refund struct {
+	ID          ID
+	UserID      ID
+	CourseID    ID
+	PaymentKey  string
+	Amount      int64
+	Currency    string
+	Reason      string
+	Status      RefundStatus
+	RequestedAt time.Time
+	ResolvedBy  null.String
+	ResolvedAt  null.Time
+}
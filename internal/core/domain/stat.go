@@ -14,3 +14,41 @@ type TestStat struct {
 	UserID ID
 	Score  int
 }
+
+// MigrateLessonStat moves the stat of the lesson to its new version,
+// tests are matched by keys, new tests are not passed, passed lesson
+// gets the score of the new version
+func MigrateLessonStat(stat LessonStat, from, to Lesson) LessonStat {
+	migrated := LessonStat{
+		ID:        NewID(),
+		LessonID:  to.ID,
+		UserID:    stat.UserID,
+		TestStats: make([]TestStat, len(to.Tests)),
+	}
+	if stat.Score > 0 {
+		migrated.Score = to.Score
+	}
+
+	scores := make(map[ID]int, len(from.Tests))
+	for _, test := range from.Tests {
+		for _, testStat := range stat.TestStats {
+			if testStat.TestID == test.ID {
+				scores[test.Key] = testStat.Score
+			}
+		}
+	}
+
+	for i, test := range to.Tests {
+		score := scores[test.Key]
+		if score > test.Score {
+			score = test.Score
+		}
+		migrated.TestStats[i] = TestStat{
+			ID:     NewID(),
+			TestID: test.ID,
+			UserID: stat.UserID,
+			Score:  score,
+		}
+	}
+	return migrated
+}
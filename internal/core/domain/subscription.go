@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/guregu/null"
+	"time"
+)
+
+// SubscriptionPlan is a school membership, subscriber studies every
+// published course of the school while the subscription is active
+type SubscriptionPlan struct {
+	ID         ID
+	SchoolID   ID
+	Name       string
+	Price      int64
+	Currency   string
+	PeriodDays int
+}
+
+func (p SubscriptionPlan) Cost() Money {
+	return NewMoney(p.Price, p.Currency)
+}
+
+func (p SubscriptionPlan) Extend(from time.Time) time.Time {
+	return from.AddDate(0, 0, p.PeriodDays)
+}
+
+// Subscription is renewed by the payment gateway with the saved payment
+// method, canceled subscription stays active until it expires
+type Subscription struct {
+	ID              ID
+	PlanID          ID
+	SchoolID        ID
+	UserID          ID
+	PaymentMethodID null.String
+	StartedAt       time.Time
+	ExpiresAt       time.Time
+	CanceledAt      null.Time
+}
+
+func (s Subscription) Active(now time.Time) bool {
+	return s.ExpiresAt.After(now)
+}
+
+// RenewalKey identifies the charge of the current period, so the period
+// is charged once even if renewal of it is retried
+func (s Subscription) RenewalKey() string {
+	return fmt.Sprintf("%s-%d", s.ID, s.ExpiresAt.Unix())
+}
+
+func (s Subscription) AutoRenewable() bool {
+	return !s.CanceledAt.Valid && s.PaymentMethodID.Valid
+}
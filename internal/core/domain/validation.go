@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/paw1a/eschool/internal/core/errs"
+)
+
+type ValidationSeverity int
+
+const (
+	ValidationError ValidationSeverity = iota
+	ValidationWarning
+)
+
+func (s ValidationSeverity) String() string {
+	switch s {
+	case ValidationWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+const (
+	IssueCourseNotEnoughLessons = "course_not_enough_lessons"
+	IssueLessonInvalidScore     = "lesson_invalid_score"
+	IssueLessonEmptyTitle       = "lesson_empty_title"
+	IssuePracticeEmptyTests     = "practice_empty_tests"
+	IssueTheoryEmptyUrl         = "theory_empty_url"
+	IssueVideoEmptyUrl          = "video_empty_url"
+	IssueTestEmptyTask          = "test_empty_task"
+	IssueTestEmptyOptions       = "test_empty_options"
+	IssueTestInvalidLevel       = "test_invalid_level"
+	IssueTestInvalidScore       = "test_invalid_score"
+	IssueTestSingleOption       = "test_single_option"
+	IssueTestAnswerNotOption    = "test_answer_not_option"
+)
+
+// ValidationIssue points to the lesson and the test which must be fixed,
+// Err is set only for issues with error severity
+type ValidationIssue struct {
+	Code     string
+	Severity ValidationSeverity
+	LessonID ID
+	TestID   ID
+	Message  string
+	Err      error
+}
+
+// CourseValidationReport lists all problems of the course content,
+// course can be published only when the report has no errors
+type CourseValidationReport struct {
+	CourseID ID
+	Issues   []ValidationIssue
+}
+
+func ValidateCourseLessons(courseID ID, lessons []Lesson) CourseValidationReport {
+	report := CourseValidationReport{CourseID: courseID, Issues: []ValidationIssue{}}
+	var theoryCount, practiceCount int
+	for _, lesson := range lessons {
+		report.Issues = append(report.Issues, lesson.Issues()...)
+
+		switch lesson.Type {
+		case PracticeLesson:
+			practiceCount++
+		case TheoryLesson:
+			theoryCount++
+		case VideoLesson:
+			theoryCount++
+		}
+	}
+
+	if theoryCount == 0 || practiceCount == 0 {
+		report.Issues = append(report.Issues,
+			newValidationError(IssueCourseNotEnoughLessons, "", "", errs.ErrCourseNotEnoughLessons))
+	}
+	return report
+}
+
+func (r *CourseValidationReport) Valid() bool {
+	return r.Err() == nil
+}
+
+// Err returns the first error of the report
+func (r *CourseValidationReport) Err() error {
+	for _, issue := range r.Issues {
+		if issue.Severity == ValidationError {
+			return issue.Err
+		}
+	}
+	return nil
+}
+
+func (r *CourseValidationReport) Count(severity ValidationSeverity) int {
+	var count int
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// Issues checks the lesson and every its test
+func (l *Lesson) Issues() []ValidationIssue {
+	var issues []ValidationIssue
+	if l.Score <= 0 {
+		issues = append(issues,
+			newValidationError(IssueLessonInvalidScore, l.ID, "", errs.ErrCourseLessonInvalidScore))
+	}
+	if l.Title == "" {
+		issues = append(issues,
+			newValidationWarning(IssueLessonEmptyTitle, l.ID, "", "lesson has no title"))
+	}
+
+	switch l.Type {
+	case PracticeLesson:
+		if len(l.Tests) == 0 {
+			issues = append(issues,
+				newValidationError(IssuePracticeEmptyTests, l.ID, "", errs.ErrCoursePracticeLessonEmptyTests))
+		}
+		for _, test := range l.Tests {
+			issues = append(issues, test.issues(l.ID)...)
+		}
+	case TheoryLesson:
+		if !l.TheoryUrl.Valid {
+			issues = append(issues,
+				newValidationError(IssueTheoryEmptyUrl, l.ID, "", errs.ErrCourseTheoryLessonEmptyUrl))
+		}
+	case VideoLesson:
+		if !l.VideoUrl.Valid {
+			issues = append(issues,
+				newValidationError(IssueVideoEmptyUrl, l.ID, "", errs.ErrCourseVideoLessonEmptyUrl))
+		}
+	}
+	return issues
+}
+
+func (t *Test) issues(lessonID ID) []ValidationIssue {
+	var issues []ValidationIssue
+	if t.TaskUrl == "" {
+		issues = append(issues,
+			newValidationError(IssueTestEmptyTask, lessonID, t.ID, errs.ErrCoursePracticeLessonEmptyTestTaskUrl))
+	}
+	if len(t.Options) == 0 {
+		issues = append(issues,
+			newValidationError(IssueTestEmptyOptions, lessonID, t.ID, errs.ErrCoursePracticeLessonEmptyTestOptions))
+	}
+	if t.Level < 0 {
+		issues = append(issues,
+			newValidationError(IssueTestInvalidLevel, lessonID, t.ID, errs.ErrCoursePracticeLessonInvalidTestLevel))
+	}
+	if t.Score <= 0 {
+		issues = append(issues,
+			newValidationError(IssueTestInvalidScore, lessonID, t.ID, errs.ErrCoursePracticeLessonInvalidTestScore))
+	}
+
+	if len(t.Options) == 1 {
+		issues = append(issues, newValidationWarning(IssueTestSingleOption, lessonID, t.ID,
+			"test has a single option, it can't be answered wrong"))
+	}
+	if len(t.Options) > 0 && !t.hasOption(t.Answer) && !t.hasOptions(t.Answers()) {
+		issues = append(issues, newValidationWarning(IssueTestAnswerNotOption, lessonID, t.ID,
+			fmt.Sprintf("test answer %q is not one of its options", t.Answer)))
+	}
+	return issues
+}
+
+func (t *Test) hasOption(option string) bool {
+	for _, o := range t.Options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// hasOptions reports whether every answer of the test with several answers is its option
+func (t *Test) hasOptions(answers []string) bool {
+	for _, answer := range answers {
+		if !t.hasOption(answer) {
+			return false
+		}
+	}
+	return true
+}
+
+func newValidationError(code string, lessonID, testID ID, err error) ValidationIssue {
+	return ValidationIssue{
+		Code:     code,
+		Severity: ValidationError,
+		LessonID: lessonID,
+		TestID:   testID,
+		Message:  err.Error(),
+		Err:      err,
+	}
+}
+
+func newValidationWarning(code string, lessonID, testID ID, message string) ValidationIssue {
+	return ValidationIssue{
+		Code:     code,
+		Severity: ValidationWarning,
+		LessonID: lessonID,
+		TestID:   testID,
+		Message:  message,
+	}
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"github.com/guregu/null"
+	"time"
+)
+
+// SeatHold reserves a seat in a course with limited capacity for a user
+// who is paying for the course or was offered a seat from the waitlist
+type SeatHold struct {
+	CourseID  ID
+	UserID    ID
+	ExpiresAt time.Time
+}
+
+type WaitlistEntry struct {
+	CourseID     ID
+	UserID       ID
+	JoinedAt     time.Time
+	OfferedUntil null.Time
+}
+
+func (w WaitlistEntry) Offered(now time.Time) bool {
+	return w.OfferedUntil.Valid && w.OfferedUntil.Time.After(now)
+}
@@ -1,6 +1,11 @@
 package errs
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
 var (
 	ErrCourseNotEnoughLessons               = errors.New("course must have at least 1 theory and 1 practice lessons")
@@ -16,13 +21,66 @@ var (
 	ErrCoursePublishedState                 = errors.New("course must be in ready state to publish it")
 	ErrCourseInvalidLevel                   = errors.New("course level must be > 0")
 	ErrCourseInvalidPrice                   = errors.New("course price must be >= 0")
+	ErrCourseInvalidCapacity                = errors.New("course capacity must be > 0")
+	ErrCourseInvalidAccessPeriod            = errors.New("course access period must be > 0 days")
+	ErrCourseInvalidCurrency                = errors.New("course currency is not a supported ISO 4217 code")
+	ErrCourseInvalidTransition              = errors.New("course status transition is not allowed")
+	ErrCourseNotForSale                     = errors.New("course is not for sale")
+	ErrCourseRevisionExists                 = errors.New("course already has a draft revision")
+	ErrCourseNoRevision                     = errors.New("course has no draft revision to publish")
+	ErrLessonPublished                      = errors.New("lesson of the published course version can't be changed, create a revision")
+	ErrEnrollmentLatestVersion              = errors.New("student already studies the latest course version")
+	ErrCoursePublishAtInPast                = errors.New("course publish date must be in the future")
+	ErrLessonInvalidRelease                 = errors.New("lesson is released either at a date or in days >= 0 after enrollment")
+	ErrLessonNotReleased                    = errors.New("lesson is not released yet")
+	ErrLessonPreviewLimit                   = errors.New("course preview lessons limit is reached")
 )
 
 var (
-	ErrUserIsNotSchoolTeacher     = errors.New("user is not a teacher in this school")
-	ErrUserIsAlreadyCourseStudent = errors.New("user has already bought this course")
-	ErrInvalidPaymentSum          = errors.New("received invalid payment")
-	ErrDecodePaymentKeyFailed     = errors.New("failed to decode payment payload")
+	ErrUserIsNotSchoolTeacher       = errors.New("user is not a teacher in this school")
+	ErrUserIsAlreadyCourseStudent   = errors.New("user has already bought this course")
+	ErrInvalidPaymentSum            = errors.New("received invalid payment")
+	ErrDecodePaymentKeyFailed       = errors.New("failed to decode payment payload")
+	ErrInvalidPromoCode             = errors.New("promo code is invalid or expired")
+	ErrPromoCodeExhausted           = errors.New("promo code usage limit is reached")
+	ErrPromoCodeNotApplicable       = errors.New("promo code does not apply to this course")
+	ErrPromoCodeEmptyCode           = errors.New("promo code must be not empty")
+	ErrPromoCodeInvalidDiscount     = errors.New("promo code percent discount must be in 1..100, fixed discount must be > 0")
+	ErrPromoCodeInvalidMaxUses      = errors.New("promo code max uses must be > 0")
+	ErrPromoCodeInvalidValidity     = errors.New("promo code validity window end must be after its start")
+	ErrPromoCodeDuplicate           = errors.New("promo code with such code already exists in this school")
+	ErrPromoCodeInvalidCurrency     = errors.New("promo code currency is not a supported ISO 4217 code")
+	ErrPromoCodeCurrencyMismatch    = errors.New("promo code currency differs from the course currency")
+	ErrUserIsNotCourseStudent       = errors.New("user is not a student of this course")
+	ErrCourseNotPaid                = errors.New("course was not paid, nothing to refund")
+	ErrRefundAlreadyRequested       = errors.New("refund for this course is already requested")
+	ErrRefundAlreadyResolved        = errors.New("refund is already approved or rejected")
+	ErrPaymentNotCompleted          = errors.New("payment is not completed by payment provider")
+	ErrPaymentGatewayFailed         = errors.New("payment provider request failed")
+	ErrCourseIsFull                 = errors.New("course has no free seats, join the waitlist")
+	ErrCourseHasNoCapacity          = errors.New("course has no seat limit, waitlist is not needed")
+	ErrWaitlistAlreadyJoined        = errors.New("user is already in the course waitlist")
+	ErrBundleInvalidPrice           = errors.New("bundle price must be > 0")
+	ErrBundleTooFewCourses          = errors.New("bundle must contain at least 2 different courses")
+	ErrBundleCourseNotInSchool      = errors.New("bundle courses must belong to the bundle school")
+	ErrBundleCourseNotPublished     = errors.New("all bundle courses must be published to publish the bundle")
+	ErrBundlePublishedState         = errors.New("bundle must be in draft state to publish it")
+	ErrBundleNotPublished           = errors.New("bundle is not published")
+	ErrBundleAlreadyOwned           = errors.New("user has already bought every course of this bundle")
+	ErrNothingToSettle              = errors.New("school has no unsettled earnings to pay out")
+	ErrPayoutInvalidCurrency        = errors.New("payout currency is not a supported ISO 4217 code")
+	ErrGiftAlreadyRedeemed          = errors.New("gift code is already redeemed")
+	ErrGiftCourseHasCapacity        = errors.New("course with seat limit can not be bought as a gift")
+	ErrCourseNotRenewable           = errors.New("user has no time-limited enrollment in this course to renew")
+	ErrSubscriptionInvalidPrice     = errors.New("subscription plan price must be > 0")
+	ErrSubscriptionInvalidPeriod    = errors.New("subscription plan period must be > 0 days")
+	ErrSubscriptionInvalidCurrency  = errors.New("subscription plan currency is not a supported ISO 4217 code")
+	ErrSubscriptionAlreadyActive    = errors.New("user already has an auto-renewed subscription to this school")
+	ErrSubscriptionNotActive        = errors.New("user has no active subscription to this school to cancel")
+	ErrRecurringPaymentNotSupported = errors.New("payment provider does not support recurring payments")
+	ErrCurrencyNotSupported         = errors.New("payment provider does not support the price currency")
+	ErrInvalidMoneyAmount           = errors.New("money amount must be a positive decimal number")
+	ErrBundleMixedCurrencies        = errors.New("bundle courses must be priced in the same currency")
 )
 
 var (
@@ -40,14 +98,104 @@ var (
 	ErrFilepathEmpty   = errors.New("validation filepath is empty error")
 	ErrFileReaderEmpty = errors.New("validation file reader is nil error")
 	ErrSaveFileError   = errors.New("failed to save file to object storage")
+	ErrCopyFileError   = errors.New("failed to copy file in object storage")
+	ErrLoadFileError   = errors.New("failed to load file from object storage")
+	ErrFileNotStored   = errors.New("file is not stored in object storage")
+)
+
+var (
+	ErrCourseArchiveInvalid     = errors.New("course archive is not a valid course export")
+	ErrCourseArchiveVersion     = errors.New("course archive format version is not supported")
+	ErrCourseArchiveTooLarge    = errors.New("course archive or one of its files is too large")
+	ErrCourseArchiveMissingFile = errors.New("file listed in the course archive manifest is missing")
+)
+
+var (
+	ErrQuizPackageInvalid  = errors.New("quiz file is not a qti item or common cartridge package")
+	ErrQuizPackageTooLarge = errors.New("quiz package or one of its files is too large")
+)
+
+var (
+	ErrCoursePrerequisiteSelf         = errors.New("course can't require itself")
+	ErrCoursePrerequisiteCycle        = errors.New("course prerequisites can't form a cycle")
+	ErrCoursePrerequisiteNotInSchool  = errors.New("required course must belong to the same school")
+	ErrCoursePrerequisiteInvalidScore = errors.New("prerequisite min score must be positive")
+	ErrCoursePrerequisitesNotMet      = errors.New("course prerequisites are not met")
+)
+
+var (
+	ErrLearningPathTooFewCourses     = errors.New("learning path must contain at least 2 courses")
+	ErrLearningPathCourseNotInSchool = errors.New("learning path courses must belong to the path school")
+	ErrLearningPathNotCompleted      = errors.New("learning path is not completed yet")
 )
 
 var (
-	ErrInvalidCredentials      = errors.New("invalid credentials")
-	ErrNotUniqueEmail          = errors.New("user with such email already exists")
-	ErrAuthSessionIsNotPresent = errors.New("session with such refresh token is not present")
-	ErrInvalidTokenSignMethod  = errors.New("invalid signing method")
-	ErrInvalidTokenClaims      = errors.New("invalid token claims")
-	ErrInvalidToken            = errors.New("invalid jwt token")
-	ErrInvalidFingerprint      = errors.New("invalid client fingerprint")
+	ErrCategoryNotEmpty  = errors.New("category with subcategories can't be deleted")
+	ErrTagInvalid        = errors.New("tag must be a non-empty name of at most 32 characters")
+	ErrCourseTooManyTags = errors.New("course has too many tags")
 )
+
+var (
+	ErrInvalidCredentials       = errors.New("invalid credentials")
+	ErrNotUniqueEmail           = errors.New("user with such email already exists")
+	ErrAuthSessionIsNotPresent  = errors.New("session with such refresh token is not present")
+	ErrInvalidTokenSignMethod   = errors.New("invalid signing method")
+	ErrInvalidTokenClaims       = errors.New("invalid token claims")
+	ErrInvalidToken             = errors.New("invalid jwt token")
+	ErrInvalidFingerprint       = errors.New("invalid client fingerprint")
+	ErrInvalidEmailToken        = errors.New("email token is invalid, expired or already used")
+	ErrEmailNotVerified         = errors.New("user email is not verified")
+	ErrInvalidMfaToken          = errors.New("mfa challenge token is invalid or expired")
+	ErrInvalidMfaCode           = errors.New("invalid two-factor authentication code")
+	ErrMfaNotEnrolled           = errors.New("two-factor authentication is not enrolled")
+	ErrMfaAlreadyEnabled        = errors.New("two-factor authentication is already enabled")
+	ErrTooManyAttempts          = errors.New("too many attempts, try again later")
+	ErrUnknownIdentityProvider  = errors.New("identity provider is not configured")
+	ErrInvalidOidcState         = errors.New("oidc login state is invalid or expired")
+	ErrOidcExchangeFailed       = errors.New("failed to complete oidc login with identity provider")
+	ErrExternalEmailNotVerified = errors.New("identity provider did not verify user email")
+	ErrInvalidApiKey            = errors.New("api key is invalid or revoked")
+	ErrApiKeyInvalidScope       = errors.New("api key scope is not supported")
+	ErrApiKeyEmptyName          = errors.New("api key name is empty")
+	ErrApiKeyScopeDenied        = errors.New("api key scope does not allow this request")
+)
+
+// CourseTransitionError names statuses of the transition
+// missing from the course transition table
+type CourseTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *CourseTransitionError) Error() string {
+	return fmt.Sprintf("course can't move from %s to %s status", e.From, e.To)
+}
+
+func (e *CourseTransitionError) Unwrap() error {
+	return ErrCourseInvalidTransition
+}
+
+// PrerequisitesError lists requirements of the course the user hasn't met
+type PrerequisitesError struct {
+	Requirements []string
+}
+
+func (e *PrerequisitesError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrCoursePrerequisitesNotMet.Error(), strings.Join(e.Requirements, "; "))
+}
+
+func (e *PrerequisitesError) Unwrap() error {
+	return ErrCoursePrerequisitesNotMet
+}
+
+type RetryAfterError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return ErrTooManyAttempts.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return ErrTooManyAttempts
+}
@@ -0,0 +1,8 @@
+package port
+
+import "github.com/paw1a/eschool/internal/core/domain"
+
+type CreateApiKeyParam struct {
+	Name   string
+	Scopes []domain.ApiKeyScope
+}
@@ -1,6 +1,7 @@
 package port
 
 import (
+	"context"
 	"github.com/guregu/null"
 	"github.com/paw1a/eschool/internal/core/domain"
 )
@@ -9,6 +10,7 @@ type SignInParam struct {
 	Email       string
 	Password    string
 	Fingerprint string
+	IP          string
 }
 
 type SignUpParam struct {
@@ -21,9 +23,44 @@ type SignUpParam struct {
 	AvatarUrl null.String
 }
 
+type ResetPasswordParam struct {
+	Token    domain.Token
+	Password string
+}
+
+type MfaVerifyParam struct {
+	MfaToken    domain.Token
+	Code        string
+	Fingerprint string
+}
+
+type OidcSignInParam struct {
+	Provider    string
+	State       string
+	Code        string
+	Fingerprint string
+}
+
 type IAuthProvider interface {
 	CreateJWTSession(payload domain.AuthPayload, fingerprint string) (domain.AuthDetails, error)
 	RefreshJWTSession(refreshToken domain.Token, fingerprint string) (domain.AuthDetails, error)
 	DeleteJWTSession(refreshToken domain.Token) error
+	DeleteUserJWTSessions(userID domain.ID) error
 	VerifyJWTToken(accessToken domain.Token) (domain.AuthPayload, error)
+	CreateEmailToken(userID domain.ID, purpose domain.EmailTokenPurpose) (domain.EmailToken, domain.Token, error)
+	VerifyEmailToken(token domain.Token) (domain.EmailToken, error)
+	CreateMfaToken(userID domain.ID) (domain.Token, error)
+	VerifyMfaToken(token domain.Token) (domain.ID, error)
+}
+
+type IIdentityProvider interface {
+	AuthCodeUrl(ctx context.Context, provider string) (string, error)
+	Exchange(ctx context.Context, provider, state, code string) (domain.ExternalProfile, error)
+}
+
+type IOtpProvider interface {
+	GenerateSecret() (string, error)
+	GenerateRecoveryCodes() ([]string, error)
+	ProvisioningUri(secret, account string) string
+	ValidateCode(secret, code string) bool
 }
@@ -0,0 +1,10 @@
+package port
+
+import "github.com/paw1a/eschool/internal/core/domain"
+
+type CreateBundleParam struct {
+	Name        string
+	Description string
+	Price       int64
+	CourseIDs   []domain.ID
+}
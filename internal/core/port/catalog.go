@@ -0,0 +1,16 @@
+package port
+
+import "github.com/paw1a/eschool/internal/core/domain"
+
+type CreateCategoryParam struct {
+	Name string
+	// ParentID is empty for the root category
+	ParentID domain.ID
+}
+
+// CourseFilter selects catalog courses, empty fields don't filter,
+// category filter includes courses of its subcategories
+type CourseFilter struct {
+	CategoryID domain.ID
+	Tag        string
+}
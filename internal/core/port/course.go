@@ -1,24 +1,38 @@
 package port
 
-import "github.com/guregu/null"
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+)
 
 type CourseInfo struct {
 	Name     string
 	Level    int
-	Price    int64
+	Price    domain.Money
 	Language string
 }
 
 type CreateCourseParam struct {
-	Name     string
-	Level    int
-	Price    int64
-	Language string
+	Name       string
+	Level      int
+	Price      int64
+	Currency   string
+	Language   string
+	Capacity   null.Int
+	AccessDays null.Int
 }
 
 type UpdateCourseParam struct {
-	Name     null.String
-	Level    null.Int
-	Price    null.Int
-	Language null.String
+	Name       null.String
+	Level      null.Int
+	Price      null.Int
+	Currency   null.String
+	Language   null.String
+	Capacity   null.Int
+	AccessDays null.Int
+}
+
+type AddPrerequisiteParam struct {
+	RequiredCourseID domain.ID
+	MinScore         null.Int
 }
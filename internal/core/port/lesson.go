@@ -55,3 +55,9 @@ type UpdateTestParam struct {
 	Level   int
 	Score   int
 }
+
+// UpdateLessonReleaseParam sets one release rule, both nulls release the lesson at once
+type UpdateLessonReleaseParam struct {
+	ReleaseAt   null.Time
+	ReleaseDays null.Int
+}
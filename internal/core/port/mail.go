@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"github.com/paw1a/eschool/internal/core/domain"
+)
+
+type IMailer interface {
+	Send(ctx context.Context, mail domain.Mail) error
+}
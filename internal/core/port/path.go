@@ -0,0 +1,9 @@
+package port
+
+import "github.com/paw1a/eschool/internal/core/domain"
+
+type CreatePathParam struct {
+	Name        string
+	Description string
+	CourseIDs   []domain.ID
+}
@@ -9,4 +9,8 @@ import (
 type IPaymentGateway interface {
 	GetPaymentUrl(ctx context.Context, payload domain.PaymentPayload) (url.URL, error)
 	ProcessPayment(ctx context.Context, key string) (domain.PaymentPayload, error)
+	Refund(ctx context.Context, refund domain.Refund) error
+	ChargeRecurring(ctx context.Context, payload domain.PaymentPayload,
+		paymentMethodID, idempotenceKey string) (string, error)
+	CancelRecurring(ctx context.Context, paymentMethodID string) error
 }
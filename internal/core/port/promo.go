@@ -0,0 +1,19 @@
+package port
+
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+)
+
+type CreatePromoCodeParam struct {
+	CourseID     null.String
+	Code         string
+	DiscountType domain.PromoDiscountType
+	Discount     int64
+	// Currency of fixed discount defaults to the course currency for course
+	// codes and to the default currency for school wide codes, percent codes have none
+	Currency   string
+	MaxUses    null.Int
+	ValidFrom  null.Time
+	ValidUntil null.Time
+}
@@ -0,0 +1,15 @@
+package port
+
+// QuizImport is the quiz read from the QTI or Common Cartridge package,
+// every assessment becomes the practice lesson
+type QuizImport struct {
+	Lessons []CreatePracticeParam
+	Skipped []SkippedQuizItem
+}
+
+// SkippedQuizItem is the item that can't be mapped to the test
+type SkippedQuizItem struct {
+	Identifier string
+	Title      string
+	Reason     string
+}
@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+type IRateLimiter interface {
+	Check(ctx context.Context, key string) (time.Duration, error)
+	Hit(ctx context.Context, key string) (time.Duration, error)
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,5 @@
+package port
+
+type CreateRefundParam struct {
+	Reason string
+}
@@ -2,7 +2,9 @@ package port
 
 import (
 	"context"
+	"github.com/guregu/null"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
 )
 
 type IUserRepository interface {
@@ -24,11 +26,24 @@ type ICourseRepository interface {
 	FindCourseTeachers(ctx context.Context, courseID domain.ID) ([]domain.User, error)
 	IsCourseStudent(ctx context.Context, studentID, courseID domain.ID) (bool, error)
 	IsCourseTeacher(ctx context.Context, teacherID, courseID domain.ID) (bool, error)
+	FindEnrollment(ctx context.Context, studentID, courseID domain.ID) (domain.Enrollment, error)
+	FindExpiringEnrollments(ctx context.Context, from, to time.Time) ([]domain.Enrollment, error)
 	AddCourseStudent(ctx context.Context, studentID, courseID domain.ID) error
+	RenewCourseStudent(ctx context.Context, studentID, courseID domain.ID,
+		renewalKey string, renewedAt time.Time) (domain.Enrollment, error)
+	MarkEnrollmentReminded(ctx context.Context, studentID, courseID domain.ID, remindedAt time.Time) error
 	AddCourseTeacher(ctx context.Context, teacherID, courseID domain.ID) error
+	RemoveCourseStudent(ctx context.Context, studentID, courseID domain.ID) error
 	Create(ctx context.Context, course domain.Course) (domain.Course, error)
 	Update(ctx context.Context, course domain.Course) (domain.Course, error)
-	UpdateStatus(ctx context.Context, courseID domain.ID, status domain.CourseStatus) error
+	UpdateStatus(ctx context.Context, transition domain.CourseTransition) error
+	FindCourseTransitions(ctx context.Context, courseID domain.ID) ([]domain.CourseTransition, error)
+	PublishRevision(ctx context.Context, courseID domain.ID) error
+	UpdateEnrollmentVersion(ctx context.Context, studentID, courseID domain.ID, version int) error
+	UpdatePublishAt(ctx context.Context, courseID domain.ID, publishAt null.Time) error
+	FindCoursePrerequisites(ctx context.Context, courseID domain.ID) ([]domain.CoursePrerequisite, error)
+	AddCoursePrerequisite(ctx context.Context, prerequisite domain.CoursePrerequisite) error
+	RemoveCoursePrerequisite(ctx context.Context, courseID, requiredCourseID domain.ID) error
 	Delete(ctx context.Context, courseID domain.ID) error
 }
 
@@ -36,8 +51,14 @@ type ILessonRepository interface {
 	FindAll(ctx context.Context) ([]domain.Lesson, error)
 	FindByID(ctx context.Context, lessonID domain.ID) (domain.Lesson, error)
 	FindCourseLessons(ctx context.Context, courseID domain.ID) ([]domain.Lesson, error)
+	FindCourseVersionLessons(ctx context.Context, courseID domain.ID, version int) ([]domain.Lesson, error)
 	FindLessonTests(ctx context.Context, lessonID domain.ID) ([]domain.Test, error)
 	Create(ctx context.Context, lesson domain.Lesson) (domain.Lesson, error)
+	CreateRevision(ctx context.Context, courseID domain.ID, version int, lessons []domain.Lesson) error
+	CreateCourseLessons(ctx context.Context, lessons []domain.Lesson) error
+	UpdateRelease(ctx context.Context, lessonID domain.ID, releaseAt null.Time,
+		releaseDays null.Int) (domain.Lesson, error)
+	UpdatePreview(ctx context.Context, lessonID domain.ID, isPreview bool) (domain.Lesson, error)
 	Update(ctx context.Context, lesson domain.Lesson) (domain.Lesson, error)
 	Delete(ctx context.Context, lessonID domain.ID) error
 }
@@ -69,3 +90,130 @@ type IStatRepository interface {
 	CreateLessonStat(ctx context.Context, stat domain.LessonStat) error
 	UpdateLessonStat(ctx context.Context, stat domain.LessonStat) error
 }
+
+type IEmailTokenRepository interface {
+	Create(ctx context.Context, token domain.EmailToken) error
+	Use(ctx context.Context, tokenID domain.ID) error
+}
+
+type IMfaRepository interface {
+	FindByUserID(ctx context.Context, userID domain.ID) (domain.MfaSettings, error)
+	Save(ctx context.Context, settings domain.MfaSettings) error
+	Delete(ctx context.Context, userID domain.ID) error
+	ReplaceRecoveryCodes(ctx context.Context, userID domain.ID, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID domain.ID, codeHash string) error
+}
+
+type IExternalIdentityRepository interface {
+	FindByProviderSubject(ctx context.Context, provider, subject string) (domain.ExternalIdentity, error)
+	Create(ctx context.Context, identity domain.ExternalIdentity) error
+}
+
+type IApiKeyRepository interface {
+	FindUserKeys(ctx context.Context, userID domain.ID) ([]domain.ApiKey, error)
+	FindByHash(ctx context.Context, keyHash string) (domain.ApiKey, error)
+	Create(ctx context.Context, key domain.ApiKey) (domain.ApiKey, error)
+	Revoke(ctx context.Context, userID, keyID domain.ID) error
+	UpdateLastUsed(ctx context.Context, keyID domain.ID, usedAt time.Time) error
+}
+
+type IPromoCodeRepository interface {
+	FindSchoolPromoCodes(ctx context.Context, schoolID domain.ID) ([]domain.PromoCode, error)
+	FindByCode(ctx context.Context, schoolID domain.ID, code string) (domain.PromoCode, error)
+	FindByID(ctx context.Context, promoID domain.ID) (domain.PromoCode, error)
+	Create(ctx context.Context, promo domain.PromoCode) (domain.PromoCode, error)
+	Delete(ctx context.Context, schoolID, promoID domain.ID) error
+	Redeem(ctx context.Context, redemption domain.PromoRedemption) error
+}
+
+type IPaymentRepository interface {
+	FindCoursePayment(ctx context.Context, userID, courseID domain.ID) (domain.Payment, error)
+	Create(ctx context.Context, payment domain.Payment) error
+}
+
+type IRefundRepository interface {
+	FindByID(ctx context.Context, refundID domain.ID) (domain.Refund, error)
+	FindUserRefunds(ctx context.Context, userID domain.ID) ([]domain.Refund, error)
+	FindSchoolRefunds(ctx context.Context, schoolID domain.ID) ([]domain.Refund, error)
+	Create(ctx context.Context, refund domain.Refund) (domain.Refund, error)
+	StartApproval(ctx context.Context, refundID domain.ID) error
+	CancelApproval(ctx context.Context, refundID domain.ID) error
+	Approve(ctx context.Context, refundID, approverID domain.ID, approvedAt time.Time) error
+	Reject(ctx context.Context, refundID, approverID domain.ID, rejectedAt time.Time) error
+}
+
+type IWaitlistRepository interface {
+	FindCourseWaitlist(ctx context.Context, courseID domain.ID) ([]domain.WaitlistEntry, error)
+	Create(ctx context.Context, entry domain.WaitlistEntry) error
+	Delete(ctx context.Context, courseID, userID domain.ID) error
+	HoldSeat(ctx context.Context, hold domain.SeatHold) error
+	OfferSeats(ctx context.Context, courseID domain.ID, offeredUntil time.Time) ([]domain.WaitlistEntry, error)
+}
+
+type IBundleRepository interface {
+	FindByID(ctx context.Context, bundleID domain.ID) (domain.Bundle, error)
+	FindSchoolBundles(ctx context.Context, schoolID domain.ID) ([]domain.Bundle, error)
+	FindBundleCourses(ctx context.Context, bundleID domain.ID) ([]domain.Course, error)
+	Create(ctx context.Context, bundle domain.Bundle, courseIDs []domain.ID) (domain.Bundle, error)
+	Publish(ctx context.Context, bundleID domain.ID) error
+	Delete(ctx context.Context, schoolID, bundleID domain.ID) error
+}
+
+type ILedgerRepository interface {
+	FindSchoolEntries(ctx context.Context, schoolID domain.ID, from, to time.Time) ([]domain.LedgerEntry, error)
+	Create(ctx context.Context, entry domain.LedgerEntry) error
+	FindSchoolPayouts(ctx context.Context, schoolID domain.ID) ([]domain.Payout, error)
+	CreatePayout(ctx context.Context, payout domain.Payout) (domain.Payout, error)
+}
+
+type IGiftRepository interface {
+	FindByCode(ctx context.Context, code string) (domain.Gift, error)
+	Create(ctx context.Context, gift domain.Gift) (domain.Gift, error)
+	Redeem(ctx context.Context, giftID, userID domain.ID, redeemedAt time.Time) error
+	CancelRedemption(ctx context.Context, giftID, userID domain.ID) error
+}
+
+type ISubscriptionRepository interface {
+	FindPlanByID(ctx context.Context, planID domain.ID) (domain.SubscriptionPlan, error)
+	FindSchoolPlan(ctx context.Context, schoolID domain.ID) (domain.SubscriptionPlan, error)
+	SavePlan(ctx context.Context, plan domain.SubscriptionPlan) (domain.SubscriptionPlan, error)
+	FindUserSubscription(ctx context.Context, userID, schoolID domain.ID) (domain.Subscription, error)
+	FindUserSubscriptions(ctx context.Context, userID domain.ID) ([]domain.Subscription, error)
+	ClaimRenewableSubscriptions(ctx context.Context, now, until,
+		lockedUntil time.Time) ([]domain.Subscription, error)
+	Extend(ctx context.Context, subscription domain.Subscription, paymentKey string) (domain.Subscription, error)
+	Cancel(ctx context.Context, subscriptionID domain.ID, canceledAt time.Time) error
+}
+
+type IJobRepository interface {
+	Create(ctx context.Context, job domain.Job) error
+	ClaimDueJobs(ctx context.Context, now, lockedUntil time.Time, limit int) ([]domain.Job, error)
+	Complete(ctx context.Context, jobID domain.ID) error
+	Retry(ctx context.Context, jobID domain.ID, retryAt time.Time, lastError string) error
+	Fail(ctx context.Context, jobID domain.ID, lastError string) error
+}
+
+type ILearningPathRepository interface {
+	FindByID(ctx context.Context, pathID domain.ID) (domain.LearningPath, error)
+	FindSchoolPaths(ctx context.Context, schoolID domain.ID) ([]domain.LearningPath, error)
+	FindPathCourses(ctx context.Context, pathID domain.ID) ([]domain.Course, error)
+	Create(ctx context.Context, path domain.LearningPath, courseIDs []domain.ID) (domain.LearningPath, error)
+	Delete(ctx context.Context, schoolID, pathID domain.ID) error
+	FindCertificateByID(ctx context.Context, certificateID domain.ID) (domain.PathCertificate, error)
+	FindUserCertificate(ctx context.Context, userID, pathID domain.ID) (domain.PathCertificate, error)
+	CreateCertificate(ctx context.Context, certificate domain.PathCertificate) (domain.PathCertificate, error)
+}
+
+type ICatalogRepository interface {
+	FindCategories(ctx context.Context) ([]domain.Category, error)
+	FindCategoryByID(ctx context.Context, categoryID domain.ID) (domain.Category, error)
+	FindCategoryAssignments(ctx context.Context) ([]domain.CourseCategory, error)
+	CreateCategory(ctx context.Context, category domain.Category) (domain.Category, error)
+	DeleteCategory(ctx context.Context, categoryID domain.ID) error
+	FindCourseCategories(ctx context.Context, courseID domain.ID) ([]domain.Category, error)
+	UpdateCourseCategories(ctx context.Context, courseID domain.ID, categoryIDs []domain.ID) error
+	FindSchoolTags(ctx context.Context, schoolID domain.ID) ([]domain.Tag, error)
+	FindCourseTags(ctx context.Context, courseID domain.ID) ([]domain.Tag, error)
+	UpdateCourseTags(ctx context.Context, courseID, schoolID domain.ID, names []string) ([]domain.Tag, error)
+	FindCourses(ctx context.Context, categoryID domain.ID, tag string) ([]domain.Course, error)
+}
@@ -2,9 +2,11 @@ package port
 
 import (
 	"context"
+	"github.com/guregu/null"
 	"github.com/paw1a/eschool/internal/core/domain"
 	"io"
 	"net/url"
+	"time"
 )
 
 type IUserService interface {
@@ -23,14 +25,34 @@ type ICourseService interface {
 	FindStudentCourses(ctx context.Context, studentID domain.ID) ([]domain.Course, error)
 	FindTeacherCourses(ctx context.Context, teacherID domain.ID) ([]domain.Course, error)
 	FindCourseTeachers(ctx context.Context, courseID domain.ID) ([]domain.User, error)
+	// IsCourseStudent reports active enrollment or active subscription
+	// to the school of the published course
 	IsCourseStudent(ctx context.Context, studentID, courseID domain.ID) (bool, error)
 	IsCourseTeacher(ctx context.Context, teacherID, courseID domain.ID) (bool, error)
+	FindEnrollment(ctx context.Context, studentID, courseID domain.ID) (domain.Enrollment, error)
 	AddCourseStudent(ctx context.Context, studentID, courseID domain.ID) error
+	RenewCourseStudent(ctx context.Context, studentID, courseID domain.ID,
+		renewalKey string) (domain.Enrollment, error)
 	AddCourseTeacher(ctx context.Context, teacherID, courseID domain.ID) error
-	ConfirmDraftCourse(ctx context.Context, courseID domain.ID) []error
-	PublishReadyCourse(ctx context.Context, courseID domain.ID) error
+	RemoveCourseStudent(ctx context.Context, studentID, courseID domain.ID) error
+	ValidateCourse(ctx context.Context, courseID domain.ID) (domain.CourseValidationReport, error)
+	ConfirmDraftCourse(ctx context.Context, userID, courseID domain.ID) (domain.CourseValidationReport, error)
+	PublishReadyCourse(ctx context.Context, userID, courseID domain.ID) error
+	ChangeCourseStatus(ctx context.Context, userID, courseID domain.ID, status domain.CourseStatus) error
+	FindCourseTransitions(ctx context.Context, courseID domain.ID) ([]domain.CourseTransition, error)
+	CreateCourseRevision(ctx context.Context, courseID domain.ID) (domain.Course, error)
+	PublishCourseRevision(ctx context.Context, courseID domain.ID) error
+	MigrateStudentVersion(ctx context.Context, studentID, courseID domain.ID) (domain.Enrollment, error)
+	ScheduleCoursePublish(ctx context.Context, courseID domain.ID, publishAt null.Time) (domain.Course, error)
+	PublishScheduledCourse(ctx context.Context, job domain.Job) error
+	FindCoursePrerequisites(ctx context.Context, courseID domain.ID) ([]domain.CoursePrerequisite, error)
+	AddCoursePrerequisite(ctx context.Context, courseID domain.ID, param AddPrerequisiteParam) error
+	RemoveCoursePrerequisite(ctx context.Context, courseID, requiredCourseID domain.ID) error
+	FindCourseProgress(ctx context.Context, studentID, courseID domain.ID) (domain.CourseProgress, error)
+	CheckCoursePrerequisites(ctx context.Context, studentID, courseID domain.ID) error
 	CreateSchoolCourse(ctx context.Context, schoolID domain.ID,
 		param CreateCourseParam) (domain.Course, error)
+	DuplicateCourse(ctx context.Context, courseID, schoolID domain.ID) (domain.Course, error)
 	Update(ctx context.Context, courseID domain.ID,
 		param UpdateCourseParam) (domain.Course, error)
 	Delete(ctx context.Context, courseID domain.ID) error
@@ -40,6 +62,7 @@ type ILessonService interface {
 	FindAll(ctx context.Context) ([]domain.Lesson, error)
 	FindByID(ctx context.Context, lessonID domain.ID) (domain.Lesson, error)
 	FindCourseLessons(ctx context.Context, courseID domain.ID) ([]domain.Lesson, error)
+	FindCourseVersionLessons(ctx context.Context, courseID domain.ID, version int) ([]domain.Lesson, error)
 	CreateTheoryLesson(ctx context.Context, courseID domain.ID,
 		param CreateTheoryParam) (domain.Lesson, error)
 	CreateVideoLesson(ctx context.Context, courseID domain.ID,
@@ -52,9 +75,22 @@ type ILessonService interface {
 		param UpdateVideoParam) (domain.Lesson, error)
 	UpdatePracticeLesson(ctx context.Context, lessonID domain.ID,
 		param UpdatePracticeParam) (domain.Lesson, error)
+	UpdateLessonRelease(ctx context.Context, lessonID domain.ID,
+		param UpdateLessonReleaseParam) (domain.Lesson, error)
+	UpdateLessonPreview(ctx context.Context, lessonID domain.ID, isPreview bool) (domain.Lesson, error)
+	CopyCourseLessons(ctx context.Context, fromCourseID, toCourseID domain.ID) ([]domain.Lesson, error)
 	Delete(ctx context.Context, lessonID domain.ID) error
 }
 
+type ICourseArchiveService interface {
+	ExportCourse(ctx context.Context, courseID domain.ID, w io.Writer) error
+	ImportCourse(ctx context.Context, schoolID domain.ID, reader io.ReaderAt, size int64) (domain.Course, error)
+}
+
+type IQuizImportService interface {
+	ParseQuiz(ctx context.Context, reader io.ReaderAt, size int64) (QuizImport, error)
+}
+
 type ISchoolService interface {
 	FindAll(ctx context.Context) ([]domain.School, error)
 	FindByID(ctx context.Context, schoolID domain.ID) (domain.School, error)
@@ -80,14 +116,18 @@ type IReviewService interface {
 
 type IStatService interface {
 	FindLessonStat(ctx context.Context, userID, lessonID domain.ID) (domain.LessonStat, error)
+	FindOrCreateLessonStat(ctx context.Context, userID, lessonID domain.ID) (domain.LessonStat, error)
 	CreateLessonStat(ctx context.Context, userID, lessonID domain.ID) error
 	UpdateLessonStat(ctx context.Context, userID, lessonID domain.ID,
 		param UpdateLessonStatParam) error
 }
 
 type IPaymentService interface {
-	GetCoursePaymentUrl(ctx context.Context, userID, courseID domain.ID) (url.URL, error)
+	GetCoursePaymentUrl(ctx context.Context, userID, courseID domain.ID, promoCode string) (url.URL, error)
+	GetBundlePaymentUrl(ctx context.Context, userID, bundleID domain.ID) (url.URL, error)
+	GetGiftPaymentUrl(ctx context.Context, userID, courseID domain.ID, recipientEmail string) (url.URL, error)
 	ProcessCoursePayment(ctx context.Context, label string, paid int64) (domain.PaymentPayload, error)
+	ProcessVerifiedPayment(ctx context.Context, key string) (domain.PaymentPayload, error)
 }
 
 type IMediaService interface {
@@ -97,6 +137,13 @@ type IMediaService interface {
 	SaveTestQuestion(ctx context.Context, testID domain.ID, reader io.Reader) (domain.Url, error)
 }
 
+type IApiKeyService interface {
+	FindUserKeys(ctx context.Context, userID domain.ID) ([]domain.ApiKey, error)
+	Create(ctx context.Context, userID domain.ID, param CreateApiKeyParam) (domain.ApiKey, domain.Token, error)
+	Revoke(ctx context.Context, userID, keyID domain.ID) error
+	Verify(ctx context.Context, token domain.Token) (domain.ApiKey, error)
+}
+
 type IAuthTokenService interface {
 	SignIn(ctx context.Context, param SignInParam) (domain.AuthDetails, error)
 	SignUp(ctx context.Context, param SignUpParam) error
@@ -104,4 +151,107 @@ type IAuthTokenService interface {
 	Refresh(ctx context.Context, refreshToken domain.Token, fingerprint string) (domain.AuthDetails, error)
 	Verify(ctx context.Context, accessToken domain.Token) error
 	Payload(ctx context.Context, accessToken domain.Token) (domain.AuthPayload, error)
+	VerifyEmail(ctx context.Context, token domain.Token) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, param ResetPasswordParam) error
+	EnrollMfa(ctx context.Context, userID domain.ID) (domain.MfaEnrollment, error)
+	ConfirmMfa(ctx context.Context, userID domain.ID, code string) error
+	DisableMfa(ctx context.Context, userID domain.ID, code string) error
+	VerifyMfa(ctx context.Context, param MfaVerifyParam) (domain.AuthDetails, error)
+	OidcAuthUrl(ctx context.Context, provider string) (string, error)
+	OidcSignIn(ctx context.Context, param OidcSignInParam) (domain.AuthDetails, error)
+}
+
+type IPromoCodeService interface {
+	FindSchoolPromoCodes(ctx context.Context, schoolID domain.ID) ([]domain.PromoCode, error)
+	CreateSchoolPromoCode(ctx context.Context, schoolID domain.ID,
+		param CreatePromoCodeParam) (domain.PromoCode, error)
+	Delete(ctx context.Context, schoolID, promoID domain.ID) error
+}
+
+type IRefundService interface {
+	FindUserRefunds(ctx context.Context, userID domain.ID) ([]domain.Refund, error)
+	FindSchoolRefunds(ctx context.Context, schoolID domain.ID) ([]domain.Refund, error)
+	RequestCourseRefund(ctx context.Context, userID, courseID domain.ID,
+		param CreateRefundParam) (domain.Refund, error)
+	ApproveRefund(ctx context.Context, schoolID, refundID, approverID domain.ID) (domain.Refund, error)
+	RejectRefund(ctx context.Context, schoolID, refundID, approverID domain.ID) (domain.Refund, error)
+}
+
+type IWaitlistService interface {
+	FindCourseWaitlist(ctx context.Context, courseID domain.ID) ([]domain.WaitlistEntry, error)
+	JoinCourseWaitlist(ctx context.Context, userID, courseID domain.ID) (domain.WaitlistEntry, error)
+	LeaveCourseWaitlist(ctx context.Context, userID, courseID domain.ID) error
+	OfferFreeSeats(ctx context.Context, courseID domain.ID) error
+}
+
+// ISeatListener is notified by the services which free a seat of the course
+type ISeatListener interface {
+	OfferFreeSeats(ctx context.Context, courseID domain.ID) error
+}
+
+type IBundleService interface {
+	FindByID(ctx context.Context, bundleID domain.ID) (domain.Bundle, error)
+	FindSchoolBundles(ctx context.Context, schoolID domain.ID) ([]domain.Bundle, error)
+	FindBundleCourses(ctx context.Context, bundleID domain.ID) ([]domain.Course, error)
+	CreateSchoolBundle(ctx context.Context, schoolID domain.ID, param CreateBundleParam) (domain.Bundle, error)
+	PublishSchoolBundle(ctx context.Context, schoolID, bundleID domain.ID) error
+	Delete(ctx context.Context, schoolID, bundleID domain.ID) error
+}
+
+type ILedgerService interface {
+	FindSchoolStatement(ctx context.Context, schoolID domain.ID, month time.Time) (domain.Statement, error)
+	FindSchoolPayouts(ctx context.Context, schoolID domain.ID) ([]domain.Payout, error)
+	CreateSchoolPayout(ctx context.Context, schoolID domain.ID, currency string,
+		settledUntil time.Time) (domain.Payout, error)
+}
+
+type IEnrollmentService interface {
+	RemindExpiringEnrollments(ctx context.Context) error
+}
+
+type IGiftService interface {
+	IssueGift(ctx context.Context, paymentKey string, payload domain.PaymentPayload) (domain.Gift, error)
+	RedeemGift(ctx context.Context, userID domain.ID, code string) (domain.Gift, error)
+	CancelGiftRedemption(ctx context.Context, userID domain.ID, gift domain.Gift) error
+}
+
+type ISubscriptionService interface {
+	FindSchoolPlan(ctx context.Context, schoolID domain.ID) (domain.SubscriptionPlan, error)
+	SaveSchoolPlan(ctx context.Context, schoolID domain.ID, param SubscriptionPlanParam) (domain.SubscriptionPlan, error)
+	FindUserSubscriptions(ctx context.Context, userID domain.ID) ([]domain.Subscription, error)
+	GetSubscriptionPaymentUrl(ctx context.Context, userID, schoolID domain.ID) (url.URL, error)
+	ActivateSubscription(ctx context.Context, paymentKey string, payload domain.PaymentPayload) (domain.Subscription, error)
+	CancelSubscription(ctx context.Context, userID, schoolID domain.ID) error
+	RenewSubscriptions(ctx context.Context) error
+}
+
+type IJobService interface {
+	ScheduleJob(ctx context.Context, job domain.Job) error
+	ClaimDueJobs(ctx context.Context, limit int) ([]domain.Job, error)
+	CompleteJob(ctx context.Context, job domain.Job) error
+	FailJob(ctx context.Context, job domain.Job, cause error) error
+}
+
+type ILearningPathService interface {
+	FindByID(ctx context.Context, pathID domain.ID) (domain.LearningPath, error)
+	FindSchoolPaths(ctx context.Context, schoolID domain.ID) ([]domain.LearningPath, error)
+	FindPathCourses(ctx context.Context, pathID domain.ID) ([]domain.Course, error)
+	CreateSchoolPath(ctx context.Context, schoolID domain.ID, param CreatePathParam) (domain.LearningPath, error)
+	Delete(ctx context.Context, schoolID, pathID domain.ID) error
+	FindPathProgress(ctx context.Context, userID, pathID domain.ID) (domain.PathProgress, error)
+	IssuePathCertificate(ctx context.Context, userID, pathID domain.ID) (domain.PathCertificate, error)
+	FindCertificate(ctx context.Context, certificateID domain.ID) (domain.PathCertificate, error)
+}
+
+type ICatalogService interface {
+	FindCategoryTree(ctx context.Context) ([]domain.CategoryNode, error)
+	CreateCategory(ctx context.Context, param CreateCategoryParam) (domain.Category, error)
+	DeleteCategory(ctx context.Context, categoryID domain.ID) error
+	FindCourseCategories(ctx context.Context, courseID domain.ID) ([]domain.Category, error)
+	UpdateCourseCategories(ctx context.Context, courseID domain.ID, categoryIDs []domain.ID) ([]domain.Category, error)
+	FindSchoolTags(ctx context.Context, schoolID domain.ID) ([]domain.Tag, error)
+	FindCourseTags(ctx context.Context, courseID domain.ID) ([]domain.Tag, error)
+	UpdateCourseTags(ctx context.Context, courseID domain.ID, names []string) ([]domain.Tag, error)
+	FindCourses(ctx context.Context, filter CourseFilter) ([]domain.Course, error)
 }
@@ -3,8 +3,14 @@ package port
 import (
 	"context"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"io"
 )
 
 type IObjectStorage interface {
 	SaveFile(ctx context.Context, file domain.File) (domain.Url, error)
+	// CopyFile copies the stored file to the new path and name
+	CopyFile(ctx context.Context, url domain.Url, path, name string) (domain.Url, error)
+	// LoadFile opens the stored file for reading, files from other hosts
+	// are reported with errs.ErrFileNotStored
+	LoadFile(ctx context.Context, url domain.Url) (io.ReadCloser, error)
 }
@@ -0,0 +1,8 @@
+package port
+
+type SubscriptionPlanParam struct {
+	Name       string
+	Price      int64
+	Currency   string
+	PeriodDays int
+}
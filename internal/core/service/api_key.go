@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+const (
+	apiKeyTokenPrefix = "esk_"
+	apiKeySecretSize  = 32
+	apiKeyPrefixSize  = 8
+)
+
+type ApiKeyService struct {
+	repo   port.IApiKeyRepository
+	logger *zap.Logger
+}
+
+func NewApiKeyService(repo port.IApiKeyRepository, logger *zap.Logger) *ApiKeyService {
+	return &ApiKeyService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func (a *ApiKeyService) FindUserKeys(ctx context.Context, userID domain.ID) ([]domain.ApiKey, error) {
+	keys, err := a.repo.FindUserKeys(ctx, userID)
+	if err != nil {
+		a.logger.Error("failed to find user api keys", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (a *ApiKeyService) Create(ctx context.Context, userID domain.ID,
+	param port.CreateApiKeyParam) (domain.ApiKey, domain.Token, error) {
+	if strings.TrimSpace(param.Name) == "" {
+		return domain.ApiKey{}, "", errs.ErrApiKeyEmptyName
+	}
+	if len(param.Scopes) == 0 {
+		return domain.ApiKey{}, "", errs.ErrApiKeyInvalidScope
+	}
+	for _, scope := range param.Scopes {
+		if !scope.Valid() {
+			return domain.ApiKey{}, "", errs.ErrApiKeyInvalidScope
+		}
+	}
+
+	secret := make([]byte, apiKeySecretSize)
+	_, err := rand.Read(secret)
+	if err != nil {
+		a.logger.Error("failed to generate api key", zap.Error(err))
+		return domain.ApiKey{}, "", err
+	}
+	encodedSecret := base64.RawURLEncoding.EncodeToString(secret)
+	token := domain.Token(apiKeyTokenPrefix + encodedSecret)
+
+	key, err := a.repo.Create(ctx, domain.ApiKey{
+		ID:        domain.NewID(),
+		UserID:    userID,
+		Name:      param.Name,
+		Prefix:    apiKeyTokenPrefix + encodedSecret[:apiKeyPrefixSize],
+		KeyHash:   hashApiKey(token),
+		Scopes:    param.Scopes,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		a.logger.Error("failed to create api key", zap.Error(err), zap.String("userID", userID.String()))
+		return domain.ApiKey{}, "", err
+	}
+
+	a.logger.Info("api key is created", zap.String("userID", userID.String()),
+		zap.String("apiKeyID", key.ID.String()))
+	return key, token, nil
+}
+
+func (a *ApiKeyService) Revoke(ctx context.Context, userID, keyID domain.ID) error {
+	err := a.repo.Revoke(ctx, userID, keyID)
+	if err != nil {
+		a.logger.Error("failed to revoke api key", zap.Error(err),
+			zap.String("userID", userID.String()), zap.String("apiKeyID", keyID.String()))
+		return err
+	}
+
+	a.logger.Info("api key is revoked", zap.String("userID", userID.String()),
+		zap.String("apiKeyID", keyID.String()))
+	return nil
+}
+
+func (a *ApiKeyService) Verify(ctx context.Context, token domain.Token) (domain.ApiKey, error) {
+	key, err := a.repo.FindByHash(ctx, hashApiKey(token))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotExist) {
+			return domain.ApiKey{}, errs.ErrInvalidApiKey
+		}
+		a.logger.Error("failed to find api key", zap.Error(err))
+		return domain.ApiKey{}, err
+	}
+
+	if key.Revoked() {
+		a.logger.Warn("revoked api key is used", zap.String("apiKeyID", key.ID.String()))
+		return domain.ApiKey{}, errs.ErrInvalidApiKey
+	}
+
+	err = a.repo.UpdateLastUsed(ctx, key.ID, time.Now().UTC())
+	if err != nil {
+		a.logger.Error("failed to update api key last used time", zap.Error(err),
+			zap.String("apiKeyID", key.ID.String()))
+	}
+	return key, nil
+}
+
+func hashApiKey(token domain.Token) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
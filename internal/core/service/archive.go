@@ -0,0 +1,558 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"github.com/pkg/errors"
+	"github.com/twinj/uuid"
+	"go.uber.org/zap"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// courseArchiveVersion is the version of the archive format,
+// it grows when the manifest changes in a backward incompatible way
+const courseArchiveVersion = 1
+
+const (
+	courseArchiveManifest = "course.json"
+	// limits of the unpacked archive, so the small archive
+	// can't expand into the huge one on import
+	courseArchiveMaxSize     = 1 << 30
+	courseArchiveMaxFileSize = 64 << 20
+)
+
+// mediaLinkRegexp finds links of markdown images and links and of html src attributes
+var mediaLinkRegexp = regexp.MustCompile(`(\]\(|src=["'])([^)"'\s]+)`)
+
+var archiveLessonTypes = map[domain.LessonType]string{
+	domain.TheoryLesson:   "theory",
+	domain.PracticeLesson: "practice",
+	domain.VideoLesson:    "video",
+}
+
+// courseArchive is the course.json manifest, markdown and media files
+// are stored in the archive and referenced by their archive paths
+type courseArchive struct {
+	FormatVersion int                   `json:"format_version"`
+	ExportedAt    time.Time             `json:"exported_at"`
+	Course        courseArchiveCourse   `json:"course"`
+	Lessons       []courseArchiveLesson `json:"lessons"`
+}
+
+type courseArchiveCourse struct {
+	Name       string   `json:"name"`
+	Level      int      `json:"level"`
+	Price      int64    `json:"price"`
+	Currency   string   `json:"currency"`
+	Language   string   `json:"language"`
+	Capacity   null.Int `json:"capacity"`
+	AccessDays null.Int `json:"access_days"`
+}
+
+type courseArchiveLesson struct {
+	Title       string              `json:"title"`
+	Score       int                 `json:"score"`
+	Type        string              `json:"type"`
+	Theory      string              `json:"theory,omitempty"`
+	VideoUrl    string              `json:"video_url,omitempty"`
+	Tests       []courseArchiveTest `json:"tests,omitempty"`
+	ReleaseAt   null.Time           `json:"release_at"`
+	ReleaseDays null.Int            `json:"release_days"`
+	IsPreview   bool                `json:"is_preview,omitempty"`
+}
+
+type courseArchiveTest struct {
+	Task    string   `json:"task"`
+	Options []string `json:"options"`
+	Answer  string   `json:"answer"`
+	Level   int      `json:"level"`
+	Score   int      `json:"score"`
+}
+
+type CourseArchiveService struct {
+	courseRepo port.ICourseRepository
+	lessonRepo port.ILessonRepository
+	storage    port.IObjectStorage
+	logger     *zap.Logger
+}
+
+func NewCourseArchiveService(courseRepo port.ICourseRepository, lessonRepo port.ILessonRepository,
+	storage port.IObjectStorage, logger *zap.Logger) *CourseArchiveService {
+	return &CourseArchiveService{
+		courseRepo: courseRepo,
+		lessonRepo: lessonRepo,
+		storage:    storage,
+		logger:     logger,
+	}
+}
+
+// ExportCourse writes the zip archive with the edited content of the course,
+// media of the markdown files is packed if it is stored in the object storage
+func (a *CourseArchiveService) ExportCourse(ctx context.Context, courseID domain.ID, w io.Writer) error {
+	course, err := a.courseRepo.FindByID(ctx, courseID)
+	if err != nil {
+		a.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return err
+	}
+
+	lessons, err := a.lessonRepo.FindCourseLessons(ctx, courseID)
+	if err != nil {
+		a.logger.Error("failed to find course lessons", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return err
+	}
+
+	exporter := &courseExporter{
+		storage: a.storage,
+		zip:     zip.NewWriter(w),
+		media:   make(map[string]string),
+	}
+	archive := courseArchive{
+		FormatVersion: courseArchiveVersion,
+		ExportedAt:    time.Now().UTC(),
+		Course: courseArchiveCourse{
+			Name:       course.Name,
+			Level:      course.Level,
+			Price:      course.Price.Amount,
+			Currency:   course.Price.Currency,
+			Language:   course.Language,
+			Capacity:   course.Capacity,
+			AccessDays: course.AccessDays,
+		},
+		Lessons: make([]courseArchiveLesson, len(lessons)),
+	}
+	for i, lesson := range lessons {
+		archive.Lessons[i], err = exporter.exportLesson(ctx, lesson, fmt.Sprintf("lessons/%02d", i+1))
+		if err != nil {
+			a.logger.Error("failed to export course lesson", zap.Error(err),
+				zap.String("courseID", courseID.String()), zap.String("lessonID", lesson.ID.String()))
+			return err
+		}
+	}
+
+	if err = exporter.writeManifest(archive); err != nil {
+		a.logger.Error("failed to write course archive", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return err
+	}
+
+	a.logger.Info("course is successfully exported",
+		zap.String("courseID", courseID.String()), zap.Int("lessons", len(lessons)),
+		zap.Int("media", len(exporter.media)))
+	return nil
+}
+
+// ImportCourse creates the draft course in the school from the archive made by ExportCourse
+func (a *CourseArchiveService) ImportCourse(ctx context.Context, schoolID domain.ID,
+	reader io.ReaderAt, size int64) (domain.Course, error) {
+	if size > courseArchiveMaxSize {
+		a.logger.Error("failed to import course, archive is too large",
+			zap.String("schoolID", schoolID.String()), zap.Int64("size", size))
+		return domain.Course{}, errs.ErrCourseArchiveTooLarge
+	}
+
+	zipReader, err := zip.NewReader(reader, size)
+	if err != nil {
+		a.logger.Error("failed to open course archive", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return domain.Course{}, errors.Wrap(errs.ErrCourseArchiveInvalid, err.Error())
+	}
+
+	importer := &courseImporter{
+		storage: a.storage,
+		files:   make(map[string]*zip.File, len(zipReader.File)),
+		media:   make(map[string]string),
+	}
+	for _, file := range zipReader.File {
+		importer.files[file.Name] = file
+	}
+
+	archive, err := importer.readManifest()
+	if err != nil {
+		a.logger.Error("failed to read course archive manifest", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return domain.Course{}, err
+	}
+
+	course, err := archive.Course.toDomain(schoolID)
+	if err != nil {
+		a.logger.Error("failed to validate imported course", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return domain.Course{}, err
+	}
+
+	lessons := make([]domain.Lesson, len(archive.Lessons))
+	for i, entry := range archive.Lessons {
+		lessons[i], err = importer.importLesson(ctx, course.ID, entry)
+		if err != nil {
+			a.logger.Error("failed to import course lesson", zap.Error(err),
+				zap.String("schoolID", schoolID.String()), zap.String("title", entry.Title))
+			return domain.Course{}, err
+		}
+	}
+
+	course, err = a.courseRepo.Create(ctx, course)
+	if err != nil {
+		a.logger.Error("failed to create imported course", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return domain.Course{}, err
+	}
+
+	if err = a.lessonRepo.CreateCourseLessons(ctx, lessons); err != nil {
+		a.logger.Error("failed to create imported course lessons", zap.Error(err),
+			zap.String("courseID", course.ID.String()))
+		if deleteErr := a.courseRepo.Delete(ctx, course.ID); deleteErr != nil {
+			a.logger.Error("failed to delete imported course", zap.Error(deleteErr),
+				zap.String("courseID", course.ID.String()))
+		}
+		return domain.Course{}, err
+	}
+
+	a.logger.Info("course is successfully imported",
+		zap.String("courseID", course.ID.String()), zap.String("schoolID", schoolID.String()),
+		zap.Int("lessons", len(lessons)), zap.Int("media", len(importer.media)))
+	return course, nil
+}
+
+// toDomain checks the course metadata the same way as the created course
+func (c courseArchiveCourse) toDomain(schoolID domain.ID) (domain.Course, error) {
+	price := domain.NewMoney(c.Price, c.Currency)
+	switch {
+	case !price.Valid():
+		return domain.Course{}, errs.ErrCourseInvalidCurrency
+	case price.Amount < 0:
+		return domain.Course{}, errs.ErrCourseInvalidPrice
+	case c.Level < 1:
+		return domain.Course{}, errs.ErrCourseInvalidLevel
+	case c.Capacity.Valid && c.Capacity.Int64 < 1:
+		return domain.Course{}, errs.ErrCourseInvalidCapacity
+	case c.AccessDays.Valid && c.AccessDays.Int64 < 1:
+		return domain.Course{}, errs.ErrCourseInvalidAccessPeriod
+	}
+
+	return domain.Course{
+		ID:         domain.NewID(),
+		SchoolID:   schoolID,
+		Name:       c.Name,
+		Level:      c.Level,
+		Price:      price,
+		Language:   c.Language,
+		Status:     domain.CourseDraft,
+		Capacity:   c.Capacity,
+		AccessDays: c.AccessDays,
+		Version:    1,
+	}, nil
+}
+
+// courseExporter writes files of the exported course,
+// media maps stored urls to their archive paths
+type courseExporter struct {
+	storage port.IObjectStorage
+	zip     *zip.Writer
+	media   map[string]string
+}
+
+func (e *courseExporter) exportLesson(ctx context.Context, lesson domain.Lesson,
+	dir string) (courseArchiveLesson, error) {
+	entry := courseArchiveLesson{
+		Title:       lesson.Title,
+		Score:       lesson.Score,
+		Type:        archiveLessonTypes[lesson.Type],
+		ReleaseAt:   lesson.ReleaseAt,
+		ReleaseDays: lesson.ReleaseDays,
+		IsPreview:   lesson.IsPreview,
+	}
+
+	var err error
+	switch lesson.Type {
+	case domain.TheoryLesson:
+		entry.Theory = dir + "/theory.md"
+		err = e.exportMarkdown(ctx, domain.Url(lesson.TheoryUrl.String), entry.Theory)
+	case domain.PracticeLesson:
+		entry.Tests = make([]courseArchiveTest, len(lesson.Tests))
+		for i, test := range lesson.Tests {
+			entry.Tests[i] = courseArchiveTest{
+				Task:    fmt.Sprintf("%s/tests/%02d.md", dir, i+1),
+				Options: test.Options,
+				Answer:  test.Answer,
+				Level:   test.Level,
+				Score:   test.Score,
+			}
+			if err = e.exportMarkdown(ctx, domain.Url(test.TaskUrl), entry.Tests[i].Task); err != nil {
+				break
+			}
+		}
+	case domain.VideoLesson:
+		entry.VideoUrl = lesson.VideoUrl.String
+	}
+	return entry, err
+}
+
+// exportMarkdown packs the stored markdown file with links to the packed media
+func (e *courseExporter) exportMarkdown(ctx context.Context, fileUrl domain.Url, name string) error {
+	file, err := e.storage.LoadFile(ctx, fileUrl)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	markdown, err := io.ReadAll(file)
+	if err != nil {
+		return errors.Wrap(errs.ErrLoadFileError, err.Error())
+	}
+
+	content, err := rewriteMediaLinks(string(markdown), func(link string) (string, error) {
+		return e.exportMedia(ctx, link)
+	})
+	if err != nil {
+		return err
+	}
+	return e.writeFile(name, strings.NewReader(content))
+}
+
+// exportMedia packs the media file once and returns its archive path,
+// links to other hosts are kept as they are
+func (e *courseExporter) exportMedia(ctx context.Context, link string) (string, error) {
+	if name, ok := e.media[link]; ok {
+		return name, nil
+	}
+
+	file, err := e.storage.LoadFile(ctx, domain.Url(link))
+	if errors.Is(err, errs.ErrFileNotStored) {
+		return link, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	linkPath := link
+	if parsedUrl, err := url.Parse(link); err == nil {
+		linkPath = parsedUrl.Path
+	}
+	name := fmt.Sprintf("media/%03d_%s", len(e.media)+1, path.Base(linkPath))
+	if err = e.writeFile(name, file); err != nil {
+		return "", err
+	}
+	e.media[link] = name
+	return name, nil
+}
+
+func (e *courseExporter) writeManifest(archive courseArchive) error {
+	manifest, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = e.writeFile(courseArchiveManifest, bytes.NewReader(manifest)); err != nil {
+		return err
+	}
+	return e.zip.Close()
+}
+
+func (e *courseExporter) writeFile(name string, reader io.Reader) error {
+	writer, err := e.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, reader)
+	return err
+}
+
+// courseImporter saves files of the imported course,
+// media maps archive paths to urls of the saved media
+type courseImporter struct {
+	storage  port.IObjectStorage
+	files    map[string]*zip.File
+	media    map[string]string
+	unpacked int64
+}
+
+func (i *courseImporter) readManifest() (courseArchive, error) {
+	manifest, err := i.readFile(courseArchiveManifest)
+	if err != nil {
+		return courseArchive{}, err
+	}
+
+	var archive courseArchive
+	if err = json.Unmarshal(manifest, &archive); err != nil {
+		return courseArchive{}, errors.Wrap(errs.ErrCourseArchiveInvalid, err.Error())
+	}
+	if archive.FormatVersion < 1 || archive.FormatVersion > courseArchiveVersion {
+		return courseArchive{}, errors.Wrap(errs.ErrCourseArchiveVersion,
+			fmt.Sprintf("format version %d", archive.FormatVersion))
+	}
+
+	previews := 0
+	for _, lesson := range archive.Lessons {
+		if lesson.IsPreview {
+			previews++
+		}
+	}
+	if previews > domain.MaxCoursePreviewLessons {
+		return courseArchive{}, errs.ErrLessonPreviewLimit
+	}
+	return archive, nil
+}
+
+func (i *courseImporter) importLesson(ctx context.Context, courseID domain.ID,
+	entry courseArchiveLesson) (domain.Lesson, error) {
+	lessonID := domain.NewID()
+	lesson := domain.Lesson{
+		ID:          lessonID,
+		Key:         lessonID,
+		CourseID:    courseID,
+		Version:     1,
+		Title:       entry.Title,
+		Score:       entry.Score,
+		ReleaseAt:   entry.ReleaseAt,
+		ReleaseDays: entry.ReleaseDays,
+		IsPreview:   entry.IsPreview,
+	}
+
+	switch entry.Type {
+	case archiveLessonTypes[domain.TheoryLesson]:
+		lesson.Type = domain.TheoryLesson
+		url, err := i.importMarkdown(ctx, entry.Theory, domain.File{
+			Name: lessonID.String() + ".md",
+			Path: "course/" + courseID.String(),
+		})
+		if err != nil {
+			return domain.Lesson{}, err
+		}
+		lesson.TheoryUrl = null.StringFrom(url.String())
+	case archiveLessonTypes[domain.PracticeLesson]:
+		lesson.Type = domain.PracticeLesson
+		lesson.Tests = make([]domain.Test, len(entry.Tests))
+		for j, test := range entry.Tests {
+			testID := domain.NewID()
+			url, err := i.importMarkdown(ctx, test.Task, domain.File{
+				Name: testID.String() + ".md",
+				Path: "course/" + courseID.String() + "/" + lessonID.String(),
+			})
+			if err != nil {
+				return domain.Lesson{}, err
+			}
+			lesson.Tests[j] = domain.Test{
+				ID:       testID,
+				Key:      testID,
+				LessonID: lessonID,
+				TaskUrl:  url.String(),
+				Options:  test.Options,
+				Answer:   test.Answer,
+				Level:    test.Level,
+				Score:    test.Score,
+			}
+		}
+	case archiveLessonTypes[domain.VideoLesson]:
+		lesson.Type = domain.VideoLesson
+		lesson.VideoUrl = null.StringFrom(entry.VideoUrl)
+	default:
+		return domain.Lesson{}, errors.Wrap(errs.ErrCourseArchiveInvalid,
+			fmt.Sprintf("unknown lesson type %q", entry.Type))
+	}
+
+	if err := lesson.Validate(); err != nil {
+		return domain.Lesson{}, err
+	}
+	return lesson, nil
+}
+
+// importMarkdown saves the markdown file with links to the saved media
+func (i *courseImporter) importMarkdown(ctx context.Context, name string,
+	file domain.File) (domain.Url, error) {
+	markdown, err := i.readFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := rewriteMediaLinks(string(markdown), func(link string) (string, error) {
+		return i.importMedia(ctx, link)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	file.Reader = strings.NewReader(content)
+	return i.storage.SaveFile(ctx, file)
+}
+
+// importMedia saves the packed media file once and returns its url,
+// links missing from the archive are kept as they are
+func (i *courseImporter) importMedia(ctx context.Context, link string) (string, error) {
+	if url, ok := i.media[link]; ok {
+		return url, nil
+	}
+	if _, ok := i.files[link]; !ok || !strings.HasPrefix(link, "media/") {
+		return link, nil
+	}
+
+	media, err := i.readFile(link)
+	if err != nil {
+		return "", err
+	}
+	url, err := i.storage.SaveFile(ctx, domain.File{
+		Name:   uuid.NewV4().String() + "_" + path.Base(link),
+		Path:   "media/",
+		Reader: bytes.NewReader(media),
+	})
+	if err != nil {
+		return "", err
+	}
+	i.media[link] = url.String()
+	return url.String(), nil
+}
+
+// readFile unpacks the archive file, sizes written in the zip headers
+// are not trusted, so the reader stops right after the limit
+func (i *courseImporter) readFile(name string) ([]byte, error) {
+	file, ok := i.files[name]
+	if !ok {
+		return nil, errors.Wrap(errs.ErrCourseArchiveMissingFile, name)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errors.Wrap(errs.ErrCourseArchiveInvalid, err.Error())
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, courseArchiveMaxFileSize+1))
+	if err != nil {
+		return nil, errors.Wrap(errs.ErrCourseArchiveInvalid, err.Error())
+	}
+	i.unpacked += int64(len(data))
+	if len(data) > courseArchiveMaxFileSize || i.unpacked > courseArchiveMaxSize {
+		return nil, errors.Wrap(errs.ErrCourseArchiveTooLarge, name)
+	}
+	return data, nil
+}
+
+// rewriteMediaLinks replaces every media link of the markdown with the rewritten one
+func rewriteMediaLinks(markdown string, rewrite func(link string) (string, error)) (string, error) {
+	var builder strings.Builder
+	last := 0
+	for _, match := range mediaLinkRegexp.FindAllStringSubmatchIndex(markdown, -1) {
+		link, err := rewrite(markdown[match[4]:match[5]])
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(markdown[last:match[4]])
+		builder.WriteString(link)
+		last = match[5]
+	}
+	builder.WriteString(markdown[last:])
+	return builder.String(), nil
+}
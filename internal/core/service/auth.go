@@ -2,40 +2,78 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"github.com/paw1a/eschool/internal/core/domain"
 	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/paw1a/eschool/internal/core/port"
 	"go.uber.org/zap"
+	"strings"
+	"time"
 )
 
 type AuthTokenService struct {
-	authProvider port.IAuthProvider
-	userRepo     port.IUserRepository
-	logger       *zap.Logger
+	authProvider     port.IAuthProvider
+	otpProvider      port.IOtpProvider
+	identityProvider port.IIdentityProvider
+	userRepo         port.IUserRepository
+	tokenRepo        port.IEmailTokenRepository
+	mfaRepo          port.IMfaRepository
+	identityRepo     port.IExternalIdentityRepository
+	apiKeyRepo       port.IApiKeyRepository
+	mailer           port.IMailer
+	limiter          port.IRateLimiter
+	logger           *zap.Logger
 }
 
-func NewAuthTokenService(authProvider port.IAuthProvider, userRepo port.IUserRepository,
-	logger *zap.Logger) *AuthTokenService {
+func NewAuthTokenService(authProvider port.IAuthProvider, otpProvider port.IOtpProvider,
+	identityProvider port.IIdentityProvider, userRepo port.IUserRepository,
+	tokenRepo port.IEmailTokenRepository, mfaRepo port.IMfaRepository,
+	identityRepo port.IExternalIdentityRepository, apiKeyRepo port.IApiKeyRepository,
+	mailer port.IMailer, limiter port.IRateLimiter, logger *zap.Logger) *AuthTokenService {
 	return &AuthTokenService{
-		authProvider: authProvider,
-		userRepo:     userRepo,
-		logger:       logger,
+		authProvider:     authProvider,
+		otpProvider:      otpProvider,
+		identityProvider: identityProvider,
+		userRepo:         userRepo,
+		tokenRepo:        tokenRepo,
+		mfaRepo:          mfaRepo,
+		identityRepo:     identityRepo,
+		apiKeyRepo:       apiKeyRepo,
+		mailer:           mailer,
+		limiter:          limiter,
+		logger:           logger,
 	}
 }
 
 func (a *AuthTokenService) SignIn(ctx context.Context, param port.SignInParam) (domain.AuthDetails, error) {
+	emailKey := "sign-in:email:" + strings.ToLower(param.Email)
+	limiterKeys := []string{emailKey}
+	if param.IP != "" {
+		limiterKeys = append(limiterKeys, "sign-in:ip:"+param.IP)
+	}
+
+	err := a.checkAttempts(ctx, limiterKeys...)
+	if err != nil {
+		return domain.AuthDetails{}, err
+	}
+
 	user, err := a.userRepo.FindByCredentials(ctx, param.Email, param.Password)
 	if err != nil {
 		a.logger.Error("failed to verify sign in credentials", zap.Error(err))
+		a.registerFailedAttempt(ctx, limiterKeys...)
 		return domain.AuthDetails{}, errs.ErrInvalidCredentials
 	}
-	details, err := a.authProvider.CreateJWTSession(domain.AuthPayload{UserID: user.ID}, param.Fingerprint)
+
+	err = a.limiter.Reset(ctx, emailKey)
 	if err != nil {
-		a.logger.Error("failed to create new jwt session", zap.Error(err))
-		return domain.AuthDetails{}, err
+		a.logger.Error("failed to reset sign in attempts", zap.Error(err), zap.String("key", emailKey))
 	}
-	a.logger.Info("user successfully signed in", zap.String("userID", user.ID.String()))
-	return details, nil
+
+	return a.startSession(ctx, user.ID, param.Fingerprint)
 }
 
 func (a *AuthTokenService) SignUp(ctx context.Context, param port.SignUpParam) error {
@@ -60,6 +98,12 @@ func (a *AuthTokenService) SignUp(ctx context.Context, param port.SignUpParam) e
 		return err
 	}
 
+	err = a.sendEmailToken(ctx, user, domain.EmailVerificationPurpose)
+	if err != nil {
+		a.logger.Error("failed to send email verification token", zap.Error(err),
+			zap.String("userID", user.ID.String()))
+	}
+
 	a.logger.Info("user successfully signed up", zap.String("userID", user.ID.String()))
 	return nil
 }
@@ -101,3 +145,520 @@ func (a *AuthTokenService) Verify(ctx context.Context, accessToken domain.Token)
 func (a *AuthTokenService) Payload(ctx context.Context, accessToken domain.Token) (domain.AuthPayload, error) {
 	return a.authProvider.VerifyJWTToken(accessToken)
 }
+
+func (a *AuthTokenService) VerifyEmail(ctx context.Context, token domain.Token) error {
+	user, err := a.useEmailToken(ctx, token, domain.EmailVerificationPurpose)
+	if err != nil {
+		return err
+	}
+
+	user.EmailVerified = true
+	_, err = a.userRepo.Update(ctx, user)
+	if err != nil {
+		a.logger.Error("failed to mark user email as verified", zap.Error(err),
+			zap.String("userID", user.ID.String()))
+		return err
+	}
+
+	a.logger.Info("user email is verified", zap.String("userID", user.ID.String()))
+	return nil
+}
+
+func (a *AuthTokenService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := a.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		a.logger.Error("failed to find user by email", zap.Error(err), zap.String("email", email))
+		return nil
+	}
+
+	err = a.sendEmailToken(ctx, user, domain.PasswordResetPurpose)
+	if err != nil {
+		a.logger.Error("failed to send password reset token", zap.Error(err),
+			zap.String("userID", user.ID.String()))
+		return err
+	}
+
+	a.logger.Info("password reset token is sent", zap.String("userID", user.ID.String()))
+	return nil
+}
+
+func (a *AuthTokenService) ResetPassword(ctx context.Context, param port.ResetPasswordParam) error {
+	user, err := a.useEmailToken(ctx, param.Token, domain.PasswordResetPurpose)
+	if err != nil {
+		return err
+	}
+
+	user.Password = param.Password
+	user.EmailVerified = true
+	_, err = a.userRepo.Update(ctx, user)
+	if err != nil {
+		a.logger.Error("failed to reset user password", zap.Error(err),
+			zap.String("userID", user.ID.String()))
+		return err
+	}
+
+	a.logger.Info("user password is reset", zap.String("userID", user.ID.String()))
+	return nil
+}
+
+func (a *AuthTokenService) EnrollMfa(ctx context.Context, userID domain.ID) (domain.MfaEnrollment, error) {
+	user, err := a.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		a.logger.Error("failed to find user by id", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return domain.MfaEnrollment{}, err
+	}
+
+	mfa, err := a.mfaRepo.FindByUserID(ctx, userID)
+	if err == nil && mfa.Enabled {
+		return domain.MfaEnrollment{}, errs.ErrMfaAlreadyEnabled
+	}
+
+	secret, err := a.otpProvider.GenerateSecret()
+	if err != nil {
+		a.logger.Error("failed to generate totp secret", zap.Error(err))
+		return domain.MfaEnrollment{}, err
+	}
+
+	recoveryCodes, err := a.otpProvider.GenerateRecoveryCodes()
+	if err != nil {
+		a.logger.Error("failed to generate mfa recovery codes", zap.Error(err))
+		return domain.MfaEnrollment{}, err
+	}
+
+	err = a.mfaRepo.Save(ctx, domain.MfaSettings{
+		UserID:  userID,
+		Secret:  secret,
+		Enabled: false,
+	})
+	if err != nil {
+		a.logger.Error("failed to save user mfa settings", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return domain.MfaEnrollment{}, err
+	}
+
+	codeHashes := make([]string, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		codeHashes[i] = hashRecoveryCode(code)
+	}
+	err = a.mfaRepo.ReplaceRecoveryCodes(ctx, userID, codeHashes)
+	if err != nil {
+		a.logger.Error("failed to save mfa recovery codes", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return domain.MfaEnrollment{}, err
+	}
+
+	a.logger.Info("user mfa enrollment is started", zap.String("userID", userID.String()))
+	return domain.MfaEnrollment{
+		ProvisioningUri: a.otpProvider.ProvisioningUri(secret, user.Email),
+		RecoveryCodes:   recoveryCodes,
+	}, nil
+}
+
+func (a *AuthTokenService) ConfirmMfa(ctx context.Context, userID domain.ID, code string) error {
+	mfa, err := a.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		a.logger.Error("failed to find user mfa settings", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return errs.ErrMfaNotEnrolled
+	}
+
+	if mfa.Enabled {
+		return errs.ErrMfaAlreadyEnabled
+	}
+
+	if !a.otpProvider.ValidateCode(mfa.Secret, code) {
+		return errs.ErrInvalidMfaCode
+	}
+
+	mfa.Enabled = true
+	err = a.mfaRepo.Save(ctx, mfa)
+	if err != nil {
+		a.logger.Error("failed to enable user mfa", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return err
+	}
+
+	a.logger.Info("user mfa is enabled", zap.String("userID", userID.String()))
+	return nil
+}
+
+func (a *AuthTokenService) DisableMfa(ctx context.Context, userID domain.ID, code string) error {
+	mfa, err := a.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil || !mfa.Enabled {
+		return errs.ErrMfaNotEnrolled
+	}
+
+	err = a.checkMfaCode(ctx, mfa, code)
+	if err != nil {
+		return err
+	}
+
+	err = a.mfaRepo.Delete(ctx, userID)
+	if err != nil {
+		a.logger.Error("failed to disable user mfa", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return err
+	}
+
+	a.logger.Info("user mfa is disabled", zap.String("userID", userID.String()))
+	return nil
+}
+
+func (a *AuthTokenService) VerifyMfa(ctx context.Context, param port.MfaVerifyParam) (domain.AuthDetails, error) {
+	userID, err := a.authProvider.VerifyMfaToken(param.MfaToken)
+	if err != nil {
+		a.logger.Error("failed to verify mfa challenge token", zap.Error(err))
+		return domain.AuthDetails{}, errs.ErrInvalidMfaToken
+	}
+
+	limiterKey := "mfa:user:" + userID.String()
+	err = a.checkAttempts(ctx, limiterKey)
+	if err != nil {
+		return domain.AuthDetails{}, err
+	}
+
+	mfa, err := a.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil || !mfa.Enabled {
+		a.logger.Error("failed to find enabled user mfa settings", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return domain.AuthDetails{}, errs.ErrMfaNotEnrolled
+	}
+
+	err = a.checkMfaCode(ctx, mfa, param.Code)
+	if err != nil {
+		a.registerFailedAttempt(ctx, limiterKey)
+		return domain.AuthDetails{}, err
+	}
+
+	err = a.limiter.Reset(ctx, limiterKey)
+	if err != nil {
+		a.logger.Error("failed to reset mfa attempts", zap.Error(err), zap.String("key", limiterKey))
+	}
+
+	details, err := a.authProvider.CreateJWTSession(domain.AuthPayload{UserID: userID}, param.Fingerprint)
+	if err != nil {
+		a.logger.Error("failed to create new jwt session", zap.Error(err))
+		return domain.AuthDetails{}, err
+	}
+
+	a.logger.Info("user successfully signed in with mfa", zap.String("userID", userID.String()))
+	return details, nil
+}
+
+func (a *AuthTokenService) OidcAuthUrl(ctx context.Context, provider string) (string, error) {
+	authUrl, err := a.identityProvider.AuthCodeUrl(ctx, provider)
+	if err != nil {
+		a.logger.Error("failed to create oidc authorization url", zap.Error(err),
+			zap.String("provider", provider))
+		return "", err
+	}
+	return authUrl, nil
+}
+
+func (a *AuthTokenService) OidcSignIn(ctx context.Context, param port.OidcSignInParam) (domain.AuthDetails, error) {
+	profile, err := a.identityProvider.Exchange(ctx, param.Provider, param.State, param.Code)
+	if err != nil {
+		a.logger.Error("failed to exchange oidc authorization code", zap.Error(err),
+			zap.String("provider", param.Provider))
+		return domain.AuthDetails{}, err
+	}
+
+	userID, err := a.findExternalUser(ctx, profile)
+	if err != nil {
+		return domain.AuthDetails{}, err
+	}
+
+	a.logger.Info("user signed in with identity provider", zap.String("userID", userID.String()),
+		zap.String("provider", profile.Provider))
+	return a.startSession(ctx, userID, param.Fingerprint)
+}
+
+func (a *AuthTokenService) findExternalUser(ctx context.Context, profile domain.ExternalProfile) (domain.ID, error) {
+	identity, err := a.identityRepo.FindByProviderSubject(ctx, profile.Provider, profile.Subject)
+	if err == nil {
+		return identity.UserID, nil
+	}
+	if !errors.Is(err, errs.ErrNotExist) {
+		a.logger.Error("failed to find external identity", zap.Error(err),
+			zap.String("provider", profile.Provider), zap.String("subject", profile.Subject))
+		return "", err
+	}
+
+	if profile.Email == "" || !profile.EmailVerified {
+		a.logger.Error("external identity email is not verified",
+			zap.String("provider", profile.Provider), zap.String("subject", profile.Subject))
+		return "", errs.ErrExternalEmailNotVerified
+	}
+
+	user, err := a.userRepo.FindByEmail(ctx, profile.Email)
+	switch {
+	case errors.Is(err, errs.ErrNotExist):
+		user, err = a.createExternalUser(ctx, profile)
+		if err != nil {
+			return "", err
+		}
+	case err != nil:
+		a.logger.Error("failed to find user by email", zap.Error(err), zap.String("email", profile.Email))
+		return "", err
+	case !user.EmailVerified:
+		user, err = a.claimUnverifiedUser(ctx, user)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = a.identityRepo.Create(ctx, domain.ExternalIdentity{
+		UserID:   user.ID,
+		Provider: profile.Provider,
+		Subject:  profile.Subject,
+		Email:    profile.Email,
+	})
+	if err != nil {
+		a.logger.Error("failed to link external identity", zap.Error(err),
+			zap.String("userID", user.ID.String()), zap.String("provider", profile.Provider))
+		return "", err
+	}
+
+	a.logger.Info("external identity is linked to user", zap.String("userID", user.ID.String()),
+		zap.String("provider", profile.Provider))
+	return user.ID, nil
+}
+
+// claimUnverifiedUser hands the account registered with the email nobody has
+// verified over to the owner of the external identity, the password, mfa,
+// api keys and sessions of whoever registered it are revoked
+func (a *AuthTokenService) claimUnverifiedUser(ctx context.Context, user domain.User) (domain.User, error) {
+	password, err := randomPassword()
+	if err != nil {
+		a.logger.Error("failed to generate user password", zap.Error(err))
+		return domain.User{}, err
+	}
+
+	user.Password = password
+	user.EmailVerified = true
+	user, err = a.userRepo.Update(ctx, user)
+	if err != nil {
+		a.logger.Error("failed to reset unverified user", zap.Error(err), zap.String("userID", user.ID.String()))
+		return domain.User{}, err
+	}
+
+	err = a.mfaRepo.Delete(ctx, user.ID)
+	if err != nil {
+		a.logger.Error("failed to disable unverified user mfa", zap.Error(err),
+			zap.String("userID", user.ID.String()))
+		return domain.User{}, err
+	}
+
+	keys, err := a.apiKeyRepo.FindUserKeys(ctx, user.ID)
+	if err != nil {
+		a.logger.Error("failed to find unverified user api keys", zap.Error(err),
+			zap.String("userID", user.ID.String()))
+		return domain.User{}, err
+	}
+	for _, key := range keys {
+		if key.Revoked() {
+			continue
+		}
+		err = a.apiKeyRepo.Revoke(ctx, user.ID, key.ID)
+		if err != nil {
+			a.logger.Error("failed to revoke unverified user api key", zap.Error(err),
+				zap.String("userID", user.ID.String()), zap.String("keyID", key.ID.String()))
+			return domain.User{}, err
+		}
+	}
+
+	err = a.authProvider.DeleteUserJWTSessions(user.ID)
+	if err != nil {
+		a.logger.Error("failed to revoke unverified user sessions", zap.Error(err),
+			zap.String("userID", user.ID.String()))
+		return domain.User{}, err
+	}
+
+	a.logger.Info("unverified user is claimed by external identity", zap.String("userID", user.ID.String()))
+	return user, nil
+}
+
+func (a *AuthTokenService) createExternalUser(ctx context.Context,
+	profile domain.ExternalProfile) (domain.User, error) {
+	password, err := randomPassword()
+	if err != nil {
+		a.logger.Error("failed to generate user password", zap.Error(err))
+		return domain.User{}, err
+	}
+
+	name := profile.Name
+	if name == "" {
+		name, _, _ = strings.Cut(profile.Email, "@")
+	}
+
+	user, err := a.userRepo.Create(ctx, domain.User{
+		ID:            domain.NewID(),
+		Name:          name,
+		Surname:       profile.Surname,
+		Email:         profile.Email,
+		Password:      password,
+		EmailVerified: true,
+	})
+	if err != nil {
+		a.logger.Error("failed to create user from external identity", zap.Error(err),
+			zap.String("email", profile.Email))
+		return domain.User{}, err
+	}
+
+	a.logger.Info("user is created from external identity", zap.String("userID", user.ID.String()),
+		zap.String("provider", profile.Provider))
+	return user, nil
+}
+
+func (a *AuthTokenService) startSession(ctx context.Context, userID domain.ID,
+	fingerprint string) (domain.AuthDetails, error) {
+	mfa, err := a.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, errs.ErrNotExist) {
+		a.logger.Error("failed to find user mfa settings", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return domain.AuthDetails{}, err
+	}
+
+	if err == nil && mfa.Enabled {
+		mfaToken, err := a.authProvider.CreateMfaToken(userID)
+		if err != nil {
+			a.logger.Error("failed to create mfa challenge token", zap.Error(err))
+			return domain.AuthDetails{}, err
+		}
+		a.logger.Info("user passed credentials check, mfa code is required",
+			zap.String("userID", userID.String()))
+		return domain.AuthDetails{MfaToken: mfaToken}, nil
+	}
+
+	details, err := a.authProvider.CreateJWTSession(domain.AuthPayload{UserID: userID}, fingerprint)
+	if err != nil {
+		a.logger.Error("failed to create new jwt session", zap.Error(err))
+		return domain.AuthDetails{}, err
+	}
+	a.logger.Info("user successfully signed in", zap.String("userID", userID.String()))
+	return details, nil
+}
+
+func (a *AuthTokenService) checkMfaCode(ctx context.Context, mfa domain.MfaSettings, code string) error {
+	if a.otpProvider.ValidateCode(mfa.Secret, code) {
+		return nil
+	}
+
+	err := a.mfaRepo.UseRecoveryCode(ctx, mfa.UserID, hashRecoveryCode(code))
+	if err != nil {
+		a.logger.Error("invalid mfa code", zap.Error(err),
+			zap.String("userID", mfa.UserID.String()))
+		return errs.ErrInvalidMfaCode
+	}
+
+	a.logger.Info("mfa recovery code is used", zap.String("userID", mfa.UserID.String()))
+	return nil
+}
+
+func (a *AuthTokenService) checkAttempts(ctx context.Context, keys ...string) error {
+	var retryAfter time.Duration
+	for _, key := range keys {
+		keyRetryAfter, err := a.limiter.Check(ctx, key)
+		if err != nil {
+			a.logger.Error("failed to check attempts limit", zap.Error(err), zap.String("key", key))
+			return err
+		}
+		if keyRetryAfter > retryAfter {
+			retryAfter = keyRetryAfter
+		}
+	}
+
+	if retryAfter > 0 {
+		a.logger.Warn("too many attempts", zap.Strings("keys", keys),
+			zap.Duration("retryAfter", retryAfter))
+		return &errs.RetryAfterError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+func (a *AuthTokenService) registerFailedAttempt(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		lockout, err := a.limiter.Hit(ctx, key)
+		if err != nil {
+			a.logger.Error("failed to register failed attempt", zap.Error(err), zap.String("key", key))
+			continue
+		}
+		if lockout > 0 {
+			a.logger.Warn("attempts limit is exceeded, key is locked", zap.String("key", key),
+				zap.Duration("lockout", lockout))
+		}
+	}
+}
+
+func randomPassword() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func (a *AuthTokenService) sendEmailToken(ctx context.Context, user domain.User,
+	purpose domain.EmailTokenPurpose) error {
+	emailToken, token, err := a.authProvider.CreateEmailToken(user.ID, purpose)
+	if err != nil {
+		return err
+	}
+
+	err = a.tokenRepo.Create(ctx, emailToken)
+	if err != nil {
+		return err
+	}
+
+	mail := domain.Mail{To: user.Email}
+	switch purpose {
+	case domain.EmailVerificationPurpose:
+		mail.Subject = "Email verification"
+		mail.Body = fmt.Sprintf("Hello, %s!\n\nUse this token to verify your email:\n%s\n",
+			user.Name, token.String())
+	case domain.PasswordResetPurpose:
+		mail.Subject = "Password reset"
+		mail.Body = fmt.Sprintf("Hello, %s!\n\nUse this token to reset your password:\n%s\n",
+			user.Name, token.String())
+	}
+
+	return a.mailer.Send(ctx, mail)
+}
+
+func (a *AuthTokenService) useEmailToken(ctx context.Context, token domain.Token,
+	purpose domain.EmailTokenPurpose) (domain.User, error) {
+	emailToken, err := a.authProvider.VerifyEmailToken(token)
+	if err != nil {
+		a.logger.Error("failed to verify email token", zap.Error(err))
+		return domain.User{}, errs.ErrInvalidEmailToken
+	}
+
+	if emailToken.Purpose != purpose {
+		a.logger.Error("email token has invalid purpose",
+			zap.String("tokenID", emailToken.ID.String()))
+		return domain.User{}, errs.ErrInvalidEmailToken
+	}
+
+	err = a.tokenRepo.Use(ctx, emailToken.ID)
+	if err != nil {
+		a.logger.Error("failed to use email token", zap.Error(err),
+			zap.String("tokenID", emailToken.ID.String()))
+		return domain.User{}, errs.ErrInvalidEmailToken
+	}
+
+	user, err := a.userRepo.FindByID(ctx, emailToken.UserID)
+	if err != nil {
+		a.logger.Error("failed to find user by id", zap.Error(err),
+			zap.String("userID", emailToken.UserID.String()))
+		return domain.User{}, err
+	}
+
+	return user, nil
+}
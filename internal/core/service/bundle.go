@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"time"
+)
+
+type BundleService struct {
+	repo       port.IBundleRepository
+	courseRepo port.ICourseRepository
+	logger     *zap.Logger
+}
+
+func NewBundleService(repo port.IBundleRepository, courseRepo port.ICourseRepository,
+	logger *zap.Logger) *BundleService {
+	return &BundleService{
+		repo:       repo,
+		courseRepo: courseRepo,
+		logger:     logger,
+	}
+}
+
+func (b *BundleService) FindByID(ctx context.Context, bundleID domain.ID) (domain.Bundle, error) {
+	bundle, err := b.repo.FindByID(ctx, bundleID)
+	if err != nil {
+		b.logger.Error("failed to find bundle by id", zap.Error(err),
+			zap.String("bundleID", bundleID.String()))
+		return domain.Bundle{}, err
+	}
+	return bundle, nil
+}
+
+func (b *BundleService) FindSchoolBundles(ctx context.Context, schoolID domain.ID) ([]domain.Bundle, error) {
+	bundles, err := b.repo.FindSchoolBundles(ctx, schoolID)
+	if err != nil {
+		b.logger.Error("failed to find school bundles", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return nil, err
+	}
+	return bundles, nil
+}
+
+func (b *BundleService) FindBundleCourses(ctx context.Context, bundleID domain.ID) ([]domain.Course, error) {
+	courses, err := b.repo.FindBundleCourses(ctx, bundleID)
+	if err != nil {
+		b.logger.Error("failed to find bundle courses", zap.Error(err),
+			zap.String("bundleID", bundleID.String()))
+		return nil, err
+	}
+	return courses, nil
+}
+
+func (b *BundleService) CreateSchoolBundle(ctx context.Context, schoolID domain.ID,
+	param port.CreateBundleParam) (domain.Bundle, error) {
+	if param.Price <= 0 {
+		return domain.Bundle{}, errs.ErrBundleInvalidPrice
+	}
+
+	courseIDs := make([]domain.ID, 0, len(param.CourseIDs))
+	seen := make(map[domain.ID]bool)
+	for _, courseID := range param.CourseIDs {
+		if seen[courseID] {
+			continue
+		}
+		seen[courseID] = true
+		courseIDs = append(courseIDs, courseID)
+	}
+	if len(courseIDs) < 2 {
+		return domain.Bundle{}, errs.ErrBundleTooFewCourses
+	}
+
+	courses := make([]domain.Course, 0, len(courseIDs))
+	for _, courseID := range courseIDs {
+		course, err := b.courseRepo.FindByID(ctx, courseID)
+		if err != nil {
+			b.logger.Error("failed to find bundle course", zap.Error(err),
+				zap.String("courseID", courseID.String()))
+			return domain.Bundle{}, err
+		}
+		if course.SchoolID != schoolID {
+			return domain.Bundle{}, errs.ErrBundleCourseNotInSchool
+		}
+		courses = append(courses, course)
+	}
+
+	if _, err := domain.CoursesCurrency(courses); err != nil {
+		return domain.Bundle{}, err
+	}
+
+	bundle, err := b.repo.Create(ctx, domain.Bundle{
+		ID:          domain.NewID(),
+		SchoolID:    schoolID,
+		Name:        param.Name,
+		Description: param.Description,
+		Price:       param.Price,
+		Status:      domain.BundleDraft,
+		CreatedAt:   time.Now().UTC(),
+	}, courseIDs)
+	if err != nil {
+		b.logger.Error("failed to create bundle", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return domain.Bundle{}, err
+	}
+
+	b.logger.Info("bundle is successfully created",
+		zap.String("bundleID", bundle.ID.String()), zap.String("schoolID", schoolID.String()))
+	return bundle, nil
+}
+
+func (b *BundleService) PublishSchoolBundle(ctx context.Context, schoolID, bundleID domain.ID) error {
+	bundle, err := b.repo.FindByID(ctx, bundleID)
+	if err != nil {
+		b.logger.Error("failed to find bundle by id", zap.Error(err),
+			zap.String("bundleID", bundleID.String()))
+		return err
+	}
+
+	if bundle.SchoolID != schoolID {
+		return errs.ErrNotExist
+	}
+	if bundle.Status != domain.BundleDraft {
+		return errs.ErrBundlePublishedState
+	}
+
+	courses, err := b.repo.FindBundleCourses(ctx, bundleID)
+	if err != nil {
+		b.logger.Error("failed to find bundle courses", zap.Error(err),
+			zap.String("bundleID", bundleID.String()))
+		return err
+	}
+
+	for _, course := range courses {
+		if course.Status != domain.CoursePublished {
+			return errs.ErrBundleCourseNotPublished
+		}
+	}
+
+	err = b.repo.Publish(ctx, bundleID)
+	if err != nil {
+		b.logger.Error("failed to publish bundle", zap.Error(err),
+			zap.String("bundleID", bundleID.String()))
+		return err
+	}
+
+	b.logger.Info("bundle is successfully published", zap.String("bundleID", bundleID.String()))
+	return nil
+}
+
+func (b *BundleService) Delete(ctx context.Context, schoolID, bundleID domain.ID) error {
+	err := b.repo.Delete(ctx, schoolID, bundleID)
+	if err != nil {
+		b.logger.Error("failed to delete bundle", zap.Error(err),
+			zap.String("bundleID", bundleID.String()))
+		return err
+	}
+
+	b.logger.Info("bundle is successfully deleted", zap.String("bundleID", bundleID.String()))
+	return nil
+}
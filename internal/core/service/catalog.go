@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"time"
+)
+
+type CatalogService struct {
+	repo       port.ICatalogRepository
+	courseRepo port.ICourseRepository
+	logger     *zap.Logger
+}
+
+func NewCatalogService(repo port.ICatalogRepository, courseRepo port.ICourseRepository,
+	logger *zap.Logger) *CatalogService {
+	return &CatalogService{
+		repo:       repo,
+		courseRepo: courseRepo,
+		logger:     logger,
+	}
+}
+
+func (c *CatalogService) FindCategoryTree(ctx context.Context) ([]domain.CategoryNode, error) {
+	categories, err := c.repo.FindCategories(ctx)
+	if err != nil {
+		c.logger.Error("failed to find categories", zap.Error(err))
+		return nil, err
+	}
+
+	assignments, err := c.repo.FindCategoryAssignments(ctx)
+	if err != nil {
+		c.logger.Error("failed to find category assignments", zap.Error(err))
+		return nil, err
+	}
+	return domain.NewCategoryTree(categories, assignments), nil
+}
+
+func (c *CatalogService) CreateCategory(ctx context.Context,
+	param port.CreateCategoryParam) (domain.Category, error) {
+	if param.ParentID != "" {
+		_, err := c.repo.FindCategoryByID(ctx, param.ParentID)
+		if err != nil {
+			c.logger.Error("failed to find parent category", zap.Error(err),
+				zap.String("categoryID", param.ParentID.String()))
+			return domain.Category{}, err
+		}
+	}
+
+	category, err := c.repo.CreateCategory(ctx, domain.Category{
+		ID:        domain.NewID(),
+		ParentID:  param.ParentID,
+		Name:      param.Name,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		c.logger.Error("failed to create category", zap.Error(err),
+			zap.String("name", param.Name))
+		return domain.Category{}, err
+	}
+
+	c.logger.Info("category is successfully created",
+		zap.String("categoryID", category.ID.String()))
+	return category, nil
+}
+
+// DeleteCategory deletes the leaf category, its courses are unassigned
+func (c *CatalogService) DeleteCategory(ctx context.Context, categoryID domain.ID) error {
+	categories, err := c.repo.FindCategories(ctx)
+	if err != nil {
+		c.logger.Error("failed to find categories", zap.Error(err))
+		return err
+	}
+	for _, category := range categories {
+		if category.ParentID == categoryID {
+			return errs.ErrCategoryNotEmpty
+		}
+	}
+
+	err = c.repo.DeleteCategory(ctx, categoryID)
+	if err != nil {
+		c.logger.Error("failed to delete category", zap.Error(err),
+			zap.String("categoryID", categoryID.String()))
+		return err
+	}
+
+	c.logger.Info("category is successfully deleted",
+		zap.String("categoryID", categoryID.String()))
+	return nil
+}
+
+func (c *CatalogService) FindCourseCategories(ctx context.Context,
+	courseID domain.ID) ([]domain.Category, error) {
+	categories, err := c.repo.FindCourseCategories(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course categories", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return nil, err
+	}
+	return categories, nil
+}
+
+// UpdateCourseCategories replaces categories of the course
+func (c *CatalogService) UpdateCourseCategories(ctx context.Context, courseID domain.ID,
+	categoryIDs []domain.ID) ([]domain.Category, error) {
+	unique := make([]domain.ID, 0, len(categoryIDs))
+	seen := make(map[domain.ID]bool)
+	for _, categoryID := range categoryIDs {
+		if seen[categoryID] {
+			continue
+		}
+		seen[categoryID] = true
+
+		_, err := c.repo.FindCategoryByID(ctx, categoryID)
+		if err != nil {
+			c.logger.Error("failed to find category", zap.Error(err),
+				zap.String("categoryID", categoryID.String()))
+			return nil, err
+		}
+		unique = append(unique, categoryID)
+	}
+
+	err := c.repo.UpdateCourseCategories(ctx, courseID, unique)
+	if err != nil {
+		c.logger.Error("failed to update course categories", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return nil, err
+	}
+
+	c.logger.Info("course categories are successfully updated",
+		zap.String("courseID", courseID.String()))
+	return c.FindCourseCategories(ctx, courseID)
+}
+
+func (c *CatalogService) FindSchoolTags(ctx context.Context, schoolID domain.ID) ([]domain.Tag, error) {
+	tags, err := c.repo.FindSchoolTags(ctx, schoolID)
+	if err != nil {
+		c.logger.Error("failed to find school tags", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (c *CatalogService) FindCourseTags(ctx context.Context, courseID domain.ID) ([]domain.Tag, error) {
+	tags, err := c.repo.FindCourseTags(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course tags", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return nil, err
+	}
+	return tags, nil
+}
+
+// UpdateCourseTags replaces tags of the course,
+// new names become tags of the course school
+func (c *CatalogService) UpdateCourseTags(ctx context.Context, courseID domain.ID,
+	names []string) ([]domain.Tag, error) {
+	unique := make([]string, 0, len(names))
+	seen := make(map[string]bool)
+	for _, name := range names {
+		tag, err := domain.NormalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		unique = append(unique, tag)
+	}
+	if len(unique) > domain.MaxCourseTags {
+		return nil, errs.ErrCourseTooManyTags
+	}
+
+	course, err := c.courseRepo.FindByID(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return nil, err
+	}
+
+	tags, err := c.repo.UpdateCourseTags(ctx, courseID, course.SchoolID, unique)
+	if err != nil {
+		c.logger.Error("failed to update course tags", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return nil, err
+	}
+
+	c.logger.Info("course tags are successfully updated",
+		zap.String("courseID", courseID.String()))
+	return tags, nil
+}
+
+// FindCourses returns catalog courses matching the filter,
+// tag of the filter matches tags of every school
+func (c *CatalogService) FindCourses(ctx context.Context, filter port.CourseFilter) ([]domain.Course, error) {
+	var tag string
+	if filter.Tag != "" {
+		var err error
+		tag, err = domain.NormalizeTag(filter.Tag)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	courses, err := c.repo.FindCourses(ctx, filter.CategoryID, tag)
+	if err != nil {
+		c.logger.Error("failed to find catalog courses", zap.Error(err),
+			zap.String("categoryID", filter.CategoryID.String()), zap.String("tag", tag))
+		return nil, err
+	}
+	return courses, nil
+}
@@ -2,28 +2,34 @@ package service
 
 import (
 	"context"
+	"errors"
+	"github.com/guregu/null"
 	"github.com/paw1a/eschool/internal/core/domain"
 	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/paw1a/eschool/internal/core/port"
 	"go.uber.org/zap"
+	"time"
 )
 
 type CourseService struct {
-	repo       port.ICourseRepository
-	lessonRepo port.ILessonRepository
-	schoolRepo port.ISchoolRepository
-	statRepo   port.IStatRepository
-	logger     *zap.Logger
+	repo         port.ICourseRepository
+	lessonRepo   port.ILessonRepository
+	schoolRepo   port.ISchoolRepository
+	statRepo     port.IStatRepository
+	seatListener port.ISeatListener
+	logger       *zap.Logger
 }
 
 func NewCourseService(repo port.ICourseRepository, lessonRepo port.ILessonRepository,
-	schoolRepo port.ISchoolRepository, statRepo port.IStatRepository, logger *zap.Logger) *CourseService {
+	schoolRepo port.ISchoolRepository, statRepo port.IStatRepository, seatListener port.ISeatListener,
+	logger *zap.Logger) *CourseService {
 	return &CourseService{
-		repo:       repo,
-		lessonRepo: lessonRepo,
-		schoolRepo: schoolRepo,
-		statRepo:   statRepo,
-		logger:     logger,
+		repo:         repo,
+		lessonRepo:   lessonRepo,
+		schoolRepo:   schoolRepo,
+		statRepo:     statRepo,
+		seatListener: seatListener,
+		logger:       logger,
 	}
 }
 
@@ -96,8 +102,36 @@ func (c *CourseService) IsCourseTeacher(ctx context.Context, teacherID, courseID
 	return flag, nil
 }
 
+func (c *CourseService) FindEnrollment(ctx context.Context, studentID, courseID domain.ID) (domain.Enrollment, error) {
+	enrollment, err := c.repo.FindEnrollment(ctx, studentID, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course enrollment", zap.Error(err),
+			zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()))
+		return domain.Enrollment{}, err
+	}
+	return enrollment, nil
+}
+
 func (c *CourseService) AddCourseStudent(ctx context.Context, studentID, courseID domain.ID) error {
-	lessons, err := c.lessonRepo.FindCourseLessons(ctx, courseID)
+	// expired enrollment is started again, statistics of the student are kept
+	_, err := c.repo.FindEnrollment(ctx, studentID, courseID)
+	if err == nil {
+		return c.addCourseStudent(ctx, studentID, courseID)
+	}
+	if !errors.Is(err, errs.ErrNotExist) {
+		c.logger.Error("failed to find course enrollment", zap.Error(err),
+			zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()))
+		return err
+	}
+
+	course, err := c.repo.FindByID(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return err
+	}
+
+	lessons, err := c.lessonRepo.FindCourseVersionLessons(ctx, courseID, course.LearnerVersion())
 	if err != nil {
 		c.logger.Error("failed to find course lessons", zap.Error(err),
 			zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()))
@@ -129,7 +163,11 @@ func (c *CourseService) AddCourseStudent(ctx context.Context, studentID, courseI
 		}
 	}
 
-	err = c.repo.AddCourseStudent(ctx, studentID, courseID)
+	return c.addCourseStudent(ctx, studentID, courseID)
+}
+
+func (c *CourseService) addCourseStudent(ctx context.Context, studentID, courseID domain.ID) error {
+	err := c.repo.AddCourseStudent(ctx, studentID, courseID)
 	if err != nil {
 		c.logger.Error("failed to add course student", zap.Error(err),
 			zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()))
@@ -141,6 +179,41 @@ func (c *CourseService) AddCourseStudent(ctx context.Context, studentID, courseI
 	return nil
 }
 
+func (c *CourseService) RenewCourseStudent(ctx context.Context, studentID, courseID domain.ID,
+	renewalKey string) (domain.Enrollment, error) {
+	enrollment, err := c.repo.RenewCourseStudent(ctx, studentID, courseID, renewalKey, time.Now().UTC())
+	if err != nil {
+		c.logger.Error("failed to renew course student", zap.Error(err),
+			zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()))
+		return domain.Enrollment{}, err
+	}
+
+	c.logger.Info("course student is successfully renewed",
+		zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()),
+		zap.Time("expiresAt", enrollment.ExpiresAt.Time))
+	return enrollment, nil
+}
+
+func (c *CourseService) RemoveCourseStudent(ctx context.Context, studentID, courseID domain.ID) error {
+	err := c.repo.RemoveCourseStudent(ctx, studentID, courseID)
+	if err != nil {
+		c.logger.Error("failed to remove course student", zap.Error(err),
+			zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()))
+		return err
+	}
+
+	c.logger.Info("course student is successfully removed",
+		zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()))
+
+	// the student is already removed, failed offer is retried on the next freed seat
+	err = c.seatListener.OfferFreeSeats(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to offer freed seat to waitlist", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+	}
+	return nil
+}
+
 func (c *CourseService) AddCourseTeacher(ctx context.Context, teacherID, courseID domain.ID) error {
 	course, err := c.FindByID(ctx, courseID)
 	if err != nil {
@@ -173,105 +246,508 @@ func (c *CourseService) AddCourseTeacher(ctx context.Context, teacherID, courseI
 	return nil
 }
 
-func (c *CourseService) checkCourseLessons(lessons []domain.Lesson) []error {
-	var errList []error
-	var theoryCount, practiceCount int
-	for _, lesson := range lessons {
-		err := lesson.Validate()
-		if err != nil {
-			errList = append(errList, err)
-		}
+// ValidateCourse checks the course content without changing the course
+func (c *CourseService) ValidateCourse(ctx context.Context,
+	courseID domain.ID) (domain.CourseValidationReport, error) {
+	return c.validateCourseLessons(ctx, courseID)
+}
 
-		switch lesson.Type {
-		case domain.PracticeLesson:
-			practiceCount++
-		case domain.TheoryLesson:
-			theoryCount++
-		case domain.VideoLesson:
-			theoryCount++
-		}
+func (c *CourseService) ConfirmDraftCourse(ctx context.Context,
+	userID, courseID domain.ID) (domain.CourseValidationReport, error) {
+	course, err := c.FindByID(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return domain.CourseValidationReport{}, err
 	}
 
-	if theoryCount == 0 || practiceCount == 0 {
-		errList = append(errList, errs.ErrCourseNotEnoughLessons)
+	if course.Status != domain.CourseDraft {
+		c.logger.Error("course is not draft to make it ready",
+			zap.String("courseID", courseID.String()))
+		return domain.CourseValidationReport{}, errs.ErrCourseReadyState
 	}
 
-	return errList
+	report, err := c.confirmCourseLessons(ctx, courseID)
+	if err != nil {
+		return report, err
+	}
+
+	err = c.transitCourse(ctx, userID, course, domain.CourseReady)
+	if err != nil {
+		return report, err
+	}
+	return report, nil
 }
 
-func (c *CourseService) ConfirmDraftCourse(ctx context.Context, courseID domain.ID) []error {
+func (c *CourseService) PublishReadyCourse(ctx context.Context, userID, courseID domain.ID) error {
 	course, err := c.FindByID(ctx, courseID)
 	if err != nil {
 		c.logger.Error("failed to find course by id", zap.Error(err),
 			zap.String("courseID", courseID.String()))
-		return []error{err}
+		return err
 	}
 
-	if course.Status != domain.CourseDraft {
-		c.logger.Error("course is not draft to make it ready",
+	if course.Status != domain.CourseReady {
+		c.logger.Error("course is not ready to be published", zap.Error(err),
 			zap.String("courseID", courseID.String()))
-		return []error{errs.ErrCourseReadyState}
+		return errs.ErrCoursePublishedState
 	}
 
-	var errList []error
-	lessons, err := c.lessonRepo.FindCourseLessons(ctx, courseID)
+	return c.transitCourse(ctx, userID, course, domain.CoursePublished)
+}
+
+// ChangeCourseStatus moves the course along the transition table,
+// lessons of the course are checked before it becomes ready
+func (c *CourseService) ChangeCourseStatus(ctx context.Context, userID, courseID domain.ID,
+	status domain.CourseStatus) error {
+	course, err := c.FindByID(ctx, courseID)
+	if err != nil {
+		return err
+	}
+
+	err = course.Status.CanTransitTo(status)
+	if err != nil {
+		c.logger.Error("course status transition is not allowed", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return err
+	}
+
+	if status == domain.CourseReady {
+		if _, err = c.confirmCourseLessons(ctx, courseID); err != nil {
+			return err
+		}
+	}
+
+	return c.transitCourse(ctx, userID, course, status)
+}
+
+func (c *CourseService) FindCourseTransitions(ctx context.Context,
+	courseID domain.ID) ([]domain.CourseTransition, error) {
+	transitions, err := c.repo.FindCourseTransitions(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course transitions", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return nil, err
+	}
+	return transitions, nil
+}
+
+// CreateCourseRevision copies lessons of the published course into the new
+// version, teachers edit the copy while students study the published one
+func (c *CourseService) CreateCourseRevision(ctx context.Context, courseID domain.ID) (domain.Course, error) {
+	course, err := c.repo.FindByID(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return domain.Course{}, err
+	}
+
+	if course.HasRevision() {
+		return domain.Course{}, errs.ErrCourseRevisionExists
+	}
+
+	lessons, err := c.lessonRepo.FindCourseVersionLessons(ctx, courseID, course.Version)
 	if err != nil {
 		c.logger.Error("failed to find course lessons", zap.Error(err),
 			zap.String("courseID", courseID.String()))
-		return []error{err}
+		return domain.Course{}, err
 	}
 
-	if checkErrors := c.checkCourseLessons(lessons); checkErrors != nil {
-		errList = append(errList, checkErrors...)
+	version := course.Version + 1
+	revision := make([]domain.Lesson, len(lessons))
+	for i, lesson := range lessons {
+		revision[i] = lesson.Revise(version)
 	}
 
-	if errList == nil {
-		err := c.repo.UpdateStatus(ctx, courseID, domain.CourseReady)
-		if err != nil {
-			c.logger.Error("failed to update course status", zap.Error(err),
-				zap.String("courseID", courseID.String()))
-			return []error{err}
-		}
-	} else {
-		c.logger.Error("failed to confirm draft course", zap.Error(errList[0]),
+	err = c.lessonRepo.CreateRevision(ctx, courseID, version, revision)
+	if err != nil {
+		c.logger.Error("failed to create course revision", zap.Error(err),
 			zap.String("courseID", courseID.String()))
-		return errList
+		return domain.Course{}, err
 	}
+	course.Version = version
 
-	c.logger.Info("course status is successfully changed to ready",
-		zap.String("courseID", courseID.String()))
+	c.logger.Info("course revision is successfully created",
+		zap.String("courseID", courseID.String()), zap.Int("version", version))
+	return course, nil
+}
+
+// PublishCourseRevision makes the draft revision studied by new students,
+// enrolled students stay on their version until they migrate
+func (c *CourseService) PublishCourseRevision(ctx context.Context, courseID domain.ID) error {
+	course, err := c.repo.FindByID(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return err
+	}
+
+	if course.PublishedVersion == 0 || !course.HasRevision() {
+		return errs.ErrCourseNoRevision
+	}
+
+	if _, err = c.confirmCourseLessons(ctx, courseID); err != nil {
+		return err
+	}
+
+	err = c.repo.PublishRevision(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to publish course revision", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return err
+	}
+
+	c.logger.Info("course revision is successfully published",
+		zap.String("courseID", courseID.String()), zap.Int("version", course.Version))
 	return nil
 }
 
-func (c *CourseService) PublishReadyCourse(ctx context.Context, courseID domain.ID) error {
-	course, err := c.FindByID(ctx, courseID)
+// MigrateStudentVersion moves the student to the published course version,
+// stats are moved to lessons and tests with the same keys
+func (c *CourseService) MigrateStudentVersion(ctx context.Context,
+	studentID, courseID domain.ID) (domain.Enrollment, error) {
+	enrollment, err := c.repo.FindEnrollment(ctx, studentID, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course enrollment", zap.Error(err),
+			zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()))
+		return domain.Enrollment{}, err
+	}
+
+	course, err := c.repo.FindByID(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return domain.Enrollment{}, err
+	}
+
+	version := course.LearnerVersion()
+	if enrollment.Version >= version {
+		return domain.Enrollment{}, errs.ErrEnrollmentLatestVersion
+	}
+
+	oldLessons, err := c.lessonRepo.FindCourseVersionLessons(ctx, courseID, enrollment.Version)
+	if err != nil {
+		c.logger.Error("failed to find course lessons", zap.Error(err),
+			zap.String("courseID", courseID.String()), zap.Int("version", enrollment.Version))
+		return domain.Enrollment{}, err
+	}
+
+	newLessons, err := c.lessonRepo.FindCourseVersionLessons(ctx, courseID, version)
+	if err != nil {
+		c.logger.Error("failed to find course lessons", zap.Error(err),
+			zap.String("courseID", courseID.String()), zap.Int("version", version))
+		return domain.Enrollment{}, err
+	}
+
+	for _, lesson := range newLessons {
+		err = c.migrateLessonStat(ctx, studentID, oldLessons, lesson)
+		if err != nil {
+			c.logger.Error("failed to migrate lesson stat", zap.Error(err),
+				zap.String("userID", studentID.String()), zap.String("lessonID", lesson.ID.String()))
+			return domain.Enrollment{}, err
+		}
+	}
+
+	err = c.repo.UpdateEnrollmentVersion(ctx, studentID, courseID, version)
+	if err != nil {
+		c.logger.Error("failed to update enrollment version", zap.Error(err),
+			zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()))
+		return domain.Enrollment{}, err
+	}
+	enrollment.Version = version
+
+	c.logger.Info("student is successfully migrated to the new course version",
+		zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()),
+		zap.Int("version", version))
+	return enrollment, nil
+}
+
+// ScheduleCoursePublish sets the date when the ready course is published,
+// null date cancels the scheduled publishing
+func (c *CourseService) ScheduleCoursePublish(ctx context.Context, courseID domain.ID,
+	publishAt null.Time) (domain.Course, error) {
+	course, err := c.repo.FindByID(ctx, courseID)
 	if err != nil {
 		c.logger.Error("failed to find course by id", zap.Error(err),
 			zap.String("courseID", courseID.String()))
+		return domain.Course{}, err
+	}
+
+	if publishAt.Valid {
+		// publish date is compared with the job run date, so it is stored in seconds
+		publishAt.Time = publishAt.Time.UTC().Truncate(time.Second)
+		if !publishAt.Time.After(time.Now().UTC()) {
+			return domain.Course{}, errs.ErrCoursePublishAtInPast
+		}
+		if course.Status != domain.CourseReady {
+			return domain.Course{}, errs.ErrCoursePublishedState
+		}
+	}
+
+	err = c.repo.UpdatePublishAt(ctx, courseID, publishAt)
+	if err != nil {
+		c.logger.Error("failed to update course publish date", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return domain.Course{}, err
+	}
+	course.PublishAt = publishAt
+
+	c.logger.Info("course publish date is successfully updated",
+		zap.String("courseID", courseID.String()), zap.Bool("scheduled", publishAt.Valid))
+	return course, nil
+}
+
+// PublishScheduledCourse runs the publishing job, jobs of the canceled or moved
+// publish date and jobs of the already published course are skipped
+func (c *CourseService) PublishScheduledCourse(ctx context.Context, job domain.Job) error {
+	course, err := c.repo.FindByID(ctx, job.TargetID)
+	if err != nil {
+		c.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", job.TargetID.String()))
 		return err
 	}
 
+	if !course.ScheduledFor(job) || course.Status == domain.CoursePublished {
+		c.logger.Info("scheduled course publishing is skipped",
+			zap.String("courseID", course.ID.String()), zap.String("jobID", job.ID.String()))
+		return nil
+	}
+
 	if course.Status != domain.CourseReady {
-		c.logger.Error("course is not ready to be published", zap.Error(err),
-			zap.String("courseID", courseID.String()))
+		c.logger.Error("scheduled course is not ready to be published",
+			zap.String("courseID", course.ID.String()), zap.String("status", course.Status.String()))
 		return errs.ErrCoursePublishedState
 	}
 
-	err = c.repo.UpdateStatus(ctx, courseID, domain.CoursePublished)
+	return c.transitCourse(ctx, job.UserID, course, domain.CoursePublished)
+}
+
+func (c *CourseService) FindCoursePrerequisites(ctx context.Context,
+	courseID domain.ID) ([]domain.CoursePrerequisite, error) {
+	prerequisites, err := c.repo.FindCoursePrerequisites(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course prerequisites", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return nil, err
+	}
+	return prerequisites, nil
+}
+
+// AddCoursePrerequisite saves the prerequisite, min score of the
+// already required course is replaced
+func (c *CourseService) AddCoursePrerequisite(ctx context.Context, courseID domain.ID,
+	param port.AddPrerequisiteParam) error {
+	if param.RequiredCourseID == courseID {
+		return errs.ErrCoursePrerequisiteSelf
+	}
+	if param.MinScore.Valid && param.MinScore.Int64 <= 0 {
+		return errs.ErrCoursePrerequisiteInvalidScore
+	}
+
+	course, err := c.repo.FindByID(ctx, courseID)
 	if err != nil {
-		c.logger.Error("failed to update course status",
+		c.logger.Error("failed to find course by id", zap.Error(err),
 			zap.String("courseID", courseID.String()))
 		return err
 	}
 
-	c.logger.Info("course status is successfully changed to published",
-		zap.String("courseID", courseID.String()))
+	required, err := c.repo.FindByID(ctx, param.RequiredCourseID)
+	if err != nil {
+		c.logger.Error("failed to find required course by id", zap.Error(err),
+			zap.String("courseID", param.RequiredCourseID.String()))
+		return err
+	}
+
+	if required.SchoolID != course.SchoolID {
+		return errs.ErrCoursePrerequisiteNotInSchool
+	}
+
+	cycle, err := c.requiresCourse(ctx, required.ID, courseID, make(map[domain.ID]bool))
+	if err != nil {
+		c.logger.Error("failed to check course prerequisites cycle", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return err
+	}
+	if cycle {
+		return errs.ErrCoursePrerequisiteCycle
+	}
+
+	err = c.repo.AddCoursePrerequisite(ctx, domain.CoursePrerequisite{
+		CourseID:         courseID,
+		RequiredCourseID: required.ID,
+		MinScore:         param.MinScore,
+	})
+	if err != nil {
+		c.logger.Error("failed to add course prerequisite", zap.Error(err),
+			zap.String("courseID", courseID.String()), zap.String("requiredCourseID", required.ID.String()))
+		return err
+	}
+
+	c.logger.Info("course prerequisite is successfully added",
+		zap.String("courseID", courseID.String()), zap.String("requiredCourseID", required.ID.String()))
+	return nil
+}
+
+func (c *CourseService) RemoveCoursePrerequisite(ctx context.Context, courseID, requiredCourseID domain.ID) error {
+	err := c.repo.RemoveCoursePrerequisite(ctx, courseID, requiredCourseID)
+	if err != nil {
+		c.logger.Error("failed to remove course prerequisite", zap.Error(err),
+			zap.String("courseID", courseID.String()), zap.String("requiredCourseID", requiredCourseID.String()))
+		return err
+	}
+
+	c.logger.Info("course prerequisite is successfully removed",
+		zap.String("courseID", courseID.String()), zap.String("requiredCourseID", requiredCourseID.String()))
+	return nil
+}
+
+func (c *CourseService) FindCourseProgress(ctx context.Context,
+	studentID, courseID domain.ID) (domain.CourseProgress, error) {
+	course, err := c.repo.FindByID(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return domain.CourseProgress{}, err
+	}
+
+	progress, err := findCourseProgress(ctx, c.repo, c.lessonRepo, c.statRepo, studentID, course)
+	if err != nil {
+		c.logger.Error("failed to find course progress", zap.Error(err),
+			zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()))
+		return domain.CourseProgress{}, err
+	}
+	return progress, nil
+}
+
+// CheckCoursePrerequisites returns PrerequisitesError if the student
+// can't be enrolled in the course yet
+func (c *CourseService) CheckCoursePrerequisites(ctx context.Context, studentID, courseID domain.ID) error {
+	err := checkCoursePrerequisites(ctx, c.repo, c.lessonRepo, c.statRepo, studentID, courseID)
+	if err != nil && !errors.Is(err, errs.ErrCoursePrerequisitesNotMet) {
+		c.logger.Error("failed to check course prerequisites", zap.Error(err),
+			zap.String("userID", studentID.String()), zap.String("courseID", courseID.String()))
+	}
+	return err
+}
+
+// requiresCourse reports whether the course requires the target course
+// directly or through prerequisites of its prerequisites
+func (c *CourseService) requiresCourse(ctx context.Context, courseID, targetID domain.ID,
+	visited map[domain.ID]bool) (bool, error) {
+	if courseID == targetID {
+		return true, nil
+	}
+	if visited[courseID] {
+		return false, nil
+	}
+	visited[courseID] = true
+
+	prerequisites, err := c.repo.FindCoursePrerequisites(ctx, courseID)
+	if err != nil {
+		return false, err
+	}
+	for _, prerequisite := range prerequisites {
+		found, err := c.requiresCourse(ctx, prerequisite.RequiredCourseID, targetID, visited)
+		if err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+// migrateLessonStat creates stat of the new lesson from the stat of the lesson
+// with the same key, stat created by interrupted migration is kept
+func (c *CourseService) migrateLessonStat(ctx context.Context, studentID domain.ID,
+	oldLessons []domain.Lesson, lesson domain.Lesson) error {
+	_, err := c.statRepo.FindLessonStat(ctx, studentID, lesson.ID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, errs.ErrNotExist) {
+		return err
+	}
+
+	stat := domain.LessonStat{UserID: studentID}
+	from := domain.Lesson{}
+	for _, oldLesson := range oldLessons {
+		if oldLesson.Key != lesson.Key {
+			continue
+		}
+		from = oldLesson
+		stat, err = c.statRepo.FindLessonStat(ctx, studentID, oldLesson.ID)
+		if err != nil && !errors.Is(err, errs.ErrNotExist) {
+			return err
+		}
+		stat.UserID = studentID
+	}
+
+	return c.statRepo.CreateLessonStat(ctx, domain.MigrateLessonStat(stat, from, lesson))
+}
+
+func (c *CourseService) validateCourseLessons(ctx context.Context,
+	courseID domain.ID) (domain.CourseValidationReport, error) {
+	lessons, err := c.lessonRepo.FindCourseLessons(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course lessons", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return domain.CourseValidationReport{}, err
+	}
+	return domain.ValidateCourseLessons(courseID, lessons), nil
+}
+
+// confirmCourseLessons fails with the first error of the validation report,
+// report is returned anyway to show all issues to the teacher
+func (c *CourseService) confirmCourseLessons(ctx context.Context,
+	courseID domain.ID) (domain.CourseValidationReport, error) {
+	report, err := c.validateCourseLessons(ctx, courseID)
+	if err != nil {
+		return report, err
+	}
+
+	if err = report.Err(); err != nil {
+		c.logger.Error("failed to confirm course lessons", zap.Error(err),
+			zap.String("courseID", courseID.String()),
+			zap.Int("errors", report.Count(domain.ValidationError)))
+		return report, err
+	}
+	return report, nil
+}
+
+// transitCourse changes the course status and records who changed it
+func (c *CourseService) transitCourse(ctx context.Context, userID domain.ID,
+	course domain.Course, status domain.CourseStatus) error {
+	err := c.repo.UpdateStatus(ctx, domain.CourseTransition{
+		ID:        domain.NewID(),
+		CourseID:  course.ID,
+		From:      course.Status,
+		To:        status,
+		UserID:    userID,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		c.logger.Error("failed to update course status", zap.Error(err),
+			zap.String("courseID", course.ID.String()))
+		return err
+	}
+
+	c.logger.Info("course status is successfully changed",
+		zap.String("courseID", course.ID.String()), zap.String("userID", userID.String()),
+		zap.String("from", course.Status.String()), zap.String("to", status.String()))
 	return nil
 }
 
 func (c *CourseService) CreateSchoolCourse(ctx context.Context, schoolID domain.ID,
 	param port.CreateCourseParam) (domain.Course, error) {
-	if param.Price < 0 {
+	if param.Currency == "" {
+		param.Currency = domain.DefaultCurrency
+	}
+	price := domain.NewMoney(param.Price, param.Currency)
+	if !price.Valid() {
+		c.logger.Error("failed to create course, currency is not supported",
+			zap.String("currency", param.Currency))
+		return domain.Course{}, errs.ErrCourseInvalidCurrency
+	}
+	if price.Amount < 0 {
 		c.logger.Error("failed to create course, price is < 0")
 		return domain.Course{}, errs.ErrCourseInvalidPrice
 	}
@@ -279,15 +755,26 @@ func (c *CourseService) CreateSchoolCourse(ctx context.Context, schoolID domain.
 		c.logger.Error("failed to create course, level is < 1")
 		return domain.Course{}, errs.ErrCourseInvalidLevel
 	}
+	if param.Capacity.Valid && param.Capacity.Int64 < 1 {
+		c.logger.Error("failed to create course, capacity is < 1")
+		return domain.Course{}, errs.ErrCourseInvalidCapacity
+	}
+	if param.AccessDays.Valid && param.AccessDays.Int64 < 1 {
+		c.logger.Error("failed to create course, access period is < 1 day")
+		return domain.Course{}, errs.ErrCourseInvalidAccessPeriod
+	}
 
 	course, err := c.repo.Create(ctx, domain.Course{
-		ID:       domain.NewID(),
-		SchoolID: schoolID,
-		Name:     param.Name,
-		Level:    param.Level,
-		Price:    param.Price,
-		Language: param.Language,
-		Status:   domain.CourseDraft,
+		ID:         domain.NewID(),
+		SchoolID:   schoolID,
+		Name:       param.Name,
+		Level:      param.Level,
+		Price:      price,
+		Language:   param.Language,
+		Status:     domain.CourseDraft,
+		Capacity:   param.Capacity,
+		AccessDays: param.AccessDays,
+		Version:    1,
 	})
 	if err != nil {
 		c.logger.Error("failed to create course", zap.Error(err))
@@ -299,6 +786,30 @@ func (c *CourseService) CreateSchoolCourse(ctx context.Context, schoolID domain.
 	return course, nil
 }
 
+// DuplicateCourse creates the draft copy of the course in the school,
+// lessons of the copy are created by the lesson service
+func (c *CourseService) DuplicateCourse(ctx context.Context, courseID,
+	schoolID domain.ID) (domain.Course, error) {
+	course, err := c.repo.FindByID(ctx, courseID)
+	if err != nil {
+		c.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return domain.Course{}, err
+	}
+
+	duplicate, err := c.repo.Create(ctx, course.Duplicate(schoolID))
+	if err != nil {
+		c.logger.Error("failed to duplicate course", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return domain.Course{}, err
+	}
+
+	c.logger.Info("course is successfully duplicated",
+		zap.String("courseID", courseID.String()), zap.String("duplicateID", duplicate.ID.String()),
+		zap.String("schoolID", schoolID.String()))
+	return duplicate, nil
+}
+
 func (c *CourseService) Update(ctx context.Context, courseID domain.ID,
 	param port.UpdateCourseParam) (domain.Course, error) {
 	course, err := c.repo.FindByID(ctx, courseID)
@@ -312,7 +823,13 @@ func (c *CourseService) Update(ctx context.Context, courseID domain.ID,
 		if param.Price.Int64 < 0 {
 			return domain.Course{}, errs.ErrCourseInvalidPrice
 		}
-		course.Price = param.Price.Int64
+		course.Price.Amount = param.Price.Int64
+	}
+	if param.Currency.Valid {
+		if !domain.SupportedCurrency(param.Currency.String) {
+			return domain.Course{}, errs.ErrCourseInvalidCurrency
+		}
+		course.Price.Currency = param.Currency.String
 	}
 	if param.Name.Valid {
 		course.Name = param.Name.String
@@ -326,6 +843,18 @@ func (c *CourseService) Update(ctx context.Context, courseID domain.ID,
 	if param.Language.Valid {
 		course.Language = param.Language.String
 	}
+	if param.Capacity.Valid {
+		if param.Capacity.Int64 < 1 {
+			return domain.Course{}, errs.ErrCourseInvalidCapacity
+		}
+		course.Capacity = param.Capacity
+	}
+	if param.AccessDays.Valid {
+		if param.AccessDays.Int64 < 1 {
+			return domain.Course{}, errs.ErrCourseInvalidAccessPeriod
+		}
+		course.AccessDays = param.AccessDays
+	}
 
 	course, err = c.repo.Update(ctx, course)
 	if err != nil {
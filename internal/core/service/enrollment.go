@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"time"
+)
+
+const enrollmentReminderAdvance = 7 * 24 * time.Hour
+
+type EnrollmentService struct {
+	courseRepo port.ICourseRepository
+	userRepo   port.IUserRepository
+	mailer     port.IMailer
+	logger     *zap.Logger
+}
+
+func NewEnrollmentService(courseRepo port.ICourseRepository, userRepo port.IUserRepository,
+	mailer port.IMailer, logger *zap.Logger) *EnrollmentService {
+	return &EnrollmentService{
+		courseRepo: courseRepo,
+		userRepo:   userRepo,
+		mailer:     mailer,
+		logger:     logger,
+	}
+}
+
+// RemindExpiringEnrollments emails students whose access ends soon, every
+// enrollment is reminded once per access period, failed reminders are retried
+// by the next run
+func (e *EnrollmentService) RemindExpiringEnrollments(ctx context.Context) error {
+	now := time.Now().UTC()
+	enrollments, err := e.courseRepo.FindExpiringEnrollments(ctx, now, now.Add(enrollmentReminderAdvance))
+	if err != nil {
+		e.logger.Error("failed to find expiring enrollments", zap.Error(err))
+		return err
+	}
+
+	var reminded int
+	for _, enrollment := range enrollments {
+		err = e.remindEnrollment(ctx, enrollment, now)
+		if err != nil {
+			e.logger.Error("failed to remind expiring enrollment", zap.Error(err),
+				zap.String("userID", enrollment.StudentID.String()),
+				zap.String("courseID", enrollment.CourseID.String()))
+			continue
+		}
+		reminded++
+	}
+
+	e.logger.Info("expiring enrollments are reminded",
+		zap.Int("found", len(enrollments)), zap.Int("reminded", reminded))
+	return nil
+}
+
+func (e *EnrollmentService) remindEnrollment(ctx context.Context, enrollment domain.Enrollment,
+	now time.Time) error {
+	user, err := e.userRepo.FindByID(ctx, enrollment.StudentID)
+	if err != nil {
+		return err
+	}
+
+	course, err := e.courseRepo.FindByID(ctx, enrollment.CourseID)
+	if err != nil {
+		return err
+	}
+
+	err = e.mailer.Send(ctx, domain.Mail{
+		To:      user.Email,
+		Subject: "Course access expires soon",
+		Body: fmt.Sprintf("Hello, %s!\n\nYour access to course \"%s\" expires on %s.\n"+
+			"Renew it to keep learning, your progress is saved.\n",
+			user.Name, course.Name, enrollment.ExpiresAt.Time.Format(time.DateOnly)),
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.courseRepo.MarkEnrollmentReminded(ctx, enrollment.StudentID, enrollment.CourseID, now)
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+const giftCodeSize = 10
+
+type GiftService struct {
+	repo       port.IGiftRepository
+	courseRepo port.ICourseRepository
+	lessonRepo port.ILessonRepository
+	statRepo   port.IStatRepository
+	mailer     port.IMailer
+	logger     *zap.Logger
+}
+
+func NewGiftService(repo port.IGiftRepository, courseRepo port.ICourseRepository,
+	lessonRepo port.ILessonRepository, statRepo port.IStatRepository,
+	mailer port.IMailer, logger *zap.Logger) *GiftService {
+	return &GiftService{
+		repo:       repo,
+		courseRepo: courseRepo,
+		lessonRepo: lessonRepo,
+		statRepo:   statRepo,
+		mailer:     mailer,
+		logger:     logger,
+	}
+}
+
+// IssueGift creates the gift of the paid course and sends its code to the
+// recipient, repeated calls for the same payment send the same code again
+func (g *GiftService) IssueGift(ctx context.Context, paymentKey string,
+	payload domain.PaymentPayload) (domain.Gift, error) {
+	course, err := g.courseRepo.FindByID(ctx, payload.CourseID)
+	if err != nil {
+		g.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", payload.CourseID.String()))
+		return domain.Gift{}, err
+	}
+
+	code, err := generateGiftCode()
+	if err != nil {
+		g.logger.Error("failed to generate gift code", zap.Error(err))
+		return domain.Gift{}, err
+	}
+
+	gift, err := g.repo.Create(ctx, domain.Gift{
+		ID:             domain.NewID(),
+		Code:           code,
+		CourseID:       payload.CourseID,
+		RecipientEmail: payload.RecipientEmail,
+		PaymentKey:     paymentKey,
+		PaySum:         payload.PaySum.Amount,
+		Currency:       payload.PaySum.Currency,
+		CreatedAt:      time.Now().UTC(),
+	})
+	if err != nil {
+		g.logger.Error("failed to create gift", zap.Error(err),
+			zap.String("courseID", payload.CourseID.String()), zap.String("key", paymentKey))
+		return domain.Gift{}, err
+	}
+
+	err = g.mailer.Send(ctx, domain.Mail{
+		To:      gift.RecipientEmail,
+		Subject: "You have received a course",
+		Body: fmt.Sprintf("Hello!\n\nSomebody has bought course \"%s\" for you.\n"+
+			"Sign in and redeem gift code %s to start learning.\n", course.Name, gift.Code),
+	})
+	if err != nil {
+		g.logger.Error("failed to send gift code", zap.Error(err),
+			zap.String("giftID", gift.ID.String()))
+		return domain.Gift{}, err
+	}
+
+	g.logger.Info("gift is successfully issued",
+		zap.String("giftID", gift.ID.String()), zap.String("courseID", gift.CourseID.String()))
+	return gift, nil
+}
+
+// RedeemGift binds the gift to the user, the caller enrolls the user
+// to the gift course and cancels the redemption if enrollment fails
+func (g *GiftService) RedeemGift(ctx context.Context, userID domain.ID, code string) (domain.Gift, error) {
+	gift, err := g.repo.FindByCode(ctx, normalizeGiftCode(code))
+	if err != nil {
+		g.logger.Error("failed to find gift by code", zap.Error(err))
+		return domain.Gift{}, err
+	}
+
+	if gift.Redeemed() {
+		return domain.Gift{}, errs.ErrGiftAlreadyRedeemed
+	}
+
+	// subscriber redeems the gift too, gifted course stays after the subscription ends
+	isStudent, err := isEnrolledStudent(ctx, g.courseRepo, userID, gift.CourseID)
+	if err != nil {
+		g.logger.Error("failed to check if user is a course student", zap.Error(err),
+			zap.String("courseID", gift.CourseID.String()), zap.String("userID", userID.String()))
+		return domain.Gift{}, err
+	}
+
+	if isStudent {
+		return domain.Gift{}, errs.ErrUserIsAlreadyCourseStudent
+	}
+
+	// the buyer can't know the progress of the recipient, so it's checked on redemption
+	err = checkCoursePrerequisites(ctx, g.courseRepo, g.lessonRepo, g.statRepo, userID, gift.CourseID)
+	if err != nil {
+		if !errors.Is(err, errs.ErrCoursePrerequisitesNotMet) {
+			g.logger.Error("failed to check course prerequisites", zap.Error(err),
+				zap.String("courseID", gift.CourseID.String()), zap.String("userID", userID.String()))
+		}
+		return domain.Gift{}, err
+	}
+
+	err = g.repo.Redeem(ctx, gift.ID, userID, time.Now().UTC())
+	if err != nil {
+		g.logger.Error("failed to redeem gift", zap.Error(err),
+			zap.String("giftID", gift.ID.String()), zap.String("userID", userID.String()))
+		return domain.Gift{}, err
+	}
+
+	g.logger.Info("gift is successfully redeemed",
+		zap.String("giftID", gift.ID.String()), zap.String("userID", userID.String()))
+	return gift, nil
+}
+
+func (g *GiftService) CancelGiftRedemption(ctx context.Context, userID domain.ID, gift domain.Gift) error {
+	err := g.repo.CancelRedemption(ctx, gift.ID, userID)
+	if err != nil {
+		g.logger.Error("failed to cancel gift redemption", zap.Error(err),
+			zap.String("giftID", gift.ID.String()), zap.String("userID", userID.String()))
+		return err
+	}
+
+	g.logger.Info("gift redemption is cancelled",
+		zap.String("giftID", gift.ID.String()), zap.String("userID", userID.String()))
+	return nil
+}
+
+func generateGiftCode() (string, error) {
+	b := make([]byte, giftCodeSize)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
+
+func normalizeGiftCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
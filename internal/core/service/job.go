@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"time"
+)
+
+// jobLease is the time given to run the claimed job,
+// job is claimed again after the lease if the instance crashed
+const jobLease = 5 * time.Minute
+
+type JobService struct {
+	repo   port.IJobRepository
+	logger *zap.Logger
+}
+
+func NewJobService(repo port.IJobRepository, logger *zap.Logger) *JobService {
+	return &JobService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func (j *JobService) ScheduleJob(ctx context.Context, job domain.Job) error {
+	err := j.repo.Create(ctx, job)
+	if err != nil {
+		j.logger.Error("failed to schedule job", zap.Error(err),
+			zap.String("kind", job.Kind), zap.String("targetID", job.TargetID.String()))
+		return err
+	}
+
+	j.logger.Info("job is successfully scheduled",
+		zap.String("jobID", job.ID.String()), zap.String("kind", job.Kind),
+		zap.String("targetID", job.TargetID.String()), zap.Time("runAt", job.RunAt))
+	return nil
+}
+
+func (j *JobService) ClaimDueJobs(ctx context.Context, limit int) ([]domain.Job, error) {
+	now := time.Now().UTC()
+	jobs, err := j.repo.ClaimDueJobs(ctx, now, now.Add(jobLease), limit)
+	if err != nil {
+		j.logger.Error("failed to claim due jobs", zap.Error(err))
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (j *JobService) CompleteJob(ctx context.Context, job domain.Job) error {
+	err := j.repo.Complete(ctx, job.ID)
+	if err != nil {
+		j.logger.Error("failed to complete job", zap.Error(err),
+			zap.String("jobID", job.ID.String()))
+		return err
+	}
+
+	j.logger.Info("job is successfully completed",
+		zap.String("jobID", job.ID.String()), zap.String("kind", job.Kind))
+	return nil
+}
+
+// FailJob postpones the failed job, job is failed for good after the last attempt
+func (j *JobService) FailJob(ctx context.Context, job domain.Job, cause error) error {
+	var err error
+	if job.Exhausted() {
+		err = j.repo.Fail(ctx, job.ID, cause.Error())
+	} else {
+		err = j.repo.Retry(ctx, job.ID, job.RetryAt(time.Now().UTC()), cause.Error())
+	}
+	if err != nil {
+		j.logger.Error("failed to save job failure", zap.Error(err),
+			zap.String("jobID", job.ID.String()))
+		return err
+	}
+
+	j.logger.Warn("job is failed", zap.Error(cause),
+		zap.String("jobID", job.ID.String()), zap.String("kind", job.Kind),
+		zap.Int("attempts", job.Attempts), zap.Bool("exhausted", job.Exhausted()))
+	return nil
+}
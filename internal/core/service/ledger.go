@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"sort"
+	"time"
+)
+
+type LedgerConfig struct {
+	CommissionPercent int64
+}
+
+type LedgerService struct {
+	repo       port.ILedgerRepository
+	schoolRepo port.ISchoolRepository
+	logger     *zap.Logger
+}
+
+func NewLedgerService(repo port.ILedgerRepository, schoolRepo port.ISchoolRepository,
+	logger *zap.Logger) *LedgerService {
+	return &LedgerService{
+		repo:       repo,
+		schoolRepo: schoolRepo,
+		logger:     logger,
+	}
+}
+
+// FindSchoolStatement sums school ledger entries of the calendar month
+// (UTC) containing the given time per course and currency
+func (l *LedgerService) FindSchoolStatement(ctx context.Context, schoolID domain.ID,
+	month time.Time) (domain.Statement, error) {
+	month = month.UTC()
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	entries, err := l.repo.FindSchoolEntries(ctx, schoolID, from, to)
+	if err != nil {
+		l.logger.Error("failed to find school ledger entries", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return domain.Statement{}, err
+	}
+
+	courses, err := l.schoolRepo.FindSchoolCourses(ctx, schoolID)
+	if err != nil {
+		l.logger.Error("failed to get school courses", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return domain.Statement{}, err
+	}
+
+	courseNames := make(map[domain.ID]string, len(courses))
+	for _, course := range courses {
+		courseNames[course.ID] = course.Name
+	}
+
+	statement := domain.Statement{
+		SchoolID: schoolID,
+		From:     from,
+		To:       to,
+	}
+	// course price currency can change, its entries are summed per currency
+	type courseKey struct {
+		courseID domain.ID
+		currency string
+	}
+	courseStatements := make(map[courseKey]*domain.CourseStatement)
+	totals := make(map[string]*domain.StatementTotal)
+	for _, entry := range entries {
+		key := courseKey{courseID: entry.CourseID, currency: entry.Currency}
+		courseStatement, ok := courseStatements[key]
+		if !ok {
+			courseStatement = &domain.CourseStatement{
+				CourseID:   entry.CourseID,
+				CourseName: courseNames[entry.CourseID],
+				Currency:   entry.Currency,
+			}
+			courseStatements[key] = courseStatement
+		}
+		total, ok := totals[entry.Currency]
+		if !ok {
+			total = &domain.StatementTotal{Currency: entry.Currency}
+			totals[entry.Currency] = total
+		}
+
+		switch entry.Kind {
+		case domain.LedgerSale:
+			courseStatement.Sales++
+		case domain.LedgerRefund:
+			courseStatement.Refunds++
+		}
+		courseStatement.Gross += entry.Gross
+		courseStatement.Commission += entry.Commission
+		courseStatement.Net += entry.Net()
+		if entry.Settled() {
+			courseStatement.Settled += entry.Net()
+		}
+
+		total.Gross += entry.Gross
+		total.Commission += entry.Commission
+		total.Net += entry.Net()
+		if entry.Settled() {
+			total.Settled += entry.Net()
+		}
+	}
+
+	statement.Courses = make([]domain.CourseStatement, 0, len(courseStatements))
+	for _, courseStatement := range courseStatements {
+		statement.Courses = append(statement.Courses, *courseStatement)
+	}
+	sort.Slice(statement.Courses, func(i, j int) bool {
+		if statement.Courses[i].CourseName != statement.Courses[j].CourseName {
+			return statement.Courses[i].CourseName < statement.Courses[j].CourseName
+		}
+		if statement.Courses[i].CourseID != statement.Courses[j].CourseID {
+			return statement.Courses[i].CourseID < statement.Courses[j].CourseID
+		}
+		return statement.Courses[i].Currency < statement.Courses[j].Currency
+	})
+
+	statement.Totals = make([]domain.StatementTotal, 0, len(totals))
+	for _, total := range totals {
+		statement.Totals = append(statement.Totals, *total)
+	}
+	sort.Slice(statement.Totals, func(i, j int) bool {
+		return statement.Totals[i].Currency < statement.Totals[j].Currency
+	})
+
+	return statement, nil
+}
+
+func (l *LedgerService) FindSchoolPayouts(ctx context.Context, schoolID domain.ID) ([]domain.Payout, error) {
+	payouts, err := l.repo.FindSchoolPayouts(ctx, schoolID)
+	if err != nil {
+		l.logger.Error("failed to find school payouts", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return nil, err
+	}
+	return payouts, nil
+}
+
+// CreateSchoolPayout pays out the school earnings in one currency,
+// empty currency means the default one
+func (l *LedgerService) CreateSchoolPayout(ctx context.Context, schoolID domain.ID, currency string,
+	settledUntil time.Time) (domain.Payout, error) {
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	if !domain.SupportedCurrency(currency) {
+		l.logger.Error("failed to create school payout", zap.Error(errs.ErrPayoutInvalidCurrency),
+			zap.String("schoolID", schoolID.String()), zap.String("currency", currency))
+		return domain.Payout{}, errs.ErrPayoutInvalidCurrency
+	}
+
+	now := time.Now().UTC()
+	if settledUntil.IsZero() || settledUntil.After(now) {
+		settledUntil = now
+	}
+
+	payout, err := l.repo.CreatePayout(ctx, domain.Payout{
+		ID:           domain.NewID(),
+		SchoolID:     schoolID,
+		Currency:     currency,
+		SettledUntil: settledUntil.UTC(),
+		CreatedAt:    now,
+	})
+	if err != nil {
+		l.logger.Error("failed to create school payout", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return domain.Payout{}, err
+	}
+
+	l.logger.Info("school payout is successfully created",
+		zap.String("payoutID", payout.ID.String()), zap.String("schoolID", schoolID.String()),
+		zap.Int64("amount", payout.Amount), zap.String("currency", payout.Currency))
+	return payout, nil
+}
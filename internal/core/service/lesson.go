@@ -4,23 +4,26 @@ import (
 	"context"
 	"github.com/guregu/null"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/paw1a/eschool/internal/core/port"
 	"go.uber.org/zap"
 	"strings"
 )
 
 type LessonService struct {
-	repo    port.ILessonRepository
-	storage port.IObjectStorage
-	logger  *zap.Logger
+	repo       port.ILessonRepository
+	courseRepo port.ICourseRepository
+	storage    port.IObjectStorage
+	logger     *zap.Logger
 }
 
-func NewLessonService(repo port.ILessonRepository, storage port.IObjectStorage,
-	logger *zap.Logger) *LessonService {
+func NewLessonService(repo port.ILessonRepository, courseRepo port.ICourseRepository,
+	storage port.IObjectStorage, logger *zap.Logger) *LessonService {
 	return &LessonService{
-		repo:    repo,
-		storage: storage,
-		logger:  logger,
+		repo:       repo,
+		courseRepo: courseRepo,
+		storage:    storage,
+		logger:     logger,
 	}
 }
 
@@ -53,8 +56,24 @@ func (l *LessonService) FindCourseLessons(ctx context.Context, courseID domain.I
 	return lessons, nil
 }
 
+func (l *LessonService) FindCourseVersionLessons(ctx context.Context, courseID domain.ID,
+	version int) ([]domain.Lesson, error) {
+	lessons, err := l.repo.FindCourseVersionLessons(ctx, courseID, version)
+	if err != nil {
+		l.logger.Error("failed to find course version lessons", zap.Error(err),
+			zap.String("courseID", courseID.String()), zap.Int("version", version))
+		return nil, err
+	}
+	return lessons, nil
+}
+
 func (l *LessonService) CreateTheoryLesson(ctx context.Context, courseID domain.ID,
 	param port.CreateTheoryParam) (domain.Lesson, error) {
+	version, err := l.findEditableVersion(ctx, courseID)
+	if err != nil {
+		return domain.Lesson{}, err
+	}
+
 	lessonID := domain.NewID()
 	url, err := l.storage.SaveFile(ctx, domain.File{
 		Name:   lessonID.String() + ".md",
@@ -69,7 +88,9 @@ func (l *LessonService) CreateTheoryLesson(ctx context.Context, courseID domain.
 
 	lesson := domain.Lesson{
 		ID:        lessonID,
+		Key:       lessonID,
 		CourseID:  courseID,
+		Version:   version,
 		Title:     param.Title,
 		Score:     param.Score,
 		Type:      domain.TheoryLesson,
@@ -95,10 +116,17 @@ func (l *LessonService) CreateTheoryLesson(ctx context.Context, courseID domain.
 
 func (l *LessonService) CreateVideoLesson(ctx context.Context, courseID domain.ID,
 	param port.CreateVideoParam) (domain.Lesson, error) {
+	version, err := l.findEditableVersion(ctx, courseID)
+	if err != nil {
+		return domain.Lesson{}, err
+	}
+
 	lessonID := domain.NewID()
 	lesson := domain.Lesson{
 		ID:       lessonID,
+		Key:      lessonID,
 		CourseID: courseID,
+		Version:  version,
 		Title:    param.Title,
 		Score:    param.Score,
 		Type:     domain.VideoLesson,
@@ -108,7 +136,7 @@ func (l *LessonService) CreateVideoLesson(ctx context.Context, courseID domain.I
 		return domain.Lesson{}, err
 	}
 
-	lesson, err := l.repo.Create(ctx, lesson)
+	lesson, err = l.repo.Create(ctx, lesson)
 	if err != nil {
 		l.logger.Error("failed to create video lesson", zap.Error(err),
 			zap.String("courseID", courseID.String()))
@@ -122,12 +150,18 @@ func (l *LessonService) CreateVideoLesson(ctx context.Context, courseID domain.I
 
 func (l *LessonService) CreatePracticeLesson(ctx context.Context, courseID domain.ID,
 	param port.CreatePracticeParam) (domain.Lesson, error) {
+	version, err := l.findEditableVersion(ctx, courseID)
+	if err != nil {
+		return domain.Lesson{}, err
+	}
 
 	lessonID := domain.NewID()
 	tests := make([]domain.Test, len(param.Tests))
 	for i, test := range param.Tests {
+		testID := domain.NewID()
 		tests[i] = domain.Test{
-			ID:       domain.NewID(),
+			ID:       testID,
+			Key:      testID,
 			LessonID: lessonID,
 			TaskUrl:  "undefined",
 			Options:  test.Options,
@@ -139,7 +173,9 @@ func (l *LessonService) CreatePracticeLesson(ctx context.Context, courseID domai
 
 	lesson := domain.Lesson{
 		ID:       lessonID,
+		Key:      lessonID,
 		CourseID: courseID,
+		Version:  version,
 		Title:    param.Title,
 		Score:    param.Score,
 		Type:     domain.PracticeLesson,
@@ -165,7 +201,7 @@ func (l *LessonService) CreatePracticeLesson(ctx context.Context, courseID domai
 		tests[i].TaskUrl = url.String()
 	}
 
-	lesson, err := l.repo.Create(ctx, lesson)
+	lesson, err = l.repo.Create(ctx, lesson)
 	if err != nil {
 		l.logger.Error("failed to create practice lesson", zap.Error(err),
 			zap.String("courseID", courseID.String()))
@@ -184,6 +220,10 @@ func (l *LessonService) UpdateTheoryLesson(ctx context.Context, lessonID domain.
 		return domain.Lesson{}, err
 	}
 
+	if err = l.checkLessonEditable(ctx, lesson); err != nil {
+		return domain.Lesson{}, err
+	}
+
 	if param.Theory.Valid {
 		url, err := l.storage.SaveFile(ctx, domain.File{
 			Name:   lesson.ID.String() + ".md",
@@ -212,6 +252,10 @@ func (l *LessonService) UpdateVideoLesson(ctx context.Context, lessonID domain.I
 		return domain.Lesson{}, err
 	}
 
+	if err = l.checkLessonEditable(ctx, lesson); err != nil {
+		return domain.Lesson{}, err
+	}
+
 	if param.VideoUrl.Valid {
 		lesson.VideoUrl = null.StringFrom(param.VideoUrl.String)
 	}
@@ -233,6 +277,10 @@ func (l *LessonService) UpdatePracticeLesson(ctx context.Context, lessonID domai
 		return domain.Lesson{}, err
 	}
 
+	if err = l.checkLessonEditable(ctx, lesson); err != nil {
+		return domain.Lesson{}, err
+	}
+
 	if param.Score.Valid {
 		lesson.Score = int(param.Score.Int64)
 	}
@@ -240,10 +288,17 @@ func (l *LessonService) UpdatePracticeLesson(ctx context.Context, lessonID domai
 		lesson.Title = param.Title.String
 	}
 
+	// tests are replaced, the test keeps the key of the test at the same position
 	tests := make([]domain.Test, len(param.Tests))
 	for i, test := range param.Tests {
+		testID := domain.NewID()
+		key := testID
+		if i < len(lesson.Tests) {
+			key = lesson.Tests[i].Key
+		}
 		tests[i] = domain.Test{
-			ID:       domain.NewID(),
+			ID:       testID,
+			Key:      key,
 			LessonID: lessonID,
 			TaskUrl:  "undefined",
 			Options:  test.Options,
@@ -272,8 +327,143 @@ func (l *LessonService) UpdatePracticeLesson(ctx context.Context, lessonID domai
 	return l.repo.Update(ctx, lesson)
 }
 
+// UpdateLessonRelease changes the release rule, it is not the lesson content,
+// so lessons of the published course version can be rescheduled too
+func (l *LessonService) UpdateLessonRelease(ctx context.Context, lessonID domain.ID,
+	param port.UpdateLessonReleaseParam) (domain.Lesson, error) {
+	if param.ReleaseAt.Valid && param.ReleaseDays.Valid {
+		return domain.Lesson{}, errs.ErrLessonInvalidRelease
+	}
+	if param.ReleaseDays.Valid && param.ReleaseDays.Int64 < 0 {
+		return domain.Lesson{}, errs.ErrLessonInvalidRelease
+	}
+	if param.ReleaseAt.Valid {
+		param.ReleaseAt.Time = param.ReleaseAt.Time.UTC()
+	}
+
+	lesson, err := l.repo.UpdateRelease(ctx, lessonID, param.ReleaseAt, param.ReleaseDays)
+	if err != nil {
+		l.logger.Error("failed to update lesson release", zap.Error(err),
+			zap.String("lessonID", lessonID.String()))
+		return domain.Lesson{}, err
+	}
+
+	l.logger.Info("lesson release is successfully updated",
+		zap.String("lessonID", lessonID.String()))
+	return lesson, nil
+}
+
+// UpdateLessonPreview marks the lesson as the course preview, like the release
+// rule it is not the lesson content, so published lessons can be marked too
+func (l *LessonService) UpdateLessonPreview(ctx context.Context, lessonID domain.ID,
+	isPreview bool) (domain.Lesson, error) {
+	lesson, err := l.repo.FindByID(ctx, lessonID)
+	if err != nil {
+		l.logger.Error("failed to find lesson by id", zap.Error(err),
+			zap.String("lessonID", lessonID.String()))
+		return domain.Lesson{}, err
+	}
+
+	if isPreview && !lesson.IsPreview {
+		lessons, err := l.repo.FindCourseVersionLessons(ctx, lesson.CourseID, lesson.Version)
+		if err != nil {
+			l.logger.Error("failed to find course version lessons", zap.Error(err),
+				zap.String("courseID", lesson.CourseID.String()))
+			return domain.Lesson{}, err
+		}
+
+		previews := 0
+		for _, courseLesson := range lessons {
+			if courseLesson.IsPreview {
+				previews++
+			}
+		}
+		if previews >= domain.MaxCoursePreviewLessons {
+			return domain.Lesson{}, errs.ErrLessonPreviewLimit
+		}
+	}
+
+	lesson, err = l.repo.UpdatePreview(ctx, lessonID, isPreview)
+	if err != nil {
+		l.logger.Error("failed to update lesson preview", zap.Error(err),
+			zap.String("lessonID", lessonID.String()))
+		return domain.Lesson{}, err
+	}
+
+	l.logger.Info("lesson preview is successfully updated",
+		zap.String("lessonID", lessonID.String()), zap.Bool("isPreview", isPreview))
+	return lesson, nil
+}
+
+// CopyCourseLessons copies the edited lessons of the course with their
+// stored markdown files into another course
+func (l *LessonService) CopyCourseLessons(ctx context.Context, fromCourseID,
+	toCourseID domain.ID) ([]domain.Lesson, error) {
+	lessons, err := l.repo.FindCourseLessons(ctx, fromCourseID)
+	if err != nil {
+		l.logger.Error("failed to find course lessons", zap.Error(err),
+			zap.String("courseID", fromCourseID.String()))
+		return nil, err
+	}
+
+	copies := make([]domain.Lesson, len(lessons))
+	for i, lesson := range lessons {
+		copies[i], err = l.copyLessonFiles(ctx, lesson.Duplicate(toCourseID))
+		if err != nil {
+			l.logger.Error("failed to copy lesson files", zap.Error(err),
+				zap.String("lessonID", lesson.ID.String()))
+			return nil, err
+		}
+	}
+
+	err = l.repo.CreateCourseLessons(ctx, copies)
+	if err != nil {
+		l.logger.Error("failed to create lesson copies", zap.Error(err),
+			zap.String("courseID", toCourseID.String()))
+		return nil, err
+	}
+
+	l.logger.Info("course lessons are successfully copied",
+		zap.String("fromCourseID", fromCourseID.String()),
+		zap.String("toCourseID", toCourseID.String()), zap.Int("count", len(copies)))
+	return copies, nil
+}
+
+// copyLessonFiles stores files of the lesson copy under the paths of its
+// course, video urls are external and kept as they are
+func (l *LessonService) copyLessonFiles(ctx context.Context, lesson domain.Lesson) (domain.Lesson, error) {
+	switch lesson.Type {
+	case domain.TheoryLesson:
+		url, err := l.storage.CopyFile(ctx, domain.Url(lesson.TheoryUrl.String),
+			"course/"+lesson.CourseID.String(), lesson.ID.String()+".md")
+		if err != nil {
+			return domain.Lesson{}, err
+		}
+		lesson.TheoryUrl = null.StringFrom(url.String())
+	case domain.PracticeLesson:
+		for i, test := range lesson.Tests {
+			url, err := l.storage.CopyFile(ctx, domain.Url(test.TaskUrl),
+				"course/"+lesson.CourseID.String()+"/"+lesson.ID.String(), test.ID.String()+".md")
+			if err != nil {
+				return domain.Lesson{}, err
+			}
+			lesson.Tests[i].TaskUrl = url.String()
+		}
+	}
+	return lesson, nil
+}
+
 func (l *LessonService) Delete(ctx context.Context, lessonID domain.ID) error {
-	err := l.repo.Delete(ctx, lessonID)
+	lesson, err := l.repo.FindByID(ctx, lessonID)
+	if err != nil {
+		return err
+	}
+
+	if err = l.checkLessonEditable(ctx, lesson); err != nil {
+		return err
+	}
+
+	err = l.repo.Delete(ctx, lessonID)
 	if err != nil {
 		l.logger.Error("failed to delete lesson", zap.Error(err),
 			zap.String("lessonID", lessonID.String()))
@@ -284,3 +474,35 @@ func (l *LessonService) Delete(ctx context.Context, lessonID domain.ID) error {
 		zap.String("lessonID", lessonID.String()))
 	return nil
 }
+
+// findEditableVersion returns the course version edited by teachers,
+// lessons are added only to the draft revision of the published course
+func (l *LessonService) findEditableVersion(ctx context.Context, courseID domain.ID) (int, error) {
+	course, err := l.courseRepo.FindByID(ctx, courseID)
+	if err != nil {
+		l.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return 0, err
+	}
+
+	if !course.Editable(course.Version) {
+		return 0, errs.ErrLessonPublished
+	}
+	return course.Version, nil
+}
+
+func (l *LessonService) checkLessonEditable(ctx context.Context, lesson domain.Lesson) error {
+	course, err := l.courseRepo.FindByID(ctx, lesson.CourseID)
+	if err != nil {
+		l.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", lesson.CourseID.String()))
+		return err
+	}
+
+	if !course.Editable(lesson.Version) {
+		l.logger.Error("published lesson can't be changed",
+			zap.String("lessonID", lesson.ID.String()), zap.Int("version", lesson.Version))
+		return errs.ErrLessonPublished
+	}
+	return nil
+}
@@ -12,6 +12,41 @@ type AuthProvider struct {
 	mock.Mock
 }
 
+// CreateEmailToken provides a mock function with given fields: userID, purpose
+func (_m *AuthProvider) CreateEmailToken(userID domain.ID, purpose domain.EmailTokenPurpose) (domain.EmailToken, domain.Token, error) {
+	ret := _m.Called(userID, purpose)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEmailToken")
+	}
+
+	var r0 domain.EmailToken
+	var r1 domain.Token
+	var r2 error
+	if rf, ok := ret.Get(0).(func(domain.ID, domain.EmailTokenPurpose) (domain.EmailToken, domain.Token, error)); ok {
+		return rf(userID, purpose)
+	}
+	if rf, ok := ret.Get(0).(func(domain.ID, domain.EmailTokenPurpose) domain.EmailToken); ok {
+		r0 = rf(userID, purpose)
+	} else {
+		r0 = ret.Get(0).(domain.EmailToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.ID, domain.EmailTokenPurpose) domain.Token); ok {
+		r1 = rf(userID, purpose)
+	} else {
+		r1 = ret.Get(1).(domain.Token)
+	}
+
+	if rf, ok := ret.Get(2).(func(domain.ID, domain.EmailTokenPurpose) error); ok {
+		r2 = rf(userID, purpose)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // CreateJWTSession provides a mock function with given fields: payload, fingerprint
 func (_m *AuthProvider) CreateJWTSession(payload domain.AuthPayload, fingerprint string) (domain.AuthDetails, error) {
 	ret := _m.Called(payload, fingerprint)
@@ -40,6 +75,34 @@ func (_m *AuthProvider) CreateJWTSession(payload domain.AuthPayload, fingerprint
 	return r0, r1
 }
 
+// CreateMfaToken provides a mock function with given fields: userID
+func (_m *AuthProvider) CreateMfaToken(userID domain.ID) (domain.Token, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMfaToken")
+	}
+
+	var r0 domain.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.ID) (domain.Token, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(domain.ID) domain.Token); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(domain.Token)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.ID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteJWTSession provides a mock function with given fields: refreshToken
 func (_m *AuthProvider) DeleteJWTSession(refreshToken domain.Token) error {
 	ret := _m.Called(refreshToken)
@@ -58,6 +121,24 @@ func (_m *AuthProvider) DeleteJWTSession(refreshToken domain.Token) error {
 	return r0
 }
 
+// DeleteUserJWTSessions provides a mock function with given fields: userID
+func (_m *AuthProvider) DeleteUserJWTSessions(userID domain.ID) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserJWTSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.ID) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RefreshJWTSession provides a mock function with given fields: refreshToken, fingerprint
 func (_m *AuthProvider) RefreshJWTSession(refreshToken domain.Token, fingerprint string) (domain.AuthDetails, error) {
 	ret := _m.Called(refreshToken, fingerprint)
@@ -86,6 +167,34 @@ func (_m *AuthProvider) RefreshJWTSession(refreshToken domain.Token, fingerprint
 	return r0, r1
 }
 
+// VerifyEmailToken provides a mock function with given fields: token
+func (_m *AuthProvider) VerifyEmailToken(token domain.Token) (domain.EmailToken, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmailToken")
+	}
+
+	var r0 domain.EmailToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Token) (domain.EmailToken, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Token) domain.EmailToken); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(domain.EmailToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Token) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VerifyJWTToken provides a mock function with given fields: accessToken
 func (_m *AuthProvider) VerifyJWTToken(accessToken domain.Token) (domain.AuthPayload, error) {
 	ret := _m.Called(accessToken)
@@ -114,6 +223,34 @@ func (_m *AuthProvider) VerifyJWTToken(accessToken domain.Token) (domain.AuthPay
 	return r0, r1
 }
 
+// VerifyMfaToken provides a mock function with given fields: token
+func (_m *AuthProvider) VerifyMfaToken(token domain.Token) (domain.ID, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMfaToken")
+	}
+
+	var r0 domain.ID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Token) (domain.ID, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Token) domain.ID); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(domain.ID)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Token) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewAuthProvider creates a new instance of AuthProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthProvider(t interface {
@@ -7,6 +7,10 @@ import (
 
 	domain "github.com/paw1a/eschool/internal/core/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	null "github.com/guregu/null"
+
+	time "time"
 )
 
 // CourseRepository is an autogenerated mock type for the ICourseRepository type
@@ -14,6 +18,24 @@ type CourseRepository struct {
 	mock.Mock
 }
 
+// AddCoursePrerequisite provides a mock function with given fields: ctx, prerequisite
+func (_m *CourseRepository) AddCoursePrerequisite(ctx context.Context, prerequisite domain.CoursePrerequisite) error {
+	ret := _m.Called(ctx, prerequisite)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCoursePrerequisite")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoursePrerequisite) error); ok {
+		r0 = rf(ctx, prerequisite)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddCourseStudent provides a mock function with given fields: ctx, studentID, courseID
 func (_m *CourseRepository) AddCourseStudent(ctx context.Context, studentID domain.ID, courseID domain.ID) error {
 	ret := _m.Called(ctx, studentID, courseID)
@@ -154,6 +176,36 @@ func (_m *CourseRepository) FindByID(ctx context.Context, courseID domain.ID) (d
 	return r0, r1
 }
 
+// FindCoursePrerequisites provides a mock function with given fields: ctx, courseID
+func (_m *CourseRepository) FindCoursePrerequisites(ctx context.Context, courseID domain.ID) ([]domain.CoursePrerequisite, error) {
+	ret := _m.Called(ctx, courseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCoursePrerequisites")
+	}
+
+	var r0 []domain.CoursePrerequisite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID) ([]domain.CoursePrerequisite, error)); ok {
+		return rf(ctx, courseID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID) []domain.CoursePrerequisite); ok {
+		r0 = rf(ctx, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CoursePrerequisite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ID) error); ok {
+		r1 = rf(ctx, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindCourseTeachers provides a mock function with given fields: ctx, courseID
 func (_m *CourseRepository) FindCourseTeachers(ctx context.Context, courseID domain.ID) ([]domain.User, error) {
 	ret := _m.Called(ctx, courseID)
@@ -184,6 +236,94 @@ func (_m *CourseRepository) FindCourseTeachers(ctx context.Context, courseID dom
 	return r0, r1
 }
 
+// FindCourseTransitions provides a mock function with given fields: ctx, courseID
+func (_m *CourseRepository) FindCourseTransitions(ctx context.Context, courseID domain.ID) ([]domain.CourseTransition, error) {
+	ret := _m.Called(ctx, courseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCourseTransitions")
+	}
+
+	var r0 []domain.CourseTransition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID) ([]domain.CourseTransition, error)); ok {
+		return rf(ctx, courseID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID) []domain.CourseTransition); ok {
+		r0 = rf(ctx, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CourseTransition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ID) error); ok {
+		r1 = rf(ctx, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindEnrollment provides a mock function with given fields: ctx, studentID, courseID
+func (_m *CourseRepository) FindEnrollment(ctx context.Context, studentID domain.ID, courseID domain.ID) (domain.Enrollment, error) {
+	ret := _m.Called(ctx, studentID, courseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEnrollment")
+	}
+
+	var r0 domain.Enrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, domain.ID) (domain.Enrollment, error)); ok {
+		return rf(ctx, studentID, courseID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, domain.ID) domain.Enrollment); ok {
+		r0 = rf(ctx, studentID, courseID)
+	} else {
+		r0 = ret.Get(0).(domain.Enrollment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ID, domain.ID) error); ok {
+		r1 = rf(ctx, studentID, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindExpiringEnrollments provides a mock function with given fields: ctx, from, to
+func (_m *CourseRepository) FindExpiringEnrollments(ctx context.Context, from time.Time, to time.Time) ([]domain.Enrollment, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExpiringEnrollments")
+	}
+
+	var r0 []domain.Enrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]domain.Enrollment, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []domain.Enrollment); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Enrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindStudentCourses provides a mock function with given fields: ctx, studentID
 func (_m *CourseRepository) FindStudentCourses(ctx context.Context, studentID domain.ID) ([]domain.Course, error) {
 	ret := _m.Called(ctx, studentID)
@@ -300,6 +440,106 @@ func (_m *CourseRepository) IsCourseTeacher(ctx context.Context, teacherID domai
 	return r0, r1
 }
 
+// MarkEnrollmentReminded provides a mock function with given fields: ctx, studentID, courseID, remindedAt
+func (_m *CourseRepository) MarkEnrollmentReminded(ctx context.Context, studentID domain.ID, courseID domain.ID, remindedAt time.Time) error {
+	ret := _m.Called(ctx, studentID, courseID, remindedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkEnrollmentReminded")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, domain.ID, time.Time) error); ok {
+		r0 = rf(ctx, studentID, courseID, remindedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublishRevision provides a mock function with given fields: ctx, courseID
+func (_m *CourseRepository) PublishRevision(ctx context.Context, courseID domain.ID) error {
+	ret := _m.Called(ctx, courseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishRevision")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID) error); ok {
+		r0 = rf(ctx, courseID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveCoursePrerequisite provides a mock function with given fields: ctx, courseID, requiredCourseID
+func (_m *CourseRepository) RemoveCoursePrerequisite(ctx context.Context, courseID domain.ID, requiredCourseID domain.ID) error {
+	ret := _m.Called(ctx, courseID, requiredCourseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveCoursePrerequisite")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, domain.ID) error); ok {
+		r0 = rf(ctx, courseID, requiredCourseID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveCourseStudent provides a mock function with given fields: ctx, studentID, courseID
+func (_m *CourseRepository) RemoveCourseStudent(ctx context.Context, studentID domain.ID, courseID domain.ID) error {
+	ret := _m.Called(ctx, studentID, courseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveCourseStudent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, domain.ID) error); ok {
+		r0 = rf(ctx, studentID, courseID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenewCourseStudent provides a mock function with given fields: ctx, studentID, courseID, renewalKey, renewedAt
+func (_m *CourseRepository) RenewCourseStudent(ctx context.Context, studentID domain.ID, courseID domain.ID, renewalKey string, renewedAt time.Time) (domain.Enrollment, error) {
+	ret := _m.Called(ctx, studentID, courseID, renewalKey, renewedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewCourseStudent")
+	}
+
+	var r0 domain.Enrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, domain.ID, string, time.Time) (domain.Enrollment, error)); ok {
+		return rf(ctx, studentID, courseID, renewalKey, renewedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, domain.ID, string, time.Time) domain.Enrollment); ok {
+		r0 = rf(ctx, studentID, courseID, renewalKey, renewedAt)
+	} else {
+		r0 = ret.Get(0).(domain.Enrollment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ID, domain.ID, string, time.Time) error); ok {
+		r1 = rf(ctx, studentID, courseID, renewalKey, renewedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, course
 func (_m *CourseRepository) Update(ctx context.Context, course domain.Course) (domain.Course, error) {
 	ret := _m.Called(ctx, course)
@@ -328,17 +568,53 @@ func (_m *CourseRepository) Update(ctx context.Context, course domain.Course) (d
 	return r0, r1
 }
 
-// UpdateStatus provides a mock function with given fields: ctx, courseID, status
-func (_m *CourseRepository) UpdateStatus(ctx context.Context, courseID domain.ID, status domain.CourseStatus) error {
-	ret := _m.Called(ctx, courseID, status)
+// UpdateEnrollmentVersion provides a mock function with given fields: ctx, studentID, courseID, version
+func (_m *CourseRepository) UpdateEnrollmentVersion(ctx context.Context, studentID domain.ID, courseID domain.ID, version int) error {
+	ret := _m.Called(ctx, studentID, courseID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEnrollmentVersion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, domain.ID, int) error); ok {
+		r0 = rf(ctx, studentID, courseID, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePublishAt provides a mock function with given fields: ctx, courseID, publishAt
+func (_m *CourseRepository) UpdatePublishAt(ctx context.Context, courseID domain.ID, publishAt null.Time) error {
+	ret := _m.Called(ctx, courseID, publishAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePublishAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, null.Time) error); ok {
+		r0 = rf(ctx, courseID, publishAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, transition
+func (_m *CourseRepository) UpdateStatus(ctx context.Context, transition domain.CourseTransition) error {
+	ret := _m.Called(ctx, transition)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateStatus")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, domain.CourseStatus) error); ok {
-		r0 = rf(ctx, courseID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CourseTransition) error); ok {
+		r0 = rf(ctx, transition)
 	} else {
 		r0 = ret.Error(0)
 	}
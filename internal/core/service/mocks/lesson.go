@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/paw1a/eschool/internal/core/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	null "github.com/guregu/null"
 )
 
 // LessonRepository is an autogenerated mock type for the ILessonRepository type
@@ -42,6 +44,42 @@ func (_m *LessonRepository) Create(ctx context.Context, lesson domain.Lesson) (d
 	return r0, r1
 }
 
+// CreateCourseLessons provides a mock function with given fields: ctx, lessons
+func (_m *LessonRepository) CreateCourseLessons(ctx context.Context, lessons []domain.Lesson) error {
+	ret := _m.Called(ctx, lessons)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCourseLessons")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Lesson) error); ok {
+		r0 = rf(ctx, lessons)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRevision provides a mock function with given fields: ctx, courseID, version, lessons
+func (_m *LessonRepository) CreateRevision(ctx context.Context, courseID domain.ID, version int, lessons []domain.Lesson) error {
+	ret := _m.Called(ctx, courseID, version, lessons)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRevision")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, int, []domain.Lesson) error); ok {
+		r0 = rf(ctx, courseID, version, lessons)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, lessonID
 func (_m *LessonRepository) Delete(ctx context.Context, lessonID domain.ID) error {
 	ret := _m.Called(ctx, lessonID)
@@ -148,6 +186,36 @@ func (_m *LessonRepository) FindCourseLessons(ctx context.Context, courseID doma
 	return r0, r1
 }
 
+// FindCourseVersionLessons provides a mock function with given fields: ctx, courseID, version
+func (_m *LessonRepository) FindCourseVersionLessons(ctx context.Context, courseID domain.ID, version int) ([]domain.Lesson, error) {
+	ret := _m.Called(ctx, courseID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCourseVersionLessons")
+	}
+
+	var r0 []domain.Lesson
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, int) ([]domain.Lesson, error)); ok {
+		return rf(ctx, courseID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, int) []domain.Lesson); ok {
+		r0 = rf(ctx, courseID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Lesson)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ID, int) error); ok {
+		r1 = rf(ctx, courseID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindLessonTests provides a mock function with given fields: ctx, lessonID
 func (_m *LessonRepository) FindLessonTests(ctx context.Context, lessonID domain.ID) ([]domain.Test, error) {
 	ret := _m.Called(ctx, lessonID)
@@ -206,6 +274,62 @@ func (_m *LessonRepository) Update(ctx context.Context, lesson domain.Lesson) (d
 	return r0, r1
 }
 
+// UpdatePreview provides a mock function with given fields: ctx, lessonID, isPreview
+func (_m *LessonRepository) UpdatePreview(ctx context.Context, lessonID domain.ID, isPreview bool) (domain.Lesson, error) {
+	ret := _m.Called(ctx, lessonID, isPreview)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePreview")
+	}
+
+	var r0 domain.Lesson
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, bool) (domain.Lesson, error)); ok {
+		return rf(ctx, lessonID, isPreview)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, bool) domain.Lesson); ok {
+		r0 = rf(ctx, lessonID, isPreview)
+	} else {
+		r0 = ret.Get(0).(domain.Lesson)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ID, bool) error); ok {
+		r1 = rf(ctx, lessonID, isPreview)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRelease provides a mock function with given fields: ctx, lessonID, releaseAt, releaseDays
+func (_m *LessonRepository) UpdateRelease(ctx context.Context, lessonID domain.ID, releaseAt null.Time, releaseDays null.Int) (domain.Lesson, error) {
+	ret := _m.Called(ctx, lessonID, releaseAt, releaseDays)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRelease")
+	}
+
+	var r0 domain.Lesson
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, null.Time, null.Int) (domain.Lesson, error)); ok {
+		return rf(ctx, lessonID, releaseAt, releaseDays)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ID, null.Time, null.Int) domain.Lesson); ok {
+		r0 = rf(ctx, lessonID, releaseAt, releaseDays)
+	} else {
+		r0 = ret.Get(0).(domain.Lesson)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ID, null.Time, null.Int) error); ok {
+		r1 = rf(ctx, lessonID, releaseAt, releaseDays)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewLessonRepository creates a new instance of LessonRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLessonRepository(t interface {
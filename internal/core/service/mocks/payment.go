@@ -16,6 +16,52 @@ type PaymentGateway struct {
 	mock.Mock
 }
 
+// CancelRecurring provides a mock function with given fields: ctx, paymentMethodID
+func (_m *PaymentGateway) CancelRecurring(ctx context.Context, paymentMethodID string) error {
+	ret := _m.Called(ctx, paymentMethodID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelRecurring")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, paymentMethodID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChargeRecurring provides a mock function with given fields: ctx, payload, paymentMethodID, idempotenceKey
+func (_m *PaymentGateway) ChargeRecurring(ctx context.Context, payload domain.PaymentPayload, paymentMethodID string, idempotenceKey string) (string, error) {
+	ret := _m.Called(ctx, payload, paymentMethodID, idempotenceKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargeRecurring")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PaymentPayload, string, string) (string, error)); ok {
+		return rf(ctx, payload, paymentMethodID, idempotenceKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PaymentPayload, string, string) string); ok {
+		r0 = rf(ctx, payload, paymentMethodID, idempotenceKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PaymentPayload, string, string) error); ok {
+		r1 = rf(ctx, payload, paymentMethodID, idempotenceKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPaymentUrl provides a mock function with given fields: ctx, payload
 func (_m *PaymentGateway) GetPaymentUrl(ctx context.Context, payload domain.PaymentPayload) (url.URL, error) {
 	ret := _m.Called(ctx, payload)
@@ -72,6 +118,24 @@ func (_m *PaymentGateway) ProcessPayment(ctx context.Context, key string) (domai
 	return r0, r1
 }
 
+// Refund provides a mock function with given fields: ctx, refund
+func (_m *PaymentGateway) Refund(ctx context.Context, refund domain.Refund) error {
+	ret := _m.Called(ctx, refund)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Refund) error); ok {
+		r0 = rf(ctx, refund)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewPaymentGateway creates a new instance of PaymentGateway. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPaymentGateway(t interface {
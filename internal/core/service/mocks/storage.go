@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/paw1a/eschool/internal/core/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	io "io"
 )
 
 // ObjectStorage is an autogenerated mock type for the IObjectStorage type
@@ -14,6 +16,64 @@ type ObjectStorage struct {
 	mock.Mock
 }
 
+// CopyFile provides a mock function with given fields: ctx, url, path, name
+func (_m *ObjectStorage) CopyFile(ctx context.Context, url domain.Url, path string, name string) (domain.Url, error) {
+	ret := _m.Called(ctx, url, path, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CopyFile")
+	}
+
+	var r0 domain.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Url, string, string) (domain.Url, error)); ok {
+		return rf(ctx, url, path, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Url, string, string) domain.Url); ok {
+		r0 = rf(ctx, url, path, name)
+	} else {
+		r0 = ret.Get(0).(domain.Url)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Url, string, string) error); ok {
+		r1 = rf(ctx, url, path, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadFile provides a mock function with given fields: ctx, url
+func (_m *ObjectStorage) LoadFile(ctx context.Context, url domain.Url) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadFile")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Url) (io.ReadCloser, error)); ok {
+		return rf(ctx, url)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Url) io.ReadCloser); ok {
+		r0 = rf(ctx, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Url) error); ok {
+		r1 = rf(ctx, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveFile provides a mock function with given fields: ctx, file
 func (_m *ObjectStorage) SaveFile(ctx context.Context, file domain.File) (domain.Url, error) {
 	ret := _m.Called(ctx, file)
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"time"
+)
+
+type LearningPathService struct {
+	repo       port.ILearningPathRepository
+	courseRepo port.ICourseRepository
+	lessonRepo port.ILessonRepository
+	statRepo   port.IStatRepository
+	logger     *zap.Logger
+}
+
+func NewLearningPathService(repo port.ILearningPathRepository, courseRepo port.ICourseRepository,
+	lessonRepo port.ILessonRepository, statRepo port.IStatRepository, logger *zap.Logger) *LearningPathService {
+	return &LearningPathService{
+		repo:       repo,
+		courseRepo: courseRepo,
+		lessonRepo: lessonRepo,
+		statRepo:   statRepo,
+		logger:     logger,
+	}
+}
+
+func (l *LearningPathService) FindByID(ctx context.Context, pathID domain.ID) (domain.LearningPath, error) {
+	path, err := l.repo.FindByID(ctx, pathID)
+	if err != nil {
+		l.logger.Error("failed to find learning path by id", zap.Error(err),
+			zap.String("pathID", pathID.String()))
+		return domain.LearningPath{}, err
+	}
+	return path, nil
+}
+
+func (l *LearningPathService) FindSchoolPaths(ctx context.Context, schoolID domain.ID) ([]domain.LearningPath, error) {
+	paths, err := l.repo.FindSchoolPaths(ctx, schoolID)
+	if err != nil {
+		l.logger.Error("failed to find school learning paths", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return nil, err
+	}
+	return paths, nil
+}
+
+func (l *LearningPathService) FindPathCourses(ctx context.Context, pathID domain.ID) ([]domain.Course, error) {
+	courses, err := l.repo.FindPathCourses(ctx, pathID)
+	if err != nil {
+		l.logger.Error("failed to find learning path courses", zap.Error(err),
+			zap.String("pathID", pathID.String()))
+		return nil, err
+	}
+	return courses, nil
+}
+
+func (l *LearningPathService) CreateSchoolPath(ctx context.Context, schoolID domain.ID,
+	param port.CreatePathParam) (domain.LearningPath, error) {
+	courseIDs := make([]domain.ID, 0, len(param.CourseIDs))
+	seen := make(map[domain.ID]bool)
+	for _, courseID := range param.CourseIDs {
+		if seen[courseID] {
+			continue
+		}
+		seen[courseID] = true
+		courseIDs = append(courseIDs, courseID)
+	}
+	if len(courseIDs) < 2 {
+		return domain.LearningPath{}, errs.ErrLearningPathTooFewCourses
+	}
+
+	for _, courseID := range courseIDs {
+		course, err := l.courseRepo.FindByID(ctx, courseID)
+		if err != nil {
+			l.logger.Error("failed to find learning path course", zap.Error(err),
+				zap.String("courseID", courseID.String()))
+			return domain.LearningPath{}, err
+		}
+		if course.SchoolID != schoolID {
+			return domain.LearningPath{}, errs.ErrLearningPathCourseNotInSchool
+		}
+	}
+
+	path, err := l.repo.Create(ctx, domain.LearningPath{
+		ID:          domain.NewID(),
+		SchoolID:    schoolID,
+		Name:        param.Name,
+		Description: param.Description,
+		CreatedAt:   time.Now().UTC(),
+	}, courseIDs)
+	if err != nil {
+		l.logger.Error("failed to create learning path", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return domain.LearningPath{}, err
+	}
+
+	l.logger.Info("learning path is successfully created",
+		zap.String("pathID", path.ID.String()), zap.String("schoolID", schoolID.String()))
+	return path, nil
+}
+
+func (l *LearningPathService) Delete(ctx context.Context, schoolID, pathID domain.ID) error {
+	err := l.repo.Delete(ctx, schoolID, pathID)
+	if err != nil {
+		l.logger.Error("failed to delete learning path", zap.Error(err),
+			zap.String("pathID", pathID.String()))
+		return err
+	}
+
+	l.logger.Info("learning path is successfully deleted", zap.String("pathID", pathID.String()))
+	return nil
+}
+
+func (l *LearningPathService) FindPathProgress(ctx context.Context,
+	userID, pathID domain.ID) (domain.PathProgress, error) {
+	courses, err := l.repo.FindPathCourses(ctx, pathID)
+	if err != nil {
+		l.logger.Error("failed to find learning path courses", zap.Error(err),
+			zap.String("pathID", pathID.String()))
+		return domain.PathProgress{}, err
+	}
+
+	progress := domain.PathProgress{
+		PathID:  pathID,
+		Courses: make([]domain.CourseProgress, len(courses)),
+	}
+	for i, course := range courses {
+		progress.Courses[i], err = findCourseProgress(ctx, l.courseRepo, l.lessonRepo, l.statRepo, userID, course)
+		if err != nil {
+			l.logger.Error("failed to find course progress", zap.Error(err),
+				zap.String("userID", userID.String()), zap.String("courseID", course.ID.String()))
+			return domain.PathProgress{}, err
+		}
+	}
+	return progress, nil
+}
+
+// IssuePathCertificate issues the certificate once the user completes
+// every course of the path, the issued certificate is returned again
+func (l *LearningPathService) IssuePathCertificate(ctx context.Context,
+	userID, pathID domain.ID) (domain.PathCertificate, error) {
+	certificate, err := l.repo.FindUserCertificate(ctx, userID, pathID)
+	if err == nil {
+		return certificate, nil
+	}
+	if !errors.Is(err, errs.ErrNotExist) {
+		l.logger.Error("failed to find path certificate", zap.Error(err),
+			zap.String("userID", userID.String()), zap.String("pathID", pathID.String()))
+		return domain.PathCertificate{}, err
+	}
+
+	progress, err := l.FindPathProgress(ctx, userID, pathID)
+	if err != nil {
+		return domain.PathCertificate{}, err
+	}
+	if !progress.Completed() {
+		return domain.PathCertificate{}, errs.ErrLearningPathNotCompleted
+	}
+
+	certificate, err = l.repo.CreateCertificate(ctx, domain.PathCertificate{
+		ID:       domain.NewID(),
+		PathID:   pathID,
+		UserID:   userID,
+		Score:    progress.Score(),
+		IssuedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		l.logger.Error("failed to create path certificate", zap.Error(err),
+			zap.String("userID", userID.String()), zap.String("pathID", pathID.String()))
+		return domain.PathCertificate{}, err
+	}
+
+	l.logger.Info("path certificate is successfully issued",
+		zap.String("certificateID", certificate.ID.String()),
+		zap.String("userID", userID.String()), zap.String("pathID", pathID.String()))
+	return certificate, nil
+}
+
+func (l *LearningPathService) FindCertificate(ctx context.Context,
+	certificateID domain.ID) (domain.PathCertificate, error) {
+	certificate, err := l.repo.FindCertificateByID(ctx, certificateID)
+	if err != nil {
+		l.logger.Error("failed to find path certificate", zap.Error(err),
+			zap.String("certificateID", certificateID.String()))
+		return domain.PathCertificate{}, err
+	}
+	return certificate, nil
+}
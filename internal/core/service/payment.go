@@ -2,29 +2,67 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/paw1a/eschool/internal/core/domain"
 	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/paw1a/eschool/internal/core/port"
 	"go.uber.org/zap"
 	"net/url"
+	"time"
 )
 
+// seat of a course with limited capacity is held while the user is paying
+const paymentSeatHoldTime = time.Hour
+
 type PaymentService struct {
-	gateway    port.IPaymentGateway
-	courseRepo port.ICourseRepository
-	logger     *zap.Logger
+	gateway      port.IPaymentGateway
+	courseRepo   port.ICourseRepository
+	lessonRepo   port.ILessonRepository
+	statRepo     port.IStatRepository
+	userRepo     port.IUserRepository
+	promoRepo    port.IPromoCodeRepository
+	paymentRepo  port.IPaymentRepository
+	waitlistRepo port.IWaitlistRepository
+	bundleRepo   port.IBundleRepository
+	ledgerRepo   port.ILedgerRepository
+	ledgerConfig *LedgerConfig
+	logger       *zap.Logger
 }
 
 func NewPaymentService(gateway port.IPaymentGateway, courseRepo port.ICourseRepository,
-	logger *zap.Logger) *PaymentService {
+	lessonRepo port.ILessonRepository, statRepo port.IStatRepository, userRepo port.IUserRepository, promoRepo port.IPromoCodeRepository,
+	paymentRepo port.IPaymentRepository, waitlistRepo port.IWaitlistRepository,
+	bundleRepo port.IBundleRepository, ledgerRepo port.ILedgerRepository,
+	ledgerConfig *LedgerConfig, logger *zap.Logger) *PaymentService {
 	return &PaymentService{
-		gateway:    gateway,
-		courseRepo: courseRepo,
-		logger:     logger,
+		gateway:      gateway,
+		courseRepo:   courseRepo,
+		lessonRepo:   lessonRepo,
+		statRepo:     statRepo,
+		userRepo:     userRepo,
+		promoRepo:    promoRepo,
+		paymentRepo:  paymentRepo,
+		waitlistRepo: waitlistRepo,
+		bundleRepo:   bundleRepo,
+		ledgerRepo:   ledgerRepo,
+		ledgerConfig: ledgerConfig,
+		logger:       logger,
 	}
 }
 
-func (p *PaymentService) GetCoursePaymentUrl(ctx context.Context, userID, courseID domain.ID) (url.URL, error) {
+func (p *PaymentService) GetCoursePaymentUrl(ctx context.Context, userID, courseID domain.ID,
+	promoCode string) (url.URL, error) {
+	user, err := p.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		p.logger.Error("failed to find user by id", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return url.URL{}, err
+	}
+
+	if !user.EmailVerified {
+		return url.URL{}, errs.ErrEmailNotVerified
+	}
+
 	course, err := p.courseRepo.FindByID(ctx, courseID)
 	if err != nil {
 		p.logger.Error("failed to find course by id", zap.Error(err),
@@ -32,22 +70,66 @@ func (p *PaymentService) GetCoursePaymentUrl(ctx context.Context, userID, course
 		return url.URL{}, err
 	}
 
-	isStudent, err := p.courseRepo.IsCourseStudent(ctx, userID, courseID)
-	if err != nil {
-		p.logger.Error("failed to check if user is a course student", zap.Error(err),
+	// time-limited enrollment is renewed by the payment, active enrollment
+	// keeps its seat, expired one takes a free seat again
+	renewal := false
+	enrollment, err := p.courseRepo.FindEnrollment(ctx, userID, courseID)
+	if err == nil {
+		if !enrollment.ExpiresAt.Valid {
+			return url.URL{}, errs.ErrUserIsAlreadyCourseStudent
+		}
+		if !course.AccessDays.Valid {
+			return url.URL{}, errs.ErrCourseNotRenewable
+		}
+		renewal = true
+	} else if !errors.Is(err, errs.ErrNotExist) {
+		p.logger.Error("failed to find course enrollment", zap.Error(err),
 			zap.String("courseID", courseID.String()), zap.String("userID", userID.String()))
 		return url.URL{}, err
 	}
 
-	if isStudent {
-		return url.URL{}, errs.ErrUserIsAlreadyCourseStudent
+	// enrolled students renew access to withdrawn courses, new students buy listed ones only
+	if !renewal && !course.Status.Listed() {
+		return url.URL{}, errs.ErrCourseNotForSale
+	}
+
+	// renewing students were enrolled once, so prerequisites are checked for new ones
+	if !renewal {
+		err = checkCoursePrerequisites(ctx, p.courseRepo, p.lessonRepo, p.statRepo, userID, courseID)
+		if err != nil {
+			if !errors.Is(err, errs.ErrCoursePrerequisitesNotMet) {
+				p.logger.Error("failed to check course prerequisites", zap.Error(err),
+					zap.String("courseID", courseID.String()), zap.String("userID", userID.String()))
+			}
+			return url.URL{}, err
+		}
 	}
 
-	link, err := p.gateway.GetPaymentUrl(ctx, domain.PaymentPayload{
+	payload := domain.PaymentPayload{
 		UserID:   userID,
 		CourseID: courseID,
 		PaySum:   course.Price,
-	})
+		Renewal:  renewal,
+	}
+	if promoCode != "" {
+		promo, err := p.findCoursePromoCode(ctx, course, promoCode)
+		if err != nil {
+			p.logger.Error("failed to apply promo code", zap.Error(err),
+				zap.String("courseID", courseID.String()), zap.String("promoCode", promoCode))
+			return url.URL{}, err
+		}
+		payload.PromoCodeID = promo.ID
+		payload.PaySum.Amount = promo.Apply(course.Price.Amount)
+	}
+
+	if !renewal || !enrollment.Active(time.Now()) {
+		err = p.holdCourseSeat(ctx, userID, course)
+		if err != nil {
+			return url.URL{}, err
+		}
+	}
+
+	link, err := p.gateway.GetPaymentUrl(ctx, payload)
 	if err != nil {
 		p.logger.Error("failed to get payment link", zap.Error(err),
 			zap.String("courseID", courseID.String()))
@@ -55,6 +137,136 @@ func (p *PaymentService) GetCoursePaymentUrl(ctx context.Context, userID, course
 	}
 
 	p.logger.Info("payment link is generated successfully",
+		zap.String("url", link.String()), zap.String("userID", userID.String()),
+		zap.String("courseID", courseID.String()), zap.Bool("renewal", renewal))
+	return link, nil
+}
+
+func (p *PaymentService) GetBundlePaymentUrl(ctx context.Context, userID, bundleID domain.ID) (url.URL, error) {
+	user, err := p.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		p.logger.Error("failed to find user by id", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return url.URL{}, err
+	}
+
+	if !user.EmailVerified {
+		return url.URL{}, errs.ErrEmailNotVerified
+	}
+
+	bundle, err := p.bundleRepo.FindByID(ctx, bundleID)
+	if err != nil {
+		p.logger.Error("failed to find bundle by id", zap.Error(err),
+			zap.String("bundleID", bundleID.String()))
+		return url.URL{}, err
+	}
+
+	if bundle.Status != domain.BundlePublished {
+		return url.URL{}, errs.ErrBundleNotPublished
+	}
+
+	courses, shares, err := p.findNotOwnedBundleCourses(ctx, userID, bundle)
+	if err != nil {
+		return url.URL{}, err
+	}
+
+	if len(courses) == 0 {
+		return url.URL{}, errs.ErrBundleAlreadyOwned
+	}
+
+	courseIDs := make([]domain.ID, len(courses))
+	for i, course := range courses {
+		courseIDs[i] = course.ID
+	}
+	for _, course := range courses {
+		err = checkCoursePrerequisites(ctx, p.courseRepo, p.lessonRepo, p.statRepo,
+			userID, course.ID, courseIDs...)
+		if err != nil {
+			if !errors.Is(err, errs.ErrCoursePrerequisitesNotMet) {
+				p.logger.Error("failed to check course prerequisites", zap.Error(err),
+					zap.String("courseID", course.ID.String()), zap.String("userID", userID.String()))
+			}
+			return url.URL{}, err
+		}
+	}
+
+	currency, err := domain.CoursesCurrency(courses)
+	if err != nil {
+		return url.URL{}, err
+	}
+
+	payload := domain.PaymentPayload{
+		UserID:   userID,
+		BundleID: bundleID,
+		PaySum:   domain.NewMoney(0, currency),
+	}
+	for i, course := range courses {
+		payload.PaySum.Amount += shares[i]
+		err = p.holdCourseSeat(ctx, userID, course)
+		if err != nil {
+			return url.URL{}, err
+		}
+	}
+
+	link, err := p.gateway.GetPaymentUrl(ctx, payload)
+	if err != nil {
+		p.logger.Error("failed to get payment link", zap.Error(err),
+			zap.String("bundleID", bundleID.String()))
+		return url.URL{}, err
+	}
+
+	p.logger.Info("bundle payment link is generated successfully",
+		zap.String("url", link.String()), zap.String("userID", userID.String()),
+		zap.String("bundleID", bundleID.String()), zap.Int64("pay sum", payload.PaySum.Amount),
+		zap.String("currency", currency))
+	return link, nil
+}
+
+// GetGiftPaymentUrl returns payment url of the course bought for another
+// person, the course is not bound to the buyer account. Courses with seat
+// limit are not gifted: the recipient is unknown until redemption, so no seat
+// can be held for them and a paid gift could not be redeemed in a full course
+func (p *PaymentService) GetGiftPaymentUrl(ctx context.Context, userID, courseID domain.ID,
+	recipientEmail string) (url.URL, error) {
+	user, err := p.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		p.logger.Error("failed to find user by id", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return url.URL{}, err
+	}
+
+	if !user.EmailVerified {
+		return url.URL{}, errs.ErrEmailNotVerified
+	}
+
+	course, err := p.courseRepo.FindByID(ctx, courseID)
+	if err != nil {
+		p.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return url.URL{}, err
+	}
+
+	if !course.Status.Listed() {
+		return url.URL{}, errs.ErrCourseNotForSale
+	}
+
+	if course.Capacity.Valid {
+		return url.URL{}, errs.ErrGiftCourseHasCapacity
+	}
+
+	payload := domain.PaymentPayload{
+		CourseID:       courseID,
+		RecipientEmail: recipientEmail,
+		PaySum:         course.Price,
+	}
+	link, err := p.gateway.GetPaymentUrl(ctx, payload)
+	if err != nil {
+		p.logger.Error("failed to get payment link", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return url.URL{}, err
+	}
+
+	p.logger.Info("gift payment link is generated successfully",
 		zap.String("url", link.String()), zap.String("userID", userID.String()),
 		zap.String("courseID", courseID.String()))
 	return link, nil
@@ -69,15 +281,228 @@ func (p *PaymentService) ProcessCoursePayment(ctx context.Context,
 		return domain.PaymentPayload{}, err
 	}
 
-	if paid < payload.PaySum {
+	if paid < payload.PaySum.Amount {
 		p.logger.Error("failed to process payment, payment sum is less then expected",
 			zap.Error(err), zap.String("key", key), zap.Int64("paid sum", paid))
 		return domain.PaymentPayload{}, errs.ErrInvalidPaymentSum
 	}
+	return p.completePayment(ctx, key, payload, paid)
+}
+
+// ProcessVerifiedPayment processes the payment fetched from the gateway,
+// paid sum is taken from the gateway instead of the unauthenticated notification
+func (p *PaymentService) ProcessVerifiedPayment(ctx context.Context, key string) (domain.PaymentPayload, error) {
+	payload, err := p.gateway.ProcessPayment(ctx, key)
+	if err != nil {
+		p.logger.Error("failed to process payment", zap.Error(err), zap.String("key", key))
+		return domain.PaymentPayload{}, err
+	}
+	return p.completePayment(ctx, key, payload, payload.PaySum.Amount)
+}
+
+func (p *PaymentService) completePayment(ctx context.Context, key string,
+	payload domain.PaymentPayload, paid int64) (domain.PaymentPayload, error) {
+	var err error
+	if payload.SubscriptionPlanID != "" {
+		// subscription sale is recorded when the subscription is activated,
+		// the same way as charges of saved payment methods
+	} else if payload.BundleID != "" {
+		err = p.saveBundlePayments(ctx, payload, key)
+	} else if payload.RecipientEmail != "" {
+		err = p.saveGiftPayment(ctx, payload, key, paid)
+	} else {
+		err = p.saveCoursePayment(ctx, payload, key, paid)
+	}
+	if err != nil {
+		p.logger.Error("failed to save payment", zap.Error(err),
+			zap.String("key", key), zap.Int64("paid sum", paid))
+		return domain.PaymentPayload{}, err
+	}
+
+	if payload.PromoCodeID != "" {
+		err = p.promoRepo.Redeem(ctx, domain.PromoRedemption{
+			PromoCodeID: payload.PromoCodeID,
+			UserID:      payload.UserID,
+			CourseID:    payload.CourseID,
+			PaySum:      paid,
+			RedeemedAt:  time.Now().UTC(),
+		})
+		if err != nil {
+			p.logger.Error("failed to redeem promo code", zap.Error(err),
+				zap.String("key", key), zap.String("promoID", payload.PromoCodeID.String()))
+			return domain.PaymentPayload{}, err
+		}
+	}
 
 	p.logger.Info("payment is processed successfully",
 		zap.String("key", key), zap.Int64("paid sum", paid),
 		zap.String("courseID", payload.CourseID.String()),
+		zap.String("bundleID", payload.BundleID.String()),
 		zap.String("userID", payload.UserID.String()))
 	return payload, nil
 }
+
+func (p *PaymentService) findCoursePromoCode(ctx context.Context, course domain.Course,
+	code string) (domain.PromoCode, error) {
+	promo, err := p.promoRepo.FindByCode(ctx, course.SchoolID, normalizePromoCode(code))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotExist) {
+			return domain.PromoCode{}, errs.ErrInvalidPromoCode
+		}
+		return domain.PromoCode{}, err
+	}
+
+	if !promo.Active(time.Now()) {
+		return domain.PromoCode{}, errs.ErrInvalidPromoCode
+	}
+	if !promo.AppliesTo(course) {
+		return domain.PromoCode{}, errs.ErrPromoCodeNotApplicable
+	}
+	if !promo.MatchesCurrency(course) {
+		return domain.PromoCode{}, errs.ErrPromoCodeCurrencyMismatch
+	}
+	if promo.Exhausted() {
+		return domain.PromoCode{}, errs.ErrPromoCodeExhausted
+	}
+	return promo, nil
+}
+
+func (p *PaymentService) holdCourseSeat(ctx context.Context, userID domain.ID, course domain.Course) error {
+	if !course.Capacity.Valid {
+		return nil
+	}
+
+	err := p.waitlistRepo.HoldSeat(ctx, domain.SeatHold{
+		CourseID:  course.ID,
+		UserID:    userID,
+		ExpiresAt: time.Now().UTC().Add(paymentSeatHoldTime),
+	})
+	if err != nil {
+		p.logger.Error("failed to hold course seat", zap.Error(err),
+			zap.String("courseID", course.ID.String()), zap.String("userID", userID.String()))
+		return err
+	}
+	return nil
+}
+
+// findNotOwnedBundleCourses returns bundle courses the user has not bought yet
+// with their shares of the bundle price, owned courses are not paid again
+func (p *PaymentService) findNotOwnedBundleCourses(ctx context.Context, userID domain.ID,
+	bundle domain.Bundle) ([]domain.Course, []int64, error) {
+	courses, err := p.bundleRepo.FindBundleCourses(ctx, bundle.ID)
+	if err != nil {
+		p.logger.Error("failed to find bundle courses", zap.Error(err),
+			zap.String("bundleID", bundle.ID.String()))
+		return nil, nil, err
+	}
+
+	// courses studied by subscription aren't owned, they stay after the subscription ends
+	shares := bundle.Shares(courses)
+	var notOwnedCourses []domain.Course
+	var notOwnedShares []int64
+	for i, course := range courses {
+		isStudent, err := isEnrolledStudent(ctx, p.courseRepo, userID, course.ID)
+		if err != nil {
+			p.logger.Error("failed to check if user is a course student", zap.Error(err),
+				zap.String("courseID", course.ID.String()), zap.String("userID", userID.String()))
+			return nil, nil, err
+		}
+		if !isStudent {
+			notOwnedCourses = append(notOwnedCourses, course)
+			notOwnedShares = append(notOwnedShares, shares[i])
+		}
+	}
+	return notOwnedCourses, notOwnedShares, nil
+}
+
+// saveBundlePayments records a payment of each bought bundle course,
+// so refunds of a single course return only its share of the bundle
+func (p *PaymentService) saveBundlePayments(ctx context.Context,
+	payload domain.PaymentPayload, key string) error {
+	bundle, err := p.bundleRepo.FindByID(ctx, payload.BundleID)
+	if err != nil {
+		return err
+	}
+
+	courses, shares, err := p.findNotOwnedBundleCourses(ctx, payload.UserID, bundle)
+	if err != nil {
+		return err
+	}
+
+	for i, course := range courses {
+		err = p.savePayment(ctx, course, domain.Payment{
+			UserID:   payload.UserID,
+			CourseID: course.ID,
+			Key:      key,
+			PaySum:   shares[i],
+			Currency: payload.PaySum.Currency,
+			PaidAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PaymentService) saveCoursePayment(ctx context.Context,
+	payload domain.PaymentPayload, key string, paid int64) error {
+	course, err := p.courseRepo.FindByID(ctx, payload.CourseID)
+	if err != nil {
+		return err
+	}
+
+	return p.savePayment(ctx, course, domain.Payment{
+		UserID:   payload.UserID,
+		CourseID: payload.CourseID,
+		Key:      key,
+		PaySum:   paid,
+		Currency: payload.PaySum.Currency,
+		PaidAt:   time.Now().UTC(),
+	})
+}
+
+// saveGiftPayment only credits the school ledger, the gift itself
+// stands for the payment until somebody redeems it
+func (p *PaymentService) saveGiftPayment(ctx context.Context,
+	payload domain.PaymentPayload, key string, paid int64) error {
+	course, err := p.courseRepo.FindByID(ctx, payload.CourseID)
+	if err != nil {
+		return err
+	}
+
+	return p.recordSale(ctx, course, domain.Payment{
+		CourseID: payload.CourseID,
+		Key:      key,
+		PaySum:   paid,
+		Currency: payload.PaySum.Currency,
+		PaidAt:   time.Now().UTC(),
+	})
+}
+
+// savePayment stores the payment and credits the course school ledger
+func (p *PaymentService) savePayment(ctx context.Context, course domain.Course, payment domain.Payment) error {
+	err := p.paymentRepo.Create(ctx, payment)
+	if err != nil {
+		return err
+	}
+
+	return p.recordSale(ctx, course, payment)
+}
+
+// recordSale credits the course school ledger,
+// commission is fixed at the moment of sale
+func (p *PaymentService) recordSale(ctx context.Context, course domain.Course, payment domain.Payment) error {
+	return p.ledgerRepo.Create(ctx, domain.LedgerEntry{
+		ID:         domain.NewID(),
+		SchoolID:   course.SchoolID,
+		CourseID:   course.ID,
+		UserID:     payment.UserID,
+		PaymentKey: payment.Key,
+		Kind:       domain.LedgerSale,
+		Gross:      payment.PaySum,
+		Commission: domain.Commission(payment.PaySum, p.ledgerConfig.CommissionPercent),
+		Currency:   payment.Currency,
+		CreatedAt:  payment.PaidAt,
+	})
+}
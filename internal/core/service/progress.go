@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"slices"
+	"time"
+)
+
+// isEnrolledStudent reports whether the student has active enrollment, unlike
+// IsCourseStudent access by subscription doesn't count, the course isn't owned
+func isEnrolledStudent(ctx context.Context, courseRepo port.ICourseRepository,
+	studentID, courseID domain.ID) (bool, error) {
+	enrollment, err := courseRepo.FindEnrollment(ctx, studentID, courseID)
+	if errors.Is(err, errs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return enrollment.Active(time.Now().UTC()), nil
+}
+
+// findCourseProgress collects stats of the student over lessons of the course
+// version the student studies, not enrolled users study the learner version
+func findCourseProgress(ctx context.Context, courseRepo port.ICourseRepository,
+	lessonRepo port.ILessonRepository, statRepo port.IStatRepository,
+	studentID domain.ID, course domain.Course) (domain.CourseProgress, error) {
+	version := course.LearnerVersion()
+	enrollment, err := courseRepo.FindEnrollment(ctx, studentID, course.ID)
+	if err == nil {
+		version = enrollment.Version
+	} else if !errors.Is(err, errs.ErrNotExist) {
+		return domain.CourseProgress{}, err
+	}
+
+	lessons, err := lessonRepo.FindCourseVersionLessons(ctx, course.ID, version)
+	if err != nil {
+		return domain.CourseProgress{}, err
+	}
+
+	stats := make([]domain.LessonStat, 0, len(lessons))
+	for _, lesson := range lessons {
+		stat, err := statRepo.FindLessonStat(ctx, studentID, lesson.ID)
+		if errors.Is(err, errs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return domain.CourseProgress{}, err
+		}
+		stats = append(stats, stat)
+	}
+	return domain.NewCourseProgress(course.ID, lessons, stats), nil
+}
+
+// checkCoursePrerequisites returns PrerequisitesError listing
+// every prerequisite of the course the student hasn't met,
+// progress made by subscription counts like progress of enrolled students.
+// Courses bought together with the course are studied first and are skipped
+func checkCoursePrerequisites(ctx context.Context, courseRepo port.ICourseRepository,
+	lessonRepo port.ILessonRepository, statRepo port.IStatRepository,
+	studentID, courseID domain.ID, boughtWith ...domain.ID) error {
+	prerequisites, err := courseRepo.FindCoursePrerequisites(ctx, courseID)
+	if err != nil {
+		return err
+	}
+
+	var requirements []string
+	for _, prerequisite := range prerequisites {
+		if slices.Contains(boughtWith, prerequisite.RequiredCourseID) {
+			continue
+		}
+
+		required, err := courseRepo.FindByID(ctx, prerequisite.RequiredCourseID)
+		if err != nil {
+			return err
+		}
+
+		progress, err := findCourseProgress(ctx, courseRepo, lessonRepo, statRepo, studentID, required)
+		if err != nil {
+			return err
+		}
+
+		if !prerequisite.MetBy(progress) {
+			requirements = append(requirements, prerequisite.Requirement(required, progress))
+		}
+	}
+
+	if len(requirements) > 0 {
+		return &errs.PrerequisitesError{Requirements: requirements}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+type PromoCodeService struct {
+	repo       port.IPromoCodeRepository
+	courseRepo port.ICourseRepository
+	logger     *zap.Logger
+}
+
+func NewPromoCodeService(repo port.IPromoCodeRepository, courseRepo port.ICourseRepository,
+	logger *zap.Logger) *PromoCodeService {
+	return &PromoCodeService{
+		repo:       repo,
+		courseRepo: courseRepo,
+		logger:     logger,
+	}
+}
+
+func (p *PromoCodeService) FindSchoolPromoCodes(ctx context.Context,
+	schoolID domain.ID) ([]domain.PromoCode, error) {
+	return p.repo.FindSchoolPromoCodes(ctx, schoolID)
+}
+
+func (p *PromoCodeService) CreateSchoolPromoCode(ctx context.Context, schoolID domain.ID,
+	param port.CreatePromoCodeParam) (domain.PromoCode, error) {
+	code := normalizePromoCode(param.Code)
+	if code == "" {
+		return domain.PromoCode{}, errs.ErrPromoCodeEmptyCode
+	}
+
+	switch param.DiscountType {
+	case domain.PromoPercentDiscount:
+		if param.Discount <= 0 || param.Discount > 100 {
+			return domain.PromoCode{}, errs.ErrPromoCodeInvalidDiscount
+		}
+	case domain.PromoFixedDiscount:
+		if param.Discount <= 0 {
+			return domain.PromoCode{}, errs.ErrPromoCodeInvalidDiscount
+		}
+	default:
+		return domain.PromoCode{}, errs.ErrPromoCodeInvalidDiscount
+	}
+
+	if param.MaxUses.Valid && param.MaxUses.Int64 <= 0 {
+		return domain.PromoCode{}, errs.ErrPromoCodeInvalidMaxUses
+	}
+
+	if param.ValidFrom.Valid && param.ValidUntil.Valid &&
+		!param.ValidUntil.Time.After(param.ValidFrom.Time) {
+		return domain.PromoCode{}, errs.ErrPromoCodeInvalidValidity
+	}
+
+	if param.Currency != "" && !domain.SupportedCurrency(param.Currency) {
+		return domain.PromoCode{}, errs.ErrPromoCodeInvalidCurrency
+	}
+	// percent discount is a share of the price in any currency
+	if param.DiscountType != domain.PromoFixedDiscount {
+		param.Currency = ""
+	}
+
+	if param.CourseID.Valid {
+		course, err := p.courseRepo.FindByID(ctx, domain.ID(param.CourseID.String))
+		if err != nil {
+			p.logger.Error("failed to find promo code course", zap.Error(err),
+				zap.String("courseID", param.CourseID.String))
+			return domain.PromoCode{}, err
+		}
+		if course.SchoolID != schoolID {
+			return domain.PromoCode{}, errs.ErrPromoCodeNotApplicable
+		}
+		if param.DiscountType == domain.PromoFixedDiscount && param.Currency == "" {
+			param.Currency = course.Price.Currency
+		}
+		if param.Currency != "" && param.Currency != course.Price.Currency {
+			return domain.PromoCode{}, errs.ErrPromoCodeCurrencyMismatch
+		}
+	} else if param.DiscountType == domain.PromoFixedDiscount && param.Currency == "" {
+		param.Currency = domain.DefaultCurrency
+	}
+
+	promo, err := p.repo.Create(ctx, domain.PromoCode{
+		ID:           domain.NewID(),
+		SchoolID:     schoolID,
+		CourseID:     param.CourseID,
+		Code:         code,
+		DiscountType: param.DiscountType,
+		Discount:     param.Discount,
+		Currency:     param.Currency,
+		MaxUses:      param.MaxUses,
+		ValidFrom:    param.ValidFrom,
+		ValidUntil:   param.ValidUntil,
+		CreatedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		p.logger.Error("failed to create promo code", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		if errors.Is(err, errs.ErrDuplicate) {
+			return domain.PromoCode{}, errs.ErrPromoCodeDuplicate
+		}
+		return domain.PromoCode{}, err
+	}
+
+	p.logger.Info("promo code is successfully created",
+		zap.String("promoID", promo.ID.String()), zap.String("schoolID", schoolID.String()))
+	return promo, nil
+}
+
+func (p *PromoCodeService) Delete(ctx context.Context, schoolID, promoID domain.ID) error {
+	return p.repo.Delete(ctx, schoolID, promoID)
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,738 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"html"
+	"io"
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	quizManifest = "imsmanifest.xml"
+	// quizTitle names the lesson of items that are not part of any assessment
+	quizTitle = "Imported quiz"
+	// limits of the unpacked quiz package, like limits of the course archive
+	quizMaxSize     = 256 << 20
+	quizMaxFileSize = 16 << 20
+	// tests of items without score and level get the smallest ones
+	quizTestScore = 1
+	quizTestLevel = 1
+)
+
+const (
+	qtiItemResource = "imsqti_item_xmlv2p1"
+	qtiTestResource = "imsqti_test_xmlv2p1"
+	// common cartridge assessments and question banks are qti 1.2 documents
+	ccResourcePrefix = "imsqti_xmlv1p2/"
+)
+
+// ccChoiceProfiles are common cartridge item profiles answered with the choice
+var ccChoiceProfiles = map[string]bool{
+	"cc.multiple_choice.v0p1":   true,
+	"cc.multiple_response.v0p1": true,
+	"cc.true_false.v0p1":        true,
+}
+
+var htmlTagRegexp = regexp.MustCompile(`<[^>]*>`)
+
+type QuizImportService struct {
+	logger *zap.Logger
+}
+
+func NewQuizImportService(logger *zap.Logger) *QuizImportService {
+	return &QuizImportService{
+		logger: logger,
+	}
+}
+
+// ParseQuiz reads the single qti file or the zip package with the manifest,
+// choice items are mapped to tests and other items are reported as skipped
+func (q *QuizImportService) ParseQuiz(ctx context.Context, reader io.ReaderAt,
+	size int64) (port.QuizImport, error) {
+	if size > quizMaxSize {
+		q.logger.Error("failed to import quiz, package is too large", zap.Int64("size", size))
+		return port.QuizImport{}, errs.ErrQuizPackageTooLarge
+	}
+
+	parser := &quizParser{title: quizTitle}
+	zipReader, err := zip.NewReader(reader, size)
+	if err == nil {
+		err = parser.parsePackage(zipReader)
+	} else {
+		var data []byte
+		data, err = io.ReadAll(io.NewSectionReader(reader, 0, size))
+		if err == nil {
+			err = parser.parseDocument(data)
+		}
+	}
+	if err != nil {
+		q.logger.Error("failed to parse quiz", zap.Error(err))
+		return port.QuizImport{}, err
+	}
+	parser.addLesson(parser.title, parser.items)
+
+	q.logger.Info("quiz is successfully parsed",
+		zap.Int("lessons", len(parser.quiz.Lessons)), zap.Int("skipped", len(parser.quiz.Skipped)))
+	return parser.quiz, nil
+}
+
+// quizParser collects lessons of assessments, items are tests
+// of files that are not part of any assessment
+type quizParser struct {
+	files    map[string]*zip.File
+	unpacked int64
+	title    string
+	items    []port.CreateTestParam
+	quiz     port.QuizImport
+}
+
+type imsManifest struct {
+	Title     string        `xml:"metadata>lom>general>title>string"`
+	Resources []imsResource `xml:"resources>resource"`
+}
+
+type imsResource struct {
+	Identifier string `xml:"identifier,attr"`
+	Type       string `xml:"type,attr"`
+	Href       string `xml:"href,attr"`
+	Files      []struct {
+		Href string `xml:"href,attr"`
+	} `xml:"file"`
+}
+
+// file returns the main file of the resource
+func (r imsResource) file() string {
+	if r.Href == "" && len(r.Files) > 0 {
+		return r.Files[0].Href
+	}
+	return r.Href
+}
+
+func (p *quizParser) parsePackage(zipReader *zip.Reader) error {
+	p.files = make(map[string]*zip.File, len(zipReader.File))
+	for _, file := range zipReader.File {
+		p.files[file.Name] = file
+	}
+
+	if _, ok := p.files[quizManifest]; !ok {
+		// package without the manifest is read as the set of qti files
+		names := make([]string, 0, len(p.files))
+		for name := range p.files {
+			if strings.EqualFold(path.Ext(name), ".xml") {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return errors.Wrap(errs.ErrQuizPackageInvalid, "package has no xml files")
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			data, err := p.readFile(name)
+			if err != nil {
+				return err
+			}
+			if err = p.parseDocument(data); err != nil {
+				return errors.Wrap(err, name)
+			}
+		}
+		return nil
+	}
+
+	data, err := p.readFile(quizManifest)
+	if err != nil {
+		return err
+	}
+	var manifest imsManifest
+	if err = xml.Unmarshal(data, &manifest); err != nil {
+		return errors.Wrap(errs.ErrQuizPackageInvalid, err.Error())
+	}
+	if title := strings.TrimSpace(manifest.Title); title != "" {
+		p.title = title
+	}
+
+	// items referenced by tests are not added to the lesson of loose items
+	referenced := make(map[string]bool)
+	for _, resource := range manifest.Resources {
+		switch {
+		case resource.Type == qtiTestResource:
+			refs, err := p.parseTest(resource.file())
+			if err != nil {
+				return err
+			}
+			for _, ref := range refs {
+				referenced[ref] = true
+			}
+		case strings.HasPrefix(resource.Type, ccResourcePrefix):
+			data, err := p.readFile(resource.file())
+			if err != nil {
+				return err
+			}
+			if err = p.parseAssessments(data); err != nil {
+				return errors.Wrap(err, resource.file())
+			}
+		}
+	}
+
+	for _, resource := range manifest.Resources {
+		if resource.Type != qtiItemResource || referenced[resource.file()] {
+			continue
+		}
+		data, err := p.readFile(resource.file())
+		if err != nil {
+			return err
+		}
+		if err = p.parseItem(data, &p.items); err != nil {
+			return errors.Wrap(err, resource.file())
+		}
+	}
+	return nil
+}
+
+// parseDocument reads the qti file by its root element
+func (p *quizParser) parseDocument(data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return errors.Wrap(errs.ErrQuizPackageInvalid, err.Error())
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "assessmentItem":
+			return p.parseItem(data, &p.items)
+		case "questestinterop":
+			return p.parseAssessments(data)
+		case "assessmentTest":
+			return errors.Wrap(errs.ErrQuizPackageInvalid,
+				"assessment test must be uploaded in the package with its manifest")
+		default:
+			return errors.Wrap(errs.ErrQuizPackageInvalid,
+				fmt.Sprintf("unknown root element %s", start.Name.Local))
+		}
+	}
+}
+
+type qtiTest struct {
+	Title string    `xml:"title,attr"`
+	Parts []qtiNode `xml:"testPart"`
+}
+
+// parseTest adds the lesson of the qti 2.1 test and returns files of its items
+func (p *quizParser) parseTest(name string) ([]string, error) {
+	data, err := p.readFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var test qtiTest
+	if err = xml.Unmarshal(data, &test); err != nil {
+		return nil, errors.Wrap(errs.ErrQuizPackageInvalid, err.Error())
+	}
+
+	// items are referenced from sections of any depth
+	var itemRefs []qtiNode
+	for _, part := range test.Parts {
+		itemRefs = append(itemRefs, part.find(func(name string) bool {
+			return name == "assessmentItemRef"
+		})...)
+	}
+
+	refs := make([]string, len(itemRefs))
+	var tests []port.CreateTestParam
+	for i, ref := range itemRefs {
+		refs[i] = path.Join(path.Dir(name), ref.attr("href"))
+		if _, ok := p.files[refs[i]]; !ok {
+			p.skip(ref.attr("identifier"), "", "item file is missing from the package")
+			continue
+		}
+		data, err := p.readFile(refs[i])
+		if err != nil {
+			return nil, err
+		}
+		if err = p.parseItem(data, &tests); err != nil {
+			return nil, errors.Wrap(err, refs[i])
+		}
+	}
+	p.addLesson(test.Title, tests)
+	return refs, nil
+}
+
+type qtiItem struct {
+	Identifier string        `xml:"identifier,attr"`
+	Title      string        `xml:"title,attr"`
+	Responses  []qtiResponse `xml:"responseDeclaration"`
+	Outcomes   []qtiOutcome  `xml:"outcomeDeclaration"`
+	Body       qtiNode       `xml:"itemBody"`
+}
+
+type qtiResponse struct {
+	Identifier  string   `xml:"identifier,attr"`
+	Cardinality string   `xml:"cardinality,attr"`
+	Correct     []string `xml:"correctResponse>value"`
+}
+
+type qtiOutcome struct {
+	Identifier    string   `xml:"identifier,attr"`
+	NormalMaximum string   `xml:"normalMaximum,attr"`
+	Default       []string `xml:"defaultValue>value"`
+}
+
+// qtiNode keeps the element with its raw content, so text of the mixed
+// content is read in the document order
+type qtiNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	InnerXML string     `xml:",innerxml"`
+	Nodes    []qtiNode  `xml:",any"`
+}
+
+func (n qtiNode) attr(name string) string {
+	for _, attr := range n.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// find returns descendants of the node matching the name
+func (n qtiNode) find(match func(name string) bool) []qtiNode {
+	var found []qtiNode
+	for _, node := range n.Nodes {
+		if match(node.XMLName.Local) {
+			found = append(found, node)
+		}
+		found = append(found, node.find(match)...)
+	}
+	return found
+}
+
+// parseItem maps the qti 2.1 choice item to the test
+func (p *quizParser) parseItem(data []byte, tests *[]port.CreateTestParam) error {
+	var item qtiItem
+	if err := xml.Unmarshal(data, &item); err != nil {
+		return errors.Wrap(errs.ErrQuizPackageInvalid, err.Error())
+	}
+
+	interactions := item.Body.find(func(name string) bool {
+		return strings.HasSuffix(name, "Interaction")
+	})
+	switch {
+	case len(interactions) == 0:
+		p.skip(item.Identifier, item.Title, "item has no interaction")
+		return nil
+	case len(interactions) > 1:
+		p.skip(item.Identifier, item.Title, "items with several interactions are not supported")
+		return nil
+	case interactions[0].XMLName.Local != "choiceInteraction":
+		p.skip(item.Identifier, item.Title,
+			fmt.Sprintf("%s is not supported", interactions[0].XMLName.Local))
+		return nil
+	}
+
+	interaction := interactions[0]
+	var correct []string
+	for _, response := range item.Responses {
+		if response.Identifier == interaction.attr("responseIdentifier") {
+			correct = response.Correct
+		}
+	}
+
+	choices := interaction.find(func(name string) bool {
+		return name == "simpleChoice"
+	})
+	labels := make([]string, len(choices))
+	options := make([]string, len(choices))
+	for i, choice := range choices {
+		labels[i] = choice.attr("identifier")
+		options[i] = xmlText(choice.InnerXML)
+	}
+
+	test, reason := newQuizTest(qtiTask(item.Title, item.Body.InnerXML), labels, options,
+		correct, qtiScore(item.Outcomes))
+	if reason != "" {
+		p.skip(item.Identifier, item.Title, reason)
+		return nil
+	}
+	*tests = append(*tests, test)
+	return nil
+}
+
+type ccQuestestinterop struct {
+	Assessments []ccAssessment `xml:"assessment"`
+	ObjectBanks []ccAssessment `xml:"objectbank"`
+}
+
+type ccAssessment struct {
+	Ident    string      `xml:"ident,attr"`
+	Title    string      `xml:"title,attr"`
+	Sections []ccSection `xml:"section"`
+	Items    []ccItem    `xml:"item"`
+}
+
+type ccSection struct {
+	Sections []ccSection `xml:"section"`
+	Items    []ccItem    `xml:"item"`
+}
+
+type ccItem struct {
+	Ident      string            `xml:"ident,attr"`
+	Title      string            `xml:"title,attr"`
+	Metadata   []ccMetadataField `xml:"itemmetadata>qtimetadata>qtimetadatafield"`
+	Material   []ccMaterial      `xml:"presentation>material"`
+	Responses  []ccResponseLid   `xml:"presentation>response_lid"`
+	Conditions []ccCondition     `xml:"resprocessing>respcondition"`
+}
+
+type ccMetadataField struct {
+	Label string `xml:"fieldlabel"`
+	Entry string `xml:"fieldentry"`
+}
+
+type ccMaterial struct {
+	Texts []struct {
+		Type string `xml:"texttype,attr"`
+		Text string `xml:",chardata"`
+	} `xml:"mattext"`
+}
+
+type ccResponseLid struct {
+	Ident  string `xml:"ident,attr"`
+	Labels []struct {
+		Ident    string       `xml:"ident,attr"`
+		Material []ccMaterial `xml:"material"`
+	} `xml:"render_choice>response_label"`
+}
+
+type ccCondition struct {
+	Var     qtiNode `xml:"conditionvar"`
+	SetVars []struct {
+		Value string `xml:",chardata"`
+	} `xml:"setvar"`
+}
+
+func (m ccMaterial) text() string {
+	var texts []string
+	for _, text := range m.Texts {
+		texts = append(texts, htmlText(text.Text))
+	}
+	return strings.Join(texts, " ")
+}
+
+func (i ccItem) metadata(label string) string {
+	for _, field := range i.Metadata {
+		if field.Label == label {
+			return strings.TrimSpace(field.Entry)
+		}
+	}
+	return ""
+}
+
+// correct returns labels of the response setting the positive score,
+// labels under not conditions are the wrong ones
+func (c ccCondition) correct() []string {
+	positive := false
+	for _, setVar := range c.SetVars {
+		if value, err := strconv.ParseFloat(strings.TrimSpace(setVar.Value), 64); err == nil && value > 0 {
+			positive = true
+		}
+	}
+	if !positive {
+		return nil
+	}
+
+	var labels []string
+	var walk func(node qtiNode)
+	walk = func(node qtiNode) {
+		for _, child := range node.Nodes {
+			switch child.XMLName.Local {
+			case "not":
+			case "varequal":
+				labels = append(labels, xmlText(child.InnerXML))
+			default:
+				walk(child)
+			}
+		}
+	}
+	walk(c.Var)
+	return labels
+}
+
+func (s ccSection) items() []ccItem {
+	items := s.Items
+	for _, section := range s.Sections {
+		items = append(items, section.items()...)
+	}
+	return items
+}
+
+// parseAssessments adds lessons of the common cartridge assessments and question banks
+func (p *quizParser) parseAssessments(data []byte) error {
+	var document ccQuestestinterop
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return errors.Wrap(errs.ErrQuizPackageInvalid, err.Error())
+	}
+
+	for _, assessment := range append(document.Assessments, document.ObjectBanks...) {
+		items := assessment.Items
+		for _, section := range assessment.Sections {
+			items = append(items, section.items()...)
+		}
+
+		var tests []port.CreateTestParam
+		for _, item := range items {
+			if test, ok := p.parseCCItem(item); ok {
+				tests = append(tests, test)
+			}
+		}
+
+		title := assessment.Title
+		if title == "" {
+			title = assessment.Ident
+		}
+		p.addLesson(title, tests)
+	}
+	return nil
+}
+
+// parseCCItem maps the qti 1.2 choice item to the test
+func (p *quizParser) parseCCItem(item ccItem) (port.CreateTestParam, bool) {
+	if profile := item.metadata("cc_profile"); profile != "" && !ccChoiceProfiles[profile] {
+		p.skip(item.Ident, item.Title, fmt.Sprintf("%s items are not supported", profile))
+		return port.CreateTestParam{}, false
+	}
+	if len(item.Responses) != 1 {
+		p.skip(item.Ident, item.Title, "item must have a single choice response")
+		return port.CreateTestParam{}, false
+	}
+
+	response := item.Responses[0]
+	labels := make([]string, len(response.Labels))
+	options := make([]string, len(response.Labels))
+	for i, label := range response.Labels {
+		labels[i] = label.Ident
+		var texts []string
+		for _, material := range label.Material {
+			texts = append(texts, material.text())
+		}
+		options[i] = strings.Join(texts, " ")
+	}
+
+	var correct []string
+	for _, condition := range item.Conditions {
+		correct = append(correct, condition.correct()...)
+	}
+
+	var texts []string
+	for _, material := range item.Material {
+		texts = append(texts, material.text())
+	}
+	score := quizTestScore
+	if weighting, err := strconv.ParseFloat(item.metadata("cc_weighting"), 64); err == nil {
+		score = max(int(math.Round(weighting)), quizTestScore)
+	}
+
+	test, reason := newQuizTest(quizTask(item.Title, strings.Join(texts, " ")), labels, options,
+		correct, score)
+	if reason != "" {
+		p.skip(item.Ident, item.Title, reason)
+		return port.CreateTestParam{}, false
+	}
+	return test, true
+}
+
+// newQuizTest returns the test of the choice item or the reason to skip it,
+// answer of the item with several correct choices joins them in the choice order
+func newQuizTest(task string, labels, options, correct []string, score int) (port.CreateTestParam, string) {
+	if len(options) < 2 {
+		return port.CreateTestParam{}, "item has less than 2 choices"
+	}
+	if len(correct) == 0 {
+		return port.CreateTestParam{}, "item has no correct response"
+	}
+
+	unique := make(map[string]bool, len(options))
+	for _, option := range options {
+		if option == "" || unique[option] {
+			return port.CreateTestParam{}, "item choices must have different non-empty text"
+		}
+		unique[option] = true
+	}
+
+	isCorrect := make(map[string]bool, len(correct))
+	for _, label := range correct {
+		isCorrect[label] = true
+	}
+	var answers []string
+	for i, label := range labels {
+		if isCorrect[label] {
+			answers = append(answers, options[i])
+			delete(isCorrect, label)
+		}
+	}
+	if len(isCorrect) > 0 {
+		return port.CreateTestParam{}, "correct response is not one of the item choices"
+	}
+
+	return port.CreateTestParam{
+		Task:    task,
+		Options: options,
+		Answer:  domain.JoinTestAnswers(answers),
+		Level:   quizTestLevel,
+		Score:   score,
+	}, ""
+}
+
+func (p *quizParser) addLesson(title string, tests []port.CreateTestParam) {
+	if len(tests) == 0 {
+		return
+	}
+	score := 0
+	for _, test := range tests {
+		score += test.Score
+	}
+	p.quiz.Lessons = append(p.quiz.Lessons, port.CreatePracticeParam{
+		Title: title,
+		Score: score,
+		Tests: tests,
+	})
+}
+
+func (p *quizParser) skip(identifier, title, reason string) {
+	p.quiz.Skipped = append(p.quiz.Skipped, port.SkippedQuizItem{
+		Identifier: identifier,
+		Title:      title,
+		Reason:     reason,
+	})
+}
+
+// readFile unpacks the package file, the reader stops right after the limit
+func (p *quizParser) readFile(name string) ([]byte, error) {
+	file, ok := p.files[name]
+	if !ok {
+		return nil, errors.Wrap(errs.ErrQuizPackageInvalid, fmt.Sprintf("file %s is missing", name))
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errors.Wrap(errs.ErrQuizPackageInvalid, err.Error())
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, quizMaxFileSize+1))
+	if err != nil {
+		return nil, errors.Wrap(errs.ErrQuizPackageInvalid, err.Error())
+	}
+	p.unpacked += int64(len(data))
+	if len(data) > quizMaxFileSize || p.unpacked > quizMaxSize {
+		return nil, errors.Wrap(errs.ErrQuizPackageTooLarge, name)
+	}
+	return data, nil
+}
+
+// qtiScore returns the maximum score of the qti 2.1 item
+func qtiScore(outcomes []qtiOutcome) int {
+	for _, outcome := range outcomes {
+		value := outcome.NormalMaximum
+		if outcome.Identifier == "MAXSCORE" && len(outcome.Default) > 0 {
+			value = outcome.Default[0]
+		}
+		if score, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			return max(int(math.Round(score)), quizTestScore)
+		}
+	}
+	return quizTestScore
+}
+
+// qtiTask returns the task of the item body without its choices and feedback
+func qtiTask(title, body string) string {
+	decoder := xml.NewDecoder(strings.NewReader(body))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	var text strings.Builder
+	skipped := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if skipped > 0 || t.Name.Local == "simpleChoice" || strings.HasPrefix(t.Name.Local, "feedback") {
+				skipped++
+			}
+			text.WriteString(" ")
+		case xml.EndElement:
+			if skipped > 0 {
+				skipped--
+			}
+			text.WriteString(" ")
+		case xml.CharData:
+			if skipped == 0 {
+				text.Write(t)
+			}
+		}
+	}
+	return quizTask(title, text.String())
+}
+
+// quizTask returns the markdown task with the item title as the heading
+func quizTask(title, text string) string {
+	title = strings.Join(strings.Fields(title), " ")
+	text = strings.Join(strings.Fields(text), " ")
+	switch {
+	case title == "":
+		return text
+	case text == "":
+		return title
+	}
+	return "### " + title + "\n\n" + text
+}
+
+// xmlText returns the text of the xml content without tags
+func xmlText(content string) string {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement, xml.EndElement:
+			text.WriteString(" ")
+		}
+	}
+	return strings.Join(strings.Fields(text.String()), " ")
+}
+
+// htmlText returns the text of the html material of common cartridge items
+func htmlText(content string) string {
+	text := html.UnescapeString(htmlTagRegexp.ReplaceAllString(content, " "))
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"time"
+)
+
+type RefundService struct {
+	repo         port.IRefundRepository
+	paymentRepo  port.IPaymentRepository
+	courseRepo   port.ICourseRepository
+	gateway      port.IPaymentGateway
+	seatListener port.ISeatListener
+	logger       *zap.Logger
+}
+
+func NewRefundService(repo port.IRefundRepository, paymentRepo port.IPaymentRepository,
+	courseRepo port.ICourseRepository, gateway port.IPaymentGateway, seatListener port.ISeatListener,
+	logger *zap.Logger) *RefundService {
+	return &RefundService{
+		repo:         repo,
+		paymentRepo:  paymentRepo,
+		courseRepo:   courseRepo,
+		gateway:      gateway,
+		seatListener: seatListener,
+		logger:       logger,
+	}
+}
+
+func (r *RefundService) FindUserRefunds(ctx context.Context, userID domain.ID) ([]domain.Refund, error) {
+	return r.repo.FindUserRefunds(ctx, userID)
+}
+
+func (r *RefundService) FindSchoolRefunds(ctx context.Context, schoolID domain.ID) ([]domain.Refund, error) {
+	return r.repo.FindSchoolRefunds(ctx, schoolID)
+}
+
+func (r *RefundService) RequestCourseRefund(ctx context.Context, userID, courseID domain.ID,
+	param port.CreateRefundParam) (domain.Refund, error) {
+	// subscribers didn't pay for the course, only enrolled students are refunded
+	isStudent, err := isEnrolledStudent(ctx, r.courseRepo, userID, courseID)
+	if err != nil {
+		r.logger.Error("failed to check if user is a course student", zap.Error(err),
+			zap.String("courseID", courseID.String()), zap.String("userID", userID.String()))
+		return domain.Refund{}, err
+	}
+
+	if !isStudent {
+		return domain.Refund{}, errs.ErrUserIsNotCourseStudent
+	}
+
+	payment, err := r.paymentRepo.FindCoursePayment(ctx, userID, courseID)
+	if err != nil {
+		r.logger.Error("failed to find course payment", zap.Error(err),
+			zap.String("courseID", courseID.String()), zap.String("userID", userID.String()))
+		if errors.Is(err, errs.ErrNotExist) {
+			return domain.Refund{}, errs.ErrCourseNotPaid
+		}
+		return domain.Refund{}, err
+	}
+
+	refund, err := r.repo.Create(ctx, domain.Refund{
+		ID:          domain.NewID(),
+		UserID:      userID,
+		CourseID:    courseID,
+		PaymentKey:  payment.Key,
+		Amount:      payment.PaySum,
+		Currency:    payment.Currency,
+		Reason:      param.Reason,
+		Status:      domain.RefundRequested,
+		RequestedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		r.logger.Error("failed to create refund request", zap.Error(err),
+			zap.String("courseID", courseID.String()), zap.String("userID", userID.String()))
+		if errors.Is(err, errs.ErrDuplicate) {
+			return domain.Refund{}, errs.ErrRefundAlreadyRequested
+		}
+		return domain.Refund{}, err
+	}
+
+	r.logger.Info("refund is successfully requested", zap.String("refundID", refund.ID.String()),
+		zap.String("courseID", courseID.String()), zap.String("userID", userID.String()))
+	return refund, nil
+}
+
+func (r *RefundService) ApproveRefund(ctx context.Context, schoolID, refundID,
+	approverID domain.ID) (domain.Refund, error) {
+	refund, err := r.findSchoolRefund(ctx, schoolID, refundID)
+	if err != nil {
+		return domain.Refund{}, err
+	}
+
+	switch refund.Status {
+	case domain.RefundRequested:
+		// concurrent rejection can't win after the payment is paid back
+		err = r.repo.StartApproval(ctx, refundID)
+		if err != nil {
+			r.logger.Error("failed to start refund approval", zap.Error(err),
+				zap.String("refundID", refundID.String()))
+			return domain.Refund{}, err
+		}
+	case domain.RefundApproving:
+		// left by failed approval, the payment may be already paid back,
+		// gateway refund is idempotent by the refund id and is repeated
+	default:
+		return domain.Refund{}, errs.ErrRefundAlreadyResolved
+	}
+
+	err = r.gateway.Refund(ctx, refund)
+	if err != nil {
+		r.logger.Error("failed to refund payment", zap.Error(err),
+			zap.String("refundID", refundID.String()))
+		if refund.Status != domain.RefundRequested {
+			return domain.Refund{}, err
+		}
+		if cancelErr := r.repo.CancelApproval(ctx, refundID); cancelErr != nil {
+			r.logger.Error("failed to cancel refund approval", zap.Error(cancelErr),
+				zap.String("refundID", refundID.String()))
+		}
+		return domain.Refund{}, err
+	}
+
+	approvedAt := time.Now().UTC()
+	err = r.repo.Approve(ctx, refundID, approverID, approvedAt)
+	if err != nil {
+		r.logger.Error("payment is paid back but refund approval failed, refund stays approving "+
+			"until it is approved again", zap.Error(err), zap.String("refundID", refundID.String()))
+		return domain.Refund{}, err
+	}
+
+	refund.Status = domain.RefundApproved
+	refund.ResolvedBy = null.StringFrom(approverID.String())
+	refund.ResolvedAt = null.TimeFrom(approvedAt)
+	r.logger.Info("refund is successfully approved", zap.String("refundID", refundID.String()),
+		zap.String("approverID", approverID.String()))
+
+	err = r.seatListener.OfferFreeSeats(ctx, refund.CourseID)
+	if err != nil {
+		r.logger.Error("failed to offer refunded seat to waitlist", zap.Error(err),
+			zap.String("courseID", refund.CourseID.String()))
+	}
+	return refund, nil
+}
+
+func (r *RefundService) RejectRefund(ctx context.Context, schoolID, refundID,
+	approverID domain.ID) (domain.Refund, error) {
+	refund, err := r.findSchoolRefund(ctx, schoolID, refundID)
+	if err != nil {
+		return domain.Refund{}, err
+	}
+
+	if refund.Status != domain.RefundRequested {
+		return domain.Refund{}, errs.ErrRefundAlreadyResolved
+	}
+
+	rejectedAt := time.Now().UTC()
+	err = r.repo.Reject(ctx, refundID, approverID, rejectedAt)
+	if err != nil {
+		r.logger.Error("failed to reject refund", zap.Error(err),
+			zap.String("refundID", refundID.String()))
+		return domain.Refund{}, err
+	}
+
+	refund.Status = domain.RefundRejected
+	refund.ResolvedBy = null.StringFrom(approverID.String())
+	refund.ResolvedAt = null.TimeFrom(rejectedAt)
+	r.logger.Info("refund is rejected", zap.String("refundID", refundID.String()),
+		zap.String("approverID", approverID.String()))
+	return refund, nil
+}
+
+// findSchoolRefund returns refund for a course of the school,
+// refunds of other schools are reported as not existing
+func (r *RefundService) findSchoolRefund(ctx context.Context, schoolID,
+	refundID domain.ID) (domain.Refund, error) {
+	refund, err := r.repo.FindByID(ctx, refundID)
+	if err != nil {
+		r.logger.Error("failed to find refund", zap.Error(err),
+			zap.String("refundID", refundID.String()))
+		return domain.Refund{}, err
+	}
+
+	course, err := r.courseRepo.FindByID(ctx, refund.CourseID)
+	if err != nil {
+		r.logger.Error("failed to find refund course", zap.Error(err),
+			zap.String("courseID", refund.CourseID.String()))
+		return domain.Refund{}, err
+	}
+
+	if course.SchoolID != schoolID {
+		return domain.Refund{}, errs.ErrNotExist
+	}
+	return refund, nil
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
 	"github.com/paw1a/eschool/internal/core/port"
 	"go.uber.org/zap"
 )
@@ -27,6 +29,24 @@ func (s *StatService) FindLessonStat(ctx context.Context,
 	return s.repo.FindLessonStat(ctx, userID, lessonID)
 }
 
+// FindOrCreateLessonStat creates empty stat on the first visit of the lesson,
+// subscribers study courses without enrollment, so their stats are not created in advance
+func (s *StatService) FindOrCreateLessonStat(ctx context.Context,
+	userID, lessonID domain.ID) (domain.LessonStat, error) {
+	stat, err := s.repo.FindLessonStat(ctx, userID, lessonID)
+	if err == nil || !errors.Is(err, errs.ErrNotExist) {
+		return stat, err
+	}
+
+	err = s.CreateLessonStat(ctx, userID, lessonID)
+	if err != nil {
+		s.logger.Error("failed to create lesson stat", zap.Error(err),
+			zap.String("userID", userID.String()), zap.String("lessonID", lessonID.String()))
+		return domain.LessonStat{}, err
+	}
+	return s.repo.FindLessonStat(ctx, userID, lessonID)
+}
+
 func (s *StatService) CreateLessonStat(ctx context.Context,
 	userID, lessonID domain.ID) error {
 	lesson, err := s.lessonRepo.FindByID(ctx, lessonID)
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"net/url"
+	"time"
+)
+
+// subscriptions are charged in advance, so a failed charge can be
+// retried by the next runs before access ends
+const subscriptionRenewalAdvance = 24 * time.Hour
+
+// subscriptionRenewalLease is the time given to renew the claimed subscription,
+// it is claimed again after the lease if the renewal run crashed
+const subscriptionRenewalLease = 5 * time.Minute
+
+type SubscriptionService struct {
+	repo         port.ISubscriptionRepository
+	userRepo     port.IUserRepository
+	gateway      port.IPaymentGateway
+	ledgerRepo   port.ILedgerRepository
+	ledgerConfig *LedgerConfig
+	logger       *zap.Logger
+}
+
+func NewSubscriptionService(repo port.ISubscriptionRepository, userRepo port.IUserRepository,
+	gateway port.IPaymentGateway, ledgerRepo port.ILedgerRepository,
+	ledgerConfig *LedgerConfig, logger *zap.Logger) *SubscriptionService {
+	return &SubscriptionService{
+		repo:         repo,
+		userRepo:     userRepo,
+		gateway:      gateway,
+		ledgerRepo:   ledgerRepo,
+		ledgerConfig: ledgerConfig,
+		logger:       logger,
+	}
+}
+
+func (s *SubscriptionService) FindSchoolPlan(ctx context.Context,
+	schoolID domain.ID) (domain.SubscriptionPlan, error) {
+	plan, err := s.repo.FindSchoolPlan(ctx, schoolID)
+	if err != nil {
+		s.logger.Error("failed to find school subscription plan", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return domain.SubscriptionPlan{}, err
+	}
+	return plan, nil
+}
+
+// SaveSchoolPlan creates the school plan or changes the existing one,
+// new price and period apply to the next payments of subscribers
+func (s *SubscriptionService) SaveSchoolPlan(ctx context.Context, schoolID domain.ID,
+	param port.SubscriptionPlanParam) (domain.SubscriptionPlan, error) {
+	if param.Price <= 0 {
+		return domain.SubscriptionPlan{}, errs.ErrSubscriptionInvalidPrice
+	}
+	if param.PeriodDays <= 0 {
+		return domain.SubscriptionPlan{}, errs.ErrSubscriptionInvalidPeriod
+	}
+	if param.Currency == "" {
+		param.Currency = domain.DefaultCurrency
+	}
+	if !domain.SupportedCurrency(param.Currency) {
+		return domain.SubscriptionPlan{}, errs.ErrSubscriptionInvalidCurrency
+	}
+
+	plan, err := s.repo.SavePlan(ctx, domain.SubscriptionPlan{
+		ID:         domain.NewID(),
+		SchoolID:   schoolID,
+		Name:       param.Name,
+		Price:      param.Price,
+		Currency:   param.Currency,
+		PeriodDays: param.PeriodDays,
+	})
+	if err != nil {
+		s.logger.Error("failed to save school subscription plan", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return domain.SubscriptionPlan{}, err
+	}
+
+	s.logger.Info("school subscription plan is successfully saved",
+		zap.String("planID", plan.ID.String()), zap.String("schoolID", schoolID.String()),
+		zap.Int64("price", plan.Price), zap.Int("period days", plan.PeriodDays))
+	return plan, nil
+}
+
+func (s *SubscriptionService) FindUserSubscriptions(ctx context.Context,
+	userID domain.ID) ([]domain.Subscription, error) {
+	subscriptions, err := s.repo.FindUserSubscriptions(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to find user subscriptions", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// GetSubscriptionPaymentUrl returns payment url of the school plan, the paid
+// period is added to the active subscription that is not renewed automatically
+func (s *SubscriptionService) GetSubscriptionPaymentUrl(ctx context.Context,
+	userID, schoolID domain.ID) (url.URL, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to find user by id", zap.Error(err),
+			zap.String("userID", userID.String()))
+		return url.URL{}, err
+	}
+
+	if !user.EmailVerified {
+		return url.URL{}, errs.ErrEmailNotVerified
+	}
+
+	plan, err := s.repo.FindSchoolPlan(ctx, schoolID)
+	if err != nil {
+		s.logger.Error("failed to find school subscription plan", zap.Error(err),
+			zap.String("schoolID", schoolID.String()))
+		return url.URL{}, err
+	}
+
+	subscription, err := s.repo.FindUserSubscription(ctx, userID, schoolID)
+	if err == nil {
+		if subscription.Active(time.Now()) && subscription.AutoRenewable() {
+			return url.URL{}, errs.ErrSubscriptionAlreadyActive
+		}
+	} else if !errors.Is(err, errs.ErrNotExist) {
+		s.logger.Error("failed to find user subscription", zap.Error(err),
+			zap.String("schoolID", schoolID.String()), zap.String("userID", userID.String()))
+		return url.URL{}, err
+	}
+
+	link, err := s.gateway.GetPaymentUrl(ctx, domain.PaymentPayload{
+		UserID:             userID,
+		SubscriptionPlanID: plan.ID,
+		PaySum:             plan.Cost(),
+	})
+	if err != nil {
+		s.logger.Error("failed to get payment link", zap.Error(err),
+			zap.String("planID", plan.ID.String()))
+		return url.URL{}, err
+	}
+
+	s.logger.Info("subscription payment link is generated successfully",
+		zap.String("url", link.String()), zap.String("userID", userID.String()),
+		zap.String("planID", plan.ID.String()))
+	return link, nil
+}
+
+// ActivateSubscription credits the school ledger and extends the user
+// subscription by the paid period, repeated calls for the same payment
+// don't extend the subscription twice
+func (s *SubscriptionService) ActivateSubscription(ctx context.Context, paymentKey string,
+	payload domain.PaymentPayload) (domain.Subscription, error) {
+	plan, err := s.repo.FindPlanByID(ctx, payload.SubscriptionPlanID)
+	if err != nil {
+		s.logger.Error("failed to find subscription plan by id", zap.Error(err),
+			zap.String("planID", payload.SubscriptionPlanID.String()))
+		return domain.Subscription{}, err
+	}
+
+	subscription, err := s.activateSubscription(ctx, paymentKey, plan, payload, time.Now().UTC())
+	if err != nil {
+		s.logger.Error("failed to activate subscription", zap.Error(err),
+			zap.String("planID", plan.ID.String()), zap.String("userID", payload.UserID.String()),
+			zap.String("key", paymentKey))
+		return domain.Subscription{}, err
+	}
+
+	s.logger.Info("subscription is successfully activated",
+		zap.String("subscriptionID", subscription.ID.String()),
+		zap.String("userID", subscription.UserID.String()),
+		zap.Time("expires at", subscription.ExpiresAt))
+	return subscription, nil
+}
+
+// CancelSubscription stops automatic renewals, the paid period stays active
+func (s *SubscriptionService) CancelSubscription(ctx context.Context, userID, schoolID domain.ID) error {
+	subscription, err := s.repo.FindUserSubscription(ctx, userID, schoolID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotExist) {
+			return errs.ErrSubscriptionNotActive
+		}
+		s.logger.Error("failed to find user subscription", zap.Error(err),
+			zap.String("schoolID", schoolID.String()), zap.String("userID", userID.String()))
+		return err
+	}
+
+	if !subscription.Active(time.Now()) || subscription.CanceledAt.Valid {
+		return errs.ErrSubscriptionNotActive
+	}
+
+	err = s.cancelSubscription(ctx, subscription, time.Now().UTC())
+	if err != nil {
+		s.logger.Error("failed to cancel subscription", zap.Error(err),
+			zap.String("subscriptionID", subscription.ID.String()))
+		return err
+	}
+
+	s.logger.Info("subscription is successfully canceled",
+		zap.String("subscriptionID", subscription.ID.String()), zap.String("userID", userID.String()))
+	return nil
+}
+
+// RenewSubscriptions charges saved payment methods of subscriptions ending
+// soon, subscription that has expired without a successful charge is canceled
+func (s *SubscriptionService) RenewSubscriptions(ctx context.Context) error {
+	now := time.Now().UTC()
+	subscriptions, err := s.repo.ClaimRenewableSubscriptions(ctx, now, now.Add(subscriptionRenewalAdvance),
+		now.Add(subscriptionRenewalLease))
+	if err != nil {
+		s.logger.Error("failed to find renewable subscriptions", zap.Error(err))
+		return err
+	}
+
+	var renewed int
+	for _, subscription := range subscriptions {
+		err = s.renewSubscription(ctx, subscription, now)
+		if err == nil {
+			renewed++
+			continue
+		}
+
+		s.logger.Error("failed to renew subscription", zap.Error(err),
+			zap.String("subscriptionID", subscription.ID.String()),
+			zap.String("userID", subscription.UserID.String()))
+		if subscription.Active(now) {
+			continue
+		}
+
+		err = s.cancelSubscription(ctx, subscription, now)
+		if err != nil {
+			s.logger.Error("failed to cancel expired subscription", zap.Error(err),
+				zap.String("subscriptionID", subscription.ID.String()))
+		}
+	}
+
+	s.logger.Info("subscriptions are renewed",
+		zap.Int("found", len(subscriptions)), zap.Int("renewed", renewed))
+	return nil
+}
+
+func (s *SubscriptionService) renewSubscription(ctx context.Context, subscription domain.Subscription,
+	now time.Time) error {
+	plan, err := s.repo.FindPlanByID(ctx, subscription.PlanID)
+	if err != nil {
+		return err
+	}
+
+	payload := domain.PaymentPayload{
+		UserID:             subscription.UserID,
+		SubscriptionPlanID: plan.ID,
+		PaymentMethodID:    subscription.PaymentMethodID.String,
+		PaySum:             plan.Cost(),
+	}
+	paymentKey, err := s.gateway.ChargeRecurring(ctx, payload, subscription.PaymentMethodID.String,
+		subscription.RenewalKey())
+	if err != nil {
+		return err
+	}
+
+	_, err = s.activateSubscription(ctx, paymentKey, plan, payload, now)
+	return err
+}
+
+func (s *SubscriptionService) activateSubscription(ctx context.Context, paymentKey string,
+	plan domain.SubscriptionPlan, payload domain.PaymentPayload, now time.Time) (domain.Subscription, error) {
+	// plan id takes the place of the course id, so statements show the plan as a product
+	err := s.ledgerRepo.Create(ctx, domain.LedgerEntry{
+		ID:         domain.NewID(),
+		SchoolID:   plan.SchoolID,
+		CourseID:   plan.ID,
+		UserID:     payload.UserID,
+		PaymentKey: paymentKey,
+		Kind:       domain.LedgerSale,
+		Gross:      payload.PaySum.Amount,
+		Commission: domain.Commission(payload.PaySum.Amount, s.ledgerConfig.CommissionPercent),
+		Currency:   payload.PaySum.Currency,
+		CreatedAt:  now,
+	})
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+
+	return s.repo.Extend(ctx, domain.Subscription{
+		ID:              domain.NewID(),
+		PlanID:          plan.ID,
+		SchoolID:        plan.SchoolID,
+		UserID:          payload.UserID,
+		PaymentMethodID: null.NewString(payload.PaymentMethodID, payload.PaymentMethodID != ""),
+		StartedAt:       now,
+		ExpiresAt:       plan.Extend(now),
+	}, paymentKey)
+}
+
+func (s *SubscriptionService) cancelSubscription(ctx context.Context, subscription domain.Subscription,
+	now time.Time) error {
+	if subscription.PaymentMethodID.Valid {
+		err := s.gateway.CancelRecurring(ctx, subscription.PaymentMethodID.String)
+		if err != nil {
+			return err
+		}
+	}
+	return s.repo.Cancel(ctx, subscription.ID, now)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/paw1a/eschool/internal/core/domain"
 	"github.com/paw1a/eschool/internal/core/port"
 	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
 	"github.com/testcontainers/testcontainers-go/modules/minio"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"go.uber.org/zap"
@@ -27,7 +28,8 @@ var createSchoolParam = port.CreateSchoolParam{
 var createCourseParam = port.CreateCourseParam{
 	Name:     "e2e course",
 	Level:    4,
-	Price:    1200,
+	Price:    120000,
+	Currency: domain.DefaultCurrency,
 	Language: "russian",
 }
 
@@ -125,9 +127,9 @@ func (s *EndToEndSuite) Test_EndToEnd(t provider.T) {
 	courseRepo := repository.NewCourseRepo(s.db)
 	statRepo := repository.NewStatRepo(s.db)
 	objectStorage := storage.NewObjectStorage(s.minioClient, &minioConfig)
-	lessonService := service.NewLessonService(lessonRepo, objectStorage, s.logger)
+	lessonService := service.NewLessonService(lessonRepo, courseRepo, objectStorage, s.logger)
 	schoolService := service.NewSchoolService(schoolRepo, s.logger)
-	courseService := service.NewCourseService(courseRepo, lessonRepo, schoolRepo, statRepo, s.logger)
+	courseService := service.NewCourseService(courseRepo, lessonRepo, schoolRepo, statRepo, mocks.NewSeatListener(t), s.logger)
 
 	school, err := schoolService.CreateUserSchool(context.Background(), userID, createSchoolParam)
 	if err != nil {
@@ -168,10 +170,11 @@ func (s *EndToEndSuite) Test_EndToEnd(t provider.T) {
 		t.Assert().Equal(test.Answer, createPracticeLessonParam.Tests[i].Answer)
 	}
 
-	errs := courseService.ConfirmDraftCourse(context.Background(), course.ID)
-	t.Assert().Nil(errs)
+	report, err := courseService.ConfirmDraftCourse(context.Background(), userID, course.ID)
+	t.Assert().Nil(err)
+	t.Assert().True(report.Valid())
 
-	err = courseService.PublishReadyCourse(context.Background(), course.ID)
+	err = courseService.PublishReadyCourse(context.Background(), userID, course.ID)
 	t.Assert().Nil(err)
 
 	course, err = courseService.FindByID(context.Background(), course.ID)
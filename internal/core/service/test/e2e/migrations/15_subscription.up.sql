@@ -0,0 +1,29 @@
+create table public.subscription_plan (
+    id uuid primary key,
+    school_id uuid not null unique,
+    name varchar(255) not null,
+    price bigint not null,
+    period_days int not null,
+    foreign key (school_id) references public.school(id) on delete cascade
+);
+
+-- subscription sales are stored in ledger_entry with the plan id as course_id
+create table public.subscription (
+    id uuid primary key,
+    plan_id uuid not null,
+    school_id uuid not null,
+    user_id uuid not null,
+    payment_method_id text,
+    started_at timestamp not null,
+    expires_at timestamp not null,
+    canceled_at timestamp,
+    -- key of the last paid period, repeated webhook calls don't extend twice
+    last_payment_key text not null,
+    unique (user_id, school_id),
+    foreign key (plan_id) references public.subscription_plan(id) on delete cascade,
+    foreign key (school_id) references public.school(id) on delete cascade,
+    foreign key (user_id) references public.user(id) on delete cascade
+);
+
+create index subscription_expires_at_idx on public.subscription (expires_at)
+    where canceled_at is null;
@@ -0,0 +1,15 @@
+alter type course_status add value 'unpublished';
+alter type course_status add value 'archived';
+
+create table public.course_transition (
+    id uuid primary key,
+    course_id uuid not null,
+    from_status course_status not null,
+    to_status course_status not null,
+    user_id uuid,
+    created_at timestamp not null,
+    foreign key (course_id) references public.course(id) on delete cascade,
+    foreign key (user_id) references public.user(id) on delete set null
+);
+
+create index course_transition_course_idx on public.course_transition (course_id);
@@ -0,0 +1,22 @@
+alter table public.course add column publish_at timestamp;
+
+alter table public.lesson add column release_at timestamp;
+alter table public.lesson add column release_days int;
+
+create type job_status as enum ('pending', 'done', 'failed');
+
+create table public.job (
+    id uuid primary key,
+    kind text not null,
+    target_id uuid not null,
+    user_id uuid,
+    run_at timestamp not null,
+    status job_status not null,
+    attempts int not null default 0,
+    locked_until timestamp,
+    last_error text,
+    created_at timestamp not null,
+    foreign key (user_id) references public.user(id) on delete set null
+);
+
+create index job_due_idx on public.job (run_at) where status = 'pending';
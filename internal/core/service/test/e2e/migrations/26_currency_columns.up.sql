@@ -0,0 +1,11 @@
+-- amounts of ledger entries, payouts, gifts, fixed promo discounts and plans
+-- are in minor units of their own currency, existing rows are priced as their courses
+alter table public.ledger_entry add column currency char(3) not null default 'RUB';
+alter table public.payout add column currency char(3) not null default 'RUB';
+alter table public.gift add column currency char(3) not null default 'RUB';
+alter table public.promo_code add column currency char(3) not null default 'RUB';
+alter table public.subscription_plan add column currency char(3) not null default 'RUB';
+
+update public.ledger_entry e set currency = c.currency from public.course c where c.id = e.course_id;
+update public.gift g set currency = c.currency from public.course c where c.id = g.course_id;
+update public.promo_code p set currency = c.currency from public.course c where c.id = p.course_id;
@@ -0,0 +1,5 @@
+-- percent discounts apply to a price in any currency, only fixed codes keep one
+alter table public.promo_code alter column currency drop not null;
+alter table public.promo_code alter column currency drop default;
+
+update public.promo_code set currency = null where discount_type = 'percent';
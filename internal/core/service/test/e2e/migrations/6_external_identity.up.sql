@@ -0,0 +1,8 @@
+create table public.external_identity (
+    provider varchar(255) not null,
+    subject varchar(255) not null,
+    user_id uuid not null,
+    email varchar(255) not null,
+    primary key (provider, subject),
+    foreign key (user_id) references public.user(id) on delete cascade
+);
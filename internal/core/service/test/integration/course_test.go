@@ -8,6 +8,7 @@ import (
 	repository "github.com/paw1a/eschool/internal/adapter/repository/postgres"
 	"github.com/paw1a/eschool/internal/core/domain"
 	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"go.uber.org/zap"
 	"testing"
@@ -19,7 +20,7 @@ var courses = []domain.Course{
 		SchoolID: domain.ID("30e18bc1-4354-4937-9a3b-03cf0b7034cc"),
 		Name:     "course1",
 		Level:    4,
-		Price:    1200,
+		Price:    domain.NewMoney(120000, domain.DefaultCurrency),
 		Language: "russian",
 		Status:   domain.CourseDraft,
 	},
@@ -28,7 +29,7 @@ var courses = []domain.Course{
 		SchoolID: domain.ID("30e18bc1-4354-4937-9a3b-03cf0b7034cc"),
 		Name:     "course2",
 		Level:    2,
-		Price:    1500,
+		Price:    domain.NewMoney(150000, domain.DefaultCurrency),
 		Language: "english",
 		Status:   domain.CoursePublished,
 	},
@@ -37,7 +38,7 @@ var courses = []domain.Course{
 		SchoolID: domain.ID("30e18bc1-4354-4937-9a3b-03cf0b7034cd"),
 		Name:     "course3",
 		Level:    3,
-		Price:    12000,
+		Price:    domain.NewMoney(1200000, domain.DefaultCurrency),
 		Language: "russian",
 		Status:   domain.CourseReady,
 	},
@@ -46,7 +47,7 @@ var courses = []domain.Course{
 		SchoolID: domain.ID("30e18bc1-4354-4937-9a3b-03cf0b7034cd"),
 		Name:     "course4",
 		Level:    2,
-		Price:    0,
+		Price:    domain.NewMoney(0, domain.DefaultCurrency),
 		Language: "english",
 		Status:   domain.CoursePublished,
 	},
@@ -105,7 +106,7 @@ func (s *CourseSuite) TestUserService_FindAll(t provider.T) {
 	lessonRepo := repository.NewLessonRepo(s.db)
 	schoolRepo := repository.NewSchoolRepo(s.db)
 	statRepo := repository.NewStatRepo(s.db)
-	courseService := service.NewCourseService(repo, lessonRepo, schoolRepo, statRepo, s.logger)
+	courseService := service.NewCourseService(repo, lessonRepo, schoolRepo, statRepo, mocks.NewSeatListener(t), s.logger)
 	found, err := courseService.FindAll(context.Background())
 	if err != nil {
 		t.Errorf("failed to find all courses: %v", err)
@@ -121,7 +122,7 @@ func (s *CourseSuite) TestUserService_FindByID(t provider.T) {
 	lessonRepo := repository.NewLessonRepo(s.db)
 	schoolRepo := repository.NewSchoolRepo(s.db)
 	statRepo := repository.NewStatRepo(s.db)
-	courseService := service.NewCourseService(repo, lessonRepo, schoolRepo, statRepo, s.logger)
+	courseService := service.NewCourseService(repo, lessonRepo, schoolRepo, statRepo, mocks.NewSeatListener(t), s.logger)
 	course, err := courseService.FindByID(context.Background(), courses[0].ID)
 	if err != nil {
 		t.Errorf("failed to find course with id: %v", err)
@@ -137,7 +138,7 @@ func (s *CourseSuite) TestUserService_FindStudentCourses(t provider.T) {
 	lessonRepo := repository.NewLessonRepo(s.db)
 	schoolRepo := repository.NewSchoolRepo(s.db)
 	statRepo := repository.NewStatRepo(s.db)
-	courseService := service.NewCourseService(repo, lessonRepo, schoolRepo, statRepo, s.logger)
+	courseService := service.NewCourseService(repo, lessonRepo, schoolRepo, statRepo, mocks.NewSeatListener(t), s.logger)
 	found, err := courseService.FindStudentCourses(context.Background(), studentCoursesID)
 	if err != nil {
 		t.Errorf("failed to find student courses: %v", err)
@@ -155,7 +156,7 @@ func (s *CourseSuite) TestUserService_FindTeacherCourses(t provider.T) {
 	lessonRepo := repository.NewLessonRepo(s.db)
 	schoolRepo := repository.NewSchoolRepo(s.db)
 	statRepo := repository.NewStatRepo(s.db)
-	courseService := service.NewCourseService(repo, lessonRepo, schoolRepo, statRepo, s.logger)
+	courseService := service.NewCourseService(repo, lessonRepo, schoolRepo, statRepo, mocks.NewSeatListener(t), s.logger)
 	found, err := courseService.FindTeacherCourses(context.Background(), teacherCoursesID)
 	if err != nil {
 		t.Errorf("failed to find teacher courses: %v", err)
@@ -173,7 +174,7 @@ func (s *CourseSuite) TestUserService_AddCourseStudent(t provider.T) {
 	lessonRepo := repository.NewLessonRepo(s.db)
 	schoolRepo := repository.NewSchoolRepo(s.db)
 	statRepo := repository.NewStatRepo(s.db)
-	courseService := service.NewCourseService(repo, lessonRepo, schoolRepo, statRepo, s.logger)
+	courseService := service.NewCourseService(repo, lessonRepo, schoolRepo, statRepo, mocks.NewSeatListener(t), s.logger)
 	err := courseService.AddCourseStudent(context.Background(), newUserID, courses[0].ID)
 	if err != nil {
 		t.Errorf("failed to add course student: %v", err)
@@ -188,7 +189,7 @@ func (s *CourseSuite) TestUserService_Delete(t provider.T) {
 	lessonRepo := repository.NewLessonRepo(s.db)
 	schoolRepo := repository.NewSchoolRepo(s.db)
 	statRepo := repository.NewStatRepo(s.db)
-	courseService := service.NewCourseService(repo, lessonRepo, schoolRepo, statRepo, s.logger)
+	courseService := service.NewCourseService(repo, lessonRepo, schoolRepo, statRepo, mocks.NewSeatListener(t), s.logger)
 	err := courseService.Delete(context.Background(), courses[0].ID)
 	if err != nil {
 		t.Errorf("failed to delete course: %v", err)
@@ -23,7 +23,9 @@ var lessonCourseID = domain.ID("30e18bc1-4354-4937-9a4d-03cf0b7027ca")
 var lessons = []domain.Lesson{
 	domain.Lesson{
 		ID:        domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7022ca"),
+		Key:       domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7022ca"),
 		CourseID:  lessonCourseID,
+		Version:   1,
 		Title:     "lesson1",
 		Score:     10,
 		Type:      domain.TheoryLesson,
@@ -33,7 +35,9 @@ var lessons = []domain.Lesson{
 	},
 	domain.Lesson{
 		ID:        domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7022cb"),
+		Key:       domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7022cb"),
 		CourseID:  lessonCourseID,
+		Version:   1,
 		Title:     "lesson2",
 		Score:     10,
 		Type:      domain.VideoLesson,
@@ -43,7 +47,9 @@ var lessons = []domain.Lesson{
 	},
 	domain.Lesson{
 		ID:        domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7022cc"),
+		Key:       domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7022cc"),
 		CourseID:  lessonCourseID,
+		Version:   1,
 		Title:     "lesson3",
 		Score:     10,
 		Type:      domain.PracticeLesson,
@@ -56,6 +62,7 @@ var lessons = []domain.Lesson{
 var tests = []domain.Test{
 	domain.Test{
 		ID:       domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7027ca"),
+		Key:      domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7027ca"),
 		LessonID: domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7022cc"),
 		TaskUrl:  "url",
 		Options:  []string{"opt1", "opt2", "opt3"},
@@ -65,6 +72,7 @@ var tests = []domain.Test{
 	},
 	domain.Test{
 		ID:       domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7027cb"),
+		Key:      domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7027cb"),
 		LessonID: domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7022cc"),
 		TaskUrl:  "url",
 		Options:  []string{"opt1", "opt2"},
@@ -74,6 +82,7 @@ var tests = []domain.Test{
 	},
 	domain.Test{
 		ID:       domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7027cc"),
+		Key:      domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7027cc"),
 		LessonID: domain.ID("30e18bc1-4352-4937-9a3b-03cf0b7022cc"),
 		TaskUrl:  "url",
 		Options:  []string{"opt1"},
@@ -194,7 +203,7 @@ func (s *LessonSuite) TestUserService_FindAll(t provider.T) {
 	}
 	repo := repository.NewLessonRepo(s.db)
 	objectStorage := storage.NewObjectStorage(s.minioClient, &minioConfig)
-	lessonService := service.NewLessonService(repo, objectStorage, s.logger)
+	lessonService := service.NewLessonService(repo, repository.NewCourseRepo(s.db), objectStorage, s.logger)
 	found, err := lessonService.FindAll(context.Background())
 	if err != nil {
 		t.Errorf("failed to find all lessons: %v", err)
@@ -219,7 +228,7 @@ func (s *LessonSuite) TestUserService_FindByID(t provider.T) {
 	}
 	repo := repository.NewLessonRepo(s.db)
 	objectStorage := storage.NewObjectStorage(s.minioClient, &minioConfig)
-	lessonService := service.NewLessonService(repo, objectStorage, s.logger)
+	lessonService := service.NewLessonService(repo, repository.NewCourseRepo(s.db), objectStorage, s.logger)
 	lesson, err := lessonService.FindByID(context.Background(), lessons[2].ID)
 	if err != nil {
 		t.Errorf("failed to find course with id: %v", err)
@@ -238,7 +247,7 @@ func (s *LessonSuite) TestUserService_FindCourseLessons(t provider.T) {
 	}
 	repo := repository.NewLessonRepo(s.db)
 	objectStorage := storage.NewObjectStorage(s.minioClient, &minioConfig)
-	lessonService := service.NewLessonService(repo, objectStorage, s.logger)
+	lessonService := service.NewLessonService(repo, repository.NewCourseRepo(s.db), objectStorage, s.logger)
 	found, err := lessonService.FindCourseLessons(context.Background(), lessonCourseID)
 	if err != nil {
 		t.Errorf("failed to find course lessons: %v", err)
@@ -263,7 +272,7 @@ func (s *LessonSuite) TestUserService_CreateTheoryLesson(t provider.T) {
 	}
 	repo := repository.NewLessonRepo(s.db)
 	objectStorage := storage.NewObjectStorage(s.minioClient, &minioConfig)
-	lessonService := service.NewLessonService(repo, objectStorage, s.logger)
+	lessonService := service.NewLessonService(repo, repository.NewCourseRepo(s.db), objectStorage, s.logger)
 	lesson, err := lessonService.CreateTheoryLesson(context.Background(), courseID, createdLesson)
 	if err != nil {
 		t.Errorf("failed to create lesson: %v", err)
@@ -277,7 +286,7 @@ func (s *LessonSuite) TestUserService_Delete(t provider.T) {
 	}
 	repo := repository.NewLessonRepo(s.db)
 	objectStorage := storage.NewObjectStorage(s.minioClient, &minioConfig)
-	lessonService := service.NewLessonService(repo, objectStorage, s.logger)
+	lessonService := service.NewLessonService(repo, repository.NewCourseRepo(s.db), objectStorage, s.logger)
 	err := lessonService.Delete(context.Background(), lessons[0].ID)
 	if err != nil {
 		t.Errorf("failed to delete lesson: %v", err)
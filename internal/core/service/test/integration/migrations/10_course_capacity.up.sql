@@ -0,0 +1,22 @@
+alter table public.course add column capacity int;
+
+create table public.course_seat_hold (
+    course_id uuid not null,
+    user_id uuid not null,
+    expires_at timestamp not null,
+    primary key (course_id, user_id),
+    foreign key (course_id) references public.course(id) on delete cascade,
+    foreign key (user_id) references public.user(id) on delete cascade
+);
+
+create table public.course_waitlist (
+    course_id uuid not null,
+    user_id uuid not null,
+    joined_at timestamp not null,
+    offered_until timestamp,
+    primary key (course_id, user_id),
+    foreign key (course_id) references public.course(id) on delete cascade,
+    foreign key (user_id) references public.user(id) on delete cascade
+);
+
+create index course_waitlist_joined_at_idx on public.course_waitlist (course_id, joined_at);
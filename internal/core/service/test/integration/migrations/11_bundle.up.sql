@@ -0,0 +1,21 @@
+create type bundle_status as enum ('draft', 'published');
+
+create table public.bundle (
+    id uuid primary key,
+    school_id uuid not null,
+    name varchar(255) not null,
+    description text not null,
+    price bigint not null,
+    status bundle_status not null,
+    created_at timestamp not null,
+    foreign key (school_id) references public.school(id) on delete cascade
+);
+
+create table public.bundle_course (
+    bundle_id uuid not null,
+    course_id uuid not null,
+    position int not null,
+    primary key (bundle_id, course_id),
+    foreign key (bundle_id) references public.bundle(id) on delete cascade,
+    foreign key (course_id) references public.course(id) on delete cascade
+);
@@ -0,0 +1,29 @@
+create type ledger_entry_kind as enum ('sale', 'refund');
+
+create table public.payout (
+    id uuid primary key,
+    school_id uuid not null,
+    amount bigint not null,
+    settled_until timestamp not null,
+    created_at timestamp not null,
+    foreign key (school_id) references public.school(id) on delete cascade
+);
+
+-- course is not referenced, entries outlive deleted courses
+create table public.ledger_entry (
+    id uuid primary key,
+    school_id uuid not null,
+    course_id uuid not null,
+    user_id uuid not null,
+    payment_key text not null,
+    kind ledger_entry_kind not null,
+    gross bigint not null,
+    commission bigint not null,
+    created_at timestamp not null,
+    payout_id uuid,
+    unique (payment_key, course_id, kind),
+    foreign key (school_id) references public.school(id) on delete cascade,
+    foreign key (payout_id) references public.payout(id) on delete set null
+);
+
+create index ledger_entry_school_created_at_idx on public.ledger_entry (school_id, created_at);
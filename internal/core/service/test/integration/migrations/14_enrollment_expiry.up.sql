@@ -0,0 +1,10 @@
+alter table public.course add column access_days int;
+
+alter table public.course_student add column enrolled_at timestamp not null default now();
+alter table public.course_student add column expires_at timestamp;
+-- key of the last renewal payment, repeated webhook calls don't extend access twice
+alter table public.course_student add column renewal_key text;
+alter table public.course_student add column reminded_at timestamp;
+
+create index course_student_expires_at_idx on public.course_student (expires_at)
+    where expires_at is not null;
@@ -0,0 +1,16 @@
+-- money amounts are stored in minor units of the currency, existing amounts are rubles
+update public.course set price = price * 100;
+update public.bundle set price = price * 100;
+update public.promo_code set discount = discount * 100 where discount_type = 'fixed';
+update public.promo_redemption set pay_sum = pay_sum * 100;
+update public.course_payment set pay_sum = pay_sum * 100;
+update public.refund set amount = amount * 100;
+update public.ledger_entry set gross = gross * 100, commission = commission * 100;
+update public.payout set amount = amount * 100;
+update public.gift set pay_sum = pay_sum * 100;
+update public.subscription_plan set price = price * 100;
+
+-- ISO 4217 currency code, bundles and ledger entries use the currency of their courses
+alter table public.course add column currency char(3) not null default 'RUB';
+alter table public.course_payment add column currency char(3) not null default 'RUB';
+alter table public.refund add column currency char(3) not null default 'RUB';
@@ -0,0 +1,37 @@
+create table public.course_prerequisite (
+    course_id uuid not null,
+    required_course_id uuid not null,
+    min_score int,
+    primary key (course_id, required_course_id),
+    foreign key (course_id) references public.course(id) on delete cascade,
+    foreign key (required_course_id) references public.course(id) on delete cascade
+);
+
+create table public.learning_path (
+    id uuid primary key,
+    school_id uuid not null,
+    name varchar(255) not null,
+    description text not null,
+    created_at timestamp not null,
+    foreign key (school_id) references public.school(id) on delete cascade
+);
+
+create table public.learning_path_course (
+    path_id uuid not null,
+    course_id uuid not null,
+    position int not null,
+    primary key (path_id, course_id),
+    foreign key (path_id) references public.learning_path(id) on delete cascade,
+    foreign key (course_id) references public.course(id) on delete cascade
+);
+
+create table public.path_certificate (
+    id uuid primary key,
+    path_id uuid not null,
+    user_id uuid not null,
+    score int not null,
+    issued_at timestamp not null,
+    unique (path_id, user_id),
+    foreign key (path_id) references public.learning_path(id) on delete cascade,
+    foreign key (user_id) references public.user(id) on delete cascade
+);
@@ -0,0 +1 @@
+alter table public.lesson add column is_preview boolean not null default false;
@@ -0,0 +1,5 @@
+-- every paid checkout is stored, renewals and repeated purchases add payments,
+-- bundle payments share the key between the bundle courses
+alter table public.course_payment drop constraint if exists course_payment_pkey;
+alter table public.course_payment add primary key (key, course_id);
+create index course_payment_user_course_idx on public.course_payment (user_id, course_id, paid_at);
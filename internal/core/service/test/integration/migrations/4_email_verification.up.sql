@@ -0,0 +1,15 @@
+alter table public.user add column email_verified boolean not null default false;
+
+-- users registered before email verification are trusted
+update public.user set email_verified = true;
+
+create type email_token_purpose as enum ('email_verification', 'password_reset');
+
+create table public.email_token (
+    id uuid primary key,
+    user_id uuid not null,
+    purpose email_token_purpose not null,
+    expires_at timestamp not null,
+    used_at timestamp,
+    foreign key (user_id) references public.user(id) on delete cascade
+);
@@ -0,0 +1,14 @@
+create table public.user_mfa (
+    user_id uuid primary key,
+    secret varchar(255) not null,
+    enabled boolean not null,
+    foreign key (user_id) references public.user(id) on delete cascade
+);
+
+create table public.mfa_recovery_code (
+    id uuid primary key,
+    user_id uuid not null,
+    code_hash varchar(255) not null,
+    used boolean not null,
+    foreign key (user_id) references public.user(id) on delete cascade
+);
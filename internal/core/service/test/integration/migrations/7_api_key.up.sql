@@ -0,0 +1,12 @@
+create table public.api_key (
+    id uuid primary key,
+    user_id uuid not null,
+    name varchar(255) not null,
+    prefix varchar(16) not null,
+    key_hash varchar(255) not null unique,
+    scopes varchar(255) not null,
+    created_at timestamp not null,
+    last_used_at timestamp,
+    revoked_at timestamp,
+    foreign key (user_id) references public.user(id) on delete cascade
+);
@@ -0,0 +1,30 @@
+create type promo_discount_type as enum ('percent', 'fixed');
+
+create table public.promo_code (
+    id uuid primary key,
+    school_id uuid not null,
+    course_id uuid,
+    code varchar(64) not null,
+    discount_type promo_discount_type not null,
+    discount bigint not null,
+    max_uses bigint,
+    used_count bigint not null default 0,
+    valid_from timestamp,
+    valid_until timestamp,
+    created_at timestamp not null,
+    unique (school_id, code),
+    foreign key (school_id) references public.school(id) on delete cascade,
+    foreign key (course_id) references public.course(id) on delete cascade
+);
+
+create table public.promo_redemption (
+    promo_code_id uuid not null,
+    user_id uuid not null,
+    course_id uuid not null,
+    pay_sum bigint not null,
+    redeemed_at timestamp not null,
+    primary key (promo_code_id, user_id, course_id),
+    foreign key (promo_code_id) references public.promo_code(id) on delete cascade,
+    foreign key (user_id) references public.user(id) on delete cascade,
+    foreign key (course_id) references public.course(id) on delete cascade
+);
@@ -0,0 +1,39 @@
+package unit
+
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type ApiKeyBuilder struct {
+	key domain.ApiKey
+}
+
+func NewApiKeyBuilder() *ApiKeyBuilder {
+	return &ApiKeyBuilder{
+		key: domain.ApiKey{
+			ID:        domain.NewID(),
+			UserID:    domain.NewID(),
+			Name:      "script",
+			Prefix:    "esk_AbCdEfGh",
+			KeyHash:   "hash",
+			Scopes:    []domain.ApiKeyScope{domain.ApiKeyReadScope},
+			CreatedAt: time.Now(),
+		},
+	}
+}
+
+func (b *ApiKeyBuilder) WithScopes(scopes ...domain.ApiKeyScope) *ApiKeyBuilder {
+	b.key.Scopes = scopes
+	return b
+}
+
+func (b *ApiKeyBuilder) WithRevokedAt(revokedAt time.Time) *ApiKeyBuilder {
+	b.key.RevokedAt = null.TimeFrom(revokedAt)
+	return b
+}
+
+func (b *ApiKeyBuilder) Build() domain.ApiKey {
+	return b.key
+}
@@ -0,0 +1,159 @@
+package unit
+
+import (
+	"context"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ApiKeySuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *ApiKeySuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+// Create Suite
+type ApiKeyCreateSuite struct {
+	ApiKeySuite
+}
+
+func ApiKeyCreateSuccessRepositoryMock(repository *mocks.ApiKeyRepository) {
+	repository.
+		On("Create", context.Background(), mock.MatchedBy(func(key domain.ApiKey) bool {
+			return key.Name == "script" && len(key.KeyHash) == 64 && strings.HasPrefix(key.Prefix, "esk_")
+		})).
+		Return(NewApiKeyBuilder().Build(), nil)
+}
+
+func (s *ApiKeyCreateSuite) TestCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Api key service create success")
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	apiKeyService := service.NewApiKeyService(apiKeyRepository, s.logger)
+	ApiKeyCreateSuccessRepositoryMock(apiKeyRepository)
+	_, token, err := apiKeyService.Create(context.Background(), domain.NewID(), port.CreateApiKeyParam{
+		Name:   "script",
+		Scopes: []domain.ApiKeyScope{domain.ApiKeyReadScope},
+	})
+	t.Assert().Nil(err)
+	t.Assert().True(strings.HasPrefix(token.String(), "esk_"))
+}
+
+func (s *ApiKeyCreateSuite) TestCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Api key service create with invalid scope")
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	apiKeyService := service.NewApiKeyService(apiKeyRepository, s.logger)
+	_, _, err := apiKeyService.Create(context.Background(), domain.NewID(), port.CreateApiKeyParam{
+		Name:   "script",
+		Scopes: []domain.ApiKeyScope{"admin"},
+	})
+	t.Assert().ErrorIs(err, errs.ErrApiKeyInvalidScope)
+}
+
+func TestApiKeyCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Api key service create", new(ApiKeyCreateSuite))
+}
+
+// Verify Suite
+type ApiKeyVerifySuite struct {
+	ApiKeySuite
+}
+
+func ApiKeyVerifySuccessRepositoryMock(repository *mocks.ApiKeyRepository, key domain.ApiKey) {
+	repository.
+		On("FindByHash", context.Background(), mock.Anything).
+		Return(key, nil)
+	repository.
+		On("UpdateLastUsed", context.Background(), key.ID, mock.Anything).
+		Return(nil)
+}
+
+func (s *ApiKeyVerifySuite) TestVerify_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Api key service verify success")
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	apiKeyService := service.NewApiKeyService(apiKeyRepository, s.logger)
+	key := NewApiKeyBuilder().Build()
+	ApiKeyVerifySuccessRepositoryMock(apiKeyRepository, key)
+	actual, err := apiKeyService.Verify(context.Background(), "esk_key")
+	t.Assert().Nil(err)
+	t.Assert().Equal(key.UserID, actual.UserID)
+}
+
+func ApiKeyVerifyFailureRepositoryMock(repository *mocks.ApiKeyRepository) {
+	repository.
+		On("FindByHash", context.Background(), mock.Anything).
+		Return(NewApiKeyBuilder().WithRevokedAt(time.Now()).Build(), nil)
+}
+
+func (s *ApiKeyVerifySuite) TestVerify_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Api key service verify revoked key")
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	apiKeyService := service.NewApiKeyService(apiKeyRepository, s.logger)
+	ApiKeyVerifyFailureRepositoryMock(apiKeyRepository)
+	_, err := apiKeyService.Verify(context.Background(), "esk_key")
+	t.Assert().ErrorIs(err, errs.ErrInvalidApiKey)
+}
+
+func TestApiKeyVerifySuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Api key service verify", new(ApiKeyVerifySuite))
+}
+
+// Revoke Suite
+type ApiKeyRevokeSuite struct {
+	ApiKeySuite
+}
+
+func ApiKeyRevokeSuccessRepositoryMock(repository *mocks.ApiKeyRepository, userID, keyID domain.ID) {
+	repository.
+		On("Revoke", context.Background(), userID, keyID).
+		Return(nil)
+}
+
+func (s *ApiKeyRevokeSuite) TestRevoke_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Api key service revoke success")
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	apiKeyService := service.NewApiKeyService(apiKeyRepository, s.logger)
+	userID, keyID := domain.NewID(), domain.NewID()
+	ApiKeyRevokeSuccessRepositoryMock(apiKeyRepository, userID, keyID)
+	err := apiKeyService.Revoke(context.Background(), userID, keyID)
+	t.Assert().Nil(err)
+}
+
+func ApiKeyRevokeFailureRepositoryMock(repository *mocks.ApiKeyRepository) {
+	repository.
+		On("Revoke", context.Background(), mock.Anything, mock.Anything).
+		Return(errs.ErrNotExist)
+}
+
+func (s *ApiKeyRevokeSuite) TestRevoke_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Api key service revoke unknown key")
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	apiKeyService := service.NewApiKeyService(apiKeyRepository, s.logger)
+	ApiKeyRevokeFailureRepositoryMock(apiKeyRepository)
+	err := apiKeyService.Revoke(context.Background(), domain.NewID(), domain.NewID())
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestApiKeyRevokeSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Api key service revoke", new(ApiKeyRevokeSuite))
+}
@@ -0,0 +1,260 @@
+package unit
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/guregu/null"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	archiveTheoryMarkdown = "# theory\n![image](http://storage/media/image.png)\n" +
+		"[link](https://example.com/page)\n"
+	archiveTaskMarkdown = "task with <img src=\"http://storage/media/image.png\">"
+)
+
+type CourseArchiveSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *CourseArchiveSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+func CourseArchiveExportSuccessRepositoryMock(courseRepository *mocks.CourseRepository,
+	lessonRepository *mocks.LessonRepository, storage *mocks.ObjectStorage, courseID domain.ID) {
+	courseRepository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithCapacity(30).Build(), nil)
+	lessonRepository.
+		On("FindCourseLessons", context.Background(), courseID).
+		Return([]domain.Lesson{
+			NewLessonBuilder().WithCourseID(courseID).WithType(domain.TheoryLesson).
+				WithTheoryUrl(null.StringFrom("http://storage/theory.md")).
+				WithReleaseDays(3).Build(),
+			NewLessonBuilder().WithCourseID(courseID).WithType(domain.PracticeLesson).
+				WithTests([]domain.Test{NewTestBuilder().WithTaskUrl("http://storage/task.md").Build()}).
+				Build(),
+		}, nil)
+	storage.
+		On("LoadFile", context.Background(), domain.Url("http://storage/theory.md")).
+		Return(io.NopCloser(strings.NewReader(archiveTheoryMarkdown)), nil)
+	storage.
+		On("LoadFile", context.Background(), domain.Url("http://storage/task.md")).
+		Return(io.NopCloser(strings.NewReader(archiveTaskMarkdown)), nil)
+	storage.
+		On("LoadFile", context.Background(), domain.Url("http://storage/media/image.png")).
+		Return(io.NopCloser(strings.NewReader("png")), nil).Once()
+	storage.
+		On("LoadFile", context.Background(), domain.Url("https://example.com/page")).
+		Return(nil, errs.ErrFileNotStored)
+}
+
+func (s *CourseArchiveSuite) TestExportCourse_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course archive service export course success")
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	archiveService := service.NewCourseArchiveService(courseRepository, lessonRepository,
+		objectStorage, s.logger)
+	CourseArchiveExportSuccessRepositoryMock(courseRepository, lessonRepository, objectStorage, courseID)
+	var archive bytes.Buffer
+	err := archiveService.ExportCourse(context.Background(), courseID, &archive)
+	t.Require().Nil(err)
+
+	files := readArchiveFiles(t, archive.Bytes())
+	var manifest map[string]interface{}
+	t.Require().Nil(json.Unmarshal(files["course.json"], &manifest))
+	t.Assert().Equal(float64(1), manifest["format_version"])
+	t.Assert().Len(manifest["lessons"], 2)
+	t.Assert().Equal("png", string(files["media/001_image.png"]))
+	t.Assert().Contains(string(files["lessons/01/theory.md"]), "![image](media/001_image.png)")
+	t.Assert().Contains(string(files["lessons/01/theory.md"]), "[link](https://example.com/page)")
+	t.Assert().Contains(string(files["lessons/02/tests/01.md"]), "src=\"media/001_image.png\"")
+}
+
+func CourseArchiveImportSuccessRepositoryMock(courseRepository *mocks.CourseRepository,
+	lessonRepository *mocks.LessonRepository, storage *mocks.ObjectStorage,
+	schoolID domain.ID, saved map[string]string) {
+	storage.
+		On("SaveFile", context.Background(), mock.AnythingOfType("domain.File")).
+		Return(func(ctx context.Context, file domain.File) domain.Url {
+			data, _ := io.ReadAll(file.Reader)
+			name := filepath.Join(file.Path, file.Name)
+			saved[name] = string(data)
+			return domain.Url("http://copy/" + name)
+		}, nil)
+	courseRepository.
+		On("Create", context.Background(), mock.MatchedBy(func(course domain.Course) bool {
+			return course.SchoolID == schoolID && course.Status == domain.CourseDraft &&
+				course.Version == 1 && course.Capacity.Int64 == 30
+		})).
+		Return(func(ctx context.Context, course domain.Course) domain.Course {
+			return course
+		}, nil)
+	lessonRepository.
+		On("CreateCourseLessons", context.Background(), mock.MatchedBy(func(lessons []domain.Lesson) bool {
+			return len(lessons) == 2 && lessons[0].ReleaseDays.Int64 == 3 &&
+				len(lessons[1].Tests) == 1
+		})).
+		Return(nil)
+}
+
+func (s *CourseArchiveSuite) TestImportCourse_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course archive service imports exported course")
+	courseID := domain.NewID()
+	schoolID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	archiveService := service.NewCourseArchiveService(courseRepository, lessonRepository,
+		objectStorage, s.logger)
+	CourseArchiveExportSuccessRepositoryMock(courseRepository, lessonRepository, objectStorage, courseID)
+	var archive bytes.Buffer
+	t.Require().Nil(archiveService.ExportCourse(context.Background(), courseID, &archive))
+
+	saved := make(map[string]string)
+	CourseArchiveImportSuccessRepositoryMock(courseRepository, lessonRepository, objectStorage,
+		schoolID, saved)
+	course, err := archiveService.ImportCourse(context.Background(), schoolID,
+		bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	t.Require().Nil(err)
+	t.Assert().NotEqual(courseID, course.ID)
+	t.Assert().Equal(schoolID, course.SchoolID)
+	t.Assert().Equal(domain.CourseDraft, course.Status)
+	// media is saved once and theory links point to the saved copy
+	t.Require().Len(saved, 3)
+	for name, content := range saved {
+		if strings.HasPrefix(name, "course/"+course.ID.String()) &&
+			strings.HasPrefix(content, "# theory") {
+			t.Assert().Contains(content, "![image](http://copy/media/")
+			t.Assert().Contains(content, "[link](https://example.com/page)")
+		}
+	}
+}
+
+func (s *CourseArchiveSuite) TestImportCourse_InvalidArchive(t provider.T) {
+	t.Parallel()
+	t.Title("Course archive service rejects invalid archive")
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	archiveService := service.NewCourseArchiveService(courseRepository, lessonRepository,
+		objectStorage, s.logger)
+	data := []byte("not a zip archive")
+	_, err := archiveService.ImportCourse(context.Background(), domain.NewID(),
+		bytes.NewReader(data), int64(len(data)))
+	t.Assert().ErrorIs(err, errs.ErrCourseArchiveInvalid)
+}
+
+func (s *CourseArchiveSuite) TestImportCourse_UnsupportedVersion(t provider.T) {
+	t.Parallel()
+	t.Title("Course archive service rejects archive of newer format")
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	archiveService := service.NewCourseArchiveService(courseRepository, lessonRepository,
+		objectStorage, s.logger)
+	data := writeArchiveFiles(t, map[string]string{"course.json": `{"format_version": 99}`})
+	_, err := archiveService.ImportCourse(context.Background(), domain.NewID(),
+		bytes.NewReader(data), int64(len(data)))
+	t.Assert().ErrorIs(err, errs.ErrCourseArchiveVersion)
+}
+
+func (s *CourseArchiveSuite) TestImportCourse_MissingFile(t provider.T) {
+	t.Parallel()
+	t.Title("Course archive service rejects archive without lesson markdown")
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	archiveService := service.NewCourseArchiveService(courseRepository, lessonRepository,
+		objectStorage, s.logger)
+	data := writeArchiveFiles(t, map[string]string{"course.json": `{"format_version": 1,
+		"course": {"name": "course", "level": 1, "price": 0, "currency": "RUB"},
+		"lessons": [{"title": "title", "score": 10, "type": "theory", "theory": "lessons/01/theory.md"}]}`})
+	_, err := archiveService.ImportCourse(context.Background(), domain.NewID(),
+		bytes.NewReader(data), int64(len(data)))
+	t.Assert().ErrorIs(err, errs.ErrCourseArchiveMissingFile)
+}
+
+func CourseArchiveImportLessonsFailureRepositoryMock(courseRepository *mocks.CourseRepository,
+	lessonRepository *mocks.LessonRepository) {
+	courseRepository.
+		On("Create", context.Background(), mock.AnythingOfType("domain.Course")).
+		Return(func(ctx context.Context, course domain.Course) domain.Course {
+			return course
+		}, nil)
+	lessonRepository.
+		On("CreateCourseLessons", context.Background(), mock.AnythingOfType("[]domain.Lesson")).
+		Return(errs.ErrTransactionError)
+	courseRepository.
+		On("Delete", context.Background(), mock.AnythingOfType("domain.ID")).
+		Return(nil)
+}
+
+func (s *CourseArchiveSuite) TestImportCourse_LessonsFailure(t provider.T) {
+	t.Parallel()
+	t.Title("Course archive service removes course when lessons are not created")
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	archiveService := service.NewCourseArchiveService(courseRepository, lessonRepository,
+		objectStorage, s.logger)
+	CourseArchiveImportLessonsFailureRepositoryMock(courseRepository, lessonRepository)
+	data := writeArchiveFiles(t, map[string]string{"course.json": `{"format_version": 1,
+		"course": {"name": "course", "level": 1, "price": 0, "currency": "RUB"},
+		"lessons": [{"title": "title", "score": 10, "type": "video", "video_url": "https://video"}]}`})
+	_, err := archiveService.ImportCourse(context.Background(), domain.NewID(),
+		bytes.NewReader(data), int64(len(data)))
+	t.Assert().ErrorIs(err, errs.ErrTransactionError)
+}
+
+func TestCourseArchiveSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course archive service", new(CourseArchiveSuite))
+}
+
+func readArchiveFiles(t provider.T, data []byte) map[string][]byte {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	t.Require().Nil(err)
+	files := make(map[string][]byte)
+	for _, file := range reader.File {
+		fileReader, err := file.Open()
+		t.Require().Nil(err)
+		files[file.Name], err = io.ReadAll(fileReader)
+		t.Require().Nil(err)
+		fileReader.Close()
+	}
+	return files
+}
+
+func writeArchiveFiles(t provider.T, files map[string]string) []byte {
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for name, content := range files {
+		fileWriter, err := writer.Create(name)
+		t.Require().Nil(err)
+		_, err = fileWriter.Write([]byte(content))
+		t.Require().Nil(err)
+	}
+	t.Require().Nil(writer.Close())
+	return archive.Bytes()
+}
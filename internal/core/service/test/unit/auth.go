@@ -0,0 +1,103 @@
+package unit
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type EmailTokenBuilder struct {
+	token domain.EmailToken
+}
+
+func NewEmailTokenBuilder() *EmailTokenBuilder {
+	return &EmailTokenBuilder{
+		token: domain.EmailToken{
+			ID:        domain.NewID(),
+			UserID:    domain.NewID(),
+			Purpose:   domain.EmailVerificationPurpose,
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func (b *EmailTokenBuilder) WithUserID(userID domain.ID) *EmailTokenBuilder {
+	b.token.UserID = userID
+	return b
+}
+
+func (b *EmailTokenBuilder) WithPurpose(purpose domain.EmailTokenPurpose) *EmailTokenBuilder {
+	b.token.Purpose = purpose
+	return b
+}
+
+func (b *EmailTokenBuilder) Build() domain.EmailToken {
+	return b.token
+}
+
+type MfaSettingsBuilder struct {
+	settings domain.MfaSettings
+}
+
+func NewMfaSettingsBuilder() *MfaSettingsBuilder {
+	return &MfaSettingsBuilder{
+		settings: domain.MfaSettings{
+			UserID:  domain.NewID(),
+			Secret:  "JBSWY3DPEHPK3PXP",
+			Enabled: false,
+		},
+	}
+}
+
+func (b *MfaSettingsBuilder) WithEnabled(enabled bool) *MfaSettingsBuilder {
+	b.settings.Enabled = enabled
+	return b
+}
+
+func (b *MfaSettingsBuilder) Build() domain.MfaSettings {
+	return b.settings
+}
+
+type ExternalProfileBuilder struct {
+	profile domain.ExternalProfile
+}
+
+func NewExternalProfileBuilder() *ExternalProfileBuilder {
+	return &ExternalProfileBuilder{
+		profile: domain.ExternalProfile{
+			Provider:      "corp",
+			Subject:       "subject",
+			Email:         "john.doe@corp.com",
+			EmailVerified: true,
+			Name:          "John",
+			Surname:       "Doe",
+		},
+	}
+}
+
+func (b *ExternalProfileBuilder) WithEmailVerified(verified bool) *ExternalProfileBuilder {
+	b.profile.EmailVerified = verified
+	return b
+}
+
+func (b *ExternalProfileBuilder) Build() domain.ExternalProfile {
+	return b.profile
+}
+
+type ExternalIdentityBuilder struct {
+	identity domain.ExternalIdentity
+}
+
+func NewExternalIdentityBuilder() *ExternalIdentityBuilder {
+	return &ExternalIdentityBuilder{
+		identity: domain.ExternalIdentity{
+			UserID:   domain.NewID(),
+			Provider: "corp",
+			Subject:  "subject",
+			Email:    "john.doe@corp.com",
+		},
+	}
+}
+
+func (b *ExternalIdentityBuilder) Build() domain.ExternalIdentity {
+	return b.identity
+}
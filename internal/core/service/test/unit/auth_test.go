@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 	"testing"
+	"time"
 )
 
 type AuthSuite struct {
@@ -30,10 +31,20 @@ type AuthSignInSuite struct {
 	UserSuite
 }
 
-func AuthSignInSuccessRepositoryMock(repository *mocks.UserRepository, provider *mocks.AuthProvider) {
+func AuthSignInSuccessRepositoryMock(repository *mocks.UserRepository, mfaRepository *mocks.MfaRepository,
+	provider *mocks.AuthProvider, limiter *mocks.RateLimiter) {
+	limiter.
+		On("Check", context.Background(), mock.Anything).
+		Return(time.Duration(0), nil)
+	limiter.
+		On("Reset", context.Background(), "sign-in:email:email").
+		Return(nil)
 	repository.
 		On("FindByCredentials", context.Background(), mock.Anything, mock.Anything).
 		Return(NewUserBuilder().Build(), nil)
+	mfaRepository.
+		On("FindByUserID", context.Background(), mock.Anything).
+		Return(domain.MfaSettings{}, errs.ErrNotExist)
 	provider.
 		On("CreateJWTSession", mock.Anything, mock.Anything).
 		Return(domain.AuthDetails{}, nil)
@@ -43,32 +54,135 @@ func (s *AuthSignInSuite) TestSignIn_Success(t provider.T) {
 	t.Parallel()
 	t.Title("Auth service sign in success")
 	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
 	provider := mocks.NewAuthProvider(t)
-	authService := service.NewAuthTokenService(provider, userRepository, s.logger)
-	AuthSignInSuccessRepositoryMock(userRepository, provider)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthSignInSuccessRepositoryMock(userRepository, mfaRepository, provider, limiter)
 	_, err := authService.SignIn(context.Background(),
 		port.SignInParam{Email: "email", Password: "password"})
 	t.Assert().Nil(err)
 }
 
-func AuthSignInFailureRepositoryMock(repository *mocks.UserRepository, provider *mocks.AuthProvider) {
+func AuthSignInMfaRequiredRepositoryMock(repository *mocks.UserRepository, mfaRepository *mocks.MfaRepository,
+	provider *mocks.AuthProvider, limiter *mocks.RateLimiter) {
+	limiter.
+		On("Check", context.Background(), mock.Anything).
+		Return(time.Duration(0), nil)
+	limiter.
+		On("Reset", context.Background(), "sign-in:email:email").
+		Return(nil)
+	repository.
+		On("FindByCredentials", context.Background(), mock.Anything, mock.Anything).
+		Return(NewUserBuilder().Build(), nil)
+	mfaRepository.
+		On("FindByUserID", context.Background(), mock.Anything).
+		Return(NewMfaSettingsBuilder().WithEnabled(true).Build(), nil)
+	provider.
+		On("CreateMfaToken", mock.Anything).
+		Return(domain.Token("mfa token"), nil)
+}
+
+func (s *AuthSignInSuite) TestSignIn_MfaRequired(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service sign in with enabled mfa")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthSignInMfaRequiredRepositoryMock(userRepository, mfaRepository, provider, limiter)
+	details, err := authService.SignIn(context.Background(),
+		port.SignInParam{Email: "email", Password: "password"})
+	t.Assert().Nil(err)
+	t.Assert().True(details.MfaRequired())
+	t.Assert().Empty(details.AccessToken)
+}
+
+func AuthSignInFailureRepositoryMock(repository *mocks.UserRepository, provider *mocks.AuthProvider,
+	limiter *mocks.RateLimiter) {
+	limiter.
+		On("Check", context.Background(), mock.Anything).
+		Return(time.Duration(0), nil)
 	repository.
 		On("FindByCredentials", context.Background(), mock.Anything, mock.Anything).
 		Return(domain.User{}, errs.ErrInvalidCredentials)
+	limiter.
+		On("Hit", context.Background(), "sign-in:email:email").
+		Return(time.Duration(0), nil)
+	limiter.
+		On("Hit", context.Background(), "sign-in:ip:127.0.0.1").
+		Return(time.Minute, nil)
 }
 
 func (s *AuthSignInSuite) TestSignIn_Failure(t provider.T) {
 	t.Parallel()
 	t.Title("Auth service sign in failure")
 	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
 	provider := mocks.NewAuthProvider(t)
-	authService := service.NewAuthTokenService(provider, userRepository, s.logger)
-	AuthSignInFailureRepositoryMock(userRepository, provider)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthSignInFailureRepositoryMock(userRepository, provider, limiter)
 	_, err := authService.SignIn(context.Background(),
-		port.SignInParam{Email: "email", Password: "password"})
+		port.SignInParam{Email: "email", Password: "password", IP: "127.0.0.1"})
 	t.Assert().ErrorIs(err, errs.ErrInvalidCredentials)
 }
 
+func AuthSignInTooManyAttemptsRepositoryMock(limiter *mocks.RateLimiter) {
+	limiter.
+		On("Check", context.Background(), "sign-in:email:email").
+		Return(time.Duration(0), nil)
+	limiter.
+		On("Check", context.Background(), "sign-in:ip:127.0.0.1").
+		Return(time.Minute, nil)
+}
+
+func (s *AuthSignInSuite) TestSignIn_TooManyAttempts(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service sign in with locked out ip")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthSignInTooManyAttemptsRepositoryMock(limiter)
+	_, err := authService.SignIn(context.Background(),
+		port.SignInParam{Email: "email", Password: "password", IP: "127.0.0.1"})
+	t.Assert().ErrorIs(err, errs.ErrTooManyAttempts)
+	var retryAfterErr *errs.RetryAfterError
+	t.Require().ErrorAs(err, &retryAfterErr)
+	t.Assert().Equal(time.Minute, retryAfterErr.RetryAfter)
+}
+
 func TestAuthSignInSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Auth service sign in", new(AuthSignInSuite))
 }
@@ -78,22 +192,41 @@ type AuthSignUpSuite struct {
 	UserSuite
 }
 
-func AuthSignUpSuccessRepositoryMock(repository *mocks.UserRepository) {
+func AuthSignUpSuccessRepositoryMock(repository *mocks.UserRepository, tokenRepository *mocks.EmailTokenRepository,
+	mailer *mocks.Mailer, provider *mocks.AuthProvider) {
 	repository.
 		On("FindByEmail", context.Background(), mock.Anything).
 		Return(domain.User{}, errs.ErrNotUniqueEmail)
 	repository.
 		On("Create", context.Background(), mock.Anything).
 		Return(NewUserBuilder().Build(), nil)
+	provider.
+		On("CreateEmailToken", mock.Anything, domain.EmailVerificationPurpose).
+		Return(domain.EmailToken{}, domain.Token("token"), nil)
+	tokenRepository.
+		On("Create", context.Background(), mock.Anything).
+		Return(nil)
+	mailer.
+		On("Send", context.Background(), mock.Anything).
+		Return(nil)
 }
 
 func (s *AuthSignUpSuite) TestSignUp_Success(t provider.T) {
 	t.Parallel()
 	t.Title("Auth service sign up success")
 	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
 	provider := mocks.NewAuthProvider(t)
-	authService := service.NewAuthTokenService(provider, userRepository, s.logger)
-	AuthSignUpSuccessRepositoryMock(userRepository)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthSignUpSuccessRepositoryMock(userRepository, tokenRepository, mailer, provider)
 	err := authService.SignUp(context.Background(),
 		port.SignUpParam{Email: "email", Password: "password"})
 	t.Assert().Nil(err)
@@ -109,8 +242,17 @@ func (s *AuthSignUpSuite) TestSignUp_Failure(t provider.T) {
 	t.Parallel()
 	t.Title("Auth service sign up failure")
 	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
 	provider := mocks.NewAuthProvider(t)
-	authService := service.NewAuthTokenService(provider, userRepository, s.logger)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
 	AuthSignUpFailureRepositoryMock(userRepository)
 	err := authService.SignUp(context.Background(),
 		port.SignUpParam{Email: "email", Password: "password"})
@@ -136,8 +278,17 @@ func (s *AuthLogOutSuite) TestLogOut_Success(t provider.T) {
 	t.Parallel()
 	t.Title("Auth service log out success")
 	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
 	provider := mocks.NewAuthProvider(t)
-	authService := service.NewAuthTokenService(provider, userRepository, s.logger)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
 	AuthLogOutSuccessRepositoryMock(provider)
 	err := authService.LogOut(context.Background(), "token")
 	t.Assert().Nil(err)
@@ -153,8 +304,17 @@ func (s *AuthLogOutSuite) TestLogOut_Failure(t provider.T) {
 	t.Parallel()
 	t.Title("Auth service log out failure")
 	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
 	provider := mocks.NewAuthProvider(t)
-	authService := service.NewAuthTokenService(provider, userRepository, s.logger)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
 	AuthLogOutFailureRepositoryMock(userRepository, provider)
 	err := authService.LogOut(context.Background(), "token")
 	t.Assert().ErrorIs(err, errs.ErrDeleteFailed)
@@ -179,8 +339,17 @@ func (s *AuthRefreshSuite) TestRefresh_Success(t provider.T) {
 	t.Parallel()
 	t.Title("Auth service refresh token success")
 	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
 	provider := mocks.NewAuthProvider(t)
-	authService := service.NewAuthTokenService(provider, userRepository, s.logger)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
 	AuthRefreshSuccessRepositoryMock(provider)
 	_, err := authService.Refresh(context.Background(), "token", "fingerprint")
 	t.Assert().Nil(err)
@@ -196,8 +365,17 @@ func (s *AuthRefreshSuite) TestRefresh_Failure(t provider.T) {
 	t.Parallel()
 	t.Title("Auth service refresh token failure")
 	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
 	provider := mocks.NewAuthProvider(t)
-	authService := service.NewAuthTokenService(provider, userRepository, s.logger)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
 	AuthRefreshFailureRepositoryMock(userRepository, provider)
 	_, err := authService.Refresh(context.Background(), "token", "fingerprint")
 	t.Assert().ErrorIs(err, errs.ErrAuthSessionIsNotPresent)
@@ -222,8 +400,17 @@ func (s *AuthVerifySuite) TestVerify_Success(t provider.T) {
 	t.Parallel()
 	t.Title("Auth service verify token success")
 	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
 	provider := mocks.NewAuthProvider(t)
-	authService := service.NewAuthTokenService(provider, userRepository, s.logger)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
 	AuthVerifySuccessRepositoryMock(provider)
 	err := authService.Verify(context.Background(), "token")
 	t.Assert().Nil(err)
@@ -239,8 +426,17 @@ func (s *AuthVerifySuite) TestVerify_Failure(t provider.T) {
 	t.Parallel()
 	t.Title("Auth service verify token failure")
 	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
 	provider := mocks.NewAuthProvider(t)
-	authService := service.NewAuthTokenService(provider, userRepository, s.logger)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
 	AuthVerifyFailureRepositoryMock(userRepository, provider)
 	err := authService.Verify(context.Background(), "token")
 	t.Assert().ErrorIs(err, errs.ErrInvalidTokenSignMethod)
@@ -265,8 +461,17 @@ func (s *AuthPayloadSuite) TestPayload_Success(t provider.T) {
 	t.Parallel()
 	t.Title("Auth service payload success")
 	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
 	provider := mocks.NewAuthProvider(t)
-	authService := service.NewAuthTokenService(provider, userRepository, s.logger)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
 	AuthPayloadSuccessRepositoryMock(provider)
 	_, err := authService.Payload(context.Background(), "token")
 	t.Assert().Nil(err)
@@ -282,8 +487,17 @@ func (s *AuthPayloadSuite) TestPayload_Failure(t provider.T) {
 	t.Parallel()
 	t.Title("Auth service payload failure")
 	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
 	provider := mocks.NewAuthProvider(t)
-	authService := service.NewAuthTokenService(provider, userRepository, s.logger)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
 	AuthPayloadFailureRepositoryMock(userRepository, provider)
 	_, err := authService.Payload(context.Background(), "token")
 	t.Assert().ErrorIs(err, errs.ErrInvalidTokenSignMethod)
@@ -292,3 +506,791 @@ func (s *AuthPayloadSuite) TestPayload_Failure(t provider.T) {
 func TestAuthPayloadSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Auth service payload", new(AuthPayloadSuite))
 }
+
+// VerifyEmail Suite
+type AuthVerifyEmailSuite struct {
+	UserSuite
+}
+
+func AuthVerifyEmailSuccessRepositoryMock(repository *mocks.UserRepository,
+	tokenRepository *mocks.EmailTokenRepository, provider *mocks.AuthProvider) {
+	provider.
+		On("VerifyEmailToken", mock.Anything).
+		Return(NewEmailTokenBuilder().Build(), nil)
+	tokenRepository.
+		On("Use", context.Background(), mock.Anything).
+		Return(nil)
+	repository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().Build(), nil)
+	repository.
+		On("Update", context.Background(), mock.MatchedBy(func(user domain.User) bool {
+			return user.EmailVerified
+		})).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+}
+
+func (s *AuthVerifyEmailSuite) TestVerifyEmail_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service verify email success")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthVerifyEmailSuccessRepositoryMock(userRepository, tokenRepository, provider)
+	err := authService.VerifyEmail(context.Background(), "token")
+	t.Assert().Nil(err)
+}
+
+func AuthVerifyEmailFailureRepositoryMock(tokenRepository *mocks.EmailTokenRepository,
+	provider *mocks.AuthProvider) {
+	provider.
+		On("VerifyEmailToken", mock.Anything).
+		Return(NewEmailTokenBuilder().Build(), nil)
+	tokenRepository.
+		On("Use", context.Background(), mock.Anything).
+		Return(errs.ErrNotExist)
+}
+
+func (s *AuthVerifyEmailSuite) TestVerifyEmail_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service verify email failure")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthVerifyEmailFailureRepositoryMock(tokenRepository, provider)
+	err := authService.VerifyEmail(context.Background(), "token")
+	t.Assert().ErrorIs(err, errs.ErrInvalidEmailToken)
+}
+
+func TestAuthVerifyEmailSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Auth service verify email", new(AuthVerifyEmailSuite))
+}
+
+// ForgotPassword Suite
+type AuthForgotPasswordSuite struct {
+	UserSuite
+}
+
+func AuthForgotPasswordSuccessRepositoryMock(repository *mocks.UserRepository,
+	tokenRepository *mocks.EmailTokenRepository, mailer *mocks.Mailer, provider *mocks.AuthProvider) {
+	repository.
+		On("FindByEmail", context.Background(), mock.Anything).
+		Return(NewUserBuilder().Build(), nil)
+	provider.
+		On("CreateEmailToken", mock.Anything, domain.PasswordResetPurpose).
+		Return(domain.EmailToken{}, domain.Token("token"), nil)
+	tokenRepository.
+		On("Create", context.Background(), mock.Anything).
+		Return(nil)
+	mailer.
+		On("Send", context.Background(), mock.Anything).
+		Return(nil)
+}
+
+func (s *AuthForgotPasswordSuite) TestForgotPassword_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service forgot password success")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthForgotPasswordSuccessRepositoryMock(userRepository, tokenRepository, mailer, provider)
+	err := authService.ForgotPassword(context.Background(), "email")
+	t.Assert().Nil(err)
+}
+
+func AuthForgotPasswordFailureRepositoryMock(repository *mocks.UserRepository,
+	tokenRepository *mocks.EmailTokenRepository, mailer *mocks.Mailer, provider *mocks.AuthProvider) {
+	repository.
+		On("FindByEmail", context.Background(), mock.Anything).
+		Return(NewUserBuilder().Build(), nil)
+	provider.
+		On("CreateEmailToken", mock.Anything, domain.PasswordResetPurpose).
+		Return(domain.EmailToken{}, domain.Token("token"), nil)
+	tokenRepository.
+		On("Create", context.Background(), mock.Anything).
+		Return(nil)
+	mailer.
+		On("Send", context.Background(), mock.Anything).
+		Return(errs.ErrPersistenceFailed)
+}
+
+func (s *AuthForgotPasswordSuite) TestForgotPassword_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service forgot password failure")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthForgotPasswordFailureRepositoryMock(userRepository, tokenRepository, mailer, provider)
+	err := authService.ForgotPassword(context.Background(), "email")
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestAuthForgotPasswordSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Auth service forgot password", new(AuthForgotPasswordSuite))
+}
+
+// ResetPassword Suite
+type AuthResetPasswordSuite struct {
+	UserSuite
+}
+
+func AuthResetPasswordSuccessRepositoryMock(repository *mocks.UserRepository,
+	tokenRepository *mocks.EmailTokenRepository, provider *mocks.AuthProvider) {
+	provider.
+		On("VerifyEmailToken", mock.Anything).
+		Return(NewEmailTokenBuilder().WithPurpose(domain.PasswordResetPurpose).Build(), nil)
+	tokenRepository.
+		On("Use", context.Background(), mock.Anything).
+		Return(nil)
+	repository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().Build(), nil)
+	repository.
+		On("Update", context.Background(), mock.MatchedBy(func(user domain.User) bool {
+			return user.Password == "new password"
+		})).
+		Return(NewUserBuilder().WithPassword("new password").Build(), nil)
+}
+
+func (s *AuthResetPasswordSuite) TestResetPassword_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service reset password success")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthResetPasswordSuccessRepositoryMock(userRepository, tokenRepository, provider)
+	err := authService.ResetPassword(context.Background(),
+		port.ResetPasswordParam{Token: "token", Password: "new password"})
+	t.Assert().Nil(err)
+}
+
+func AuthResetPasswordFailureRepositoryMock(provider *mocks.AuthProvider) {
+	provider.
+		On("VerifyEmailToken", mock.Anything).
+		Return(NewEmailTokenBuilder().WithPurpose(domain.EmailVerificationPurpose).Build(), nil)
+}
+
+func (s *AuthResetPasswordSuite) TestResetPassword_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service reset password failure")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthResetPasswordFailureRepositoryMock(provider)
+	err := authService.ResetPassword(context.Background(),
+		port.ResetPasswordParam{Token: "token", Password: "new password"})
+	t.Assert().ErrorIs(err, errs.ErrInvalidEmailToken)
+}
+
+func TestAuthResetPasswordSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Auth service reset password", new(AuthResetPasswordSuite))
+}
+
+// EnrollMfa Suite
+type AuthEnrollMfaSuite struct {
+	UserSuite
+}
+
+func AuthEnrollMfaSuccessRepositoryMock(repository *mocks.UserRepository, mfaRepository *mocks.MfaRepository,
+	otpProvider *mocks.OtpProvider) {
+	repository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().Build(), nil)
+	mfaRepository.
+		On("FindByUserID", context.Background(), mock.Anything).
+		Return(domain.MfaSettings{}, errs.ErrNotExist)
+	otpProvider.
+		On("GenerateSecret").
+		Return("SECRET", nil)
+	otpProvider.
+		On("GenerateRecoveryCodes").
+		Return([]string{"code1", "code2"}, nil)
+	otpProvider.
+		On("ProvisioningUri", "SECRET", mock.Anything).
+		Return("otpauth://totp/eschool")
+	mfaRepository.
+		On("Save", context.Background(), mock.MatchedBy(func(settings domain.MfaSettings) bool {
+			return settings.Secret == "SECRET" && !settings.Enabled
+		})).
+		Return(nil)
+	mfaRepository.
+		On("ReplaceRecoveryCodes", context.Background(), mock.Anything, mock.MatchedBy(func(hashes []string) bool {
+			return len(hashes) == 2 && hashes[0] != "code1"
+		})).
+		Return(nil)
+}
+
+func (s *AuthEnrollMfaSuite) TestEnrollMfa_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service enroll mfa success")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthEnrollMfaSuccessRepositoryMock(userRepository, mfaRepository, otpProvider)
+	enrollment, err := authService.EnrollMfa(context.Background(), domain.NewID())
+	t.Assert().Nil(err)
+	t.Assert().Equal("otpauth://totp/eschool", enrollment.ProvisioningUri)
+	t.Assert().Equal([]string{"code1", "code2"}, enrollment.RecoveryCodes)
+}
+
+func AuthEnrollMfaFailureRepositoryMock(repository *mocks.UserRepository, mfaRepository *mocks.MfaRepository) {
+	repository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().Build(), nil)
+	mfaRepository.
+		On("FindByUserID", context.Background(), mock.Anything).
+		Return(NewMfaSettingsBuilder().WithEnabled(true).Build(), nil)
+}
+
+func (s *AuthEnrollMfaSuite) TestEnrollMfa_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service enroll mfa failure")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthEnrollMfaFailureRepositoryMock(userRepository, mfaRepository)
+	_, err := authService.EnrollMfa(context.Background(), domain.NewID())
+	t.Assert().ErrorIs(err, errs.ErrMfaAlreadyEnabled)
+}
+
+func TestAuthEnrollMfaSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Auth service enroll mfa", new(AuthEnrollMfaSuite))
+}
+
+// ConfirmMfa Suite
+type AuthConfirmMfaSuite struct {
+	UserSuite
+}
+
+func AuthConfirmMfaSuccessRepositoryMock(mfaRepository *mocks.MfaRepository, otpProvider *mocks.OtpProvider) {
+	mfaRepository.
+		On("FindByUserID", context.Background(), mock.Anything).
+		Return(NewMfaSettingsBuilder().Build(), nil)
+	otpProvider.
+		On("ValidateCode", mock.Anything, "123456").
+		Return(true)
+	mfaRepository.
+		On("Save", context.Background(), mock.MatchedBy(func(settings domain.MfaSettings) bool {
+			return settings.Enabled
+		})).
+		Return(nil)
+}
+
+func (s *AuthConfirmMfaSuite) TestConfirmMfa_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service confirm mfa success")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthConfirmMfaSuccessRepositoryMock(mfaRepository, otpProvider)
+	err := authService.ConfirmMfa(context.Background(), domain.NewID(), "123456")
+	t.Assert().Nil(err)
+}
+
+func AuthConfirmMfaFailureRepositoryMock(mfaRepository *mocks.MfaRepository, otpProvider *mocks.OtpProvider) {
+	mfaRepository.
+		On("FindByUserID", context.Background(), mock.Anything).
+		Return(NewMfaSettingsBuilder().Build(), nil)
+	otpProvider.
+		On("ValidateCode", mock.Anything, "000000").
+		Return(false)
+}
+
+func (s *AuthConfirmMfaSuite) TestConfirmMfa_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service confirm mfa failure")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthConfirmMfaFailureRepositoryMock(mfaRepository, otpProvider)
+	err := authService.ConfirmMfa(context.Background(), domain.NewID(), "000000")
+	t.Assert().ErrorIs(err, errs.ErrInvalidMfaCode)
+}
+
+func TestAuthConfirmMfaSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Auth service confirm mfa", new(AuthConfirmMfaSuite))
+}
+
+// DisableMfa Suite
+type AuthDisableMfaSuite struct {
+	UserSuite
+}
+
+func AuthDisableMfaSuccessRepositoryMock(mfaRepository *mocks.MfaRepository, otpProvider *mocks.OtpProvider) {
+	mfaRepository.
+		On("FindByUserID", context.Background(), mock.Anything).
+		Return(NewMfaSettingsBuilder().WithEnabled(true).Build(), nil)
+	otpProvider.
+		On("ValidateCode", mock.Anything, "123456").
+		Return(true)
+	mfaRepository.
+		On("Delete", context.Background(), mock.Anything).
+		Return(nil)
+}
+
+func (s *AuthDisableMfaSuite) TestDisableMfa_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service disable mfa success")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthDisableMfaSuccessRepositoryMock(mfaRepository, otpProvider)
+	err := authService.DisableMfa(context.Background(), domain.NewID(), "123456")
+	t.Assert().Nil(err)
+}
+
+func AuthDisableMfaFailureRepositoryMock(mfaRepository *mocks.MfaRepository) {
+	mfaRepository.
+		On("FindByUserID", context.Background(), mock.Anything).
+		Return(domain.MfaSettings{}, errs.ErrNotExist)
+}
+
+func (s *AuthDisableMfaSuite) TestDisableMfa_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service disable mfa failure")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthDisableMfaFailureRepositoryMock(mfaRepository)
+	err := authService.DisableMfa(context.Background(), domain.NewID(), "123456")
+	t.Assert().ErrorIs(err, errs.ErrMfaNotEnrolled)
+}
+
+func TestAuthDisableMfaSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Auth service disable mfa", new(AuthDisableMfaSuite))
+}
+
+// VerifyMfa Suite
+type AuthVerifyMfaSuite struct {
+	UserSuite
+}
+
+func AuthVerifyMfaSuccessRepositoryMock(mfaRepository *mocks.MfaRepository, provider *mocks.AuthProvider,
+	otpProvider *mocks.OtpProvider, limiter *mocks.RateLimiter) {
+	provider.
+		On("VerifyMfaToken", domain.Token("mfa token")).
+		Return(domain.NewID(), nil)
+	limiter.
+		On("Check", context.Background(), mock.Anything).
+		Return(time.Duration(0), nil)
+	mfaRepository.
+		On("FindByUserID", context.Background(), mock.Anything).
+		Return(NewMfaSettingsBuilder().WithEnabled(true).Build(), nil)
+	otpProvider.
+		On("ValidateCode", mock.Anything, "recovery").
+		Return(false)
+	mfaRepository.
+		On("UseRecoveryCode", context.Background(), mock.Anything, mock.Anything).
+		Return(nil)
+	limiter.
+		On("Reset", context.Background(), mock.Anything).
+		Return(nil)
+	provider.
+		On("CreateJWTSession", mock.Anything, "fingerprint").
+		Return(domain.AuthDetails{AccessToken: "access", RefreshToken: "refresh"}, nil)
+}
+
+func (s *AuthVerifyMfaSuite) TestVerifyMfa_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service verify mfa with recovery code success")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthVerifyMfaSuccessRepositoryMock(mfaRepository, provider, otpProvider, limiter)
+	details, err := authService.VerifyMfa(context.Background(), port.MfaVerifyParam{
+		MfaToken:    "mfa token",
+		Code:        "recovery",
+		Fingerprint: "fingerprint",
+	})
+	t.Assert().Nil(err)
+	t.Assert().Equal(domain.Token("access"), details.AccessToken)
+}
+
+func AuthVerifyMfaFailureRepositoryMock(mfaRepository *mocks.MfaRepository, provider *mocks.AuthProvider,
+	otpProvider *mocks.OtpProvider, limiter *mocks.RateLimiter) {
+	provider.
+		On("VerifyMfaToken", domain.Token("mfa token")).
+		Return(domain.NewID(), nil)
+	limiter.
+		On("Check", context.Background(), mock.Anything).
+		Return(time.Duration(0), nil)
+	mfaRepository.
+		On("FindByUserID", context.Background(), mock.Anything).
+		Return(NewMfaSettingsBuilder().WithEnabled(true).Build(), nil)
+	otpProvider.
+		On("ValidateCode", mock.Anything, "000000").
+		Return(false)
+	mfaRepository.
+		On("UseRecoveryCode", context.Background(), mock.Anything, mock.Anything).
+		Return(errs.ErrNotExist)
+	limiter.
+		On("Hit", context.Background(), mock.Anything).
+		Return(time.Duration(0), nil)
+}
+
+func (s *AuthVerifyMfaSuite) TestVerifyMfa_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service verify mfa failure")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthVerifyMfaFailureRepositoryMock(mfaRepository, provider, otpProvider, limiter)
+	_, err := authService.VerifyMfa(context.Background(), port.MfaVerifyParam{
+		MfaToken:    "mfa token",
+		Code:        "000000",
+		Fingerprint: "fingerprint",
+	})
+	t.Assert().ErrorIs(err, errs.ErrInvalidMfaCode)
+}
+
+func TestAuthVerifyMfaSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Auth service verify mfa", new(AuthVerifyMfaSuite))
+}
+
+// OidcSignIn Suite
+type AuthOidcSignInSuite struct {
+	UserSuite
+}
+
+func AuthOidcSignInLinkedRepositoryMock(identityProvider *mocks.IdentityProvider,
+	identityRepository *mocks.ExternalIdentityRepository, mfaRepository *mocks.MfaRepository,
+	provider *mocks.AuthProvider, profile domain.ExternalProfile) {
+	identityProvider.
+		On("Exchange", context.Background(), "corp", "state", "code").
+		Return(profile, nil)
+	identityRepository.
+		On("FindByProviderSubject", context.Background(), "corp", profile.Subject).
+		Return(NewExternalIdentityBuilder().Build(), nil)
+	mfaRepository.
+		On("FindByUserID", context.Background(), mock.Anything).
+		Return(domain.MfaSettings{}, errs.ErrNotExist)
+	provider.
+		On("CreateJWTSession", mock.Anything, "fingerprint").
+		Return(domain.AuthDetails{AccessToken: "access", RefreshToken: "refresh"}, nil)
+}
+
+func (s *AuthOidcSignInSuite) TestOidcSignIn_Linked(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service oidc sign in with linked identity")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthOidcSignInLinkedRepositoryMock(identityProvider, identityRepository, mfaRepository, provider,
+		NewExternalProfileBuilder().Build())
+	details, err := authService.OidcSignIn(context.Background(), port.OidcSignInParam{
+		Provider:    "corp",
+		State:       "state",
+		Code:        "code",
+		Fingerprint: "fingerprint",
+	})
+	t.Assert().Nil(err)
+	t.Assert().Equal(domain.Token("access"), details.AccessToken)
+}
+
+func AuthOidcSignInNewUserRepositoryMock(identityProvider *mocks.IdentityProvider,
+	identityRepository *mocks.ExternalIdentityRepository, repository *mocks.UserRepository,
+	mfaRepository *mocks.MfaRepository, provider *mocks.AuthProvider, profile domain.ExternalProfile) {
+	identityProvider.
+		On("Exchange", context.Background(), "corp", "state", "code").
+		Return(profile, nil)
+	identityRepository.
+		On("FindByProviderSubject", context.Background(), "corp", profile.Subject).
+		Return(domain.ExternalIdentity{}, errs.ErrNotExist)
+	repository.
+		On("FindByEmail", context.Background(), profile.Email).
+		Return(domain.User{}, errs.ErrNotExist)
+	repository.
+		On("Create", context.Background(), mock.MatchedBy(func(user domain.User) bool {
+			return user.Email == profile.Email && user.EmailVerified && user.Password != ""
+		})).
+		Return(NewUserBuilder().WithEmail(profile.Email).WithEmailVerified(true).Build(), nil)
+	identityRepository.
+		On("Create", context.Background(), mock.MatchedBy(func(identity domain.ExternalIdentity) bool {
+			return identity.Provider == "corp" && identity.Subject == profile.Subject
+		})).
+		Return(nil)
+	mfaRepository.
+		On("FindByUserID", context.Background(), mock.Anything).
+		Return(domain.MfaSettings{}, errs.ErrNotExist)
+	provider.
+		On("CreateJWTSession", mock.Anything, "fingerprint").
+		Return(domain.AuthDetails{AccessToken: "access", RefreshToken: "refresh"}, nil)
+}
+
+func (s *AuthOidcSignInSuite) TestOidcSignIn_NewUser(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service oidc sign in creates new user")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthOidcSignInNewUserRepositoryMock(identityProvider, identityRepository, userRepository, mfaRepository,
+		provider, NewExternalProfileBuilder().Build())
+	details, err := authService.OidcSignIn(context.Background(), port.OidcSignInParam{
+		Provider:    "corp",
+		State:       "state",
+		Code:        "code",
+		Fingerprint: "fingerprint",
+	})
+	t.Assert().Nil(err)
+	t.Assert().Equal(domain.Token("access"), details.AccessToken)
+}
+
+func AuthOidcSignInUnverifiedUserRepositoryMock(identityProvider *mocks.IdentityProvider,
+	identityRepository *mocks.ExternalIdentityRepository, repository *mocks.UserRepository,
+	mfaRepository *mocks.MfaRepository, apiKeyRepository *mocks.ApiKeyRepository,
+	provider *mocks.AuthProvider, profile domain.ExternalProfile, user domain.User,
+	activeKey, revokedKey domain.ApiKey) {
+	identityProvider.
+		On("Exchange", context.Background(), "corp", "state", "code").
+		Return(profile, nil)
+	identityRepository.
+		On("FindByProviderSubject", context.Background(), "corp", profile.Subject).
+		Return(domain.ExternalIdentity{}, errs.ErrNotExist)
+	repository.
+		On("FindByEmail", context.Background(), profile.Email).
+		Return(user, nil)
+	repository.
+		On("Update", context.Background(), mock.MatchedBy(func(updated domain.User) bool {
+			return updated.ID == user.ID && updated.EmailVerified &&
+				updated.Password != "" && updated.Password != user.Password
+		})).
+		Return(user, nil)
+	mfaRepository.
+		On("Delete", context.Background(), user.ID).
+		Return(nil)
+	apiKeyRepository.
+		On("FindUserKeys", context.Background(), user.ID).
+		Return([]domain.ApiKey{activeKey, revokedKey}, nil)
+	apiKeyRepository.
+		On("Revoke", context.Background(), user.ID, activeKey.ID).
+		Return(nil)
+	provider.
+		On("DeleteUserJWTSessions", user.ID).
+		Return(nil)
+	identityRepository.
+		On("Create", context.Background(), mock.MatchedBy(func(identity domain.ExternalIdentity) bool {
+			return identity.UserID == user.ID && identity.Subject == profile.Subject
+		})).
+		Return(nil)
+	mfaRepository.
+		On("FindByUserID", context.Background(), user.ID).
+		Return(domain.MfaSettings{}, errs.ErrNotExist)
+	provider.
+		On("CreateJWTSession", domain.AuthPayload{UserID: user.ID}, "fingerprint").
+		Return(domain.AuthDetails{AccessToken: "access", RefreshToken: "refresh"}, nil)
+}
+
+func (s *AuthOidcSignInSuite) TestOidcSignIn_UnverifiedUser(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service oidc sign in claims unverified user with the same email")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	profile := NewExternalProfileBuilder().Build()
+	user := NewUserBuilder().WithID(domain.NewID()).WithEmail(profile.Email).
+		WithPassword("attacker-password").WithEmailVerified(false).Build()
+	AuthOidcSignInUnverifiedUserRepositoryMock(identityProvider, identityRepository, userRepository,
+		mfaRepository, apiKeyRepository, provider, profile, user,
+		NewApiKeyBuilder().Build(), NewApiKeyBuilder().WithRevokedAt(time.Now()).Build())
+	details, err := authService.OidcSignIn(context.Background(), port.OidcSignInParam{
+		Provider:    "corp",
+		State:       "state",
+		Code:        "code",
+		Fingerprint: "fingerprint",
+	})
+	t.Assert().Nil(err)
+	t.Assert().Equal(domain.Token("access"), details.AccessToken)
+}
+
+func AuthOidcSignInFailureRepositoryMock(identityProvider *mocks.IdentityProvider,
+	identityRepository *mocks.ExternalIdentityRepository, profile domain.ExternalProfile) {
+	identityProvider.
+		On("Exchange", context.Background(), "corp", "state", "code").
+		Return(profile, nil)
+	identityRepository.
+		On("FindByProviderSubject", context.Background(), "corp", profile.Subject).
+		Return(domain.ExternalIdentity{}, errs.ErrNotExist)
+}
+
+func (s *AuthOidcSignInSuite) TestOidcSignIn_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Auth service oidc sign in with unverified email")
+	userRepository := mocks.NewUserRepository(t)
+	tokenRepository := mocks.NewEmailTokenRepository(t)
+	mfaRepository := mocks.NewMfaRepository(t)
+	mailer := mocks.NewMailer(t)
+	provider := mocks.NewAuthProvider(t)
+	otpProvider := mocks.NewOtpProvider(t)
+	identityProvider := mocks.NewIdentityProvider(t)
+	identityRepository := mocks.NewExternalIdentityRepository(t)
+	apiKeyRepository := mocks.NewApiKeyRepository(t)
+	limiter := mocks.NewRateLimiter(t)
+	authService := service.NewAuthTokenService(provider, otpProvider, identityProvider, userRepository,
+		tokenRepository, mfaRepository, identityRepository, apiKeyRepository, mailer, limiter, s.logger)
+	AuthOidcSignInFailureRepositoryMock(identityProvider, identityRepository,
+		NewExternalProfileBuilder().WithEmailVerified(false).Build())
+	_, err := authService.OidcSignIn(context.Background(), port.OidcSignInParam{
+		Provider:    "corp",
+		State:       "state",
+		Code:        "code",
+		Fingerprint: "fingerprint",
+	})
+	t.Assert().ErrorIs(err, errs.ErrExternalEmailNotVerified)
+}
+
+func TestAuthOidcSignInSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Auth service oidc sign in", new(AuthOidcSignInSuite))
+}
@@ -0,0 +1,43 @@
+package unit
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type BundleBuilder struct {
+	bundle domain.Bundle
+}
+
+func NewBundleBuilder() *BundleBuilder {
+	return &BundleBuilder{
+		bundle: domain.Bundle{
+			ID:          domain.NewID(),
+			SchoolID:    domain.NewID(),
+			Name:        "bundle name",
+			Description: "bundle description",
+			Price:       8000,
+			Status:      domain.BundleDraft,
+			CreatedAt:   time.Now(),
+		},
+	}
+}
+
+func (b *BundleBuilder) WithSchoolID(schoolID domain.ID) *BundleBuilder {
+	b.bundle.SchoolID = schoolID
+	return b
+}
+
+func (b *BundleBuilder) WithPrice(price int64) *BundleBuilder {
+	b.bundle.Price = price
+	return b
+}
+
+func (b *BundleBuilder) WithStatus(status domain.BundleStatus) *BundleBuilder {
+	b.bundle.Status = status
+	return b
+}
+
+func (b *BundleBuilder) Build() domain.Bundle {
+	return b.bundle
+}
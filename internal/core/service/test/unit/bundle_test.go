@@ -0,0 +1,230 @@
+package unit
+
+import (
+	"context"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"testing"
+)
+
+type BundleSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *BundleSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+// CreateSchoolBundle Suite
+type BundleCreateSchoolBundleSuite struct {
+	BundleSuite
+}
+
+func BundleCreateSchoolBundleSuccessRepositoryMock(repository *mocks.BundleRepository,
+	courseRepository *mocks.CourseRepository, schoolID domain.ID, courses []domain.Course) {
+	courseIDs := make([]domain.ID, len(courses))
+	for i, course := range courses {
+		courseIDs[i] = course.ID
+		courseRepository.
+			On("FindByID", context.Background(), course.ID).
+			Return(course, nil)
+	}
+	repository.
+		On("Create", context.Background(), mock.MatchedBy(func(bundle domain.Bundle) bool {
+			return bundle.SchoolID == schoolID && bundle.Status == domain.BundleDraft
+		}), courseIDs).
+		Return(NewBundleBuilder().WithSchoolID(schoolID).Build(), nil)
+}
+
+func (s *BundleCreateSchoolBundleSuite) TestCreateSchoolBundle_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Create school bundle success")
+	repository := mocks.NewBundleRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	bundleService := service.NewBundleService(repository, courseRepository, s.logger)
+	schoolID := domain.NewID()
+	courses := []domain.Course{
+		NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build(),
+		NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build(),
+	}
+	BundleCreateSchoolBundleSuccessRepositoryMock(repository, courseRepository, schoolID, courses)
+	_, err := bundleService.CreateSchoolBundle(context.Background(), schoolID, port.CreateBundleParam{
+		Name:      "bundle",
+		Price:     5000,
+		CourseIDs: []domain.ID{courses[0].ID, courses[1].ID, courses[0].ID},
+	})
+	t.Assert().Nil(err)
+}
+
+func BundleCreateSchoolBundleFailureRepositoryMock(courseRepository *mocks.CourseRepository,
+	courses []domain.Course) {
+	for _, course := range courses {
+		courseRepository.
+			On("FindByID", context.Background(), course.ID).
+			Return(course, nil).Maybe()
+	}
+}
+
+func (s *BundleCreateSchoolBundleSuite) TestCreateSchoolBundle_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Create school bundle with course of another school")
+	repository := mocks.NewBundleRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	bundleService := service.NewBundleService(repository, courseRepository, s.logger)
+	schoolID := domain.NewID()
+	courses := []domain.Course{
+		NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build(),
+		NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).Build(),
+	}
+	BundleCreateSchoolBundleFailureRepositoryMock(courseRepository, courses)
+	_, err := bundleService.CreateSchoolBundle(context.Background(), schoolID, port.CreateBundleParam{
+		Name:      "bundle",
+		Price:     5000,
+		CourseIDs: []domain.ID{courses[0].ID, courses[1].ID},
+	})
+	t.Assert().ErrorIs(err, errs.ErrBundleCourseNotInSchool)
+}
+
+func (s *BundleCreateSchoolBundleSuite) TestCreateSchoolBundle_TooFewCourses(t provider.T) {
+	t.Parallel()
+	t.Title("Create school bundle with one course")
+	repository := mocks.NewBundleRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	bundleService := service.NewBundleService(repository, courseRepository, s.logger)
+	courseID := domain.NewID()
+	_, err := bundleService.CreateSchoolBundle(context.Background(), domain.NewID(), port.CreateBundleParam{
+		Name:      "bundle",
+		Price:     5000,
+		CourseIDs: []domain.ID{courseID, courseID},
+	})
+	t.Assert().ErrorIs(err, errs.ErrBundleTooFewCourses)
+}
+
+func (s *BundleCreateSchoolBundleSuite) TestCreateSchoolBundle_MixedCurrencies(t provider.T) {
+	t.Parallel()
+	t.Title("Create school bundle with courses priced in different currencies")
+	repository := mocks.NewBundleRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	bundleService := service.NewBundleService(repository, courseRepository, s.logger)
+	schoolID := domain.NewID()
+	courses := []domain.Course{
+		NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build(),
+		NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).WithCurrency("USD").Build(),
+	}
+	BundleCreateSchoolBundleFailureRepositoryMock(courseRepository, courses)
+	_, err := bundleService.CreateSchoolBundle(context.Background(), schoolID, port.CreateBundleParam{
+		Name:      "bundle",
+		Price:     5000,
+		CourseIDs: []domain.ID{courses[0].ID, courses[1].ID},
+	})
+	t.Assert().ErrorIs(err, errs.ErrBundleMixedCurrencies)
+}
+
+func TestBundleCreateSchoolBundleSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Create school bundle", new(BundleCreateSchoolBundleSuite))
+}
+
+// PublishSchoolBundle Suite
+type BundlePublishSchoolBundleSuite struct {
+	BundleSuite
+}
+
+func BundlePublishSchoolBundleSuccessRepositoryMock(repository *mocks.BundleRepository,
+	bundle domain.Bundle) {
+	repository.
+		On("FindByID", context.Background(), bundle.ID).
+		Return(bundle, nil)
+	repository.
+		On("FindBundleCourses", context.Background(), bundle.ID).
+		Return([]domain.Course{
+			NewCourseBuilder().WithStatus(domain.CoursePublished).Build(),
+			NewCourseBuilder().WithStatus(domain.CoursePublished).Build(),
+		}, nil)
+	repository.
+		On("Publish", context.Background(), bundle.ID).
+		Return(nil)
+}
+
+func (s *BundlePublishSchoolBundleSuite) TestPublishSchoolBundle_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Publish school bundle success")
+	repository := mocks.NewBundleRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	bundleService := service.NewBundleService(repository, courseRepository, s.logger)
+	bundle := NewBundleBuilder().Build()
+	BundlePublishSchoolBundleSuccessRepositoryMock(repository, bundle)
+	err := bundleService.PublishSchoolBundle(context.Background(), bundle.SchoolID, bundle.ID)
+	t.Assert().Nil(err)
+}
+
+func BundlePublishSchoolBundleFailureRepositoryMock(repository *mocks.BundleRepository,
+	bundle domain.Bundle) {
+	repository.
+		On("FindByID", context.Background(), bundle.ID).
+		Return(bundle, nil)
+	repository.
+		On("FindBundleCourses", context.Background(), bundle.ID).
+		Return([]domain.Course{
+			NewCourseBuilder().WithStatus(domain.CoursePublished).Build(),
+			NewCourseBuilder().WithStatus(domain.CourseDraft).Build(),
+		}, nil)
+}
+
+func (s *BundlePublishSchoolBundleSuite) TestPublishSchoolBundle_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Publish school bundle with draft course")
+	repository := mocks.NewBundleRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	bundleService := service.NewBundleService(repository, courseRepository, s.logger)
+	bundle := NewBundleBuilder().Build()
+	BundlePublishSchoolBundleFailureRepositoryMock(repository, bundle)
+	err := bundleService.PublishSchoolBundle(context.Background(), bundle.SchoolID, bundle.ID)
+	t.Assert().ErrorIs(err, errs.ErrBundleCourseNotPublished)
+}
+
+func TestBundlePublishSchoolBundleSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Publish school bundle", new(BundlePublishSchoolBundleSuite))
+}
+
+// Shares Suite
+type BundleSharesSuite struct {
+	BundleSuite
+}
+
+func (s *BundleSharesSuite) TestShares_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Bundle price is split in proportion to course prices")
+	bundle := NewBundleBuilder().WithPrice(8001).Build()
+	shares := bundle.Shares([]domain.Course{
+		NewCourseBuilder().WithPrice(3000).Build(),
+		NewCourseBuilder().WithPrice(3000).Build(),
+		NewCourseBuilder().WithPrice(4000).Build(),
+	})
+	t.Assert().Equal([]int64{2400, 2400, 3201}, shares)
+}
+
+func (s *BundleSharesSuite) TestShares_FreeCourses(t provider.T) {
+	t.Parallel()
+	t.Title("Bundle price is split equally between free courses")
+	bundle := NewBundleBuilder().WithPrice(1000).Build()
+	shares := bundle.Shares([]domain.Course{
+		NewCourseBuilder().Build(),
+		NewCourseBuilder().Build(),
+		NewCourseBuilder().Build(),
+	})
+	t.Assert().Equal([]int64{333, 333, 334}, shares)
+}
+
+func TestBundleSharesSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Bundle price shares", new(BundleSharesSuite))
+}
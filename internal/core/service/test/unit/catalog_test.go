@@ -0,0 +1,214 @@
+package unit
+
+import (
+	"context"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+)
+
+type CategoryTreeSuite struct {
+	suite.Suite
+}
+
+func (s *CategoryTreeSuite) TestNewCategoryTree_CourseCount(t provider.T) {
+	t.Parallel()
+	t.Title("Category course count includes courses of subcategories once")
+	root := domain.Category{ID: domain.NewID(), Name: "Programming"}
+	child := domain.Category{ID: domain.NewID(), ParentID: root.ID, Name: "Go"}
+	other := domain.Category{ID: domain.NewID(), Name: "Design"}
+	firstCourseID, secondCourseID := domain.NewID(), domain.NewID()
+	tree := domain.NewCategoryTree([]domain.Category{root, child, other}, []domain.CourseCategory{
+		{CourseID: firstCourseID, CategoryID: root.ID},
+		{CourseID: firstCourseID, CategoryID: child.ID},
+		{CourseID: secondCourseID, CategoryID: child.ID},
+	})
+	t.Require().Len(tree, 2)
+	t.Assert().Equal(root.ID, tree[0].ID)
+	t.Assert().Equal(2, tree[0].CourseCount)
+	t.Require().Len(tree[0].Children, 1)
+	t.Assert().Equal(2, tree[0].Children[0].CourseCount)
+	t.Assert().Equal(0, tree[1].CourseCount)
+	t.Assert().Empty(tree[1].Children)
+}
+
+func (s *CategoryTreeSuite) TestNormalizeTag(t provider.T) {
+	t.Parallel()
+	t.Title("Tag is lowercased and spaces are collapsed")
+	tag, err := domain.NormalizeTag("  Web   Development ")
+	t.Assert().Nil(err)
+	t.Assert().Equal("web development", tag)
+
+	_, err = domain.NormalizeTag("   ")
+	t.Assert().ErrorIs(err, errs.ErrTagInvalid)
+	_, err = domain.NormalizeTag(strings.Repeat("a", domain.MaxTagLength+1))
+	t.Assert().ErrorIs(err, errs.ErrTagInvalid)
+}
+
+func TestCategoryTreeSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Category tree", new(CategoryTreeSuite))
+}
+
+type CatalogSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *CatalogSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+// DeleteCategory Suite
+type CatalogDeleteCategorySuite struct {
+	CatalogSuite
+}
+
+func CatalogDeleteCategorySuccessRepositoryMock(repository *mocks.CatalogRepository, categoryID domain.ID) {
+	repository.
+		On("FindCategories", context.Background()).
+		Return([]domain.Category{{ID: categoryID, Name: "Go"}}, nil)
+	repository.
+		On("DeleteCategory", context.Background(), categoryID).
+		Return(nil)
+}
+
+func (s *CatalogDeleteCategorySuite) TestDeleteCategory_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Delete category success")
+	catalogRepository := mocks.NewCatalogRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	catalogService := service.NewCatalogService(catalogRepository, courseRepository, s.logger)
+	categoryID := domain.NewID()
+	CatalogDeleteCategorySuccessRepositoryMock(catalogRepository, categoryID)
+	err := catalogService.DeleteCategory(context.Background(), categoryID)
+	t.Assert().Nil(err)
+}
+
+func CatalogDeleteCategoryFailureRepositoryMock(repository *mocks.CatalogRepository, categoryID domain.ID) {
+	repository.
+		On("FindCategories", context.Background()).
+		Return([]domain.Category{
+			{ID: categoryID, Name: "Programming"},
+			{ID: domain.NewID(), ParentID: categoryID, Name: "Go"},
+		}, nil)
+}
+
+func (s *CatalogDeleteCategorySuite) TestDeleteCategory_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Delete category with subcategories")
+	catalogRepository := mocks.NewCatalogRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	catalogService := service.NewCatalogService(catalogRepository, courseRepository, s.logger)
+	categoryID := domain.NewID()
+	CatalogDeleteCategoryFailureRepositoryMock(catalogRepository, categoryID)
+	err := catalogService.DeleteCategory(context.Background(), categoryID)
+	t.Assert().ErrorIs(err, errs.ErrCategoryNotEmpty)
+}
+
+func TestCatalogDeleteCategorySuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Delete category", new(CatalogDeleteCategorySuite))
+}
+
+// UpdateCourseTags Suite
+type CatalogUpdateCourseTagsSuite struct {
+	CatalogSuite
+}
+
+func CatalogUpdateCourseTagsSuccessRepositoryMock(repository *mocks.CatalogRepository,
+	courseRepository *mocks.CourseRepository, course domain.Course, names []string) {
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	tags := make([]domain.Tag, len(names))
+	for i, name := range names {
+		tags[i] = domain.Tag{ID: domain.NewID(), SchoolID: course.SchoolID, Name: name}
+	}
+	repository.
+		On("UpdateCourseTags", context.Background(), course.ID, course.SchoolID, names).
+		Return(tags, nil)
+}
+
+func (s *CatalogUpdateCourseTagsSuite) TestUpdateCourseTags_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Update course tags with duplicates in different case")
+	catalogRepository := mocks.NewCatalogRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	catalogService := service.NewCatalogService(catalogRepository, courseRepository, s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).Build()
+	CatalogUpdateCourseTagsSuccessRepositoryMock(catalogRepository, courseRepository, course,
+		[]string{"golang", "backend"})
+	tags, err := catalogService.UpdateCourseTags(context.Background(), course.ID,
+		[]string{"GoLang", " backend", "golang "})
+	t.Assert().Nil(err)
+	t.Assert().Len(tags, 2)
+}
+
+func (s *CatalogUpdateCourseTagsSuite) TestUpdateCourseTags_TooMany(t provider.T) {
+	t.Parallel()
+	t.Title("Update course tags over the limit")
+	catalogRepository := mocks.NewCatalogRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	catalogService := service.NewCatalogService(catalogRepository, courseRepository, s.logger)
+	names := make([]string, domain.MaxCourseTags+1)
+	for i := range names {
+		names[i] = strings.Repeat("a", i+1)
+	}
+	_, err := catalogService.UpdateCourseTags(context.Background(), domain.NewID(), names)
+	t.Assert().ErrorIs(err, errs.ErrCourseTooManyTags)
+}
+
+func (s *CatalogUpdateCourseTagsSuite) TestUpdateCourseTags_Invalid(t provider.T) {
+	t.Parallel()
+	t.Title("Update course tags with empty tag")
+	catalogRepository := mocks.NewCatalogRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	catalogService := service.NewCatalogService(catalogRepository, courseRepository, s.logger)
+	_, err := catalogService.UpdateCourseTags(context.Background(), domain.NewID(), []string{"golang", " "})
+	t.Assert().ErrorIs(err, errs.ErrTagInvalid)
+}
+
+func TestCatalogUpdateCourseTagsSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Update course tags", new(CatalogUpdateCourseTagsSuite))
+}
+
+// FindCourses Suite
+type CatalogFindCoursesSuite struct {
+	CatalogSuite
+}
+
+func CatalogFindCoursesSuccessRepositoryMock(repository *mocks.CatalogRepository,
+	categoryID domain.ID, tag string, courses []domain.Course) {
+	repository.
+		On("FindCourses", context.Background(), categoryID, tag).
+		Return(courses, nil)
+}
+
+func (s *CatalogFindCoursesSuite) TestFindCourses_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Find catalog courses by category and tag in any case")
+	catalogRepository := mocks.NewCatalogRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	catalogService := service.NewCatalogService(catalogRepository, courseRepository, s.logger)
+	categoryID := domain.NewID()
+	courses := []domain.Course{NewCourseBuilder().WithID(domain.NewID()).Build()}
+	CatalogFindCoursesSuccessRepositoryMock(catalogRepository, categoryID, "golang", courses)
+	found, err := catalogService.FindCourses(context.Background(), port.CourseFilter{
+		CategoryID: categoryID,
+		Tag:        " GoLang ",
+	})
+	t.Assert().Nil(err)
+	t.Assert().Equal(courses, found)
+}
+
+func TestCatalogFindCoursesSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Find catalog courses", new(CatalogFindCoursesSuite))
+}
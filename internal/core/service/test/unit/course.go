@@ -4,6 +4,7 @@ import (
 	"github.com/guregu/null"
 	"github.com/paw1a/eschool/internal/core/domain"
 	"github.com/paw1a/eschool/internal/core/port"
+	"time"
 )
 
 type CourseBuilder struct {
@@ -16,6 +17,9 @@ func NewCourseBuilder() *CourseBuilder {
 			Name:   "course name",
 			Status: domain.CourseDraft,
 			Level:  3,
+			Price:  domain.NewMoney(0, domain.DefaultCurrency),
+
+			Version: 1,
 		},
 	}
 }
@@ -25,6 +29,12 @@ func (b *CourseBuilder) WithID(id domain.ID) *CourseBuilder {
 	return b
 }
 
+func (b *CourseBuilder) WithVersion(version, publishedVersion int) *CourseBuilder {
+	b.course.Version = version
+	b.course.PublishedVersion = publishedVersion
+	return b
+}
+
 func (b *CourseBuilder) WithSchoolID(schoolID domain.ID) *CourseBuilder {
 	b.course.SchoolID = schoolID
 	return b
@@ -41,7 +51,12 @@ func (b *CourseBuilder) WithLevel(level int) *CourseBuilder {
 }
 
 func (b *CourseBuilder) WithPrice(price int64) *CourseBuilder {
-	b.course.Price = price
+	b.course.Price.Amount = price
+	return b
+}
+
+func (b *CourseBuilder) WithCurrency(currency string) *CourseBuilder {
+	b.course.Price.Currency = currency
 	return b
 }
 
@@ -55,6 +70,21 @@ func (b *CourseBuilder) WithStatus(status domain.CourseStatus) *CourseBuilder {
 	return b
 }
 
+func (b *CourseBuilder) WithCapacity(capacity int64) *CourseBuilder {
+	b.course.Capacity = null.IntFrom(capacity)
+	return b
+}
+
+func (b *CourseBuilder) WithAccessDays(accessDays int64) *CourseBuilder {
+	b.course.AccessDays = null.IntFrom(accessDays)
+	return b
+}
+
+func (b *CourseBuilder) WithPublishAt(publishAt time.Time) *CourseBuilder {
+	b.course.PublishAt = null.TimeFrom(publishAt)
+	return b
+}
+
 func (b *CourseBuilder) Build() domain.Course {
 	return b.course
 }
@@ -78,7 +108,7 @@ func (b *CourseInfoBuilder) WithLevel(level int) *CourseInfoBuilder {
 }
 
 func (b *CourseInfoBuilder) WithPrice(price int64) *CourseInfoBuilder {
-	b.info.Price = price
+	b.info.Price = domain.NewMoney(price, domain.DefaultCurrency)
 	return b
 }
 
@@ -121,11 +151,21 @@ func (b *CreateCourseParamBuilder) WithPrice(price int64) *CreateCourseParamBuil
 	return b
 }
 
+func (b *CreateCourseParamBuilder) WithCurrency(currency string) *CreateCourseParamBuilder {
+	b.param.Currency = currency
+	return b
+}
+
 func (b *CreateCourseParamBuilder) WithLanguage(language string) *CreateCourseParamBuilder {
 	b.param.Language = language
 	return b
 }
 
+func (b *CreateCourseParamBuilder) WithAccessDays(accessDays int64) *CreateCourseParamBuilder {
+	b.param.AccessDays = null.IntFrom(accessDays)
+	return b
+}
+
 func (b *CreateCourseParamBuilder) Build() port.CreateCourseParam {
 	return b.param
 }
@@ -153,6 +193,11 @@ func (b *UpdateCourseParamBuilder) WithPrice(price null.Int) *UpdateCourseParamB
 	return b
 }
 
+func (b *UpdateCourseParamBuilder) WithCurrency(currency null.String) *UpdateCourseParamBuilder {
+	b.param.Currency = currency
+	return b
+}
+
 func (b *UpdateCourseParamBuilder) WithLanguage(language null.String) *UpdateCourseParamBuilder {
 	b.param.Language = language
 	return b
@@ -0,0 +1,208 @@
+package unit
+
+import (
+	"context"
+	"github.com/guregu/null"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+)
+
+type CourseDuplicateSuite struct {
+	suite.Suite
+}
+
+func (s *CourseDuplicateSuite) TestCourseDuplicate(t provider.T) {
+	t.Parallel()
+	t.Title("Course copy is a new draft in the target school")
+	schoolID := domain.NewID()
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).
+		WithVersion(3, 2).WithCapacity(30).Build()
+	duplicate := course.Duplicate(schoolID)
+	t.Assert().NotEqual(course.ID, duplicate.ID)
+	t.Assert().Equal(schoolID, duplicate.SchoolID)
+	t.Assert().Equal(course.Name, duplicate.Name)
+	t.Assert().Equal(course.Price, duplicate.Price)
+	t.Assert().Equal(course.Capacity, duplicate.Capacity)
+	t.Assert().Equal(domain.CourseDraft, duplicate.Status)
+	t.Assert().Equal(1, duplicate.Version)
+	t.Assert().Equal(0, duplicate.PublishedVersion)
+}
+
+func (s *CourseDuplicateSuite) TestLessonDuplicate(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson copy gets new ids and keys")
+	courseID := domain.NewID()
+	lesson := NewLessonBuilder().
+		WithType(domain.PracticeLesson).
+		WithVersion(3).
+		WithTests([]domain.Test{NewTestBuilder().Build()}).
+		Build()
+	duplicate := lesson.Duplicate(courseID)
+	t.Assert().NotEqual(lesson.ID, duplicate.ID)
+	t.Assert().Equal(duplicate.ID, duplicate.Key)
+	t.Assert().Equal(courseID, duplicate.CourseID)
+	t.Assert().Equal(1, duplicate.Version)
+	t.Require().Len(duplicate.Tests, 1)
+	t.Assert().NotEqual(lesson.Tests[0].ID, duplicate.Tests[0].ID)
+	t.Assert().Equal(duplicate.Tests[0].ID, duplicate.Tests[0].Key)
+	t.Assert().Equal(duplicate.ID, duplicate.Tests[0].LessonID)
+	t.Assert().Equal(lesson.Tests[0].LessonID, lesson.ID)
+}
+
+func TestCourseDuplicateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course duplicate", new(CourseDuplicateSuite))
+}
+
+// DuplicateCourse Suite
+type CourseDuplicateCourseSuite struct {
+	CourseSuite
+}
+
+func CourseDuplicateCourseSuccessRepositoryMock(repository *mocks.CourseRepository,
+	courseID, schoolID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithStatus(domain.CoursePublished).Build(), nil)
+	repository.
+		On("Create", context.Background(), mock.MatchedBy(func(course domain.Course) bool {
+			return course.ID != courseID && course.SchoolID == schoolID &&
+				course.Status == domain.CourseDraft
+		})).
+		Return(func(ctx context.Context, course domain.Course) domain.Course {
+			return course
+		}, nil)
+}
+
+func (s *CourseDuplicateCourseSuite) TestDuplicateCourse_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course service duplicate course success")
+	courseID := domain.NewID()
+	schoolID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseDuplicateCourseSuccessRepositoryMock(courseRepository, courseID, schoolID)
+	course, err := courseService.DuplicateCourse(context.Background(), courseID, schoolID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(schoolID, course.SchoolID)
+	t.Assert().Equal(domain.CourseDraft, course.Status)
+}
+
+func CourseDuplicateCourseNotFoundRepositoryMock(repository *mocks.CourseRepository,
+	courseID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(domain.Course{}, errs.ErrNotExist)
+}
+
+func (s *CourseDuplicateCourseSuite) TestDuplicateCourse_NotFound(t provider.T) {
+	t.Parallel()
+	t.Title("Course service duplicate missing course")
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseDuplicateCourseNotFoundRepositoryMock(courseRepository, courseID)
+	_, err := courseService.DuplicateCourse(context.Background(), courseID, domain.NewID())
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestCourseDuplicateCourseSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course service duplicate course", new(CourseDuplicateCourseSuite))
+}
+
+// CopyCourseLessons Suite
+type LessonCopyCourseLessonsSuite struct {
+	LessonSuite
+}
+
+func LessonCopyCourseLessonsSuccessRepositoryMock(repository *mocks.LessonRepository,
+	storage *mocks.ObjectStorage, fromCourseID, toCourseID domain.ID) {
+	theory := NewLessonBuilder().WithCourseID(fromCourseID).
+		WithType(domain.TheoryLesson).WithTheoryUrl(null.StringFrom("theory")).Build()
+	practice := NewLessonBuilder().WithCourseID(fromCourseID).
+		WithType(domain.PracticeLesson).
+		WithTests([]domain.Test{NewTestBuilder().WithTaskUrl("task").Build()}).Build()
+	repository.
+		On("FindCourseLessons", context.Background(), fromCourseID).
+		Return([]domain.Lesson{theory, practice}, nil)
+	storage.
+		On("CopyFile", context.Background(), domain.Url("theory"),
+			"course/"+toCourseID.String(), mock.AnythingOfType("string")).
+		Return(domain.Url("theory copy"), nil)
+	storage.
+		On("CopyFile", context.Background(), domain.Url("task"),
+			mock.MatchedBy(func(path string) bool {
+				return strings.HasPrefix(path, "course/"+toCourseID.String()+"/")
+			}), mock.AnythingOfType("string")).
+		Return(domain.Url("task copy"), nil)
+	repository.
+		On("CreateCourseLessons", context.Background(), mock.MatchedBy(func(lessons []domain.Lesson) bool {
+			return len(lessons) == 2 && lessons[0].CourseID == toCourseID &&
+				lessons[1].CourseID == toCourseID
+		})).
+		Return(nil)
+}
+
+func (s *LessonCopyCourseLessonsSuite) TestCopyCourseLessons_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson service copy course lessons success")
+	fromCourseID := domain.NewID()
+	toCourseID := domain.NewID()
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonCopyCourseLessonsSuccessRepositoryMock(lessonRepository, objectStorage,
+		fromCourseID, toCourseID)
+	lessons, err := lessonService.CopyCourseLessons(context.Background(), fromCourseID, toCourseID)
+	t.Assert().Nil(err)
+	t.Require().Len(lessons, 2)
+	t.Assert().Equal("theory copy", lessons[0].TheoryUrl.String)
+	t.Assert().Equal("task copy", lessons[1].Tests[0].TaskUrl)
+}
+
+func LessonCopyCourseLessonsStorageFailureRepositoryMock(repository *mocks.LessonRepository,
+	storage *mocks.ObjectStorage, fromCourseID domain.ID) {
+	theory := NewLessonBuilder().WithCourseID(fromCourseID).
+		WithType(domain.TheoryLesson).WithTheoryUrl(null.StringFrom("theory")).Build()
+	repository.
+		On("FindCourseLessons", context.Background(), fromCourseID).
+		Return([]domain.Lesson{theory}, nil)
+	storage.
+		On("CopyFile", context.Background(), domain.Url("theory"),
+			mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+		Return(domain.Url(""), errs.ErrCopyFileError)
+}
+
+func (s *LessonCopyCourseLessonsSuite) TestCopyCourseLessons_StorageFailure(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson service copy course lessons storage failure")
+	fromCourseID := domain.NewID()
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonCopyCourseLessonsStorageFailureRepositoryMock(lessonRepository, objectStorage, fromCourseID)
+	_, err := lessonService.CopyCourseLessons(context.Background(), fromCourseID, domain.NewID())
+	t.Assert().ErrorIs(err, errs.ErrCopyFileError)
+}
+
+func TestLessonCopyCourseLessonsSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Lesson service copy course lessons", new(LessonCopyCourseLessonsSuite))
+}
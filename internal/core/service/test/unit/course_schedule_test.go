@@ -0,0 +1,276 @@
+package unit
+
+import (
+	"context"
+	"github.com/guregu/null"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+type LessonReleaseSuite struct {
+	suite.Suite
+}
+
+func (s *LessonReleaseSuite) TestReleased_NoRule(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson without release rule is released at once")
+	lesson := NewLessonBuilder().Build()
+	enrollment := NewEnrollmentBuilder().Build()
+	t.Assert().True(lesson.Released(enrollment, time.Now()))
+}
+
+func (s *LessonReleaseSuite) TestReleased_Date(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson is released at the release date")
+	now := time.Now().UTC()
+	enrollment := NewEnrollmentBuilder().Build()
+	future := NewLessonBuilder().WithReleaseAt(now.Add(time.Hour)).Build()
+	past := NewLessonBuilder().WithReleaseAt(now.Add(-time.Hour)).Build()
+	t.Assert().False(future.Released(enrollment, now))
+	t.Assert().True(past.Released(enrollment, now))
+}
+
+func (s *LessonReleaseSuite) TestReleased_Days(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson is released in days after enrollment")
+	now := time.Now().UTC()
+	lesson := NewLessonBuilder().WithReleaseDays(7).Build()
+	recent := NewEnrollmentBuilder().WithEnrolledAt(now.AddDate(0, 0, -3)).Build()
+	old := NewEnrollmentBuilder().WithEnrolledAt(now.AddDate(0, 0, -7)).Build()
+	t.Assert().False(lesson.Released(recent, now))
+	t.Assert().True(lesson.Released(old, now))
+	t.Assert().Equal(recent.EnrolledAt.AddDate(0, 0, 7), lesson.ReleaseTime(recent).Time)
+}
+
+func (s *LessonReleaseSuite) TestReleased_DaysWithoutEnrollment(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson released in days is open for learners without enrollment date")
+	lesson := NewLessonBuilder().WithReleaseDays(7).Build()
+	t.Assert().True(lesson.Released(domain.Enrollment{}, time.Now()))
+}
+
+func (s *LessonReleaseSuite) TestLock(t provider.T) {
+	t.Parallel()
+	t.Title("Locked lesson has no content")
+	lesson := NewLessonBuilder().
+		WithType(domain.PracticeLesson).
+		WithTheoryUrl(null.StringFrom("url")).
+		WithTests([]domain.Test{NewTestBuilder().Build()}).
+		Build()
+	locked := lesson.Lock()
+	t.Assert().Equal(lesson.ID, locked.ID)
+	t.Assert().Equal(lesson.Title, locked.Title)
+	t.Assert().False(locked.TheoryUrl.Valid)
+	t.Assert().Empty(locked.Tests)
+	t.Assert().Len(lesson.Tests, 1)
+}
+
+func TestLessonReleaseSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Lesson release", new(LessonReleaseSuite))
+}
+
+// ScheduleCoursePublish Suite
+type CourseScheduleCoursePublishSuite struct {
+	CourseSuite
+}
+
+func CourseScheduleCoursePublishSuccessRepositoryMock(repository *mocks.CourseRepository,
+	courseID domain.ID, publishAt time.Time) {
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithStatus(domain.CourseReady).Build(), nil)
+	repository.
+		On("UpdatePublishAt", context.Background(), courseID,
+			null.TimeFrom(publishAt.UTC().Truncate(time.Second))).
+		Return(nil)
+}
+
+func (s *CourseScheduleCoursePublishSuite) TestScheduleCoursePublish_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course service schedule course publish success")
+	courseID := domain.NewID()
+	publishAt := time.Now().Add(24 * time.Hour)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseScheduleCoursePublishSuccessRepositoryMock(courseRepository, courseID, publishAt)
+	course, err := courseService.ScheduleCoursePublish(context.Background(), courseID,
+		null.TimeFrom(publishAt))
+	t.Assert().Nil(err)
+	t.Assert().Equal(publishAt.UTC().Truncate(time.Second), course.PublishAt.Time)
+}
+
+func CourseScheduleCoursePublishCancelRepositoryMock(repository *mocks.CourseRepository,
+	courseID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithStatus(domain.CourseReady).
+			WithPublishAt(time.Now().Add(time.Hour)).Build(), nil)
+	repository.
+		On("UpdatePublishAt", context.Background(), courseID, null.Time{}).
+		Return(nil)
+}
+
+func (s *CourseScheduleCoursePublishSuite) TestScheduleCoursePublish_Cancel(t provider.T) {
+	t.Parallel()
+	t.Title("Course service schedule course publish cancel")
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseScheduleCoursePublishCancelRepositoryMock(courseRepository, courseID)
+	course, err := courseService.ScheduleCoursePublish(context.Background(), courseID, null.Time{})
+	t.Assert().Nil(err)
+	t.Assert().False(course.PublishAt.Valid)
+}
+
+func CourseScheduleCoursePublishPastRepositoryMock(repository *mocks.CourseRepository,
+	courseID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithStatus(domain.CourseReady).Build(), nil)
+}
+
+func (s *CourseScheduleCoursePublishSuite) TestScheduleCoursePublish_Past(t provider.T) {
+	t.Parallel()
+	t.Title("Course service schedule course publish in the past")
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseScheduleCoursePublishPastRepositoryMock(courseRepository, courseID)
+	_, err := courseService.ScheduleCoursePublish(context.Background(), courseID,
+		null.TimeFrom(time.Now().Add(-time.Hour)))
+	t.Assert().ErrorIs(err, errs.ErrCoursePublishAtInPast)
+}
+
+func CourseScheduleCoursePublishNotReadyRepositoryMock(repository *mocks.CourseRepository,
+	courseID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithStatus(domain.CourseDraft).Build(), nil)
+}
+
+func (s *CourseScheduleCoursePublishSuite) TestScheduleCoursePublish_NotReady(t provider.T) {
+	t.Parallel()
+	t.Title("Course service schedule draft course publish")
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseScheduleCoursePublishNotReadyRepositoryMock(courseRepository, courseID)
+	_, err := courseService.ScheduleCoursePublish(context.Background(), courseID,
+		null.TimeFrom(time.Now().Add(time.Hour)))
+	t.Assert().ErrorIs(err, errs.ErrCoursePublishedState)
+}
+
+func TestCourseScheduleCoursePublishSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course service schedule course publish", new(CourseScheduleCoursePublishSuite))
+}
+
+// PublishScheduledCourse Suite
+type CoursePublishScheduledCourseSuite struct {
+	CourseSuite
+}
+
+func CoursePublishScheduledCourseSuccessRepositoryMock(repository *mocks.CourseRepository,
+	job domain.Job) {
+	repository.
+		On("FindByID", context.Background(), job.TargetID).
+		Return(NewCourseBuilder().WithID(job.TargetID).WithStatus(domain.CourseReady).
+			WithPublishAt(job.RunAt).Build(), nil)
+	repository.
+		On("UpdateStatus", context.Background(),
+			mock.MatchedBy(func(transition domain.CourseTransition) bool {
+				return transition.CourseID == job.TargetID && transition.UserID == job.UserID &&
+					transition.From == domain.CourseReady && transition.To == domain.CoursePublished
+			})).
+		Return(nil)
+}
+
+func (s *CoursePublishScheduledCourseSuite) TestPublishScheduledCourse_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course service publish scheduled course success")
+	job := domain.NewJob(domain.JobPublishCourse, domain.NewID(), domain.NewID(),
+		time.Now().UTC().Truncate(time.Second))
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CoursePublishScheduledCourseSuccessRepositoryMock(courseRepository, job)
+	err := courseService.PublishScheduledCourse(context.Background(), job)
+	t.Assert().Nil(err)
+}
+
+func CoursePublishScheduledCourseStaleRepositoryMock(repository *mocks.CourseRepository,
+	job domain.Job) {
+	repository.
+		On("FindByID", context.Background(), job.TargetID).
+		Return(NewCourseBuilder().WithID(job.TargetID).WithStatus(domain.CourseReady).
+			WithPublishAt(job.RunAt.Add(time.Hour)).Build(), nil)
+}
+
+func (s *CoursePublishScheduledCourseSuite) TestPublishScheduledCourse_Stale(t provider.T) {
+	t.Parallel()
+	t.Title("Course service skips job of the moved publish date")
+	job := domain.NewJob(domain.JobPublishCourse, domain.NewID(), domain.NewID(),
+		time.Now().UTC().Truncate(time.Second))
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CoursePublishScheduledCourseStaleRepositoryMock(courseRepository, job)
+	err := courseService.PublishScheduledCourse(context.Background(), job)
+	t.Assert().Nil(err)
+}
+
+func CoursePublishScheduledCourseNotReadyRepositoryMock(repository *mocks.CourseRepository,
+	job domain.Job) {
+	repository.
+		On("FindByID", context.Background(), job.TargetID).
+		Return(NewCourseBuilder().WithID(job.TargetID).WithStatus(domain.CourseDraft).
+			WithPublishAt(job.RunAt).Build(), nil)
+}
+
+func (s *CoursePublishScheduledCourseSuite) TestPublishScheduledCourse_NotReady(t provider.T) {
+	t.Parallel()
+	t.Title("Course service publish scheduled course moved back to draft")
+	job := domain.NewJob(domain.JobPublishCourse, domain.NewID(), domain.NewID(),
+		time.Now().UTC().Truncate(time.Second))
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CoursePublishScheduledCourseNotReadyRepositoryMock(courseRepository, job)
+	err := courseService.PublishScheduledCourse(context.Background(), job)
+	t.Assert().ErrorIs(err, errs.ErrCoursePublishedState)
+}
+
+func TestCoursePublishScheduledCourseSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course service publish scheduled course", new(CoursePublishScheduledCourseSuite))
+}
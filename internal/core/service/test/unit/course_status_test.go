@@ -0,0 +1,50 @@
+package unit
+
+import (
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"testing"
+)
+
+type CourseStatusSuite struct {
+	suite.Suite
+}
+
+func (s *CourseStatusSuite) TestCanTransitTo_Allowed(t provider.T) {
+	t.Parallel()
+	t.Title("Course status moves along the transition table")
+	t.Assert().Nil(domain.CourseDraft.CanTransitTo(domain.CourseReady))
+	t.Assert().Nil(domain.CourseReady.CanTransitTo(domain.CoursePublished))
+	t.Assert().Nil(domain.CoursePublished.CanTransitTo(domain.CourseUnpublished))
+	t.Assert().Nil(domain.CoursePublished.CanTransitTo(domain.CourseArchived))
+	t.Assert().Nil(domain.CourseUnpublished.CanTransitTo(domain.CourseDraft))
+	t.Assert().Nil(domain.CourseArchived.CanTransitTo(domain.CourseUnpublished))
+}
+
+func (s *CourseStatusSuite) TestCanTransitTo_Forbidden(t provider.T) {
+	t.Parallel()
+	t.Title("Course status can't skip the transition table")
+	err := domain.CourseDraft.CanTransitTo(domain.CoursePublished)
+	t.Assert().ErrorIs(err, errs.ErrCourseInvalidTransition)
+	t.Assert().Equal("course can't move from draft to published status", err.Error())
+	t.Assert().ErrorIs(domain.CourseArchived.CanTransitTo(domain.CoursePublished),
+		errs.ErrCourseInvalidTransition)
+	t.Assert().ErrorIs(domain.CoursePublished.CanTransitTo(domain.CoursePublished),
+		errs.ErrCourseInvalidTransition)
+}
+
+func (s *CourseStatusSuite) TestParseCourseStatus(t provider.T) {
+	t.Parallel()
+	t.Title("Course status is parsed from its name")
+	status, ok := domain.ParseCourseStatus("archived")
+	t.Assert().True(ok)
+	t.Assert().Equal(domain.CourseArchived, status)
+	_, ok = domain.ParseCourseStatus("deleted")
+	t.Assert().False(ok)
+}
+
+func TestCourseStatusSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course status", new(CourseStatusSuite))
+}
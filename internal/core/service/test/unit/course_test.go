@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 	"testing"
+	"time"
 )
 
 type CourseSuite struct {
@@ -45,7 +46,7 @@ func (s *CourseFindAllSuite) TestFindAll_Success(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseFindAllSuccessRepositoryMock(courseRepository)
 	_, err := courseService.FindAll(context.Background())
 	t.Assert().Nil(err)
@@ -65,7 +66,7 @@ func (s *CourseFindAllSuite) TestFindAll_Failure(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseFindAllFailureRepositoryMock(courseRepository)
 	_, err := courseService.FindAll(context.Background())
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
@@ -95,7 +96,7 @@ func (s *CourseFindByIDSuite) TestFindByID_Success(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseFindByIDSuccessRepositoryMock(courseRepository, courseID)
 	course, err := courseService.FindByID(context.Background(), courseID)
 	t.Assert().Nil(err)
@@ -117,7 +118,7 @@ func (s *CourseFindByIDSuite) TestFindByID_Failure(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseFindByIDFailureRepositoryMock(courseRepository, courseID)
 	_, err := courseService.FindByID(context.Background(), courseID)
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
@@ -147,7 +148,7 @@ func (s *CourseFindStudentCoursesSuite) TestFindStudentCourses_Success(t provide
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseFindStudentCoursesSuccessRepositoryMock(courseRepository, userID)
 	courses, err := courseService.FindStudentCourses(context.Background(), userID)
 	t.Assert().Nil(err)
@@ -169,7 +170,7 @@ func (s *CourseFindStudentCoursesSuite) TestFindStudentCourses_Failure(t provide
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseFindStudentCoursesFailureRepositoryMock(courseRepository, userID)
 	_, err := courseService.FindStudentCourses(context.Background(), userID)
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
@@ -199,7 +200,7 @@ func (s *CourseFindTeacherCoursesSuite) TestFindTeacherCourses_Success(t provide
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseFindTeacherCoursesSuccessRepositoryMock(courseRepository, userID)
 	courses, err := courseService.FindTeacherCourses(context.Background(), userID)
 	t.Assert().Nil(err)
@@ -221,7 +222,7 @@ func (s *CourseFindTeacherCoursesSuite) TestFindTeacherCourses_Failure(t provide
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseFindTeacherCoursesFailureRepositoryMock(courseRepository, userID)
 	_, err := courseService.FindTeacherCourses(context.Background(), userID)
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
@@ -251,7 +252,7 @@ func (s *CourseFindCourseTeachersSuite) TestFindCourseTeachers_Success(t provide
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseFindCourseTeachersSuccessRepositoryMock(courseRepository, courseID)
 	teachers, err := courseService.FindCourseTeachers(context.Background(), courseID)
 	t.Assert().Nil(err)
@@ -273,7 +274,7 @@ func (s *CourseFindCourseTeachersSuite) TestFindCourseTeachers_Failure(t provide
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseFindCourseTeachersFailureRepositoryMock(courseRepository, courseID)
 	_, err := courseService.FindCourseTeachers(context.Background(), courseID)
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
@@ -304,7 +305,7 @@ func (s *CourseIsCourseStudentSuite) TestIsCourseStudent_Success(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseIsCourseStudentSuccessRepositoryMock(courseRepository, courseID, studentID)
 	isStudent, err := courseService.IsCourseStudent(context.Background(), courseID, studentID)
 	t.Assert().Nil(err)
@@ -327,7 +328,7 @@ func (s *CourseIsCourseStudentSuite) TestIsCourseStudent_Failure(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseIsCourseStudentFailureRepositoryMock(courseRepository, courseID, studentID)
 	isStudent, err := courseService.IsCourseStudent(context.Background(), courseID, studentID)
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
@@ -359,7 +360,7 @@ func (s *CourseIsCourseTeacherSuite) TestIsCourseTeacher_Success(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseIsCourseTeacherSuccessRepositoryMock(courseRepository, courseID, teacherID)
 	isTeacher, err := courseService.IsCourseTeacher(context.Background(), courseID, teacherID)
 	t.Assert().Nil(err)
@@ -382,7 +383,7 @@ func (s *CourseIsCourseTeacherSuite) TestIsCourseTeacher_Failure(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseIsCourseTeacherFailureRepositoryMock(courseRepository, courseID, teacherID)
 	isTeacher, err := courseService.IsCourseTeacher(context.Background(), courseID, teacherID)
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
@@ -401,11 +402,17 @@ type CourseAddCourseStudentSuite struct {
 func CourseAddCourseStudentSuccessRepositoryMock(repository *mocks.CourseRepository,
 	lessonRepository *mocks.LessonRepository, statRepository *mocks.StatRepository,
 	courseID, studentID domain.ID) {
+	repository.
+		On("FindEnrollment", context.Background(), studentID, courseID).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
 	repository.
 		On("AddCourseStudent", context.Background(), studentID, courseID).
 		Return(nil)
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).Build(), nil)
 	lessonRepository.
-		On("FindCourseLessons", context.Background(), courseID).
+		On("FindCourseVersionLessons", context.Background(), courseID, 1).
 		Return([]domain.Lesson{NewLessonBuilder().Build()}, nil)
 	statRepository.
 		On("CreateLessonStat", context.Background(), mock.Anything).
@@ -422,7 +429,7 @@ func (s *CourseAddCourseStudentSuite) TestAddCourseStudent_Success(t provider.T)
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseAddCourseStudentSuccessRepositoryMock(courseRepository, lessonRepository,
 		statRepository, courseID, studentID)
 	err := courseService.AddCourseStudent(context.Background(), studentID, courseID)
@@ -432,11 +439,17 @@ func (s *CourseAddCourseStudentSuite) TestAddCourseStudent_Success(t provider.T)
 func CourseAddCourseStudentFailureRepositoryMock(repository *mocks.CourseRepository,
 	lessonRepository *mocks.LessonRepository,
 	statRepository *mocks.StatRepository, courseID, studentID domain.ID) {
+	repository.
+		On("FindEnrollment", context.Background(), studentID, courseID).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
 	repository.
 		On("AddCourseStudent", context.Background(), studentID, courseID).
 		Return(errs.ErrNotExist)
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).Build(), nil)
 	lessonRepository.
-		On("FindCourseLessons", context.Background(), courseID).
+		On("FindCourseVersionLessons", context.Background(), courseID, 1).
 		Return([]domain.Lesson{NewLessonBuilder().Build()}, nil)
 	statRepository.
 		On("CreateLessonStat", context.Background(), mock.Anything).
@@ -453,17 +466,162 @@ func (s *CourseAddCourseStudentSuite) TestAddCourseStudent_Failure(t provider.T)
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseAddCourseStudentFailureRepositoryMock(courseRepository, lessonRepository,
 		statRepository, courseID, studentID)
 	err := courseService.AddCourseStudent(context.Background(), studentID, courseID)
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
 }
 
+func CourseAddCourseStudentExpiredRepositoryMock(repository *mocks.CourseRepository,
+	courseID, studentID domain.ID) {
+	repository.
+		On("FindEnrollment", context.Background(), studentID, courseID).
+		Return(NewEnrollmentBuilder().
+			WithStudentID(studentID).
+			WithCourseID(courseID).
+			WithExpiresAt(time.Now().Add(-time.Hour)).
+			Build(), nil)
+	repository.
+		On("AddCourseStudent", context.Background(), studentID, courseID).
+		Return(nil)
+}
+
+func (s *CourseAddCourseStudentSuite) TestAddCourseStudent_ExpiredKeepsStats(t provider.T) {
+	t.Parallel()
+	t.Title("Course service add course student with expired enrollment keeps statistics")
+	courseID := domain.NewID()
+	studentID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseAddCourseStudentExpiredRepositoryMock(courseRepository, courseID, studentID)
+	err := courseService.AddCourseStudent(context.Background(), studentID, courseID)
+	t.Assert().Nil(err)
+}
+
 func TestCourseAddCourseStudentSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Course service add course student", new(CourseAddCourseStudentSuite))
 }
 
+// RenewCourseStudent Suite
+type CourseRenewCourseStudentSuite struct {
+	CourseSuite
+}
+
+func CourseRenewCourseStudentSuccessRepositoryMock(repository *mocks.CourseRepository,
+	enrollment domain.Enrollment, renewalKey string) {
+	repository.
+		On("RenewCourseStudent", context.Background(), enrollment.StudentID, enrollment.CourseID,
+			renewalKey, mock.Anything).
+		Return(enrollment, nil)
+}
+
+func (s *CourseRenewCourseStudentSuite) TestRenewCourseStudent_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course service renew course student success")
+	enrollment := NewEnrollmentBuilder().WithExpiresAt(time.Now().AddDate(0, 0, 30)).Build()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseRenewCourseStudentSuccessRepositoryMock(courseRepository, enrollment, "key")
+	renewed, err := courseService.RenewCourseStudent(context.Background(),
+		enrollment.StudentID, enrollment.CourseID, "key")
+	t.Assert().Nil(err)
+	t.Assert().Equal(enrollment.ExpiresAt, renewed.ExpiresAt)
+}
+
+func CourseRenewCourseStudentFailureRepositoryMock(repository *mocks.CourseRepository,
+	studentID, courseID domain.ID) {
+	repository.
+		On("RenewCourseStudent", context.Background(), studentID, courseID, mock.Anything, mock.Anything).
+		Return(domain.Enrollment{}, errs.ErrCourseNotRenewable)
+}
+
+func (s *CourseRenewCourseStudentSuite) TestRenewCourseStudent_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Course service renew course student failure")
+	studentID := domain.NewID()
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseRenewCourseStudentFailureRepositoryMock(courseRepository, studentID, courseID)
+	_, err := courseService.RenewCourseStudent(context.Background(), studentID, courseID, "key")
+	t.Assert().ErrorIs(err, errs.ErrCourseNotRenewable)
+}
+
+func TestCourseRenewCourseStudentSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course service renew course student", new(CourseRenewCourseStudentSuite))
+}
+
+type CourseRemoveCourseStudentSuite struct {
+	CourseSuite
+}
+
+func CourseRemoveCourseStudentSuccessRepositoryMock(repository *mocks.CourseRepository,
+	seatListener *mocks.SeatListener, studentID, courseID domain.ID) {
+	repository.
+		On("RemoveCourseStudent", context.Background(), studentID, courseID).
+		Return(nil)
+	seatListener.
+		On("OfferFreeSeats", context.Background(), courseID).
+		Return(nil)
+}
+
+func (s *CourseRemoveCourseStudentSuite) TestRemoveCourseStudent_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course service remove course student offers freed seat")
+	studentID := domain.NewID()
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	seatListener := mocks.NewSeatListener(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, seatListener, s.logger)
+	CourseRemoveCourseStudentSuccessRepositoryMock(courseRepository, seatListener, studentID, courseID)
+	err := courseService.RemoveCourseStudent(context.Background(), studentID, courseID)
+	t.Assert().Nil(err)
+}
+
+func CourseRemoveCourseStudentFailureRepositoryMock(repository *mocks.CourseRepository,
+	studentID, courseID domain.ID) {
+	repository.
+		On("RemoveCourseStudent", context.Background(), studentID, courseID).
+		Return(errs.ErrNotExist)
+}
+
+func (s *CourseRemoveCourseStudentSuite) TestRemoveCourseStudent_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Course service remove course student failure")
+	studentID := domain.NewID()
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseRemoveCourseStudentFailureRepositoryMock(courseRepository, studentID, courseID)
+	err := courseService.RemoveCourseStudent(context.Background(), studentID, courseID)
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestCourseRemoveCourseStudentSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course service remove course student", new(CourseRemoveCourseStudentSuite))
+}
+
 // AddCourseTeacher Suite
 type CourseAddCourseTeacherSuite struct {
 	CourseSuite
@@ -492,7 +650,7 @@ func (s *CourseAddCourseTeacherSuite) TestAddCourseTeacher_Success(t provider.T)
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseAddCourseTeacherSuccessRepositoryMock(courseRepository, schoolRepository, courseID, teacherID)
 	err := courseService.AddCourseTeacher(context.Background(), teacherID, courseID)
 	t.Assert().Nil(err)
@@ -521,7 +679,7 @@ func (s *CourseAddCourseTeacherSuite) TestAddCourseTeacher_Failure(t provider.T)
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseAddCourseTeacherFailureRepositoryMock(courseRepository, schoolRepository, courseID, teacherID)
 	err := courseService.AddCourseTeacher(context.Background(), teacherID, courseID)
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
@@ -553,7 +711,7 @@ func (s *CourseCreateSuite) TestCreate_Success(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseCreateSuccessRepositoryMock(courseRepository, name)
 	course, err := courseService.CreateSchoolCourse(context.Background(), schoolID, param)
 	t.Assert().Nil(err)
@@ -576,12 +734,62 @@ func (s *CourseCreateSuite) TestCreate_Failure(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseCreateFailureRepositoryMock(courseRepository)
 	_, err := courseService.CreateSchoolCourse(context.Background(), schoolID, param)
 	t.Assert().NotNil(err)
 }
 
+func (s *CourseCreateSuite) TestCreate_InvalidAccessPeriod(t provider.T) {
+	t.Parallel()
+	t.Title("Course service create course with invalid access period")
+	schoolID := domain.NewID()
+	param := NewCreateCourseParamBuilder().WithAccessDays(0).Build()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	_, err := courseService.CreateSchoolCourse(context.Background(), schoolID, param)
+	t.Assert().ErrorIs(err, errs.ErrCourseInvalidAccessPeriod)
+}
+
+func (s *CourseCreateSuite) TestCreate_InvalidCurrency(t provider.T) {
+	t.Parallel()
+	t.Title("Course service create course with unsupported currency")
+	schoolID := domain.NewID()
+	param := NewCreateCourseParamBuilder().WithPrice(399000).WithCurrency("XYZ").Build()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	_, err := courseService.CreateSchoolCourse(context.Background(), schoolID, param)
+	t.Assert().ErrorIs(err, errs.ErrCourseInvalidCurrency)
+}
+
+func (s *CourseCreateSuite) TestCreate_DefaultCurrency(t provider.T) {
+	t.Parallel()
+	t.Title("Course service create course without currency priced in default currency")
+	schoolID := domain.NewID()
+	param := NewCreateCourseParamBuilder().WithPrice(399000).Build()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	courseRepository.
+		On("Create", context.Background(), mock.MatchedBy(func(course domain.Course) bool {
+			return course.Price == domain.NewMoney(399000, domain.DefaultCurrency)
+		})).
+		Return(NewCourseBuilder().WithPrice(399000).Build(), nil)
+	_, err := courseService.CreateSchoolCourse(context.Background(), schoolID, param)
+	t.Assert().Nil(err)
+}
+
 func TestCourseCreateSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Course service create course", new(CourseCreateSuite))
 }
@@ -612,7 +820,7 @@ func (s *CourseUpdateSuite) TestUpdate_Success(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseUpdateSuccessRepositoryMock(courseRepository, courseID, name)
 	course, err := courseService.Update(context.Background(), courseID, param)
 	t.Assert().Nil(err)
@@ -635,12 +843,30 @@ func (s *CourseUpdateSuite) TestUpdate_Failure(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseUpdateFailureRepositoryMock(courseRepository, courseID)
 	_, err := courseService.Update(context.Background(), courseID, param)
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
 }
 
+func (s *CourseUpdateSuite) TestUpdate_InvalidCurrency(t provider.T) {
+	t.Parallel()
+	t.Title("Course service update course with unsupported currency")
+	courseID := domain.NewID()
+	param := NewUpdateCourseParamBuilder().WithCurrency(null.StringFrom("rub")).Build()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	courseRepository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).Build(), nil)
+	_, err := courseService.Update(context.Background(), courseID, param)
+	t.Assert().ErrorIs(err, errs.ErrCourseInvalidCurrency)
+}
+
 func TestCourseUpdateSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Course service update course", new(CourseUpdateSuite))
 }
@@ -665,7 +891,7 @@ func (s *CourseDeleteSuite) TestDelete_Success(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseDeleteSuccessRepositoryMock(courseRepository, courseID)
 	err := courseService.Delete(context.Background(), courseID)
 	t.Assert().Nil(err)
@@ -686,7 +912,7 @@ func (s *CourseDeleteSuite) TestDelete_Failure(t provider.T) {
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseDeleteFailureRepositoryMock(courseRepository, courseID)
 	err := courseService.Delete(context.Background(), courseID)
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
@@ -706,7 +932,7 @@ func CoursePublishReadyCourseSuccessRepositoryMock(repository *mocks.CourseRepos
 		On("FindByID", context.Background(), courseID).
 		Return(NewCourseBuilder().WithID(courseID).WithStatus(domain.CourseReady).Build(), nil)
 	repository.
-		On("UpdateStatus", context.Background(), courseID, mock.Anything).
+		On("UpdateStatus", context.Background(), mock.Anything).
 		Return(nil)
 }
 
@@ -719,9 +945,9 @@ func (s *CoursePublishReadyCourseSuite) TestPublishReadyCourse_Success(t provide
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CoursePublishReadyCourseSuccessRepositoryMock(courseRepository, courseID)
-	err := courseService.PublishReadyCourse(context.Background(), courseID)
+	err := courseService.PublishReadyCourse(context.Background(), domain.NewID(), courseID)
 	t.Assert().Nil(err)
 }
 
@@ -730,7 +956,7 @@ func CoursePublishReadyCourseFailureRepositoryMock(repository *mocks.CourseRepos
 		On("FindByID", context.Background(), courseID).
 		Return(NewCourseBuilder().WithID(courseID).WithStatus(domain.CourseReady).Build(), nil)
 	repository.
-		On("UpdateStatus", context.Background(), courseID, mock.Anything).
+		On("UpdateStatus", context.Background(), mock.Anything).
 		Return(errs.ErrUpdateFailed)
 }
 
@@ -743,9 +969,9 @@ func (s *CoursePublishReadyCourseSuite) TestPublishReadyCourse_Failure(t provide
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CoursePublishReadyCourseFailureRepositoryMock(courseRepository, courseID)
-	err := courseService.PublishReadyCourse(context.Background(), courseID)
+	err := courseService.PublishReadyCourse(context.Background(), domain.NewID(), courseID)
 	t.Assert().ErrorIs(err, errs.ErrUpdateFailed)
 }
 
@@ -764,7 +990,7 @@ func CourseConfirmDraftCourseSuccessRepositoryMock(repository *mocks.CourseRepos
 		On("FindByID", context.Background(), courseID).
 		Return(NewCourseBuilder().WithID(courseID).WithStatus(domain.CourseDraft).Build(), nil)
 	repository.
-		On("UpdateStatus", context.Background(), courseID, mock.Anything).
+		On("UpdateStatus", context.Background(), mock.Anything).
 		Return(nil)
 	lessonRepository.
 		On("FindCourseLessons", context.Background(), courseID).
@@ -788,10 +1014,11 @@ func (s *CourseConfirmDraftCourseSuite) TestConfirmDraftCourse_Success(t provide
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseConfirmDraftCourseSuccessRepositoryMock(courseRepository, lessonRepository, courseID)
-	err := courseService.ConfirmDraftCourse(context.Background(), courseID)
+	report, err := courseService.ConfirmDraftCourse(context.Background(), domain.NewID(), courseID)
 	t.Assert().Nil(err)
+	t.Assert().True(report.Valid())
 }
 
 func CourseConfirmDraftCourseFailureRepositoryMock(repository *mocks.CourseRepository,
@@ -800,7 +1027,7 @@ func CourseConfirmDraftCourseFailureRepositoryMock(repository *mocks.CourseRepos
 		On("FindByID", context.Background(), courseID).
 		Return(NewCourseBuilder().WithID(courseID).WithStatus(domain.CourseDraft).Build(), nil)
 	repository.
-		On("UpdateStatus", context.Background(), courseID, mock.Anything).
+		On("UpdateStatus", context.Background(), mock.Anything).
 		Return(errs.ErrUpdateFailed)
 	lessonRepository.
 		On("FindCourseLessons", context.Background(), courseID).
@@ -824,12 +1051,71 @@ func (s *CourseConfirmDraftCourseSuite) TestConfirmDraftCourse_Failure(t provide
 	schoolRepository := mocks.NewSchoolRepository(t)
 	statRepository := mocks.NewStatRepository(t)
 	courseService := service.NewCourseService(courseRepository, lessonRepository,
-		schoolRepository, statRepository, s.logger)
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
 	CourseConfirmDraftCourseFailureRepositoryMock(courseRepository, lessonRepository, courseID)
-	errArray := courseService.ConfirmDraftCourse(context.Background(), courseID)
-	t.Assert().ErrorIs(errArray[0], errs.ErrUpdateFailed)
+	_, err := courseService.ConfirmDraftCourse(context.Background(), domain.NewID(), courseID)
+	t.Assert().ErrorIs(err, errs.ErrUpdateFailed)
 }
 
 func TestCourseConfirmDraftCourseSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Course service confirm draft course", new(CourseConfirmDraftCourseSuite))
 }
+
+// ChangeCourseStatus Suite
+type CourseChangeCourseStatusSuite struct {
+	CourseSuite
+}
+
+func CourseChangeCourseStatusSuccessRepositoryMock(repository *mocks.CourseRepository,
+	courseID, userID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithStatus(domain.CoursePublished).Build(), nil)
+	repository.
+		On("UpdateStatus", context.Background(), mock.MatchedBy(func(transition domain.CourseTransition) bool {
+			return transition.CourseID == courseID && transition.UserID == userID &&
+				transition.From == domain.CoursePublished && transition.To == domain.CourseUnpublished
+		})).
+		Return(nil)
+}
+
+func (s *CourseChangeCourseStatusSuite) TestChangeCourseStatus_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course service change course status success")
+	courseID := domain.NewID()
+	userID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseChangeCourseStatusSuccessRepositoryMock(courseRepository, courseID, userID)
+	err := courseService.ChangeCourseStatus(context.Background(), userID, courseID, domain.CourseUnpublished)
+	t.Assert().Nil(err)
+}
+
+func CourseChangeCourseStatusFailureRepositoryMock(repository *mocks.CourseRepository, courseID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithStatus(domain.CourseDraft).Build(), nil)
+}
+
+func (s *CourseChangeCourseStatusSuite) TestChangeCourseStatus_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Course service change course status failure")
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseChangeCourseStatusFailureRepositoryMock(courseRepository, courseID)
+	err := courseService.ChangeCourseStatus(context.Background(), domain.NewID(), courseID, domain.CoursePublished)
+	t.Assert().ErrorIs(err, errs.ErrCourseInvalidTransition)
+}
+
+func TestCourseChangeCourseStatusSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course service change course status", new(CourseChangeCourseStatusSuite))
+}
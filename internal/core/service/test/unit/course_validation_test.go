@@ -0,0 +1,129 @@
+package unit
+
+import (
+	"context"
+	"github.com/guregu/null"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"testing"
+)
+
+type CourseValidationSuite struct {
+	suite.Suite
+}
+
+func (s *CourseValidationSuite) TestValidateCourseLessons_Issues(t provider.T) {
+	t.Parallel()
+	t.Title("Course validation report points to the failed lesson and test")
+	courseID := domain.NewID()
+	badTest := NewTestBuilder().WithID(domain.NewID()).WithOptions([]string{}).Build()
+	warnTest := NewTestBuilder().WithID(domain.NewID()).WithAnswer("opt3").Build()
+	practice := NewLessonBuilder().
+		WithID(domain.NewID()).
+		WithType(domain.PracticeLesson).
+		WithTests([]domain.Test{badTest, warnTest}).
+		Build()
+
+	report := domain.ValidateCourseLessons(courseID, []domain.Lesson{practice})
+	t.Assert().False(report.Valid())
+	t.Assert().ErrorIs(report.Err(), errs.ErrCoursePracticeLessonEmptyTestOptions)
+	t.Assert().Equal(2, report.Count(domain.ValidationError))
+	t.Assert().Equal(1, report.Count(domain.ValidationWarning))
+	t.Require().Len(report.Issues, 3)
+
+	t.Assert().Equal(domain.IssueTestEmptyOptions, report.Issues[0].Code)
+	t.Assert().Equal(practice.ID, report.Issues[0].LessonID)
+	t.Assert().Equal(badTest.ID, report.Issues[0].TestID)
+
+	t.Assert().Equal(domain.IssueTestAnswerNotOption, report.Issues[1].Code)
+	t.Assert().Equal(domain.ValidationWarning, report.Issues[1].Severity)
+	t.Assert().Equal(warnTest.ID, report.Issues[1].TestID)
+
+	t.Assert().Equal(domain.IssueCourseNotEnoughLessons, report.Issues[2].Code)
+	t.Assert().Equal(domain.ID(""), report.Issues[2].LessonID)
+}
+
+func (s *CourseValidationSuite) TestValidateCourseLessons_WarningsOnly(t provider.T) {
+	t.Parallel()
+	t.Title("Course validation report with warnings only is valid")
+	lessons := []domain.Lesson{
+		NewLessonBuilder().
+			WithTitle("").
+			WithType(domain.TheoryLesson).
+			WithTheoryUrl(null.StringFrom("url")).
+			Build(),
+		NewLessonBuilder().
+			WithType(domain.PracticeLesson).
+			WithTests([]domain.Test{NewTestBuilder().WithOptions([]string{"opt1"}).Build()}).
+			Build(),
+	}
+
+	report := domain.ValidateCourseLessons(domain.NewID(), lessons)
+	t.Assert().True(report.Valid())
+	t.Assert().Nil(report.Err())
+	t.Assert().Equal(2, report.Count(domain.ValidationWarning))
+}
+
+func TestCourseValidationSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course validation", new(CourseValidationSuite))
+}
+
+// ValidateCourse Suite
+type CourseValidateCourseSuite struct {
+	CourseSuite
+}
+
+func CourseValidateCourseRepositoryMock(lessonRepository *mocks.LessonRepository, courseID domain.ID) {
+	lessonRepository.
+		On("FindCourseLessons", context.Background(), courseID).
+		Return([]domain.Lesson{
+			NewLessonBuilder().
+				WithType(domain.TheoryLesson).
+				Build()}, nil)
+}
+
+func (s *CourseValidateCourseSuite) TestValidateCourse_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course service validate course returns all issues")
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseValidateCourseRepositoryMock(lessonRepository, courseID)
+	report, err := courseService.ValidateCourse(context.Background(), courseID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(courseID, report.CourseID)
+	t.Assert().Equal(2, report.Count(domain.ValidationError))
+}
+
+func CourseValidateCourseFailureRepositoryMock(lessonRepository *mocks.LessonRepository, courseID domain.ID) {
+	lessonRepository.
+		On("FindCourseLessons", context.Background(), courseID).
+		Return(nil, errs.ErrPersistenceFailed)
+}
+
+func (s *CourseValidateCourseSuite) TestValidateCourse_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Course service validate course failure")
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseValidateCourseFailureRepositoryMock(lessonRepository, courseID)
+	_, err := courseService.ValidateCourse(context.Background(), courseID)
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestCourseValidateCourseSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course service validate course", new(CourseValidateCourseSuite))
+}
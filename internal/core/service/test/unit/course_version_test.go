@@ -0,0 +1,296 @@
+package unit
+
+import (
+	"context"
+	"github.com/guregu/null"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type CourseVersionSuite struct {
+	suite.Suite
+}
+
+func (s *CourseVersionSuite) TestRevise(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson revision keeps keys and gets new ids")
+	lesson := NewLessonBuilder().
+		WithType(domain.PracticeLesson).
+		WithTests([]domain.Test{NewTestBuilder().WithKey(domain.NewID()).Build()}).
+		Build()
+	lesson.Key = lesson.ID
+	revision := lesson.Revise(2)
+	t.Assert().NotEqual(lesson.ID, revision.ID)
+	t.Assert().Equal(lesson.Key, revision.Key)
+	t.Assert().Equal(2, revision.Version)
+	t.Assert().NotEqual(lesson.Tests[0].ID, revision.Tests[0].ID)
+	t.Assert().Equal(lesson.Tests[0].Key, revision.Tests[0].Key)
+	t.Assert().Equal(revision.ID, revision.Tests[0].LessonID)
+}
+
+func (s *CourseVersionSuite) TestMigrateLessonStat(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson stat is moved to tests with the same keys")
+	userID := domain.NewID()
+	kept := NewTestBuilder().WithKey(domain.NewID()).WithScore(10).Build()
+	removed := NewTestBuilder().WithKey(domain.NewID()).WithScore(10).Build()
+	from := NewLessonBuilder().WithScore(10).
+		WithTests([]domain.Test{kept, removed}).Build()
+	to := NewLessonBuilder().WithScore(20).
+		WithTests([]domain.Test{
+			NewTestBuilder().WithKey(kept.Key).WithScore(5).Build(),
+			NewTestBuilder().WithKey(domain.NewID()).WithScore(10).Build(),
+		}).Build()
+	stat := domain.LessonStat{
+		ID:       domain.NewID(),
+		LessonID: from.ID,
+		UserID:   userID,
+		Score:    10,
+		TestStats: []domain.TestStat{
+			{ID: domain.NewID(), TestID: kept.ID, UserID: userID, Score: 10},
+			{ID: domain.NewID(), TestID: removed.ID, UserID: userID, Score: 10},
+		},
+	}
+
+	migrated := domain.MigrateLessonStat(stat, from, to)
+	t.Assert().Equal(to.ID, migrated.LessonID)
+	t.Assert().Equal(userID, migrated.UserID)
+	t.Assert().Equal(20, migrated.Score)
+	t.Require().Len(migrated.TestStats, 2)
+	t.Assert().Equal(to.Tests[0].ID, migrated.TestStats[0].TestID)
+	t.Assert().Equal(5, migrated.TestStats[0].Score)
+	t.Assert().Equal(0, migrated.TestStats[1].Score)
+}
+
+func TestCourseVersionSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course version", new(CourseVersionSuite))
+}
+
+// CreateCourseRevision Suite
+type CourseCreateCourseRevisionSuite struct {
+	CourseSuite
+}
+
+func CourseCreateCourseRevisionSuccessRepositoryMock(repository *mocks.CourseRepository,
+	lessonRepository *mocks.LessonRepository, courseID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithVersion(1, 1).Build(), nil)
+	lessonRepository.
+		On("FindCourseVersionLessons", context.Background(), courseID, 1).
+		Return([]domain.Lesson{NewLessonBuilder().WithCourseID(courseID).Build()}, nil)
+	lessonRepository.
+		On("CreateRevision", context.Background(), courseID, 2,
+			mock.MatchedBy(func(lessons []domain.Lesson) bool {
+				return len(lessons) == 1 && lessons[0].Version == 2
+			})).
+		Return(nil)
+}
+
+func (s *CourseCreateCourseRevisionSuite) TestCreateCourseRevision_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course service create course revision success")
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseCreateCourseRevisionSuccessRepositoryMock(courseRepository, lessonRepository, courseID)
+	course, err := courseService.CreateCourseRevision(context.Background(), courseID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(2, course.Version)
+	t.Assert().Equal(1, course.PublishedVersion)
+}
+
+func CourseCreateCourseRevisionExistsRepositoryMock(repository *mocks.CourseRepository,
+	courseID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithVersion(2, 1).Build(), nil)
+}
+
+func (s *CourseCreateCourseRevisionSuite) TestCreateCourseRevision_Exists(t provider.T) {
+	t.Parallel()
+	t.Title("Course service create course revision when revision exists")
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseCreateCourseRevisionExistsRepositoryMock(courseRepository, courseID)
+	_, err := courseService.CreateCourseRevision(context.Background(), courseID)
+	t.Assert().ErrorIs(err, errs.ErrCourseRevisionExists)
+}
+
+func TestCourseCreateCourseRevisionSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course service create course revision", new(CourseCreateCourseRevisionSuite))
+}
+
+// PublishCourseRevision Suite
+type CoursePublishCourseRevisionSuite struct {
+	CourseSuite
+}
+
+func CoursePublishCourseRevisionSuccessRepositoryMock(repository *mocks.CourseRepository,
+	lessonRepository *mocks.LessonRepository, courseID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithVersion(2, 1).Build(), nil)
+	lessonRepository.
+		On("FindCourseLessons", context.Background(), courseID).
+		Return([]domain.Lesson{
+			NewLessonBuilder().
+				WithType(domain.TheoryLesson).
+				WithTheoryUrl(null.StringFrom("url")).
+				Build(),
+			NewLessonBuilder().
+				WithType(domain.PracticeLesson).
+				WithTests([]domain.Test{NewTestBuilder().Build()}).
+				Build()}, nil)
+	repository.
+		On("PublishRevision", context.Background(), courseID).
+		Return(nil)
+}
+
+func (s *CoursePublishCourseRevisionSuite) TestPublishCourseRevision_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course service publish course revision success")
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CoursePublishCourseRevisionSuccessRepositoryMock(courseRepository, lessonRepository, courseID)
+	err := courseService.PublishCourseRevision(context.Background(), courseID)
+	t.Assert().Nil(err)
+}
+
+func CoursePublishCourseRevisionFailureRepositoryMock(repository *mocks.CourseRepository,
+	courseID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithVersion(1, 1).Build(), nil)
+}
+
+func (s *CoursePublishCourseRevisionSuite) TestPublishCourseRevision_NoRevision(t provider.T) {
+	t.Parallel()
+	t.Title("Course service publish course revision without revision")
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CoursePublishCourseRevisionFailureRepositoryMock(courseRepository, courseID)
+	err := courseService.PublishCourseRevision(context.Background(), courseID)
+	t.Assert().ErrorIs(err, errs.ErrCourseNoRevision)
+}
+
+func TestCoursePublishCourseRevisionSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course service publish course revision", new(CoursePublishCourseRevisionSuite))
+}
+
+// MigrateStudentVersion Suite
+type CourseMigrateStudentVersionSuite struct {
+	CourseSuite
+}
+
+func CourseMigrateStudentVersionSuccessRepositoryMock(repository *mocks.CourseRepository,
+	lessonRepository *mocks.LessonRepository, statRepository *mocks.StatRepository,
+	studentID, courseID domain.ID) {
+	oldLesson := NewLessonBuilder().WithCourseID(courseID).Build()
+	oldLesson.Key = oldLesson.ID
+	newLesson := oldLesson.Revise(2)
+
+	enrollment := NewEnrollmentBuilder().WithStudentID(studentID).WithCourseID(courseID).Build()
+	enrollment.Version = 1
+	repository.
+		On("FindEnrollment", context.Background(), studentID, courseID).
+		Return(enrollment, nil)
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithVersion(2, 2).Build(), nil)
+	lessonRepository.
+		On("FindCourseVersionLessons", context.Background(), courseID, 1).
+		Return([]domain.Lesson{oldLesson}, nil)
+	lessonRepository.
+		On("FindCourseVersionLessons", context.Background(), courseID, 2).
+		Return([]domain.Lesson{newLesson}, nil)
+	statRepository.
+		On("FindLessonStat", context.Background(), studentID, newLesson.ID).
+		Return(domain.LessonStat{}, errs.ErrNotExist)
+	statRepository.
+		On("FindLessonStat", context.Background(), studentID, oldLesson.ID).
+		Return(domain.LessonStat{LessonID: oldLesson.ID, UserID: studentID, Score: 10}, nil)
+	statRepository.
+		On("CreateLessonStat", context.Background(), mock.MatchedBy(func(stat domain.LessonStat) bool {
+			return stat.LessonID == newLesson.ID && stat.Score == newLesson.Score
+		})).
+		Return(nil)
+	repository.
+		On("UpdateEnrollmentVersion", context.Background(), studentID, courseID, 2).
+		Return(nil)
+}
+
+func (s *CourseMigrateStudentVersionSuite) TestMigrateStudentVersion_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Course service migrate student version success")
+	studentID := domain.NewID()
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseMigrateStudentVersionSuccessRepositoryMock(courseRepository, lessonRepository,
+		statRepository, studentID, courseID)
+	enrollment, err := courseService.MigrateStudentVersion(context.Background(), studentID, courseID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(2, enrollment.Version)
+}
+
+func CourseMigrateStudentVersionLatestRepositoryMock(repository *mocks.CourseRepository,
+	studentID, courseID domain.ID) {
+	enrollment := NewEnrollmentBuilder().WithStudentID(studentID).WithCourseID(courseID).Build()
+	enrollment.Version = 2
+	repository.
+		On("FindEnrollment", context.Background(), studentID, courseID).
+		Return(enrollment, nil)
+	repository.
+		On("FindByID", context.Background(), courseID).
+		Return(NewCourseBuilder().WithID(courseID).WithVersion(3, 2).Build(), nil)
+}
+
+func (s *CourseMigrateStudentVersionSuite) TestMigrateStudentVersion_Latest(t provider.T) {
+	t.Parallel()
+	t.Title("Course service migrate student already on the latest version")
+	studentID := domain.NewID()
+	courseID := domain.NewID()
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	CourseMigrateStudentVersionLatestRepositoryMock(courseRepository, studentID, courseID)
+	_, err := courseService.MigrateStudentVersion(context.Background(), studentID, courseID)
+	t.Assert().ErrorIs(err, errs.ErrEnrollmentLatestVersion)
+}
+
+func TestCourseMigrateStudentVersionSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Course service migrate student version", new(CourseMigrateStudentVersionSuite))
+}
@@ -0,0 +1,45 @@
+package unit
+
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type EnrollmentBuilder struct {
+	enrollment domain.Enrollment
+}
+
+func NewEnrollmentBuilder() *EnrollmentBuilder {
+	return &EnrollmentBuilder{
+		enrollment: domain.Enrollment{
+			StudentID:  domain.NewID(),
+			CourseID:   domain.NewID(),
+			EnrolledAt: time.Now().UTC(),
+		},
+	}
+}
+
+func (b *EnrollmentBuilder) WithStudentID(studentID domain.ID) *EnrollmentBuilder {
+	b.enrollment.StudentID = studentID
+	return b
+}
+
+func (b *EnrollmentBuilder) WithCourseID(courseID domain.ID) *EnrollmentBuilder {
+	b.enrollment.CourseID = courseID
+	return b
+}
+
+func (b *EnrollmentBuilder) WithEnrolledAt(enrolledAt time.Time) *EnrollmentBuilder {
+	b.enrollment.EnrolledAt = enrolledAt
+	return b
+}
+
+func (b *EnrollmentBuilder) WithExpiresAt(expiresAt time.Time) *EnrollmentBuilder {
+	b.enrollment.ExpiresAt = null.TimeFrom(expiresAt)
+	return b
+}
+
+func (b *EnrollmentBuilder) Build() domain.Enrollment {
+	return b.enrollment
+}
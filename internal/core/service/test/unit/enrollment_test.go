@@ -0,0 +1,130 @@
+package unit
+
+import (
+	"context"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+	"time"
+)
+
+type EnrollmentSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *EnrollmentSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+// RemindExpiringEnrollments Suite
+type EnrollmentRemindExpiringEnrollmentsSuite struct {
+	EnrollmentSuite
+}
+
+func EnrollmentRemindExpiringEnrollmentsSuccessRepositoryMock(courseRepository *mocks.CourseRepository,
+	userRepository *mocks.UserRepository, mailer *mocks.Mailer,
+	enrollment domain.Enrollment, user domain.User, course domain.Course) {
+	courseRepository.
+		On("FindExpiringEnrollments", context.Background(), mock.Anything, mock.Anything).
+		Return([]domain.Enrollment{enrollment}, nil)
+	userRepository.
+		On("FindByID", context.Background(), enrollment.StudentID).
+		Return(user, nil)
+	courseRepository.
+		On("FindByID", context.Background(), enrollment.CourseID).
+		Return(course, nil)
+	mailer.
+		On("Send", context.Background(), mock.MatchedBy(func(mail domain.Mail) bool {
+			return mail.To == user.Email && strings.Contains(mail.Body, course.Name)
+		})).
+		Return(nil)
+	courseRepository.
+		On("MarkEnrollmentReminded", context.Background(), enrollment.StudentID,
+			enrollment.CourseID, mock.Anything).
+		Return(nil)
+}
+
+func (s *EnrollmentRemindExpiringEnrollmentsSuite) TestRemindExpiringEnrollments_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Remind expiring enrollments success")
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	mailer := mocks.NewMailer(t)
+	enrollmentService := service.NewEnrollmentService(courseRepository, userRepository, mailer, s.logger)
+	user := NewUserBuilder().WithID(domain.NewID()).WithEmail("student@example.com").Build()
+	course := NewCourseBuilder().WithID(domain.NewID()).WithName("Go basics").Build()
+	enrollment := NewEnrollmentBuilder().WithStudentID(user.ID).WithCourseID(course.ID).
+		WithExpiresAt(time.Now().AddDate(0, 0, 3)).Build()
+	EnrollmentRemindExpiringEnrollmentsSuccessRepositoryMock(courseRepository, userRepository,
+		mailer, enrollment, user, course)
+	err := enrollmentService.RemindExpiringEnrollments(context.Background())
+	t.Assert().Nil(err)
+}
+
+func EnrollmentRemindExpiringEnrollmentsMailFailureRepositoryMock(courseRepository *mocks.CourseRepository,
+	userRepository *mocks.UserRepository, mailer *mocks.Mailer,
+	enrollment domain.Enrollment, user domain.User, course domain.Course) {
+	courseRepository.
+		On("FindExpiringEnrollments", context.Background(), mock.Anything, mock.Anything).
+		Return([]domain.Enrollment{enrollment}, nil)
+	userRepository.
+		On("FindByID", context.Background(), enrollment.StudentID).
+		Return(user, nil)
+	courseRepository.
+		On("FindByID", context.Background(), enrollment.CourseID).
+		Return(course, nil)
+	mailer.
+		On("Send", context.Background(), mock.Anything).
+		Return(errors.New("smtp error"))
+}
+
+func (s *EnrollmentRemindExpiringEnrollmentsSuite) TestRemindExpiringEnrollments_MailFailure(t provider.T) {
+	t.Parallel()
+	t.Title("Remind expiring enrollments keeps enrollment not reminded if mail fails")
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	mailer := mocks.NewMailer(t)
+	enrollmentService := service.NewEnrollmentService(courseRepository, userRepository, mailer, s.logger)
+	user := NewUserBuilder().WithID(domain.NewID()).Build()
+	course := NewCourseBuilder().WithID(domain.NewID()).Build()
+	enrollment := NewEnrollmentBuilder().WithStudentID(user.ID).WithCourseID(course.ID).
+		WithExpiresAt(time.Now().AddDate(0, 0, 3)).Build()
+	EnrollmentRemindExpiringEnrollmentsMailFailureRepositoryMock(courseRepository, userRepository,
+		mailer, enrollment, user, course)
+	err := enrollmentService.RemindExpiringEnrollments(context.Background())
+	t.Assert().Nil(err)
+}
+
+func EnrollmentRemindExpiringEnrollmentsFailureRepositoryMock(courseRepository *mocks.CourseRepository) {
+	courseRepository.
+		On("FindExpiringEnrollments", context.Background(), mock.Anything, mock.Anything).
+		Return(nil, errs.ErrPersistenceFailed)
+}
+
+func (s *EnrollmentRemindExpiringEnrollmentsSuite) TestRemindExpiringEnrollments_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Remind expiring enrollments failure")
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	mailer := mocks.NewMailer(t)
+	enrollmentService := service.NewEnrollmentService(courseRepository, userRepository, mailer, s.logger)
+	EnrollmentRemindExpiringEnrollmentsFailureRepositoryMock(courseRepository)
+	err := enrollmentService.RemindExpiringEnrollments(context.Background())
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestEnrollmentRemindExpiringEnrollmentsSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Enrollment service remind expiring enrollments",
+		new(EnrollmentRemindExpiringEnrollmentsSuite))
+}
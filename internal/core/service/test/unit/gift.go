@@ -0,0 +1,41 @@
+package unit
+
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type GiftBuilder struct {
+	gift domain.Gift
+}
+
+func NewGiftBuilder() *GiftBuilder {
+	return &GiftBuilder{
+		gift: domain.Gift{
+			ID:             domain.NewID(),
+			Code:           "GIFTCODE",
+			CourseID:       domain.NewID(),
+			RecipientEmail: "recipient@example.com",
+			PaymentKey:     "key",
+			PaySum:         1000,
+			Currency:       domain.DefaultCurrency,
+			CreatedAt:      time.Now(),
+		},
+	}
+}
+
+func (b *GiftBuilder) WithCourseID(courseID domain.ID) *GiftBuilder {
+	b.gift.CourseID = courseID
+	return b
+}
+
+func (b *GiftBuilder) WithRedeemedBy(userID domain.ID) *GiftBuilder {
+	b.gift.RedeemedBy = null.StringFrom(userID.String())
+	b.gift.RedeemedAt = null.TimeFrom(time.Now())
+	return b
+}
+
+func (b *GiftBuilder) Build() domain.Gift {
+	return b.gift
+}
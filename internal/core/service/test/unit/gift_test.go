@@ -0,0 +1,221 @@
+package unit
+
+import (
+	"context"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+)
+
+type GiftSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *GiftSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+// IssueGift Suite
+type GiftIssueGiftSuite struct {
+	GiftSuite
+}
+
+func GiftIssueGiftSuccessRepositoryMock(repository *mocks.GiftRepository,
+	courseRepository *mocks.CourseRepository, mailer *mocks.Mailer,
+	course domain.Course, payload domain.PaymentPayload) {
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	gift := NewGiftBuilder().WithCourseID(course.ID).Build()
+	repository.
+		On("Create", context.Background(), mock.MatchedBy(func(gift domain.Gift) bool {
+			return gift.CourseID == course.ID && gift.RecipientEmail == payload.RecipientEmail &&
+				gift.PaymentKey == "key" && gift.Code != ""
+		})).
+		Return(gift, nil)
+	mailer.
+		On("Send", context.Background(), mock.MatchedBy(func(mail domain.Mail) bool {
+			return mail.To == gift.RecipientEmail && strings.Contains(mail.Body, gift.Code)
+		})).
+		Return(nil)
+}
+
+func (s *GiftIssueGiftSuite) TestIssueGift_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Issue gift success")
+	repository := mocks.NewGiftRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	mailer := mocks.NewMailer(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	giftService := service.NewGiftService(repository, courseRepository, lessonRepository, statRepository,
+		mailer, s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).Build()
+	payload := domain.PaymentPayload{
+		CourseID:       course.ID,
+		RecipientEmail: "recipient@example.com",
+		PaySum:         course.Price,
+	}
+	GiftIssueGiftSuccessRepositoryMock(repository, courseRepository, mailer, course, payload)
+	_, err := giftService.IssueGift(context.Background(), "key", payload)
+	t.Assert().Nil(err)
+}
+
+func GiftIssueGiftFailureRepositoryMock(courseRepository *mocks.CourseRepository, courseID domain.ID) {
+	courseRepository.
+		On("FindByID", context.Background(), courseID).
+		Return(domain.Course{}, errs.ErrNotExist)
+}
+
+func (s *GiftIssueGiftSuite) TestIssueGift_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Issue gift of unknown course")
+	repository := mocks.NewGiftRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	mailer := mocks.NewMailer(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	giftService := service.NewGiftService(repository, courseRepository, lessonRepository, statRepository,
+		mailer, s.logger)
+	courseID := domain.NewID()
+	GiftIssueGiftFailureRepositoryMock(courseRepository, courseID)
+	_, err := giftService.IssueGift(context.Background(), "key", domain.PaymentPayload{
+		CourseID:       courseID,
+		RecipientEmail: "recipient@example.com",
+	})
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestGiftIssueGiftSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Issue gift", new(GiftIssueGiftSuite))
+}
+
+// RedeemGift Suite
+type GiftRedeemGiftSuite struct {
+	GiftSuite
+}
+
+func GiftRedeemGiftSuccessRepositoryMock(repository *mocks.GiftRepository,
+	courseRepository *mocks.CourseRepository, gift domain.Gift, userID domain.ID) {
+	repository.
+		On("FindByCode", context.Background(), gift.Code).
+		Return(gift, nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), userID, gift.CourseID).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
+	courseRepository.
+		On("FindCoursePrerequisites", context.Background(), gift.CourseID).
+		Return([]domain.CoursePrerequisite{}, nil)
+	repository.
+		On("Redeem", context.Background(), gift.ID, userID, mock.Anything).
+		Return(nil)
+}
+
+func (s *GiftRedeemGiftSuite) TestRedeemGift_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Redeem gift success")
+	repository := mocks.NewGiftRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	mailer := mocks.NewMailer(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	giftService := service.NewGiftService(repository, courseRepository, lessonRepository, statRepository,
+		mailer, s.logger)
+	gift := NewGiftBuilder().Build()
+	userID := domain.NewID()
+	GiftRedeemGiftSuccessRepositoryMock(repository, courseRepository, gift, userID)
+	redeemed, err := giftService.RedeemGift(context.Background(), userID, " giftcode ")
+	t.Assert().Nil(err)
+	t.Assert().Equal(gift.CourseID, redeemed.CourseID)
+}
+
+func GiftRedeemGiftFailureRepositoryMock(repository *mocks.GiftRepository, gift domain.Gift) {
+	repository.
+		On("FindByCode", context.Background(), gift.Code).
+		Return(gift, nil)
+}
+
+func (s *GiftRedeemGiftSuite) TestRedeemGift_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Redeem already redeemed gift")
+	repository := mocks.NewGiftRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	mailer := mocks.NewMailer(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	giftService := service.NewGiftService(repository, courseRepository, lessonRepository, statRepository,
+		mailer, s.logger)
+	gift := NewGiftBuilder().WithRedeemedBy(domain.NewID()).Build()
+	GiftRedeemGiftFailureRepositoryMock(repository, gift)
+	_, err := giftService.RedeemGift(context.Background(), domain.NewID(), gift.Code)
+	t.Assert().ErrorIs(err, errs.ErrGiftAlreadyRedeemed)
+}
+
+func (s *GiftRedeemGiftSuite) TestRedeemGift_Subscriber(t provider.T) {
+	t.Parallel()
+	t.Title("Redeem gift by subscriber enrolls in the course")
+	repository := mocks.NewGiftRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	giftService := service.NewGiftService(repository, courseRepository, lessonRepository, statRepository,
+		mocks.NewMailer(t), s.logger)
+	gift := NewGiftBuilder().Build()
+	userID := domain.NewID()
+	// access by subscription isn't enrollment, the subscriber owns no course
+	GiftRedeemGiftSuccessRepositoryMock(repository, courseRepository, gift, userID)
+	redeemed, err := giftService.RedeemGift(context.Background(), userID, gift.Code)
+	t.Assert().Nil(err)
+	t.Assert().Equal(gift.CourseID, redeemed.CourseID)
+}
+
+func GiftRedeemGiftPrerequisitesRepositoryMock(repository *mocks.GiftRepository,
+	courseRepository *mocks.CourseRepository, lessonRepository *mocks.LessonRepository,
+	statRepository *mocks.StatRepository, gift domain.Gift, userID domain.ID, required domain.Course) {
+	repository.
+		On("FindByCode", context.Background(), gift.Code).
+		Return(gift, nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), userID, gift.CourseID).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
+	courseRepository.
+		On("FindCoursePrerequisites", context.Background(), gift.CourseID).
+		Return([]domain.CoursePrerequisite{{CourseID: gift.CourseID, RequiredCourseID: required.ID}}, nil)
+	courseRepository.
+		On("FindByID", context.Background(), required.ID).
+		Return(required, nil)
+	CourseProgressRepositoryMock(courseRepository, lessonRepository, statRepository, userID, required,
+		[]domain.Lesson{NewLessonBuilder().WithID(domain.NewID()).Build()}, 0)
+}
+
+func (s *GiftRedeemGiftSuite) TestRedeemGift_Prerequisites(t provider.T) {
+	t.Parallel()
+	t.Title("Redeem gift with not completed required course")
+	repository := mocks.NewGiftRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	giftService := service.NewGiftService(repository, courseRepository, lessonRepository, statRepository,
+		mocks.NewMailer(t), s.logger)
+	gift := NewGiftBuilder().Build()
+	userID := domain.NewID()
+	required := NewCourseBuilder().WithID(domain.NewID()).Build()
+	GiftRedeemGiftPrerequisitesRepositoryMock(repository, courseRepository, lessonRepository, statRepository,
+		gift, userID, required)
+	_, err := giftService.RedeemGift(context.Background(), userID, gift.Code)
+	t.Assert().ErrorIs(err, errs.ErrCoursePrerequisitesNotMet)
+}
+
+func TestGiftRedeemGiftSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Redeem gift", new(GiftRedeemGiftSuite))
+}
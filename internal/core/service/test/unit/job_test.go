@@ -0,0 +1,151 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+type JobSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *JobSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+func (s *JobSuite) TestRetryAt(t provider.T) {
+	t.Parallel()
+	t.Title("Job retry delay doubles after every attempt")
+	now := time.Now().UTC()
+	job := domain.NewJob(domain.JobPublishCourse, domain.NewID(), domain.NewID(), now)
+	job.Attempts = 1
+	t.Assert().Equal(now.Add(time.Minute), job.RetryAt(now))
+	job.Attempts = 3
+	t.Assert().Equal(now.Add(4*time.Minute), job.RetryAt(now))
+	t.Assert().False(job.Exhausted())
+	job.Attempts = domain.JobMaxAttempts
+	t.Assert().True(job.Exhausted())
+}
+
+func JobScheduleJobSuccessRepositoryMock(repository *mocks.JobRepository, job domain.Job) {
+	repository.
+		On("Create", context.Background(), job).
+		Return(nil)
+}
+
+func (s *JobSuite) TestScheduleJob_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Job service schedule job success")
+	job := domain.NewJob(domain.JobPublishCourse, domain.NewID(), domain.NewID(), time.Now())
+	jobRepository := mocks.NewJobRepository(t)
+	jobService := service.NewJobService(jobRepository, s.logger)
+	JobScheduleJobSuccessRepositoryMock(jobRepository, job)
+	err := jobService.ScheduleJob(context.Background(), job)
+	t.Assert().Nil(err)
+}
+
+func JobClaimDueJobsSuccessRepositoryMock(repository *mocks.JobRepository) {
+	repository.
+		On("ClaimDueJobs", context.Background(), mock.AnythingOfType("time.Time"),
+			mock.AnythingOfType("time.Time"), 10).
+		Return([]domain.Job{domain.NewJob(domain.JobPublishCourse, domain.NewID(),
+			domain.NewID(), time.Now())}, nil)
+}
+
+func (s *JobSuite) TestClaimDueJobs_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Job service claim due jobs success")
+	jobRepository := mocks.NewJobRepository(t)
+	jobService := service.NewJobService(jobRepository, s.logger)
+	JobClaimDueJobsSuccessRepositoryMock(jobRepository)
+	jobs, err := jobService.ClaimDueJobs(context.Background(), 10)
+	t.Assert().Nil(err)
+	t.Assert().Len(jobs, 1)
+}
+
+func JobClaimDueJobsFailureRepositoryMock(repository *mocks.JobRepository) {
+	repository.
+		On("ClaimDueJobs", context.Background(), mock.AnythingOfType("time.Time"),
+			mock.AnythingOfType("time.Time"), 10).
+		Return(nil, errs.ErrPersistenceFailed)
+}
+
+func (s *JobSuite) TestClaimDueJobs_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Job service claim due jobs failure")
+	jobRepository := mocks.NewJobRepository(t)
+	jobService := service.NewJobService(jobRepository, s.logger)
+	JobClaimDueJobsFailureRepositoryMock(jobRepository)
+	_, err := jobService.ClaimDueJobs(context.Background(), 10)
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func JobCompleteJobSuccessRepositoryMock(repository *mocks.JobRepository, jobID domain.ID) {
+	repository.
+		On("Complete", context.Background(), jobID).
+		Return(nil)
+}
+
+func (s *JobSuite) TestCompleteJob_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Job service complete job success")
+	job := domain.NewJob(domain.JobPublishCourse, domain.NewID(), domain.NewID(), time.Now())
+	jobRepository := mocks.NewJobRepository(t)
+	jobService := service.NewJobService(jobRepository, s.logger)
+	JobCompleteJobSuccessRepositoryMock(jobRepository, job.ID)
+	err := jobService.CompleteJob(context.Background(), job)
+	t.Assert().Nil(err)
+}
+
+func JobFailJobRetryRepositoryMock(repository *mocks.JobRepository, jobID domain.ID) {
+	repository.
+		On("Retry", context.Background(), jobID, mock.AnythingOfType("time.Time"), "failure").
+		Return(nil)
+}
+
+func (s *JobSuite) TestFailJob_Retry(t provider.T) {
+	t.Parallel()
+	t.Title("Job service retries failed job")
+	job := domain.NewJob(domain.JobPublishCourse, domain.NewID(), domain.NewID(), time.Now())
+	job.Attempts = 1
+	jobRepository := mocks.NewJobRepository(t)
+	jobService := service.NewJobService(jobRepository, s.logger)
+	JobFailJobRetryRepositoryMock(jobRepository, job.ID)
+	err := jobService.FailJob(context.Background(), job, errors.New("failure"))
+	t.Assert().Nil(err)
+}
+
+func JobFailJobExhaustedRepositoryMock(repository *mocks.JobRepository, jobID domain.ID) {
+	repository.
+		On("Fail", context.Background(), jobID, "failure").
+		Return(nil)
+}
+
+func (s *JobSuite) TestFailJob_Exhausted(t provider.T) {
+	t.Parallel()
+	t.Title("Job service fails job after the last attempt")
+	job := domain.NewJob(domain.JobPublishCourse, domain.NewID(), domain.NewID(), time.Now())
+	job.Attempts = domain.JobMaxAttempts
+	jobRepository := mocks.NewJobRepository(t)
+	jobService := service.NewJobService(jobRepository, s.logger)
+	JobFailJobExhaustedRepositoryMock(jobRepository, job.ID)
+	err := jobService.FailJob(context.Background(), job, errors.New("failure"))
+	t.Assert().Nil(err)
+}
+
+func TestJobSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Job service", new(JobSuite))
+}
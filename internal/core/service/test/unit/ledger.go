@@ -0,0 +1,59 @@
+package unit
+
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type LedgerEntryBuilder struct {
+	entry domain.LedgerEntry
+}
+
+func NewLedgerEntryBuilder() *LedgerEntryBuilder {
+	return &LedgerEntryBuilder{
+		entry: domain.LedgerEntry{
+			ID:         domain.NewID(),
+			SchoolID:   domain.NewID(),
+			CourseID:   domain.NewID(),
+			UserID:     domain.NewID(),
+			PaymentKey: "key",
+			Kind:       domain.LedgerSale,
+			Gross:      1000,
+			Commission: 100,
+			Currency:   domain.DefaultCurrency,
+			CreatedAt:  time.Now(),
+		},
+	}
+}
+
+func (b *LedgerEntryBuilder) WithSchoolID(schoolID domain.ID) *LedgerEntryBuilder {
+	b.entry.SchoolID = schoolID
+	return b
+}
+
+func (b *LedgerEntryBuilder) WithCourseID(courseID domain.ID) *LedgerEntryBuilder {
+	b.entry.CourseID = courseID
+	return b
+}
+
+func (b *LedgerEntryBuilder) WithCurrency(currency string) *LedgerEntryBuilder {
+	b.entry.Currency = currency
+	return b
+}
+
+func (b *LedgerEntryBuilder) WithRefund() *LedgerEntryBuilder {
+	b.entry.Kind = domain.LedgerRefund
+	b.entry.Gross = -b.entry.Gross
+	b.entry.Commission = -b.entry.Commission
+	return b
+}
+
+func (b *LedgerEntryBuilder) WithPayoutID(payoutID domain.ID) *LedgerEntryBuilder {
+	b.entry.PayoutID = null.StringFrom(payoutID.String())
+	return b
+}
+
+func (b *LedgerEntryBuilder) Build() domain.LedgerEntry {
+	return b.entry
+}
@@ -0,0 +1,201 @@
+package unit
+
+import (
+	"context"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+type LedgerSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *LedgerSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+// FindSchoolStatement Suite
+type LedgerFindSchoolStatementSuite struct {
+	LedgerSuite
+}
+
+func LedgerFindSchoolStatementSuccessRepositoryMock(repository *mocks.LedgerRepository,
+	schoolRepository *mocks.SchoolRepository, schoolID domain.ID,
+	entries []domain.LedgerEntry, courses []domain.Course) {
+	from := time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)
+	repository.
+		On("FindSchoolEntries", context.Background(), schoolID, from, from.AddDate(0, 1, 0)).
+		Return(entries, nil)
+	schoolRepository.
+		On("FindSchoolCourses", context.Background(), schoolID).
+		Return(courses, nil)
+}
+
+func (s *LedgerFindSchoolStatementSuite) TestFindSchoolStatement_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Find school statement success")
+	repository := mocks.NewLedgerRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	ledgerService := service.NewLedgerService(repository, schoolRepository, s.logger)
+	schoolID := domain.NewID()
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build()
+	entries := []domain.LedgerEntry{
+		NewLedgerEntryBuilder().WithSchoolID(schoolID).WithCourseID(course.ID).
+			WithPayoutID(domain.NewID()).Build(),
+		NewLedgerEntryBuilder().WithSchoolID(schoolID).WithCourseID(course.ID).Build(),
+		NewLedgerEntryBuilder().WithSchoolID(schoolID).WithCourseID(course.ID).WithRefund().Build(),
+	}
+	LedgerFindSchoolStatementSuccessRepositoryMock(repository, schoolRepository, schoolID,
+		entries, []domain.Course{course})
+	statement, err := ledgerService.FindSchoolStatement(context.Background(), schoolID,
+		time.Date(2024, time.October, 15, 12, 0, 0, 0, time.UTC))
+	t.Assert().Nil(err)
+	t.Assert().Len(statement.Courses, 1)
+	t.Assert().Equal(course.Name, statement.Courses[0].CourseName)
+	t.Assert().Equal(2, statement.Courses[0].Sales)
+	t.Assert().Equal(1, statement.Courses[0].Refunds)
+	t.Assert().Len(statement.Totals, 1)
+	t.Assert().Equal(domain.DefaultCurrency, statement.Totals[0].Currency)
+	t.Assert().Equal(int64(1000), statement.Totals[0].Gross)
+	t.Assert().Equal(int64(100), statement.Totals[0].Commission)
+	t.Assert().Equal(int64(900), statement.Totals[0].Net)
+	t.Assert().Equal(int64(900), statement.Totals[0].Settled)
+}
+
+func (s *LedgerFindSchoolStatementSuite) TestFindSchoolStatement_SeveralCurrencies(t provider.T) {
+	t.Parallel()
+	t.Title("Find school statement with sales in several currencies")
+	repository := mocks.NewLedgerRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	ledgerService := service.NewLedgerService(repository, schoolRepository, s.logger)
+	schoolID := domain.NewID()
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build()
+	entries := []domain.LedgerEntry{
+		NewLedgerEntryBuilder().WithSchoolID(schoolID).WithCourseID(course.ID).Build(),
+		NewLedgerEntryBuilder().WithSchoolID(schoolID).WithCourseID(course.ID).WithCurrency("USD").Build(),
+		NewLedgerEntryBuilder().WithSchoolID(schoolID).WithCourseID(course.ID).WithCurrency("USD").Build(),
+	}
+	LedgerFindSchoolStatementSuccessRepositoryMock(repository, schoolRepository, schoolID,
+		entries, []domain.Course{course})
+	statement, err := ledgerService.FindSchoolStatement(context.Background(), schoolID,
+		time.Date(2024, time.October, 15, 12, 0, 0, 0, time.UTC))
+	t.Assert().Nil(err)
+	t.Assert().Len(statement.Courses, 2)
+	t.Assert().Equal("RUB", statement.Courses[0].Currency)
+	t.Assert().Equal(1, statement.Courses[0].Sales)
+	t.Assert().Equal("USD", statement.Courses[1].Currency)
+	t.Assert().Equal(2, statement.Courses[1].Sales)
+	t.Assert().Len(statement.Totals, 2)
+	t.Assert().Equal("RUB", statement.Totals[0].Currency)
+	t.Assert().Equal(int64(900), statement.Totals[0].Net)
+	t.Assert().Equal("USD", statement.Totals[1].Currency)
+	t.Assert().Equal(int64(1800), statement.Totals[1].Net)
+}
+
+func LedgerFindSchoolStatementFailureRepositoryMock(repository *mocks.LedgerRepository) {
+	repository.
+		On("FindSchoolEntries", context.Background(), mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errs.ErrNotExist)
+}
+
+func (s *LedgerFindSchoolStatementSuite) TestFindSchoolStatement_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Find school statement failure")
+	repository := mocks.NewLedgerRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	ledgerService := service.NewLedgerService(repository, schoolRepository, s.logger)
+	LedgerFindSchoolStatementFailureRepositoryMock(repository)
+	_, err := ledgerService.FindSchoolStatement(context.Background(), domain.NewID(), time.Now())
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestLedgerFindSchoolStatementSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Find school statement", new(LedgerFindSchoolStatementSuite))
+}
+
+// CreateSchoolPayout Suite
+type LedgerCreateSchoolPayoutSuite struct {
+	LedgerSuite
+}
+
+func LedgerCreateSchoolPayoutSuccessRepositoryMock(repository *mocks.LedgerRepository,
+	schoolID domain.ID, currency string, settledUntil time.Time) {
+	repository.
+		On("CreatePayout", context.Background(), mock.MatchedBy(func(payout domain.Payout) bool {
+			return payout.SchoolID == schoolID && payout.Currency == currency &&
+				payout.SettledUntil.Equal(settledUntil)
+		})).
+		Return(domain.Payout{SchoolID: schoolID, Amount: 900, Currency: currency,
+			SettledUntil: settledUntil}, nil)
+}
+
+func (s *LedgerCreateSchoolPayoutSuite) TestCreateSchoolPayout_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Create school payout success")
+	repository := mocks.NewLedgerRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	ledgerService := service.NewLedgerService(repository, schoolRepository, s.logger)
+	schoolID := domain.NewID()
+	settledUntil := time.Date(2024, time.November, 1, 0, 0, 0, 0, time.UTC)
+	LedgerCreateSchoolPayoutSuccessRepositoryMock(repository, schoolID, domain.DefaultCurrency, settledUntil)
+	payout, err := ledgerService.CreateSchoolPayout(context.Background(), schoolID, "", settledUntil)
+	t.Assert().Nil(err)
+	t.Assert().Equal(int64(900), payout.Amount)
+	t.Assert().Equal(domain.DefaultCurrency, payout.Currency)
+}
+
+func (s *LedgerCreateSchoolPayoutSuite) TestCreateSchoolPayout_Currency(t provider.T) {
+	t.Parallel()
+	t.Title("Create school payout in the given currency")
+	repository := mocks.NewLedgerRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	ledgerService := service.NewLedgerService(repository, schoolRepository, s.logger)
+	schoolID := domain.NewID()
+	settledUntil := time.Date(2024, time.November, 1, 0, 0, 0, 0, time.UTC)
+	LedgerCreateSchoolPayoutSuccessRepositoryMock(repository, schoolID, "USD", settledUntil)
+	payout, err := ledgerService.CreateSchoolPayout(context.Background(), schoolID, "USD", settledUntil)
+	t.Assert().Nil(err)
+	t.Assert().Equal("USD", payout.Currency)
+}
+
+func (s *LedgerCreateSchoolPayoutSuite) TestCreateSchoolPayout_InvalidCurrency(t provider.T) {
+	t.Parallel()
+	t.Title("Create school payout in unsupported currency")
+	repository := mocks.NewLedgerRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	ledgerService := service.NewLedgerService(repository, schoolRepository, s.logger)
+	_, err := ledgerService.CreateSchoolPayout(context.Background(), domain.NewID(), "XXX", time.Time{})
+	t.Assert().ErrorIs(err, errs.ErrPayoutInvalidCurrency)
+}
+
+func LedgerCreateSchoolPayoutFailureRepositoryMock(repository *mocks.LedgerRepository) {
+	repository.
+		On("CreatePayout", context.Background(), mock.Anything).
+		Return(domain.Payout{}, errs.ErrNothingToSettle)
+}
+
+func (s *LedgerCreateSchoolPayoutSuite) TestCreateSchoolPayout_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Create school payout without unsettled earnings")
+	repository := mocks.NewLedgerRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	ledgerService := service.NewLedgerService(repository, schoolRepository, s.logger)
+	LedgerCreateSchoolPayoutFailureRepositoryMock(repository)
+	_, err := ledgerService.CreateSchoolPayout(context.Background(), domain.NewID(), "", time.Time{})
+	t.Assert().ErrorIs(err, errs.ErrNothingToSettle)
+}
+
+func TestLedgerCreateSchoolPayoutSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Create school payout", new(LedgerCreateSchoolPayoutSuite))
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/guregu/null"
 	"github.com/paw1a/eschool/internal/core/domain"
 	"github.com/paw1a/eschool/internal/core/port"
+	"time"
 )
 
 type LessonMother struct {
@@ -35,6 +36,7 @@ type LessonBuilder struct {
 func NewLessonBuilder() *LessonBuilder {
 	return &LessonBuilder{
 		lesson: domain.Lesson{
+			Version:   1,
 			Title:     "title",
 			Score:     10,
 			Type:      domain.TheoryLesson,
@@ -50,6 +52,16 @@ func (b *LessonBuilder) WithID(id domain.ID) *LessonBuilder {
 	return b
 }
 
+func (b *LessonBuilder) WithKey(key domain.ID) *LessonBuilder {
+	b.lesson.Key = key
+	return b
+}
+
+func (b *LessonBuilder) WithVersion(version int) *LessonBuilder {
+	b.lesson.Version = version
+	return b
+}
+
 func (b *LessonBuilder) WithCourseID(courseID domain.ID) *LessonBuilder {
 	b.lesson.CourseID = courseID
 	return b
@@ -85,6 +97,21 @@ func (b *LessonBuilder) WithTests(tests []domain.Test) *LessonBuilder {
 	return b
 }
 
+func (b *LessonBuilder) WithReleaseAt(releaseAt time.Time) *LessonBuilder {
+	b.lesson.ReleaseAt = null.TimeFrom(releaseAt)
+	return b
+}
+
+func (b *LessonBuilder) WithReleaseDays(releaseDays int64) *LessonBuilder {
+	b.lesson.ReleaseDays = null.IntFrom(releaseDays)
+	return b
+}
+
+func (b *LessonBuilder) WithPreview(isPreview bool) *LessonBuilder {
+	b.lesson.IsPreview = isPreview
+	return b
+}
+
 func (b *LessonBuilder) Build() domain.Lesson {
 	return b.lesson
 }
@@ -110,6 +137,11 @@ func (b *TestBuilder) WithID(id domain.ID) *TestBuilder {
 	return b
 }
 
+func (b *TestBuilder) WithKey(key domain.ID) *TestBuilder {
+	b.test.Key = key
+	return b
+}
+
 func (b *TestBuilder) WithLessonID(lessonID domain.ID) *TestBuilder {
 	b.test.LessonID = lessonID
 	return b
@@ -8,11 +8,13 @@ import (
 	"github.com/ozontech/allure-go/pkg/framework/suite"
 	"github.com/paw1a/eschool/internal/core/domain"
 	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
 	"github.com/paw1a/eschool/internal/core/service"
 	"github.com/paw1a/eschool/internal/core/service/mocks"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 	"testing"
+	"time"
 )
 
 type LessonSuite struct {
@@ -26,6 +28,12 @@ func (s *LessonSuite) BeforeEach(t provider.T) {
 	s.logger, _ = loggerBuilder.Build()
 }
 
+func LessonDraftCourseRepositoryMock(repository *mocks.CourseRepository) {
+	repository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewCourseBuilder().Build(), nil)
+}
+
 // FindAll Suite
 type LessonFindAllSuite struct {
 	LessonSuite
@@ -42,7 +50,9 @@ func (s *LessonFindAllSuite) TestFindAll_Success(t provider.T) {
 	t.Title("Lesson service find all success")
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
 	LessonFindAllSuccessRepositoryMock(lessonRepository)
 	_, err := lessonService.FindAll(context.Background())
 	t.Assert().Nil(err)
@@ -59,7 +69,9 @@ func (s *LessonFindAllSuite) TestFindAll_Failure(t provider.T) {
 	t.Title("Lesson service find all failure")
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
 	LessonFindAllFailureRepositoryMock(lessonRepository)
 	_, err := lessonService.FindAll(context.Background())
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
@@ -87,7 +99,9 @@ func (s *LessonFindByIDSuite) TestFindByID_Success(t provider.T) {
 	lesson := NewLessonMother("title", 10).Create()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
 	LessonFindByIDSuccessRepositoryMock(lessonRepository, lesson)
 	actual, err := lessonService.FindByID(context.Background(), lesson.ID)
 	t.Assert().Nil(err)
@@ -106,7 +120,9 @@ func (s *LessonFindByIDSuite) TestFindByID_Failure(t provider.T) {
 	lessonID := domain.NewID()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
 	LessonFindByIDFailureRepositoryMock(lessonRepository, lessonID)
 	_, err := lessonService.FindByID(context.Background(), lessonID)
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
@@ -135,7 +151,9 @@ func (s *LessonFindCourseLessonsSuite) TestFindUserLessons_Success(t provider.T)
 	lesson := NewLessonMother("title", 10).Create()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
 	LessonFindCourseLessonsSuccessRepositoryMock(lessonRepository, courseID, lesson)
 	lessons, err := lessonService.FindCourseLessons(context.Background(), courseID)
 	t.Assert().Nil(err)
@@ -154,7 +172,9 @@ func (s *LessonFindCourseLessonsSuite) TestFindUserLessons_Failure(t provider.T)
 	courseID := domain.NewID()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
 	LessonFindCourseLessonsFailureRepositoryMock(lessonRepository, courseID)
 	_, err := lessonService.FindCourseLessons(context.Background(), courseID)
 	t.Assert().ErrorIs(err, errs.ErrNotExist)
@@ -187,7 +207,10 @@ func (s *LessonCreateTheoryLessonSuite) TestCreateTheoryLesson_Success(t provide
 	param := NewCreateTheoryParamBuilder().WithTitle(title).Build()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonDraftCourseRepositoryMock(courseRepository)
 	LessonCreateTheoryLessonSuccessRepositoryMock(lessonRepository, objectStorage, title)
 	lesson, err := lessonService.CreateTheoryLesson(context.Background(), courseID, param)
 	t.Assert().Nil(err)
@@ -211,7 +234,10 @@ func (s *LessonCreateTheoryLessonSuite) TestCreateTheoryLesson_Failure(t provide
 	param := NewCreateTheoryParamBuilder().Build()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonDraftCourseRepositoryMock(courseRepository)
 	LessonCreateTheoryLessonFailureRepositoryMock(lessonRepository, objectStorage)
 	_, err := lessonService.CreateTheoryLesson(context.Background(), courseID, param)
 	t.Assert().NotNil(err)
@@ -240,7 +266,10 @@ func (s *LessonCreateVideoLessonSuite) TestCreateVideoLesson_Success(t provider.
 	param := NewCreateVideoParamBuilder().WithTitle(title).Build()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonDraftCourseRepositoryMock(courseRepository)
 	LessonCreateVideoLessonSuccessRepositoryMock(lessonRepository, title)
 	lesson, err := lessonService.CreateVideoLesson(context.Background(), courseID, param)
 	t.Assert().Nil(err)
@@ -260,7 +289,10 @@ func (s *LessonCreateVideoLessonSuite) TestCreateVideoLesson_Failure(t provider.
 	param := NewCreateVideoParamBuilder().Build()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonDraftCourseRepositoryMock(courseRepository)
 	LessonCreateVideoLessonFailureRepositoryMock(lessonRepository)
 	_, err := lessonService.CreateVideoLesson(context.Background(), courseID, param)
 	t.Assert().NotNil(err)
@@ -293,7 +325,10 @@ func (s *LessonCreatePracticeLessonSuite) TestCreatePracticeLesson_Success(t pro
 	param := NewCreatePracticeParamBuilder().WithTitle(title).Build()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonDraftCourseRepositoryMock(courseRepository)
 	LessonCreatePracticeLessonSuccessRepositoryMock(lessonRepository, objectStorage, title)
 	lesson, err := lessonService.CreatePracticeLesson(context.Background(), courseID, param)
 	t.Assert().Nil(err)
@@ -317,7 +352,10 @@ func (s *LessonCreatePracticeLessonSuite) TestCreatePracticeLesson_Failure(t pro
 	param := NewCreatePracticeParamBuilder().Build()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonDraftCourseRepositoryMock(courseRepository)
 	LessonCreatePracticeLessonFailureRepositoryMock(lessonRepository, objectStorage)
 	_, err := lessonService.CreatePracticeLesson(context.Background(), courseID, param)
 	t.Assert().NotNil(err)
@@ -350,7 +388,10 @@ func (s *LessonUpdateTheoryLessonSuite) TestUpdateTheoryLesson_Success(t provide
 	param := NewUpdateTheoryParamBuilder().WithTitle(null.StringFrom(title)).Build()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonDraftCourseRepositoryMock(courseRepository)
 	LessonUpdateTheoryLessonSuccessRepositoryMock(lessonRepository, objectStorage, title, lessonID)
 	lesson, err := lessonService.UpdateTheoryLesson(context.Background(), lessonID, param)
 	t.Assert().Nil(err)
@@ -374,12 +415,40 @@ func (s *LessonUpdateTheoryLessonSuite) TestUpdateTheoryLesson_Failure(t provide
 	param := NewUpdateTheoryParamBuilder().Build()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonDraftCourseRepositoryMock(courseRepository)
 	LessonUpdateTheoryLessonFailureRepositoryMock(lessonRepository, objectStorage, lessonID)
 	_, err := lessonService.UpdateTheoryLesson(context.Background(), lessonID, param)
 	t.Assert().NotNil(err)
 }
 
+func LessonUpdateTheoryLessonPublishedRepositoryMock(repository *mocks.LessonRepository,
+	courseRepository *mocks.CourseRepository, lessonID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), lessonID).
+		Return(NewLessonBuilder().WithID(lessonID).Build(), nil)
+	courseRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewCourseBuilder().WithVersion(1, 1).Build(), nil)
+}
+
+func (s *LessonUpdateTheoryLessonSuite) TestUpdateTheoryLesson_Published(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson service update theory lesson of the published version")
+	lessonID := domain.NewID()
+	param := NewUpdateTheoryParamBuilder().Build()
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonUpdateTheoryLessonPublishedRepositoryMock(lessonRepository, courseRepository, lessonID)
+	_, err := lessonService.UpdateTheoryLesson(context.Background(), lessonID, param)
+	t.Assert().ErrorIs(err, errs.ErrLessonPublished)
+}
+
 func TestLessonUpdateTheoryLessonSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Lesson service update theory lesson", new(LessonUpdateTheoryLessonSuite))
 }
@@ -407,7 +476,10 @@ func (s *LessonUpdateVideoLessonSuite) TestUpdateVideoLesson_Success(t provider.
 	param := NewUpdateVideoParamBuilder().WithTitle(null.StringFrom(title)).Build()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonDraftCourseRepositoryMock(courseRepository)
 	LessonUpdateVideoLessonSuccessRepositoryMock(lessonRepository, title, lessonID)
 	lesson, err := lessonService.UpdateVideoLesson(context.Background(), lessonID, param)
 	t.Assert().Nil(err)
@@ -430,7 +502,10 @@ func (s *LessonUpdateVideoLessonSuite) TestUpdateVideoLesson_Failure(t provider.
 	param := NewUpdateVideoParamBuilder().Build()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonDraftCourseRepositoryMock(courseRepository)
 	LessonUpdateVideoLessonFailureRepositoryMock(lessonRepository, lessonID)
 	_, err := lessonService.UpdateVideoLesson(context.Background(), lessonID, param)
 	t.Assert().NotNil(err)
@@ -466,7 +541,10 @@ func (s *LessonUpdatePracticeLessonSuite) TestUpdatePracticeLesson_Success(t pro
 	param := NewUpdatePracticeParamBuilder().WithTitle(null.StringFrom(title)).Build()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonDraftCourseRepositoryMock(courseRepository)
 	LessonUpdatePracticeLessonSuccessRepositoryMock(lessonRepository, objectStorage, title, lessonID)
 	lesson, err := lessonService.UpdatePracticeLesson(context.Background(), lessonID, param)
 	t.Assert().Nil(err)
@@ -493,7 +571,10 @@ func (s *LessonUpdatePracticeLessonSuite) TestUpdatePracticeLesson_Failure(t pro
 	param := NewUpdatePracticeParamBuilder().Build()
 	lessonRepository := mocks.NewLessonRepository(t)
 	objectStorage := mocks.NewObjectStorage(t)
-	lessonService := service.NewLessonService(lessonRepository, objectStorage, s.logger)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonDraftCourseRepositoryMock(courseRepository)
 	LessonUpdatePracticeLessonFailureRepositoryMock(lessonRepository, objectStorage, lessonID)
 	_, err := lessonService.UpdatePracticeLesson(context.Background(), lessonID, param)
 	t.Assert().NotNil(err)
@@ -502,3 +583,64 @@ func (s *LessonUpdatePracticeLessonSuite) TestUpdatePracticeLesson_Failure(t pro
 func TestLessonUpdatePracticeLessonSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Lesson service update practice lesson", new(LessonUpdatePracticeLessonSuite))
 }
+
+// UpdateLessonRelease Suite
+type LessonUpdateLessonReleaseSuite struct {
+	LessonSuite
+}
+
+func LessonUpdateLessonReleaseSuccessRepositoryMock(repository *mocks.LessonRepository,
+	lessonID domain.ID) {
+	repository.
+		On("UpdateRelease", context.Background(), lessonID, null.Time{}, null.IntFrom(7)).
+		Return(NewLessonBuilder().WithID(lessonID).WithReleaseDays(7).Build(), nil)
+}
+
+func (s *LessonUpdateLessonReleaseSuite) TestUpdateLessonRelease_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson service update lesson release success")
+	lessonID := domain.NewID()
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	LessonUpdateLessonReleaseSuccessRepositoryMock(lessonRepository, lessonID)
+	lesson, err := lessonService.UpdateLessonRelease(context.Background(), lessonID,
+		port.UpdateLessonReleaseParam{ReleaseDays: null.IntFrom(7)})
+	t.Assert().Nil(err)
+	t.Assert().Equal(int64(7), lesson.ReleaseDays.Int64)
+}
+
+func (s *LessonUpdateLessonReleaseSuite) TestUpdateLessonRelease_BothRules(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson service update lesson release with both rules")
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	_, err := lessonService.UpdateLessonRelease(context.Background(), domain.NewID(),
+		port.UpdateLessonReleaseParam{
+			ReleaseAt:   null.TimeFrom(time.Now()),
+			ReleaseDays: null.IntFrom(7),
+		})
+	t.Assert().ErrorIs(err, errs.ErrLessonInvalidRelease)
+}
+
+func (s *LessonUpdateLessonReleaseSuite) TestUpdateLessonRelease_NegativeDays(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson service update lesson release with negative days")
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	_, err := lessonService.UpdateLessonRelease(context.Background(), domain.NewID(),
+		port.UpdateLessonReleaseParam{ReleaseDays: null.IntFrom(-1)})
+	t.Assert().ErrorIs(err, errs.ErrLessonInvalidRelease)
+}
+
+func TestLessonUpdateLessonReleaseSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Lesson service update lesson release", new(LessonUpdateLessonReleaseSuite))
+}
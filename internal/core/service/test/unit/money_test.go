@@ -0,0 +1,69 @@
+package unit
+
+import (
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"testing"
+)
+
+type MoneySuite struct {
+	suite.Suite
+}
+
+func (s *MoneySuite) TestFormat_English(t provider.T) {
+	t.Parallel()
+	t.Title("Money is formatted with symbol before the amount in english locale")
+	t.Assert().Equal("$1,234.50", domain.NewMoney(123450, "USD").Format("en-US"))
+	t.Assert().Equal("¥1,500", domain.NewMoney(1500, "JPY").Format("en"))
+	t.Assert().Equal("$0.05", domain.NewMoney(5, "USD").Format("en"))
+}
+
+func (s *MoneySuite) TestFormat_Russian(t provider.T) {
+	t.Parallel()
+	t.Title("Money is formatted with symbol after the amount in russian locale")
+	t.Assert().Equal("1\u00a0234\u00a0567,00\u00a0₽", domain.NewMoney(123456700, "RUB").Format("ru-RU"))
+}
+
+func (s *MoneySuite) TestFormat_UnknownLocale(t provider.T) {
+	t.Parallel()
+	t.Title("Money in unknown locale is formatted as english")
+	t.Assert().Equal("€39.90", domain.NewMoney(3990, "EUR").Format("xx"))
+}
+
+func (s *MoneySuite) TestDecimal(t provider.T) {
+	t.Parallel()
+	t.Title("Money decimal has no grouping and a dot separator")
+	t.Assert().Equal("3990.50", domain.NewMoney(399050, "RUB").Decimal())
+	t.Assert().Equal("1500", domain.NewMoney(1500, "JPY").Decimal())
+}
+
+func (s *MoneySuite) TestParseAmount_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Decimal amount is converted to minor units")
+	amount, err := domain.ParseAmount("3990.5", "RUB")
+	t.Assert().Nil(err)
+	t.Assert().Equal(int64(399050), amount)
+
+	amount, err = domain.ParseAmount("100", "RUB")
+	t.Assert().Nil(err)
+	t.Assert().Equal(int64(10000), amount)
+}
+
+func (s *MoneySuite) TestParseAmount_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Invalid decimal amount is rejected")
+	_, err := domain.ParseAmount("10.555", "RUB")
+	t.Assert().ErrorIs(err, errs.ErrInvalidMoneyAmount)
+	_, err = domain.ParseAmount("10.5", "JPY")
+	t.Assert().ErrorIs(err, errs.ErrInvalidMoneyAmount)
+	_, err = domain.ParseAmount("-10", "RUB")
+	t.Assert().ErrorIs(err, errs.ErrInvalidMoneyAmount)
+	_, err = domain.ParseAmount("10", "XYZ")
+	t.Assert().ErrorIs(err, errs.ErrCurrencyNotSupported)
+}
+
+func TestMoneySuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Money", new(MoneySuite))
+}
@@ -0,0 +1,31 @@
+package unit
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type LearningPathBuilder struct {
+	path domain.LearningPath
+}
+
+func NewLearningPathBuilder() *LearningPathBuilder {
+	return &LearningPathBuilder{
+		path: domain.LearningPath{
+			ID:          domain.NewID(),
+			SchoolID:    domain.NewID(),
+			Name:        "path name",
+			Description: "path description",
+			CreatedAt:   time.Now(),
+		},
+	}
+}
+
+func (b *LearningPathBuilder) WithSchoolID(schoolID domain.ID) *LearningPathBuilder {
+	b.path.SchoolID = schoolID
+	return b
+}
+
+func (b *LearningPathBuilder) Build() domain.LearningPath {
+	return b.path
+}
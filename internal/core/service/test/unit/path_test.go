@@ -0,0 +1,290 @@
+package unit
+
+import (
+	"context"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"testing"
+)
+
+type LearningPathSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *LearningPathSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+// CourseProgressRepositoryMock makes the user pass the given number
+// of lessons of the course, the user is not enrolled in the course
+func CourseProgressRepositoryMock(courseRepository *mocks.CourseRepository,
+	lessonRepository *mocks.LessonRepository, statRepository *mocks.StatRepository,
+	userID domain.ID, course domain.Course, lessons []domain.Lesson, passed int) {
+	courseRepository.
+		On("FindEnrollment", context.Background(), userID, course.ID).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
+	lessonRepository.
+		On("FindCourseVersionLessons", context.Background(), course.ID, course.LearnerVersion()).
+		Return(lessons, nil)
+	for i, lesson := range lessons {
+		if i < passed {
+			statRepository.
+				On("FindLessonStat", context.Background(), userID, lesson.ID).
+				Return(domain.LessonStat{LessonID: lesson.ID, UserID: userID, Score: lesson.Score}, nil)
+		} else {
+			statRepository.
+				On("FindLessonStat", context.Background(), userID, lesson.ID).
+				Return(domain.LessonStat{}, errs.ErrNotExist)
+		}
+	}
+}
+
+// CreateSchoolPath Suite
+type LearningPathCreateSchoolPathSuite struct {
+	LearningPathSuite
+}
+
+func LearningPathCreateSchoolPathSuccessRepositoryMock(repository *mocks.LearningPathRepository,
+	courseRepository *mocks.CourseRepository, schoolID domain.ID, courses []domain.Course) {
+	courseIDs := make([]domain.ID, len(courses))
+	for i, course := range courses {
+		courseIDs[i] = course.ID
+		courseRepository.
+			On("FindByID", context.Background(), course.ID).
+			Return(course, nil)
+	}
+	repository.
+		On("Create", context.Background(), mock.MatchedBy(func(path domain.LearningPath) bool {
+			return path.SchoolID == schoolID
+		}), courseIDs).
+		Return(NewLearningPathBuilder().WithSchoolID(schoolID).Build(), nil)
+}
+
+func (s *LearningPathCreateSchoolPathSuite) TestCreateSchoolPath_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Create school learning path success")
+	repository := mocks.NewLearningPathRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	pathService := service.NewLearningPathService(repository, courseRepository,
+		lessonRepository, statRepository, s.logger)
+	schoolID := domain.NewID()
+	courses := []domain.Course{
+		NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build(),
+		NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build(),
+	}
+	LearningPathCreateSchoolPathSuccessRepositoryMock(repository, courseRepository, schoolID, courses)
+	_, err := pathService.CreateSchoolPath(context.Background(), schoolID, port.CreatePathParam{
+		Name:      "path",
+		CourseIDs: []domain.ID{courses[0].ID, courses[1].ID, courses[0].ID},
+	})
+	t.Assert().Nil(err)
+}
+
+func LearningPathCreateSchoolPathFailureRepositoryMock(courseRepository *mocks.CourseRepository,
+	courses []domain.Course) {
+	for _, course := range courses {
+		courseRepository.
+			On("FindByID", context.Background(), course.ID).
+			Return(course, nil).Maybe()
+	}
+}
+
+func (s *LearningPathCreateSchoolPathSuite) TestCreateSchoolPath_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Create school learning path with course of another school")
+	repository := mocks.NewLearningPathRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	pathService := service.NewLearningPathService(repository, courseRepository,
+		lessonRepository, statRepository, s.logger)
+	schoolID := domain.NewID()
+	courses := []domain.Course{
+		NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build(),
+		NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).Build(),
+	}
+	LearningPathCreateSchoolPathFailureRepositoryMock(courseRepository, courses)
+	_, err := pathService.CreateSchoolPath(context.Background(), schoolID, port.CreatePathParam{
+		Name:      "path",
+		CourseIDs: []domain.ID{courses[0].ID, courses[1].ID},
+	})
+	t.Assert().ErrorIs(err, errs.ErrLearningPathCourseNotInSchool)
+}
+
+func (s *LearningPathCreateSchoolPathSuite) TestCreateSchoolPath_TooFewCourses(t provider.T) {
+	t.Parallel()
+	t.Title("Create school learning path with a single course")
+	repository := mocks.NewLearningPathRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	pathService := service.NewLearningPathService(repository, courseRepository,
+		lessonRepository, statRepository, s.logger)
+	courseID := domain.NewID()
+	_, err := pathService.CreateSchoolPath(context.Background(), domain.NewID(), port.CreatePathParam{
+		Name:      "path",
+		CourseIDs: []domain.ID{courseID, courseID},
+	})
+	t.Assert().ErrorIs(err, errs.ErrLearningPathTooFewCourses)
+}
+
+func TestLearningPathCreateSchoolPathSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Create school learning path", new(LearningPathCreateSchoolPathSuite))
+}
+
+// FindPathProgress Suite
+type LearningPathFindPathProgressSuite struct {
+	LearningPathSuite
+}
+
+func (s *LearningPathFindPathProgressSuite) TestFindPathProgress_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Find learning path progress across its courses")
+	repository := mocks.NewLearningPathRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	pathService := service.NewLearningPathService(repository, courseRepository,
+		lessonRepository, statRepository, s.logger)
+	userID := domain.NewID()
+	path := NewLearningPathBuilder().Build()
+	first := NewCourseBuilder().WithID(domain.NewID()).Build()
+	second := NewCourseBuilder().WithID(domain.NewID()).Build()
+	repository.
+		On("FindPathCourses", context.Background(), path.ID).
+		Return([]domain.Course{first, second}, nil)
+	CourseProgressRepositoryMock(courseRepository, lessonRepository, statRepository, userID, first,
+		[]domain.Lesson{NewLessonBuilder().WithID(domain.NewID()).Build()}, 1)
+	CourseProgressRepositoryMock(courseRepository, lessonRepository, statRepository, userID, second,
+		[]domain.Lesson{
+			NewLessonBuilder().WithID(domain.NewID()).Build(),
+			NewLessonBuilder().WithID(domain.NewID()).Build(),
+		}, 1)
+	progress, err := pathService.FindPathProgress(context.Background(), userID, path.ID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(1, progress.CompletedCourses())
+	t.Assert().Equal(second.ID, progress.CurrentCourseID())
+	t.Assert().Equal(20, progress.Score())
+	t.Assert().False(progress.Completed())
+}
+
+func TestLearningPathFindPathProgressSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Find learning path progress", new(LearningPathFindPathProgressSuite))
+}
+
+// IssuePathCertificate Suite
+type LearningPathIssuePathCertificateSuite struct {
+	LearningPathSuite
+}
+
+func LearningPathIssuePathCertificateSuccessRepositoryMock(repository *mocks.LearningPathRepository,
+	courseRepository *mocks.CourseRepository, lessonRepository *mocks.LessonRepository,
+	statRepository *mocks.StatRepository, userID, pathID domain.ID, courses []domain.Course) {
+	repository.
+		On("FindUserCertificate", context.Background(), userID, pathID).
+		Return(domain.PathCertificate{}, errs.ErrNotExist)
+	repository.
+		On("FindPathCourses", context.Background(), pathID).
+		Return(courses, nil)
+	for _, course := range courses {
+		CourseProgressRepositoryMock(courseRepository, lessonRepository, statRepository, userID, course,
+			[]domain.Lesson{NewLessonBuilder().WithID(domain.NewID()).Build()}, 1)
+	}
+	repository.
+		On("CreateCertificate", context.Background(), mock.MatchedBy(func(certificate domain.PathCertificate) bool {
+			return certificate.UserID == userID && certificate.PathID == pathID && certificate.Score == 20
+		})).
+		Return(domain.PathCertificate{ID: domain.NewID(), UserID: userID, PathID: pathID, Score: 20}, nil)
+}
+
+func (s *LearningPathIssuePathCertificateSuite) TestIssuePathCertificate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Issue certificate of the completed learning path")
+	repository := mocks.NewLearningPathRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	pathService := service.NewLearningPathService(repository, courseRepository,
+		lessonRepository, statRepository, s.logger)
+	userID := domain.NewID()
+	path := NewLearningPathBuilder().Build()
+	courses := []domain.Course{
+		NewCourseBuilder().WithID(domain.NewID()).Build(),
+		NewCourseBuilder().WithID(domain.NewID()).Build(),
+	}
+	LearningPathIssuePathCertificateSuccessRepositoryMock(repository, courseRepository, lessonRepository,
+		statRepository, userID, path.ID, courses)
+	certificate, err := pathService.IssuePathCertificate(context.Background(), userID, path.ID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(20, certificate.Score)
+}
+
+func LearningPathIssuePathCertificateFailureRepositoryMock(repository *mocks.LearningPathRepository,
+	courseRepository *mocks.CourseRepository, lessonRepository *mocks.LessonRepository,
+	statRepository *mocks.StatRepository, userID, pathID domain.ID, courses []domain.Course) {
+	repository.
+		On("FindUserCertificate", context.Background(), userID, pathID).
+		Return(domain.PathCertificate{}, errs.ErrNotExist)
+	repository.
+		On("FindPathCourses", context.Background(), pathID).
+		Return(courses, nil)
+	CourseProgressRepositoryMock(courseRepository, lessonRepository, statRepository, userID, courses[0],
+		[]domain.Lesson{NewLessonBuilder().WithID(domain.NewID()).Build()}, 1)
+	CourseProgressRepositoryMock(courseRepository, lessonRepository, statRepository, userID, courses[1],
+		[]domain.Lesson{NewLessonBuilder().WithID(domain.NewID()).Build()}, 0)
+}
+
+func (s *LearningPathIssuePathCertificateSuite) TestIssuePathCertificate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Issue certificate of the not completed learning path")
+	repository := mocks.NewLearningPathRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	pathService := service.NewLearningPathService(repository, courseRepository,
+		lessonRepository, statRepository, s.logger)
+	userID := domain.NewID()
+	path := NewLearningPathBuilder().Build()
+	courses := []domain.Course{
+		NewCourseBuilder().WithID(domain.NewID()).Build(),
+		NewCourseBuilder().WithID(domain.NewID()).Build(),
+	}
+	LearningPathIssuePathCertificateFailureRepositoryMock(repository, courseRepository, lessonRepository,
+		statRepository, userID, path.ID, courses)
+	_, err := pathService.IssuePathCertificate(context.Background(), userID, path.ID)
+	t.Assert().ErrorIs(err, errs.ErrLearningPathNotCompleted)
+}
+
+func (s *LearningPathIssuePathCertificateSuite) TestIssuePathCertificate_AlreadyIssued(t provider.T) {
+	t.Parallel()
+	t.Title("Issue certificate of the learning path again")
+	repository := mocks.NewLearningPathRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	pathService := service.NewLearningPathService(repository, courseRepository,
+		lessonRepository, statRepository, s.logger)
+	issued := domain.PathCertificate{ID: domain.NewID(), UserID: domain.NewID(), PathID: domain.NewID()}
+	repository.
+		On("FindUserCertificate", context.Background(), issued.UserID, issued.PathID).
+		Return(issued, nil)
+	certificate, err := pathService.IssuePathCertificate(context.Background(), issued.UserID, issued.PathID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(issued.ID, certificate.ID)
+}
+
+func TestLearningPathIssuePathCertificateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Issue learning path certificate", new(LearningPathIssuePathCertificateSuite))
+}
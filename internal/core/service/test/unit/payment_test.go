@@ -12,17 +12,20 @@ import (
 	"go.uber.org/zap"
 	"net/url"
 	"testing"
+	"time"
 )
 
 type PaymentSuite struct {
 	suite.Suite
-	logger *zap.Logger
+	logger       *zap.Logger
+	ledgerConfig *service.LedgerConfig
 }
 
 func (s *PaymentSuite) BeforeEach(t provider.T) {
 	loggerBuilder := zap.NewDevelopmentConfig()
 	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
 	s.logger, _ = loggerBuilder.Build()
+	s.ledgerConfig = &service.LedgerConfig{CommissionPercent: 10}
 }
 
 // GetCoursePaymentUrl Suite
@@ -31,16 +34,22 @@ type PaymentGetCoursePaymentUrlSuite struct {
 }
 
 func PaymentGetCoursePaymentUrlSuccessRepositoryMock(gateway *mocks.PaymentGateway,
-	courseRepository *mocks.CourseRepository) {
+	courseRepository *mocks.CourseRepository, userRepository *mocks.UserRepository) {
+	userRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
 	gateway.
 		On("GetPaymentUrl", context.Background(), mock.Anything).
 		Return(url.URL{}, nil)
 	courseRepository.
 		On("FindByID", context.Background(), mock.Anything).
-		Return(NewCourseBuilder().Build(), nil)
+		Return(NewCourseBuilder().WithStatus(domain.CoursePublished).Build(), nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), mock.Anything, mock.Anything).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
 	courseRepository.
-		On("IsCourseStudent", context.Background(), mock.Anything, mock.Anything).
-		Return(false, nil)
+		On("FindCoursePrerequisites", context.Background(), mock.Anything).
+		Return([]domain.CoursePrerequisite{}, nil)
 }
 
 func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_Success(t provider.T) {
@@ -48,20 +57,33 @@ func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_Success(t prov
 	t.Title("Get course payment url success")
 	gateway := mocks.NewPaymentGateway(t)
 	courseRepository := mocks.NewCourseRepository(t)
-	paymentService := service.NewPaymentService(gateway, courseRepository, s.logger)
-	PaymentGetCoursePaymentUrlSuccessRepositoryMock(gateway, courseRepository)
-	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), domain.NewID())
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	PaymentGetCoursePaymentUrlSuccessRepositoryMock(gateway, courseRepository, userRepository)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), domain.NewID(), "")
 	t.Assert().Nil(err)
 }
 
 func PaymentGetCoursePaymentUrlFailureRepositoryMock(gateway *mocks.PaymentGateway,
-	courseRepository *mocks.CourseRepository) {
+	courseRepository *mocks.CourseRepository, userRepository *mocks.UserRepository) {
+	userRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
 	courseRepository.
 		On("FindByID", context.Background(), mock.Anything).
-		Return(NewCourseBuilder().Build(), nil)
+		Return(NewCourseBuilder().WithStatus(domain.CoursePublished).Build(), nil)
 	courseRepository.
-		On("IsCourseStudent", context.Background(), mock.Anything, mock.Anything).
-		Return(false, errs.ErrUserIsAlreadyCourseStudent)
+		On("FindEnrollment", context.Background(), mock.Anything, mock.Anything).
+		Return(NewEnrollmentBuilder().Build(), nil)
 }
 
 func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_Failure(t provider.T) {
@@ -69,25 +91,539 @@ func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_Failure(t prov
 	t.Title("Get course payment url failure")
 	gateway := mocks.NewPaymentGateway(t)
 	courseRepository := mocks.NewCourseRepository(t)
-	paymentService := service.NewPaymentService(gateway, courseRepository, s.logger)
-	PaymentGetCoursePaymentUrlFailureRepositoryMock(gateway, courseRepository)
-	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), domain.NewID())
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	PaymentGetCoursePaymentUrlFailureRepositoryMock(gateway, courseRepository, userRepository)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), domain.NewID(), "")
 	t.Assert().ErrorIs(err, errs.ErrUserIsAlreadyCourseStudent)
 }
 
+func PaymentGetCoursePaymentUrlRenewalRepositoryMock(gateway *mocks.PaymentGateway,
+	courseRepository *mocks.CourseRepository, userRepository *mocks.UserRepository, course domain.Course,
+	expiresAt time.Time) {
+	userRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), mock.Anything, course.ID).
+		Return(NewEnrollmentBuilder().WithCourseID(course.ID).
+			WithExpiresAt(expiresAt).Build(), nil)
+	gateway.
+		On("GetPaymentUrl", context.Background(), mock.MatchedBy(func(payload domain.PaymentPayload) bool {
+			return payload.Renewal && payload.PaySum == course.Price
+		})).
+		Return(url.URL{}, nil)
+}
+
+func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_Renewal(t provider.T) {
+	t.Parallel()
+	t.Title("Get course payment url for renewal of expired enrollment")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).WithID(domain.NewID()).WithPrice(4000).
+		WithCapacity(1).WithAccessDays(30).Build()
+	PaymentGetCoursePaymentUrlRenewalRepositoryMock(gateway, courseRepository, userRepository, course,
+		time.Now().Add(-time.Hour))
+	waitlistRepository.
+		On("HoldSeat", context.Background(), mock.MatchedBy(func(hold domain.SeatHold) bool {
+			return hold.CourseID == course.ID
+		})).
+		Return(nil)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), course.ID, "")
+	t.Assert().Nil(err)
+}
+
+func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_ActiveRenewal(t provider.T) {
+	t.Parallel()
+	t.Title("Get course payment url for renewal of active enrollment keeps its seat")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).WithID(domain.NewID()).WithPrice(4000).
+		WithCapacity(1).WithAccessDays(30).Build()
+	PaymentGetCoursePaymentUrlRenewalRepositoryMock(gateway, courseRepository, userRepository, course,
+		time.Now().Add(time.Hour))
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), course.ID, "")
+	t.Assert().Nil(err)
+}
+
+func PaymentGetCoursePaymentUrlNotVerifiedRepositoryMock(userRepository *mocks.UserRepository) {
+	userRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().WithEmailVerified(false).Build(), nil)
+}
+
+func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_NotVerified(t provider.T) {
+	t.Parallel()
+	t.Title("Get course payment url for user with not verified email")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	PaymentGetCoursePaymentUrlNotVerifiedRepositoryMock(userRepository)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), domain.NewID(), "")
+	t.Assert().ErrorIs(err, errs.ErrEmailNotVerified)
+}
+
+func PaymentGetCoursePaymentUrlWithdrawnRepositoryMock(courseRepository *mocks.CourseRepository,
+	userRepository *mocks.UserRepository) {
+	userRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	courseRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewCourseBuilder().WithStatus(domain.CourseArchived).Build(), nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), mock.Anything, mock.Anything).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
+}
+
+func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_Withdrawn(t provider.T) {
+	t.Parallel()
+	t.Title("Get course payment url for archived course")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	PaymentGetCoursePaymentUrlWithdrawnRepositoryMock(courseRepository, userRepository)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), domain.NewID(), "")
+	t.Assert().ErrorIs(err, errs.ErrCourseNotForSale)
+}
+
+func PaymentGetCoursePaymentUrlDraftRepositoryMock(courseRepository *mocks.CourseRepository,
+	userRepository *mocks.UserRepository) {
+	userRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	courseRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewCourseBuilder().WithStatus(domain.CourseDraft).Build(), nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), mock.Anything, mock.Anything).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
+}
+
+func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_Draft(t provider.T) {
+	t.Parallel()
+	t.Title("Get course payment url for draft course")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	PaymentGetCoursePaymentUrlDraftRepositoryMock(courseRepository, userRepository)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), domain.NewID(), "")
+	t.Assert().ErrorIs(err, errs.ErrCourseNotForSale)
+}
+
+func PaymentGetCoursePaymentUrlPrerequisitesRepositoryMock(courseRepository *mocks.CourseRepository,
+	lessonRepository *mocks.LessonRepository, statRepository *mocks.StatRepository,
+	userRepository *mocks.UserRepository, userID domain.ID, course, required domain.Course) {
+	userRepository.
+		On("FindByID", context.Background(), userID).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), userID, course.ID).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
+	courseRepository.
+		On("FindCoursePrerequisites", context.Background(), course.ID).
+		Return([]domain.CoursePrerequisite{{CourseID: course.ID, RequiredCourseID: required.ID}}, nil)
+	courseRepository.
+		On("FindByID", context.Background(), required.ID).
+		Return(required, nil)
+	CourseProgressRepositoryMock(courseRepository, lessonRepository, statRepository, userID, required,
+		[]domain.Lesson{NewLessonBuilder().WithID(domain.NewID()).Build()}, 0)
+}
+
+func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_Prerequisites(t provider.T) {
+	t.Parallel()
+	t.Title("Get course payment url with not completed required course")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	userID := domain.NewID()
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).WithID(domain.NewID()).Build()
+	required := NewCourseBuilder().WithID(domain.NewID()).Build()
+	PaymentGetCoursePaymentUrlPrerequisitesRepositoryMock(courseRepository, lessonRepository, statRepository,
+		userRepository, userID, course, required)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), userID, course.ID, "")
+	t.Assert().ErrorIs(err, errs.ErrCoursePrerequisitesNotMet)
+}
+
+func PaymentGetCoursePaymentUrlPromoCodeRepositoryMock(gateway *mocks.PaymentGateway,
+	courseRepository *mocks.CourseRepository, userRepository *mocks.UserRepository,
+	promoRepository *mocks.PromoCodeRepository, course domain.Course, promo domain.PromoCode) {
+	userRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), mock.Anything, mock.Anything).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
+	courseRepository.
+		On("FindCoursePrerequisites", context.Background(), mock.Anything).
+		Return([]domain.CoursePrerequisite{}, nil)
+	promoRepository.
+		On("FindByCode", context.Background(), course.SchoolID, promo.Code).
+		Return(promo, nil)
+	gateway.
+		On("GetPaymentUrl", context.Background(), mock.MatchedBy(func(payload domain.PaymentPayload) bool {
+			return payload.PaySum.Amount == 3000 && payload.PromoCodeID == promo.ID
+		})).
+		Return(url.URL{}, nil)
+}
+
+func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_PromoCode(t provider.T) {
+	t.Parallel()
+	t.Title("Get course payment url with discount promo code")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).WithID(domain.NewID()).WithSchoolID(domain.NewID()).WithPrice(4000).Build()
+	promo := NewPromoCodeBuilder().WithSchoolID(course.SchoolID).WithCourseID(course.ID).Build()
+	PaymentGetCoursePaymentUrlPromoCodeRepositoryMock(gateway, courseRepository, userRepository,
+		promoRepository, course, promo)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), course.ID, " spring25 ")
+	t.Assert().Nil(err)
+}
+
+func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_PercentPromoCodeAnyCurrency(t provider.T) {
+	t.Parallel()
+	t.Title("Get course payment url with school wide percent promo code for course in another currency")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).WithID(domain.NewID()).WithSchoolID(domain.NewID()).
+		WithPrice(4000).WithCurrency("USD").Build()
+	promo := NewPromoCodeBuilder().WithSchoolID(course.SchoolID).Build()
+	PaymentGetCoursePaymentUrlPromoCodeRepositoryMock(gateway, courseRepository, userRepository,
+		promoRepository, course, promo)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), course.ID, promo.Code)
+	t.Assert().Nil(err)
+}
+
+func PaymentGetCoursePaymentUrlPromoCodeFailureRepositoryMock(courseRepository *mocks.CourseRepository,
+	userRepository *mocks.UserRepository, promoRepository *mocks.PromoCodeRepository,
+	course domain.Course, promo domain.PromoCode) {
+	userRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), mock.Anything, mock.Anything).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
+	courseRepository.
+		On("FindCoursePrerequisites", context.Background(), mock.Anything).
+		Return([]domain.CoursePrerequisite{}, nil)
+	promoRepository.
+		On("FindByCode", context.Background(), course.SchoolID, promo.Code).
+		Return(promo, nil)
+}
+
+func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_PromoCodeExhausted(t provider.T) {
+	t.Parallel()
+	t.Title("Get course payment url with exhausted promo code")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).WithID(domain.NewID()).WithSchoolID(domain.NewID()).WithPrice(4000).Build()
+	promo := NewPromoCodeBuilder().WithSchoolID(course.SchoolID).WithUsage(10, 10).Build()
+	PaymentGetCoursePaymentUrlPromoCodeFailureRepositoryMock(courseRepository, userRepository,
+		promoRepository, course, promo)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), course.ID, promo.Code)
+	t.Assert().ErrorIs(err, errs.ErrPromoCodeExhausted)
+}
+
+func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_PromoCodeExpired(t provider.T) {
+	t.Parallel()
+	t.Title("Get course payment url with expired promo code")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).WithID(domain.NewID()).WithSchoolID(domain.NewID()).WithPrice(4000).Build()
+	promo := NewPromoCodeBuilder().WithSchoolID(course.SchoolID).
+		WithValidUntil(time.Now().Add(-time.Hour)).Build()
+	PaymentGetCoursePaymentUrlPromoCodeFailureRepositoryMock(courseRepository, userRepository,
+		promoRepository, course, promo)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), course.ID, promo.Code)
+	t.Assert().ErrorIs(err, errs.ErrInvalidPromoCode)
+}
+
+func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_PromoCodeOtherCourse(t provider.T) {
+	t.Parallel()
+	t.Title("Get course payment url with promo code of another course")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).WithID(domain.NewID()).WithSchoolID(domain.NewID()).WithPrice(4000).Build()
+	promo := NewPromoCodeBuilder().WithSchoolID(course.SchoolID).WithCourseID(domain.NewID()).Build()
+	PaymentGetCoursePaymentUrlPromoCodeFailureRepositoryMock(courseRepository, userRepository,
+		promoRepository, course, promo)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), course.ID, promo.Code)
+	t.Assert().ErrorIs(err, errs.ErrPromoCodeNotApplicable)
+}
+
+func (s *PaymentGetCoursePaymentUrlSuite) TestGetCoursePaymentUrl_PromoCodeOtherCurrency(t provider.T) {
+	t.Parallel()
+	t.Title("Get course payment url with promo code in another currency")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).WithID(domain.NewID()).WithSchoolID(domain.NewID()).
+		WithPrice(4000).WithCurrency("USD").Build()
+	promo := NewPromoCodeBuilder().WithSchoolID(course.SchoolID).
+		WithDiscount(domain.PromoFixedDiscount, 1000).WithCurrency(domain.DefaultCurrency).Build()
+	PaymentGetCoursePaymentUrlPromoCodeFailureRepositoryMock(courseRepository, userRepository,
+		promoRepository, course, promo)
+	_, err := paymentService.GetCoursePaymentUrl(context.Background(), domain.NewID(), course.ID, promo.Code)
+	t.Assert().ErrorIs(err, errs.ErrPromoCodeCurrencyMismatch)
+}
+
 func TestPaymentGetCoursePaymentUrlSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Get course payment url", new(PaymentGetCoursePaymentUrlSuite))
 }
 
+// GetGiftPaymentUrl Suite
+type PaymentGetGiftPaymentUrlSuite struct {
+	PaymentSuite
+}
+
+func PaymentGetGiftPaymentUrlSuccessRepositoryMock(gateway *mocks.PaymentGateway,
+	courseRepository *mocks.CourseRepository, userRepository *mocks.UserRepository, course domain.Course) {
+	userRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	gateway.
+		On("GetPaymentUrl", context.Background(), mock.MatchedBy(func(payload domain.PaymentPayload) bool {
+			return payload.CourseID == course.ID && payload.UserID == "" &&
+				payload.RecipientEmail == "friend@mail.ru"
+		})).
+		Return(url.URL{}, nil)
+}
+
+func (s *PaymentGetGiftPaymentUrlSuite) TestGetGiftPaymentUrl_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Get gift payment url success")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).WithID(domain.NewID()).Build()
+	PaymentGetGiftPaymentUrlSuccessRepositoryMock(gateway, courseRepository, userRepository, course)
+	_, err := paymentService.GetGiftPaymentUrl(context.Background(), domain.NewID(), course.ID, "friend@mail.ru")
+	t.Assert().Nil(err)
+}
+
+func PaymentGetGiftPaymentUrlCapacityRepositoryMock(courseRepository *mocks.CourseRepository,
+	userRepository *mocks.UserRepository, course domain.Course) {
+	userRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+}
+
+func (s *PaymentGetGiftPaymentUrlSuite) TestGetGiftPaymentUrl_Capacity(t provider.T) {
+	t.Parallel()
+	t.Title("Get gift payment url for course with seat limit")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).WithID(domain.NewID()).
+		WithCapacity(10).Build()
+	PaymentGetGiftPaymentUrlCapacityRepositoryMock(courseRepository, userRepository, course)
+	_, err := paymentService.GetGiftPaymentUrl(context.Background(), domain.NewID(), course.ID, "friend@mail.ru")
+	t.Assert().ErrorIs(err, errs.ErrGiftCourseHasCapacity)
+}
+
+func TestPaymentGetGiftPaymentUrlSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Get gift payment url", new(PaymentGetGiftPaymentUrlSuite))
+}
+
 // ProcessCoursePayment Suite
 type PaymentProcessCoursePaymentSuite struct {
 	PaymentSuite
 }
 
-func PaymentProcessCoursePaymentSuccessRepositoryMock(gateway *mocks.PaymentGateway) {
+func PaymentProcessCoursePaymentSuccessRepositoryMock(gateway *mocks.PaymentGateway,
+	courseRepository *mocks.CourseRepository, paymentRepository *mocks.PaymentRepository,
+	ledgerRepository *mocks.LedgerRepository, course domain.Course) {
 	gateway.
 		On("ProcessPayment", context.Background(), mock.Anything).
-		Return(domain.PaymentPayload{}, nil)
+		Return(domain.PaymentPayload{CourseID: course.ID}, nil)
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	paymentRepository.
+		On("Create", context.Background(), mock.MatchedBy(func(payment domain.Payment) bool {
+			return payment.Key == "key" && payment.PaySum == 1000
+		})).
+		Return(nil)
+	ledgerRepository.
+		On("Create", context.Background(), mock.MatchedBy(func(entry domain.LedgerEntry) bool {
+			return entry.SchoolID == course.SchoolID && entry.Kind == domain.LedgerSale &&
+				entry.Gross == 1000 && entry.Commission == 100 && entry.Net() == 900
+		})).
+		Return(nil)
 }
 
 func (s *PaymentProcessCoursePaymentSuite) TestProcessCoursePayment_Success(t provider.T) {
@@ -95,8 +631,22 @@ func (s *PaymentProcessCoursePaymentSuite) TestProcessCoursePayment_Success(t pr
 	t.Title("Process payment success")
 	gateway := mocks.NewPaymentGateway(t)
 	courseRepository := mocks.NewCourseRepository(t)
-	paymentService := service.NewPaymentService(gateway, courseRepository, s.logger)
-	PaymentProcessCoursePaymentSuccessRepositoryMock(gateway)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).
+		WithPrice(1000).
+		Build()
+	PaymentProcessCoursePaymentSuccessRepositoryMock(gateway, courseRepository, paymentRepository,
+		ledgerRepository, course)
 	_, err := paymentService.ProcessCoursePayment(context.Background(), "key", 1000)
 	t.Assert().Nil(err)
 }
@@ -112,12 +662,362 @@ func (s *PaymentProcessCoursePaymentSuite) TestProcessCoursePayment_Failure(t pr
 	t.Title("Process payment failure")
 	gateway := mocks.NewPaymentGateway(t)
 	courseRepository := mocks.NewCourseRepository(t)
-	paymentService := service.NewPaymentService(gateway, courseRepository, s.logger)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
 	PaymentProcessCoursePaymentFailureRepositoryMock(gateway)
 	_, err := paymentService.ProcessCoursePayment(context.Background(), "key", 1000)
 	t.Assert().ErrorIs(err, errs.ErrDecodePaymentKeyFailed)
 }
 
+func PaymentProcessCoursePaymentPromoCodeRepositoryMock(gateway *mocks.PaymentGateway,
+	courseRepository *mocks.CourseRepository, promoRepository *mocks.PromoCodeRepository,
+	paymentRepository *mocks.PaymentRepository, ledgerRepository *mocks.LedgerRepository,
+	payload domain.PaymentPayload) {
+	gateway.
+		On("ProcessPayment", context.Background(), mock.Anything).
+		Return(payload, nil)
+	courseRepository.
+		On("FindByID", context.Background(), payload.CourseID).
+		Return(NewCourseBuilder().WithStatus(domain.CoursePublished).WithID(payload.CourseID).Build(), nil)
+	paymentRepository.
+		On("Create", context.Background(), mock.Anything).
+		Return(nil)
+	ledgerRepository.
+		On("Create", context.Background(), mock.Anything).
+		Return(nil)
+	promoRepository.
+		On("Redeem", context.Background(), mock.MatchedBy(func(redemption domain.PromoRedemption) bool {
+			return redemption.PromoCodeID == payload.PromoCodeID && redemption.PaySum == payload.PaySum.Amount
+		})).
+		Return(nil)
+}
+
+func (s *PaymentProcessCoursePaymentSuite) TestProcessCoursePayment_PromoCode(t provider.T) {
+	t.Parallel()
+	t.Title("Process payment with promo code counts redemption")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	payload := domain.PaymentPayload{
+		UserID:      domain.NewID(),
+		CourseID:    domain.NewID(),
+		PromoCodeID: domain.NewID(),
+		PaySum:      domain.NewMoney(3000, domain.DefaultCurrency),
+	}
+	PaymentProcessCoursePaymentPromoCodeRepositoryMock(gateway, courseRepository, promoRepository,
+		paymentRepository, ledgerRepository, payload)
+	_, err := paymentService.ProcessCoursePayment(context.Background(), "key", 3000)
+	t.Assert().Nil(err)
+}
+
+func PaymentProcessCoursePaymentInvalidSumRepositoryMock(gateway *mocks.PaymentGateway) {
+	gateway.
+		On("ProcessPayment", context.Background(), mock.Anything).
+		Return(domain.PaymentPayload{PromoCodeID: domain.NewID(), PaySum: domain.NewMoney(3000, domain.DefaultCurrency)}, nil)
+}
+
+func (s *PaymentProcessCoursePaymentSuite) TestProcessCoursePayment_InvalidSum(t provider.T) {
+	t.Parallel()
+	t.Title("Process payment with sum less than discounted price")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	PaymentProcessCoursePaymentInvalidSumRepositoryMock(gateway)
+	_, err := paymentService.ProcessCoursePayment(context.Background(), "key", 2999)
+	t.Assert().ErrorIs(err, errs.ErrInvalidPaymentSum)
+}
+
+func PaymentProcessCoursePaymentGiftRepositoryMock(gateway *mocks.PaymentGateway,
+	courseRepository *mocks.CourseRepository, ledgerRepository *mocks.LedgerRepository,
+	course domain.Course, payload domain.PaymentPayload) {
+	gateway.
+		On("ProcessPayment", context.Background(), mock.Anything).
+		Return(payload, nil)
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	ledgerRepository.
+		On("Create", context.Background(), mock.MatchedBy(func(entry domain.LedgerEntry) bool {
+			return entry.UserID == "" && entry.CourseID == course.ID && entry.Gross == payload.PaySum.Amount
+		})).
+		Return(nil)
+}
+
+func (s *PaymentProcessCoursePaymentSuite) TestProcessCoursePayment_Gift(t provider.T) {
+	t.Parallel()
+	t.Title("Process gift payment credits ledger without course payment")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).
+		WithID(domain.NewID()).
+		WithPrice(3000).
+		Build()
+	payload := domain.PaymentPayload{
+		CourseID:       course.ID,
+		RecipientEmail: "recipient@example.com",
+		PaySum:         domain.NewMoney(3000, domain.DefaultCurrency),
+	}
+	PaymentProcessCoursePaymentGiftRepositoryMock(gateway, courseRepository, ledgerRepository, course, payload)
+	processed, err := paymentService.ProcessCoursePayment(context.Background(), "key", 3000)
+	t.Assert().Nil(err)
+	t.Assert().Equal(payload.RecipientEmail, processed.RecipientEmail)
+}
+
+func PaymentProcessVerifiedPaymentRepositoryMock(gateway *mocks.PaymentGateway,
+	courseRepository *mocks.CourseRepository, paymentRepository *mocks.PaymentRepository,
+	ledgerRepository *mocks.LedgerRepository, course domain.Course) {
+	gateway.
+		On("ProcessPayment", context.Background(), "key").
+		Return(domain.PaymentPayload{CourseID: course.ID, PaySum: domain.NewMoney(1000, "RUB")}, nil)
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	paymentRepository.
+		On("Create", context.Background(), mock.MatchedBy(func(payment domain.Payment) bool {
+			return payment.Key == "key" && payment.PaySum == 1000
+		})).
+		Return(nil)
+	ledgerRepository.
+		On("Create", context.Background(), mock.MatchedBy(func(entry domain.LedgerEntry) bool {
+			return entry.Gross == 1000 && entry.Commission == 100
+		})).
+		Return(nil)
+}
+
+func (s *PaymentProcessCoursePaymentSuite) TestProcessVerifiedPayment_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Process verified payment records the sum of the gateway")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	course := NewCourseBuilder().WithStatus(domain.CoursePublished).
+		WithPrice(1000).
+		Build()
+	PaymentProcessVerifiedPaymentRepositoryMock(gateway, courseRepository, paymentRepository,
+		ledgerRepository, course)
+	_, err := paymentService.ProcessVerifiedPayment(context.Background(), "key")
+	t.Assert().Nil(err)
+}
+
 func TestPaymentProcessCoursePaymentSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Process payment", new(PaymentProcessCoursePaymentSuite))
 }
+
+// GetBundlePaymentUrl Suite
+type PaymentGetBundlePaymentUrlSuite struct {
+	PaymentSuite
+}
+
+func PaymentGetBundlePaymentUrlSuccessRepositoryMock(gateway *mocks.PaymentGateway,
+	courseRepository *mocks.CourseRepository, userRepository *mocks.UserRepository,
+	bundleRepository *mocks.BundleRepository, bundle domain.Bundle, courses []domain.Course) {
+	userRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	bundleRepository.
+		On("FindByID", context.Background(), bundle.ID).
+		Return(bundle, nil)
+	bundleRepository.
+		On("FindBundleCourses", context.Background(), bundle.ID).
+		Return(courses, nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), mock.Anything, courses[0].ID).
+		Return(NewEnrollmentBuilder().Build(), nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), mock.Anything, mock.Anything).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
+	courseRepository.
+		On("FindCoursePrerequisites", context.Background(), mock.Anything).
+		Return([]domain.CoursePrerequisite{}, nil)
+	gateway.
+		On("GetPaymentUrl", context.Background(), mock.MatchedBy(func(payload domain.PaymentPayload) bool {
+			return payload.BundleID == bundle.ID && payload.CourseID == "" && payload.PaySum.Amount == 5600
+		})).
+		Return(url.URL{}, nil)
+}
+
+func (s *PaymentGetBundlePaymentUrlSuite) TestGetBundlePaymentUrl_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Get bundle payment url without share of already bought course")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	bundle := NewBundleBuilder().WithPrice(8000).WithStatus(domain.BundlePublished).Build()
+	courses := []domain.Course{
+		NewCourseBuilder().WithID(domain.NewID()).WithPrice(3000).Build(),
+		NewCourseBuilder().WithID(domain.NewID()).WithPrice(3000).Build(),
+		NewCourseBuilder().WithID(domain.NewID()).WithPrice(4000).Build(),
+	}
+	PaymentGetBundlePaymentUrlSuccessRepositoryMock(gateway, courseRepository, userRepository,
+		bundleRepository, bundle, courses)
+	_, err := paymentService.GetBundlePaymentUrl(context.Background(), domain.NewID(), bundle.ID)
+	t.Assert().Nil(err)
+}
+
+func PaymentGetBundlePaymentUrlFailureRepositoryMock(courseRepository *mocks.CourseRepository,
+	userRepository *mocks.UserRepository, bundleRepository *mocks.BundleRepository, bundle domain.Bundle) {
+	userRepository.
+		On("FindByID", context.Background(), mock.Anything).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	bundleRepository.
+		On("FindByID", context.Background(), bundle.ID).
+		Return(bundle, nil)
+	bundleRepository.
+		On("FindBundleCourses", context.Background(), bundle.ID).
+		Return([]domain.Course{NewCourseBuilder().Build(), NewCourseBuilder().Build()}, nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), mock.Anything, mock.Anything).
+		Return(NewEnrollmentBuilder().Build(), nil)
+}
+
+func (s *PaymentGetBundlePaymentUrlSuite) TestGetBundlePaymentUrl_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Get bundle payment url when every course is already bought")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	bundle := NewBundleBuilder().WithStatus(domain.BundlePublished).Build()
+	PaymentGetBundlePaymentUrlFailureRepositoryMock(courseRepository, userRepository, bundleRepository, bundle)
+	_, err := paymentService.GetBundlePaymentUrl(context.Background(), domain.NewID(), bundle.ID)
+	t.Assert().ErrorIs(err, errs.ErrBundleAlreadyOwned)
+}
+
+func PaymentGetBundlePaymentUrlPrerequisitesRepositoryMock(courseRepository *mocks.CourseRepository,
+	lessonRepository *mocks.LessonRepository, statRepository *mocks.StatRepository,
+	userRepository *mocks.UserRepository, bundleRepository *mocks.BundleRepository,
+	userID domain.ID, bundle domain.Bundle, courses []domain.Course, required domain.Course) {
+	userRepository.
+		On("FindByID", context.Background(), userID).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	bundleRepository.
+		On("FindByID", context.Background(), bundle.ID).
+		Return(bundle, nil)
+	bundleRepository.
+		On("FindBundleCourses", context.Background(), bundle.ID).
+		Return(courses, nil)
+	for _, course := range courses {
+		courseRepository.
+			On("FindEnrollment", context.Background(), userID, course.ID).
+			Return(domain.Enrollment{}, errs.ErrNotExist)
+	}
+	// the second course requires the first one bought in the same bundle
+	courseRepository.
+		On("FindCoursePrerequisites", context.Background(), courses[0].ID).
+		Return([]domain.CoursePrerequisite{}, nil)
+	courseRepository.
+		On("FindCoursePrerequisites", context.Background(), courses[1].ID).
+		Return([]domain.CoursePrerequisite{
+			{CourseID: courses[1].ID, RequiredCourseID: courses[0].ID},
+			{CourseID: courses[1].ID, RequiredCourseID: required.ID},
+		}, nil)
+	courseRepository.
+		On("FindByID", context.Background(), required.ID).
+		Return(required, nil)
+	CourseProgressRepositoryMock(courseRepository, lessonRepository, statRepository, userID, required,
+		[]domain.Lesson{NewLessonBuilder().WithID(domain.NewID()).Build()}, 0)
+}
+
+func (s *PaymentGetBundlePaymentUrlSuite) TestGetBundlePaymentUrl_Prerequisites(t provider.T) {
+	t.Parallel()
+	t.Title("Get bundle payment url with not completed course required outside the bundle")
+	gateway := mocks.NewPaymentGateway(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	promoRepository := mocks.NewPromoCodeRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	waitlistRepository := mocks.NewWaitlistRepository(t)
+	bundleRepository := mocks.NewBundleRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	paymentService := service.NewPaymentService(gateway, courseRepository, lessonRepository, statRepository,
+		userRepository, promoRepository, paymentRepository, waitlistRepository, bundleRepository,
+		ledgerRepository, s.ledgerConfig, s.logger)
+	userID := domain.NewID()
+	bundle := NewBundleBuilder().WithPrice(6000).WithStatus(domain.BundlePublished).Build()
+	courses := []domain.Course{
+		NewCourseBuilder().WithID(domain.NewID()).WithPrice(3000).Build(),
+		NewCourseBuilder().WithID(domain.NewID()).WithPrice(4000).Build(),
+	}
+	required := NewCourseBuilder().WithID(domain.NewID()).Build()
+	PaymentGetBundlePaymentUrlPrerequisitesRepositoryMock(courseRepository, lessonRepository, statRepository,
+		userRepository, bundleRepository, userID, bundle, courses, required)
+	_, err := paymentService.GetBundlePaymentUrl(context.Background(), userID, bundle.ID)
+	var prerequisitesErr *errs.PrerequisitesError
+	t.Require().ErrorAs(err, &prerequisitesErr)
+	t.Assert().Len(prerequisitesErr.Requirements, 1)
+}
+
+func TestPaymentGetBundlePaymentUrlSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Get bundle payment url", new(PaymentGetBundlePaymentUrlSuite))
+}
@@ -0,0 +1,205 @@
+package unit
+
+import (
+	"context"
+	"github.com/guregu/null"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"testing"
+)
+
+// AddCoursePrerequisite Suite
+type CourseAddCoursePrerequisiteSuite struct {
+	CourseSuite
+}
+
+func CourseAddCoursePrerequisiteSuccessRepositoryMock(repository *mocks.CourseRepository,
+	course, required domain.Course, minScore null.Int) {
+	repository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	repository.
+		On("FindByID", context.Background(), required.ID).
+		Return(required, nil)
+	repository.
+		On("FindCoursePrerequisites", context.Background(), required.ID).
+		Return([]domain.CoursePrerequisite{}, nil)
+	repository.
+		On("AddCoursePrerequisite", context.Background(), domain.CoursePrerequisite{
+			CourseID:         course.ID,
+			RequiredCourseID: required.ID,
+			MinScore:         minScore,
+		}).
+		Return(nil)
+}
+
+func (s *CourseAddCoursePrerequisiteSuite) TestAddCoursePrerequisite_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Add course prerequisite success")
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	schoolID := domain.NewID()
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build()
+	required := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build()
+	CourseAddCoursePrerequisiteSuccessRepositoryMock(courseRepository, course, required, null.IntFrom(50))
+	err := courseService.AddCoursePrerequisite(context.Background(), course.ID, port.AddPrerequisiteParam{
+		RequiredCourseID: required.ID,
+		MinScore:         null.IntFrom(50),
+	})
+	t.Assert().Nil(err)
+}
+
+func CourseAddCoursePrerequisiteCycleRepositoryMock(repository *mocks.CourseRepository,
+	course, required domain.Course) {
+	repository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	repository.
+		On("FindByID", context.Background(), required.ID).
+		Return(required, nil)
+	repository.
+		On("FindCoursePrerequisites", context.Background(), required.ID).
+		Return([]domain.CoursePrerequisite{{CourseID: required.ID, RequiredCourseID: course.ID}}, nil)
+}
+
+func (s *CourseAddCoursePrerequisiteSuite) TestAddCoursePrerequisite_Cycle(t provider.T) {
+	t.Parallel()
+	t.Title("Add course prerequisite requiring the course itself")
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	schoolID := domain.NewID()
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build()
+	required := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(schoolID).Build()
+	CourseAddCoursePrerequisiteCycleRepositoryMock(courseRepository, course, required)
+	err := courseService.AddCoursePrerequisite(context.Background(), course.ID, port.AddPrerequisiteParam{
+		RequiredCourseID: required.ID,
+	})
+	t.Assert().ErrorIs(err, errs.ErrCoursePrerequisiteCycle)
+}
+
+func CourseAddCoursePrerequisiteFailureRepositoryMock(repository *mocks.CourseRepository,
+	course, required domain.Course) {
+	repository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	repository.
+		On("FindByID", context.Background(), required.ID).
+		Return(required, nil)
+}
+
+func (s *CourseAddCoursePrerequisiteSuite) TestAddCoursePrerequisite_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Add course prerequisite of another school")
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).Build()
+	required := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).Build()
+	CourseAddCoursePrerequisiteFailureRepositoryMock(courseRepository, course, required)
+	err := courseService.AddCoursePrerequisite(context.Background(), course.ID, port.AddPrerequisiteParam{
+		RequiredCourseID: required.ID,
+	})
+	t.Assert().ErrorIs(err, errs.ErrCoursePrerequisiteNotInSchool)
+}
+
+func (s *CourseAddCoursePrerequisiteSuite) TestAddCoursePrerequisite_Self(t provider.T) {
+	t.Parallel()
+	t.Title("Add course prerequisite to itself")
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	courseID := domain.NewID()
+	err := courseService.AddCoursePrerequisite(context.Background(), courseID, port.AddPrerequisiteParam{
+		RequiredCourseID: courseID,
+	})
+	t.Assert().ErrorIs(err, errs.ErrCoursePrerequisiteSelf)
+}
+
+func TestCourseAddCoursePrerequisiteSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Add course prerequisite", new(CourseAddCoursePrerequisiteSuite))
+}
+
+// CheckCoursePrerequisites Suite
+type CourseCheckCoursePrerequisitesSuite struct {
+	CourseSuite
+}
+
+func CourseCheckCoursePrerequisitesRepositoryMock(courseRepository *mocks.CourseRepository,
+	lessonRepository *mocks.LessonRepository, statRepository *mocks.StatRepository,
+	userID, courseID domain.ID, required domain.Course, minScore null.Int, passed int) {
+	courseRepository.
+		On("FindCoursePrerequisites", context.Background(), courseID).
+		Return([]domain.CoursePrerequisite{{
+			CourseID:         courseID,
+			RequiredCourseID: required.ID,
+			MinScore:         minScore,
+		}}, nil)
+	courseRepository.
+		On("FindByID", context.Background(), required.ID).
+		Return(required, nil)
+	CourseProgressRepositoryMock(courseRepository, lessonRepository, statRepository, userID, required,
+		[]domain.Lesson{
+			NewLessonBuilder().WithID(domain.NewID()).Build(),
+			NewLessonBuilder().WithID(domain.NewID()).Build(),
+		}, passed)
+}
+
+func (s *CourseCheckCoursePrerequisitesSuite) TestCheckCoursePrerequisites_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Check course prerequisites met by reaching the score")
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	userID := domain.NewID()
+	courseID := domain.NewID()
+	required := NewCourseBuilder().WithID(domain.NewID()).Build()
+	CourseCheckCoursePrerequisitesRepositoryMock(courseRepository, lessonRepository, statRepository,
+		userID, courseID, required, null.IntFrom(10), 1)
+	err := courseService.CheckCoursePrerequisites(context.Background(), userID, courseID)
+	t.Assert().Nil(err)
+}
+
+func (s *CourseCheckCoursePrerequisitesSuite) TestCheckCoursePrerequisites_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Check course prerequisites with not completed required course")
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	schoolRepository := mocks.NewSchoolRepository(t)
+	statRepository := mocks.NewStatRepository(t)
+	courseService := service.NewCourseService(courseRepository, lessonRepository,
+		schoolRepository, statRepository, mocks.NewSeatListener(t), s.logger)
+	userID := domain.NewID()
+	courseID := domain.NewID()
+	required := NewCourseBuilder().WithID(domain.NewID()).WithName("Go basics").Build()
+	CourseCheckCoursePrerequisitesRepositoryMock(courseRepository, lessonRepository, statRepository,
+		userID, courseID, required, null.Int{}, 1)
+	err := courseService.CheckCoursePrerequisites(context.Background(), userID, courseID)
+	t.Assert().ErrorIs(err, errs.ErrCoursePrerequisitesNotMet)
+	t.Assert().Contains(err.Error(), "Go basics")
+}
+
+func TestCourseCheckCoursePrerequisitesSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Check course prerequisites", new(CourseCheckCoursePrerequisitesSuite))
+}
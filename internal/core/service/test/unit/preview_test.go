@@ -0,0 +1,144 @@
+package unit
+
+import (
+	"context"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"testing"
+)
+
+type LessonPreviewSuite struct {
+	suite.Suite
+}
+
+func (s *LessonPreviewSuite) TestPreviewOf_Listed(t provider.T) {
+	t.Parallel()
+	t.Title("Preview lesson of the published content is shown")
+	course := NewCourseBuilder().WithID(domain.NewID()).
+		WithStatus(domain.CoursePublished).WithVersion(2, 1).Build()
+	lesson := NewLessonBuilder().WithCourseID(course.ID).WithVersion(1).WithPreview(true).Build()
+	revision := NewLessonBuilder().WithCourseID(course.ID).WithVersion(2).WithPreview(true).Build()
+	t.Assert().True(lesson.PreviewOf(course))
+	t.Assert().False(revision.PreviewOf(course))
+}
+
+func (s *LessonPreviewSuite) TestPreviewOf_NotListed(t provider.T) {
+	t.Parallel()
+	t.Title("Preview lesson of the draft course is hidden")
+	course := NewCourseBuilder().WithID(domain.NewID()).
+		WithStatus(domain.CourseDraft).WithVersion(1, 1).Build()
+	lesson := NewLessonBuilder().WithCourseID(course.ID).WithVersion(1).WithPreview(true).Build()
+	other := NewLessonBuilder().WithCourseID(domain.NewID()).WithVersion(1).WithPreview(true).Build()
+	t.Assert().False(lesson.PreviewOf(course))
+	course.Status = domain.CoursePublished
+	t.Assert().False(other.PreviewOf(course))
+}
+
+func (s *LessonPreviewSuite) TestHideAnswers(t provider.T) {
+	t.Parallel()
+	t.Title("Answers of the preview lesson tests are hidden")
+	lesson := NewLessonBuilder().WithType(domain.PracticeLesson).WithTests([]domain.Test{
+		NewTestBuilder().WithAnswer("42").Build(),
+	}).Build()
+	hidden := lesson.HideAnswers()
+	t.Assert().Empty(hidden.Tests[0].Answer)
+	t.Assert().Equal("42", lesson.Tests[0].Answer)
+}
+
+func TestLessonPreviewSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Lesson preview", new(LessonPreviewSuite))
+}
+
+// UpdateLessonPreview Suite
+type LessonUpdateLessonPreviewSuite struct {
+	LessonSuite
+}
+
+func LessonUpdateLessonPreviewSuccessRepositoryMock(repository *mocks.LessonRepository,
+	lesson domain.Lesson) {
+	repository.
+		On("FindByID", context.Background(), lesson.ID).
+		Return(lesson, nil)
+	repository.
+		On("FindCourseVersionLessons", context.Background(), lesson.CourseID, lesson.Version).
+		Return([]domain.Lesson{lesson, NewLessonBuilder().WithPreview(true).Build()}, nil)
+	repository.
+		On("UpdatePreview", context.Background(), lesson.ID, true).
+		Return(NewLessonBuilder().WithID(lesson.ID).WithPreview(true).Build(), nil)
+}
+
+func (s *LessonUpdateLessonPreviewSuite) TestUpdateLessonPreview_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson service update lesson preview success")
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	lesson := NewLessonBuilder().WithID(domain.NewID()).Build()
+	LessonUpdateLessonPreviewSuccessRepositoryMock(lessonRepository, lesson)
+	updated, err := lessonService.UpdateLessonPreview(context.Background(), lesson.ID, true)
+	t.Assert().Nil(err)
+	t.Assert().True(updated.IsPreview)
+}
+
+func LessonUpdateLessonPreviewFailureRepositoryMock(repository *mocks.LessonRepository,
+	lesson domain.Lesson) {
+	lessons := []domain.Lesson{lesson}
+	for i := 0; i < domain.MaxCoursePreviewLessons; i++ {
+		lessons = append(lessons, NewLessonBuilder().WithID(domain.NewID()).WithPreview(true).Build())
+	}
+	repository.
+		On("FindByID", context.Background(), lesson.ID).
+		Return(lesson, nil)
+	repository.
+		On("FindCourseVersionLessons", context.Background(), lesson.CourseID, lesson.Version).
+		Return(lessons, nil)
+}
+
+func (s *LessonUpdateLessonPreviewSuite) TestUpdateLessonPreview_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson service update lesson preview over the limit")
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	lesson := NewLessonBuilder().WithID(domain.NewID()).Build()
+	LessonUpdateLessonPreviewFailureRepositoryMock(lessonRepository, lesson)
+	_, err := lessonService.UpdateLessonPreview(context.Background(), lesson.ID, true)
+	t.Assert().ErrorIs(err, errs.ErrLessonPreviewLimit)
+}
+
+func LessonUpdateLessonPreviewUnmarkRepositoryMock(repository *mocks.LessonRepository,
+	lesson domain.Lesson) {
+	repository.
+		On("FindByID", context.Background(), lesson.ID).
+		Return(lesson, nil)
+	repository.
+		On("UpdatePreview", context.Background(), lesson.ID, false).
+		Return(NewLessonBuilder().WithID(lesson.ID).Build(), nil)
+}
+
+func (s *LessonUpdateLessonPreviewSuite) TestUpdateLessonPreview_Unmark(t provider.T) {
+	t.Parallel()
+	t.Title("Lesson service unmark preview lesson")
+	lessonRepository := mocks.NewLessonRepository(t)
+	objectStorage := mocks.NewObjectStorage(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	lessonService := service.NewLessonService(lessonRepository, courseRepository,
+		objectStorage, s.logger)
+	lesson := NewLessonBuilder().WithID(domain.NewID()).WithPreview(true).Build()
+	LessonUpdateLessonPreviewUnmarkRepositoryMock(lessonRepository, lesson)
+	updated, err := lessonService.UpdateLessonPreview(context.Background(), lesson.ID, false)
+	t.Assert().Nil(err)
+	t.Assert().False(updated.IsPreview)
+}
+
+func TestLessonUpdateLessonPreviewSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Lesson service update lesson preview", new(LessonUpdateLessonPreviewSuite))
+}
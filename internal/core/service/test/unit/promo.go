@@ -0,0 +1,60 @@
+package unit
+
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type PromoCodeBuilder struct {
+	promo domain.PromoCode
+}
+
+func NewPromoCodeBuilder() *PromoCodeBuilder {
+	return &PromoCodeBuilder{
+		promo: domain.PromoCode{
+			ID:           domain.NewID(),
+			SchoolID:     domain.NewID(),
+			Code:         "SPRING25",
+			DiscountType: domain.PromoPercentDiscount,
+			Discount:     25,
+			CreatedAt:    time.Now(),
+		},
+	}
+}
+
+func (b *PromoCodeBuilder) WithSchoolID(schoolID domain.ID) *PromoCodeBuilder {
+	b.promo.SchoolID = schoolID
+	return b
+}
+
+func (b *PromoCodeBuilder) WithCourseID(courseID domain.ID) *PromoCodeBuilder {
+	b.promo.CourseID = null.StringFrom(courseID.String())
+	return b
+}
+
+func (b *PromoCodeBuilder) WithDiscount(discountType domain.PromoDiscountType, discount int64) *PromoCodeBuilder {
+	b.promo.DiscountType = discountType
+	b.promo.Discount = discount
+	return b
+}
+
+func (b *PromoCodeBuilder) WithCurrency(currency string) *PromoCodeBuilder {
+	b.promo.Currency = currency
+	return b
+}
+
+func (b *PromoCodeBuilder) WithUsage(maxUses, usedCount int64) *PromoCodeBuilder {
+	b.promo.MaxUses = null.IntFrom(maxUses)
+	b.promo.UsedCount = usedCount
+	return b
+}
+
+func (b *PromoCodeBuilder) WithValidUntil(validUntil time.Time) *PromoCodeBuilder {
+	b.promo.ValidUntil = null.TimeFrom(validUntil)
+	return b
+}
+
+func (b *PromoCodeBuilder) Build() domain.PromoCode {
+	return b.promo
+}
@@ -0,0 +1,195 @@
+package unit
+
+import (
+	"context"
+	"github.com/guregu/null"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+type PromoCodeSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *PromoCodeSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+// CreateSchoolPromoCode Suite
+type PromoCodeCreateSuite struct {
+	PromoCodeSuite
+}
+
+func PromoCodeCreateSuccessRepositoryMock(repository *mocks.PromoCodeRepository,
+	courseRepository *mocks.CourseRepository, course domain.Course) {
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	repository.
+		On("Create", context.Background(), mock.MatchedBy(func(promo domain.PromoCode) bool {
+			return promo.Code == "SPRING25" && promo.SchoolID == course.SchoolID && promo.Currency == ""
+		})).
+		Return(NewPromoCodeBuilder().WithSchoolID(course.SchoolID).WithCourseID(course.ID).Build(), nil)
+}
+
+func (s *PromoCodeCreateSuite) TestCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Create course promo code success")
+	repository := mocks.NewPromoCodeRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	promoService := service.NewPromoCodeService(repository, courseRepository, s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).Build()
+	PromoCodeCreateSuccessRepositoryMock(repository, courseRepository, course)
+	promo, err := promoService.CreateSchoolPromoCode(context.Background(), course.SchoolID,
+		port.CreatePromoCodeParam{
+			CourseID:     null.StringFrom(course.ID.String()),
+			Code:         "spring25",
+			DiscountType: domain.PromoPercentDiscount,
+			Discount:     25,
+			MaxUses:      null.IntFrom(100),
+		})
+	t.Assert().Nil(err)
+	t.Assert().Equal(course.ID.String(), promo.CourseID.String)
+}
+
+func (s *PromoCodeCreateSuite) TestCreate_InvalidDiscount(t provider.T) {
+	t.Parallel()
+	t.Title("Create promo code with percent discount over 100")
+	repository := mocks.NewPromoCodeRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	promoService := service.NewPromoCodeService(repository, courseRepository, s.logger)
+	_, err := promoService.CreateSchoolPromoCode(context.Background(), domain.NewID(),
+		port.CreatePromoCodeParam{
+			Code:         "SPRING25",
+			DiscountType: domain.PromoPercentDiscount,
+			Discount:     150,
+		})
+	t.Assert().ErrorIs(err, errs.ErrPromoCodeInvalidDiscount)
+}
+
+func (s *PromoCodeCreateSuite) TestCreate_InvalidValidity(t provider.T) {
+	t.Parallel()
+	t.Title("Create promo code with validity window ending before start")
+	repository := mocks.NewPromoCodeRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	promoService := service.NewPromoCodeService(repository, courseRepository, s.logger)
+	now := time.Now()
+	_, err := promoService.CreateSchoolPromoCode(context.Background(), domain.NewID(),
+		port.CreatePromoCodeParam{
+			Code:         "SPRING25",
+			DiscountType: domain.PromoFixedDiscount,
+			Discount:     500,
+			ValidFrom:    null.TimeFrom(now),
+			ValidUntil:   null.TimeFrom(now.Add(-time.Hour)),
+		})
+	t.Assert().ErrorIs(err, errs.ErrPromoCodeInvalidValidity)
+}
+
+func PromoCodeCreateFailureRepositoryMock(courseRepository *mocks.CourseRepository, course domain.Course) {
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+}
+
+func (s *PromoCodeCreateSuite) TestCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Create promo code for course of another school")
+	repository := mocks.NewPromoCodeRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	promoService := service.NewPromoCodeService(repository, courseRepository, s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).Build()
+	PromoCodeCreateFailureRepositoryMock(courseRepository, course)
+	_, err := promoService.CreateSchoolPromoCode(context.Background(), domain.NewID(),
+		port.CreatePromoCodeParam{
+			CourseID:     null.StringFrom(course.ID.String()),
+			Code:         "SPRING25",
+			DiscountType: domain.PromoPercentDiscount,
+			Discount:     25,
+		})
+	t.Assert().ErrorIs(err, errs.ErrPromoCodeNotApplicable)
+}
+
+func PromoCodeCreateFixedRepositoryMock(repository *mocks.PromoCodeRepository,
+	courseRepository *mocks.CourseRepository, course domain.Course) {
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	repository.
+		On("Create", context.Background(), mock.MatchedBy(func(promo domain.PromoCode) bool {
+			return promo.DiscountType == domain.PromoFixedDiscount && promo.Currency == course.Price.Currency
+		})).
+		Return(NewPromoCodeBuilder().WithDiscount(domain.PromoFixedDiscount, 500).
+			WithCurrency(course.Price.Currency).Build(), nil)
+}
+
+func (s *PromoCodeCreateSuite) TestCreate_FixedCourseCurrency(t provider.T) {
+	t.Parallel()
+	t.Title("Create fixed course promo code in the course currency")
+	repository := mocks.NewPromoCodeRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	promoService := service.NewPromoCodeService(repository, courseRepository, s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).
+		WithCurrency("USD").Build()
+	PromoCodeCreateFixedRepositoryMock(repository, courseRepository, course)
+	promo, err := promoService.CreateSchoolPromoCode(context.Background(), course.SchoolID,
+		port.CreatePromoCodeParam{
+			CourseID:     null.StringFrom(course.ID.String()),
+			Code:         "SPRING25",
+			DiscountType: domain.PromoFixedDiscount,
+			Discount:     500,
+		})
+	t.Assert().Nil(err)
+	t.Assert().Equal("USD", promo.Currency)
+}
+
+func (s *PromoCodeCreateSuite) TestCreate_CurrencyMismatch(t provider.T) {
+	t.Parallel()
+	t.Title("Create course promo code in currency other than the course one")
+	repository := mocks.NewPromoCodeRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	promoService := service.NewPromoCodeService(repository, courseRepository, s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).
+		WithCurrency("USD").Build()
+	PromoCodeCreateFailureRepositoryMock(courseRepository, course)
+	_, err := promoService.CreateSchoolPromoCode(context.Background(), course.SchoolID,
+		port.CreatePromoCodeParam{
+			CourseID:     null.StringFrom(course.ID.String()),
+			Code:         "SPRING25",
+			DiscountType: domain.PromoFixedDiscount,
+			Discount:     500,
+			Currency:     "RUB",
+		})
+	t.Assert().ErrorIs(err, errs.ErrPromoCodeCurrencyMismatch)
+}
+
+func (s *PromoCodeCreateSuite) TestCreate_InvalidCurrency(t provider.T) {
+	t.Parallel()
+	t.Title("Create promo code in unsupported currency")
+	repository := mocks.NewPromoCodeRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	promoService := service.NewPromoCodeService(repository, courseRepository, s.logger)
+	_, err := promoService.CreateSchoolPromoCode(context.Background(), domain.NewID(),
+		port.CreatePromoCodeParam{
+			Code:         "SPRING25",
+			DiscountType: domain.PromoFixedDiscount,
+			Discount:     500,
+			Currency:     "XXX",
+		})
+	t.Assert().ErrorIs(err, errs.ErrPromoCodeInvalidCurrency)
+}
+
+func TestPromoCodeCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Create promo code", new(PromoCodeCreateSuite))
+}
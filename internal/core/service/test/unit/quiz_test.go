@@ -0,0 +1,278 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/service"
+	"go.uber.org/zap"
+	"testing"
+)
+
+const qtiChoiceItem = `<?xml version="1.0" encoding="UTF-8"?>
+<assessmentItem xmlns="http://www.imsglobal.org/xsd/imsqti_v2p1" identifier="capital" title="Capital">
+  <responseDeclaration identifier="RESPONSE" cardinality="single" baseType="identifier">
+    <correctResponse><value>B</value></correctResponse>
+  </responseDeclaration>
+  <outcomeDeclaration identifier="SCORE" cardinality="single" baseType="float" normalMaximum="2"/>
+  <itemBody>
+    <p>Choose the <b>capital</b> of France.</p>
+    <choiceInteraction responseIdentifier="RESPONSE" maxChoices="1">
+      <simpleChoice identifier="A">Lyon</simpleChoice>
+      <simpleChoice identifier="B">Paris</simpleChoice>
+    </choiceInteraction>
+  </itemBody>
+</assessmentItem>`
+
+const qtiMultipleChoiceItem = `<?xml version="1.0" encoding="UTF-8"?>
+<assessmentItem xmlns="http://www.imsglobal.org/xsd/imsqti_v2p1" identifier="primes" title="Primes">
+  <responseDeclaration identifier="RESPONSE" cardinality="multiple" baseType="identifier">
+    <correctResponse><value>C</value><value>A</value></correctResponse>
+  </responseDeclaration>
+  <itemBody>
+    <choiceInteraction responseIdentifier="RESPONSE" maxChoices="0">
+      <prompt>Which numbers are prime?</prompt>
+      <simpleChoice identifier="A">2</simpleChoice>
+      <simpleChoice identifier="B">4</simpleChoice>
+      <simpleChoice identifier="C">5</simpleChoice>
+    </choiceInteraction>
+  </itemBody>
+</assessmentItem>`
+
+const qtiTextEntryItem = `<?xml version="1.0" encoding="UTF-8"?>
+<assessmentItem xmlns="http://www.imsglobal.org/xsd/imsqti_v2p1" identifier="essay" title="Essay">
+  <responseDeclaration identifier="RESPONSE" cardinality="single" baseType="string"/>
+  <itemBody>
+    <p>Name the capital of France <textEntryInteraction responseIdentifier="RESPONSE"/></p>
+  </itemBody>
+</assessmentItem>`
+
+const qtiTest = `<?xml version="1.0" encoding="UTF-8"?>
+<assessmentTest xmlns="http://www.imsglobal.org/xsd/imsqti_v2p1" identifier="test" title="Geography">
+  <testPart identifier="part" navigationMode="linear" submissionMode="individual">
+    <assessmentSection identifier="section" title="Section" visible="true">
+      <assessmentItemRef identifier="capital" href="../items/capital.xml"/>
+      <assessmentItemRef identifier="lost" href="../items/lost.xml"/>
+    </assessmentSection>
+  </testPart>
+</assessmentTest>`
+
+const qtiManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest xmlns="http://www.imsglobal.org/xsd/imscp_v1p1" identifier="manifest">
+  <resources>
+    <resource identifier="test" type="imsqti_test_xmlv2p1" href="tests/test.xml"/>
+    <resource identifier="capital" type="imsqti_item_xmlv2p1" href="items/capital.xml"/>
+    <resource identifier="primes" type="imsqti_item_xmlv2p1" href="items/primes.xml"/>
+  </resources>
+</manifest>`
+
+const ccManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest xmlns="http://www.imsglobal.org/xsd/imsccv1p1/imscp_v1p1" identifier="cartridge">
+  <metadata>
+    <lom xmlns="http://ltsc.ieee.org/xsd/imsccv1p1/LOM/resource">
+      <general><title><string>History course</string></title></general>
+    </lom>
+  </metadata>
+  <resources>
+    <resource identifier="quiz" type="imsqti_xmlv1p2/imscc_xmlv1p1/assessment">
+      <file href="quiz/assessment.xml"/>
+    </resource>
+    <resource identifier="page" type="webcontent" href="page.html"/>
+  </resources>
+</manifest>`
+
+const ccAssessment = `<?xml version="1.0" encoding="UTF-8"?>
+<questestinterop xmlns="http://www.imsglobal.org/xsd/ims_qtiasiv1p2">
+  <assessment ident="quiz" title="History quiz">
+    <section ident="root_section">
+      <item ident="year" title="Year">
+        <itemmetadata><qtimetadata>
+          <qtimetadatafield><fieldlabel>cc_profile</fieldlabel><fieldentry>cc.multiple_choice.v0p1</fieldentry></qtimetadatafield>
+          <qtimetadatafield><fieldlabel>cc_weighting</fieldlabel><fieldentry>3</fieldentry></qtimetadatafield>
+        </qtimetadata></itemmetadata>
+        <presentation>
+          <material><mattext texttype="text/html">&lt;p&gt;When did the war end?&lt;/p&gt;</mattext></material>
+          <response_lid ident="response1" rcardinality="Single">
+            <render_choice>
+              <response_label ident="1"><material><mattext>1918</mattext></material></response_label>
+              <response_label ident="2"><material><mattext>1945</mattext></material></response_label>
+            </render_choice>
+          </response_lid>
+        </presentation>
+        <resprocessing>
+          <outcomes><decvar varname="SCORE" vartype="Decimal" minvalue="0" maxvalue="100"/></outcomes>
+          <respcondition continue="No">
+            <conditionvar><varequal respident="response1">2</varequal></conditionvar>
+            <setvar action="Set" varname="SCORE">100</setvar>
+          </respcondition>
+        </resprocessing>
+      </item>
+      <item ident="allies" title="Allies">
+        <itemmetadata><qtimetadata>
+          <qtimetadatafield><fieldlabel>cc_profile</fieldlabel><fieldentry>cc.multiple_response.v0p1</fieldentry></qtimetadatafield>
+        </qtimetadata></itemmetadata>
+        <presentation>
+          <material><mattext>Who were the allies?</mattext></material>
+          <response_lid ident="response1" rcardinality="Multiple">
+            <render_choice>
+              <response_label ident="a"><material><mattext>USA</mattext></material></response_label>
+              <response_label ident="b"><material><mattext>Italy</mattext></material></response_label>
+              <response_label ident="c"><material><mattext>UK</mattext></material></response_label>
+            </render_choice>
+          </response_lid>
+        </presentation>
+        <resprocessing>
+          <respcondition continue="No">
+            <conditionvar>
+              <and>
+                <varequal respident="response1">a</varequal>
+                <not><varequal respident="response1">b</varequal></not>
+                <varequal respident="response1">c</varequal>
+              </and>
+            </conditionvar>
+            <setvar action="Set" varname="SCORE">100</setvar>
+          </respcondition>
+        </resprocessing>
+      </item>
+      <item ident="essay" title="Essay">
+        <itemmetadata><qtimetadata>
+          <qtimetadatafield><fieldlabel>cc_profile</fieldlabel><fieldentry>cc.essay.v0p1</fieldentry></qtimetadatafield>
+        </qtimetadata></itemmetadata>
+        <presentation>
+          <material><mattext>Describe the war</mattext></material>
+          <response_str ident="response1" rcardinality="Single"><render_fib/></response_str>
+        </presentation>
+      </item>
+    </section>
+  </assessment>
+</questestinterop>`
+
+type QuizImportSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *QuizImportSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+func (s *QuizImportSuite) TestParseQuiz_ChoiceItem(t provider.T) {
+	t.Parallel()
+	t.Title("Quiz import service parses qti choice item")
+	quizService := service.NewQuizImportService(s.logger)
+	data := []byte(qtiChoiceItem)
+	quiz, err := quizService.ParseQuiz(context.Background(), bytes.NewReader(data), int64(len(data)))
+	t.Require().Nil(err)
+	t.Require().Len(quiz.Lessons, 1)
+	t.Assert().Empty(quiz.Skipped)
+	t.Assert().Equal(2, quiz.Lessons[0].Score)
+	t.Require().Len(quiz.Lessons[0].Tests, 1)
+	test := quiz.Lessons[0].Tests[0]
+	t.Assert().Equal("### Capital\n\nChoose the capital of France.", test.Task)
+	t.Assert().Equal([]string{"Lyon", "Paris"}, test.Options)
+	t.Assert().Equal("Paris", test.Answer)
+	t.Assert().Equal(2, test.Score)
+}
+
+func (s *QuizImportSuite) TestParseQuiz_MultipleChoiceItem(t provider.T) {
+	t.Parallel()
+	t.Title("Quiz import service joins answers of multiple choice item")
+	quizService := service.NewQuizImportService(s.logger)
+	data := []byte(qtiMultipleChoiceItem)
+	quiz, err := quizService.ParseQuiz(context.Background(), bytes.NewReader(data), int64(len(data)))
+	t.Require().Nil(err)
+	t.Require().Len(quiz.Lessons, 1)
+	test := quiz.Lessons[0].Tests[0]
+	t.Assert().Equal("### Primes\n\nWhich numbers are prime?", test.Task)
+	t.Assert().Equal(domain.JoinTestAnswers([]string{"2", "5"}), test.Answer)
+	t.Assert().Equal(1, test.Score)
+}
+
+func (s *QuizImportSuite) TestParseQuiz_UnsupportedItem(t provider.T) {
+	t.Parallel()
+	t.Title("Quiz import service skips text entry item")
+	quizService := service.NewQuizImportService(s.logger)
+	data := []byte(qtiTextEntryItem)
+	quiz, err := quizService.ParseQuiz(context.Background(), bytes.NewReader(data), int64(len(data)))
+	t.Require().Nil(err)
+	t.Assert().Empty(quiz.Lessons)
+	t.Require().Len(quiz.Skipped, 1)
+	t.Assert().Equal("essay", quiz.Skipped[0].Identifier)
+	t.Assert().Equal("textEntryInteraction is not supported", quiz.Skipped[0].Reason)
+}
+
+func (s *QuizImportSuite) TestParseQuiz_QtiPackage(t provider.T) {
+	t.Parallel()
+	t.Title("Quiz import service parses qti package with test")
+	quizService := service.NewQuizImportService(s.logger)
+	data := writeArchiveFiles(t, map[string]string{
+		"imsmanifest.xml":   qtiManifest,
+		"tests/test.xml":    qtiTest,
+		"items/capital.xml": qtiChoiceItem,
+		"items/primes.xml":  qtiMultipleChoiceItem,
+	})
+	quiz, err := quizService.ParseQuiz(context.Background(), bytes.NewReader(data), int64(len(data)))
+	t.Require().Nil(err)
+	t.Require().Len(quiz.Lessons, 2)
+	t.Assert().Equal("Geography", quiz.Lessons[0].Title)
+	t.Require().Len(quiz.Lessons[0].Tests, 1)
+	t.Assert().Equal("Paris", quiz.Lessons[0].Tests[0].Answer)
+	// item not referenced by the test gets the lesson of loose items
+	t.Assert().Equal("Imported quiz", quiz.Lessons[1].Title)
+	t.Require().Len(quiz.Lessons[1].Tests, 1)
+	t.Require().Len(quiz.Skipped, 1)
+	t.Assert().Equal("lost", quiz.Skipped[0].Identifier)
+}
+
+func (s *QuizImportSuite) TestParseQuiz_CommonCartridge(t provider.T) {
+	t.Parallel()
+	t.Title("Quiz import service parses common cartridge assessment")
+	quizService := service.NewQuizImportService(s.logger)
+	data := writeArchiveFiles(t, map[string]string{
+		"imsmanifest.xml":     ccManifest,
+		"quiz/assessment.xml": ccAssessment,
+		"page.html":           "<p>page</p>",
+	})
+	quiz, err := quizService.ParseQuiz(context.Background(), bytes.NewReader(data), int64(len(data)))
+	t.Require().Nil(err)
+	t.Require().Len(quiz.Lessons, 1)
+	lesson := quiz.Lessons[0]
+	t.Assert().Equal("History quiz", lesson.Title)
+	t.Assert().Equal(4, lesson.Score)
+	t.Require().Len(lesson.Tests, 2)
+	t.Assert().Equal("### Year\n\nWhen did the war end?", lesson.Tests[0].Task)
+	t.Assert().Equal("1945", lesson.Tests[0].Answer)
+	t.Assert().Equal(3, lesson.Tests[0].Score)
+	t.Assert().Equal(domain.JoinTestAnswers([]string{"USA", "UK"}), lesson.Tests[1].Answer)
+	t.Require().Len(quiz.Skipped, 1)
+	t.Assert().Equal("essay", quiz.Skipped[0].Identifier)
+	t.Assert().Equal("cc.essay.v0p1 items are not supported", quiz.Skipped[0].Reason)
+}
+
+func (s *QuizImportSuite) TestParseQuiz_Invalid(t provider.T) {
+	t.Parallel()
+	t.Title("Quiz import service rejects file that is not qti")
+	quizService := service.NewQuizImportService(s.logger)
+	data := []byte("<html><body>not a quiz</body></html>")
+	_, err := quizService.ParseQuiz(context.Background(), bytes.NewReader(data), int64(len(data)))
+	t.Assert().ErrorIs(err, errs.ErrQuizPackageInvalid)
+}
+
+func (s *QuizImportSuite) TestMultipleAnswerValidation(t provider.T) {
+	t.Parallel()
+	t.Title("Test with several correct options has no answer warning")
+	test := NewTestBuilder().WithAnswer(domain.JoinTestAnswers([]string{"opt1", "opt2"})).Build()
+	lesson := NewLessonBuilder().WithType(domain.PracticeLesson).
+		WithTests([]domain.Test{test}).Build()
+	t.Assert().Empty(lesson.Issues())
+	t.Assert().Equal([]string{"opt1", "opt2"}, test.Answers())
+}
+
+func TestQuizImportSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Quiz import service", new(QuizImportSuite))
+}
@@ -0,0 +1,39 @@
+package unit
+
+import (
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type RefundBuilder struct {
+	refund domain.Refund
+}
+
+func NewRefundBuilder() *RefundBuilder {
+	return &RefundBuilder{
+		refund: domain.Refund{
+			ID:          domain.NewID(),
+			UserID:      domain.NewID(),
+			CourseID:    domain.NewID(),
+			PaymentKey:  "key",
+			Amount:      3990,
+			Reason:      "reason",
+			Status:      domain.RefundRequested,
+			RequestedAt: time.Now(),
+		},
+	}
+}
+
+func (b *RefundBuilder) WithCourseID(courseID domain.ID) *RefundBuilder {
+	b.refund.CourseID = courseID
+	return b
+}
+
+func (b *RefundBuilder) WithStatus(status domain.RefundStatus) *RefundBuilder {
+	b.refund.Status = status
+	return b
+}
+
+func (b *RefundBuilder) Build() domain.Refund {
+	return b.refund
+}
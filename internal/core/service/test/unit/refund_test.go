@@ -0,0 +1,286 @@
+package unit
+
+import (
+	"context"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"testing"
+)
+
+type RefundSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *RefundSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+// RequestCourseRefund Suite
+type RefundRequestCourseRefundSuite struct {
+	RefundSuite
+}
+
+func RefundRequestCourseRefundSuccessRepositoryMock(repository *mocks.RefundRepository,
+	paymentRepository *mocks.PaymentRepository, courseRepository *mocks.CourseRepository,
+	payment domain.Payment) {
+	courseRepository.
+		On("FindEnrollment", context.Background(), payment.UserID, payment.CourseID).
+		Return(NewEnrollmentBuilder().Build(), nil)
+	paymentRepository.
+		On("FindCoursePayment", context.Background(), payment.UserID, payment.CourseID).
+		Return(payment, nil)
+	repository.
+		On("Create", context.Background(), mock.MatchedBy(func(refund domain.Refund) bool {
+			return refund.Amount == payment.PaySum && refund.PaymentKey == payment.Key &&
+				refund.Status == domain.RefundRequested
+		})).
+		Return(NewRefundBuilder().Build(), nil)
+}
+
+func (s *RefundRequestCourseRefundSuite) TestRequestCourseRefund_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Request course refund success")
+	repository := mocks.NewRefundRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	gateway := mocks.NewPaymentGateway(t)
+	seatListener := mocks.NewSeatListener(t)
+	refundService := service.NewRefundService(repository, paymentRepository, courseRepository, gateway, seatListener,
+		s.logger)
+	payment := domain.Payment{
+		UserID:   domain.NewID(),
+		CourseID: domain.NewID(),
+		Key:      "key",
+		PaySum:   3990,
+	}
+	RefundRequestCourseRefundSuccessRepositoryMock(repository, paymentRepository, courseRepository, payment)
+	_, err := refundService.RequestCourseRefund(context.Background(), payment.UserID, payment.CourseID,
+		port.CreateRefundParam{Reason: "reason"})
+	t.Assert().Nil(err)
+}
+
+func RefundRequestCourseRefundFailureRepositoryMock(paymentRepository *mocks.PaymentRepository,
+	courseRepository *mocks.CourseRepository) {
+	courseRepository.
+		On("FindEnrollment", context.Background(), mock.Anything, mock.Anything).
+		Return(NewEnrollmentBuilder().Build(), nil)
+	paymentRepository.
+		On("FindCoursePayment", context.Background(), mock.Anything, mock.Anything).
+		Return(domain.Payment{}, errs.ErrNotExist)
+}
+
+func (s *RefundRequestCourseRefundSuite) TestRequestCourseRefund_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Request refund for course that was not paid")
+	repository := mocks.NewRefundRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	gateway := mocks.NewPaymentGateway(t)
+	seatListener := mocks.NewSeatListener(t)
+	refundService := service.NewRefundService(repository, paymentRepository, courseRepository, gateway, seatListener,
+		s.logger)
+	RefundRequestCourseRefundFailureRepositoryMock(paymentRepository, courseRepository)
+	_, err := refundService.RequestCourseRefund(context.Background(), domain.NewID(), domain.NewID(),
+		port.CreateRefundParam{Reason: "reason"})
+	t.Assert().ErrorIs(err, errs.ErrCourseNotPaid)
+}
+
+func RefundRequestCourseRefundSubscriberRepositoryMock(courseRepository *mocks.CourseRepository,
+	userID, courseID domain.ID) {
+	courseRepository.
+		On("FindEnrollment", context.Background(), userID, courseID).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
+}
+
+func (s *RefundRequestCourseRefundSuite) TestRequestCourseRefund_Subscriber(t provider.T) {
+	t.Parallel()
+	t.Title("Request refund by subscriber who isn't enrolled in the course")
+	repository := mocks.NewRefundRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	gateway := mocks.NewPaymentGateway(t)
+	seatListener := mocks.NewSeatListener(t)
+	refundService := service.NewRefundService(repository, paymentRepository, courseRepository, gateway, seatListener,
+		s.logger)
+	userID, courseID := domain.NewID(), domain.NewID()
+	RefundRequestCourseRefundSubscriberRepositoryMock(courseRepository, userID, courseID)
+	_, err := refundService.RequestCourseRefund(context.Background(), userID, courseID,
+		port.CreateRefundParam{Reason: "reason"})
+	t.Assert().ErrorIs(err, errs.ErrUserIsNotCourseStudent)
+}
+
+func TestRefundRequestCourseRefundSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Request course refund", new(RefundRequestCourseRefundSuite))
+}
+
+// ApproveRefund Suite
+type RefundApproveRefundSuite struct {
+	RefundSuite
+}
+
+func RefundApproveRefundSuccessRepositoryMock(repository *mocks.RefundRepository,
+	courseRepository *mocks.CourseRepository, gateway *mocks.PaymentGateway,
+	refund domain.Refund, course domain.Course, approverID domain.ID) {
+	repository.
+		On("FindByID", context.Background(), refund.ID).
+		Return(refund, nil)
+	courseRepository.
+		On("FindByID", context.Background(), refund.CourseID).
+		Return(course, nil)
+	repository.
+		On("StartApproval", context.Background(), refund.ID).
+		Return(nil)
+	gateway.
+		On("Refund", context.Background(), refund).
+		Return(nil)
+	repository.
+		On("Approve", context.Background(), refund.ID, approverID, mock.Anything).
+		Return(nil)
+}
+
+func (s *RefundApproveRefundSuite) TestApproveRefund_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Approve refund success")
+	repository := mocks.NewRefundRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	gateway := mocks.NewPaymentGateway(t)
+	seatListener := mocks.NewSeatListener(t)
+	refundService := service.NewRefundService(repository, paymentRepository, courseRepository, gateway, seatListener,
+		s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).Build()
+	refund := NewRefundBuilder().WithCourseID(course.ID).Build()
+	approverID := domain.NewID()
+	RefundApproveRefundSuccessRepositoryMock(repository, courseRepository, gateway, refund, course, approverID)
+	seatListener.
+		On("OfferFreeSeats", context.Background(), course.ID).
+		Return(nil)
+	approved, err := refundService.ApproveRefund(context.Background(), course.SchoolID, refund.ID, approverID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(domain.RefundApproved, approved.Status)
+	t.Assert().Equal(approverID.String(), approved.ResolvedBy.String)
+}
+
+func RefundApproveRefundFailureRepositoryMock(repository *mocks.RefundRepository,
+	courseRepository *mocks.CourseRepository, refund domain.Refund, course domain.Course) {
+	repository.
+		On("FindByID", context.Background(), refund.ID).
+		Return(refund, nil)
+	courseRepository.
+		On("FindByID", context.Background(), refund.CourseID).
+		Return(course, nil)
+}
+
+func (s *RefundApproveRefundSuite) TestApproveRefund_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Approve refund of another school course")
+	repository := mocks.NewRefundRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	gateway := mocks.NewPaymentGateway(t)
+	seatListener := mocks.NewSeatListener(t)
+	refundService := service.NewRefundService(repository, paymentRepository, courseRepository, gateway, seatListener,
+		s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).Build()
+	refund := NewRefundBuilder().WithCourseID(course.ID).Build()
+	RefundApproveRefundFailureRepositoryMock(repository, courseRepository, refund, course)
+	_, err := refundService.ApproveRefund(context.Background(), domain.NewID(), refund.ID, domain.NewID())
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func (s *RefundApproveRefundSuite) TestApproveRefund_AlreadyResolved(t provider.T) {
+	t.Parallel()
+	t.Title("Approve already rejected refund")
+	repository := mocks.NewRefundRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	gateway := mocks.NewPaymentGateway(t)
+	seatListener := mocks.NewSeatListener(t)
+	refundService := service.NewRefundService(repository, paymentRepository, courseRepository, gateway, seatListener,
+		s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).Build()
+	refund := NewRefundBuilder().WithCourseID(course.ID).WithStatus(domain.RefundRejected).Build()
+	RefundApproveRefundFailureRepositoryMock(repository, courseRepository, refund, course)
+	_, err := refundService.ApproveRefund(context.Background(), course.SchoolID, refund.ID, domain.NewID())
+	t.Assert().ErrorIs(err, errs.ErrRefundAlreadyResolved)
+}
+
+func RefundApproveRefundGatewayFailureRepositoryMock(repository *mocks.RefundRepository,
+	courseRepository *mocks.CourseRepository, gateway *mocks.PaymentGateway,
+	refund domain.Refund, course domain.Course) {
+	RefundApproveRefundFailureRepositoryMock(repository, courseRepository, refund, course)
+	repository.
+		On("StartApproval", context.Background(), refund.ID).
+		Return(nil)
+	gateway.
+		On("Refund", context.Background(), refund).
+		Return(errs.ErrPaymentGatewayFailed)
+	repository.
+		On("CancelApproval", context.Background(), refund.ID).
+		Return(nil)
+}
+
+func (s *RefundApproveRefundSuite) TestApproveRefund_GatewayFailure(t provider.T) {
+	t.Parallel()
+	t.Title("Approve refund returns it to requested when pay back fails")
+	repository := mocks.NewRefundRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	gateway := mocks.NewPaymentGateway(t)
+	seatListener := mocks.NewSeatListener(t)
+	refundService := service.NewRefundService(repository, paymentRepository, courseRepository, gateway, seatListener,
+		s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).Build()
+	refund := NewRefundBuilder().WithCourseID(course.ID).Build()
+	RefundApproveRefundGatewayFailureRepositoryMock(repository, courseRepository, gateway, refund, course)
+	_, err := refundService.ApproveRefund(context.Background(), course.SchoolID, refund.ID, domain.NewID())
+	t.Assert().ErrorIs(err, errs.ErrPaymentGatewayFailed)
+}
+
+func RefundApproveRefundApprovingRepositoryMock(repository *mocks.RefundRepository,
+	courseRepository *mocks.CourseRepository, gateway *mocks.PaymentGateway,
+	refund domain.Refund, course domain.Course, approverID domain.ID) {
+	RefundApproveRefundFailureRepositoryMock(repository, courseRepository, refund, course)
+	gateway.
+		On("Refund", context.Background(), refund).
+		Return(nil)
+	repository.
+		On("Approve", context.Background(), refund.ID, approverID, mock.Anything).
+		Return(nil)
+}
+
+func (s *RefundApproveRefundSuite) TestApproveRefund_Approving(t provider.T) {
+	t.Parallel()
+	t.Title("Approve refund left approving by failed approval")
+	repository := mocks.NewRefundRepository(t)
+	paymentRepository := mocks.NewPaymentRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	gateway := mocks.NewPaymentGateway(t)
+	seatListener := mocks.NewSeatListener(t)
+	refundService := service.NewRefundService(repository, paymentRepository, courseRepository, gateway, seatListener,
+		s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithSchoolID(domain.NewID()).Build()
+	refund := NewRefundBuilder().WithCourseID(course.ID).WithStatus(domain.RefundApproving).Build()
+	approverID := domain.NewID()
+	RefundApproveRefundApprovingRepositoryMock(repository, courseRepository, gateway, refund, course, approverID)
+	seatListener.
+		On("OfferFreeSeats", context.Background(), course.ID).
+		Return(nil)
+	approved, err := refundService.ApproveRefund(context.Background(), course.SchoolID, refund.ID, approverID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(domain.RefundApproved, approved.Status)
+}
+
+func TestRefundApproveRefundSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Approve refund", new(RefundApproveRefundSuite))
+}
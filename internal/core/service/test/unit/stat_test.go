@@ -74,6 +74,62 @@ func TestStatFindByIDSuite(t *testing.T) {
 	suite.RunNamedSuite(t, "Find lesson stat", new(StatFindLessonStatSuite))
 }
 
+// FindOrCreateLessonStat Suite
+type StatFindOrCreateLessonStatSuite struct {
+	StatSuite
+}
+
+func (s *StatFindOrCreateLessonStatSuite) TestFindOrCreate_Existing(t provider.T) {
+	t.Parallel()
+	t.Title("Find or create existing lesson stat")
+	userID := domain.NewID()
+	lessonID := domain.NewID()
+	statRepository := mocks.NewStatRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statService := service.NewStatService(statRepository, lessonRepository, s.logger)
+	StatFindByIDSuccessRepositoryMock(statRepository, userID, lessonID)
+	stat, err := statService.FindOrCreateLessonStat(context.Background(), userID, lessonID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(stat.LessonID, lessonID)
+}
+
+func (s *StatFindOrCreateLessonStatSuite) TestFindOrCreate_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Find or create missing lesson stat")
+	userID := domain.NewID()
+	lessonID := domain.NewID()
+	statRepository := mocks.NewStatRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statService := service.NewStatService(statRepository, lessonRepository, s.logger)
+	statRepository.
+		On("FindLessonStat", context.Background(), userID, lessonID).
+		Return(domain.LessonStat{}, errs.ErrNotExist).Once()
+	StatCreateSuccessRepositoryMock(statRepository, lessonRepository, lessonID)
+	StatFindByIDSuccessRepositoryMock(statRepository, userID, lessonID)
+	stat, err := statService.FindOrCreateLessonStat(context.Background(), userID, lessonID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(stat.UserID, userID)
+}
+
+func (s *StatFindOrCreateLessonStatSuite) TestFindOrCreate_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Find or create lesson stat failure")
+	userID := domain.NewID()
+	lessonID := domain.NewID()
+	statRepository := mocks.NewStatRepository(t)
+	lessonRepository := mocks.NewLessonRepository(t)
+	statService := service.NewStatService(statRepository, lessonRepository, s.logger)
+	statRepository.
+		On("FindLessonStat", context.Background(), userID, lessonID).
+		Return(domain.LessonStat{}, errs.ErrPersistenceFailed)
+	_, err := statService.FindOrCreateLessonStat(context.Background(), userID, lessonID)
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestStatFindOrCreateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Find or create lesson stat", new(StatFindOrCreateLessonStatSuite))
+}
+
 // Create Suite
 type StatCreateSuite struct {
 	StatSuite
@@ -0,0 +1,81 @@
+package unit
+
+import (
+	"github.com/guregu/null"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"time"
+)
+
+type SubscriptionPlanBuilder struct {
+	plan domain.SubscriptionPlan
+}
+
+func NewSubscriptionPlanBuilder() *SubscriptionPlanBuilder {
+	return &SubscriptionPlanBuilder{
+		plan: domain.SubscriptionPlan{
+			ID:         domain.NewID(),
+			SchoolID:   domain.NewID(),
+			Name:       "All courses",
+			Price:      990,
+			Currency:   domain.DefaultCurrency,
+			PeriodDays: 30,
+		},
+	}
+}
+
+func (b *SubscriptionPlanBuilder) WithSchoolID(schoolID domain.ID) *SubscriptionPlanBuilder {
+	b.plan.SchoolID = schoolID
+	return b
+}
+
+func (b *SubscriptionPlanBuilder) Build() domain.SubscriptionPlan {
+	return b.plan
+}
+
+type SubscriptionBuilder struct {
+	subscription domain.Subscription
+}
+
+func NewSubscriptionBuilder() *SubscriptionBuilder {
+	now := time.Now().UTC()
+	return &SubscriptionBuilder{
+		subscription: domain.Subscription{
+			ID:        domain.NewID(),
+			PlanID:    domain.NewID(),
+			SchoolID:  domain.NewID(),
+			UserID:    domain.NewID(),
+			StartedAt: now,
+			ExpiresAt: now.AddDate(0, 0, 30),
+		},
+	}
+}
+
+func (b *SubscriptionBuilder) WithPlan(plan domain.SubscriptionPlan) *SubscriptionBuilder {
+	b.subscription.PlanID = plan.ID
+	b.subscription.SchoolID = plan.SchoolID
+	return b
+}
+
+func (b *SubscriptionBuilder) WithUserID(userID domain.ID) *SubscriptionBuilder {
+	b.subscription.UserID = userID
+	return b
+}
+
+func (b *SubscriptionBuilder) WithPaymentMethodID(paymentMethodID string) *SubscriptionBuilder {
+	b.subscription.PaymentMethodID = null.StringFrom(paymentMethodID)
+	return b
+}
+
+func (b *SubscriptionBuilder) WithExpiresAt(expiresAt time.Time) *SubscriptionBuilder {
+	b.subscription.ExpiresAt = expiresAt
+	return b
+}
+
+func (b *SubscriptionBuilder) WithCanceledAt(canceledAt time.Time) *SubscriptionBuilder {
+	b.subscription.CanceledAt = null.TimeFrom(canceledAt)
+	return b
+}
+
+func (b *SubscriptionBuilder) Build() domain.Subscription {
+	return b.subscription
+}
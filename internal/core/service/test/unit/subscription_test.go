@@ -0,0 +1,332 @@
+package unit
+
+import (
+	"context"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type SubscriptionSuite struct {
+	suite.Suite
+	logger       *zap.Logger
+	ledgerConfig *service.LedgerConfig
+}
+
+func (s *SubscriptionSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+	s.ledgerConfig = &service.LedgerConfig{CommissionPercent: 10}
+}
+
+// SaveSchoolPlan Suite
+type SubscriptionSaveSchoolPlanSuite struct {
+	SubscriptionSuite
+}
+
+func (s *SubscriptionSaveSchoolPlanSuite) TestSaveSchoolPlan_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Save school subscription plan success")
+	repository := mocks.NewSubscriptionRepository(t)
+	subscriptionService := service.NewSubscriptionService(repository, mocks.NewUserRepository(t),
+		mocks.NewPaymentGateway(t), mocks.NewLedgerRepository(t), s.ledgerConfig, s.logger)
+	schoolID := domain.NewID()
+	repository.
+		On("SavePlan", context.Background(), mock.MatchedBy(func(plan domain.SubscriptionPlan) bool {
+			return plan.SchoolID == schoolID && plan.Price == 990 && plan.PeriodDays == 30 &&
+				plan.Currency == domain.DefaultCurrency
+		})).
+		Return(NewSubscriptionPlanBuilder().WithSchoolID(schoolID).Build(), nil)
+	plan, err := subscriptionService.SaveSchoolPlan(context.Background(), schoolID,
+		port.SubscriptionPlanParam{Name: "All courses", Price: 990, PeriodDays: 30})
+	t.Assert().Nil(err)
+	t.Assert().Equal(schoolID, plan.SchoolID)
+}
+
+func (s *SubscriptionSaveSchoolPlanSuite) TestSaveSchoolPlan_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Save school subscription plan with invalid period")
+	subscriptionService := service.NewSubscriptionService(mocks.NewSubscriptionRepository(t),
+		mocks.NewUserRepository(t), mocks.NewPaymentGateway(t), mocks.NewLedgerRepository(t),
+		s.ledgerConfig, s.logger)
+	_, err := subscriptionService.SaveSchoolPlan(context.Background(), domain.NewID(),
+		port.SubscriptionPlanParam{Name: "All courses", Price: 990, PeriodDays: 0})
+	t.Assert().ErrorIs(err, errs.ErrSubscriptionInvalidPeriod)
+}
+
+func (s *SubscriptionSaveSchoolPlanSuite) TestSaveSchoolPlan_InvalidCurrency(t provider.T) {
+	t.Parallel()
+	t.Title("Save school subscription plan in unsupported currency")
+	subscriptionService := service.NewSubscriptionService(mocks.NewSubscriptionRepository(t),
+		mocks.NewUserRepository(t), mocks.NewPaymentGateway(t), mocks.NewLedgerRepository(t),
+		s.ledgerConfig, s.logger)
+	_, err := subscriptionService.SaveSchoolPlan(context.Background(), domain.NewID(),
+		port.SubscriptionPlanParam{Name: "All courses", Price: 990, Currency: "XXX", PeriodDays: 30})
+	t.Assert().ErrorIs(err, errs.ErrSubscriptionInvalidCurrency)
+}
+
+func TestSubscriptionSaveSchoolPlanSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Save school subscription plan", new(SubscriptionSaveSchoolPlanSuite))
+}
+
+// GetSubscriptionPaymentUrl Suite
+type SubscriptionGetPaymentUrlSuite struct {
+	SubscriptionSuite
+}
+
+func (s *SubscriptionGetPaymentUrlSuite) TestGetSubscriptionPaymentUrl_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Get subscription payment url success")
+	repository := mocks.NewSubscriptionRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	gateway := mocks.NewPaymentGateway(t)
+	subscriptionService := service.NewSubscriptionService(repository, userRepository,
+		gateway, mocks.NewLedgerRepository(t), s.ledgerConfig, s.logger)
+	plan := NewSubscriptionPlanBuilder().Build()
+	userID := domain.NewID()
+	userRepository.
+		On("FindByID", context.Background(), userID).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	repository.
+		On("FindSchoolPlan", context.Background(), plan.SchoolID).
+		Return(plan, nil)
+	// canceled subscription is extended by the manual payment
+	repository.
+		On("FindUserSubscription", context.Background(), userID, plan.SchoolID).
+		Return(NewSubscriptionBuilder().WithPlan(plan).WithUserID(userID).
+			WithCanceledAt(time.Now()).Build(), nil)
+	gateway.
+		On("GetPaymentUrl", context.Background(), domain.PaymentPayload{
+			UserID:             userID,
+			SubscriptionPlanID: plan.ID,
+			PaySum:             plan.Cost(),
+		}).
+		Return(url.URL{Scheme: "https", Host: "pay.example.com"}, nil)
+	link, err := subscriptionService.GetSubscriptionPaymentUrl(context.Background(), userID, plan.SchoolID)
+	t.Assert().Nil(err)
+	t.Assert().Equal("pay.example.com", link.Host)
+}
+
+func (s *SubscriptionGetPaymentUrlSuite) TestGetSubscriptionPaymentUrl_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Get subscription payment url of auto-renewed subscription")
+	repository := mocks.NewSubscriptionRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	subscriptionService := service.NewSubscriptionService(repository, userRepository,
+		mocks.NewPaymentGateway(t), mocks.NewLedgerRepository(t), s.ledgerConfig, s.logger)
+	plan := NewSubscriptionPlanBuilder().Build()
+	userID := domain.NewID()
+	userRepository.
+		On("FindByID", context.Background(), userID).
+		Return(NewUserBuilder().WithEmailVerified(true).Build(), nil)
+	repository.
+		On("FindSchoolPlan", context.Background(), plan.SchoolID).
+		Return(plan, nil)
+	repository.
+		On("FindUserSubscription", context.Background(), userID, plan.SchoolID).
+		Return(NewSubscriptionBuilder().WithPlan(plan).WithUserID(userID).
+			WithPaymentMethodID("method").Build(), nil)
+	_, err := subscriptionService.GetSubscriptionPaymentUrl(context.Background(), userID, plan.SchoolID)
+	t.Assert().ErrorIs(err, errs.ErrSubscriptionAlreadyActive)
+}
+
+func TestSubscriptionGetPaymentUrlSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Get subscription payment url", new(SubscriptionGetPaymentUrlSuite))
+}
+
+// ActivateSubscription Suite
+type SubscriptionActivateSuite struct {
+	SubscriptionSuite
+}
+
+func (s *SubscriptionActivateSuite) TestActivateSubscription_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Activate subscription success")
+	repository := mocks.NewSubscriptionRepository(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	subscriptionService := service.NewSubscriptionService(repository, mocks.NewUserRepository(t),
+		mocks.NewPaymentGateway(t), ledgerRepository, s.ledgerConfig, s.logger)
+	plan := NewSubscriptionPlanBuilder().Build()
+	payload := domain.PaymentPayload{
+		UserID:             domain.NewID(),
+		SubscriptionPlanID: plan.ID,
+		PaymentMethodID:    "method",
+		PaySum:             plan.Cost(),
+	}
+	repository.
+		On("FindPlanByID", context.Background(), plan.ID).
+		Return(plan, nil)
+	ledgerRepository.
+		On("Create", context.Background(), mock.MatchedBy(func(entry domain.LedgerEntry) bool {
+			return entry.SchoolID == plan.SchoolID && entry.CourseID == plan.ID &&
+				entry.PaymentKey == "key" && entry.Gross == 990 && entry.Commission == 99
+		})).
+		Return(nil)
+	repository.
+		On("Extend", context.Background(), mock.MatchedBy(func(subscription domain.Subscription) bool {
+			return subscription.UserID == payload.UserID && subscription.PaymentMethodID.String == "method" &&
+				subscription.ExpiresAt.Sub(subscription.StartedAt) == 30*24*time.Hour
+		}), "key").
+		Return(NewSubscriptionBuilder().WithPlan(plan).WithUserID(payload.UserID).Build(), nil)
+	subscription, err := subscriptionService.ActivateSubscription(context.Background(), "key", payload)
+	t.Assert().Nil(err)
+	t.Assert().Equal(payload.UserID, subscription.UserID)
+}
+
+func (s *SubscriptionActivateSuite) TestActivateSubscription_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Activate subscription of unknown plan")
+	repository := mocks.NewSubscriptionRepository(t)
+	subscriptionService := service.NewSubscriptionService(repository, mocks.NewUserRepository(t),
+		mocks.NewPaymentGateway(t), mocks.NewLedgerRepository(t), s.ledgerConfig, s.logger)
+	planID := domain.NewID()
+	repository.
+		On("FindPlanByID", context.Background(), planID).
+		Return(domain.SubscriptionPlan{}, errs.ErrNotExist)
+	_, err := subscriptionService.ActivateSubscription(context.Background(), "key",
+		domain.PaymentPayload{UserID: domain.NewID(), SubscriptionPlanID: planID})
+	t.Assert().ErrorIs(err, errs.ErrNotExist)
+}
+
+func TestSubscriptionActivateSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Activate subscription", new(SubscriptionActivateSuite))
+}
+
+// CancelSubscription Suite
+type SubscriptionCancelSuite struct {
+	SubscriptionSuite
+}
+
+func (s *SubscriptionCancelSuite) TestCancelSubscription_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Cancel subscription success")
+	repository := mocks.NewSubscriptionRepository(t)
+	gateway := mocks.NewPaymentGateway(t)
+	subscriptionService := service.NewSubscriptionService(repository, mocks.NewUserRepository(t),
+		gateway, mocks.NewLedgerRepository(t), s.ledgerConfig, s.logger)
+	subscription := NewSubscriptionBuilder().WithPaymentMethodID("method").Build()
+	repository.
+		On("FindUserSubscription", context.Background(), subscription.UserID, subscription.SchoolID).
+		Return(subscription, nil)
+	gateway.
+		On("CancelRecurring", context.Background(), "method").
+		Return(nil)
+	repository.
+		On("Cancel", context.Background(), subscription.ID, mock.Anything).
+		Return(nil)
+	err := subscriptionService.CancelSubscription(context.Background(),
+		subscription.UserID, subscription.SchoolID)
+	t.Assert().Nil(err)
+}
+
+func (s *SubscriptionCancelSuite) TestCancelSubscription_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Cancel already canceled subscription")
+	repository := mocks.NewSubscriptionRepository(t)
+	subscriptionService := service.NewSubscriptionService(repository, mocks.NewUserRepository(t),
+		mocks.NewPaymentGateway(t), mocks.NewLedgerRepository(t), s.ledgerConfig, s.logger)
+	subscription := NewSubscriptionBuilder().WithCanceledAt(time.Now()).Build()
+	repository.
+		On("FindUserSubscription", context.Background(), subscription.UserID, subscription.SchoolID).
+		Return(subscription, nil)
+	err := subscriptionService.CancelSubscription(context.Background(),
+		subscription.UserID, subscription.SchoolID)
+	t.Assert().ErrorIs(err, errs.ErrSubscriptionNotActive)
+}
+
+func TestSubscriptionCancelSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Cancel subscription", new(SubscriptionCancelSuite))
+}
+
+// RenewSubscriptions Suite
+type SubscriptionRenewSuite struct {
+	SubscriptionSuite
+}
+
+func (s *SubscriptionRenewSuite) TestRenewSubscriptions_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Renew subscriptions success")
+	repository := mocks.NewSubscriptionRepository(t)
+	gateway := mocks.NewPaymentGateway(t)
+	ledgerRepository := mocks.NewLedgerRepository(t)
+	subscriptionService := service.NewSubscriptionService(repository, mocks.NewUserRepository(t),
+		gateway, ledgerRepository, s.ledgerConfig, s.logger)
+	plan := NewSubscriptionPlanBuilder().Build()
+	subscription := NewSubscriptionBuilder().WithPlan(plan).WithPaymentMethodID("method").
+		WithExpiresAt(time.Now().Add(time.Hour)).Build()
+	repository.
+		On("ClaimRenewableSubscriptions", context.Background(), mock.Anything, mock.Anything, mock.Anything).
+		Return([]domain.Subscription{subscription}, nil)
+	repository.
+		On("FindPlanByID", context.Background(), plan.ID).
+		Return(plan, nil)
+	gateway.
+		On("ChargeRecurring", context.Background(), mock.MatchedBy(func(payload domain.PaymentPayload) bool {
+			return payload.SubscriptionPlanID == plan.ID && payload.PaySum == plan.Cost()
+		}), "method", subscription.RenewalKey()).
+		Return("renewal-key", nil)
+	ledgerRepository.
+		On("Create", context.Background(), mock.Anything).
+		Return(nil)
+	repository.
+		On("Extend", context.Background(), mock.Anything, "renewal-key").
+		Return(subscription, nil)
+	err := subscriptionService.RenewSubscriptions(context.Background())
+	t.Assert().Nil(err)
+}
+
+func (s *SubscriptionRenewSuite) TestRenewSubscriptions_ExpiredIsCanceled(t provider.T) {
+	t.Parallel()
+	t.Title("Renew subscriptions cancels expired subscription with declined charge")
+	repository := mocks.NewSubscriptionRepository(t)
+	gateway := mocks.NewPaymentGateway(t)
+	subscriptionService := service.NewSubscriptionService(repository, mocks.NewUserRepository(t),
+		gateway, mocks.NewLedgerRepository(t), s.ledgerConfig, s.logger)
+	plan := NewSubscriptionPlanBuilder().Build()
+	subscription := NewSubscriptionBuilder().WithPlan(plan).WithPaymentMethodID("method").
+		WithExpiresAt(time.Now().Add(-time.Hour)).Build()
+	repository.
+		On("ClaimRenewableSubscriptions", context.Background(), mock.Anything, mock.Anything, mock.Anything).
+		Return([]domain.Subscription{subscription}, nil)
+	repository.
+		On("FindPlanByID", context.Background(), plan.ID).
+		Return(plan, nil)
+	gateway.
+		On("ChargeRecurring", context.Background(), mock.Anything, "method", subscription.RenewalKey()).
+		Return("", errs.ErrPaymentNotCompleted)
+	gateway.
+		On("CancelRecurring", context.Background(), "method").
+		Return(nil)
+	repository.
+		On("Cancel", context.Background(), subscription.ID, mock.Anything).
+		Return(nil)
+	err := subscriptionService.RenewSubscriptions(context.Background())
+	t.Assert().Nil(err)
+}
+
+func (s *SubscriptionRenewSuite) TestRenewSubscriptions_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Renew subscriptions failure")
+	repository := mocks.NewSubscriptionRepository(t)
+	subscriptionService := service.NewSubscriptionService(repository, mocks.NewUserRepository(t),
+		mocks.NewPaymentGateway(t), mocks.NewLedgerRepository(t), s.ledgerConfig, s.logger)
+	repository.
+		On("ClaimRenewableSubscriptions", context.Background(), mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errs.ErrPersistenceFailed)
+	err := subscriptionService.RenewSubscriptions(context.Background())
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestSubscriptionRenewSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Renew subscriptions", new(SubscriptionRenewSuite))
+}
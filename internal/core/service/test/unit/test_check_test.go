@@ -0,0 +1,65 @@
+package unit
+
+import (
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"strings"
+	"testing"
+)
+
+type TestCheckSuite struct {
+	suite.Suite
+}
+
+func (s *TestCheckSuite) TestCheck_SingleAnswer(t provider.T) {
+	t.Parallel()
+	t.Title("Test with one correct option accepts only this option")
+	test := domain.Test{Options: []string{"1", "2", "3"}, Answer: "2"}
+	t.Assert().True(test.Check("2"))
+	t.Assert().False(test.Check("3"))
+	t.Assert().False(test.Check(""))
+}
+
+func (s *TestCheckSuite) TestCheck_SeveralAnswersAnyOrder(t provider.T) {
+	t.Parallel()
+	t.Title("Test with several correct options accepts them in any order")
+	test := domain.Test{
+		Options: []string{"Go", "Rust", "Python"},
+		Answer:  domain.JoinTestAnswers([]string{"Go", "Rust"}),
+	}
+	t.Assert().True(test.Check("Go; Rust"))
+	t.Assert().True(test.Check("Rust; Go"))
+	t.Assert().False(test.Check("Go"))
+	t.Assert().False(test.Check("Go; Rust; Python"))
+	t.Assert().False(test.Check("Go; Go"))
+	t.Assert().False(test.Check("Go;Rust"))
+}
+
+func (s *TestCheckSuite) TestCheck_SeparatorInOption(t provider.T) {
+	t.Parallel()
+	t.Title("Options containing the answer separator are matched as a whole")
+	test := domain.Test{
+		Options: []string{"a; b", "c", "d"},
+		Answer:  domain.JoinTestAnswers([]string{"a; b", "c"}),
+	}
+	t.Assert().Equal([]string{"a; b", "c"}, test.Answers())
+	t.Assert().True(test.Check("c; a; b"))
+	t.Assert().False(test.Check("a; b"))
+	t.Assert().False(test.Check("c; d"))
+}
+
+func (s *TestCheckSuite) TestCheck_OverlappingOptions(t provider.T) {
+	t.Parallel()
+	t.Title("Answer of many overlapping options is checked without exponential search")
+	test := domain.Test{
+		Options: []string{"a", "a; a", "a; a; a"},
+		Answer:  "a",
+	}
+	answer := strings.Repeat("a"+domain.TestAnswerSeparator, 300) + "b"
+	t.Assert().False(test.Check(answer))
+}
+
+func TestTestCheckSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Check test answer", new(TestCheckSuite))
+}
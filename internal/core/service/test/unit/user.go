@@ -147,6 +147,11 @@ func (b *UserBuilder) WithPassword(password string) *UserBuilder {
 	return b
 }
 
+func (b *UserBuilder) WithEmailVerified(verified bool) *UserBuilder {
+	b.user.EmailVerified = verified
+	return b
+}
+
 func (b *UserBuilder) Build() domain.User {
 	return b.user
 }
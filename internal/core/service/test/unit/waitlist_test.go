@@ -0,0 +1,180 @@
+package unit
+
+import (
+	"context"
+	"github.com/guregu/null"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/service"
+	"github.com/paw1a/eschool/internal/core/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+type WaitlistSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func (s *WaitlistSuite) BeforeEach(t provider.T) {
+	loggerBuilder := zap.NewDevelopmentConfig()
+	loggerBuilder.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+	s.logger, _ = loggerBuilder.Build()
+}
+
+// JoinCourseWaitlist Suite
+type WaitlistJoinCourseWaitlistSuite struct {
+	WaitlistSuite
+}
+
+func WaitlistJoinCourseWaitlistSuccessRepositoryMock(repository *mocks.WaitlistRepository,
+	courseRepository *mocks.CourseRepository, course domain.Course, userID domain.ID) {
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	courseRepository.
+		On("FindEnrollment", context.Background(), userID, course.ID).
+		Return(domain.Enrollment{}, errs.ErrNotExist)
+	repository.
+		On("Create", context.Background(), mock.MatchedBy(func(entry domain.WaitlistEntry) bool {
+			return entry.CourseID == course.ID && entry.UserID == userID
+		})).
+		Return(nil)
+	repository.
+		On("OfferSeats", context.Background(), course.ID, mock.Anything).
+		Return(nil, nil)
+}
+
+func (s *WaitlistJoinCourseWaitlistSuite) TestJoinCourseWaitlist_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Join course waitlist success")
+	repository := mocks.NewWaitlistRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	mailer := mocks.NewMailer(t)
+	waitlistService := service.NewWaitlistService(repository, courseRepository, userRepository, mailer, s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithCapacity(10).Build()
+	userID := domain.NewID()
+	WaitlistJoinCourseWaitlistSuccessRepositoryMock(repository, courseRepository, course, userID)
+	entry, err := waitlistService.JoinCourseWaitlist(context.Background(), userID, course.ID)
+	t.Assert().Nil(err)
+	t.Assert().False(entry.OfferedUntil.Valid)
+}
+
+func WaitlistJoinCourseWaitlistFailureRepositoryMock(courseRepository *mocks.CourseRepository,
+	course domain.Course) {
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+}
+
+func (s *WaitlistJoinCourseWaitlistSuite) TestJoinCourseWaitlist_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Join waitlist of course without capacity")
+	repository := mocks.NewWaitlistRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	mailer := mocks.NewMailer(t)
+	waitlistService := service.NewWaitlistService(repository, courseRepository, userRepository, mailer, s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).Build()
+	WaitlistJoinCourseWaitlistFailureRepositoryMock(courseRepository, course)
+	_, err := waitlistService.JoinCourseWaitlist(context.Background(), domain.NewID(), course.ID)
+	t.Assert().ErrorIs(err, errs.ErrCourseHasNoCapacity)
+}
+
+func (s *WaitlistJoinCourseWaitlistSuite) TestJoinCourseWaitlist_Subscriber(t provider.T) {
+	t.Parallel()
+	t.Title("Join course waitlist by subscriber to buy a seat")
+	repository := mocks.NewWaitlistRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	waitlistService := service.NewWaitlistService(repository, courseRepository, mocks.NewUserRepository(t),
+		mocks.NewMailer(t), s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithCapacity(10).Build()
+	userID := domain.NewID()
+	// access by subscription isn't enrollment, the subscriber owns no course
+	WaitlistJoinCourseWaitlistSuccessRepositoryMock(repository, courseRepository, course, userID)
+	entry, err := waitlistService.JoinCourseWaitlist(context.Background(), userID, course.ID)
+	t.Assert().Nil(err)
+	t.Assert().Equal(userID, entry.UserID)
+}
+
+func TestWaitlistJoinCourseWaitlistSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Join course waitlist", new(WaitlistJoinCourseWaitlistSuite))
+}
+
+// OfferFreeSeats Suite
+type WaitlistOfferFreeSeatsSuite struct {
+	WaitlistSuite
+}
+
+func WaitlistOfferFreeSeatsSuccessRepositoryMock(repository *mocks.WaitlistRepository,
+	courseRepository *mocks.CourseRepository, userRepository *mocks.UserRepository,
+	mailer *mocks.Mailer, course domain.Course, user domain.User) {
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	repository.
+		On("OfferSeats", context.Background(), course.ID, mock.Anything).
+		Return([]domain.WaitlistEntry{{
+			CourseID:     course.ID,
+			UserID:       user.ID,
+			JoinedAt:     time.Now(),
+			OfferedUntil: null.TimeFrom(time.Now().Add(time.Hour)),
+		}}, nil)
+	userRepository.
+		On("FindByID", context.Background(), user.ID).
+		Return(user, nil)
+	mailer.
+		On("Send", context.Background(), mock.MatchedBy(func(mail domain.Mail) bool {
+			return mail.To == user.Email
+		})).
+		Return(nil)
+}
+
+func (s *WaitlistOfferFreeSeatsSuite) TestOfferFreeSeats_Success(t provider.T) {
+	t.Parallel()
+	t.Title("Offer free course seats success")
+	repository := mocks.NewWaitlistRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	mailer := mocks.NewMailer(t)
+	waitlistService := service.NewWaitlistService(repository, courseRepository, userRepository, mailer, s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithCapacity(1).Build()
+	user := NewUserBuilder().Build()
+	WaitlistOfferFreeSeatsSuccessRepositoryMock(repository, courseRepository, userRepository,
+		mailer, course, user)
+	err := waitlistService.OfferFreeSeats(context.Background(), course.ID)
+	t.Assert().Nil(err)
+}
+
+func WaitlistOfferFreeSeatsFailureRepositoryMock(repository *mocks.WaitlistRepository,
+	courseRepository *mocks.CourseRepository, course domain.Course) {
+	courseRepository.
+		On("FindByID", context.Background(), course.ID).
+		Return(course, nil)
+	repository.
+		On("OfferSeats", context.Background(), course.ID, mock.Anything).
+		Return(nil, errs.ErrPersistenceFailed)
+}
+
+func (s *WaitlistOfferFreeSeatsSuite) TestOfferFreeSeats_Failure(t provider.T) {
+	t.Parallel()
+	t.Title("Offer free course seats failure")
+	repository := mocks.NewWaitlistRepository(t)
+	courseRepository := mocks.NewCourseRepository(t)
+	userRepository := mocks.NewUserRepository(t)
+	mailer := mocks.NewMailer(t)
+	waitlistService := service.NewWaitlistService(repository, courseRepository, userRepository, mailer, s.logger)
+	course := NewCourseBuilder().WithID(domain.NewID()).WithCapacity(1).Build()
+	WaitlistOfferFreeSeatsFailureRepositoryMock(repository, courseRepository, course)
+	err := waitlistService.OfferFreeSeats(context.Background(), course.ID)
+	t.Assert().ErrorIs(err, errs.ErrPersistenceFailed)
+}
+
+func TestWaitlistOfferFreeSeatsSuite(t *testing.T) {
+	suite.RunNamedSuite(t, "Offer free course seats", new(WaitlistOfferFreeSeatsSuite))
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/paw1a/eschool/internal/core/domain"
+	"github.com/paw1a/eschool/internal/core/errs"
+	"github.com/paw1a/eschool/internal/core/port"
+	"go.uber.org/zap"
+	"time"
+)
+
+// seat offered from the waitlist is held for the user during this time
+const waitlistOfferTime = 24 * time.Hour
+
+type WaitlistService struct {
+	repo       port.IWaitlistRepository
+	courseRepo port.ICourseRepository
+	userRepo   port.IUserRepository
+	mailer     port.IMailer
+	logger     *zap.Logger
+}
+
+func NewWaitlistService(repo port.IWaitlistRepository, courseRepo port.ICourseRepository,
+	userRepo port.IUserRepository, mailer port.IMailer, logger *zap.Logger) *WaitlistService {
+	return &WaitlistService{
+		repo:       repo,
+		courseRepo: courseRepo,
+		userRepo:   userRepo,
+		mailer:     mailer,
+		logger:     logger,
+	}
+}
+
+func (w *WaitlistService) FindCourseWaitlist(ctx context.Context,
+	courseID domain.ID) ([]domain.WaitlistEntry, error) {
+	entries, err := w.repo.FindCourseWaitlist(ctx, courseID)
+	if err != nil {
+		w.logger.Error("failed to find course waitlist", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (w *WaitlistService) JoinCourseWaitlist(ctx context.Context,
+	userID, courseID domain.ID) (domain.WaitlistEntry, error) {
+	course, err := w.courseRepo.FindByID(ctx, courseID)
+	if err != nil {
+		w.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return domain.WaitlistEntry{}, err
+	}
+
+	if !course.Capacity.Valid {
+		return domain.WaitlistEntry{}, errs.ErrCourseHasNoCapacity
+	}
+
+	// subscribers don't take seats, they may wait for a seat to buy the course
+	isStudent, err := isEnrolledStudent(ctx, w.courseRepo, userID, courseID)
+	if err != nil {
+		w.logger.Error("failed to check if user is a course student", zap.Error(err),
+			zap.String("courseID", courseID.String()), zap.String("userID", userID.String()))
+		return domain.WaitlistEntry{}, err
+	}
+
+	if isStudent {
+		return domain.WaitlistEntry{}, errs.ErrUserIsAlreadyCourseStudent
+	}
+
+	entry := domain.WaitlistEntry{
+		CourseID: courseID,
+		UserID:   userID,
+		JoinedAt: time.Now().UTC(),
+	}
+	err = w.repo.Create(ctx, entry)
+	if err != nil {
+		w.logger.Error("failed to join course waitlist", zap.Error(err),
+			zap.String("courseID", courseID.String()), zap.String("userID", userID.String()))
+		if errors.Is(err, errs.ErrDuplicate) {
+			return domain.WaitlistEntry{}, errs.ErrWaitlistAlreadyJoined
+		}
+		return domain.WaitlistEntry{}, err
+	}
+
+	// seat may be already free, then it is offered to the user right away
+	offered, err := w.offerFreeSeats(ctx, course)
+	if err != nil {
+		return domain.WaitlistEntry{}, err
+	}
+	for _, offer := range offered {
+		if offer.UserID == userID {
+			entry = offer
+		}
+	}
+
+	w.logger.Info("user successfully joined course waitlist",
+		zap.String("courseID", courseID.String()), zap.String("userID", userID.String()))
+	return entry, nil
+}
+
+func (w *WaitlistService) LeaveCourseWaitlist(ctx context.Context, userID, courseID domain.ID) error {
+	err := w.repo.Delete(ctx, courseID, userID)
+	if err != nil {
+		w.logger.Error("failed to leave course waitlist", zap.Error(err),
+			zap.String("courseID", courseID.String()), zap.String("userID", userID.String()))
+		return err
+	}
+
+	w.logger.Info("user successfully left course waitlist",
+		zap.String("courseID", courseID.String()), zap.String("userID", userID.String()))
+	return w.OfferFreeSeats(ctx, courseID)
+}
+
+func (w *WaitlistService) OfferFreeSeats(ctx context.Context, courseID domain.ID) error {
+	course, err := w.courseRepo.FindByID(ctx, courseID)
+	if err != nil {
+		w.logger.Error("failed to find course by id", zap.Error(err),
+			zap.String("courseID", courseID.String()))
+		return err
+	}
+
+	_, err = w.offerFreeSeats(ctx, course)
+	return err
+}
+
+func (w *WaitlistService) offerFreeSeats(ctx context.Context,
+	course domain.Course) ([]domain.WaitlistEntry, error) {
+	if !course.Capacity.Valid {
+		return nil, nil
+	}
+
+	offered, err := w.repo.OfferSeats(ctx, course.ID, time.Now().UTC().Add(waitlistOfferTime))
+	if err != nil {
+		w.logger.Error("failed to offer free course seats", zap.Error(err),
+			zap.String("courseID", course.ID.String()))
+		return nil, err
+	}
+
+	// seats stay held even if notification is not delivered,
+	// user still sees the offer in the waitlist
+	for _, entry := range offered {
+		err = w.notifySeatOffer(ctx, course, entry)
+		if err != nil {
+			w.logger.Error("failed to notify user about course seat", zap.Error(err),
+				zap.String("courseID", course.ID.String()), zap.String("userID", entry.UserID.String()))
+			continue
+		}
+
+		w.logger.Info("course seat is offered to waitlisted user",
+			zap.String("courseID", course.ID.String()), zap.String("userID", entry.UserID.String()))
+	}
+	return offered, nil
+}
+
+func (w *WaitlistService) notifySeatOffer(ctx context.Context, course domain.Course,
+	entry domain.WaitlistEntry) error {
+	user, err := w.userRepo.FindByID(ctx, entry.UserID)
+	if err != nil {
+		return err
+	}
+
+	return w.mailer.Send(ctx, domain.Mail{
+		To:      user.Email,
+		Subject: "A seat is available",
+		Body: fmt.Sprintf("Hello, %s!\n\nA seat in course \"%s\" is available for you.\n"+
+			"It is held until %s, enroll before then to keep it.\n",
+			user.Name, course.Name, entry.OfferedUntil.Time.Format(time.RFC1123)),
+	})
+}
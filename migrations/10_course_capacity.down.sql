@@ -0,0 +1,3 @@
+drop table if exists public.course_waitlist;
+drop table if exists public.course_seat_hold;
+alter table public.course drop column if exists capacity;
@@ -0,0 +1,3 @@
+drop table if exists public.bundle_course;
+drop table if exists public.bundle;
+drop type if exists bundle_status;
@@ -0,0 +1,3 @@
+drop table if exists public.ledger_entry;
+drop table if exists public.payout;
+drop type if exists ledger_entry_kind;
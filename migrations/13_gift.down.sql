@@ -0,0 +1,4 @@
+delete from public.ledger_entry where user_id is null;
+alter table public.ledger_entry alter column user_id set not null;
+
+drop table if exists public.gift;
@@ -0,0 +1,16 @@
+create table public.gift (
+    id uuid primary key,
+    code text not null unique,
+    course_id uuid not null,
+    recipient_email text not null,
+    payment_key text not null unique,
+    pay_sum bigint not null,
+    created_at timestamp not null,
+    redeemed_by uuid,
+    redeemed_at timestamp,
+    foreign key (course_id) references public.course(id) on delete cascade,
+    foreign key (redeemed_by) references public.user(id) on delete set null
+);
+
+-- gifts are bought without a buyer account bound to the payment
+alter table public.ledger_entry alter column user_id drop not null;
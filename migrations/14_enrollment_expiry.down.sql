@@ -0,0 +1,8 @@
+drop index if exists public.course_student_expires_at_idx;
+
+alter table public.course_student drop column if exists reminded_at;
+alter table public.course_student drop column if exists renewal_key;
+alter table public.course_student drop column if exists expires_at;
+alter table public.course_student drop column if exists enrolled_at;
+
+alter table public.course drop column if exists access_days;
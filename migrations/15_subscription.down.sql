@@ -0,0 +1,4 @@
+drop index if exists public.subscription_expires_at_idx;
+
+drop table if exists public.subscription;
+drop table if exists public.subscription_plan;
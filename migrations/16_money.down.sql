@@ -0,0 +1,14 @@
+alter table public.refund drop column if exists currency;
+alter table public.course_payment drop column if exists currency;
+alter table public.course drop column if exists currency;
+
+update public.subscription_plan set price = price / 100;
+update public.gift set pay_sum = pay_sum / 100;
+update public.payout set amount = amount / 100;
+update public.ledger_entry set gross = gross / 100, commission = commission / 100;
+update public.refund set amount = amount / 100;
+update public.course_payment set pay_sum = pay_sum / 100;
+update public.promo_redemption set pay_sum = pay_sum / 100;
+update public.promo_code set discount = discount / 100 where discount_type = 'fixed';
+update public.bundle set price = price / 100;
+update public.course set price = price / 100;
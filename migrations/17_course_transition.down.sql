@@ -0,0 +1,9 @@
+drop table if exists public.course_transition;
+
+update public.course set status = 'draft' where status in ('unpublished', 'archived');
+
+alter type course_status rename to course_status_old;
+create type course_status as enum ('draft', 'ready', 'published');
+alter table public.course alter column status type course_status
+    using status::text::course_status;
+drop type course_status_old;
@@ -0,0 +1,12 @@
+alter table public.course_student drop column if exists version;
+
+alter table public.test drop column if exists key;
+
+delete from public.lesson l using public.course c
+    where c.id = l.course_id and l.version <> c.published_version and c.published_version > 0;
+drop index if exists lesson_course_version_idx;
+alter table public.lesson drop column if exists version;
+alter table public.lesson drop column if exists key;
+
+alter table public.course drop column if exists published_version;
+alter table public.course drop column if exists version;
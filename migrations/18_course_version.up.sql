@@ -0,0 +1,15 @@
+alter table public.course add column version int not null default 1;
+alter table public.course add column published_version int not null default 0;
+update public.course set published_version = 1 where status in ('published', 'unpublished', 'archived');
+
+alter table public.lesson add column key uuid;
+alter table public.lesson add column version int not null default 1;
+update public.lesson set key = id;
+alter table public.lesson alter column key set not null;
+create index lesson_course_version_idx on public.lesson (course_id, version);
+
+alter table public.test add column key uuid;
+update public.test set key = id;
+alter table public.test alter column key set not null;
+
+alter table public.course_student add column version int not null default 1;
@@ -0,0 +1,7 @@
+drop table if exists public.job;
+drop type if exists job_status;
+
+alter table public.lesson drop column if exists release_days;
+alter table public.lesson drop column if exists release_at;
+
+alter table public.course drop column if exists publish_at;
@@ -0,0 +1,4 @@
+drop table if exists public.path_certificate;
+drop table if exists public.learning_path_course;
+drop table if exists public.learning_path;
+drop table if exists public.course_prerequisite;
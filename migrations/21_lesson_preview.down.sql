@@ -0,0 +1 @@
+alter table public.lesson drop column if exists is_preview;
@@ -0,0 +1,4 @@
+drop table if exists public.course_tag;
+drop table if exists public.tag;
+drop table if exists public.course_category;
+drop table if exists public.category;
@@ -0,0 +1,34 @@
+create table public.category (
+    id uuid primary key,
+    parent_id uuid,
+    name varchar(255) not null,
+    created_at timestamp not null,
+    unique nulls not distinct (parent_id, name),
+    foreign key (parent_id) references public.category(id)
+);
+
+create table public.course_category (
+    course_id uuid not null,
+    category_id uuid not null,
+    primary key (course_id, category_id),
+    foreign key (course_id) references public.course(id) on delete cascade,
+    foreign key (category_id) references public.category(id) on delete cascade
+);
+
+create table public.tag (
+    id uuid primary key,
+    school_id uuid not null,
+    name varchar(32) not null,
+    unique (school_id, name),
+    foreign key (school_id) references public.school(id) on delete cascade
+);
+
+create table public.course_tag (
+    course_id uuid not null,
+    tag_id uuid not null,
+    primary key (course_id, tag_id),
+    foreign key (course_id) references public.course(id) on delete cascade,
+    foreign key (tag_id) references public.tag(id) on delete cascade
+);
+
+create index tag_name_idx on public.tag (name);
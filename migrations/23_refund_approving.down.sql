@@ -0,0 +1,5 @@
+update public.refund set status = 'requested' where status = 'approving';
+
+drop index if exists public.refund_requested_idx;
+create unique index refund_requested_idx on public.refund (user_id, course_id)
+    where status = 'requested';
@@ -0,0 +1,7 @@
+alter type refund_status add value if not exists 'approving';
+
+-- new enum value can't be used in the migration transaction,
+-- so the predicate lists resolved statuses instead
+drop index if exists public.refund_requested_idx;
+create unique index refund_requested_idx on public.refund (user_id, course_id)
+    where status <> 'approved' and status <> 'rejected';
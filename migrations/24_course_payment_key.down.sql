@@ -0,0 +1,8 @@
+-- only the latest payment of the course is kept
+delete from public.course_payment p using public.course_payment newer
+    where newer.user_id = p.user_id and newer.course_id = p.course_id
+    and (newer.paid_at, newer.key) > (p.paid_at, p.key);
+
+drop index if exists public.course_payment_user_course_idx;
+alter table public.course_payment drop constraint if exists course_payment_pkey;
+alter table public.course_payment add primary key (user_id, course_id);
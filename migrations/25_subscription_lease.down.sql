@@ -0,0 +1 @@
+alter table public.subscription drop column if exists renewal_locked_until;
@@ -0,0 +1,3 @@
+-- renewal run claims the subscription until the lease ends,
+-- so concurrent runs don't charge the saved payment method twice
+alter table public.subscription add column renewal_locked_until timestamp;
@@ -0,0 +1,5 @@
+alter table public.ledger_entry drop column if exists currency;
+alter table public.payout drop column if exists currency;
+alter table public.gift drop column if exists currency;
+alter table public.promo_code drop column if exists currency;
+alter table public.subscription_plan drop column if exists currency;
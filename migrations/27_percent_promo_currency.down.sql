@@ -0,0 +1,4 @@
+update public.promo_code set currency = 'RUB' where currency is null;
+
+alter table public.promo_code alter column currency set default 'RUB';
+alter table public.promo_code alter column currency set not null;
@@ -0,0 +1,5 @@
+drop table if exists public.email_token;
+
+drop type if exists email_token_purpose;
+
+alter table public.user drop column if exists email_verified;
@@ -0,0 +1,2 @@
+drop table if exists public.mfa_recovery_code;
+drop table if exists public.user_mfa;
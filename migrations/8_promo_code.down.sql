@@ -0,0 +1,3 @@
+drop table if exists public.promo_redemption;
+drop table if exists public.promo_code;
+drop type if exists promo_discount_type;
@@ -0,0 +1,3 @@
+drop table if exists public.refund;
+drop type if exists refund_status;
+drop table if exists public.course_payment;
@@ -0,0 +1,31 @@
+create table public.course_payment (
+    user_id uuid not null,
+    course_id uuid not null,
+    key text not null,
+    pay_sum bigint not null,
+    paid_at timestamp not null,
+    primary key (user_id, course_id),
+    foreign key (user_id) references public.user(id) on delete cascade,
+    foreign key (course_id) references public.course(id) on delete cascade
+);
+
+create type refund_status as enum ('requested', 'approved', 'rejected');
+
+create table public.refund (
+    id uuid primary key,
+    user_id uuid not null,
+    course_id uuid not null,
+    payment_key text not null,
+    amount bigint not null,
+    reason text not null,
+    status refund_status not null,
+    requested_at timestamp not null,
+    resolved_by uuid,
+    resolved_at timestamp,
+    foreign key (user_id) references public.user(id) on delete cascade,
+    foreign key (course_id) references public.course(id) on delete cascade,
+    foreign key (resolved_by) references public.user(id) on delete set null
+);
+
+create unique index refund_requested_idx on public.refund (user_id, course_id)
+    where status = 'requested';
@@ -0,0 +1,466 @@
+package paysim
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"html/template"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	maxBodySize    = 1 << 20
+	webhookTimeout = 10 * time.Second
+)
+
+const (
+	statusPending           = "pending"
+	statusWaitingForCapture = "waiting_for_capture"
+	statusSucceeded         = "succeeded"
+	statusCanceled          = "canceled"
+)
+
+type Config struct {
+	ShopID     string
+	SecretKey  string
+	PublicUrl  string // base url of the simulator used in confirmation urls
+	WebhookUrl string // merchant url notified about payment status changes
+}
+
+type amount struct {
+	Value    int64  `json:"value"`
+	Currency string `json:"currency"`
+}
+
+type paymentMethod struct {
+	ID    string `json:"id"`
+	Saved bool   `json:"saved"`
+}
+
+type payment struct {
+	ID                string            `json:"id"`
+	Status            string            `json:"status"`
+	Amount            amount            `json:"amount"`
+	RefundedAmount    amount            `json:"refunded_amount"`
+	Description       string            `json:"description,omitempty"`
+	Metadata          map[string]string `json:"metadata"`
+	PaymentMethod     *paymentMethod    `json:"payment_method,omitempty"`
+	ConfirmationUrl   string            `json:"confirmation_url,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+	capture           bool
+	returnUrl         string
+	savePaymentMethod bool
+}
+
+type refund struct {
+	ID        string    `json:"id"`
+	PaymentID string    `json:"payment_id"`
+	Status    string    `json:"status"`
+	Amount    amount    `json:"amount"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// createPaymentRequest with payment method id charges the saved method
+// without customer confirmation
+type createPaymentRequest struct {
+	Amount            amount            `json:"amount"`
+	Capture           bool              `json:"capture"`
+	Description       string            `json:"description"`
+	ReturnUrl         string            `json:"return_url"`
+	Metadata          map[string]string `json:"metadata"`
+	SavePaymentMethod bool              `json:"save_payment_method"`
+	PaymentMethodID   string            `json:"payment_method_id"`
+}
+
+type createRefundRequest struct {
+	PaymentID string `json:"payment_id"`
+	Amount    amount `json:"amount"`
+	Reason    string `json:"reason"`
+}
+
+type notification struct {
+	Event  string  `json:"event"`
+	Object payment `json:"object"`
+}
+
+type errorResponse struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+type idempotentResponse struct {
+	status int
+	body   []byte
+}
+
+// Server simulates a card acquirer: merchant creates a payment, customer
+// confirms it on the checkout page, acquirer notifies merchant webhook and
+// merchant captures or refunds the payment. State is kept in memory.
+type Server struct {
+	config     Config
+	mux        *http.ServeMux
+	httpClient *http.Client
+	mu         sync.Mutex
+	payments   map[string]*payment
+	refunds    map[string]*refund
+	methods    map[string]bool
+	responses  map[string]idempotentResponse
+}
+
+func NewServer(config Config) *Server {
+	s := &Server{
+		config:     config,
+		mux:        http.NewServeMux(),
+		httpClient: &http.Client{Timeout: webhookTimeout},
+		payments:   make(map[string]*payment),
+		refunds:    make(map[string]*refund),
+		methods:    make(map[string]bool),
+		responses:  make(map[string]idempotentResponse),
+	}
+
+	s.mux.HandleFunc("POST /payments", s.authorized(s.idempotent(s.createPayment)))
+	s.mux.HandleFunc("GET /payments/{id}", s.authorized(s.getPayment))
+	s.mux.HandleFunc("POST /payments/{id}/capture", s.authorized(s.idempotent(s.capturePayment)))
+	s.mux.HandleFunc("POST /refunds", s.authorized(s.idempotent(s.createRefund)))
+	s.mux.HandleFunc("DELETE /payment_methods/{id}", s.authorized(s.deletePaymentMethod))
+	s.mux.HandleFunc("GET /checkout/{id}", s.checkoutPage)
+	s.mux.HandleFunc("POST /checkout/{id}", s.checkout)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// SetPublicUrl is used when listen address is known only after start,
+// e.g. with httptest server
+func (s *Server) SetPublicUrl(publicUrl string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config.PublicUrl = publicUrl
+}
+
+func (s *Server) createPayment(w http.ResponseWriter, r *http.Request) {
+	var request createPaymentRequest
+	if !decodeJson(w, r, &request) {
+		return
+	}
+	if request.Amount.Value <= 0 || request.Amount.Currency == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "amount must be positive and have currency")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := uuid.New().String()
+	p := &payment{
+		ID:                id,
+		Status:            statusPending,
+		Amount:            request.Amount,
+		RefundedAmount:    amount{Currency: request.Amount.Currency},
+		Description:       request.Description,
+		Metadata:          request.Metadata,
+		CreatedAt:         time.Now().UTC(),
+		capture:           request.Capture,
+		returnUrl:         request.ReturnUrl,
+		savePaymentMethod: request.SavePaymentMethod,
+	}
+
+	if request.PaymentMethodID != "" {
+		if !s.methods[request.PaymentMethodID] {
+			writeError(w, http.StatusBadRequest, "invalid_request", "payment method is not saved")
+			return
+		}
+		p.PaymentMethod = &paymentMethod{ID: request.PaymentMethodID, Saved: true}
+		p.Status = statusWaitingForCapture
+		if p.capture {
+			p.Status = statusSucceeded
+		}
+	} else {
+		p.ConfirmationUrl = strings.TrimSuffix(s.config.PublicUrl, "/") + "/checkout/" + id
+	}
+
+	s.payments[id] = p
+	writeJson(w, http.StatusOK, p)
+}
+
+func (s *Server) deletePaymentMethod(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if !s.methods[id] {
+		writeError(w, http.StatusNotFound, "not_found", "payment method does not exist")
+		return
+	}
+	delete(s.methods, id)
+	writeJson(w, http.StatusOK, paymentMethod{ID: id, Saved: false})
+}
+
+func (s *Server) getPayment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.payments[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "payment does not exist")
+		return
+	}
+	writeJson(w, http.StatusOK, p)
+}
+
+func (s *Server) capturePayment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.payments[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "payment does not exist")
+		return
+	}
+
+	switch p.Status {
+	case statusWaitingForCapture:
+		p.Status = statusSucceeded
+	case statusSucceeded:
+	default:
+		writeError(w, http.StatusBadRequest, "invalid_status",
+			fmt.Sprintf("payment in status %s can't be captured", p.Status))
+		return
+	}
+	writeJson(w, http.StatusOK, p)
+}
+
+func (s *Server) createRefund(w http.ResponseWriter, r *http.Request) {
+	var request createRefundRequest
+	if !decodeJson(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.payments[request.PaymentID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "payment does not exist")
+		return
+	}
+	if p.Status != statusSucceeded {
+		writeError(w, http.StatusBadRequest, "invalid_status", "only succeeded payments can be refunded")
+		return
+	}
+	if request.Amount.Value <= 0 || request.Amount.Currency != p.Amount.Currency ||
+		p.RefundedAmount.Value+request.Amount.Value > p.Amount.Value {
+		writeError(w, http.StatusBadRequest, "invalid_request", "refund amount exceeds payment amount")
+		return
+	}
+
+	p.RefundedAmount.Value += request.Amount.Value
+	ref := &refund{
+		ID:        uuid.New().String(),
+		PaymentID: p.ID,
+		Status:    statusSucceeded,
+		Amount:    request.Amount,
+		Reason:    request.Reason,
+		CreatedAt: time.Now().UTC(),
+	}
+	s.refunds[ref.ID] = ref
+	writeJson(w, http.StatusOK, ref)
+}
+
+var checkoutTemplate = template.Must(template.New("checkout").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Payment simulator</title></head>
+<body>
+<h1>Payment {{.ID}}</h1>
+<p>{{.Description}}</p>
+<p>Amount: {{.Amount.Value}} {{.Amount.Currency}}</p>
+<p>Status: {{.Status}}</p>
+{{if eq .Status "pending"}}
+<form method="post">
+<button type="submit" name="action" value="pay">Pay</button>
+<button type="submit" name="action" value="decline">Decline</button>
+</form>
+{{end}}
+</body>
+</html>`))
+
+func (s *Server) checkoutPage(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	p, ok := s.payments[r.PathValue("id")]
+	var view payment
+	if ok {
+		view = *p
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = checkoutTemplate.Execute(w, view)
+}
+
+// checkout confirms or declines payment on behalf of the customer,
+// notifies merchant and redirects customer back to the merchant
+func (s *Server) checkout(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	p, ok := s.payments[r.PathValue("id")]
+	if !ok {
+		s.mu.Unlock()
+		http.NotFound(w, r)
+		return
+	}
+	if p.Status != statusPending {
+		s.mu.Unlock()
+		http.Error(w, "payment is already processed", http.StatusConflict)
+		return
+	}
+
+	var event string
+	switch r.PostFormValue("action") {
+	case "pay":
+		if p.savePaymentMethod {
+			methodID := uuid.New().String()
+			s.methods[methodID] = true
+			p.PaymentMethod = &paymentMethod{ID: methodID, Saved: true}
+		}
+		if p.capture {
+			p.Status = statusSucceeded
+			event = "payment.succeeded"
+		} else {
+			p.Status = statusWaitingForCapture
+			event = "payment.waiting_for_capture"
+		}
+	case "decline":
+		p.Status = statusCanceled
+		event = "payment.canceled"
+	default:
+		s.mu.Unlock()
+		http.Error(w, "action must be pay or decline", http.StatusBadRequest)
+		return
+	}
+	view := *p
+	webhookUrl := s.config.WebhookUrl
+	s.mu.Unlock()
+
+	if webhookUrl != "" {
+		if err := s.notify(webhookUrl, notification{Event: event, Object: view}); err != nil {
+			log.Printf("failed to notify merchant about payment %s: %v", view.ID, err)
+		}
+	}
+
+	if view.returnUrl == "" {
+		_, _ = fmt.Fprintf(w, "payment %s is %s\n", view.ID, view.Status)
+		return
+	}
+	http.Redirect(w, r, view.returnUrl, http.StatusSeeOther)
+}
+
+func (s *Server) notify(webhookUrl string, n notification) error {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.httpClient.Post(webhookUrl, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+	return nil
+}
+
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		shopID, secretKey, ok := r.BasicAuth()
+		if !ok || subtle.ConstantTimeCompare([]byte(shopID), []byte(s.config.ShopID)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(secretKey), []byte(s.config.SecretKey)) != 1 {
+			writeError(w, http.StatusUnauthorized, "invalid_credentials", "shop id or secret key is invalid")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// idempotent replays saved response for repeated requests with the same
+// Idempotence-Key header
+func (s *Server) idempotent(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotence-Key")
+		if key == "" {
+			writeError(w, http.StatusBadRequest, "invalid_request", "Idempotence-Key header is required")
+			return
+		}
+		key = r.URL.Path + ":" + key
+
+		s.mu.Lock()
+		saved, ok := s.responses[key]
+		s.mu.Unlock()
+		if ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(saved.status)
+			_, _ = w.Write(saved.body)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(recorder, r)
+
+		if recorder.status < http.StatusInternalServerError {
+			s.mu.Lock()
+			s.responses[key] = idempotentResponse{status: recorder.status, body: recorder.body.Bytes()}
+			s.mu.Unlock()
+		}
+	}
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func decodeJson(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJson(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, description string) {
+	writeJson(w, status, errorResponse{Code: code, Description: description})
+}